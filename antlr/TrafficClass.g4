@@ -4,6 +4,7 @@ WHITESPACE: [ \r\n\t]+ -> skip;
 DIGITS: '0' | [1-9] [0-9]*;
 HEX_DIGITS: ('a' .. 'f' | 'A' .. 'F' | [0-9])+;
 NET: DIGITS '.' DIGITS '.' DIGITS '.' DIGITS '/' DIGITS;
+NET6: [0-9a-fA-F]* ':' [0-9a-fA-F:.]* '/' DIGITS;
 
 ANY: 'ANY' | 'any';
 ALL: 'ALL' | 'all';
@@ -16,14 +17,25 @@ TOS: 'TOS' | 'tos';
 PROTOCOL: 'PROTOCOL' | 'protocol';
 SRCPORT: 'SRCPORT' | 'srcport';
 DSTPORT: 'DSTPORT' | 'dstport';
+ICMPTYPE: 'ICMPTYPE' | 'icmptype';
+TC: 'TC' | 'tc';
+FLOWLABEL: 'FLOWLABEL' | 'flowlabel';
+NEXTHEADER: 'NEXTHEADER' | 'nextheader';
+ICMP6TYPE: 'ICMP6TYPE' | 'icmp6type';
 
-STRING: [a-zA-Z]+;
+STRING: [a-zA-Z] [a-zA-Z0-9]*;
 
-matchSrc: SRC '=' NET;
-matchDst: DST '=' NET;
+matchSrc: SRC '=' (NET | NET6);
+matchDst: DST '=' (NET | NET6);
 matchDSCP: DSCP '=0x' (HEX_DIGITS | DIGITS);
 matchTOS: TOS '=0x' (HEX_DIGITS | DIGITS);
 matchProtocol: PROTOCOL '=' STRING;
+matchICMPType: ICMPTYPE '=' DIGITS;
+
+matchTC: TC '=0x' (HEX_DIGITS | DIGITS);
+matchFlowLabel: FLOWLABEL '=0x' (HEX_DIGITS | DIGITS);
+matchNextHeader: NEXTHEADER '=' STRING;
+matchICMP6Type: ICMP6TYPE '=' DIGITS;
 
 matchSrcPort: SRCPORT '=' DIGITS;
 matchSrcPortRange: SRCPORT '=' DIGITS '-' DIGITS;
@@ -36,8 +48,9 @@ condAll: ALL '(' cond (',' cond)* ')';
 condNot: NOT '(' cond ')';
 condBool: BOOL '=' ('true' | 'false');
 
-condIPv4: matchSrc | matchDst | matchDSCP | matchTOS | matchProtocol;
+condIPv4: matchSrc | matchDst | matchDSCP | matchTOS | matchProtocol | matchICMPType;
+condIPv6: matchTC | matchFlowLabel | matchNextHeader | matchICMP6Type;
 condPort: matchSrcPort | matchSrcPortRange | matchDstPort | matchDstPortRange;
-cond: condAll | condAny | condNot | condIPv4 | condPort | condCls | condBool;
+cond: condAll | condAny | condNot | condIPv4 | condIPv6 | condPort | condCls | condBool;
 
 trafficClass: cond EOF;
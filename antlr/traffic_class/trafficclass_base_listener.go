@@ -50,6 +50,36 @@ func (s *BaseTrafficClassListener) EnterMatchProtocol(ctx *MatchProtocolContext)
 // ExitMatchProtocol is called when production matchProtocol is exited.
 func (s *BaseTrafficClassListener) ExitMatchProtocol(ctx *MatchProtocolContext) {}
 
+// EnterMatchICMPType is called when production matchICMPType is entered.
+func (s *BaseTrafficClassListener) EnterMatchICMPType(ctx *MatchICMPTypeContext) {}
+
+// ExitMatchICMPType is called when production matchICMPType is exited.
+func (s *BaseTrafficClassListener) ExitMatchICMPType(ctx *MatchICMPTypeContext) {}
+
+// EnterMatchTC is called when production matchTC is entered.
+func (s *BaseTrafficClassListener) EnterMatchTC(ctx *MatchTCContext) {}
+
+// ExitMatchTC is called when production matchTC is exited.
+func (s *BaseTrafficClassListener) ExitMatchTC(ctx *MatchTCContext) {}
+
+// EnterMatchFlowLabel is called when production matchFlowLabel is entered.
+func (s *BaseTrafficClassListener) EnterMatchFlowLabel(ctx *MatchFlowLabelContext) {}
+
+// ExitMatchFlowLabel is called when production matchFlowLabel is exited.
+func (s *BaseTrafficClassListener) ExitMatchFlowLabel(ctx *MatchFlowLabelContext) {}
+
+// EnterMatchNextHeader is called when production matchNextHeader is entered.
+func (s *BaseTrafficClassListener) EnterMatchNextHeader(ctx *MatchNextHeaderContext) {}
+
+// ExitMatchNextHeader is called when production matchNextHeader is exited.
+func (s *BaseTrafficClassListener) ExitMatchNextHeader(ctx *MatchNextHeaderContext) {}
+
+// EnterMatchICMP6Type is called when production matchICMP6Type is entered.
+func (s *BaseTrafficClassListener) EnterMatchICMP6Type(ctx *MatchICMP6TypeContext) {}
+
+// ExitMatchICMP6Type is called when production matchICMP6Type is exited.
+func (s *BaseTrafficClassListener) ExitMatchICMP6Type(ctx *MatchICMP6TypeContext) {}
+
 // EnterMatchSrcPort is called when production matchSrcPort is entered.
 func (s *BaseTrafficClassListener) EnterMatchSrcPort(ctx *MatchSrcPortContext) {}
 
@@ -110,6 +140,12 @@ func (s *BaseTrafficClassListener) EnterCondIPv4(ctx *CondIPv4Context) {}
 // ExitCondIPv4 is called when production condIPv4 is exited.
 func (s *BaseTrafficClassListener) ExitCondIPv4(ctx *CondIPv4Context) {}
 
+// EnterCondIPv6 is called when production condIPv6 is entered.
+func (s *BaseTrafficClassListener) EnterCondIPv6(ctx *CondIPv6Context) {}
+
+// ExitCondIPv6 is called when production condIPv6 is exited.
+func (s *BaseTrafficClassListener) ExitCondIPv6(ctx *CondIPv6Context) {}
+
 // EnterCondPort is called when production condPort is entered.
 func (s *BaseTrafficClassListener) EnterCondPort(ctx *CondPortContext) {}
 
@@ -48,121 +48,176 @@ func trafficclasslexerLexerInit() {
 	}
 	staticData.SymbolicNames = []string{
 		"", "", "", "", "", "", "", "", "", "", "WHITESPACE", "DIGITS", "HEX_DIGITS",
-		"NET", "ANY", "ALL", "NOT", "BOOL", "SRC", "DST", "DSCP", "TOS", "PROTOCOL",
-		"SRCPORT", "DSTPORT", "STRING",
+		"NET", "NET6", "ANY", "ALL", "NOT", "BOOL", "SRC", "DST", "DSCP", "TOS",
+		"PROTOCOL", "SRCPORT", "DSTPORT", "ICMPTYPE", "TC", "FLOWLABEL", "NEXTHEADER",
+		"ICMP6TYPE", "STRING",
 	}
 	staticData.RuleNames = []string{
 		"T__0", "T__1", "T__2", "T__3", "T__4", "T__5", "T__6", "T__7", "T__8",
-		"WHITESPACE", "DIGITS", "HEX_DIGITS", "NET", "ANY", "ALL", "NOT", "BOOL",
-		"SRC", "DST", "DSCP", "TOS", "PROTOCOL", "SRCPORT", "DSTPORT", "STRING",
+		"WHITESPACE", "DIGITS", "HEX_DIGITS", "NET", "NET6", "ANY", "ALL", "NOT",
+		"BOOL", "SRC", "DST", "DSCP", "TOS", "PROTOCOL", "SRCPORT", "DSTPORT",
+		"ICMPTYPE", "TC", "FLOWLABEL", "NEXTHEADER", "ICMP6TYPE", "STRING",
 	}
 	staticData.PredictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 0, 25, 236, 6, -1, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2,
+		4, 0, 31, 352, 6, -1, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2,
 		4, 7, 4, 2, 5, 7, 5, 2, 6, 7, 6, 2, 7, 7, 7, 2, 8, 7, 8, 2, 9, 7, 9, 2,
 		10, 7, 10, 2, 11, 7, 11, 2, 12, 7, 12, 2, 13, 7, 13, 2, 14, 7, 14, 2, 15,
 		7, 15, 2, 16, 7, 16, 2, 17, 7, 17, 2, 18, 7, 18, 2, 19, 7, 19, 2, 20, 7,
-		20, 2, 21, 7, 21, 2, 22, 7, 22, 2, 23, 7, 23, 2, 24, 7, 24, 1, 0, 1, 0,
-		1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 1, 2, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 4,
-		1, 4, 1, 5, 1, 5, 1, 6, 1, 6, 1, 7, 1, 7, 1, 7, 1, 7, 1, 7, 1, 8, 1, 8,
-		1, 8, 1, 8, 1, 8, 1, 8, 1, 9, 4, 9, 83, 8, 9, 11, 9, 12, 9, 84, 1, 9, 1,
-		9, 1, 10, 1, 10, 1, 10, 5, 10, 92, 8, 10, 10, 10, 12, 10, 95, 9, 10, 3,
-		10, 97, 8, 10, 1, 11, 4, 11, 100, 8, 11, 11, 11, 12, 11, 101, 1, 12, 1,
-		12, 1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 1, 13, 1, 13,
-		1, 13, 1, 13, 1, 13, 1, 13, 3, 13, 120, 8, 13, 1, 14, 1, 14, 1, 14, 1,
-		14, 1, 14, 1, 14, 3, 14, 128, 8, 14, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15,
-		1, 15, 3, 15, 136, 8, 15, 1, 16, 1, 16, 1, 16, 1, 16, 1, 16, 1, 16, 1,
-		16, 1, 16, 3, 16, 146, 8, 16, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17,
-		3, 17, 154, 8, 17, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 3, 18, 162,
-		8, 18, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 3, 19, 172,
-		8, 19, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 3, 20, 180, 8, 20, 1,
-		21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21,
-		1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 3, 21, 198, 8, 21, 1, 22, 1, 22, 1,
-		22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22,
-		1, 22, 3, 22, 214, 8, 22, 1, 23, 1, 23, 1, 23, 1, 23, 1, 23, 1, 23, 1,
-		23, 1, 23, 1, 23, 1, 23, 1, 23, 1, 23, 1, 23, 1, 23, 3, 23, 230, 8, 23,
-		1, 24, 4, 24, 233, 8, 24, 11, 24, 12, 24, 234, 0, 0, 25, 1, 1, 3, 2, 5,
-		3, 7, 4, 9, 5, 11, 6, 13, 7, 15, 8, 17, 9, 19, 10, 21, 11, 23, 12, 25,
-		13, 27, 14, 29, 15, 31, 16, 33, 17, 35, 18, 37, 19, 39, 20, 41, 21, 43,
-		22, 45, 23, 47, 24, 49, 25, 1, 0, 5, 3, 0, 9, 10, 13, 13, 32, 32, 1, 0,
-		49, 57, 1, 0, 48, 57, 3, 0, 48, 57, 65, 70, 97, 102, 2, 0, 65, 90, 97,
-		122, 251, 0, 1, 1, 0, 0, 0, 0, 3, 1, 0, 0, 0, 0, 5, 1, 0, 0, 0, 0, 7, 1,
-		0, 0, 0, 0, 9, 1, 0, 0, 0, 0, 11, 1, 0, 0, 0, 0, 13, 1, 0, 0, 0, 0, 15,
-		1, 0, 0, 0, 0, 17, 1, 0, 0, 0, 0, 19, 1, 0, 0, 0, 0, 21, 1, 0, 0, 0, 0,
-		23, 1, 0, 0, 0, 0, 25, 1, 0, 0, 0, 0, 27, 1, 0, 0, 0, 0, 29, 1, 0, 0, 0,
-		0, 31, 1, 0, 0, 0, 0, 33, 1, 0, 0, 0, 0, 35, 1, 0, 0, 0, 0, 37, 1, 0, 0,
-		0, 0, 39, 1, 0, 0, 0, 0, 41, 1, 0, 0, 0, 0, 43, 1, 0, 0, 0, 0, 45, 1, 0,
-		0, 0, 0, 47, 1, 0, 0, 0, 0, 49, 1, 0, 0, 0, 1, 51, 1, 0, 0, 0, 3, 53, 1,
-		0, 0, 0, 5, 57, 1, 0, 0, 0, 7, 59, 1, 0, 0, 0, 9, 64, 1, 0, 0, 0, 11, 66,
-		1, 0, 0, 0, 13, 68, 1, 0, 0, 0, 15, 70, 1, 0, 0, 0, 17, 75, 1, 0, 0, 0,
-		19, 82, 1, 0, 0, 0, 21, 96, 1, 0, 0, 0, 23, 99, 1, 0, 0, 0, 25, 103, 1,
-		0, 0, 0, 27, 119, 1, 0, 0, 0, 29, 127, 1, 0, 0, 0, 31, 135, 1, 0, 0, 0,
-		33, 145, 1, 0, 0, 0, 35, 153, 1, 0, 0, 0, 37, 161, 1, 0, 0, 0, 39, 171,
-		1, 0, 0, 0, 41, 179, 1, 0, 0, 0, 43, 197, 1, 0, 0, 0, 45, 213, 1, 0, 0,
-		0, 47, 229, 1, 0, 0, 0, 49, 232, 1, 0, 0, 0, 51, 52, 5, 61, 0, 0, 52, 2,
-		1, 0, 0, 0, 53, 54, 5, 61, 0, 0, 54, 55, 5, 48, 0, 0, 55, 56, 5, 120, 0,
-		0, 56, 4, 1, 0, 0, 0, 57, 58, 5, 45, 0, 0, 58, 6, 1, 0, 0, 0, 59, 60, 5,
-		99, 0, 0, 60, 61, 5, 108, 0, 0, 61, 62, 5, 115, 0, 0, 62, 63, 5, 61, 0,
-		0, 63, 8, 1, 0, 0, 0, 64, 65, 5, 40, 0, 0, 65, 10, 1, 0, 0, 0, 66, 67,
-		5, 44, 0, 0, 67, 12, 1, 0, 0, 0, 68, 69, 5, 41, 0, 0, 69, 14, 1, 0, 0,
-		0, 70, 71, 5, 116, 0, 0, 71, 72, 5, 114, 0, 0, 72, 73, 5, 117, 0, 0, 73,
-		74, 5, 101, 0, 0, 74, 16, 1, 0, 0, 0, 75, 76, 5, 102, 0, 0, 76, 77, 5,
-		97, 0, 0, 77, 78, 5, 108, 0, 0, 78, 79, 5, 115, 0, 0, 79, 80, 5, 101, 0,
-		0, 80, 18, 1, 0, 0, 0, 81, 83, 7, 0, 0, 0, 82, 81, 1, 0, 0, 0, 83, 84,
-		1, 0, 0, 0, 84, 82, 1, 0, 0, 0, 84, 85, 1, 0, 0, 0, 85, 86, 1, 0, 0, 0,
-		86, 87, 6, 9, 0, 0, 87, 20, 1, 0, 0, 0, 88, 97, 5, 48, 0, 0, 89, 93, 7,
-		1, 0, 0, 90, 92, 7, 2, 0, 0, 91, 90, 1, 0, 0, 0, 92, 95, 1, 0, 0, 0, 93,
-		91, 1, 0, 0, 0, 93, 94, 1, 0, 0, 0, 94, 97, 1, 0, 0, 0, 95, 93, 1, 0, 0,
-		0, 96, 88, 1, 0, 0, 0, 96, 89, 1, 0, 0, 0, 97, 22, 1, 0, 0, 0, 98, 100,
-		7, 3, 0, 0, 99, 98, 1, 0, 0, 0, 100, 101, 1, 0, 0, 0, 101, 99, 1, 0, 0,
-		0, 101, 102, 1, 0, 0, 0, 102, 24, 1, 0, 0, 0, 103, 104, 3, 21, 10, 0, 104,
-		105, 5, 46, 0, 0, 105, 106, 3, 21, 10, 0, 106, 107, 5, 46, 0, 0, 107, 108,
-		3, 21, 10, 0, 108, 109, 5, 46, 0, 0, 109, 110, 3, 21, 10, 0, 110, 111,
-		5, 47, 0, 0, 111, 112, 3, 21, 10, 0, 112, 26, 1, 0, 0, 0, 113, 114, 5,
-		65, 0, 0, 114, 115, 5, 78, 0, 0, 115, 120, 5, 89, 0, 0, 116, 117, 5, 97,
-		0, 0, 117, 118, 5, 110, 0, 0, 118, 120, 5, 121, 0, 0, 119, 113, 1, 0, 0,
-		0, 119, 116, 1, 0, 0, 0, 120, 28, 1, 0, 0, 0, 121, 122, 5, 65, 0, 0, 122,
-		123, 5, 76, 0, 0, 123, 128, 5, 76, 0, 0, 124, 125, 5, 97, 0, 0, 125, 126,
-		5, 108, 0, 0, 126, 128, 5, 108, 0, 0, 127, 121, 1, 0, 0, 0, 127, 124, 1,
-		0, 0, 0, 128, 30, 1, 0, 0, 0, 129, 130, 5, 78, 0, 0, 130, 131, 5, 79, 0,
-		0, 131, 136, 5, 84, 0, 0, 132, 133, 5, 110, 0, 0, 133, 134, 5, 111, 0,
-		0, 134, 136, 5, 116, 0, 0, 135, 129, 1, 0, 0, 0, 135, 132, 1, 0, 0, 0,
-		136, 32, 1, 0, 0, 0, 137, 138, 5, 66, 0, 0, 138, 139, 5, 79, 0, 0, 139,
-		140, 5, 79, 0, 0, 140, 146, 5, 76, 0, 0, 141, 142, 5, 98, 0, 0, 142, 143,
-		5, 111, 0, 0, 143, 144, 5, 111, 0, 0, 144, 146, 5, 108, 0, 0, 145, 137,
-		1, 0, 0, 0, 145, 141, 1, 0, 0, 0, 146, 34, 1, 0, 0, 0, 147, 148, 5, 83,
-		0, 0, 148, 149, 5, 82, 0, 0, 149, 154, 5, 67, 0, 0, 150, 151, 5, 115, 0,
-		0, 151, 152, 5, 114, 0, 0, 152, 154, 5, 99, 0, 0, 153, 147, 1, 0, 0, 0,
-		153, 150, 1, 0, 0, 0, 154, 36, 1, 0, 0, 0, 155, 156, 5, 68, 0, 0, 156,
-		157, 5, 83, 0, 0, 157, 162, 5, 84, 0, 0, 158, 159, 5, 100, 0, 0, 159, 160,
-		5, 115, 0, 0, 160, 162, 5, 116, 0, 0, 161, 155, 1, 0, 0, 0, 161, 158, 1,
-		0, 0, 0, 162, 38, 1, 0, 0, 0, 163, 164, 5, 68, 0, 0, 164, 165, 5, 83, 0,
-		0, 165, 166, 5, 67, 0, 0, 166, 172, 5, 80, 0, 0, 167, 168, 5, 100, 0, 0,
-		168, 169, 5, 115, 0, 0, 169, 170, 5, 99, 0, 0, 170, 172, 5, 112, 0, 0,
-		171, 163, 1, 0, 0, 0, 171, 167, 1, 0, 0, 0, 172, 40, 1, 0, 0, 0, 173, 174,
-		5, 84, 0, 0, 174, 175, 5, 79, 0, 0, 175, 180, 5, 83, 0, 0, 176, 177, 5,
-		116, 0, 0, 177, 178, 5, 111, 0, 0, 178, 180, 5, 115, 0, 0, 179, 173, 1,
-		0, 0, 0, 179, 176, 1, 0, 0, 0, 180, 42, 1, 0, 0, 0, 181, 182, 5, 80, 0,
-		0, 182, 183, 5, 82, 0, 0, 183, 184, 5, 79, 0, 0, 184, 185, 5, 84, 0, 0,
-		185, 186, 5, 79, 0, 0, 186, 187, 5, 67, 0, 0, 187, 188, 5, 79, 0, 0, 188,
-		198, 5, 76, 0, 0, 189, 190, 5, 112, 0, 0, 190, 191, 5, 114, 0, 0, 191,
-		192, 5, 111, 0, 0, 192, 193, 5, 116, 0, 0, 193, 194, 5, 111, 0, 0, 194,
-		195, 5, 99, 0, 0, 195, 196, 5, 111, 0, 0, 196, 198, 5, 108, 0, 0, 197,
-		181, 1, 0, 0, 0, 197, 189, 1, 0, 0, 0, 198, 44, 1, 0, 0, 0, 199, 200, 5,
-		83, 0, 0, 200, 201, 5, 82, 0, 0, 201, 202, 5, 67, 0, 0, 202, 203, 5, 80,
-		0, 0, 203, 204, 5, 79, 0, 0, 204, 205, 5, 82, 0, 0, 205, 214, 5, 84, 0,
-		0, 206, 207, 5, 115, 0, 0, 207, 208, 5, 114, 0, 0, 208, 209, 5, 99, 0,
-		0, 209, 210, 5, 112, 0, 0, 210, 211, 5, 111, 0, 0, 211, 212, 5, 114, 0,
-		0, 212, 214, 5, 116, 0, 0, 213, 199, 1, 0, 0, 0, 213, 206, 1, 0, 0, 0,
-		214, 46, 1, 0, 0, 0, 215, 216, 5, 68, 0, 0, 216, 217, 5, 83, 0, 0, 217,
-		218, 5, 84, 0, 0, 218, 219, 5, 80, 0, 0, 219, 220, 5, 79, 0, 0, 220, 221,
-		5, 82, 0, 0, 221, 230, 5, 84, 0, 0, 222, 223, 5, 100, 0, 0, 223, 224, 5,
-		115, 0, 0, 224, 225, 5, 116, 0, 0, 225, 226, 5, 112, 0, 0, 226, 227, 5,
-		111, 0, 0, 227, 228, 5, 114, 0, 0, 228, 230, 5, 116, 0, 0, 229, 215, 1,
-		0, 0, 0, 229, 222, 1, 0, 0, 0, 230, 48, 1, 0, 0, 0, 231, 233, 7, 4, 0,
-		0, 232, 231, 1, 0, 0, 0, 233, 234, 1, 0, 0, 0, 234, 232, 1, 0, 0, 0, 234,
-		235, 1, 0, 0, 0, 235, 50, 1, 0, 0, 0, 18, 0, 84, 93, 96, 99, 101, 119,
-		127, 135, 145, 153, 161, 171, 179, 197, 213, 229, 234, 1, 6, 0, 0,
+		20, 2, 21, 7, 21, 2, 22, 7, 22, 2, 23, 7, 23, 2, 24, 7, 24, 2, 25, 7, 25,
+		2, 26, 7, 26, 2, 27, 7, 27, 2, 28, 7, 28, 2, 29, 7, 29, 2, 30, 7, 30, 1,
+		0, 1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 1, 2, 1, 3, 1, 3, 1, 3, 1, 3, 1,
+		3, 1, 4, 1, 4, 1, 5, 1, 5, 1, 6, 1, 6, 1, 7, 1, 7, 1, 7, 1, 7, 1, 7, 1,
+		8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 9, 4, 9, 95, 8, 9, 11, 9, 12, 9, 96,
+		1, 9, 1, 9, 1, 10, 1, 10, 1, 10, 5, 10, 104, 8, 10, 10, 10, 12, 10, 107,
+		9, 10, 3, 10, 109, 8, 10, 1, 11, 4, 11, 112, 8, 11, 11, 11, 12, 11, 113,
+		1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 1,
+		13, 5, 13, 127, 8, 13, 10, 13, 12, 13, 130, 9, 13, 1, 13, 1, 13, 5, 13,
+		134, 8, 13, 10, 13, 12, 13, 137, 9, 13, 1, 13, 1, 13, 1, 13, 1, 14, 1,
+		14, 1, 14, 1, 14, 1, 14, 1, 14, 3, 14, 148, 8, 14, 1, 15, 1, 15, 1, 15,
+		1, 15, 1, 15, 1, 15, 3, 15, 156, 8, 15, 1, 16, 1, 16, 1, 16, 1, 16, 1,
+		16, 1, 16, 3, 16, 164, 8, 16, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17,
+		1, 17, 1, 17, 3, 17, 174, 8, 17, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 1,
+		18, 3, 18, 182, 8, 18, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 3, 19,
+		190, 8, 19, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 3,
+		20, 200, 8, 20, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 3, 21, 208, 8,
+		21, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22,
+		1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 3, 22, 226, 8, 22, 1, 23, 1,
+		23, 1, 23, 1, 23, 1, 23, 1, 23, 1, 23, 1, 23, 1, 23, 1, 23, 1, 23, 1, 23,
+		1, 23, 1, 23, 3, 23, 242, 8, 23, 1, 24, 1, 24, 1, 24, 1, 24, 1, 24, 1,
+		24, 1, 24, 1, 24, 1, 24, 1, 24, 1, 24, 1, 24, 1, 24, 1, 24, 3, 24, 258,
+		8, 24, 1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1,
+		25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 3, 25, 276, 8, 25, 1, 26,
+		1, 26, 1, 26, 1, 26, 3, 26, 282, 8, 26, 1, 27, 1, 27, 1, 27, 1, 27, 1,
+		27, 1, 27, 1, 27, 1, 27, 1, 27, 1, 27, 1, 27, 1, 27, 1, 27, 1, 27, 1, 27,
+		1, 27, 1, 27, 1, 27, 3, 27, 302, 8, 27, 1, 28, 1, 28, 1, 28, 1, 28, 1,
+		28, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 1, 28,
+		1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 3, 28, 324, 8, 28, 1, 29, 1, 29, 1,
+		29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 1, 29,
+		1, 29, 1, 29, 1, 29, 1, 29, 1, 29, 3, 29, 344, 8, 29, 1, 30, 1, 30, 5,
+		30, 348, 8, 30, 10, 30, 12, 30, 351, 9, 30, 0, 0, 31, 1, 1, 3, 2, 5, 3,
+		7, 4, 9, 5, 11, 6, 13, 7, 15, 8, 17, 9, 19, 10, 21, 11, 23, 12, 25, 13,
+		27, 14, 29, 15, 31, 16, 33, 17, 35, 18, 37, 19, 39, 20, 41, 21, 43, 22,
+		45, 23, 47, 24, 49, 25, 51, 26, 53, 27, 55, 28, 57, 29, 59, 30, 61, 31,
+		1, 0, 7, 3, 0, 9, 10, 13, 13, 32, 32, 1, 0, 49, 57, 1, 0, 48, 57, 3, 0,
+		48, 57, 65, 70, 97, 102, 4, 0, 46, 46, 48, 58, 65, 70, 97, 102, 2, 0, 65,
+		90, 97, 122, 3, 0, 48, 57, 65, 90, 97, 122, 374, 0, 1, 1, 0, 0, 0, 0, 3,
+		1, 0, 0, 0, 0, 5, 1, 0, 0, 0, 0, 7, 1, 0, 0, 0, 0, 9, 1, 0, 0, 0, 0, 11,
+		1, 0, 0, 0, 0, 13, 1, 0, 0, 0, 0, 15, 1, 0, 0, 0, 0, 17, 1, 0, 0, 0, 0,
+		19, 1, 0, 0, 0, 0, 21, 1, 0, 0, 0, 0, 23, 1, 0, 0, 0, 0, 25, 1, 0, 0, 0,
+		0, 27, 1, 0, 0, 0, 0, 29, 1, 0, 0, 0, 0, 31, 1, 0, 0, 0, 0, 33, 1, 0, 0,
+		0, 0, 35, 1, 0, 0, 0, 0, 37, 1, 0, 0, 0, 0, 39, 1, 0, 0, 0, 0, 41, 1, 0,
+		0, 0, 0, 43, 1, 0, 0, 0, 0, 45, 1, 0, 0, 0, 0, 47, 1, 0, 0, 0, 0, 49, 1,
+		0, 0, 0, 0, 51, 1, 0, 0, 0, 0, 53, 1, 0, 0, 0, 0, 55, 1, 0, 0, 0, 0, 57,
+		1, 0, 0, 0, 0, 59, 1, 0, 0, 0, 0, 61, 1, 0, 0, 0, 1, 63, 1, 0, 0, 0, 3,
+		65, 1, 0, 0, 0, 5, 69, 1, 0, 0, 0, 7, 71, 1, 0, 0, 0, 9, 76, 1, 0, 0, 0,
+		11, 78, 1, 0, 0, 0, 13, 80, 1, 0, 0, 0, 15, 82, 1, 0, 0, 0, 17, 87, 1,
+		0, 0, 0, 19, 94, 1, 0, 0, 0, 21, 108, 1, 0, 0, 0, 23, 111, 1, 0, 0, 0,
+		25, 115, 1, 0, 0, 0, 27, 128, 1, 0, 0, 0, 29, 147, 1, 0, 0, 0, 31, 155,
+		1, 0, 0, 0, 33, 163, 1, 0, 0, 0, 35, 173, 1, 0, 0, 0, 37, 181, 1, 0, 0,
+		0, 39, 189, 1, 0, 0, 0, 41, 199, 1, 0, 0, 0, 43, 207, 1, 0, 0, 0, 45, 225,
+		1, 0, 0, 0, 47, 241, 1, 0, 0, 0, 49, 257, 1, 0, 0, 0, 51, 275, 1, 0, 0,
+		0, 53, 281, 1, 0, 0, 0, 55, 301, 1, 0, 0, 0, 57, 323, 1, 0, 0, 0, 59, 343,
+		1, 0, 0, 0, 61, 345, 1, 0, 0, 0, 63, 64, 5, 61, 0, 0, 64, 2, 1, 0, 0, 0,
+		65, 66, 5, 61, 0, 0, 66, 67, 5, 48, 0, 0, 67, 68, 5, 120, 0, 0, 68, 4,
+		1, 0, 0, 0, 69, 70, 5, 45, 0, 0, 70, 6, 1, 0, 0, 0, 71, 72, 5, 99, 0, 0,
+		72, 73, 5, 108, 0, 0, 73, 74, 5, 115, 0, 0, 74, 75, 5, 61, 0, 0, 75, 8,
+		1, 0, 0, 0, 76, 77, 5, 40, 0, 0, 77, 10, 1, 0, 0, 0, 78, 79, 5, 44, 0,
+		0, 79, 12, 1, 0, 0, 0, 80, 81, 5, 41, 0, 0, 81, 14, 1, 0, 0, 0, 82, 83,
+		5, 116, 0, 0, 83, 84, 5, 114, 0, 0, 84, 85, 5, 117, 0, 0, 85, 86, 5, 101,
+		0, 0, 86, 16, 1, 0, 0, 0, 87, 88, 5, 102, 0, 0, 88, 89, 5, 97, 0, 0, 89,
+		90, 5, 108, 0, 0, 90, 91, 5, 115, 0, 0, 91, 92, 5, 101, 0, 0, 92, 18, 1,
+		0, 0, 0, 93, 95, 7, 0, 0, 0, 94, 93, 1, 0, 0, 0, 95, 96, 1, 0, 0, 0, 96,
+		94, 1, 0, 0, 0, 96, 97, 1, 0, 0, 0, 97, 98, 1, 0, 0, 0, 98, 99, 6, 9, 0,
+		0, 99, 20, 1, 0, 0, 0, 100, 109, 5, 48, 0, 0, 101, 105, 7, 1, 0, 0, 102,
+		104, 7, 2, 0, 0, 103, 102, 1, 0, 0, 0, 104, 107, 1, 0, 0, 0, 105, 103,
+		1, 0, 0, 0, 105, 106, 1, 0, 0, 0, 106, 109, 1, 0, 0, 0, 107, 105, 1, 0,
+		0, 0, 108, 100, 1, 0, 0, 0, 108, 101, 1, 0, 0, 0, 109, 22, 1, 0, 0, 0,
+		110, 112, 7, 3, 0, 0, 111, 110, 1, 0, 0, 0, 112, 113, 1, 0, 0, 0, 113,
+		111, 1, 0, 0, 0, 113, 114, 1, 0, 0, 0, 114, 24, 1, 0, 0, 0, 115, 116, 3,
+		21, 10, 0, 116, 117, 5, 46, 0, 0, 117, 118, 3, 21, 10, 0, 118, 119, 5,
+		46, 0, 0, 119, 120, 3, 21, 10, 0, 120, 121, 5, 46, 0, 0, 121, 122, 3, 21,
+		10, 0, 122, 123, 5, 47, 0, 0, 123, 124, 3, 21, 10, 0, 124, 26, 1, 0, 0,
+		0, 125, 127, 7, 3, 0, 0, 126, 125, 1, 0, 0, 0, 127, 130, 1, 0, 0, 0, 128,
+		126, 1, 0, 0, 0, 128, 129, 1, 0, 0, 0, 129, 131, 1, 0, 0, 0, 130, 128,
+		1, 0, 0, 0, 131, 135, 5, 58, 0, 0, 132, 134, 7, 4, 0, 0, 133, 132, 1, 0,
+		0, 0, 134, 137, 1, 0, 0, 0, 135, 133, 1, 0, 0, 0, 135, 136, 1, 0, 0, 0,
+		136, 138, 1, 0, 0, 0, 137, 135, 1, 0, 0, 0, 138, 139, 5, 47, 0, 0, 139,
+		140, 3, 21, 10, 0, 140, 28, 1, 0, 0, 0, 141, 142, 5, 65, 0, 0, 142, 143,
+		5, 78, 0, 0, 143, 148, 5, 89, 0, 0, 144, 145, 5, 97, 0, 0, 145, 146, 5,
+		110, 0, 0, 146, 148, 5, 121, 0, 0, 147, 141, 1, 0, 0, 0, 147, 144, 1, 0,
+		0, 0, 148, 30, 1, 0, 0, 0, 149, 150, 5, 65, 0, 0, 150, 151, 5, 76, 0, 0,
+		151, 156, 5, 76, 0, 0, 152, 153, 5, 97, 0, 0, 153, 154, 5, 108, 0, 0, 154,
+		156, 5, 108, 0, 0, 155, 149, 1, 0, 0, 0, 155, 152, 1, 0, 0, 0, 156, 32,
+		1, 0, 0, 0, 157, 158, 5, 78, 0, 0, 158, 159, 5, 79, 0, 0, 159, 164, 5,
+		84, 0, 0, 160, 161, 5, 110, 0, 0, 161, 162, 5, 111, 0, 0, 162, 164, 5,
+		116, 0, 0, 163, 157, 1, 0, 0, 0, 163, 160, 1, 0, 0, 0, 164, 34, 1, 0, 0,
+		0, 165, 166, 5, 66, 0, 0, 166, 167, 5, 79, 0, 0, 167, 168, 5, 79, 0, 0,
+		168, 174, 5, 76, 0, 0, 169, 170, 5, 98, 0, 0, 170, 171, 5, 111, 0, 0, 171,
+		172, 5, 111, 0, 0, 172, 174, 5, 108, 0, 0, 173, 165, 1, 0, 0, 0, 173, 169,
+		1, 0, 0, 0, 174, 36, 1, 0, 0, 0, 175, 176, 5, 83, 0, 0, 176, 177, 5, 82,
+		0, 0, 177, 182, 5, 67, 0, 0, 178, 179, 5, 115, 0, 0, 179, 180, 5, 114,
+		0, 0, 180, 182, 5, 99, 0, 0, 181, 175, 1, 0, 0, 0, 181, 178, 1, 0, 0, 0,
+		182, 38, 1, 0, 0, 0, 183, 184, 5, 68, 0, 0, 184, 185, 5, 83, 0, 0, 185,
+		190, 5, 84, 0, 0, 186, 187, 5, 100, 0, 0, 187, 188, 5, 115, 0, 0, 188,
+		190, 5, 116, 0, 0, 189, 183, 1, 0, 0, 0, 189, 186, 1, 0, 0, 0, 190, 40,
+		1, 0, 0, 0, 191, 192, 5, 68, 0, 0, 192, 193, 5, 83, 0, 0, 193, 194, 5,
+		67, 0, 0, 194, 200, 5, 80, 0, 0, 195, 196, 5, 100, 0, 0, 196, 197, 5, 115,
+		0, 0, 197, 198, 5, 99, 0, 0, 198, 200, 5, 112, 0, 0, 199, 191, 1, 0, 0,
+		0, 199, 195, 1, 0, 0, 0, 200, 42, 1, 0, 0, 0, 201, 202, 5, 84, 0, 0, 202,
+		203, 5, 79, 0, 0, 203, 208, 5, 83, 0, 0, 204, 205, 5, 116, 0, 0, 205, 206,
+		5, 111, 0, 0, 206, 208, 5, 115, 0, 0, 207, 201, 1, 0, 0, 0, 207, 204, 1,
+		0, 0, 0, 208, 44, 1, 0, 0, 0, 209, 210, 5, 80, 0, 0, 210, 211, 5, 82, 0,
+		0, 211, 212, 5, 79, 0, 0, 212, 213, 5, 84, 0, 0, 213, 214, 5, 79, 0, 0,
+		214, 215, 5, 67, 0, 0, 215, 216, 5, 79, 0, 0, 216, 226, 5, 76, 0, 0, 217,
+		218, 5, 112, 0, 0, 218, 219, 5, 114, 0, 0, 219, 220, 5, 111, 0, 0, 220,
+		221, 5, 116, 0, 0, 221, 222, 5, 111, 0, 0, 222, 223, 5, 99, 0, 0, 223,
+		224, 5, 111, 0, 0, 224, 226, 5, 108, 0, 0, 225, 209, 1, 0, 0, 0, 225, 217,
+		1, 0, 0, 0, 226, 46, 1, 0, 0, 0, 227, 228, 5, 83, 0, 0, 228, 229, 5, 82,
+		0, 0, 229, 230, 5, 67, 0, 0, 230, 231, 5, 80, 0, 0, 231, 232, 5, 79, 0,
+		0, 232, 233, 5, 82, 0, 0, 233, 242, 5, 84, 0, 0, 234, 235, 5, 115, 0, 0,
+		235, 236, 5, 114, 0, 0, 236, 237, 5, 99, 0, 0, 237, 238, 5, 112, 0, 0,
+		238, 239, 5, 111, 0, 0, 239, 240, 5, 114, 0, 0, 240, 242, 5, 116, 0, 0,
+		241, 227, 1, 0, 0, 0, 241, 234, 1, 0, 0, 0, 242, 48, 1, 0, 0, 0, 243, 244,
+		5, 68, 0, 0, 244, 245, 5, 83, 0, 0, 245, 246, 5, 84, 0, 0, 246, 247, 5,
+		80, 0, 0, 247, 248, 5, 79, 0, 0, 248, 249, 5, 82, 0, 0, 249, 258, 5, 84,
+		0, 0, 250, 251, 5, 100, 0, 0, 251, 252, 5, 115, 0, 0, 252, 253, 5, 116,
+		0, 0, 253, 254, 5, 112, 0, 0, 254, 255, 5, 111, 0, 0, 255, 256, 5, 114,
+		0, 0, 256, 258, 5, 116, 0, 0, 257, 243, 1, 0, 0, 0, 257, 250, 1, 0, 0,
+		0, 258, 50, 1, 0, 0, 0, 259, 260, 5, 73, 0, 0, 260, 261, 5, 67, 0, 0, 261,
+		262, 5, 77, 0, 0, 262, 263, 5, 80, 0, 0, 263, 264, 5, 84, 0, 0, 264, 265,
+		5, 89, 0, 0, 265, 266, 5, 80, 0, 0, 266, 276, 5, 69, 0, 0, 267, 268, 5,
+		105, 0, 0, 268, 269, 5, 99, 0, 0, 269, 270, 5, 109, 0, 0, 270, 271, 5,
+		112, 0, 0, 271, 272, 5, 116, 0, 0, 272, 273, 5, 121, 0, 0, 273, 274, 5,
+		112, 0, 0, 274, 276, 5, 101, 0, 0, 275, 259, 1, 0, 0, 0, 275, 267, 1, 0,
+		0, 0, 276, 52, 1, 0, 0, 0, 277, 278, 5, 84, 0, 0, 278, 282, 5, 67, 0, 0,
+		279, 280, 5, 116, 0, 0, 280, 282, 5, 99, 0, 0, 281, 277, 1, 0, 0, 0, 281,
+		279, 1, 0, 0, 0, 282, 54, 1, 0, 0, 0, 283, 284, 5, 70, 0, 0, 284, 285,
+		5, 76, 0, 0, 285, 286, 5, 79, 0, 0, 286, 287, 5, 87, 0, 0, 287, 288, 5,
+		76, 0, 0, 288, 289, 5, 65, 0, 0, 289, 290, 5, 66, 0, 0, 290, 291, 5, 69,
+		0, 0, 291, 302, 5, 76, 0, 0, 292, 293, 5, 102, 0, 0, 293, 294, 5, 108,
+		0, 0, 294, 295, 5, 111, 0, 0, 295, 296, 5, 119, 0, 0, 296, 297, 5, 108,
+		0, 0, 297, 298, 5, 97, 0, 0, 298, 299, 5, 98, 0, 0, 299, 300, 5, 101, 0,
+		0, 300, 302, 5, 108, 0, 0, 301, 283, 1, 0, 0, 0, 301, 292, 1, 0, 0, 0,
+		302, 56, 1, 0, 0, 0, 303, 304, 5, 78, 0, 0, 304, 305, 5, 69, 0, 0, 305,
+		306, 5, 88, 0, 0, 306, 307, 5, 84, 0, 0, 307, 308, 5, 72, 0, 0, 308, 309,
+		5, 69, 0, 0, 309, 310, 5, 65, 0, 0, 310, 311, 5, 68, 0, 0, 311, 312, 5,
+		69, 0, 0, 312, 324, 5, 82, 0, 0, 313, 314, 5, 110, 0, 0, 314, 315, 5, 101,
+		0, 0, 315, 316, 5, 120, 0, 0, 316, 317, 5, 116, 0, 0, 317, 318, 5, 104,
+		0, 0, 318, 319, 5, 101, 0, 0, 319, 320, 5, 97, 0, 0, 320, 321, 5, 100,
+		0, 0, 321, 322, 5, 101, 0, 0, 322, 324, 5, 114, 0, 0, 323, 303, 1, 0, 0,
+		0, 323, 313, 1, 0, 0, 0, 324, 58, 1, 0, 0, 0, 325, 326, 5, 73, 0, 0, 326,
+		327, 5, 67, 0, 0, 327, 328, 5, 77, 0, 0, 328, 329, 5, 80, 0, 0, 329, 330,
+		5, 54, 0, 0, 330, 331, 5, 84, 0, 0, 331, 332, 5, 89, 0, 0, 332, 333, 5,
+		80, 0, 0, 333, 344, 5, 69, 0, 0, 334, 335, 5, 105, 0, 0, 335, 336, 5, 99,
+		0, 0, 336, 337, 5, 109, 0, 0, 337, 338, 5, 112, 0, 0, 338, 339, 5, 54,
+		0, 0, 339, 340, 5, 116, 0, 0, 340, 341, 5, 121, 0, 0, 341, 342, 5, 112,
+		0, 0, 342, 344, 5, 101, 0, 0, 343, 325, 1, 0, 0, 0, 343, 334, 1, 0, 0,
+		0, 344, 60, 1, 0, 0, 0, 345, 349, 7, 5, 0, 0, 346, 348, 7, 6, 0, 0, 347,
+		346, 1, 0, 0, 0, 348, 351, 1, 0, 0, 0, 349, 347, 1, 0, 0, 0, 349, 350,
+		1, 0, 0, 0, 350, 62, 1, 0, 0, 0, 351, 349, 1, 0, 0, 0, 25, 0, 96, 105,
+		108, 111, 113, 128, 135, 147, 155, 163, 173, 181, 189, 199, 207, 225, 241,
+		257, 275, 281, 301, 323, 343, 349, 1, 6, 0, 0,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -216,16 +271,22 @@ const (
 	TrafficClassLexerDIGITS     = 11
 	TrafficClassLexerHEX_DIGITS = 12
 	TrafficClassLexerNET        = 13
-	TrafficClassLexerANY        = 14
-	TrafficClassLexerALL        = 15
-	TrafficClassLexerNOT        = 16
-	TrafficClassLexerBOOL       = 17
-	TrafficClassLexerSRC        = 18
-	TrafficClassLexerDST        = 19
-	TrafficClassLexerDSCP       = 20
-	TrafficClassLexerTOS        = 21
-	TrafficClassLexerPROTOCOL   = 22
-	TrafficClassLexerSRCPORT    = 23
-	TrafficClassLexerDSTPORT    = 24
-	TrafficClassLexerSTRING     = 25
+	TrafficClassLexerNET6       = 14
+	TrafficClassLexerANY        = 15
+	TrafficClassLexerALL        = 16
+	TrafficClassLexerNOT        = 17
+	TrafficClassLexerBOOL       = 18
+	TrafficClassLexerSRC        = 19
+	TrafficClassLexerDST        = 20
+	TrafficClassLexerDSCP       = 21
+	TrafficClassLexerTOS        = 22
+	TrafficClassLexerPROTOCOL   = 23
+	TrafficClassLexerSRCPORT    = 24
+	TrafficClassLexerDSTPORT    = 25
+	TrafficClassLexerICMPTYPE   = 26
+	TrafficClassLexerTC         = 27
+	TrafficClassLexerFLOWLABEL  = 28
+	TrafficClassLexerNEXTHEADER = 29
+	TrafficClassLexerICMP6TYPE  = 30
+	TrafficClassLexerSTRING     = 31
 )
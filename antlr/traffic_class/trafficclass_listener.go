@@ -22,6 +22,21 @@ type TrafficClassListener interface {
 	// EnterMatchProtocol is called when entering the matchProtocol production.
 	EnterMatchProtocol(c *MatchProtocolContext)
 
+	// EnterMatchICMPType is called when entering the matchICMPType production.
+	EnterMatchICMPType(c *MatchICMPTypeContext)
+
+	// EnterMatchTC is called when entering the matchTC production.
+	EnterMatchTC(c *MatchTCContext)
+
+	// EnterMatchFlowLabel is called when entering the matchFlowLabel production.
+	EnterMatchFlowLabel(c *MatchFlowLabelContext)
+
+	// EnterMatchNextHeader is called when entering the matchNextHeader production.
+	EnterMatchNextHeader(c *MatchNextHeaderContext)
+
+	// EnterMatchICMP6Type is called when entering the matchICMP6Type production.
+	EnterMatchICMP6Type(c *MatchICMP6TypeContext)
+
 	// EnterMatchSrcPort is called when entering the matchSrcPort production.
 	EnterMatchSrcPort(c *MatchSrcPortContext)
 
@@ -52,6 +67,9 @@ type TrafficClassListener interface {
 	// EnterCondIPv4 is called when entering the condIPv4 production.
 	EnterCondIPv4(c *CondIPv4Context)
 
+	// EnterCondIPv6 is called when entering the condIPv6 production.
+	EnterCondIPv6(c *CondIPv6Context)
+
 	// EnterCondPort is called when entering the condPort production.
 	EnterCondPort(c *CondPortContext)
 
@@ -76,6 +94,21 @@ type TrafficClassListener interface {
 	// ExitMatchProtocol is called when exiting the matchProtocol production.
 	ExitMatchProtocol(c *MatchProtocolContext)
 
+	// ExitMatchICMPType is called when exiting the matchICMPType production.
+	ExitMatchICMPType(c *MatchICMPTypeContext)
+
+	// ExitMatchTC is called when exiting the matchTC production.
+	ExitMatchTC(c *MatchTCContext)
+
+	// ExitMatchFlowLabel is called when exiting the matchFlowLabel production.
+	ExitMatchFlowLabel(c *MatchFlowLabelContext)
+
+	// ExitMatchNextHeader is called when exiting the matchNextHeader production.
+	ExitMatchNextHeader(c *MatchNextHeaderContext)
+
+	// ExitMatchICMP6Type is called when exiting the matchICMP6Type production.
+	ExitMatchICMP6Type(c *MatchICMP6TypeContext)
+
 	// ExitMatchSrcPort is called when exiting the matchSrcPort production.
 	ExitMatchSrcPort(c *MatchSrcPortContext)
 
@@ -106,6 +139,9 @@ type TrafficClassListener interface {
 	// ExitCondIPv4 is called when exiting the condIPv4 production.
 	ExitCondIPv4(c *CondIPv4Context)
 
+	// ExitCondIPv6 is called when exiting the condIPv6 production.
+	ExitCondIPv6(c *CondIPv6Context)
+
 	// ExitCondPort is called when exiting the condPort production.
 	ExitCondPort(c *CondPortContext)
 
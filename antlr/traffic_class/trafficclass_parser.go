@@ -37,72 +37,91 @@ func trafficclassParserInit() {
 	}
 	staticData.SymbolicNames = []string{
 		"", "", "", "", "", "", "", "", "", "", "WHITESPACE", "DIGITS", "HEX_DIGITS",
-		"NET", "ANY", "ALL", "NOT", "BOOL", "SRC", "DST", "DSCP", "TOS", "PROTOCOL",
-		"SRCPORT", "DSTPORT", "STRING",
+		"NET", "NET6", "ANY", "ALL", "NOT", "BOOL", "SRC", "DST", "DSCP", "TOS",
+		"PROTOCOL", "SRCPORT", "DSTPORT", "ICMPTYPE", "TC", "FLOWLABEL", "NEXTHEADER",
+		"ICMP6TYPE", "STRING",
 	}
 	staticData.RuleNames = []string{
-		"matchSrc", "matchDst", "matchDSCP", "matchTOS", "matchProtocol", "matchSrcPort",
+		"matchSrc", "matchDst", "matchDSCP", "matchTOS", "matchProtocol", "matchICMPType",
+		"matchTC", "matchFlowLabel", "matchNextHeader", "matchICMP6Type", "matchSrcPort",
 		"matchSrcPortRange", "matchDstPort", "matchDstPortRange", "condCls",
-		"condAny", "condAll", "condNot", "condBool", "condIPv4", "condPort",
-		"cond", "trafficClass",
+		"condAny", "condAll", "condNot", "condBool", "condIPv4", "condIPv6",
+		"condPort", "cond", "trafficClass",
 	}
 	staticData.PredictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 1, 25, 138, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
+		4, 1, 31, 178, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
 		4, 2, 5, 7, 5, 2, 6, 7, 6, 2, 7, 7, 7, 2, 8, 7, 8, 2, 9, 7, 9, 2, 10, 7,
 		10, 2, 11, 7, 11, 2, 12, 7, 12, 2, 13, 7, 13, 2, 14, 7, 14, 2, 15, 7, 15,
-		2, 16, 7, 16, 2, 17, 7, 17, 1, 0, 1, 0, 1, 0, 1, 0, 1, 1, 1, 1, 1, 1, 1,
-		1, 1, 2, 1, 2, 1, 2, 1, 2, 1, 3, 1, 3, 1, 3, 1, 3, 1, 4, 1, 4, 1, 4, 1,
-		4, 1, 5, 1, 5, 1, 5, 1, 5, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 7, 1,
-		7, 1, 7, 1, 7, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 9, 1, 9, 1, 9, 1,
-		10, 1, 10, 1, 10, 1, 10, 1, 10, 5, 10, 85, 8, 10, 10, 10, 12, 10, 88, 9,
-		10, 1, 10, 1, 10, 1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 5, 11, 97, 8, 11,
-		10, 11, 12, 11, 100, 9, 11, 1, 11, 1, 11, 1, 12, 1, 12, 1, 12, 1, 12, 1,
-		12, 1, 13, 1, 13, 1, 13, 1, 13, 1, 14, 1, 14, 1, 14, 1, 14, 1, 14, 3, 14,
-		118, 8, 14, 1, 15, 1, 15, 1, 15, 1, 15, 3, 15, 124, 8, 15, 1, 16, 1, 16,
-		1, 16, 1, 16, 1, 16, 1, 16, 1, 16, 3, 16, 133, 8, 16, 1, 17, 1, 17, 1,
-		17, 1, 17, 0, 0, 18, 0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26,
-		28, 30, 32, 34, 0, 2, 1, 0, 11, 12, 1, 0, 8, 9, 134, 0, 36, 1, 0, 0, 0,
-		2, 40, 1, 0, 0, 0, 4, 44, 1, 0, 0, 0, 6, 48, 1, 0, 0, 0, 8, 52, 1, 0, 0,
-		0, 10, 56, 1, 0, 0, 0, 12, 60, 1, 0, 0, 0, 14, 66, 1, 0, 0, 0, 16, 70,
-		1, 0, 0, 0, 18, 76, 1, 0, 0, 0, 20, 79, 1, 0, 0, 0, 22, 91, 1, 0, 0, 0,
-		24, 103, 1, 0, 0, 0, 26, 108, 1, 0, 0, 0, 28, 117, 1, 0, 0, 0, 30, 123,
-		1, 0, 0, 0, 32, 132, 1, 0, 0, 0, 34, 134, 1, 0, 0, 0, 36, 37, 5, 18, 0,
-		0, 37, 38, 5, 1, 0, 0, 38, 39, 5, 13, 0, 0, 39, 1, 1, 0, 0, 0, 40, 41,
-		5, 19, 0, 0, 41, 42, 5, 1, 0, 0, 42, 43, 5, 13, 0, 0, 43, 3, 1, 0, 0, 0,
-		44, 45, 5, 20, 0, 0, 45, 46, 5, 2, 0, 0, 46, 47, 7, 0, 0, 0, 47, 5, 1,
-		0, 0, 0, 48, 49, 5, 21, 0, 0, 49, 50, 5, 2, 0, 0, 50, 51, 7, 0, 0, 0, 51,
-		7, 1, 0, 0, 0, 52, 53, 5, 22, 0, 0, 53, 54, 5, 1, 0, 0, 54, 55, 5, 25,
-		0, 0, 55, 9, 1, 0, 0, 0, 56, 57, 5, 23, 0, 0, 57, 58, 5, 1, 0, 0, 58, 59,
-		5, 11, 0, 0, 59, 11, 1, 0, 0, 0, 60, 61, 5, 23, 0, 0, 61, 62, 5, 1, 0,
-		0, 62, 63, 5, 11, 0, 0, 63, 64, 5, 3, 0, 0, 64, 65, 5, 11, 0, 0, 65, 13,
-		1, 0, 0, 0, 66, 67, 5, 24, 0, 0, 67, 68, 5, 1, 0, 0, 68, 69, 5, 11, 0,
-		0, 69, 15, 1, 0, 0, 0, 70, 71, 5, 24, 0, 0, 71, 72, 5, 1, 0, 0, 72, 73,
-		5, 11, 0, 0, 73, 74, 5, 3, 0, 0, 74, 75, 5, 11, 0, 0, 75, 17, 1, 0, 0,
-		0, 76, 77, 5, 4, 0, 0, 77, 78, 5, 11, 0, 0, 78, 19, 1, 0, 0, 0, 79, 80,
-		5, 14, 0, 0, 80, 81, 5, 5, 0, 0, 81, 86, 3, 32, 16, 0, 82, 83, 5, 6, 0,
-		0, 83, 85, 3, 32, 16, 0, 84, 82, 1, 0, 0, 0, 85, 88, 1, 0, 0, 0, 86, 84,
-		1, 0, 0, 0, 86, 87, 1, 0, 0, 0, 87, 89, 1, 0, 0, 0, 88, 86, 1, 0, 0, 0,
-		89, 90, 5, 7, 0, 0, 90, 21, 1, 0, 0, 0, 91, 92, 5, 15, 0, 0, 92, 93, 5,
-		5, 0, 0, 93, 98, 3, 32, 16, 0, 94, 95, 5, 6, 0, 0, 95, 97, 3, 32, 16, 0,
-		96, 94, 1, 0, 0, 0, 97, 100, 1, 0, 0, 0, 98, 96, 1, 0, 0, 0, 98, 99, 1,
-		0, 0, 0, 99, 101, 1, 0, 0, 0, 100, 98, 1, 0, 0, 0, 101, 102, 5, 7, 0, 0,
-		102, 23, 1, 0, 0, 0, 103, 104, 5, 16, 0, 0, 104, 105, 5, 5, 0, 0, 105,
-		106, 3, 32, 16, 0, 106, 107, 5, 7, 0, 0, 107, 25, 1, 0, 0, 0, 108, 109,
-		5, 17, 0, 0, 109, 110, 5, 1, 0, 0, 110, 111, 7, 1, 0, 0, 111, 27, 1, 0,
-		0, 0, 112, 118, 3, 0, 0, 0, 113, 118, 3, 2, 1, 0, 114, 118, 3, 4, 2, 0,
-		115, 118, 3, 6, 3, 0, 116, 118, 3, 8, 4, 0, 117, 112, 1, 0, 0, 0, 117,
-		113, 1, 0, 0, 0, 117, 114, 1, 0, 0, 0, 117, 115, 1, 0, 0, 0, 117, 116,
-		1, 0, 0, 0, 118, 29, 1, 0, 0, 0, 119, 124, 3, 10, 5, 0, 120, 124, 3, 12,
-		6, 0, 121, 124, 3, 14, 7, 0, 122, 124, 3, 16, 8, 0, 123, 119, 1, 0, 0,
-		0, 123, 120, 1, 0, 0, 0, 123, 121, 1, 0, 0, 0, 123, 122, 1, 0, 0, 0, 124,
-		31, 1, 0, 0, 0, 125, 133, 3, 22, 11, 0, 126, 133, 3, 20, 10, 0, 127, 133,
-		3, 24, 12, 0, 128, 133, 3, 28, 14, 0, 129, 133, 3, 30, 15, 0, 130, 133,
-		3, 18, 9, 0, 131, 133, 3, 26, 13, 0, 132, 125, 1, 0, 0, 0, 132, 126, 1,
-		0, 0, 0, 132, 127, 1, 0, 0, 0, 132, 128, 1, 0, 0, 0, 132, 129, 1, 0, 0,
-		0, 132, 130, 1, 0, 0, 0, 132, 131, 1, 0, 0, 0, 133, 33, 1, 0, 0, 0, 134,
-		135, 3, 32, 16, 0, 135, 136, 5, 0, 0, 1, 136, 35, 1, 0, 0, 0, 5, 86, 98,
-		117, 123, 132,
+		2, 16, 7, 16, 2, 17, 7, 17, 2, 18, 7, 18, 2, 19, 7, 19, 2, 20, 7, 20, 2,
+		21, 7, 21, 2, 22, 7, 22, 2, 23, 7, 23, 1, 0, 1, 0, 1, 0, 1, 0, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 2, 1, 2, 1, 2, 1, 2, 1, 3, 1, 3, 1, 3, 1, 3, 1, 4, 1,
+		4, 1, 4, 1, 4, 1, 5, 1, 5, 1, 5, 1, 5, 1, 6, 1, 6, 1, 6, 1, 6, 1, 7, 1,
+		7, 1, 7, 1, 7, 1, 8, 1, 8, 1, 8, 1, 8, 1, 9, 1, 9, 1, 9, 1, 9, 1, 10, 1,
+		10, 1, 10, 1, 10, 1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 1, 12, 1, 12,
+		1, 12, 1, 12, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1, 14, 1, 14, 1,
+		14, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 5, 15, 117, 8, 15, 10, 15, 12, 15,
+		120, 9, 15, 1, 15, 1, 15, 1, 16, 1, 16, 1, 16, 1, 16, 1, 16, 5, 16, 129,
+		8, 16, 10, 16, 12, 16, 132, 9, 16, 1, 16, 1, 16, 1, 17, 1, 17, 1, 17, 1,
+		17, 1, 17, 1, 18, 1, 18, 1, 18, 1, 18, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19,
+		1, 19, 3, 19, 151, 8, 19, 1, 20, 1, 20, 1, 20, 1, 20, 3, 20, 157, 8, 20,
+		1, 21, 1, 21, 1, 21, 1, 21, 3, 21, 163, 8, 21, 1, 22, 1, 22, 1, 22, 1,
+		22, 1, 22, 1, 22, 1, 22, 1, 22, 3, 22, 173, 8, 22, 1, 23, 1, 23, 1, 23,
+		1, 23, 0, 0, 24, 0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28,
+		30, 32, 34, 36, 38, 40, 42, 44, 46, 0, 3, 1, 0, 13, 14, 1, 0, 11, 12, 1,
+		0, 8, 9, 173, 0, 48, 1, 0, 0, 0, 2, 52, 1, 0, 0, 0, 4, 56, 1, 0, 0, 0,
+		6, 60, 1, 0, 0, 0, 8, 64, 1, 0, 0, 0, 10, 68, 1, 0, 0, 0, 12, 72, 1, 0,
+		0, 0, 14, 76, 1, 0, 0, 0, 16, 80, 1, 0, 0, 0, 18, 84, 1, 0, 0, 0, 20, 88,
+		1, 0, 0, 0, 22, 92, 1, 0, 0, 0, 24, 98, 1, 0, 0, 0, 26, 102, 1, 0, 0, 0,
+		28, 108, 1, 0, 0, 0, 30, 111, 1, 0, 0, 0, 32, 123, 1, 0, 0, 0, 34, 135,
+		1, 0, 0, 0, 36, 140, 1, 0, 0, 0, 38, 150, 1, 0, 0, 0, 40, 156, 1, 0, 0,
+		0, 42, 162, 1, 0, 0, 0, 44, 172, 1, 0, 0, 0, 46, 174, 1, 0, 0, 0, 48, 49,
+		5, 19, 0, 0, 49, 50, 5, 1, 0, 0, 50, 51, 7, 0, 0, 0, 51, 1, 1, 0, 0, 0,
+		52, 53, 5, 20, 0, 0, 53, 54, 5, 1, 0, 0, 54, 55, 7, 0, 0, 0, 55, 3, 1,
+		0, 0, 0, 56, 57, 5, 21, 0, 0, 57, 58, 5, 2, 0, 0, 58, 59, 7, 1, 0, 0, 59,
+		5, 1, 0, 0, 0, 60, 61, 5, 22, 0, 0, 61, 62, 5, 2, 0, 0, 62, 63, 7, 1, 0,
+		0, 63, 7, 1, 0, 0, 0, 64, 65, 5, 23, 0, 0, 65, 66, 5, 1, 0, 0, 66, 67,
+		5, 31, 0, 0, 67, 9, 1, 0, 0, 0, 68, 69, 5, 26, 0, 0, 69, 70, 5, 1, 0, 0,
+		70, 71, 5, 11, 0, 0, 71, 11, 1, 0, 0, 0, 72, 73, 5, 27, 0, 0, 73, 74, 5,
+		2, 0, 0, 74, 75, 7, 1, 0, 0, 75, 13, 1, 0, 0, 0, 76, 77, 5, 28, 0, 0, 77,
+		78, 5, 2, 0, 0, 78, 79, 7, 1, 0, 0, 79, 15, 1, 0, 0, 0, 80, 81, 5, 29,
+		0, 0, 81, 82, 5, 1, 0, 0, 82, 83, 5, 31, 0, 0, 83, 17, 1, 0, 0, 0, 84,
+		85, 5, 30, 0, 0, 85, 86, 5, 1, 0, 0, 86, 87, 5, 11, 0, 0, 87, 19, 1, 0,
+		0, 0, 88, 89, 5, 24, 0, 0, 89, 90, 5, 1, 0, 0, 90, 91, 5, 11, 0, 0, 91,
+		21, 1, 0, 0, 0, 92, 93, 5, 24, 0, 0, 93, 94, 5, 1, 0, 0, 94, 95, 5, 11,
+		0, 0, 95, 96, 5, 3, 0, 0, 96, 97, 5, 11, 0, 0, 97, 23, 1, 0, 0, 0, 98,
+		99, 5, 25, 0, 0, 99, 100, 5, 1, 0, 0, 100, 101, 5, 11, 0, 0, 101, 25, 1,
+		0, 0, 0, 102, 103, 5, 25, 0, 0, 103, 104, 5, 1, 0, 0, 104, 105, 5, 11,
+		0, 0, 105, 106, 5, 3, 0, 0, 106, 107, 5, 11, 0, 0, 107, 27, 1, 0, 0, 0,
+		108, 109, 5, 4, 0, 0, 109, 110, 5, 11, 0, 0, 110, 29, 1, 0, 0, 0, 111,
+		112, 5, 15, 0, 0, 112, 113, 5, 5, 0, 0, 113, 118, 3, 44, 22, 0, 114, 115,
+		5, 6, 0, 0, 115, 117, 3, 44, 22, 0, 116, 114, 1, 0, 0, 0, 117, 120, 1,
+		0, 0, 0, 118, 116, 1, 0, 0, 0, 118, 119, 1, 0, 0, 0, 119, 121, 1, 0, 0,
+		0, 120, 118, 1, 0, 0, 0, 121, 122, 5, 7, 0, 0, 122, 31, 1, 0, 0, 0, 123,
+		124, 5, 16, 0, 0, 124, 125, 5, 5, 0, 0, 125, 130, 3, 44, 22, 0, 126, 127,
+		5, 6, 0, 0, 127, 129, 3, 44, 22, 0, 128, 126, 1, 0, 0, 0, 129, 132, 1,
+		0, 0, 0, 130, 128, 1, 0, 0, 0, 130, 131, 1, 0, 0, 0, 131, 133, 1, 0, 0,
+		0, 132, 130, 1, 0, 0, 0, 133, 134, 5, 7, 0, 0, 134, 33, 1, 0, 0, 0, 135,
+		136, 5, 17, 0, 0, 136, 137, 5, 5, 0, 0, 137, 138, 3, 44, 22, 0, 138, 139,
+		5, 7, 0, 0, 139, 35, 1, 0, 0, 0, 140, 141, 5, 18, 0, 0, 141, 142, 5, 1,
+		0, 0, 142, 143, 7, 2, 0, 0, 143, 37, 1, 0, 0, 0, 144, 151, 3, 0, 0, 0,
+		145, 151, 3, 2, 1, 0, 146, 151, 3, 4, 2, 0, 147, 151, 3, 6, 3, 0, 148,
+		151, 3, 8, 4, 0, 149, 151, 3, 10, 5, 0, 150, 144, 1, 0, 0, 0, 150, 145,
+		1, 0, 0, 0, 150, 146, 1, 0, 0, 0, 150, 147, 1, 0, 0, 0, 150, 148, 1, 0,
+		0, 0, 150, 149, 1, 0, 0, 0, 151, 39, 1, 0, 0, 0, 152, 157, 3, 12, 6, 0,
+		153, 157, 3, 14, 7, 0, 154, 157, 3, 16, 8, 0, 155, 157, 3, 18, 9, 0, 156,
+		152, 1, 0, 0, 0, 156, 153, 1, 0, 0, 0, 156, 154, 1, 0, 0, 0, 156, 155,
+		1, 0, 0, 0, 157, 41, 1, 0, 0, 0, 158, 163, 3, 20, 10, 0, 159, 163, 3, 22,
+		11, 0, 160, 163, 3, 24, 12, 0, 161, 163, 3, 26, 13, 0, 162, 158, 1, 0,
+		0, 0, 162, 159, 1, 0, 0, 0, 162, 160, 1, 0, 0, 0, 162, 161, 1, 0, 0, 0,
+		163, 43, 1, 0, 0, 0, 164, 173, 3, 32, 16, 0, 165, 173, 3, 30, 15, 0, 166,
+		173, 3, 34, 17, 0, 167, 173, 3, 38, 19, 0, 168, 173, 3, 40, 20, 0, 169,
+		173, 3, 42, 21, 0, 170, 173, 3, 28, 14, 0, 171, 173, 3, 36, 18, 0, 172,
+		164, 1, 0, 0, 0, 172, 165, 1, 0, 0, 0, 172, 166, 1, 0, 0, 0, 172, 167,
+		1, 0, 0, 0, 172, 168, 1, 0, 0, 0, 172, 169, 1, 0, 0, 0, 172, 170, 1, 0,
+		0, 0, 172, 171, 1, 0, 0, 0, 173, 45, 1, 0, 0, 0, 174, 175, 3, 44, 22, 0,
+		175, 176, 5, 0, 0, 1, 176, 47, 1, 0, 0, 0, 6, 118, 130, 150, 156, 162,
+		172,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -154,18 +173,24 @@ const (
 	TrafficClassParserDIGITS     = 11
 	TrafficClassParserHEX_DIGITS = 12
 	TrafficClassParserNET        = 13
-	TrafficClassParserANY        = 14
-	TrafficClassParserALL        = 15
-	TrafficClassParserNOT        = 16
-	TrafficClassParserBOOL       = 17
-	TrafficClassParserSRC        = 18
-	TrafficClassParserDST        = 19
-	TrafficClassParserDSCP       = 20
-	TrafficClassParserTOS        = 21
-	TrafficClassParserPROTOCOL   = 22
-	TrafficClassParserSRCPORT    = 23
-	TrafficClassParserDSTPORT    = 24
-	TrafficClassParserSTRING     = 25
+	TrafficClassParserNET6       = 14
+	TrafficClassParserANY        = 15
+	TrafficClassParserALL        = 16
+	TrafficClassParserNOT        = 17
+	TrafficClassParserBOOL       = 18
+	TrafficClassParserSRC        = 19
+	TrafficClassParserDST        = 20
+	TrafficClassParserDSCP       = 21
+	TrafficClassParserTOS        = 22
+	TrafficClassParserPROTOCOL   = 23
+	TrafficClassParserSRCPORT    = 24
+	TrafficClassParserDSTPORT    = 25
+	TrafficClassParserICMPTYPE   = 26
+	TrafficClassParserTC         = 27
+	TrafficClassParserFLOWLABEL  = 28
+	TrafficClassParserNEXTHEADER = 29
+	TrafficClassParserICMP6TYPE  = 30
+	TrafficClassParserSTRING     = 31
 )
 
 // TrafficClassParser rules.
@@ -175,19 +200,25 @@ const (
 	TrafficClassParserRULE_matchDSCP         = 2
 	TrafficClassParserRULE_matchTOS          = 3
 	TrafficClassParserRULE_matchProtocol     = 4
-	TrafficClassParserRULE_matchSrcPort      = 5
-	TrafficClassParserRULE_matchSrcPortRange = 6
-	TrafficClassParserRULE_matchDstPort      = 7
-	TrafficClassParserRULE_matchDstPortRange = 8
-	TrafficClassParserRULE_condCls           = 9
-	TrafficClassParserRULE_condAny           = 10
-	TrafficClassParserRULE_condAll           = 11
-	TrafficClassParserRULE_condNot           = 12
-	TrafficClassParserRULE_condBool          = 13
-	TrafficClassParserRULE_condIPv4          = 14
-	TrafficClassParserRULE_condPort          = 15
-	TrafficClassParserRULE_cond              = 16
-	TrafficClassParserRULE_trafficClass      = 17
+	TrafficClassParserRULE_matchICMPType     = 5
+	TrafficClassParserRULE_matchTC           = 6
+	TrafficClassParserRULE_matchFlowLabel    = 7
+	TrafficClassParserRULE_matchNextHeader   = 8
+	TrafficClassParserRULE_matchICMP6Type    = 9
+	TrafficClassParserRULE_matchSrcPort      = 10
+	TrafficClassParserRULE_matchSrcPortRange = 11
+	TrafficClassParserRULE_matchDstPort      = 12
+	TrafficClassParserRULE_matchDstPortRange = 13
+	TrafficClassParserRULE_condCls           = 14
+	TrafficClassParserRULE_condAny           = 15
+	TrafficClassParserRULE_condAll           = 16
+	TrafficClassParserRULE_condNot           = 17
+	TrafficClassParserRULE_condBool          = 18
+	TrafficClassParserRULE_condIPv4          = 19
+	TrafficClassParserRULE_condIPv6          = 20
+	TrafficClassParserRULE_condPort          = 21
+	TrafficClassParserRULE_cond              = 22
+	TrafficClassParserRULE_trafficClass      = 23
 )
 
 // IMatchSrcContext is an interface to support dynamic dispatch.
@@ -200,6 +231,7 @@ type IMatchSrcContext interface {
 	// Getter signatures
 	SRC() antlr.TerminalNode
 	NET() antlr.TerminalNode
+	NET6() antlr.TerminalNode
 
 	// IsMatchSrcContext differentiates from other interfaces.
 	IsMatchSrcContext()
@@ -245,6 +277,10 @@ func (s *MatchSrcContext) NET() antlr.TerminalNode {
 	return s.GetToken(TrafficClassParserNET, 0)
 }
 
+func (s *MatchSrcContext) NET6() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserNET6, 0)
+}
+
 func (s *MatchSrcContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -268,9 +304,11 @@ func (s *MatchSrcContext) ExitRule(listener antlr.ParseTreeListener) {
 func (p *TrafficClassParser) MatchSrc() (localctx IMatchSrcContext) {
 	localctx = NewMatchSrcContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 0, TrafficClassParserRULE_matchSrc)
+	var _la int
+
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(36)
+		p.SetState(48)
 		p.Match(TrafficClassParserSRC)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -278,7 +316,7 @@ func (p *TrafficClassParser) MatchSrc() (localctx IMatchSrcContext) {
 		}
 	}
 	{
-		p.SetState(37)
+		p.SetState(49)
 		p.Match(TrafficClassParserT__0)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -286,11 +324,14 @@ func (p *TrafficClassParser) MatchSrc() (localctx IMatchSrcContext) {
 		}
 	}
 	{
-		p.SetState(38)
-		p.Match(TrafficClassParserNET)
-		if p.HasError() {
-			// Recognition error - abort rule
-			goto errorExit
+		p.SetState(50)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserNET || _la == TrafficClassParserNET6) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
 		}
 	}
 
@@ -317,6 +358,7 @@ type IMatchDstContext interface {
 	// Getter signatures
 	DST() antlr.TerminalNode
 	NET() antlr.TerminalNode
+	NET6() antlr.TerminalNode
 
 	// IsMatchDstContext differentiates from other interfaces.
 	IsMatchDstContext()
@@ -362,6 +404,10 @@ func (s *MatchDstContext) NET() antlr.TerminalNode {
 	return s.GetToken(TrafficClassParserNET, 0)
 }
 
+func (s *MatchDstContext) NET6() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserNET6, 0)
+}
+
 func (s *MatchDstContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -385,9 +431,11 @@ func (s *MatchDstContext) ExitRule(listener antlr.ParseTreeListener) {
 func (p *TrafficClassParser) MatchDst() (localctx IMatchDstContext) {
 	localctx = NewMatchDstContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 2, TrafficClassParserRULE_matchDst)
+	var _la int
+
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(40)
+		p.SetState(52)
 		p.Match(TrafficClassParserDST)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -395,7 +443,7 @@ func (p *TrafficClassParser) MatchDst() (localctx IMatchDstContext) {
 		}
 	}
 	{
-		p.SetState(41)
+		p.SetState(53)
 		p.Match(TrafficClassParserT__0)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -403,11 +451,14 @@ func (p *TrafficClassParser) MatchDst() (localctx IMatchDstContext) {
 		}
 	}
 	{
-		p.SetState(42)
-		p.Match(TrafficClassParserNET)
-		if p.HasError() {
-			// Recognition error - abort rule
-			goto errorExit
+		p.SetState(54)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserNET || _la == TrafficClassParserNET6) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
 		}
 	}
 
@@ -511,7 +562,7 @@ func (p *TrafficClassParser) MatchDSCP() (localctx IMatchDSCPContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(44)
+		p.SetState(56)
 		p.Match(TrafficClassParserDSCP)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -519,7 +570,7 @@ func (p *TrafficClassParser) MatchDSCP() (localctx IMatchDSCPContext) {
 		}
 	}
 	{
-		p.SetState(45)
+		p.SetState(57)
 		p.Match(TrafficClassParserT__1)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -527,7 +578,7 @@ func (p *TrafficClassParser) MatchDSCP() (localctx IMatchDSCPContext) {
 		}
 	}
 	{
-		p.SetState(46)
+		p.SetState(58)
 		_la = p.GetTokenStream().LA(1)
 
 		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
@@ -638,7 +689,7 @@ func (p *TrafficClassParser) MatchTOS() (localctx IMatchTOSContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(48)
+		p.SetState(60)
 		p.Match(TrafficClassParserTOS)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -646,7 +697,7 @@ func (p *TrafficClassParser) MatchTOS() (localctx IMatchTOSContext) {
 		}
 	}
 	{
-		p.SetState(49)
+		p.SetState(61)
 		p.Match(TrafficClassParserT__1)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -654,7 +705,7 @@ func (p *TrafficClassParser) MatchTOS() (localctx IMatchTOSContext) {
 		}
 	}
 	{
-		p.SetState(50)
+		p.SetState(62)
 		_la = p.GetTokenStream().LA(1)
 
 		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
@@ -693,80 +744,685 @@ type IMatchProtocolContext interface {
 	IsMatchProtocolContext()
 }
 
-type MatchProtocolContext struct {
+type MatchProtocolContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchProtocolContext() *MatchProtocolContext {
+	var p = new(MatchProtocolContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchProtocol
+	return p
+}
+
+func InitEmptyMatchProtocolContext(p *MatchProtocolContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchProtocol
+}
+
+func (*MatchProtocolContext) IsMatchProtocolContext() {}
+
+func NewMatchProtocolContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchProtocolContext {
+	var p = new(MatchProtocolContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchProtocol
+
+	return p
+}
+
+func (s *MatchProtocolContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchProtocolContext) PROTOCOL() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserPROTOCOL, 0)
+}
+
+func (s *MatchProtocolContext) STRING() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserSTRING, 0)
+}
+
+func (s *MatchProtocolContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchProtocolContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchProtocolContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchProtocol(s)
+	}
+}
+
+func (s *MatchProtocolContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchProtocol(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchProtocol() (localctx IMatchProtocolContext) {
+	localctx = NewMatchProtocolContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 8, TrafficClassParserRULE_matchProtocol)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(64)
+		p.Match(TrafficClassParserPROTOCOL)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(65)
+		p.Match(TrafficClassParserT__0)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(66)
+		p.Match(TrafficClassParserSTRING)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IMatchICMPTypeContext is an interface to support dynamic dispatch.
+type IMatchICMPTypeContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	ICMPTYPE() antlr.TerminalNode
+	DIGITS() antlr.TerminalNode
+
+	// IsMatchICMPTypeContext differentiates from other interfaces.
+	IsMatchICMPTypeContext()
+}
+
+type MatchICMPTypeContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchICMPTypeContext() *MatchICMPTypeContext {
+	var p = new(MatchICMPTypeContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchICMPType
+	return p
+}
+
+func InitEmptyMatchICMPTypeContext(p *MatchICMPTypeContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchICMPType
+}
+
+func (*MatchICMPTypeContext) IsMatchICMPTypeContext() {}
+
+func NewMatchICMPTypeContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchICMPTypeContext {
+	var p = new(MatchICMPTypeContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchICMPType
+
+	return p
+}
+
+func (s *MatchICMPTypeContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchICMPTypeContext) ICMPTYPE() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserICMPTYPE, 0)
+}
+
+func (s *MatchICMPTypeContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchICMPTypeContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchICMPTypeContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchICMPTypeContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchICMPType(s)
+	}
+}
+
+func (s *MatchICMPTypeContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchICMPType(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchICMPType() (localctx IMatchICMPTypeContext) {
+	localctx = NewMatchICMPTypeContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 10, TrafficClassParserRULE_matchICMPType)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(68)
+		p.Match(TrafficClassParserICMPTYPE)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(69)
+		p.Match(TrafficClassParserT__0)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(70)
+		p.Match(TrafficClassParserDIGITS)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IMatchTCContext is an interface to support dynamic dispatch.
+type IMatchTCContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	TC() antlr.TerminalNode
+	HEX_DIGITS() antlr.TerminalNode
+	DIGITS() antlr.TerminalNode
+
+	// IsMatchTCContext differentiates from other interfaces.
+	IsMatchTCContext()
+}
+
+type MatchTCContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchTCContext() *MatchTCContext {
+	var p = new(MatchTCContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchTC
+	return p
+}
+
+func InitEmptyMatchTCContext(p *MatchTCContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchTC
+}
+
+func (*MatchTCContext) IsMatchTCContext() {}
+
+func NewMatchTCContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchTCContext {
+	var p = new(MatchTCContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchTC
+
+	return p
+}
+
+func (s *MatchTCContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchTCContext) TC() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserTC, 0)
+}
+
+func (s *MatchTCContext) HEX_DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserHEX_DIGITS, 0)
+}
+
+func (s *MatchTCContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchTCContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchTCContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchTCContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchTC(s)
+	}
+}
+
+func (s *MatchTCContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchTC(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchTC() (localctx IMatchTCContext) {
+	localctx = NewMatchTCContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 12, TrafficClassParserRULE_matchTC)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(72)
+		p.Match(TrafficClassParserTC)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(73)
+		p.Match(TrafficClassParserT__1)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(74)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IMatchFlowLabelContext is an interface to support dynamic dispatch.
+type IMatchFlowLabelContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	FLOWLABEL() antlr.TerminalNode
+	HEX_DIGITS() antlr.TerminalNode
+	DIGITS() antlr.TerminalNode
+
+	// IsMatchFlowLabelContext differentiates from other interfaces.
+	IsMatchFlowLabelContext()
+}
+
+type MatchFlowLabelContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchFlowLabelContext() *MatchFlowLabelContext {
+	var p = new(MatchFlowLabelContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchFlowLabel
+	return p
+}
+
+func InitEmptyMatchFlowLabelContext(p *MatchFlowLabelContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchFlowLabel
+}
+
+func (*MatchFlowLabelContext) IsMatchFlowLabelContext() {}
+
+func NewMatchFlowLabelContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchFlowLabelContext {
+	var p = new(MatchFlowLabelContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchFlowLabel
+
+	return p
+}
+
+func (s *MatchFlowLabelContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchFlowLabelContext) FLOWLABEL() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserFLOWLABEL, 0)
+}
+
+func (s *MatchFlowLabelContext) HEX_DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserHEX_DIGITS, 0)
+}
+
+func (s *MatchFlowLabelContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchFlowLabelContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchFlowLabelContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchFlowLabelContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchFlowLabel(s)
+	}
+}
+
+func (s *MatchFlowLabelContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchFlowLabel(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchFlowLabel() (localctx IMatchFlowLabelContext) {
+	localctx = NewMatchFlowLabelContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 14, TrafficClassParserRULE_matchFlowLabel)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(76)
+		p.Match(TrafficClassParserFLOWLABEL)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(77)
+		p.Match(TrafficClassParserT__1)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(78)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IMatchNextHeaderContext is an interface to support dynamic dispatch.
+type IMatchNextHeaderContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	NEXTHEADER() antlr.TerminalNode
+	STRING() antlr.TerminalNode
+
+	// IsMatchNextHeaderContext differentiates from other interfaces.
+	IsMatchNextHeaderContext()
+}
+
+type MatchNextHeaderContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchNextHeaderContext() *MatchNextHeaderContext {
+	var p = new(MatchNextHeaderContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchNextHeader
+	return p
+}
+
+func InitEmptyMatchNextHeaderContext(p *MatchNextHeaderContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchNextHeader
+}
+
+func (*MatchNextHeaderContext) IsMatchNextHeaderContext() {}
+
+func NewMatchNextHeaderContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchNextHeaderContext {
+	var p = new(MatchNextHeaderContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchNextHeader
+
+	return p
+}
+
+func (s *MatchNextHeaderContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchNextHeaderContext) NEXTHEADER() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserNEXTHEADER, 0)
+}
+
+func (s *MatchNextHeaderContext) STRING() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserSTRING, 0)
+}
+
+func (s *MatchNextHeaderContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchNextHeaderContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchNextHeaderContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchNextHeader(s)
+	}
+}
+
+func (s *MatchNextHeaderContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchNextHeader(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchNextHeader() (localctx IMatchNextHeaderContext) {
+	localctx = NewMatchNextHeaderContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 16, TrafficClassParserRULE_matchNextHeader)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(80)
+		p.Match(TrafficClassParserNEXTHEADER)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(81)
+		p.Match(TrafficClassParserT__0)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(82)
+		p.Match(TrafficClassParserSTRING)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IMatchICMP6TypeContext is an interface to support dynamic dispatch.
+type IMatchICMP6TypeContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	ICMP6TYPE() antlr.TerminalNode
+	DIGITS() antlr.TerminalNode
+
+	// IsMatchICMP6TypeContext differentiates from other interfaces.
+	IsMatchICMP6TypeContext()
+}
+
+type MatchICMP6TypeContext struct {
 	antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyMatchProtocolContext() *MatchProtocolContext {
-	var p = new(MatchProtocolContext)
+func NewEmptyMatchICMP6TypeContext() *MatchICMP6TypeContext {
+	var p = new(MatchICMP6TypeContext)
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = TrafficClassParserRULE_matchProtocol
+	p.RuleIndex = TrafficClassParserRULE_matchICMP6Type
 	return p
 }
 
-func InitEmptyMatchProtocolContext(p *MatchProtocolContext) {
+func InitEmptyMatchICMP6TypeContext(p *MatchICMP6TypeContext) {
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = TrafficClassParserRULE_matchProtocol
+	p.RuleIndex = TrafficClassParserRULE_matchICMP6Type
 }
 
-func (*MatchProtocolContext) IsMatchProtocolContext() {}
+func (*MatchICMP6TypeContext) IsMatchICMP6TypeContext() {}
 
-func NewMatchProtocolContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchProtocolContext {
-	var p = new(MatchProtocolContext)
+func NewMatchICMP6TypeContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchICMP6TypeContext {
+	var p = new(MatchICMP6TypeContext)
 
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = TrafficClassParserRULE_matchProtocol
+	p.RuleIndex = TrafficClassParserRULE_matchICMP6Type
 
 	return p
 }
 
-func (s *MatchProtocolContext) GetParser() antlr.Parser { return s.parser }
+func (s *MatchICMP6TypeContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *MatchProtocolContext) PROTOCOL() antlr.TerminalNode {
-	return s.GetToken(TrafficClassParserPROTOCOL, 0)
+func (s *MatchICMP6TypeContext) ICMP6TYPE() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserICMP6TYPE, 0)
 }
 
-func (s *MatchProtocolContext) STRING() antlr.TerminalNode {
-	return s.GetToken(TrafficClassParserSTRING, 0)
+func (s *MatchICMP6TypeContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
 }
 
-func (s *MatchProtocolContext) GetRuleContext() antlr.RuleContext {
+func (s *MatchICMP6TypeContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *MatchProtocolContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *MatchICMP6TypeContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *MatchProtocolContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *MatchICMP6TypeContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.EnterMatchProtocol(s)
+		listenerT.EnterMatchICMP6Type(s)
 	}
 }
 
-func (s *MatchProtocolContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *MatchICMP6TypeContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.ExitMatchProtocol(s)
+		listenerT.ExitMatchICMP6Type(s)
 	}
 }
 
-func (p *TrafficClassParser) MatchProtocol() (localctx IMatchProtocolContext) {
-	localctx = NewMatchProtocolContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 8, TrafficClassParserRULE_matchProtocol)
+func (p *TrafficClassParser) MatchICMP6Type() (localctx IMatchICMP6TypeContext) {
+	localctx = NewMatchICMP6TypeContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 18, TrafficClassParserRULE_matchICMP6Type)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(52)
-		p.Match(TrafficClassParserPROTOCOL)
+		p.SetState(84)
+		p.Match(TrafficClassParserICMP6TYPE)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
 	{
-		p.SetState(53)
+		p.SetState(85)
 		p.Match(TrafficClassParserT__0)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -774,8 +1430,8 @@ func (p *TrafficClassParser) MatchProtocol() (localctx IMatchProtocolContext) {
 		}
 	}
 	{
-		p.SetState(54)
-		p.Match(TrafficClassParserSTRING)
+		p.SetState(86)
+		p.Match(TrafficClassParserDIGITS)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
@@ -872,10 +1528,10 @@ func (s *MatchSrcPortContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) MatchSrcPort() (localctx IMatchSrcPortContext) {
 	localctx = NewMatchSrcPortContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 10, TrafficClassParserRULE_matchSrcPort)
+	p.EnterRule(localctx, 20, TrafficClassParserRULE_matchSrcPort)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(56)
+		p.SetState(88)
 		p.Match(TrafficClassParserSRCPORT)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -883,7 +1539,7 @@ func (p *TrafficClassParser) MatchSrcPort() (localctx IMatchSrcPortContext) {
 		}
 	}
 	{
-		p.SetState(57)
+		p.SetState(89)
 		p.Match(TrafficClassParserT__0)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -891,7 +1547,7 @@ func (p *TrafficClassParser) MatchSrcPort() (localctx IMatchSrcPortContext) {
 		}
 	}
 	{
-		p.SetState(58)
+		p.SetState(90)
 		p.Match(TrafficClassParserDIGITS)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -994,10 +1650,10 @@ func (s *MatchSrcPortRangeContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) MatchSrcPortRange() (localctx IMatchSrcPortRangeContext) {
 	localctx = NewMatchSrcPortRangeContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 12, TrafficClassParserRULE_matchSrcPortRange)
+	p.EnterRule(localctx, 22, TrafficClassParserRULE_matchSrcPortRange)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(60)
+		p.SetState(92)
 		p.Match(TrafficClassParserSRCPORT)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1005,7 +1661,7 @@ func (p *TrafficClassParser) MatchSrcPortRange() (localctx IMatchSrcPortRangeCon
 		}
 	}
 	{
-		p.SetState(61)
+		p.SetState(93)
 		p.Match(TrafficClassParserT__0)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1013,7 +1669,7 @@ func (p *TrafficClassParser) MatchSrcPortRange() (localctx IMatchSrcPortRangeCon
 		}
 	}
 	{
-		p.SetState(62)
+		p.SetState(94)
 		p.Match(TrafficClassParserDIGITS)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1021,7 +1677,7 @@ func (p *TrafficClassParser) MatchSrcPortRange() (localctx IMatchSrcPortRangeCon
 		}
 	}
 	{
-		p.SetState(63)
+		p.SetState(95)
 		p.Match(TrafficClassParserT__2)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1029,7 +1685,7 @@ func (p *TrafficClassParser) MatchSrcPortRange() (localctx IMatchSrcPortRangeCon
 		}
 	}
 	{
-		p.SetState(64)
+		p.SetState(96)
 		p.Match(TrafficClassParserDIGITS)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1127,10 +1783,10 @@ func (s *MatchDstPortContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) MatchDstPort() (localctx IMatchDstPortContext) {
 	localctx = NewMatchDstPortContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 14, TrafficClassParserRULE_matchDstPort)
+	p.EnterRule(localctx, 24, TrafficClassParserRULE_matchDstPort)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(66)
+		p.SetState(98)
 		p.Match(TrafficClassParserDSTPORT)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1138,7 +1794,7 @@ func (p *TrafficClassParser) MatchDstPort() (localctx IMatchDstPortContext) {
 		}
 	}
 	{
-		p.SetState(67)
+		p.SetState(99)
 		p.Match(TrafficClassParserT__0)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1146,7 +1802,7 @@ func (p *TrafficClassParser) MatchDstPort() (localctx IMatchDstPortContext) {
 		}
 	}
 	{
-		p.SetState(68)
+		p.SetState(100)
 		p.Match(TrafficClassParserDIGITS)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1249,10 +1905,10 @@ func (s *MatchDstPortRangeContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) MatchDstPortRange() (localctx IMatchDstPortRangeContext) {
 	localctx = NewMatchDstPortRangeContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 16, TrafficClassParserRULE_matchDstPortRange)
+	p.EnterRule(localctx, 26, TrafficClassParserRULE_matchDstPortRange)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(70)
+		p.SetState(102)
 		p.Match(TrafficClassParserDSTPORT)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1260,7 +1916,7 @@ func (p *TrafficClassParser) MatchDstPortRange() (localctx IMatchDstPortRangeCon
 		}
 	}
 	{
-		p.SetState(71)
+		p.SetState(103)
 		p.Match(TrafficClassParserT__0)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1268,7 +1924,7 @@ func (p *TrafficClassParser) MatchDstPortRange() (localctx IMatchDstPortRangeCon
 		}
 	}
 	{
-		p.SetState(72)
+		p.SetState(104)
 		p.Match(TrafficClassParserDIGITS)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1276,7 +1932,7 @@ func (p *TrafficClassParser) MatchDstPortRange() (localctx IMatchDstPortRangeCon
 		}
 	}
 	{
-		p.SetState(73)
+		p.SetState(105)
 		p.Match(TrafficClassParserT__2)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1284,7 +1940,7 @@ func (p *TrafficClassParser) MatchDstPortRange() (localctx IMatchDstPortRangeCon
 		}
 	}
 	{
-		p.SetState(74)
+		p.SetState(106)
 		p.Match(TrafficClassParserDIGITS)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1377,10 +2033,10 @@ func (s *CondClsContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondCls() (localctx ICondClsContext) {
 	localctx = NewCondClsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 18, TrafficClassParserRULE_condCls)
+	p.EnterRule(localctx, 28, TrafficClassParserRULE_condCls)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(76)
+		p.SetState(108)
 		p.Match(TrafficClassParserT__3)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1388,7 +2044,7 @@ func (p *TrafficClassParser) CondCls() (localctx ICondClsContext) {
 		}
 	}
 	{
-		p.SetState(77)
+		p.SetState(109)
 		p.Match(TrafficClassParserDIGITS)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1524,12 +2180,12 @@ func (s *CondAnyContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondAny() (localctx ICondAnyContext) {
 	localctx = NewCondAnyContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 20, TrafficClassParserRULE_condAny)
+	p.EnterRule(localctx, 30, TrafficClassParserRULE_condAny)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(79)
+		p.SetState(111)
 		p.Match(TrafficClassParserANY)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1537,7 +2193,7 @@ func (p *TrafficClassParser) CondAny() (localctx ICondAnyContext) {
 		}
 	}
 	{
-		p.SetState(80)
+		p.SetState(112)
 		p.Match(TrafficClassParserT__4)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1545,10 +2201,10 @@ func (p *TrafficClassParser) CondAny() (localctx ICondAnyContext) {
 		}
 	}
 	{
-		p.SetState(81)
+		p.SetState(113)
 		p.Cond()
 	}
-	p.SetState(86)
+	p.SetState(118)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -1557,7 +2213,7 @@ func (p *TrafficClassParser) CondAny() (localctx ICondAnyContext) {
 
 	for _la == TrafficClassParserT__5 {
 		{
-			p.SetState(82)
+			p.SetState(114)
 			p.Match(TrafficClassParserT__5)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -1565,11 +2221,11 @@ func (p *TrafficClassParser) CondAny() (localctx ICondAnyContext) {
 			}
 		}
 		{
-			p.SetState(83)
+			p.SetState(115)
 			p.Cond()
 		}
 
-		p.SetState(88)
+		p.SetState(120)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -1577,7 +2233,7 @@ func (p *TrafficClassParser) CondAny() (localctx ICondAnyContext) {
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(89)
+		p.SetState(121)
 		p.Match(TrafficClassParserT__6)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1713,12 +2369,12 @@ func (s *CondAllContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondAll() (localctx ICondAllContext) {
 	localctx = NewCondAllContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 22, TrafficClassParserRULE_condAll)
+	p.EnterRule(localctx, 32, TrafficClassParserRULE_condAll)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(91)
+		p.SetState(123)
 		p.Match(TrafficClassParserALL)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1726,7 +2382,7 @@ func (p *TrafficClassParser) CondAll() (localctx ICondAllContext) {
 		}
 	}
 	{
-		p.SetState(92)
+		p.SetState(124)
 		p.Match(TrafficClassParserT__4)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1734,10 +2390,10 @@ func (p *TrafficClassParser) CondAll() (localctx ICondAllContext) {
 		}
 	}
 	{
-		p.SetState(93)
+		p.SetState(125)
 		p.Cond()
 	}
-	p.SetState(98)
+	p.SetState(130)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -1746,7 +2402,7 @@ func (p *TrafficClassParser) CondAll() (localctx ICondAllContext) {
 
 	for _la == TrafficClassParserT__5 {
 		{
-			p.SetState(94)
+			p.SetState(126)
 			p.Match(TrafficClassParserT__5)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -1754,11 +2410,11 @@ func (p *TrafficClassParser) CondAll() (localctx ICondAllContext) {
 			}
 		}
 		{
-			p.SetState(95)
+			p.SetState(127)
 			p.Cond()
 		}
 
-		p.SetState(100)
+		p.SetState(132)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -1766,7 +2422,7 @@ func (p *TrafficClassParser) CondAll() (localctx ICondAllContext) {
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(101)
+		p.SetState(133)
 		p.Match(TrafficClassParserT__6)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1876,10 +2532,10 @@ func (s *CondNotContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondNot() (localctx ICondNotContext) {
 	localctx = NewCondNotContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 24, TrafficClassParserRULE_condNot)
+	p.EnterRule(localctx, 34, TrafficClassParserRULE_condNot)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(103)
+		p.SetState(135)
 		p.Match(TrafficClassParserNOT)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1887,7 +2543,7 @@ func (p *TrafficClassParser) CondNot() (localctx ICondNotContext) {
 		}
 	}
 	{
-		p.SetState(104)
+		p.SetState(136)
 		p.Match(TrafficClassParserT__4)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1895,11 +2551,11 @@ func (p *TrafficClassParser) CondNot() (localctx ICondNotContext) {
 		}
 	}
 	{
-		p.SetState(105)
+		p.SetState(137)
 		p.Cond()
 	}
 	{
-		p.SetState(106)
+		p.SetState(138)
 		p.Match(TrafficClassParserT__6)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1992,12 +2648,12 @@ func (s *CondBoolContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondBool() (localctx ICondBoolContext) {
 	localctx = NewCondBoolContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 26, TrafficClassParserRULE_condBool)
+	p.EnterRule(localctx, 36, TrafficClassParserRULE_condBool)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(108)
+		p.SetState(140)
 		p.Match(TrafficClassParserBOOL)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -2005,7 +2661,7 @@ func (p *TrafficClassParser) CondBool() (localctx ICondBoolContext) {
 		}
 	}
 	{
-		p.SetState(109)
+		p.SetState(141)
 		p.Match(TrafficClassParserT__0)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -2013,7 +2669,7 @@ func (p *TrafficClassParser) CondBool() (localctx ICondBoolContext) {
 		}
 	}
 	{
-		p.SetState(110)
+		p.SetState(142)
 		_la = p.GetTokenStream().LA(1)
 
 		if !(_la == TrafficClassParserT__7 || _la == TrafficClassParserT__8) {
@@ -2050,6 +2706,7 @@ type ICondIPv4Context interface {
 	MatchDSCP() IMatchDSCPContext
 	MatchTOS() IMatchTOSContext
 	MatchProtocol() IMatchProtocolContext
+	MatchICMPType() IMatchICMPTypeContext
 
 	// IsCondIPv4Context differentiates from other interfaces.
 	IsCondIPv4Context()
@@ -2167,6 +2824,22 @@ func (s *CondIPv4Context) MatchProtocol() IMatchProtocolContext {
 	return t.(IMatchProtocolContext)
 }
 
+func (s *CondIPv4Context) MatchICMPType() IMatchICMPTypeContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IMatchICMPTypeContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchICMPTypeContext)
+}
+
 func (s *CondIPv4Context) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -2189,8 +2862,8 @@ func (s *CondIPv4Context) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondIPv4() (localctx ICondIPv4Context) {
 	localctx = NewCondIPv4Context(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 28, TrafficClassParserRULE_condIPv4)
-	p.SetState(117)
+	p.EnterRule(localctx, 38, TrafficClassParserRULE_condIPv4)
+	p.SetState(150)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -2200,38 +2873,234 @@ func (p *TrafficClassParser) CondIPv4() (localctx ICondIPv4Context) {
 	case TrafficClassParserSRC:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(112)
+			p.SetState(144)
 			p.MatchSrc()
 		}
 
 	case TrafficClassParserDST:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(113)
+			p.SetState(145)
 			p.MatchDst()
 		}
 
 	case TrafficClassParserDSCP:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(114)
+			p.SetState(146)
 			p.MatchDSCP()
 		}
 
 	case TrafficClassParserTOS:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(115)
+			p.SetState(147)
 			p.MatchTOS()
 		}
 
 	case TrafficClassParserPROTOCOL:
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(116)
+			p.SetState(148)
 			p.MatchProtocol()
 		}
 
+	case TrafficClassParserICMPTYPE:
+		p.EnterOuterAlt(localctx, 6)
+		{
+			p.SetState(149)
+			p.MatchICMPType()
+		}
+
+	default:
+		p.SetError(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		goto errorExit
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// ICondIPv6Context is an interface to support dynamic dispatch.
+type ICondIPv6Context interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	MatchTC() IMatchTCContext
+	MatchFlowLabel() IMatchFlowLabelContext
+	MatchNextHeader() IMatchNextHeaderContext
+	MatchICMP6Type() IMatchICMP6TypeContext
+
+	// IsCondIPv6Context differentiates from other interfaces.
+	IsCondIPv6Context()
+}
+
+type CondIPv6Context struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyCondIPv6Context() *CondIPv6Context {
+	var p = new(CondIPv6Context)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_condIPv6
+	return p
+}
+
+func InitEmptyCondIPv6Context(p *CondIPv6Context) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_condIPv6
+}
+
+func (*CondIPv6Context) IsCondIPv6Context() {}
+
+func NewCondIPv6Context(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CondIPv6Context {
+	var p = new(CondIPv6Context)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_condIPv6
+
+	return p
+}
+
+func (s *CondIPv6Context) GetParser() antlr.Parser { return s.parser }
+
+func (s *CondIPv6Context) MatchTC() IMatchTCContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IMatchTCContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchTCContext)
+}
+
+func (s *CondIPv6Context) MatchFlowLabel() IMatchFlowLabelContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IMatchFlowLabelContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchFlowLabelContext)
+}
+
+func (s *CondIPv6Context) MatchNextHeader() IMatchNextHeaderContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IMatchNextHeaderContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchNextHeaderContext)
+}
+
+func (s *CondIPv6Context) MatchICMP6Type() IMatchICMP6TypeContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IMatchICMP6TypeContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchICMP6TypeContext)
+}
+
+func (s *CondIPv6Context) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *CondIPv6Context) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *CondIPv6Context) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterCondIPv6(s)
+	}
+}
+
+func (s *CondIPv6Context) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitCondIPv6(s)
+	}
+}
+
+func (p *TrafficClassParser) CondIPv6() (localctx ICondIPv6Context) {
+	localctx = NewCondIPv6Context(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 40, TrafficClassParserRULE_condIPv6)
+	p.SetState(156)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+
+	switch p.GetTokenStream().LA(1) {
+	case TrafficClassParserTC:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(152)
+			p.MatchTC()
+		}
+
+	case TrafficClassParserFLOWLABEL:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(153)
+			p.MatchFlowLabel()
+		}
+
+	case TrafficClassParserNEXTHEADER:
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(154)
+			p.MatchNextHeader()
+		}
+
+	case TrafficClassParserICMP6TYPE:
+		p.EnterOuterAlt(localctx, 4)
+		{
+			p.SetState(155)
+			p.MatchICMP6Type()
+		}
+
 	default:
 		p.SetError(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 		goto errorExit
@@ -2385,39 +3254,39 @@ func (s *CondPortContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondPort() (localctx ICondPortContext) {
 	localctx = NewCondPortContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 30, TrafficClassParserRULE_condPort)
-	p.SetState(123)
+	p.EnterRule(localctx, 42, TrafficClassParserRULE_condPort)
+	p.SetState(162)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 
-	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 3, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 4, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(119)
+			p.SetState(158)
 			p.MatchSrcPort()
 		}
 
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(120)
+			p.SetState(159)
 			p.MatchSrcPortRange()
 		}
 
 	case 3:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(121)
+			p.SetState(160)
 			p.MatchDstPort()
 		}
 
 	case 4:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(122)
+			p.SetState(161)
 			p.MatchDstPortRange()
 		}
 
@@ -2450,6 +3319,7 @@ type ICondContext interface {
 	CondAny() ICondAnyContext
 	CondNot() ICondNotContext
 	CondIPv4() ICondIPv4Context
+	CondIPv6() ICondIPv6Context
 	CondPort() ICondPortContext
 	CondCls() ICondClsContext
 	CondBool() ICondBoolContext
@@ -2554,6 +3424,22 @@ func (s *CondContext) CondIPv4() ICondIPv4Context {
 	return t.(ICondIPv4Context)
 }
 
+func (s *CondContext) CondIPv6() ICondIPv6Context {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ICondIPv6Context); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ICondIPv6Context)
+}
+
 func (s *CondContext) CondPort() ICondPortContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
@@ -2624,8 +3510,8 @@ func (s *CondContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) Cond() (localctx ICondContext) {
 	localctx = NewCondContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 32, TrafficClassParserRULE_cond)
-	p.SetState(132)
+	p.EnterRule(localctx, 44, TrafficClassParserRULE_cond)
+	p.SetState(172)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -2635,49 +3521,56 @@ func (p *TrafficClassParser) Cond() (localctx ICondContext) {
 	case TrafficClassParserALL:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(125)
+			p.SetState(164)
 			p.CondAll()
 		}
 
 	case TrafficClassParserANY:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(126)
+			p.SetState(165)
 			p.CondAny()
 		}
 
 	case TrafficClassParserNOT:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(127)
+			p.SetState(166)
 			p.CondNot()
 		}
 
-	case TrafficClassParserSRC, TrafficClassParserDST, TrafficClassParserDSCP, TrafficClassParserTOS, TrafficClassParserPROTOCOL:
+	case TrafficClassParserSRC, TrafficClassParserDST, TrafficClassParserDSCP, TrafficClassParserTOS, TrafficClassParserPROTOCOL, TrafficClassParserICMPTYPE:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(128)
+			p.SetState(167)
 			p.CondIPv4()
 		}
 
-	case TrafficClassParserSRCPORT, TrafficClassParserDSTPORT:
+	case TrafficClassParserTC, TrafficClassParserFLOWLABEL, TrafficClassParserNEXTHEADER, TrafficClassParserICMP6TYPE:
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(129)
+			p.SetState(168)
+			p.CondIPv6()
+		}
+
+	case TrafficClassParserSRCPORT, TrafficClassParserDSTPORT:
+		p.EnterOuterAlt(localctx, 6)
+		{
+			p.SetState(169)
 			p.CondPort()
 		}
 
 	case TrafficClassParserT__3:
-		p.EnterOuterAlt(localctx, 6)
+		p.EnterOuterAlt(localctx, 7)
 		{
-			p.SetState(130)
+			p.SetState(170)
 			p.CondCls()
 		}
 
 	case TrafficClassParserBOOL:
-		p.EnterOuterAlt(localctx, 7)
+		p.EnterOuterAlt(localctx, 8)
 		{
-			p.SetState(131)
+			p.SetState(171)
 			p.CondBool()
 		}
 
@@ -2788,14 +3681,14 @@ func (s *TrafficClassContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) TrafficClass() (localctx ITrafficClassContext) {
 	localctx = NewTrafficClassContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 34, TrafficClassParserRULE_trafficClass)
+	p.EnterRule(localctx, 46, TrafficClassParserRULE_trafficClass)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(134)
+		p.SetState(174)
 		p.Cond()
 	}
 	{
-		p.SetState(135)
+		p.SetState(175)
 		p.Match(TrafficClassParserEOF)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -251,6 +251,9 @@ type Policy struct {
 	Type PolicyType `yaml:"Type"`
 	// RegistrationPolicies contains the registration policies for this policy.
 	RegistrationPolicies []RegistrationPolicy `yaml:"RegistrationPolicies"`
+	// SelectionAlgorithm is the name of the algorithm that selects the best beacons. If
+	// empty, the default algorithm of the beacon store is used.
+	SelectionAlgorithm string `yaml:"SelectionAlgorithm"`
 }
 
 // InitDefaults initializes the default values for unset fields.
@@ -272,6 +275,9 @@ func (p *Policy) InitDefaults() {
 }
 
 func (p *Policy) Validate() error {
+	if _, err := SelectionAlgorithmByName(p.SelectionAlgorithm); err != nil {
+		return err
+	}
 	// Check that the policy does not have duplicate registration policy names.
 	for i := range len(p.RegistrationPolicies) {
 		for j := i + 1; j < len(p.RegistrationPolicies); j++ {
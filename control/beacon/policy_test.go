@@ -39,6 +39,7 @@ func TestLoadPolicyFromYaml(t *testing.T) {
 	tests := map[string]struct {
 		File         string
 		Type         beacon.PolicyType
+		Algorithm    string
 		ErrAssertion assert.ErrorAssertionFunc
 	}{
 		"policy with matching type": {
@@ -54,6 +55,7 @@ func TestLoadPolicyFromYaml(t *testing.T) {
 		"policy without type": {
 			File:         "testdata/policy.yml",
 			Type:         beacon.PropPolicy,
+			Algorithm:    beacon.SelectionLatency,
 			ErrAssertion: assert.NoError,
 		},
 	}
@@ -68,6 +70,7 @@ func TestLoadPolicyFromYaml(t *testing.T) {
 			assert.Equal(t, 20, p.CandidateSetSize)
 			assert.Equal(t, test.Type, p.Type)
 			assert.Equal(t, uint8(42), *p.MaxExpTime)
+			assert.Equal(t, test.Algorithm, p.SelectionAlgorithm)
 			assert.Equal(t, 8, p.Filter.MaxHopsLength)
 			assert.Equal(t, []addr.AS{ia110.AS(), ia111.AS()}, p.Filter.AsBlackList)
 			assert.Equal(t, []addr.ISD{1, 2, 3}, p.Filter.IsdBlackList)
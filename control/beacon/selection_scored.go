@@ -0,0 +1,273 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/segment/extensions/staticinfo"
+	"github.com/scionproto/scion/pkg/segment/iface"
+)
+
+// Names of the selection algorithms that can be chosen in a policy.
+const (
+	// SelectionShortest selects the shortest beacons, and one diverse beacon. This is the
+	// default algorithm.
+	SelectionShortest = "Shortest"
+	// SelectionLatency prefers the beacons with the lowest accumulated latency.
+	SelectionLatency = "Latency"
+	// SelectionBandwidth prefers the beacons with the highest bottleneck bandwidth.
+	SelectionBandwidth = "Bandwidth"
+	// SelectionGeoDiverse prefers the beacons that are geographically far from the beacons
+	// already selected.
+	SelectionGeoDiverse = "GeoDiverse"
+	// SelectionDiverse prefers the beacons that share few links with the beacons already
+	// selected.
+	SelectionDiverse = "Diverse"
+)
+
+var scoredAlgos = map[string]scoredAlgo{
+	SelectionLatency:    {hops: 0.25, latency: 1, links: 0.5},
+	SelectionBandwidth:  {hops: 0.25, bandwidth: 1, links: 0.5},
+	SelectionGeoDiverse: {hops: 0.5, geo: 1, links: 0.25},
+	SelectionDiverse:    {hops: 0.5, links: 1},
+}
+
+// SelectionAlgorithmByName returns the selection algorithm with the given name. The empty name
+// refers to the default algorithm.
+func SelectionAlgorithmByName(name string) (SelectionAlgorithm, error) {
+	if name == "" || name == SelectionShortest {
+		return DefaultSelectionAlgorithm(), nil
+	}
+	if algo, ok := scoredAlgos[name]; ok {
+		return algo, nil
+	}
+	return nil, serrors.New("unknown selection algorithm", "name", name)
+}
+
+// scoredAlgo selects the beacons one after the other. Each time, the beacon with the highest
+// weighted score is selected. The score combines the metrics of the beacon, which are
+// normalized over the candidates, and its diversity to the beacons that are already selected.
+// The static info extension is used for the latency, the bandwidth and the geographic
+// location. Beacons without this information get the lowest score for the metric.
+type scoredAlgo struct {
+	hops      float64
+	latency   float64
+	bandwidth float64
+	// links and geo weigh the diversity to the beacons that are already selected.
+	links float64
+	geo   float64
+}
+
+// candidate is a beacon with its metrics.
+type candidate struct {
+	beacon Beacon
+	hops   int
+	// latency is only valid if it is known for all the hops.
+	latency      time.Duration
+	latencyKnown bool
+	// bandwidth is the bottleneck of the known hops in Kbit/s, or 0 if none is known.
+	bandwidth uint64
+	locations []staticinfo.GeoCoordinates
+	// score is the normalized and weighted score of the metrics.
+	score float64
+}
+
+func (a scoredAlgo) SelectBeacons(_ context.Context, beacons []Beacon, resultSize int) []Beacon {
+	if len(beacons) <= resultSize {
+		return beacons
+	}
+	candidates := make([]*candidate, 0, len(beacons))
+	for _, b := range beacons {
+		candidates = append(candidates, newCandidate(b))
+	}
+	a.score(candidates)
+
+	result := make([]Beacon, 0, resultSize)
+	var selected []*candidate
+	geoSpread := make([]float64, len(candidates))
+	for len(result) < resultSize {
+		var maxSpread float64
+		if a.geo != 0 {
+			for i, c := range candidates {
+				if c != nil {
+					geoSpread[i] = c.geoSpread(selected)
+					maxSpread = max(maxSpread, geoSpread[i])
+				}
+			}
+		}
+		best, bestScore := -1, math.Inf(-1)
+		for i, c := range candidates {
+			if c == nil {
+				continue
+			}
+			s := c.score + a.links*c.linkDiversity(selected)
+			if maxSpread > 0 {
+				s += a.geo * geoSpread[i] / maxSpread
+			}
+			if s > bestScore {
+				best, bestScore = i, s
+			}
+		}
+		selected = append(selected, candidates[best])
+		result = append(result, candidates[best].beacon)
+		candidates[best] = nil
+	}
+	return result
+}
+
+// score sets the score of the metrics of the candidates.
+func (a scoredAlgo) score(candidates []*candidate) {
+	minHops, maxHops := math.MaxInt, 0
+	minLatency, maxLatency := time.Duration(math.MaxInt64), time.Duration(0)
+	var maxBandwidth uint64
+	for _, c := range candidates {
+		minHops, maxHops = min(minHops, c.hops), max(maxHops, c.hops)
+		if c.latencyKnown {
+			minLatency, maxLatency = min(minLatency, c.latency), max(maxLatency, c.latency)
+		}
+		maxBandwidth = max(maxBandwidth, c.bandwidth)
+	}
+	for _, c := range candidates {
+		c.score = a.hops * lowerIsBetter(float64(c.hops), float64(minHops), float64(maxHops))
+		if c.latencyKnown {
+			c.score += a.latency * lowerIsBetter(
+				float64(c.latency), float64(minLatency), float64(maxLatency))
+		}
+		if maxBandwidth > 0 {
+			c.score += a.bandwidth * float64(c.bandwidth) / float64(maxBandwidth)
+		}
+	}
+}
+
+// lowerIsBetter maps v in [lo, hi] to [0, 1], where lo is 1.
+func lowerIsBetter(v, lo, hi float64) float64 {
+	if hi <= lo {
+		return 1
+	}
+	return (hi - v) / (hi - lo)
+}
+
+func newCandidate(b Beacon) *candidate {
+	c := &candidate{
+		beacon:       b,
+		hops:         len(b.Segment.ASEntries),
+		latencyKnown: true,
+	}
+	for _, entry := range b.Segment.ASEntries {
+		info := entry.Extensions.StaticInfo
+		if info == nil {
+			c.latencyKnown = false
+			continue
+		}
+		ingress := iface.ID(entry.HopEntry.HopField.ConsIngress)
+		egress := iface.ID(entry.HopEntry.HopField.ConsEgress)
+		// The intra-AS values are given from the egress to the other interfaces, the inter-AS
+		// values for the link of the egress interface.
+		if ingress != 0 {
+			c.addLatency(info.Latency.Intra, ingress)
+			c.addBandwidth(info.Bandwidth.Intra, ingress)
+		}
+		if egress != 0 {
+			c.addLatency(info.Latency.Inter, egress)
+			c.addBandwidth(info.Bandwidth.Inter, egress)
+		}
+		for _, id := range []iface.ID{ingress, egress} {
+			if loc, ok := info.Geo[id]; ok && id != 0 {
+				c.locations = append(c.locations, loc)
+			}
+		}
+	}
+	if !c.latencyKnown {
+		c.latency = 0
+	}
+	return c
+}
+
+func (c *candidate) addLatency(latencies map[iface.ID]time.Duration, id iface.ID) {
+	l, ok := latencies[id]
+	if !ok {
+		c.latencyKnown = false
+		return
+	}
+	c.latency += l
+}
+
+func (c *candidate) addBandwidth(bandwidths map[iface.ID]uint64, id iface.ID) {
+	bw, ok := bandwidths[id]
+	if !ok || bw == 0 {
+		return
+	}
+	if c.bandwidth == 0 || bw < c.bandwidth {
+		c.bandwidth = bw
+	}
+}
+
+// linkDiversity is the fraction of the links of the candidate that are not in the most similar
+// selected beacon. It is 1 if no beacon is selected.
+func (c *candidate) linkDiversity(selected []*candidate) float64 {
+	if c.hops == 0 {
+		return 0
+	}
+	diversity := 1.0
+	for _, s := range selected {
+		diversity = min(diversity, float64(c.beacon.Diversity(s.beacon))/float64(c.hops))
+	}
+	return diversity
+}
+
+// geoSpread is the geographic distance in km of the candidate to the closest selected beacon.
+// The distance to a beacon is the average distance of the locations of the candidate to the
+// closest location of the beacon. It is 0 if no beacon is selected, or the locations are
+// unknown.
+func (c *candidate) geoSpread(selected []*candidate) float64 {
+	if len(selected) == 0 || len(c.locations) == 0 {
+		return 0
+	}
+	spread := math.Inf(1)
+	for _, s := range selected {
+		if len(s.locations) == 0 {
+			continue
+		}
+		var sum float64
+		for _, p := range c.locations {
+			closest := math.Inf(1)
+			for _, q := range s.locations {
+				closest = min(closest, geoDistance(p, q))
+			}
+			sum += closest
+		}
+		spread = min(spread, sum/float64(len(c.locations)))
+	}
+	if math.IsInf(spread, 1) {
+		return 0
+	}
+	return spread
+}
+
+// geoDistance is the great-circle distance in km.
+func geoDistance(a, b staticinfo.GeoCoordinates) float64 {
+	const earthRadius = 6371.0
+	lat1 := float64(a.Latitude) * math.Pi / 180
+	lat2 := float64(b.Latitude) * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := float64(b.Longitude-a.Longitude) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
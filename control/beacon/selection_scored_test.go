@@ -0,0 +1,248 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/control/beacon/mock_beacon"
+	"github.com/scionproto/scion/pkg/addr"
+	seg "github.com/scionproto/scion/pkg/segment"
+	"github.com/scionproto/scion/pkg/segment/extensions/staticinfo"
+	"github.com/scionproto/scion/pkg/segment/iface"
+)
+
+// staticHop is an AS entry of a test beacon. The latency and the bandwidth are those of the
+// link of the egress interface.
+type staticHop struct {
+	ia        string
+	in, out   uint16
+	latency   time.Duration
+	bandwidth uint64
+	loc       *staticinfo.GeoCoordinates
+}
+
+func staticBeacon(hops ...staticHop) beacon.Beacon {
+	var entries []seg.ASEntry
+	for _, h := range hops {
+		entry := seg.ASEntry{
+			Local: addr.MustParseIA(h.ia),
+			HopEntry: seg.HopEntry{
+				HopField: seg.HopField{ConsIngress: h.in, ConsEgress: h.out},
+			},
+		}
+		if h.latency != 0 || h.bandwidth != 0 || h.loc != nil {
+			info := &staticinfo.Extension{
+				Latency: staticinfo.LatencyInfo{
+					Intra: map[iface.ID]time.Duration{},
+					Inter: map[iface.ID]time.Duration{},
+				},
+				Bandwidth: staticinfo.BandwidthInfo{
+					Intra: map[iface.ID]uint64{},
+					Inter: map[iface.ID]uint64{},
+				},
+				Geo: staticinfo.GeoInfo{},
+			}
+			if h.latency != 0 {
+				info.Latency.Inter[iface.ID(h.out)] = h.latency
+				if h.in != 0 {
+					info.Latency.Intra[iface.ID(h.in)] = 0
+				}
+			}
+			if h.bandwidth != 0 {
+				info.Bandwidth.Inter[iface.ID(h.out)] = h.bandwidth
+			}
+			if h.loc != nil {
+				info.Geo[iface.ID(h.out)] = *h.loc
+				if h.in != 0 {
+					info.Geo[iface.ID(h.in)] = *h.loc
+				}
+			}
+			entry.Extensions.StaticInfo = info
+		}
+		entries = append(entries, entry)
+	}
+	last := hops[len(hops)-1]
+	return beacon.Beacon{
+		Segment: &seg.PathSegment{ASEntries: entries},
+		InIfID:  last.out,
+	}
+}
+
+func TestSelectionAlgorithmByName(t *testing.T) {
+	for _, name := range []string{
+		"",
+		beacon.SelectionShortest,
+		beacon.SelectionLatency,
+		beacon.SelectionBandwidth,
+		beacon.SelectionGeoDiverse,
+		beacon.SelectionDiverse,
+	} {
+		algo, err := beacon.SelectionAlgorithmByName(name)
+		assert.NoError(t, err, name)
+		assert.NotNil(t, algo, name)
+	}
+	_, err := beacon.SelectionAlgorithmByName("Fastest")
+	assert.Error(t, err)
+}
+
+func TestScoredSelection(t *testing.T) {
+	zurich := &staticinfo.GeoCoordinates{Latitude: 47.37, Longitude: 8.54}
+	bern := &staticinfo.GeoCoordinates{Latitude: 46.95, Longitude: 7.45}
+	newYork := &staticinfo.GeoCoordinates{Latitude: 40.71, Longitude: -74.01}
+
+	// short is the shortest beacon, but it is slow and has little bandwidth.
+	short := staticBeacon(
+		staticHop{ia: "1-ff00:0:110", out: 1, latency: 25 * time.Millisecond, bandwidth: 100,
+			loc: zurich},
+		staticHop{ia: "1-ff00:0:120", in: 2, out: 3, latency: 25 * time.Millisecond,
+			bandwidth: 100, loc: zurich},
+	)
+	// fast has the lowest latency.
+	fast := staticBeacon(
+		staticHop{ia: "1-ff00:0:110", out: 4, latency: 3 * time.Millisecond, bandwidth: 1000,
+			loc: zurich},
+		staticHop{ia: "1-ff00:0:130", in: 5, out: 6, latency: 4 * time.Millisecond,
+			bandwidth: 500, loc: bern},
+		staticHop{ia: "1-ff00:0:140", in: 7, out: 8, latency: 3 * time.Millisecond,
+			bandwidth: 1000, loc: bern},
+	)
+	// wide shares the first link with fast, and has the highest bandwidth.
+	wide := staticBeacon(
+		staticHop{ia: "1-ff00:0:110", out: 4, latency: 3 * time.Millisecond, bandwidth: 1000,
+			loc: zurich},
+		staticHop{ia: "1-ff00:0:130", in: 5, out: 9, latency: 5 * time.Millisecond,
+			bandwidth: 900, loc: bern},
+		staticHop{ia: "1-ff00:0:150", in: 10, out: 11, latency: 4 * time.Millisecond,
+			bandwidth: 900, loc: bern},
+	)
+	// far has only geographic information, and crosses the ocean.
+	far := staticBeacon(
+		staticHop{ia: "1-ff00:0:110", out: 12, loc: zurich},
+		staticHop{ia: "1-ff00:0:160", in: 13, out: 14, loc: newYork},
+		staticHop{ia: "1-ff00:0:170", in: 15, out: 16, loc: newYork},
+	)
+	beacons := []beacon.Beacon{short, fast, wide, far}
+
+	testCases := map[string]struct {
+		algo     string
+		size     int
+		expected []beacon.Beacon
+	}{
+		"shortest": {
+			algo:     beacon.SelectionShortest,
+			size:     2,
+			expected: []beacon.Beacon{short, fast},
+		},
+		"latency": {
+			algo:     beacon.SelectionLatency,
+			size:     2,
+			expected: []beacon.Beacon{fast, wide},
+		},
+		"bandwidth": {
+			algo:     beacon.SelectionBandwidth,
+			size:     2,
+			expected: []beacon.Beacon{wide, fast},
+		},
+		"geo diverse": {
+			algo:     beacon.SelectionGeoDiverse,
+			size:     2,
+			expected: []beacon.Beacon{short, far},
+		},
+		"diverse": {
+			algo: beacon.SelectionDiverse,
+			size: 3,
+			// wide shares a link with fast, far does not share any link.
+			expected: []beacon.Beacon{short, fast, far},
+		},
+		"all": {
+			algo:     beacon.SelectionLatency,
+			size:     4,
+			expected: beacons,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			algo, err := beacon.SelectionAlgorithmByName(tc.algo)
+			require.NoError(t, err)
+			selected := algo.SelectBeacons(context.Background(), beacons, tc.size)
+			assert.Equal(t, tc.expected, selected)
+		})
+	}
+}
+
+func TestStorePolicySelectionAlgorithm(t *testing.T) {
+	short := staticBeacon(
+		staticHop{ia: "1-ff00:0:110", out: 1, latency: 25 * time.Millisecond},
+		staticHop{ia: "1-ff00:0:120", in: 2, out: 3, latency: 25 * time.Millisecond},
+	)
+	fast := staticBeacon(
+		staticHop{ia: "1-ff00:0:110", out: 4, latency: 3 * time.Millisecond},
+		staticHop{ia: "1-ff00:0:130", in: 5, out: 6, latency: 4 * time.Millisecond},
+		staticHop{ia: "1-ff00:0:140", in: 7, out: 8, latency: 3 * time.Millisecond},
+	)
+	slow := staticBeacon(
+		staticHop{ia: "1-ff00:0:110", out: 9, latency: 30 * time.Millisecond},
+		staticHop{ia: "1-ff00:0:150", in: 10, out: 11, latency: 30 * time.Millisecond},
+		staticHop{ia: "1-ff00:0:160", in: 12, out: 13, latency: 30 * time.Millisecond},
+	)
+	beacons := []beacon.Beacon{short, fast, slow}
+
+	t.Run("per policy", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		db := mock_beacon.NewMockDB(mctrl)
+		db.EXPECT().CandidateBeacons(gomock.Any(), gomock.Any(), gomock.Any(), addr.IA(0)).
+			Return(beacons, nil).Times(2)
+		var wrapped int
+		policies := beacon.Policies{
+			Prop:    beacon.Policy{BestSetSize: 2, SelectionAlgorithm: beacon.SelectionLatency},
+			UpReg:   beacon.Policy{BestSetSize: 2},
+			DownReg: beacon.Policy{BestSetSize: 2},
+		}
+		store, err := beacon.NewBeaconStore(policies, db,
+			beacon.WithSelectionAlgorithmWrapper(
+				func(algo beacon.SelectionAlgorithm) beacon.SelectionAlgorithm {
+					wrapped++
+					return algo
+				},
+			),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, 1, wrapped)
+
+		selected, err := store.BeaconsToPropagate(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []beacon.Beacon{fast, short}, selected)
+
+		registered, err := store.SegmentsToRegister(context.Background(), seg.TypeUp)
+		require.NoError(t, err)
+		assert.Equal(t, []beacon.Beacon{short, fast}, registered[beacon.DefaultGroup])
+	})
+	t.Run("unknown", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		policies := beacon.Policies{
+			Prop: beacon.Policy{SelectionAlgorithm: "Fastest"},
+		}
+		_, err := beacon.NewBeaconStore(policies, mock_beacon.NewMockDB(mctrl))
+		assert.Error(t, err)
+	})
+}
@@ -29,6 +29,7 @@ type usager interface {
 
 type storeOptions struct {
 	selectionAlgo SelectionAlgorithm
+	wrapAlgo      func(SelectionAlgorithm) SelectionAlgorithm
 }
 
 type StoreOption interface {
@@ -49,6 +50,14 @@ func WithSelectionAlgorithm(algo SelectionAlgorithm) StoreOption {
 	})
 }
 
+// WithSelectionAlgorithmWrapper sets a function that wraps the selection algorithms that the
+// policies choose by name. This allows, for example, to filter the beacons before the selection.
+func WithSelectionAlgorithmWrapper(wrap func(SelectionAlgorithm) SelectionAlgorithm) StoreOption {
+	return applyFunc(func(o *storeOptions) {
+		o.wrapAlgo = wrap
+	})
+}
+
 func applyStoreOptions(opts []StoreOption) storeOptions {
 	var o storeOptions
 	for _, f := range opts {
@@ -111,10 +120,15 @@ func NewBeaconStore(policies Policies, db DB, opts ...StoreOption) (*Store, erro
 		return nil, err
 	}
 	o := applyStoreOptions(opts)
+	algos, err := policyAlgos(o, &policies.Prop, &policies.UpReg, &policies.DownReg)
+	if err != nil {
+		return nil, err
+	}
 	s := &Store{
 		baseStore: baseStore{
-			db:   db,
-			algo: selectAlgo(o),
+			db:          db,
+			algo:        selectAlgo(o),
+			policyAlgos: algos,
 		},
 		policies: policies,
 	}
@@ -159,7 +173,7 @@ func (s *Store) getBeacons(ctx context.Context, policy *Policy) ([]Beacon, error
 	if err != nil {
 		return nil, err
 	}
-	return s.algo.SelectBeacons(ctx, beacons, policy.BestSetSize), nil
+	return s.algoFor(policy).SelectBeacons(ctx, beacons, policy.BestSetSize), nil
 }
 
 // MaxExpTime returns the segment maximum expiration time for the given policy.
@@ -191,10 +205,15 @@ func NewCoreBeaconStore(policies CorePolicies, db DB, opts ...StoreOption) (*Cor
 		return nil, err
 	}
 	o := applyStoreOptions(opts)
+	algos, err := policyAlgos(o, &policies.Prop, &policies.CoreReg)
+	if err != nil {
+		return nil, err
+	}
 	s := &CoreStore{
 		baseStore: baseStore{
-			db:   db,
-			algo: selectAlgo(o),
+			db:          db,
+			algo:        selectAlgo(o),
+			policyAlgos: algos,
 		},
 		policies: policies,
 	}
@@ -231,6 +250,7 @@ func (s *CoreStore) getBeacons(ctx context.Context, policy *Policy) ([]Beacon, e
 	if err != nil {
 		return nil, err
 	}
+	algo := s.algoFor(policy)
 	var beacons []Beacon
 	for _, src := range srcs {
 		candidateBeacons, err := s.db.CandidateBeacons(ctx, policy.CandidateSetSize,
@@ -240,7 +260,7 @@ func (s *CoreStore) getBeacons(ctx context.Context, policy *Policy) ([]Beacon, e
 			log.FromCtx(ctx).Error("Error getting candidate beacons", "src", src, "err", err)
 			continue
 		}
-		selBeacons := s.algo.SelectBeacons(ctx, candidateBeacons, policy.BestSetSize)
+		selBeacons := algo.SelectBeacons(ctx, candidateBeacons, policy.BestSetSize)
 		beacons = append(beacons, selBeacons...)
 	}
 	return beacons, nil
@@ -262,6 +282,16 @@ type baseStore struct {
 	db     DB
 	usager usager
 	algo   SelectionAlgorithm
+	// policyAlgos are the selection algorithms that the policies choose by name.
+	policyAlgos map[PolicyType]SelectionAlgorithm
+}
+
+// algoFor returns the selection algorithm for the policy.
+func (s *baseStore) algoFor(policy *Policy) SelectionAlgorithm {
+	if algo, ok := s.policyAlgos[policy.Type]; ok {
+		return algo
+	}
+	return s.algo
 }
 
 // PreFilter indicates whether the beacon will be filtered on insert by
@@ -297,3 +327,22 @@ func selectAlgo(o storeOptions) SelectionAlgorithm {
 	}
 	return algo
 }
+
+// policyAlgos returns the selection algorithms that the policies choose by name.
+func policyAlgos(o storeOptions, policies ...*Policy) (map[PolicyType]SelectionAlgorithm, error) {
+	algos := make(map[PolicyType]SelectionAlgorithm)
+	for _, policy := range policies {
+		if policy.SelectionAlgorithm == "" {
+			continue
+		}
+		algo, err := SelectionAlgorithmByName(policy.SelectionAlgorithm)
+		if err != nil {
+			return nil, serrors.Wrap("invalid policy", err, "type", policy.Type)
+		}
+		if o.wrapAlgo != nil {
+			algo = o.wrapAlgo(algo)
+		}
+		algos[policy.Type] = algo
+	}
+	return algos, nil
+}
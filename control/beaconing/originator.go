@@ -67,6 +67,8 @@ type Originator struct {
 	Signer                seg.Signer
 	AllInterfaces         *ifstate.Interfaces
 	OriginationInterfaces func() []*ifstate.Interface
+	// Rules restrict the egress interfaces that beacons are originated on.
+	Rules Rules
 
 	Originated metrics.Counter
 
@@ -130,13 +132,32 @@ func (o *Originator) originateBeacons(ctx context.Context) {
 }
 
 // needBeacon returns a list of interfaces that need a beacon.
+//
+// Without rules, all active interfaces need a beacon when the period of the
+// tick has passed. Between two periods, only the interfaces on which no beacon
+// was originated for longer than the period need one, e.g., interfaces that
+// just came up.
+//
+// With rules, the interfaces are checked one by one:
+//   - Interfaces on which the rules do not allow beacons originated by the
+//     local AS, see Rule.AllowedOrigins, never need a beacon.
+//   - Interfaces with an interval in the rules, see Rule.Interval, need a
+//     beacon once that interval has passed since the last beacon was
+//     originated on them, independently of the period of the tick.
+//   - All other interfaces need a beacon as without rules.
+//
+// The other restrictions of the rules only apply to propagated beacons.
 func (o *Originator) needBeacon(active []*ifstate.Interface) []*ifstate.Interface {
-	if o.Tick.Passed() {
+	if o.Tick.Passed() && len(o.Rules) == 0 {
 		return active
 	}
 	var stale []*ifstate.Interface
 	for _, intf := range active {
-		if o.Tick.Overdue(intf.LastOriginate()) {
+		topoInfo := intf.TopoInfo()
+		if !o.Rules.AllowOrigin(topoInfo, o.IA) {
+			continue
+		}
+		if o.Rules.due(&o.Tick, topoInfo, intf.LastOriginate()) {
 			stale = append(stale, intf)
 		}
 	}
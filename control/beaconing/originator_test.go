@@ -126,6 +126,104 @@ func TestOriginatorRun(t *testing.T) {
 		// The second run should not cause any beacons to originate.
 		o.Run(context.Background())
 	})
+	t.Run("rules skip interfaces that do not allow the local AS", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		intfs := ifstate.NewInterfaces(interfaceInfos(topo), ifstate.Config{})
+		senderFactory := mock_beaconing.NewMockSenderFactory(mctrl)
+		sender := mock_beaconing.NewMockSender(mctrl)
+		o := beaconing.Originator{
+			Extender: &beaconing.DefaultExtender{
+				IA:                   topo.IA(),
+				MTU:                  topo.MTU(),
+				SignerGen:            testSignerGen{Signers: []trust.Signer{signer}},
+				Intfs:                intfs,
+				MAC:                  macFactory,
+				MaxExpTime:           func() uint8 { return beacon.DefaultMaxExpTime },
+				StaticInfo:           func() *beaconing.StaticInfoCfg { return nil },
+				DiscoveryInformation: func() *discovery.Extension { return nil },
+			},
+			SenderFactory: senderFactory,
+			IA:            topo.IA(),
+			Signer:        signer,
+			AllInterfaces: intfs,
+			OriginationInterfaces: func() []*ifstate.Interface {
+				return intfs.Filtered(originationFilter)
+			},
+			Rules: beaconing.Rules{{
+				Egress: beaconing.InterfaceMatcher{
+					Neighbors: []addr.IA{addr.MustParseIA("2-0")},
+				},
+				AllowedOrigins: []addr.IA{addr.MustParseIA("2-0")},
+			}},
+			Tick: beaconing.NewTick(time.Hour),
+		}
+
+		// The core interface to 2-ff00:0:210 is skipped.
+		senderFactory.EXPECT().NewSender(gomock.Any(), gomock.Not(addr.MustParseIA("2-ff00:0:210")),
+			gomock.Any(), gomock.Any()).Times(3).Return(sender, nil)
+		sender.EXPECT().Send(gomock.Any(), gomock.Any()).Times(3).Return(nil)
+		sender.EXPECT().Close().Times(3)
+
+		o.Run(context.Background())
+		// The second run should not cause any beacons to originate.
+		o.Run(context.Background())
+	})
+	t.Run("rules originate on their own interval", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		intfs := ifstate.NewInterfaces(interfaceInfos(topo), ifstate.Config{})
+		senderFactory := mock_beaconing.NewMockSenderFactory(mctrl)
+		sender := mock_beaconing.NewMockSender(mctrl)
+		o := beaconing.Originator{
+			Extender: &beaconing.DefaultExtender{
+				IA:                   topo.IA(),
+				MTU:                  topo.MTU(),
+				SignerGen:            testSignerGen{Signers: []trust.Signer{signer}},
+				Intfs:                intfs,
+				MAC:                  macFactory,
+				MaxExpTime:           func() uint8 { return beacon.DefaultMaxExpTime },
+				StaticInfo:           func() *beaconing.StaticInfoCfg { return nil },
+				DiscoveryInformation: func() *discovery.Extension { return nil },
+			},
+			SenderFactory: senderFactory,
+			IA:            topo.IA(),
+			Signer:        signer,
+			AllInterfaces: intfs,
+			OriginationInterfaces: func() []*ifstate.Interface {
+				return intfs.Filtered(originationFilter)
+			},
+			Rules: beaconing.Rules{{
+				Egress:   beaconing.InterfaceMatcher{LinkTypes: []topology.LinkType{topology.Child}},
+				Interval: time.Minute,
+			}},
+			Tick: beaconing.NewTick(time.Hour),
+		}
+		child := func(intf *ifstate.Interface) bool {
+			return intf.TopoInfo().LinkType == topology.Child
+		}
+		// age moves the last origination on the child interface back in time,
+		// as if the given time had passed since.
+		age := func(d time.Duration) {
+			for _, intf := range intfs.Filtered(child) {
+				intf.Originate(time.Now().Add(-d))
+			}
+		}
+
+		// 1. Initial run where beacons are sent on all interfaces. -> 4 calls
+		// 2. Run within the interval of the rule. -> no call
+		// 3. Run once the interval has passed. -> 1 call on the child interface
+		senderFactory.EXPECT().NewSender(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any()).Times(4).Return(sender, nil)
+		senderFactory.EXPECT().NewSender(gomock.Any(), addr.MustParseIA("1-ff00:0:111"),
+			gomock.Any(), gomock.Any()).Times(1).Return(sender, nil)
+		sender.EXPECT().Send(gomock.Any(), gomock.Any()).Times(5).Return(nil)
+		sender.EXPECT().Close().Times(5)
+
+		o.Run(context.Background())
+		age(30 * time.Second)
+		o.Run(context.Background())
+		age(time.Minute)
+		o.Run(context.Background())
+	})
 	t.Run("Fast recovery", func(t *testing.T) {
 		mctrl := gomock.NewController(t)
 		intfs := ifstate.NewInterfaces(interfaceInfos(topo), ifstate.Config{})
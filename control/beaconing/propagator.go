@@ -60,6 +60,8 @@ type Propagator struct {
 	AllInterfaces         *ifstate.Interfaces
 	PropagationInterfaces func() []*ifstate.Interface
 	AllowIsdLoop          bool
+	// Rules restrict the beacons that are propagated on the egress interfaces.
+	Rules Rules
 
 	Propagated     metrics.Counter
 	InternalErrors metrics.Counter
@@ -139,17 +141,28 @@ func (p *Propagator) run(ctx context.Context) error {
 // needsBeacons returns a list of active interfaces that beacons should be
 // propagated on. In a core AS, these are all active core links. In a non-core
 // AS, these are all active child links.
+//
+// When the period of the tick has passed, all of these interfaces need
+// beacons. Between two periods, only the interfaces on which nothing was
+// propagated for longer than the period need them, e.g., interfaces that just
+// came up.
+//
+// Interfaces with an interval in the rules, see Rule.Interval, do not follow
+// the period of the tick: they need beacons once their interval has passed
+// since the last propagation on them. Whether any beacons are then propagated
+// on an interface, and which, is decided by the rules per beacon, see
+// Rules.Apply.
 func (p *Propagator) needsBeacons() []*ifstate.Interface {
 	intfs := p.PropagationInterfaces()
 	sort.Slice(intfs, func(i, j int) bool {
 		return intfs[i].TopoInfo().ID < intfs[j].TopoInfo().ID
 	})
-	if p.Tick.Passed() {
+	if p.Tick.Passed() && len(p.Rules) == 0 {
 		return intfs
 	}
 	stale := make([]*ifstate.Interface, 0, len(intfs))
 	for _, intf := range intfs {
-		if p.Tick.Overdue(intf.LastPropagate()) {
+		if p.Rules.due(&p.Tick, intf.TopoInfo(), intf.LastPropagate()) {
 			stale = append(stale, intf)
 		}
 	}
@@ -173,11 +186,16 @@ func (p *Propagator) beaconsPerInterface(
 	}
 	r := make(map[*ifstate.Interface][]beacon.Beacon)
 	for _, intf := range intfs {
-		toPropagate := make([]beacon.Beacon, 0, len(beacons))
+		candidates := make([]beacon.Beacon, 0, len(beacons))
 		for _, b := range beacons {
 			if p.shouldIgnore(b, intf) {
 				continue
 			}
+			candidates = append(candidates, b)
+		}
+		candidates = p.Rules.Apply(intf.TopoInfo(), p.AllInterfaces, candidates)
+		toPropagate := make([]beacon.Beacon, 0, len(candidates))
+		for _, b := range candidates {
 			ps, err := seg.BeaconFromPB(seg.PathSegmentToPB(b.Segment))
 			if err != nil {
 				return nil, err
@@ -119,6 +119,101 @@ func TestPropagatorRunNonCore(t *testing.T) {
 	p.Run(context.Background())
 }
 
+func TestPropagatorRunRules(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	pub := priv.Public()
+
+	beacons := [][]uint16{
+		{graph.If_120_X_111_B},
+		{graph.If_130_B_120_A, graph.If_120_X_111_B},
+	}
+
+	mctrl := gomock.NewController(t)
+	topo, err := topology.FromJSONFile(topoNonCore)
+	require.NoError(t, err)
+	intfs := ifstate.NewInterfaces(interfaceInfos(topo), ifstate.Config{})
+	provider := mock_beaconing.NewMockBeaconProvider(mctrl)
+	senderFactory := mock_beaconing.NewMockSenderFactory(mctrl)
+	filter := func(intf *ifstate.Interface) bool {
+		return intf.TopoInfo().LinkType == topology.Child
+	}
+	p := beaconing.Propagator{
+		Extender: &beaconing.DefaultExtender{
+			IA:  topo.IA(),
+			MTU: topo.MTU(),
+			SignerGen: testSignerGen{
+				Signers: []trust.Signer{testSigner(t, priv, topo.IA())},
+			},
+			Intfs:                intfs,
+			MAC:                  macFactory,
+			MaxExpTime:           func() uint8 { return beacon.DefaultMaxExpTime },
+			StaticInfo:           func() *beaconing.StaticInfoCfg { return nil },
+			DiscoveryInformation: func() *discovery.Extension { return nil },
+		},
+		SenderFactory: senderFactory,
+		IA:            topo.IA(),
+		Signer:        testSigner(t, priv, topo.IA()),
+		AllInterfaces: intfs,
+		PropagationInterfaces: func() []*ifstate.Interface {
+			return intfs.Filtered(filter)
+		},
+		// The interval of the rule is shorter than the period of the tick,
+		// such that beacons are propagated again once the interval passed.
+		Rules: beaconing.Rules{{
+			Egress:     beaconing.InterfaceMatcher{LinkTypes: []topology.LinkType{topology.Child}},
+			MaxBeacons: 1,
+			Interval:   time.Minute,
+		}},
+		Tick:     beaconing.NewTick(time.Hour),
+		Provider: provider,
+	}
+	g := graph.NewDefaultGraph(mctrl)
+	provider.EXPECT().BeaconsToPropagate(gomock.Any()).Times(2).DoAndReturn(
+		func(_ any) ([]beacon.Beacon, error) {
+			res := make([]beacon.Beacon, 0, len(beacons))
+			for _, desc := range beacons {
+				res = append(res, testBeacon(g, desc))
+			}
+			return res, nil
+		},
+	)
+
+	senderFactory.EXPECT().NewSender(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any()).Times(2).DoAndReturn(
+
+		func(_ context.Context, _ addr.IA, egIfID uint16,
+			nextHop *net.UDPAddr,
+		) (beaconing.Sender, error) {
+			sender := mock_beaconing.NewMockSender(mctrl)
+			sender.EXPECT().Send(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+				func(ctx context.Context, b *seg.PathSegment) error {
+					validateSend(ctx, t, b, egIfID, nextHop, pub, topo)
+					// Only the shortest beacon fits into the quota.
+					assert.Len(t, b.ASEntries, 2)
+					return nil
+				},
+			)
+			sender.EXPECT().Close().Times(1)
+
+			return sender, nil
+		},
+	)
+	// age moves the last propagation on the child interfaces back in time, as
+	// if the given time had passed since.
+	age := func(d time.Duration) {
+		for _, intf := range intfs.Filtered(filter) {
+			intf.Propagate(time.Now().Add(-d))
+		}
+	}
+	p.Run(context.Background())
+	// Within the interval, nothing is propagated.
+	age(30 * time.Second)
+	p.Run(context.Background())
+	age(time.Minute)
+	p.Run(context.Background())
+}
+
 func TestPropagatorRunCore(t *testing.T) {
 	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	require.NoError(t, err)
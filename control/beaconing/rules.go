@@ -0,0 +1,184 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing
+
+import (
+	"slices"
+	"time"
+
+	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/control/ifstate"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/private/topology"
+)
+
+// InterfaceMatcher selects interfaces by their ID, the ISD-AS of the neighbor
+// and the link type. An interface matches if it matches all the criteria that
+// are set. The empty matcher matches all interfaces.
+type InterfaceMatcher struct {
+	// Interfaces are the interface IDs.
+	Interfaces []uint16
+	// Neighbors are the ISD-ASes of the neighbors. The zero ISD and AS act as
+	// wildcards.
+	Neighbors []addr.IA
+	// LinkTypes are the link types.
+	LinkTypes []topology.LinkType
+}
+
+// Match indicates whether the interface matches.
+func (m InterfaceMatcher) Match(info ifstate.InterfaceInfo) bool {
+	if len(m.Interfaces) > 0 && !slices.Contains(m.Interfaces, info.ID) {
+		return false
+	}
+	if len(m.Neighbors) > 0 && !matchIA(m.Neighbors, info.IA) {
+		return false
+	}
+	if len(m.LinkTypes) > 0 && !slices.Contains(m.LinkTypes, info.LinkType) {
+		return false
+	}
+	return true
+}
+
+// Rule restricts the beacons that are originated or propagated on the egress
+// interfaces that it applies to.
+type Rule struct {
+	// Egress selects the egress interfaces that the rule applies to.
+	Egress InterfaceMatcher
+	// DenyIngress selects the ingress interfaces of the beacons that must not
+	// be propagated on the egress interfaces. If nil, no ingress interface is
+	// denied.
+	DenyIngress *InterfaceMatcher
+	// MaxBeacons is the maximum number of beacons that are propagated per
+	// interval on each egress interface. Zero means no limit.
+	MaxBeacons int
+	// AllowedOrigins are the ISD-ASes that originated the beacons that are
+	// allowed. The zero ISD and AS act as wildcards. If empty, all origins are
+	// allowed. For originated beacons, the origin is the local AS.
+	AllowedOrigins []addr.IA
+	// MaxHops is the maximum number of AS entries of the beacons before they
+	// are extended by the local AS. Zero means no limit.
+	MaxHops int
+	// Interval is the interval between originating or propagating beacons on
+	// each egress interface. Zero means the interval of the task is used.
+	Interval time.Duration
+}
+
+// Rules are the rules for the egress interfaces. If several rules apply to an
+// egress interface, all their restrictions apply. The interval of the first
+// rule that sets one is used.
+type Rules []Rule
+
+// For returns the rules that apply to the egress interface.
+func (r Rules) For(egress ifstate.InterfaceInfo) Rules {
+	var res Rules
+	for _, rule := range r {
+		if rule.Egress.Match(egress) {
+			res = append(res, rule)
+		}
+	}
+	return res
+}
+
+// Interval returns the interval for the egress interface, or the fallback if no
+// rule sets one.
+func (r Rules) Interval(egress ifstate.InterfaceInfo, fallback time.Duration) time.Duration {
+	for _, rule := range r.For(egress) {
+		if rule.Interval > 0 {
+			return rule.Interval
+		}
+	}
+	return fallback
+}
+
+// due indicates whether beacons need to be sent on the egress interface, given
+// the last time beacons were sent on it. Without an interval in the rules, the
+// period of the tick is used.
+func (r Rules) due(tick *Tick, egress ifstate.InterfaceInfo, last time.Time) bool {
+	if interval := r.Interval(egress, 0); interval > 0 {
+		return tick.Now().Sub(last) >= interval
+	}
+	return tick.Passed() || tick.Overdue(last)
+}
+
+// AllowOrigin indicates whether beacons originated by the ISD-AS may be sent on
+// the egress interface. For originated beacons, the ISD-AS is the local AS.
+func (r Rules) AllowOrigin(egress ifstate.InterfaceInfo, origin addr.IA) bool {
+	for _, rule := range r.For(egress) {
+		if len(rule.AllowedOrigins) > 0 && !matchIA(rule.AllowedOrigins, origin) {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply returns the beacons that may be propagated on the egress interface, in
+// the same order as the input. The ingress interface of a beacon is looked up in
+// intfs. Beacons with an unknown ingress interface are only checked against the
+// rules that do not depend on it.
+func (r Rules) Apply(
+	egress ifstate.InterfaceInfo,
+	intfs *ifstate.Interfaces,
+	beacons []beacon.Beacon,
+) []beacon.Beacon {
+	rules := r.For(egress)
+	if len(rules) == 0 {
+		return beacons
+	}
+	maxBeacons := 0
+	for _, rule := range rules {
+		if rule.MaxBeacons > 0 && (maxBeacons == 0 || rule.MaxBeacons < maxBeacons) {
+			maxBeacons = rule.MaxBeacons
+		}
+	}
+	res := make([]beacon.Beacon, 0, len(beacons))
+	for _, b := range beacons {
+		if maxBeacons > 0 && len(res) == maxBeacons {
+			break
+		}
+		if rules.allow(b, intfs) {
+			res = append(res, b)
+		}
+	}
+	return res
+}
+
+// allow indicates whether the beacon is allowed by all rules.
+func (r Rules) allow(b beacon.Beacon, intfs *ifstate.Interfaces) bool {
+	for _, rule := range r {
+		if len(rule.AllowedOrigins) > 0 && !matchIA(rule.AllowedOrigins, b.Segment.FirstIA()) {
+			return false
+		}
+		if rule.MaxHops > 0 && len(b.Segment.ASEntries) > rule.MaxHops {
+			return false
+		}
+		if rule.DenyIngress == nil {
+			continue
+		}
+		if ingress := intfs.Get(b.InIfID); ingress != nil &&
+			rule.DenyIngress.Match(ingress.TopoInfo()) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchIA(patterns []addr.IA, ia addr.IA) bool {
+	for _, p := range patterns {
+		if (p.ISD() == 0 || p.ISD() == ia.ISD()) && (p.AS() == 0 || p.AS() == ia.AS()) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/control/beaconing"
+	"github.com/scionproto/scion/control/ifstate"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/xtest/graph"
+	"github.com/scionproto/scion/private/topology"
+)
+
+func TestInterfaceMatcher(t *testing.T) {
+	info := ifstate.InterfaceInfo{
+		ID:       5,
+		IA:       addr.MustParseIA("1-ff00:0:110"),
+		LinkType: topology.Child,
+	}
+	testCases := map[string]struct {
+		matcher  beaconing.InterfaceMatcher
+		expected bool
+	}{
+		"empty": {
+			expected: true,
+		},
+		"interface": {
+			matcher:  beaconing.InterfaceMatcher{Interfaces: []uint16{4, 5}},
+			expected: true,
+		},
+		"other interface": {
+			matcher:  beaconing.InterfaceMatcher{Interfaces: []uint16{4}},
+			expected: false,
+		},
+		"neighbor wildcard": {
+			matcher:  beaconing.InterfaceMatcher{Neighbors: []addr.IA{addr.MustParseIA("1-0")}},
+			expected: true,
+		},
+		"other neighbor": {
+			matcher: beaconing.InterfaceMatcher{
+				Neighbors: []addr.IA{addr.MustParseIA("1-ff00:0:111")},
+			},
+			expected: false,
+		},
+		"all criteria": {
+			matcher: beaconing.InterfaceMatcher{
+				Interfaces: []uint16{5},
+				Neighbors:  []addr.IA{addr.MustParseIA("1-ff00:0:110")},
+				LinkTypes:  []topology.LinkType{topology.Child},
+			},
+			expected: true,
+		},
+		"other link type": {
+			matcher: beaconing.InterfaceMatcher{
+				Interfaces: []uint16{5},
+				LinkTypes:  []topology.LinkType{topology.Parent},
+			},
+			expected: false,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.matcher.Match(info))
+		})
+	}
+}
+
+func TestRulesApply(t *testing.T) {
+	g := graph.NewDefaultGraph(gomock.NewController(t))
+	intfs := ifstate.NewInterfaces(map[uint16]ifstate.InterfaceInfo{
+		graph.If_111_A_112_X: {
+			ID:       graph.If_111_A_112_X,
+			IA:       addr.MustParseIA("1-ff00:0:112"),
+			LinkType: topology.Child,
+		},
+		graph.If_111_B_120_X: {
+			ID:       graph.If_111_B_120_X,
+			IA:       addr.MustParseIA("1-ff00:0:120"),
+			LinkType: topology.Parent,
+		},
+		graph.If_111_B_211_A: {
+			ID:       graph.If_111_B_211_A,
+			IA:       addr.MustParseIA("2-ff00:0:211"),
+			LinkType: topology.Peer,
+		},
+	}, ifstate.Config{})
+	egress := intfs.Get(graph.If_111_A_112_X).TopoInfo()
+
+	short := testBeacon(g, []uint16{graph.If_120_X_111_B})
+	long := testBeacon(g, []uint16{graph.If_130_B_120_A, graph.If_120_X_111_B})
+	peer := beacon.Beacon{Segment: short.Segment, InIfID: graph.If_111_B_211_A}
+	beacons := []beacon.Beacon{short, long, peer}
+
+	testCases := map[string]struct {
+		rules    beaconing.Rules
+		expected []beacon.Beacon
+	}{
+		"no rules": {
+			expected: beacons,
+		},
+		"other egress": {
+			rules: beaconing.Rules{{
+				Egress: beaconing.InterfaceMatcher{
+					LinkTypes: []topology.LinkType{topology.Core},
+				},
+				MaxBeacons: 1,
+			}},
+			expected: beacons,
+		},
+		"max beacons": {
+			rules:    beaconing.Rules{{MaxBeacons: 2}},
+			expected: []beacon.Beacon{short, long},
+		},
+		"smallest max beacons": {
+			rules:    beaconing.Rules{{MaxBeacons: 2}, {MaxBeacons: 1}},
+			expected: []beacon.Beacon{short},
+		},
+		"allowed origins": {
+			rules: beaconing.Rules{{
+				AllowedOrigins: []addr.IA{addr.MustParseIA("1-ff00:0:130")},
+			}},
+			expected: []beacon.Beacon{long},
+		},
+		"max hops": {
+			rules:    beaconing.Rules{{MaxHops: 1}},
+			expected: []beacon.Beacon{short, peer},
+		},
+		"deny ingress": {
+			rules: beaconing.Rules{{
+				Egress: beaconing.InterfaceMatcher{
+					Neighbors: []addr.IA{addr.MustParseIA("1-ff00:0:112")},
+				},
+				DenyIngress: &beaconing.InterfaceMatcher{
+					LinkTypes: []topology.LinkType{topology.Peer},
+				},
+			}},
+			expected: []beacon.Beacon{short, long},
+		},
+		"quota after filter": {
+			rules: beaconing.Rules{
+				{MaxHops: 1},
+				{MaxBeacons: 1, DenyIngress: &beaconing.InterfaceMatcher{
+					Interfaces: []uint16{graph.If_111_B_120_X},
+				}},
+			},
+			expected: []beacon.Beacon{peer},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.rules.Apply(egress, intfs, beacons))
+		})
+	}
+}
+
+func TestRulesIntervalAndOrigin(t *testing.T) {
+	core := ifstate.InterfaceInfo{ID: 1, LinkType: topology.Core}
+	child := ifstate.InterfaceInfo{ID: 2, LinkType: topology.Child}
+	local := addr.MustParseIA("1-ff00:0:110")
+	rules := beaconing.Rules{
+		{
+			Egress: beaconing.InterfaceMatcher{
+				LinkTypes: []topology.LinkType{topology.Core},
+			},
+			AllowedOrigins: []addr.IA{addr.MustParseIA("2-0")},
+		},
+		{
+			Egress:   beaconing.InterfaceMatcher{Interfaces: []uint16{2}},
+			Interval: time.Minute,
+		},
+		{Interval: time.Hour},
+	}
+	assert.Equal(t, time.Hour, rules.Interval(core, time.Second))
+	assert.Equal(t, time.Minute, rules.Interval(child, time.Second))
+	assert.Equal(t, time.Second, beaconing.Rules(nil).Interval(child, time.Second))
+
+	assert.False(t, rules.AllowOrigin(core, local))
+	assert.True(t, rules.AllowOrigin(core, addr.MustParseIA("2-ff00:0:210")))
+	assert.True(t, rules.AllowOrigin(child, local))
+}
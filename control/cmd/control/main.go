@@ -117,6 +117,7 @@ import (
 	"github.com/scionproto/scion/private/trust"
 	"github.com/scionproto/scion/private/trust/compat"
 	trustconnect "github.com/scionproto/scion/private/trust/connect"
+	"github.com/scionproto/scion/private/trust/expiry"
 	trustgrpc "github.com/scionproto/scion/private/trust/grpc"
 	trusthappy "github.com/scionproto/scion/private/trust/happy"
 	trustmetrics "github.com/scionproto/scion/private/trust/metrics"
@@ -192,10 +193,24 @@ func realMain(ctx context.Context) error {
 	})
 	defer pathDB.Close()
 
-	macGen, err := cs.MACGenFactory(globalCfg.General.ConfigDir)
+	macGen, err := cs.NewMACGen(globalCfg.General.ConfigDir)
 	if err != nil {
 		return err
 	}
+	g.Go(func() error {
+		defer log.HandlePanic()
+		sighup := app.SIGHUPChannel(errCtx)
+		for {
+			select {
+			case <-sighup:
+				if _, err := macGen.Reload(); err != nil {
+					log.Error("Reloading master key failed", "err", err)
+				}
+			case <-errCtx.Done():
+				return nil
+			}
+		}
+	})
 
 	trustDB, err := storage.NewTrustStorage(globalCfg.TrustDB)
 	if err != nil {
@@ -230,6 +245,12 @@ func realMain(ctx context.Context) error {
 	if err := cs.LoadTrustMaterial(ctx, globalCfg.General.ConfigDir, trustDB); err != nil {
 		return err
 	}
+	asKeys, err := cs.NewKeyRing(
+		filepath.Join(globalCfg.General.ConfigDir, "crypto/as"), globalCfg.Keys.AS,
+	)
+	if err != nil {
+		return serrors.Wrap("initializing AS key ring", err)
+	}
 
 	// FIXME: readability would be improved if we could be consistent with address
 	// representations in NetworkConfig (string or cooked, chose one).
@@ -240,9 +261,11 @@ func realMain(ctx context.Context) error {
 			TLSVerifier: trust.NewTLSCryptoVerifier(trustDB),
 			GetCertificate: cs.NewTLSCertificateLoader(
 				topo.IA(), x509.ExtKeyUsageServerAuth, trustDB, globalCfg.General.ConfigDir,
+				asKeys,
 			).GetCertificate,
 			GetClientCertificate: cs.NewTLSCertificateLoader(
 				topo.IA(), x509.ExtKeyUsageClientAuth, trustDB, globalCfg.General.ConfigDir,
+				asKeys,
 			).GetClientCertificate,
 		},
 		SVCResolver: topo,
@@ -262,7 +285,7 @@ func realMain(ctx context.Context) error {
 	dialer := &libgrpc.QUICDialer{
 		Rewriter: &onehop.AddressRewriter{
 			Rewriter: nc.AddressRewriter(),
-			MAC:      macGen(),
+			MAC:      macGen.New,
 		},
 		Dialer: quicStack.InsecureDialer,
 	}
@@ -466,11 +489,12 @@ func realMain(ctx context.Context) error {
 
 	ctxSigner, cancel := context.WithTimeout(ctx, time.Second)
 	defer cancel()
-	signer := cs.NewSigner(ctxSigner, topo.IA(), trustDB, globalCfg.General.ConfigDir)
+	signer := cs.NewSigner(ctxSigner, topo.IA(), trustDB, globalCfg.General.ConfigDir, asKeys)
 
 	var chainBuilder renewal.ChainBuilder
 	var caClient *caapi.Client
 	var caHealthCached *cachedCAHealth
+	var enrollmentTokens renewal.EnrollmentTokenStore
 	if globalCfg.CA.Mode != config.Disabled {
 		renewalGauges := libmetrics.NewPromGauge(metrics.RenewalRegisteredHandlers)
 		libmetrics.GaugeWith(renewalGauges, "type", "legacy").Set(0)
@@ -493,6 +517,22 @@ func realMain(ctx context.Context) error {
 				libmetrics.NewPromCounter(metrics.RenewalHandledRequestsTotal),
 				"type", "in-process",
 			)
+			var ledger renewal.Ledger
+			if globalCfg.CA.LedgerDB.Connection != "" {
+				ledgerDB, err := storage.NewCALedgerStorage(globalCfg.CA.LedgerDB)
+				if err != nil {
+					return serrors.Wrap("initializing CA ledger storage", err)
+				}
+				defer ledgerDB.Close()
+				ledger = ledgerDB
+				enrollmentTokens = ledgerDB
+			}
+			caKeys, err := cs.NewKeyRing(
+				filepath.Join(globalCfg.General.ConfigDir, "crypto/ca"), globalCfg.Keys.CA,
+			)
+			if err != nil {
+				return serrors.Wrap("initializing CA key ring", err)
+			}
 			chainBuilder = cs.NewChainBuilder(
 				cs.ChainBuilderConfig{
 					IA:                   topo.IA(),
@@ -501,6 +541,12 @@ func realMain(ctx context.Context) error {
 					ConfigDir:            globalCfg.General.ConfigDir,
 					Metrics:              metrics.RenewalMetrics,
 					ForceECDSAWithSHA512: !globalCfg.Features.AppropriateDigest,
+					Ledger:               ledger,
+					KeyRing:              caKeys,
+					IssuanceLimit: renewal.IssuanceLimit{
+						MaxChains: globalCfg.CA.MaxIssuedChains,
+						Window:    globalCfg.CA.IssuanceWindow.Duration,
+					},
 				},
 			)
 
@@ -517,8 +563,24 @@ func realMain(ctx context.Context) error {
 					NotFoundError: cmsCtr.With(prom.LabelResult, prom.ErrNotFound),
 					ParseError:    cmsCtr.With(prom.LabelResult, prom.ErrParse),
 					VerifyError:   cmsCtr.With(prom.LabelResult, prom.ErrVerify),
+					LimitError:    cmsCtr.With(prom.LabelResult, prom.ErrLimit),
 				},
 			}
+			if enrollmentTokens != nil {
+				renewalServer.EnrollmentHandler = &renewalgrpc.Enrollment{
+					ChainBuilder: chainBuilder,
+					Verifier: renewal.EnrollmentVerifier{
+						ISD:    topo.IA().ISD(),
+						Tokens: enrollmentTokens,
+					},
+					Metrics: renewalgrpc.EnrollmentHandlerMetrics{
+						Success:       cmsCtr.With(prom.LabelResult, prom.Success),
+						InternalError: cmsCtr.With(prom.LabelResult, prom.ErrInternal),
+						LimitError:    cmsCtr.With(prom.LabelResult, prom.ErrLimit),
+						VerifyError:   cmsCtr.With(prom.LabelResult, prom.ErrVerify),
+					},
+				}
+			}
 		case config.Delegating:
 			libmetrics.GaugeWith(renewalGauges, "type", "delegating").Set(1)
 			delCtr := libmetrics.CounterWith(
@@ -543,7 +605,7 @@ func realMain(ctx context.Context) error {
 			caHealthCached = &cachedCAHealth{status: api.Unavailable}
 			caHealthGauge := libmetrics.NewPromGauge(metrics.CAHealth)
 			updateCAHealthMetrics(caHealthGauge, api.Unavailable)
-			renewalServer.CMSHandler = &renewalgrpc.DelegatingHandler{
+			delegatingHandler := &renewalgrpc.DelegatingHandler{
 				Client: caClient,
 				Metrics: renewalgrpc.DelegatingHandlerMetrics{
 					BadRequests: libmetrics.CounterWith(delCtr,
@@ -556,6 +618,8 @@ func realMain(ctx context.Context) error {
 						prom.LabelResult, prom.Success),
 				},
 			}
+			renewalServer.CMSHandler = delegatingHandler
+			renewalServer.EnrollmentHandler = delegatingHandler
 			// Periodically check the connection to the CA backend
 			// SA1019: fix later (https://github.com/scionproto/scion/issues/4776).
 			//nolint:staticcheck
@@ -642,6 +706,27 @@ func realMain(ctx context.Context) error {
 	)
 	trcRunner.TriggerRun()
 
+	var expiryDirs []string
+	if globalCfg.CA.Mode == config.InProcess {
+		expiryDirs = append(expiryDirs, filepath.Join(globalCfg.General.ConfigDir, "crypto/ca"))
+	}
+	expiryMonitor := &expiry.Monitor{
+		IA:     topo.IA(),
+		DB:     trustDB,
+		Dirs:   expiryDirs,
+		Policy: globalCfg.TrustEngine.Expiry.Policy(),
+		Metrics: expiry.Metrics{
+			Remaining: trustmetrics.ExpiryRemainingSeconds,
+		},
+	}
+	//nolint:staticcheck // SA1019: fix later (https://github.com/scionproto/scion/issues/4776).
+	expiryRunner := periodic.Start(
+		expiryMonitor,
+		globalCfg.TrustEngine.Expiry.Interval.Duration,
+		10*time.Second,
+	)
+	expiryRunner.TriggerRun()
+
 	ds := discovery.Topology{
 		Information: topo,
 		Requests:    libmetrics.NewPromCounter(metrics.DiscoveryRequestsTotal),
@@ -857,6 +942,8 @@ func realMain(ctx context.Context) error {
 				ISD:      topo.IA().ISD(),
 				CAHealth: caHealthCached,
 			},
+			EnrollmentTokens: enrollmentTokens,
+			Expiry:           expiryMonitor,
 		}
 		log.Info("Exposing API", "addr", globalCfg.API.Addr)
 		s := http.Server{
@@ -972,7 +1059,7 @@ func realMain(ctx context.Context) error {
 		Inspector:   inspector,
 		Metrics:     metrics,
 		DRKeyEngine: drkeyEngine,
-		MACGen:      macGen,
+		MACGen:      macGen.New,
 		NextHopper:  topo,
 		StaticInfo:  func() *beaconing.StaticInfoCfg { return staticInfo },
 
@@ -994,6 +1081,7 @@ func realMain(ctx context.Context) error {
 		DRKeyEpochInterval:        epochDuration,
 		HiddenPathRegistrationCfg: hpWriterCfg,
 		AllowIsdLoop:              isdLoopAllowed,
+		BeaconingRules:            cs.BeaconingRules(globalCfg.BS.Rules),
 		EPIC:                      globalCfg.BS.EPIC,
 	}
 
@@ -1034,6 +1122,7 @@ func realMain(ctx context.Context) error {
 		}
 	}
 	ignorePlugin := &segreg.IgnoreSegmentRegistrationPlugin{}
+	externalPlugin := &segreg.ExternalSegmentRegistrationPlugin{}
 	defaultPlugin := &DefaultSegmentRegistrationPlugin{
 		LocalPlugin:  localPlugin,
 		RemotePlugin: remotePlugin,
@@ -1044,6 +1133,7 @@ func realMain(ctx context.Context) error {
 		localPlugin,
 		remotePlugin,
 		ignorePlugin,
+		externalPlugin,
 		defaultPlugin,
 	}
 	if hiddenPathPlugin != nil {
@@ -1123,19 +1213,23 @@ func createBeaconStore(
 	db storage.BeaconDB,
 	provider beacon.ChainProvider,
 ) (cs.Store, bool, error) {
+	// Only the beacons that can be verified with the local crypto material are selected.
+	withChains := func(algo beacon.SelectionAlgorithm) beacon.SelectionAlgorithm {
+		return beacon.NewChainsAvailableAlgo(provider, algo)
+	}
 	switch {
 	case policies.CorePolicies != nil:
 		policies := policies.CorePolicies
-		selectionAlgo := beacon.NewChainsAvailableAlgo(provider, beacon.DefaultSelectionAlgorithm())
 		store, err := beacon.NewCoreBeaconStore(*policies, db,
-			beacon.WithSelectionAlgorithm(selectionAlgo),
+			beacon.WithSelectionAlgorithm(withChains(beacon.DefaultSelectionAlgorithm())),
+			beacon.WithSelectionAlgorithmWrapper(withChains),
 		)
 		return store, *policies.Prop.Filter.AllowIsdLoop, err
 	case policies.NonCorePolicies != nil:
 		policies := policies.NonCorePolicies
-		selectionAlgo := beacon.NewChainsAvailableAlgo(provider, beacon.DefaultSelectionAlgorithm())
 		store, err := beacon.NewBeaconStore(*policies, db,
-			beacon.WithSelectionAlgorithm(selectionAlgo),
+			beacon.WithSelectionAlgorithm(withChains(beacon.DefaultSelectionAlgorithm())),
+			beacon.WithSelectionAlgorithmWrapper(withChains),
 		)
 		return store, *policies.Prop.Filter.AllowIsdLoop, err
 	default:
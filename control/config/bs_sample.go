@@ -26,6 +26,31 @@ registration_interval = "5s"
 
 # Add EPIC authenticators to the beacons. (default false)
 epic = false
+
+# Rules restrict the beacons that are originated and propagated on the egress
+# interfaces. A rule applies to the egress interfaces that match all the
+# criteria of egress. If several rules apply to an egress interface, all their
+# restrictions apply. (default no rules)
+#
+# [[beaconing.rules]]
+# # The egress interfaces, by interface ID, neighbor ISD-AS and link type
+# # (core, parent, child or peer). (default all interfaces)
+# egress = { neighbors = ["1-ff00:0:110"], link_types = ["child"] }
+# # Beacons received on these interfaces are not propagated. (default none)
+# deny_ingress = { link_types = ["peer"] }
+# # The maximum number of beacons propagated per interval on each egress
+# # interface. (default 0, no limit)
+# max_beacons = 10
+# # The ISD-ASes that originated the beacons. The zero ISD and AS act as
+# # wildcards. For originated beacons, the origin is the local AS.
+# # (default all origins)
+# allowed_origins = ["1-0"]
+# # The maximum number of AS hops of the beacons before they are extended by
+# # the local AS. (default 0, no limit)
+# max_hops = 6
+# # The interval between originating and propagating beacons on each egress
+# # interface. (default origination_interval and propagation_interval)
+# interval = "10s"
 `
 
 const policiesSample = `
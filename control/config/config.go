@@ -20,6 +20,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/util"
@@ -27,7 +28,9 @@ import (
 	"github.com/scionproto/scion/private/env"
 	api "github.com/scionproto/scion/private/mgmtapi"
 	"github.com/scionproto/scion/private/mgmtapi/jwtauth"
+	"github.com/scionproto/scion/private/pkcs11"
 	"github.com/scionproto/scion/private/storage"
+	"github.com/scionproto/scion/private/topology"
 	trustengine "github.com/scionproto/scion/private/trust/config"
 )
 
@@ -44,6 +47,9 @@ const (
 	DefaultQueryInterval = 5 * time.Minute
 	// DefaultMaxASValidity is the default validity period for renewed AS certificates.
 	DefaultMaxASValidity = 3 * 24 * time.Hour
+	// DefaultIssuanceWindow is the default window over which the issued
+	// certificate chains are counted for the issuance limit.
+	DefaultIssuanceWindow = 24 * time.Hour
 )
 
 var _ config.Config = (*Config)(nil)
@@ -63,6 +69,7 @@ type Config struct {
 	BS          BSConfig           `toml:"beaconing,omitempty"`
 	PS          PSConfig           `toml:"path,omitempty"`
 	CA          CA                 `toml:"ca,omitempty"`
+	Keys        Keys               `toml:"keys,omitempty"`
 	TrustEngine trustengine.Config `toml:"trustengine,omitempty"`
 	DRKey       DRKeyConfig        `toml:"drkey,omitempty"`
 }
@@ -83,6 +90,7 @@ func (cfg *Config) InitDefaults() {
 		&cfg.BS,
 		&cfg.PS,
 		&cfg.CA,
+		&cfg.Keys,
 		&cfg.TrustEngine,
 		&cfg.DRKey,
 	)
@@ -103,6 +111,7 @@ func (cfg *Config) Validate() error {
 		&cfg.BS,
 		&cfg.PS,
 		&cfg.CA,
+		&cfg.Keys,
 		&cfg.TrustEngine,
 		&cfg.DRKey,
 	)
@@ -142,6 +151,7 @@ func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
 		&cfg.BS,
 		&cfg.PS,
 		&cfg.CA,
+		&cfg.Keys,
 		&cfg.TrustEngine,
 		&cfg.DRKey,
 	)
@@ -161,6 +171,9 @@ type BSConfig struct {
 	Policies Policies `toml:"policies,omitempty"`
 	// EPIC specifies whether the EPIC authenticators should be added to the beacons.
 	EPIC bool `toml:"epic,omitempty"`
+	// Rules restrict the beacons that are originated and propagated on the
+	// egress interfaces.
+	Rules []BeaconingRule `toml:"rules,omitempty"`
 }
 
 // InitDefaults the default values for the durations that are equal to zero.
@@ -178,6 +191,11 @@ func (cfg *BSConfig) Validate() error {
 	if cfg.RegistrationInterval.Duration == 0 {
 		initDurWrap(&cfg.RegistrationInterval, DefaultRegistrationInterval)
 	}
+	for i, rule := range cfg.Rules {
+		if err := rule.Validate(); err != nil {
+			return serrors.Wrap("validating beaconing rule", err, "index", i)
+		}
+	}
 	return nil
 }
 
@@ -192,6 +210,57 @@ func (cfg *BSConfig) ConfigName() string {
 	return "beaconing"
 }
 
+// BeaconingRule restricts the beacons that are originated and propagated on
+// the egress interfaces that it applies to.
+type BeaconingRule struct {
+	// Egress selects the egress interfaces that the rule applies to. If empty,
+	// the rule applies to all egress interfaces.
+	Egress InterfaceSelector `toml:"egress,omitempty"`
+	// DenyIngress selects the ingress interfaces of the beacons that must not
+	// be propagated on the egress interfaces.
+	DenyIngress *InterfaceSelector `toml:"deny_ingress,omitempty"`
+	// MaxBeacons is the maximum number of beacons that are propagated per
+	// interval on each egress interface. Zero means no limit.
+	MaxBeacons int `toml:"max_beacons,omitempty"`
+	// AllowedOrigins are the ISD-ASes that originated the beacons that are
+	// allowed. The zero ISD and AS act as wildcards, e.g., "1-0". If empty, all
+	// origins are allowed.
+	AllowedOrigins []addr.IA `toml:"allowed_origins,omitempty"`
+	// MaxHops is the maximum number of AS hops of the beacons before they are
+	// extended by the local AS. Zero means no limit.
+	MaxHops int `toml:"max_hops,omitempty"`
+	// Interval is the interval between originating and propagating beacons on
+	// each egress interface. If zero, the origination and propagation
+	// intervals are used.
+	Interval util.DurWrap `toml:"interval,omitempty"`
+}
+
+// Validate validates the rule.
+func (r *BeaconingRule) Validate() error {
+	if r.MaxBeacons < 0 {
+		return serrors.New("max_beacons must not be negative", "value", r.MaxBeacons)
+	}
+	if r.MaxHops < 0 {
+		return serrors.New("max_hops must not be negative", "value", r.MaxHops)
+	}
+	if r.Interval.Duration < 0 {
+		return serrors.New("interval must not be negative", "value", r.Interval)
+	}
+	return nil
+}
+
+// InterfaceSelector selects interfaces. An interface is selected if it matches
+// all the criteria that are set.
+type InterfaceSelector struct {
+	// Interfaces are the interface IDs.
+	Interfaces []uint16 `toml:"interfaces,omitempty"`
+	// Neighbors are the ISD-ASes of the neighbors. The zero ISD and AS act as
+	// wildcards.
+	Neighbors []addr.IA `toml:"neighbors,omitempty"`
+	// LinkTypes are the link types, i.e., "core", "parent", "child" or "peer".
+	LinkTypes []topology.LinkType `toml:"link_types,omitempty"`
+}
+
 func initDurWrap(w *util.DurWrap, def time.Duration) {
 	if w.Duration == 0 {
 		w.Duration = def
@@ -275,18 +344,46 @@ type CA struct {
 	Mode CAMode `toml:"mode,omitempty"`
 	// Service contains details about CA functionality delegation.
 	Service CAService `toml:"service,omitempty"`
+	// LedgerDB is the database that records the certificate chains issued in
+	// the in-process mode. If the connection is empty, the issued chains are
+	// not recorded.
+	LedgerDB storage.DBConfig `toml:"ledger_db,omitempty"`
+	// MaxIssuedChains is the maximum number of certificate chains issued per
+	// ISD-AS within the issuance window. Zero means no limit. The limit is
+	// enforced based on the ledger.
+	MaxIssuedChains int `toml:"max_issued_chains,omitempty"`
+	// IssuanceWindow is the sliding window over which the issued certificate
+	// chains are counted for the issuance limit.
+	IssuanceWindow util.DurWrap `toml:"issuance_window,omitempty"`
 }
 
 func (cfg *CA) InitDefaults() {
 	if cfg.Mode == "" {
 		cfg.Mode = Disabled
 	}
+	config.InitAll(cfg.LedgerDB.WithDefault(""))
 }
 
 func (cfg *CA) Validate() error {
 	if cfg.MaxASValidity.Duration == 0 {
 		cfg.MaxASValidity.Duration = DefaultMaxASValidity
 	}
+	if cfg.IssuanceWindow.Duration == 0 {
+		cfg.IssuanceWindow.Duration = DefaultIssuanceWindow
+	}
+	if cfg.MaxIssuedChains < 0 {
+		return serrors.New("max_issued_chains must not be negative",
+			"value", cfg.MaxIssuedChains)
+	}
+	if cfg.IssuanceWindow.Duration < 0 {
+		return serrors.New("issuance_window must not be negative", "value", cfg.IssuanceWindow)
+	}
+	if cfg.MaxIssuedChains > 0 && cfg.LedgerDB.Connection == "" {
+		return serrors.New("max_issued_chains requires ledger_db to be set")
+	}
+	if err := cfg.LedgerDB.WithAllowEmptyConn().Validate(); err != nil {
+		return err
+	}
 	switch strings.ToLower(string(cfg.Mode)) {
 	case string(Disabled):
 		cfg.Mode = Disabled
@@ -302,13 +399,59 @@ func (cfg *CA) Validate() error {
 
 func (cfg *CA) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
 	config.WriteString(dst, caSample)
-	config.WriteSample(dst, path, ctx, &cfg.Service)
+	config.WriteSample(dst, path, ctx,
+		&cfg.Service,
+		config.OverrideName(
+			config.FormatData(
+				&cfg.LedgerDB,
+				storage.SetID(storage.SampleCALedgerDB, idSample).Connection,
+			),
+			"ledger_db",
+		),
+	)
 }
 
 func (cfg *CA) ConfigName() string {
 	return "ca"
 }
 
+// Keys configures where the private keys of the control service are stored.
+// By default, the keys are loaded from the crypto directory in the config
+// directory.
+type Keys struct {
+	config.NoDefaulter
+	// AS is the PKCS#11 URI that selects the AS private keys used for
+	// signing control-plane messages. If it is empty, the keys are loaded
+	// from the crypto/as directory.
+	AS string `toml:"as,omitempty"`
+	// CA is the PKCS#11 URI that selects the CA private keys used for issuing
+	// AS certificates. If it is empty, the keys are loaded from the crypto/ca
+	// directory.
+	CA string `toml:"ca,omitempty"`
+}
+
+func (cfg *Keys) Validate() error {
+	if cfg.AS != "" {
+		if _, err := pkcs11.ParseURI(cfg.AS); err != nil {
+			return serrors.Wrap("parsing PKCS#11 URI", err, "key", "as")
+		}
+	}
+	if cfg.CA != "" {
+		if _, err := pkcs11.ParseURI(cfg.CA); err != nil {
+			return serrors.Wrap("parsing PKCS#11 URI", err, "key", "ca")
+		}
+	}
+	return nil
+}
+
+func (cfg *Keys) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, keysSample)
+}
+
+func (cfg *Keys) ConfigName() string {
+	return "keys"
+}
+
 type CAMode string
 
 const (
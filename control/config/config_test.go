@@ -17,15 +17,19 @@ package config
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/pelletier/go-toml/v2"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log/logtest"
 	"github.com/scionproto/scion/private/env/envtest"
 	"github.com/scionproto/scion/private/mgmtapi/jwtauth"
 	apitest "github.com/scionproto/scion/private/mgmtapi/mgmtapitest"
+	"github.com/scionproto/scion/private/storage"
 	storagetest "github.com/scionproto/scion/private/storage/test"
+	"github.com/scionproto/scion/private/topology"
 )
 
 func TestConfigSample(t *testing.T) {
@@ -46,6 +50,7 @@ func InitTestConfig(cfg *Config) {
 	InitTestBSConfig(&cfg.BS)
 	InitTestPSConfig(&cfg.PS)
 	InitTestCA(&cfg.CA)
+	InitTestKeys(&cfg.Keys)
 }
 
 func InitTestBSConfig(cfg *BSConfig) {
@@ -68,7 +73,8 @@ func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 	storagetest.CheckTestPathDBConfig(t, &cfg.PathDB, id)
 	CheckTestBSConfig(t, &cfg.BS)
 	CheckTestPSConfig(t, &cfg.PS, id)
-	CheckTestCA(t, &cfg.CA)
+	CheckTestCA(t, &cfg.CA, id)
+	CheckTestKeys(t, &cfg.Keys)
 }
 
 func CheckTestBSConfig(t *testing.T, cfg *BSConfig) {
@@ -76,9 +82,42 @@ func CheckTestBSConfig(t *testing.T, cfg *BSConfig) {
 	assert.Equal(t, DefaultPropagationInterval, cfg.PropagationInterval.Duration)
 	assert.Equal(t, DefaultRegistrationInterval, cfg.RegistrationInterval.Duration)
 	assert.False(t, cfg.EPIC)
+	assert.Empty(t, cfg.Rules)
 	CheckTestPolicies(t, &cfg.Policies)
 }
 
+func TestBeaconingRules(t *testing.T) {
+	raw := `
+[[rules]]
+egress = { neighbors = ["1-ff00:0:110"], link_types = ["child"] }
+deny_ingress = { interfaces = [1, 2] }
+max_beacons = 10
+allowed_origins = ["1-0"]
+max_hops = 6
+interval = "10s"
+
+[[rules]]
+max_beacons = -1
+`
+	var cfg BSConfig
+	err := toml.NewDecoder(bytes.NewReader([]byte(raw))).DisallowUnknownFields().Decode(&cfg)
+	assert.NoError(t, err)
+	if assert.Len(t, cfg.Rules, 2) {
+		rule := cfg.Rules[0]
+		assert.Equal(t, []addr.IA{addr.MustParseIA("1-ff00:0:110")}, rule.Egress.Neighbors)
+		assert.Equal(t, []topology.LinkType{topology.Child}, rule.Egress.LinkTypes)
+		if assert.NotNil(t, rule.DenyIngress) {
+			assert.Equal(t, []uint16{1, 2}, rule.DenyIngress.Interfaces)
+		}
+		assert.Equal(t, 10, rule.MaxBeacons)
+		assert.Equal(t, []addr.IA{addr.MustParseIA("1-0")}, rule.AllowedOrigins)
+		assert.Equal(t, 6, rule.MaxHops)
+		assert.Equal(t, 10*time.Second, rule.Interval.Duration)
+		assert.NoError(t, rule.Validate())
+	}
+	assert.Error(t, cfg.Validate())
+}
+
 func CheckTestPolicies(t *testing.T, cfg *Policies) {
 	assert.Empty(t, cfg.Propagation)
 	assert.Empty(t, cfg.CoreRegistration)
@@ -98,12 +137,66 @@ func CheckTestPSConfig(t *testing.T, cfg *PSConfig, id string) {
 func InitTestCA(cfg *CA) {
 }
 
-func CheckTestCA(t *testing.T, cfg *CA) {
+func CheckTestCA(t *testing.T, cfg *CA, id string) {
 	assert.Equal(t, DefaultMaxASValidity, cfg.MaxASValidity.Duration)
 	assert.Equal(t, cfg.Mode, InProcess)
+	assert.Zero(t, cfg.MaxIssuedChains)
+	assert.Equal(t, DefaultIssuanceWindow, cfg.IssuanceWindow.Duration)
+	assert.Equal(t, storage.SetID(storage.SampleCALedgerDB, id), &cfg.LedgerDB)
 	CheckTestService(t, &cfg.Service)
 }
 
+func TestCAValidate(t *testing.T) {
+	testCases := map[string]struct {
+		CA           CA
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"defaults": {
+			ErrAssertion: assert.NoError,
+		},
+		"limit with ledger": {
+			CA: CA{
+				MaxIssuedChains: 10,
+				LedgerDB:        storage.DBConfig{Connection: "ledger.db"},
+			},
+			ErrAssertion: assert.NoError,
+		},
+		"limit without ledger": {
+			CA:           CA{MaxIssuedChains: 10},
+			ErrAssertion: assert.Error,
+		},
+		"negative limit": {
+			CA:           CA{MaxIssuedChains: -1},
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tc.CA.InitDefaults()
+			err := tc.CA.Validate()
+			if tc.ErrAssertion(t, err) && err == nil {
+				assert.Equal(t, DefaultIssuanceWindow, tc.CA.IssuanceWindow.Duration)
+			}
+		})
+	}
+}
+
+func InitTestKeys(cfg *Keys) {
+	cfg.AS = "garbage"
+	cfg.CA = "garbage"
+}
+
+func CheckTestKeys(t *testing.T, cfg *Keys) {
+	assert.Empty(t, cfg.AS)
+	assert.Empty(t, cfg.CA)
+}
+
+func TestKeysValidate(t *testing.T) {
+	assert.NoError(t, (&Keys{}).Validate())
+	assert.NoError(t, (&Keys{AS: "pkcs11:token=scion;object=cp-as"}).Validate())
+	assert.Error(t, (&Keys{CA: "crypto/ca/cp-ca.key"}).Validate())
+}
+
 func CheckTestService(t *testing.T, cfg *CAService) {
 	assert.Empty(t, cfg.SharedSecret)
 	assert.Empty(t, cfg.Address)
@@ -49,6 +49,27 @@ max_as_validity = "3d"
 #
 # (default disabled)
 mode = "in-process"
+
+# The maximum number of certificate chains issued per ISD-AS within the
+# issuance window in the in-process mode. Requires the ledger_db to be set.
+# (default 0, no limit)
+max_issued_chains = 0
+
+# The sliding window over which the issued certificate chains are counted for
+# max_issued_chains. (default 24h)
+issuance_window = "24h"
+`
+
+const keysSample = `
+# The PKCS#11 URI that selects the AS private keys on a token, e.g., a hardware
+# security module. The URI must contain the module-path and should reference
+# the PIN with pin-source. If empty, the keys are loaded from the crypto/as
+# directory. (default "")
+as = ""
+
+# The PKCS#11 URI that selects the CA private keys on a token. If empty, the
+# keys are loaded from the crypto/ca directory. (default "")
+ca = ""
 `
 
 const serviceSample = `
@@ -17,21 +17,63 @@ package control
 import (
 	"hash"
 	"path/filepath"
+	"sync"
 
+	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/scrypto"
 	"github.com/scionproto/scion/private/keyconf"
 )
 
-// MACGenFactory creates a MAC factory
-func MACGenFactory(configDir string) (func() hash.Hash, error) {
-	mk, err := keyconf.LoadMaster(filepath.Join(configDir, "keys"))
+// MACGen creates the hashers with which hop field MACs are issued. It uses the current master
+// key, master0.key, which can be reloaded while in use.
+type MACGen struct {
+	configDir string
+
+	mtx     sync.RWMutex
+	keyID   string
+	factory func() hash.Hash
+}
+
+// NewMACGen creates a MAC generator with the master key in the given config directory.
+func NewMACGen(configDir string) (*MACGen, error) {
+	g := &MACGen{configDir: configDir}
+	if _, err := g.Reload(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// New returns a new hasher with the current key.
+func (g *MACGen) New() hash.Hash {
+	g.mtx.RLock()
+	defer g.mtx.RUnlock()
+	return g.factory()
+}
+
+// Reload loads the master key again. It returns whether the key changed. The hashers created
+// before keep the key they were created with.
+//
+// On a key rollover, reload the routers of the AS first: they drop the hop fields issued with a
+// key that they do not know yet.
+func (g *MACGen) Reload() (bool, error) {
+	mk, err := keyconf.LoadMaster(filepath.Join(g.configDir, "keys"))
 	if err != nil {
-		return nil, serrors.Wrap("loading master key", err)
+		return false, serrors.Wrap("loading master key", err)
 	}
-	hfMacFactory, err := scrypto.HFMacFactory(mk.Key0)
+	keyID := keyconf.KeyID(mk.Key0)
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	if keyID == g.keyID {
+		return false, nil
+	}
+	factory, err := scrypto.HFMacFactory(mk.Key0)
 	if err != nil {
-		return nil, err
+		return false, err
+	}
+	if g.keyID != "" {
+		log.Info("Master key changed", "previous", g.keyID, "current", keyID)
 	}
-	return hfMacFactory, nil
+	g.keyID, g.factory = keyID, factory
+	return true, nil
 }
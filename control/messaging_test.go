@@ -0,0 +1,72 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package control_test
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cs "github.com/scionproto/scion/control"
+	"github.com/scionproto/scion/pkg/scrypto"
+	"github.com/scionproto/scion/private/keyconf"
+)
+
+func TestMACGenReload(t *testing.T) {
+	dir := t.TempDir()
+	writeKeys := func(key0, key1 string) {
+		keys := filepath.Join(dir, "keys")
+		require.NoError(t, os.MkdirAll(keys, 0o755))
+		for name, key := range map[string]string{
+			keyconf.MasterKey0: key0,
+			keyconf.MasterKey1: key1,
+		} {
+			raw := base64.StdEncoding.EncodeToString([]byte(key))
+			require.NoError(t, os.WriteFile(filepath.Join(keys, name), []byte(raw), 0o600))
+		}
+	}
+	// macWith returns the MAC of an empty input with the given master key.
+	macWith := func(key string) []byte {
+		newMAC, err := scrypto.HFMacFactory([]byte(key))
+		require.NoError(t, err)
+		return newMAC().Sum(nil)
+	}
+
+	writeKeys("first master key", "older master key")
+	g, err := cs.NewMACGen(dir)
+	require.NoError(t, err)
+	before := g.New()
+	assert.Equal(t, macWith("first master key"), g.New().Sum(nil))
+
+	changed, err := g.Reload()
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	writeKeys("second master key", "first master key")
+	changed, err = g.Reload()
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, macWith("second master key"), g.New().Sum(nil))
+	assert.Equal(t, macWith("first master key"), before.Sum(nil))
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "keys", keyconf.MasterKey0)))
+	_, err = g.Reload()
+	assert.Error(t, err)
+	assert.Equal(t, macWith("second master key"), g.New().Sum(nil))
+}
@@ -36,11 +36,13 @@ import (
 	"github.com/scionproto/scion/private/ca/renewal"
 	api "github.com/scionproto/scion/private/mgmtapi"
 	cppkiapi "github.com/scionproto/scion/private/mgmtapi/cppki/api"
+	"github.com/scionproto/scion/private/mgmtapi/health"
 	healthapi "github.com/scionproto/scion/private/mgmtapi/health/api"
 	segapi "github.com/scionproto/scion/private/mgmtapi/segments/api"
 	"github.com/scionproto/scion/private/storage"
 	beaconstorage "github.com/scionproto/scion/private/storage/beacon"
 	"github.com/scionproto/scion/private/trust"
+	"github.com/scionproto/scion/private/trust/expiry"
 )
 
 type BeaconStore interface {
@@ -54,6 +56,12 @@ type Healther interface {
 	GetCAHealth(context.Context) (CAHealthStatus, bool)
 }
 
+// ExpiryMonitor provides the results of the last scan of the local trust
+// material for expiration.
+type ExpiryMonitor interface {
+	Results() []expiry.Result
+}
+
 // SignerHealthData is used to extract the relevant signer data for the signer health check.
 type SignerHealthData struct {
 	SignerMissing       bool
@@ -91,6 +99,12 @@ type Server struct {
 	Topology       http.HandlerFunc
 	TrustDB        storage.TrustDB
 	Healther       Healther
+	// EnrollmentTokens stores the enrollment tokens created by the CA. If nil,
+	// no enrollment tokens can be created.
+	EnrollmentTokens renewal.EnrollmentTokenStore
+	// Expiry provides the expiration status of the local trust material. If
+	// nil, the expiry health check is omitted.
+	Expiry ExpiryMonitor
 
 	// nowProvider can be set during tests to control the current time.
 	nowProvider func() time.Time
@@ -586,6 +600,150 @@ func (s *Server) GetCa(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetCaIssued lists the certificate chains issued by the CA.
+func (s *Server) GetCaIssued(w http.ResponseWriter, r *http.Request, params GetCaIssuedParams) {
+	w.Header().Set("Content-Type", "application/json")
+	if s.CA.Ledger == nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef("This instance is not configured with an issuance ledger"),
+			Status: http.StatusNotImplemented,
+			Title:  "No issuance ledger",
+			Type:   api.StringRef(api.NotImplemented),
+		})
+		return
+	}
+	var q renewal.IssuedChainsQuery
+	if params.IsdAs != nil {
+		ia, err := addr.ParseIA(*params.IsdAs)
+		if err != nil {
+			ErrorResponse(w, Problem{
+				Detail: api.StringRef(err.Error()),
+				Status: http.StatusBadRequest,
+				Title:  "malformed query parameters",
+				Type:   api.StringRef(api.BadRequest),
+			})
+			return
+		}
+		q.IAs = []addr.IA{ia}
+	}
+	if params.Since != nil {
+		q.Since = *params.Since
+	}
+	if params.Until != nil {
+		q.Until = *params.Until
+	}
+	chains, err := s.CA.Ledger.IssuedChains(r.Context(), q)
+	if err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "error getting issued chains",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+	rep := make([]IssuedChain, 0, len(chains))
+	for _, c := range chains {
+		rep = append(rep, IssuedChain{
+			CaFingerprint: fmt.Sprintf("% X", c.CAFingerprint),
+			IsdAs:         c.Subject.String(),
+			IssuedAt:      c.IssuedAt,
+			Serial:        c.Serial.Text(16),
+			Source:        c.Source,
+			Validity: Validity{
+				NotAfter:  c.NotAfter,
+				NotBefore: c.NotBefore,
+			},
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(rep); err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+}
+
+// PostCaEnrollmentToken creates a single-use enrollment token for a new AS.
+func (s *Server) PostCaEnrollmentToken(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if s.EnrollmentTokens == nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef("This instance is not configured with an enrollment " +
+				"token store"),
+			Status: http.StatusNotImplemented,
+			Title:  "No enrollment token store",
+			Type:   api.StringRef(api.NotImplemented),
+		})
+		return
+	}
+	var req EnrollmentTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusBadRequest,
+			Title:  "malformed enrollment token request",
+			Type:   api.StringRef(api.BadRequest),
+		})
+		return
+	}
+	ia, err := addr.ParseIA(req.IsdAs)
+	if err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusBadRequest,
+			Title:  "malformed enrollment token request",
+			Type:   api.StringRef(api.BadRequest),
+		})
+		return
+	}
+	now := s.now()
+	validity := renewal.DefaultEnrollmentTokenValidity
+	if req.Expiration != nil {
+		validity = req.Expiration.Sub(now)
+	}
+	token, record, err := renewal.NewEnrollmentToken(ia, validity, now)
+	if err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusBadRequest,
+			Title:  "invalid enrollment token request",
+			Type:   api.StringRef(api.BadRequest),
+		})
+		return
+	}
+	if err := s.EnrollmentTokens.InsertEnrollmentToken(r.Context(), record); err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "error storing enrollment token",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+	rep := EnrollmentToken{
+		Expiration: record.Expiration,
+		IsdAs:      ia.String(),
+		Token:      token,
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(rep); err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+}
+
 // GetTrcs gets the trcs specified by it's params.
 func (s *Server) GetTrcs(
 	w http.ResponseWriter,
@@ -815,6 +973,15 @@ func (s *Server) GetHealth(w http.ResponseWriter, r *http.Request) {
 		}
 		checks = append(checks, caCheck)
 	}
+	if s.Expiry != nil {
+		expiryCheck := health.ExpiryCheck(s.Expiry.Results())
+		checks = append(checks, Check{
+			Data:   CheckData(expiryCheck.Data),
+			Detail: expiryCheck.Detail,
+			Name:   expiryCheck.Name,
+			Status: Status(expiryCheck.Status),
+		})
+	}
 	rep := HealthResponse{
 		Health: Health{
 			Status: Status(healthapi.AggregateHealthStatus(
@@ -15,10 +15,13 @@
 package mgmtapi_test
 
 import (
+	"context"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -46,6 +49,7 @@ import (
 	"github.com/scionproto/scion/private/ca/renewal/mock_renewal"
 	"github.com/scionproto/scion/private/storage/beacon"
 	"github.com/scionproto/scion/private/trust"
+	"github.com/scionproto/scion/private/trust/expiry"
 )
 
 var update = xtest.UpdateGoldenFiles()
@@ -435,6 +439,44 @@ func TestAPI(t *testing.T) {
 			RequestURL: "/ca",
 			Status:     500,
 		},
+		"ca issued": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				l := mock_renewal.NewMockLedger(ctrl)
+				s := &api.Server{
+					CA: renewal.ChainBuilder{
+						Ledger: l,
+					},
+				}
+				l.EXPECT().IssuedChains(gomock.Any(), renewal.IssuedChainsQuery{
+					IAs:   []addr.IA{addr.MustParseIA("1-ff00:0:111")},
+					Since: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				}).Return(issuedChains(), nil)
+				return api.Handler(s)
+			},
+			RequestURL: "/ca/issued?isd_as=1-ff00:0:111&since=2021-01-01T00:00:00Z",
+			Status:     200,
+		},
+		"ca issued malformed isd_as": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				s := &api.Server{
+					CA: renewal.ChainBuilder{
+						Ledger: mock_renewal.NewMockLedger(ctrl),
+					},
+				}
+				return api.Handler(s)
+			},
+			RequestURL:         "/ca/issued?isd_as=garbage",
+			Status:             400,
+			IgnoreResponseBody: true,
+		},
+		"ca issued no ledger": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				return api.Handler(&api.Server{})
+			},
+			RequestURL:         "/ca/issued",
+			Status:             501,
+			IgnoreResponseBody: true,
+		},
 		"health": {
 			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
 				h := mock_mgmtapi.NewMockHealther(ctrl)
@@ -467,6 +509,50 @@ func TestAPI(t *testing.T) {
 			TimestampOffset: 10 * time.Hour,
 			Status:          200,
 		},
+		"health trust material expiry": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				h := mock_mgmtapi.NewMockHealther(ctrl)
+				s := &api.Server{
+					Healther: h,
+					Expiry: staticExpiry{
+						{
+							Entry: expiry.Entry{
+								Kind:     expiry.KindAS,
+								Subject:  "1-ff00:0:110",
+								ID:       "42",
+								NotAfter: now.Add(5 * time.Hour),
+							},
+							Remaining: 5 * time.Hour,
+							Level:     expiry.LevelCritical,
+						},
+					},
+				}
+				h.EXPECT().GetSignerHealth(gomock.Any()).Return(
+					api.SignerHealthData{
+						SignerMissing: false,
+						Expiration:    now.Add(5 * time.Hour),
+						InGrace:       false,
+					},
+				)
+				h.EXPECT().GetTRCHealth(gomock.Any()).Return(
+					api.TRCHealthData{
+						TRCNotFound: false,
+						TRCID: cppki.TRCID{
+							Base:   2,
+							Serial: 1,
+							ISD:    12,
+						},
+					},
+				)
+				h.EXPECT().GetCAHealth(gomock.Any()).Return(
+					api.Available, false,
+				)
+				return api.Handler(s)
+			},
+			RequestURL:      "/health",
+			TimestampOffset: 5 * time.Hour,
+			Status:          200,
+		},
 		"health expired signer": {
 			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
 				h := mock_mgmtapi.NewMockHealther(ctrl)
@@ -911,6 +997,135 @@ func TestAPI(t *testing.T) {
 	}
 }
 
+func TestPostCaEnrollmentToken(t *testing.T) {
+	ia := addr.MustParseIA("1-ff00:0:111")
+	expiration := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	unusedStore := func(
+		ctrl *gomock.Controller,
+		_ *renewal.EnrollmentToken,
+	) renewal.EnrollmentTokenStore {
+
+		return mock_renewal.NewMockEnrollmentTokenStore(ctrl)
+	}
+	recordingStore := func(
+		ctrl *gomock.Controller,
+		stored *renewal.EnrollmentToken,
+	) renewal.EnrollmentTokenStore {
+
+		s := mock_renewal.NewMockEnrollmentTokenStore(ctrl)
+		s.EXPECT().InsertEnrollmentToken(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, token renewal.EnrollmentToken) error {
+				*stored = token
+				return nil
+			},
+		)
+		return s
+	}
+	testCases := map[string]struct {
+		Body       string
+		Store      func(*gomock.Controller, *renewal.EnrollmentToken) renewal.EnrollmentTokenStore
+		Status     int
+		Expiration func(now time.Time) time.Time
+	}{
+		"default expiration": {
+			Body:   `{"isd_as": "1-ff00:0:111"}`,
+			Store:  recordingStore,
+			Status: 200,
+			Expiration: func(now time.Time) time.Time {
+				return now.Add(renewal.DefaultEnrollmentTokenValidity)
+			},
+		},
+		"expiration": {
+			Body: fmt.Sprintf(`{"isd_as": "1-ff00:0:111", "expiration": %q}`,
+				expiration.Format(time.RFC3339)),
+			Store:  recordingStore,
+			Status: 200,
+			Expiration: func(time.Time) time.Time {
+				return expiration
+			},
+		},
+		"expiration too late": {
+			Body: fmt.Sprintf(`{"isd_as": "1-ff00:0:111", "expiration": %q}`,
+				time.Now().Add(30*24*time.Hour).Format(time.RFC3339)),
+			Store:  unusedStore,
+			Status: 400,
+		},
+		"malformed isd_as": {
+			Body:   `{"isd_as": "garbage"}`,
+			Store:  unusedStore,
+			Status: 400,
+		},
+		"malformed body": {
+			Body:   `{`,
+			Store:  unusedStore,
+			Status: 400,
+		},
+		"store error": {
+			Body: `{"isd_as": "1-ff00:0:111"}`,
+			Store: func(
+				ctrl *gomock.Controller,
+				_ *renewal.EnrollmentToken,
+			) renewal.EnrollmentTokenStore {
+
+				s := mock_renewal.NewMockEnrollmentTokenStore(ctrl)
+				s.EXPECT().InsertEnrollmentToken(gomock.Any(), gomock.Any()).Return(
+					serrors.New("internal"),
+				)
+				return s
+			},
+			Status: 500,
+		},
+		"no store": {
+			Body:   `{"isd_as": "1-ff00:0:111"}`,
+			Status: 501,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			var stored renewal.EnrollmentToken
+			s := &api.Server{}
+			if tc.Store != nil {
+				s.EnrollmentTokens = tc.Store(ctrl, &stored)
+			}
+			req, err := http.NewRequest("POST", "/ca/enrollment-tokens",
+				strings.NewReader(tc.Body))
+			require.NoError(t, err)
+			now := time.Now()
+			rr := httptest.NewRecorder()
+			api.Handler(s).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.Status, rr.Result().StatusCode)
+			if tc.Status != 200 {
+				return
+			}
+			var rep api.EnrollmentToken
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &rep))
+			assert.Equal(t, ia.String(), rep.IsdAs)
+			assert.Equal(t, renewal.HashEnrollmentToken(rep.Token), stored.Hash)
+			assert.Equal(t, ia, stored.Subject)
+			assert.True(t, stored.Expiration.Equal(rep.Expiration))
+			assert.WithinDuration(t, tc.Expiration(now), rep.Expiration, time.Second)
+		})
+	}
+}
+
+func issuedChains() []renewal.IssuedChain {
+	return []renewal.IssuedChain{
+		{
+			Subject:       addr.MustParseIA("1-ff00:0:111"),
+			Serial:        big.NewInt(0x4a3f0c),
+			NotBefore:     time.Date(2021, 1, 1, 8, 0, 0, 0, time.UTC),
+			NotAfter:      time.Date(2021, 1, 4, 8, 0, 0, 0, time.UTC),
+			CAFingerprint: []byte{0x89, 0xb9, 0x49, 0xc2},
+			Source:        "1-ff00:0:111,127.0.0.1:31000",
+			IssuedAt:      time.Date(2021, 1, 1, 8, 0, 1, 0, time.UTC),
+		},
+	}
+}
+
 func createBeacons(t *testing.T) []beacon.Beacon {
 	return []beacon.Beacon{
 		{
@@ -1019,3 +1234,9 @@ func (m queryMatcher) Matches(x any) bool {
 func (m queryMatcher) String() string {
 	return fmt.Sprintf("%v with ValidAt around %s", m.query, m.creationTime)
 }
+
+type staticExpiry []expiry.Result
+
+func (s staticExpiry) Results() []expiry.Result {
+	return s
+}
@@ -104,6 +104,14 @@ type ClientInterface interface {
 	// GetCa request
 	GetCa(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PostCaEnrollmentTokenWithBody request with any body
+	PostCaEnrollmentTokenWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostCaEnrollmentToken(ctx context.Context, body PostCaEnrollmentTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCaIssued request
+	GetCaIssued(ctx context.Context, params *GetCaIssuedParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetCertificates request
 	GetCertificates(ctx context.Context, params *GetCertificatesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -221,6 +229,42 @@ func (c *Client) GetCa(ctx context.Context, reqEditors ...RequestEditorFn) (*htt
 	return c.Client.Do(req)
 }
 
+func (c *Client) PostCaEnrollmentTokenWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostCaEnrollmentTokenRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostCaEnrollmentToken(ctx context.Context, body PostCaEnrollmentTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostCaEnrollmentTokenRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCaIssued(ctx context.Context, params *GetCaIssuedParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCaIssuedRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetCertificates(ctx context.Context, params *GetCertificatesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetCertificatesRequest(c.Server, params)
 	if err != nil {
@@ -723,6 +767,127 @@ func NewGetCaRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
+// NewPostCaEnrollmentTokenRequest calls the generic PostCaEnrollmentToken builder with application/json body
+func NewPostCaEnrollmentTokenRequest(server string, body PostCaEnrollmentTokenJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostCaEnrollmentTokenRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostCaEnrollmentTokenRequestWithBody generates requests for PostCaEnrollmentToken with any type of body
+func NewPostCaEnrollmentTokenRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/ca/enrollment-tokens")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetCaIssuedRequest generates requests for GetCaIssued
+func NewGetCaIssuedRequest(server string, params *GetCaIssuedParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/ca/issued")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.IsdAs != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "isd_as", runtime.ParamLocationQuery, *params.IsdAs); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Since != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since", runtime.ParamLocationQuery, *params.Since); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Until != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "until", runtime.ParamLocationQuery, *params.Until); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewGetCertificatesRequest generates requests for GetCertificates
 func NewGetCertificatesRequest(server string, params *GetCertificatesParams) (*http.Request, error) {
 	var err error
@@ -1487,6 +1652,14 @@ type ClientWithResponsesInterface interface {
 	// GetCaWithResponse request
 	GetCaWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetCaResponse, error)
 
+	// PostCaEnrollmentTokenWithBodyWithResponse request with any body
+	PostCaEnrollmentTokenWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostCaEnrollmentTokenResponse, error)
+
+	PostCaEnrollmentTokenWithResponse(ctx context.Context, body PostCaEnrollmentTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*PostCaEnrollmentTokenResponse, error)
+
+	// GetCaIssuedWithResponse request
+	GetCaIssuedWithResponse(ctx context.Context, params *GetCaIssuedParams, reqEditors ...RequestEditorFn) (*GetCaIssuedResponse, error)
+
 	// GetCertificatesWithResponse request
 	GetCertificatesWithResponse(ctx context.Context, params *GetCertificatesParams, reqEditors ...RequestEditorFn) (*GetCertificatesResponse, error)
 
@@ -1660,6 +1833,52 @@ func (r GetCaResponse) StatusCode() int {
 	return 0
 }
 
+type PostCaEnrollmentTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *EnrollmentToken
+	JSON400      *BadRequest
+}
+
+// Status returns HTTPResponse.Status
+func (r PostCaEnrollmentTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostCaEnrollmentTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCaIssuedResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]IssuedChain
+	JSON400      *BadRequest
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCaIssuedResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCaIssuedResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type GetCertificatesResponse struct {
 	Body                      []byte
 	HTTPResponse              *http.Response
@@ -2113,6 +2332,32 @@ func (c *ClientWithResponses) GetCaWithResponse(ctx context.Context, reqEditors
 	return ParseGetCaResponse(rsp)
 }
 
+// PostCaEnrollmentTokenWithBodyWithResponse request with arbitrary body returning *PostCaEnrollmentTokenResponse
+func (c *ClientWithResponses) PostCaEnrollmentTokenWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostCaEnrollmentTokenResponse, error) {
+	rsp, err := c.PostCaEnrollmentTokenWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostCaEnrollmentTokenResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostCaEnrollmentTokenWithResponse(ctx context.Context, body PostCaEnrollmentTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*PostCaEnrollmentTokenResponse, error) {
+	rsp, err := c.PostCaEnrollmentToken(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostCaEnrollmentTokenResponse(rsp)
+}
+
+// GetCaIssuedWithResponse request returning *GetCaIssuedResponse
+func (c *ClientWithResponses) GetCaIssuedWithResponse(ctx context.Context, params *GetCaIssuedParams, reqEditors ...RequestEditorFn) (*GetCaIssuedResponse, error) {
+	rsp, err := c.GetCaIssued(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCaIssuedResponse(rsp)
+}
+
 // GetCertificatesWithResponse request returning *GetCertificatesResponse
 func (c *ClientWithResponses) GetCertificatesWithResponse(ctx context.Context, params *GetCertificatesParams, reqEditors ...RequestEditorFn) (*GetCertificatesResponse, error) {
 	rsp, err := c.GetCertificates(ctx, params, reqEditors...)
@@ -2443,6 +2688,72 @@ func ParseGetCaResponse(rsp *http.Response) (*GetCaResponse, error) {
 	return response, nil
 }
 
+// ParsePostCaEnrollmentTokenResponse parses an HTTP response from a PostCaEnrollmentTokenWithResponse call
+func ParsePostCaEnrollmentTokenResponse(rsp *http.Response) (*PostCaEnrollmentTokenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostCaEnrollmentTokenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest EnrollmentToken
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCaIssuedResponse parses an HTTP response from a GetCaIssuedWithResponse call
+func ParseGetCaIssuedResponse(rsp *http.Response) (*GetCaIssuedResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCaIssuedResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []IssuedChain
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseGetCertificatesResponse parses an HTTP response from a GetCertificatesWithResponse call
 func ParseGetCertificatesResponse(rsp *http.Response) (*GetCertificatesResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
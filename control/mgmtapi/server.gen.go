@@ -28,6 +28,12 @@ type ServerInterface interface {
 	// Information about the CA.
 	// (GET /ca)
 	GetCa(w http.ResponseWriter, r *http.Request)
+	// Create an enrollment token
+	// (POST /ca/enrollment-tokens)
+	PostCaEnrollmentToken(w http.ResponseWriter, r *http.Request)
+	// List the certificate chains issued by the CA
+	// (GET /ca/issued)
+	GetCaIssued(w http.ResponseWriter, r *http.Request, params GetCaIssuedParams)
 	// List the certificate chains
 	// (GET /certificates)
 	GetCertificates(w http.ResponseWriter, r *http.Request, params GetCertificatesParams)
@@ -118,6 +124,18 @@ func (_ Unimplemented) GetCa(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Create an enrollment token
+// (POST /ca/enrollment-tokens)
+func (_ Unimplemented) PostCaEnrollmentToken(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the certificate chains issued by the CA
+// (GET /ca/issued)
+func (_ Unimplemented) GetCaIssued(w http.ResponseWriter, r *http.Request, params GetCaIssuedParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List the certificate chains
 // (GET /certificates)
 func (_ Unimplemented) GetCertificates(w http.ResponseWriter, r *http.Request, params GetCertificatesParams) {
@@ -399,6 +417,63 @@ func (siw *ServerInterfaceWrapper) GetCa(w http.ResponseWriter, r *http.Request)
 	handler.ServeHTTP(w, r)
 }
 
+// PostCaEnrollmentToken operation middleware
+func (siw *ServerInterfaceWrapper) PostCaEnrollmentToken(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostCaEnrollmentToken(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCaIssued operation middleware
+func (siw *ServerInterfaceWrapper) GetCaIssued(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCaIssuedParams
+
+	// ------------- Optional query parameter "isd_as" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "isd_as", r.URL.Query(), &params.IsdAs)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "isd_as", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", r.URL.Query(), &params.Since)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "until" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "until", r.URL.Query(), &params.Until)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "until", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCaIssued(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetCertificates operation middleware
 func (siw *ServerInterfaceWrapper) GetCertificates(w http.ResponseWriter, r *http.Request) {
 
@@ -963,6 +1038,12 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/ca", wrapper.GetCa)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/ca/enrollment-tokens", wrapper.PostCaEnrollmentToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/ca/issued", wrapper.GetCaIssued)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/certificates", wrapper.GetCertificates)
 	})
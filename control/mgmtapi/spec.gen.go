@@ -18,85 +18,96 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+x8bXPbNrbwX8Fw90M7K8myY29rzTwfFNlp9WyTeGx1d6ZNrgORRyIaCGAB0Laur/77",
-	"HbyQAklQouwkze5tJh8sCjw472840GMU81XGGTAlo9FjJEBmnEkwH17i5Bp+z0Eq/SnmTAEzf+IsoyTG",
-	"inB29JvkTD+TcQorrP/6q4BFNIr+crQFfWS/lUc3CrMEi+RSCC6izWbTixKQsSCZBhaN9J5IuE03vWjK",
-	"FAiG6ZdDoNgR3YC4A4GKhT23geUM4Njuiil9u4hGv+7ZFZYrjfqm9xhlgmcgFLE8JmwpQMpbordd4Bj0",
-	"wzpGZgkqlyC+QCoFNDdYDKJepNYZRKNIr1iC0IzLJV7aHXbhZen42a7VNGrWEwFJNPq1ANEL4Pi+3JLP",
-	"f4NYRRv9hCiqH91Mpm/foAyrtC8t3SjmTCqRx5oih7ZG0m7/A6hrp3b/38myyqN5ye39tDSocC83MS62",
-	"N9Rr4MDylaE7uxWwJFIJo2BRL0r4Pas/i7mA+jONNl7aTx5DxpTye0iQ3Q8ZvnpSk0oQtqwhZJVDweoQ",
-	"GWoYxaY/Eam0omC3+dzbXHq7YyHwOupFOSO/5zC1OyqRw6YXTcZNYcQg1O0dpiQhar0Pt38W6za9KOOU",
-	"xHvfuLKrtLnlVlD7DDov5eneuP0I61uSdHzxH7CeXjS0pti8AbSko1fjREjBJpptC+2ooMnIhEhF2DIn",
-	"MoXkluGVWdPQCSKTW7xXCaYyGcs6DzBdcqPYD3iVGaW4nFzcjEOa9xzW9aLD1aHG7gAvSso98AHyGqh7",
-	"duexH/kuNSSpFJOA5yFS5iD2keWLubviVt5qVT+HQQtVsUa7E20vBdGoNAjcK2vzthVzN27UVbHz+mdr",
-	"kTHPBus8wB4XDT9Q/CReTi+qVrXAZy/w8BRHvWjBxQqraBSl8NB35rVLdNMEmH5k8GxY5SSF+GPAc2CF",
-	"94sN4o8XeqHJcBQmtJlZjJOE6D8xRYRZ1IlNKLbEhfAqnFUV2hu8MqlJCpiqFMUagyosIwgkyZKBQPgO",
-	"E4rnFEI7CMAuFajucW2eowUXFj5aYEJzAftxlgqrXHZID/WqumY5j+Rg9KwEPG360ZI8KUgO6E0hDp0z",
-	"lmy/8uSqY+4W4isBoMlcoe1qpLc1tOvsr87mxp4WqUAE12/IJm+LjMEHbDKFTmmI1dVNLa94LuNLjjuk",
-	"/TQzX62wWHsY28UIs8RDvoUtRcbZZE9asm0Xvo65dXzdyz6aIO5IXIqrZmdN7HgW8NJ+cVCq+elJKPE/",
-	"KF+oO9Ai4lYzfUfJFdY8dhl9yrMQ+hZuxTse9xeL4XA0HB0fD3X6hJUusKJR9F/v3iV/63/zK+4vhv3z",
-	"94/HvdPN6NvHk0310bf/o9f91XOj05uL/vhmj+/8iS9/gjugTW7S4nFN/flySdgS2a97ZTmQwDxfGp4s",
-	"dLoBph5877sb900NhRpvLdhQlnhVJsZ1O8WE3VKyAEVWVdFH352kw9VQ7t21BiO4veBzCqtAmGmLGijN",
-	"V5ghATjR/hvBQ0YxMzqNZAaxDnFIcaRSIhGP41wIYNuyNbMbIpVihYhEKdBskVP9BuUmNvqrtDUvyR0g",
-	"nBg74gyl/F4vzgSPAZIB+pcgSgFDhKFLtqREpuatEj/tMYEtCQMQsodymWNK14hxhWROFCRmBeMMKYhT",
-	"RmJMtS/5CCmnCQjrUfRqjR4l/w1JNdxMOGNga1vFjZOeYwlIczxBPFch9SRMKsxC5f4Y/Xw9RQIWYLlm",
-	"2VToujTMKbncyt0egsFygOZrEz/YEmG0ENjabglMIC6QzOd9XaxbiXniWWcwQK/xGs0B5RKSmoAE58pu",
-	"SmT5EmEWP56LGFDMk1pkPnILj+KSZ31jUX9R/COwvjalvhZc33Cvb7lXZlW5IP2SM7ujfJWpsxTQj7PZ",
-	"VREjNGZoCQwE1vKfrw3aXJAlYUjazo8NtLtUuELb2fBFL1rhB7LSfuPs/LwXrQizn46Hw5Cvdg6tqQEy",
-	"5UIrZxnhmoL5o5W+iGs/s52JnH2gKVzgnGoZ4jnP1WhOMfsY9brovu1M0HXdCHx+IM70Aqt9plH4oDy+",
-	"3ZEEEjS+mg7Q2yzjTpl9S7LeizB0/WrS/+774Xc9RIx3YkBUCgIJiPlqBSyx7851SVkgahiu+ZVxwpT+",
-	"Glsf2S/FkfA418Zn92FcoCXlcyMSS1+Z11XE3M14DjCRtvzKqmIoPhS9y0Z8gIeMuNbX6HGLQIIVGOsN",
-	"qUPKs+6dLZ0LBRLKDv0Ji7KtWimW6jbPNFpJd0T1c6nwKuv6SqgW3QLp+dyq4eS4EuyglvnWnrrUUdxS",
-	"5QNLbg/sIx3KZGBLmzTXkirzvLBER0xFq49DjlEqLNTts1LZJKqB6flsKDFutASezPtGV2B+epacniZ7",
-	"uwLu/T357I2pmpuyxfI2rrYZD2hVVU24Kjq7IdouQWRlXed87boX2uXNrieoaLBU3dXJ8OSkPzzuD09n",
-	"w/PR2fnoxYtffGbstj8Rd2hEzq4nVv/Mcna7FDiG2wwE4UkgCbie2EQGS6RELpXNYYjUft+8iuyrPUOZ",
-	"1liKFUhliIwxY1y9Y0U2VAEyeOepxpxzCrh5FFFxATW5lRSHafH7f5wpwSnSOTcUzRSvrAyqaOXUq+kf",
-	"isdVfpnVaAXSnC3s83hlYRTa3SVlRU2VYSmtESSwFDgxXnCBCdUPK7XVdmWt1+ISudKzmGwkeKpys+1D",
-	"1ru7zy6Vg+T63fGKS/j+HL08R6fnaHKCTl7p/+cTdHGBhhfoZIzOvkPjc3Rxib6/NF+doVcv0PAcHQ/R",
-	"xbFvODLDMST9qjOpUz27ngScRa5SLojOQu7gFssDjpnKyFAPx+Yg7NOAqqhf6Cyku0P4NM1k7+RhS2Yv",
-	"xMYq8p65atexJ4DMridPbs87gpvINwJbN0SsytbOX7GEW5av5jb+7A7dRCYdulQSBME0BPRFc3nT9KJe",
-	"Bak6vBr7Q4HVI5pnnPLlem9ntv7iPz0VqzKMcXWLF6pG2fMCooY5hwUX0AB6/ESg9Sb3doeeR4LHzIJi",
-	"Fyab3NxsXJ+sWdNeTcsKx6ZYRRxzhWTUjHBFiTm+mmpbBCEtrOFgODjWPOEZMJyRaBS9GAwHJ7a7mBoR",
-	"HNnzbvP3ElRLt3uLjVtuK04sAH1k/J4VVWLsMCrCDJqlgATInCqpEwNdDi4IVSC2zQSTfKLxTQ+RxgCH",
-	"Ti/MSXxtlAO9XCNXKfcQphTlzCQN5fm9NLgJULlgkGg8iERzSPEd4aLAJE4xW0KC7olKDfQPmNIPZtMP",
-	"xqPdYvUBZVjgFSgQpk2u1dekD9MkGkU/gHrp+Kd5Wiw0cy61LNFQ6TqyfFGgaTmEk8QQrvEiLKZ5Auie",
-	"0CTGIpHom+G3aM5VWurF9ObCIDm+8VpU1Zyy1kwmGoXfcxDaQ9tTqXrS320sqAzydfpe2xZOOUZh5ycK",
-	"sRWC2JL9ltF1U5mKt3XOTKl51QFyLQuqtfGeUKrlV4rXJ73bXMr7ME/KUZ5u3KiPBe2fSCJVZE/CaDQH",
-	"iXyMyt7Z38/OXpx53bNhKCQ0kvui1kZYofuUxGlDOkYUxgAGaLpAOZNgXIDrGpken0LaYWp/qesCneg7",
-	"IzMNphRLhBmCxQJihcjCWNb/W2Aq4UOj+DnuHx/3T85mxyejk+HobDg4O/mlRWcLq6zwo5sLb8rG2llB",
-	"s4AlFgnV4uILv5ozx2QC7AcNfdCCHKa0glfZyjN0h6qeOk7/SsH00BRHArQfB9clFgpxkYBA32AZAzON",
-	"6nnpAr9tw0hDfyZKY6UEmecK9H6Fulh/rrVEo2ZFbzQmB/TB9ysfbI9SFvHB+T+/sW4dxIIIaQ7LqtpR",
-	"qQSDTowLFaaw3jsqSqoKSL/xVPOHtdd3zfaVSva+Vx0MPRkODxrIDI3zHTrg1qwXNsH0I3ymvcIqTrV2",
-	"VaL9QAM9tcSEMCiJPvJGYc0oqO3Mt6YRWgR4Kf35Q/1akZQcPbrWUp8kGytdCipwEnBhnjfgbyP7ktwB",
-	"KxtV04tmKLcgHA/3BPPZtkeHphfV3KRs3mnXqR8TluXKGQeR9shC23iKGcIemKKRrgknicmQMMoELMiD",
-	"8UE6IJbi8T21ZUrhf603XuTUpAvmO/8FXQ4kiBtXTgSi7jBXb2+tm9hDg+MTNF8rKBBwJOJY5Zj6fDT9",
-	"HHjIKE+gdCvGUnWK6RlqKcjIz6ZtydBxJNlvpEq1Nh5CEuMqAqZ32lQTN1jqGIZkHscg5SKndP00Fe9F",
-	"Z11eKaezqzbRorUho+iFk/MfbGD261Uzy7o9+PQB78hf/yCNn+fK6nR5VOVrW3VDeMCxomvEWbFxr0hK",
-	"TG5vUCGylhV+hYo5/GRD+uG58IB7rzjFymjNsx17oYKVLWr9k64u/mhO+by1Eg3upN/QxcHV5WsELOY6",
-	"Ndqh5y/1Bg1d/7dTk4d+Bqv+gtBak6Ov/728/GH6Bl2NZz+im8sfXl++mZnH75hhnOXDYDB4x8zjyzcX",
-	"obXRHiUykvo8yjO3MgpqTYw99WjIeIKjz2htk3HQtMoggt4W+DyfMdOtjSIzCGDYNBkPPMbEWfaRFHzZ",
-	"HpF0aOW4Eo6udUAnd9Cc721p8LxjOzo8oQaPTfgH6FUudGWz4gJ675h24XpxhqXUSQ4WisQ5xcINBhBb",
-	"aG0rVIP0Fsd3zCFZFqoISxt2BmiMXDlT4FPONZiySgcHnUu9Yz7PerX6z2ZHtn2nP99h4o7uTMLT1Dyf",
-	"/w3/Eqzxn9x4+eSFcZditlEpPjeudRyWLUfym2VNaxHT1GbPIFsQdCMjfzvMJRQzgcH7cVYxxe5yKIDr",
-	"fgs/ejRLi6poZ7Rsju7rugC7isjN6e/X6halrgbJAqsnh8jyEsVnTZvsJZaAzBr3Dr46vWmV6mFa0y3R",
-	"aqqOybDsmb5OuHSFKG0K9iSlCmdjX5NidUi0JpfXs+mr6WQ8u3S50/jGV6RqqtVcvRPUZHwIqKiDStcz",
-	"t69cr+vZYEW5OVuQ5c6E0K7YK3IFD+ooo+5uWyPqlcHyC2V/V4IwZSvi2dvXPyFLaO4a0lobK3kgX63K",
-	"BHl7KyNo2lcCpC6pvYsx1ckQhClny23jDB4gzhUkzdsuDWa7qx6f0XHXrqSE5LHjFsknSMoTUo51y8pO",
-	"vjyKuy1GHsUpb5uGTu2diH8v/XyJJYl95qIML8ErVGpVgr2CIGWr1lK+PCqvm7Sxqryp8hk1rNzji/FS",
-	"ez5au1LT4FEvyvIAU25qTDHwX/Jk/UX4UVwE8vffRubNf5SUbrpISWuy6xN1nqfwB2jbpioOn6bALEFg",
-	"JwRqI8VoymQGsXKN2oTckcRr6UuXyOlCHdmLTZCgOwL3QZd/U1B74PRDaMD5y88szECsCMMU7UDqpEDq",
-	"pBWpyrj0YSh9kSK6MvN+QBldOwusaOrg662oA9h6xuoe1az16QeNlQH4g48bizsjTzp98bf+vKeNVSfV",
-	"+cyx+tr/6ZPH4G0Jw8KvwZDKY8wvh0Hrj0e1n4763Ata9DMPSSv2tCPa/QecHx34W2DFr3K1nQlV9Lql",
-	"5vq6+gz7by91jxeHnFpWdmztpu3Svj9PMK+wSou7YE8/x6xI4qvuibXh26qk5Q24tkra3ZH7nC7D7vCl",
-	"O2YkeGw6vkF+G7S4o6/55Hcr+vammLvH1XbSarn71Aa66ZVX7b6lTW45OHG9/T9b1p9u2OCgHrPybr20",
-	"mVN5M+YzGlS5xx/RhHYUlOP84xtU8GV3N1qJuEMnxF0etX5uZu6KXnOu0MRve9vOBOA4NfcfDr5/0jKd",
-	"MEBvM3uTia7tVZLZ9aTsrjjHbG4kSAXYzAKYCXcPb84g3BCfaeq7hermcECll1DW+YHfxmj8jJQNy9oR",
-	"Rl/36X55n++ApoTbdk7BCOpTTiZreG1eQMTyiMjkkchk058/6lp205eP9jrdpmPy16baLRFgJuJOh6NW",
-	"Wdozup1XDEsNqcLUBHYDetwZpmVWN6ih242fs8SZXU9CWje7nnzCEUm9yZP065AKo03JiiqjSD5Mj8UU",
-	"G63a1/l4/k8NfGIiNrueuDzol9/G929/G//99ezyflrLmraroqCKfuL8qIQY0FV7Jfmu0IVc0GgUpUpl",
-	"o6Ojx5RLtRk9ZlyojbkULoh21PbXArlUtfs5PMbUPDY/eixqX78Ynp6daJt8X6LR+N2FOxBrZTqUAqj5",
-	"gSrFw93qehUcNU8DdkGbXF39Y4pWWBkF8sBZxjSBTUwWhMZXUwQP5a+BWGAuOfGxcklTACmWmJFI6ePk",
-	"Hd5vf90hANUdRG/eb/43AAD//y7RBnW/XgAA",
+	"H4sIAAAAAAAC/+x9bXPjuJH/V0ExeZGtULIs2zuxqv4vNLJnV//s7PhsJblLPOeByJaIHQrgAqBtnU/f",
+	"/QoPpEASlCjbMzu5SyovxhQINPoJ3b9ucJ+CiK0yRoFKEYyeAg4iY1SA/uMtjq/h1xyEVH9FjEqg+p84",
+	"y1ISYUkYPfpFMKqeiSiBFVb/+j2HRTAKfne0nfrI/CqObiSmMebxJeeMB5vNJgxiEBEnmZosGKk1EbeL",
+	"bsJgSiVwitOvR0CxIroBfg8cFQNDu4DhDODIrIrT9MMiGP1jz6qwXCnSN+FTkHGWAZfE8JjQJQch7oha",
+	"doEjUA/rFOkhqByC2ALJBNBcU9EPwkCuMwhGgRqxBK4Ylwu8NCvsosvs4y9mrNqjYj3hEAejfxRThB4a",
+	"P5ZLsvkvEMlgo54QmapHN5Pph59RhmXSE2bfKGJUSJ5HakeWbEWkWf4HkNdW7f6/lWWVR/OS2/v30tiF",
+	"fblJcRg4u1eTA81Xet/ZHYclEZJrBQvCIGYPtP4sYhzqzxTZeGn+chgyTlP2ADEy6yHNV0dqQnJClzWC",
+	"jHJIWB0iw2CzXfQnIqRSFGwXnzuLC2d1zDleB2GQU/JrDlOzouQ5bMJgMm4KIwIu7+5xSmIi1/to+2sx",
+	"bhMGGUtJtPeNKzNKmVtuBLXPoPNSnvaNu8+wviNxxxf/DOvpRUNrisUbk5b7CGuc8CnYRLFtoRwVNBkZ",
+	"EyEJXeZEJBDfUbzSYxo6QUR8h/cqwVTEY1HnAU6XTL0Ij3iVaaW4nFzcjH2a9xLWhcHh6lBjt4cX5c6d",
+	"6T3ba5Du2J3DfuS6VJ+kEkw8nocIkQPfty1XzN0Vt/JWq/pZClp2FSmyO+3tLSew8Gxwr6z120bM3bhR",
+	"V8XO41+sRdo8G6xzJna4qPmBomfxcnpRtaoFPjvBg1MchMGC8RWWwShI4LFnzWuX6KYxUPUI+Ha1rVVO",
+	"Eog+ezwHlni/2CD6fKEG6ghHYpI2I4txHBP1T5wiQg3pxAQU28356CqcVXW2n/FKhyYJ4FQmKFIUVOfS",
+	"gkCCLClwhO8xSfE8Bd8KHLANBaprXOvnaMG4mR8tMElzDvtpFhLLXHQID9WoumZZj2TnCI0EHG360Wx5",
+	"UmzZozeFOFTMWLL9ypGrOnO3M77jAGqbK7QdjdSyeu8q+quzubHmJeUsTVdA5Yx9Bo93g8eM2Nilos/D",
+	"wXDYGxz3Bqezwfno7Hx0cvJ3V7djLKEnycoruQPPK1mQVpXzLAEkCF2m0MsFICi3gvQLVXGf/DrsvXm4",
+	"HI/HF8PsnPzH/Ip/z98+DH55/+bz+5PH5d8Wp9m/Pwz+LfleNCmuSdrQ4xw+DpMcgV/WCOrAfyeV2iWG",
+	"Gh/IChBeSODoISFRoiWvV0QRpogylDK6BI7mgDjEACuI++gCFjhPpUCS3VJGASUs52jB2QpR9hAiTGO0",
+	"yoVUb2GJVkxIJOAeKIrxWpQD+7c0CH8Tvag7dvPuDv6jDHO8Aglc+ERh7NMTzCrjEU22F8Gza2M6aO4U",
+	"kRu3vamF2C/1QaXzsUS7GVe+WmG+dig2g7Wgt8S3sKVIvprsSUq27aLXMrdOr33ZJRP4PYlKz1U7cprU",
+	"saxJUiVPLlXzdOjLgV9B5cKgmvTanVxhxWOb3CYs85Fv5nWpDI57i8VgMBqMjo8HQRhkWErgNBgF/3l7",
+	"G/+x94d/4N5i0Dv/+HQcnm5G3z0NN9VH3/23Gvd7J6KY3lz0xjd7woipEDnELSFuhO8WRLmQjBMqm6Zw",
+	"8+O4Nzz7HjmDCvxhMq4EUDLB0hzusf5ZRzhVT/2nc/T2HJ2eo8kQDd+p/59P0MUFGlyg4RidvUHjc3Rx",
+	"if50qX86c52LyHAEca8aV73w8NERYnyH5SuefwI4wZ5Y60Y/RzRfzYEXHBzfuBwMUQKPCGjEYoirjDvF",
+	"J4tB5F2P5dyHGo3jWKNGdiWLqQGvzuto5HF4PHzTH/QH/ePRyfFgMPCt9vIYvbAry6dKdlfTxXJzrqQc",
+	"KzR63Qzifdb4E1v+BPeQNg0gLR7XjgC2XBK6RObnsESHYpjnS+0XFkw91vDgR5en9pfdcYaZ1gcaXJU4",
+	"Sf2swoTepWQBWvUqGvtmmAxWg/3RTW0O7/KczVNYebKOtiQCJfkKU8QBxyqcR/CYpZhqv45EBpGSDZIM",
+	"yYQIxKIo5xzoFsXMzILGfRCBEkizRZ6qN1JmHct2lDrRluQeEI71WcIoStiDGpxxFoGKfv7GiZRAEaHo",
+	"ki5TIhL9VkmfCqCBLgkF4CJEuchxmq4RZRKJnEiI9QjKKJIQJZREOFXn6WdIWBoDN6eqGq3IS8l/1W11",
+	"wigFA3VKpmP2ORaAFMdjxHLpdVxUSEy9doz+cj1FHBZguGbYVPh7oZlTcrmVuyGC/rKP5mudTtAlwmjB",
+	"sTm/ysk4YhyJfN5T2K2RmCOedQZ99B6vVdCYC4hrAuKM2YOBiPIlQg192oyR8mtVVh3ZgUdRybOetqjf",
+	"6aiup0yppwSn3W3cM9wrHXHOSa/kzO6kr5le/DibXRVxkqIMLYECx0r+87Umm3GyJBQJUwgwedcuFa7s",
+	"7WxwEgYr/EhWym+cnZ+HwYpQ89fxYOCLV6xfa2qASBhXyllGeU3B/NZKX8R2f6E783rzQO1QZyjBKMBz",
+	"lsvRPMX0cxB20X0DVKfruhG4/ECMputC+3Td6FE6fLsnMcRofDXtow9Zxqwyu5ZkvBeh6PrdpPfmT4M3",
+	"ISLaO1EgMgGOOERstQIam3fngGIoCNUMV/zKGNEJCsLGR/ZKccQsypXxmXUo42iZsrkWidlfmeZXxNzN",
+	"eA4wkbYcw6ii73woSll70thuwVLCsu6FDpUPeJKqDnC1IdmAmCkW8i7PFFlxd0LVcyHxKuv6ig+a3E5S",
+	"gRVqNFmueAtqZc6xB6a0O24BfYHGd4dGygcyGejSJI61oEo/LyzRbqai1cc+xygk5vLuRelcHNSmCV02",
+	"lBQ3EOJn874BEs9Pz+LT03gvSGzf35PT3WgQtSlbLO6iatXpgMrFLiTKLIi2QxBZGdc5X1swW7m82fUE",
+	"FRF9//XQI8mjDnWp2fVkelEOp3dLjiO4y4ATFnuCgOuJCWSwQJIrOEzHMEQov69fReZVA5gpjU2xBCH1",
+	"JiNMKZO3dA6eSQxyZncxZywF3KxMV1xATW7ljv17cctBjErOUqRibiiwdQda8apopQmi6R+Kx1V+6dFo",
+	"BUKXmvd5vDIx8q1ug7Iip8qwEMYIYlhyHGsvqJB99bCSW21H1qB3G8iVnkVHI94i+822LFUv9r0KQtnY",
+	"rlssHT09Hw1B707Q4BwdD9DF8cHIyOx64nEWuUwYJyoKuYc7LA7oOtgC+LXjWPdFvM5UFfXzlca7O4TX",
+	"qS26UEW5zdDHxirxjrkq17HnAJldT55drbUbbhLfONi6ETK9aFKhstk7g2NV9Pm4BYPtgNQaMMg36Ulz",
+	"eNP0grBCVH2+Gvt9B6uzaZaxlC3Xewt19Rf/6qhYlWGUyTtdw3lFmFHNOYcF49CY9PiZk9b46qwQOltw",
+	"mFns2B6TTW5uNhYna+a0V9MywzEhVnGO2UQyaJ5w9heVtylbBC7MXBqyVDxhGVCcEVUR7A/6Q4OwJ1oE",
+	"R6b9Sf97CbKl4rOlxg43GSfmgD5T9kCLLDGyFBXHDJpphFXompuqy80BLUgqgW/BBB18ovFNiEijn0+F",
+	"F7oxq9bZh96ukc2UQ9XIhXKqg4aynUto2jjInFMFfc0UPjGHBN8TxgtKogTTJcTogUhTP/yE0/STXvST",
+	"9mh3WH5yCmjq1FTqq8OHaRyMgh9AvrX8C4PtQN32WIsS9S5tVYItCjINh7CFpBVdhEZpHgN6IGkcYR4L",
+	"9IfBd2jOZFLqxfTmQhM5vnEgqlb4eqAx2WAU/JoDVx7aNCnUg/5uXaLlIV/f33sD4ZRddVpqDshuBLHd",
+	"9geFQzSUqXhbxcxpql+1E1nIIlXa+EDSFM23s1a23q1N8aOfJ2VnZzdu1LtE9zeokiqxQz8Zzb5Sl6IS",
+	"O/v+7OzkzEHPBr4jwVc417m2KnCb0nldOloU2gD6aLpAORWgXYBFjTTGJxV+q8FylReoQN8amQaYEiwQ",
+	"pggWC4gkIgttWf9vgVMBnxrJz3Hv+Lg3PJsdD0fDwehs0D8b/r1FZwurrPCjmwtvysbYWbFnDkvM49RW",
+	"hpxsTpeKOZg/1Oz9FuJwmlboKqE8vW9f1lOn6W8JaAxNMsRB+XGwKDGXiPEYOPoDFhFQDVTPSxf4XRtF",
+	"avYXkjSWkpN5LkGtV6iL8eeYG9KM6LXG5IA+uX7lk8EoRXE+WP/nAuvGQSwIF7pgXNWOSibodWKMS/8O",
+	"69hRkVJVpnSBp5o/rL2+q9W7VLKPYfWewHAwOKg/39fdfWi/czNf2HjDD39fxwrLKFHaVTnt+2rS08Gg",
+	"jYJy00fOzYiN7nTUyHxrGKFEgJfCbUdXrxVBydGThZZ6JN4Y6aYgPZWAC/28Mf/2ZFeVMVoCVdOL5lFu",
+	"prA83HOYz7YYHZpeVGOT4gftOtVjQrNcWuMgwpQslI0nmCLsTFMA6WrjJNYREkYZhwV51D5IHYileFxP",
+	"bZhS+F/jjVWdUIUL+jf3BZUOxKo4KBMgHKW2mKuWN9ZNTNHgeIjmawkFAXaLOJI5Th2ii06oLGUxlG5F",
+	"W6oKMR1DLQUZuNG0SRk63lBxgVQh19pDCKJdhcf0TptqYqRbMAyJPIpAiEWepuvnqXgYnHV5pbysU7WJ",
+	"Fq31GUXoD85/MAezm68qUeFt4dOdeEf8+htp/DyXRqfLUpWrbdUF4RFHMl0jRouFwyIoIcI+UctVo8Jv",
+	"UDEHr3Zny39NyOPeK06x0l72YsdeqGBliRp+0tXFH81TNm/NRL0rqTdUcnB1+d40CBG63KHnb9UCDV3/",
+	"p1OTx14Gq96CpDWQo6f+9/byh+nP6Go8+xHdXP7w/vLnmX58SzXjDB/6/f4t1Y8vf77wjQ32KJGW1JdR",
+	"nrmRkVdrIuyoR0PGExx8QWubjL2mVR4i6ENBz8sZM93aKNKNALavsO8wJsqyz6Tky9G2Fbyne0T0/jMm",
+	"PJY04YAlIOx2kut3bCaeK78qFZesXyaUSNWk51Ribqmpbdn+OXPsUHhA4xuTDpgJlWtmOY1rGYBOqjQK",
+	"c0uL9mzEaATIIGsqFjKIjoVIJmOTWQrJuCUqwaKomN5SvVrodIETKSBdoCIjLXNcYg+MQggmgqmq0hUT",
+	"coLr1wSM3YOQb1m8fjWtammG92ja7tbuqk/afEE7qPOlA6kvN4hCZWnjykO7RZjeyP3IZqNP0iJSD6AU",
+	"UU9SgJWTcagKmhwilY8rbbqlMjGjsFLfFOIlcBf4bMM9t1igVmB7PUtbhh2h0RWuMEqltdtrDpZGhzyf",
+	"Emt/aDpBmyeeF3V6JShQQ3saqLOEWhZiBWLYOxvaBHeBKYLQGuz1PJinjRjrZvbSkVNJ0sPpeGnU17E+",
+	"uG1fb2b9rTk+aekOFq+Y5XtMqm5IbXa7fbNDUcKCkelapaaq+7XNluulilu6o1bhM1ljrn30LucyAb5i",
+	"HEJzh0gNzrAQKl3HXJIoTzG3LW7EQIZbrLXGHHuKOpCrci86geqjMbLAXEFP2aEnmT3QFCpwS12ehTUk",
+	"0+T5phCl/lZNiKYJpcVnuPz/wn7j1SHeLrBsA/P8Krbq3DU+wFR32mgLgbb58Y+HHepFd7v3wx9GMfmh",
+	"Jt/Bwo+e9NAC39uZ9zUW0AgXttievYC8X6tblLqa7hVUPTvZK2+Hf1EAwPr+pswaF6q/Ob1plephWtMN",
+	"Mmiqjr1MpLytgg4U1ikMmPAspfLjCt+SYnWADCaX17Ppu+lkPLu0KMD4xlWkKmjQHL1zqsn4kKmCDipd",
+	"xyC+cb2u4xoV5WZ0QZY7oQ0zYq/IJTzKoyy1Nxobp155WH4lHOOKEypNuj778P4nZDaam+lVfAUVRIOt",
+	"ViXUs71j6zXtKw5C54DbG//VHkeE1Q3wbQkIHiHKJcTNu8sNZtuLu1/QcdcuGPvkseNO8CvASzEpLyiJ",
+	"ykquPIqbyloeRb9Sm4YqyOqfTj/fYkEil7koU91GW8itliWYy3RCtGptypZH5cXJNlaVdy6/oIaVa3w1",
+	"XirPl9YuhzZ4FAZZ7mHKTY0pr4+z7eJHcaXVXf/rYGlfX0o3XaSkNNlWPDp3BrpXQdr6Aw/vC1RwGJhe",
+	"t9rlGDSlIoNIWoA6JvckdorTwgZyKlFH5oouxOiewIPX5d8Uuz2wj893Vefrd9/NgK8IxSnaQdSwIGrY",
+	"SlTl4s9hJH2VJLpye+uANLrW1VLR1P63m1F7qHWM1T6qWevzW2bcdQ5vnLGieV4fgbv0l+2bqTqpzt0z",
+	"1df+T/fQeO/9aRZ+C4ZUNuR8PQpav4rb3ufjcs9r0S9s96nY047T7n9BJ8SBHzm2+25tkanodUvO9W3h",
+	"DPvv4XY/Lw7pv6ms2Iqm7dK+f/XiqK9lWUrQ8ztyKpL4pjGxNnpblbS8y92WSdvb3l/SZZgVvjZiRrwN",
+	"QOMb5MKgxddmFJ9ctKJn7jzbG8ltPUOGu88F0NVrNbtvgckNBycW2/8XZP16bXMHYczSub/ZZk7lHc8v",
+	"aFDlGr8FCG13IJzvzBV82Y1GSx51QELsZxCMn5vprx5cMybRxIW9DTIBOEr0Tb6Db1K2dCeoT/aYO7np",
+	"2lyKnF1PSnTFOmbdsiQkYN0LoDvjHLoZBT8gPlO773ZUN5sDgtCX53u+8tT4KKg5lpUjDL7t6n55M/0A",
+	"UMIuqz56pAT1mnds1HxtXoBH4oiI+ImIeNObP6lcdtMTT+Zi+KZj8Nem2i0nwIxHnYqjRlnaI7qdl+U3",
+	"oXdOtcFukx53nrP8RGOHWX339L9kiqO+Z+HRutn15BWb/dUiz9KvQzKMNiUrsowi+NAYi042WrWvc3n+",
+	"Xxr4zEBsdj2xcdDffxk/fPhl/P372eXDtBY1bUcFXhV95fionNGjq+oFDdkYXch5GoyCRMpsdHT0lDAh",
+	"N6OnjHG50Z834UQ5as2qpGypL2+aqi//6cf6v+bCaz+fDE7PhsomP5ZkNL4gdA98LTVCySHVn1qUzI9W",
+	"17PgYBMeMtvk6urPU4WHagVypjOMaU420VGQ+raEasgvvmtlJrPBiUuVDZo8RNFYt0QKlyaneL/9TpFn",
+	"VjMm2Hzc/M8AhkCkd5hrAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -114,6 +114,23 @@ type Check struct {
 // CheckData defines model for CheckData.
 type CheckData map[string]interface{}
 
+// EnrollmentToken defines model for EnrollmentToken.
+type EnrollmentToken struct {
+	Expiration time.Time `json:"expiration"`
+	IsdAs      IsdAs     `json:"isd_as"`
+
+	// Token The single-use enrollment token.
+	Token string `json:"token"`
+}
+
+// EnrollmentTokenRequest defines model for EnrollmentTokenRequest.
+type EnrollmentTokenRequest struct {
+	// Expiration Time after which the token can no longer be redeemed. Defaults to
+	// one hour from now, and must be at most seven days from now.
+	Expiration *time.Time `json:"expiration,omitempty"`
+	IsdAs      IsdAs      `json:"isd_as"`
+}
+
 // Health defines model for Health.
 type Health struct {
 	// Checks List of health checks.
@@ -135,6 +152,21 @@ type Hop struct {
 // IsdAs defines model for IsdAs.
 type IsdAs = string
 
+// IssuedChain defines model for IssuedChain.
+type IssuedChain struct {
+	// CaFingerprint SHA-256 fingerprint of the CA certificate that signed the chain.
+	CaFingerprint string    `json:"ca_fingerprint"`
+	IsdAs         IsdAs     `json:"isd_as"`
+	IssuedAt      time.Time `json:"issued_at"`
+
+	// Serial Serial number of the AS certificate, hex encoded.
+	Serial string `json:"serial"`
+
+	// Source Address of the requester.
+	Source   string   `json:"source"`
+	Validity Validity `json:"validity"`
+}
+
 // LogLevel defines model for LogLevel.
 type LogLevel struct {
 	// Level Logging level
@@ -282,6 +314,17 @@ type GetBeaconsParams struct {
 // GetBeaconsParamsSort defines parameters for GetBeacons.
 type GetBeaconsParamsSort string
 
+// GetCaIssuedParams defines parameters for GetCaIssued.
+type GetCaIssuedParams struct {
+	IsdAs *IsdAs `form:"isd_as,omitempty" json:"isd_as,omitempty"`
+
+	// Since Only list chains issued at or after this time.
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until Only list chains issued before this time.
+	Until *time.Time `form:"until,omitempty" json:"until,omitempty"`
+}
+
 // GetCertificatesParams defines parameters for GetCertificates.
 type GetCertificatesParams struct {
 	IsdAs   *IsdAs     `form:"isd_as,omitempty" json:"isd_as,omitempty"`
@@ -304,5 +347,8 @@ type GetTrcsParams struct {
 	All *bool  `form:"all,omitempty" json:"all,omitempty"`
 }
 
+// PostCaEnrollmentTokenJSONRequestBody defines body for PostCaEnrollmentToken for application/json ContentType.
+type PostCaEnrollmentTokenJSONRequestBody = EnrollmentTokenRequest
+
 // SetLogLevelJSONRequestBody defines body for SetLogLevel for application/json ContentType.
 type SetLogLevelJSONRequestBody = LogLevel
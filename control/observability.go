@@ -66,6 +66,7 @@ type Metrics struct {
 	BeaconingReceivedTotal                 *prometheus.CounterVec
 	BeaconingRegisteredTotal               *prometheus.CounterVec
 	BeaconingRegistrarInternalErrorsTotal  *prometheus.CounterVec
+	BeaconingPluginRegisteredTotal         *prometheus.CounterVec
 	CAHealth                               *prometheus.GaugeVec
 	DiscoveryRequestsTotal                 *prometheus.CounterVec
 	PathDBQueriesTotal                     *prometheus.CounterVec
@@ -179,6 +180,13 @@ func NewMetrics() *Metrics {
 			},
 			[]string{"seg_type"},
 		),
+		BeaconingPluginRegisteredTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "control_beaconing_plugin_registered_segments_total",
+				Help: "Total number of segments passed to the segment registration plugins.",
+			},
+			[]string{"plugin", "registration_policy", "seg_type", prom.LabelResult},
+		),
 		CAHealth: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "renewal_ca_health_status",
@@ -19,7 +19,6 @@ import (
 	"fmt"
 	"hash"
 	"net"
-	"sync"
 	"time"
 
 	"github.com/scionproto/scion/pkg/addr"
@@ -52,10 +51,8 @@ func (a Addr) String() string {
 type AddressRewriter struct {
 	// Rewriter is used to perform the SVC resolution.
 	Rewriter *infraenv.AddressRewriter
-	// MAC is the mac to issue hop fields.
-	MAC hash.Hash
-	// macMtx protects the MAC.
-	macMtx sync.Mutex
+	// MAC creates the mac to issue hop fields.
+	MAC func() hash.Hash
 }
 
 func (r *AddressRewriter) RedirectToQUIC(
@@ -80,8 +77,5 @@ func (r *AddressRewriter) RedirectToQUIC(
 }
 
 func (r *AddressRewriter) getPath(egress uint16) (path.OneHop, error) {
-	r.macMtx.Lock()
-	defer r.macMtx.Unlock()
-
-	return path.NewOneHop(egress, time.Now(), 63, r.MAC)
+	return path.NewOneHop(egress, time.Now(), 63, r.MAC())
 }
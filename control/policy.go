@@ -16,6 +16,7 @@ package control
 
 import (
 	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/control/beaconing"
 	"github.com/scionproto/scion/control/config"
 	"github.com/scionproto/scion/pkg/private/serrors"
 )
@@ -64,3 +65,34 @@ func loadPolicy(fn string, t beacon.PolicyType) (beacon.Policy, error) {
 	policy.InitDefaults()
 	return policy, nil
 }
+
+// BeaconingRules converts the configured beaconing rules.
+func BeaconingRules(cfg []config.BeaconingRule) beaconing.Rules {
+	if len(cfg) == 0 {
+		return nil
+	}
+	rules := make(beaconing.Rules, 0, len(cfg))
+	for _, r := range cfg {
+		rule := beaconing.Rule{
+			Egress:         interfaceMatcher(r.Egress),
+			MaxBeacons:     r.MaxBeacons,
+			AllowedOrigins: r.AllowedOrigins,
+			MaxHops:        r.MaxHops,
+			Interval:       r.Interval.Duration,
+		}
+		if r.DenyIngress != nil {
+			m := interfaceMatcher(*r.DenyIngress)
+			rule.DenyIngress = &m
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func interfaceMatcher(s config.InterfaceSelector) beaconing.InterfaceMatcher {
+	return beaconing.InterfaceMatcher{
+		Interfaces: s.Interfaces,
+		Neighbors:  s.Neighbors,
+		LinkTypes:  s.LinkTypes,
+	}
+}
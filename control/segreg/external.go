@@ -0,0 +1,322 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segreg
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/go-viper/mapstructure/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/scionproto/scion/control/beacon"
+	libgrpc "github.com/scionproto/scion/pkg/grpc"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	"github.com/scionproto/scion/pkg/proto/control_plane/v1/control_planeconnect"
+	seg "github.com/scionproto/scion/pkg/segment"
+)
+
+// ExternalPluginID is the id of the ExternalSegmentRegistrationPlugin.
+const ExternalPluginID = "external"
+
+// defaultExternalTimeout is the default timeout of a call to the external registrar.
+const defaultExternalTimeout = 5 * time.Second
+
+// externalProtocol defines the protocol that is used to reach the external registrar.
+type externalProtocol string
+
+const (
+	ProtocolGRPC    externalProtocol = "grpc"
+	ProtocolConnect externalProtocol = "connect"
+)
+
+// failureMode defines what happens to the segments if the external registrar cannot be reached.
+type failureMode string
+
+const (
+	// FailureModeDrop drops the segments. The error is logged.
+	FailureModeDrop failureMode = "drop"
+	// FailureModeFallback registers the segments with the default plugin.
+	FailureModeFallback failureMode = "fallback"
+)
+
+// externalConfig holds the configuration for the ExternalSegmentRegistrationPlugin.
+type externalConfig struct {
+	// Address is host:port for gRPC, and the base URL for connect.
+	Address     string
+	Protocol    externalProtocol
+	Timeout     time.Duration
+	FailureMode failureMode
+}
+
+func parseExternalConfig(config map[string]any) (externalConfig, error) {
+	var parsedConfig struct {
+		Address     string
+		Protocol    string
+		Timeout     string
+		FailureMode string
+	}
+	err := mapstructure.Decode(config, &parsedConfig)
+	if err != nil {
+		return externalConfig{}, serrors.Wrap("decoding plugin configuration", err,
+			"config", config)
+	}
+	if parsedConfig.Address == "" {
+		return externalConfig{}, serrors.New("address is required")
+	}
+	conf := externalConfig{
+		Address:     parsedConfig.Address,
+		Protocol:    ProtocolGRPC,
+		Timeout:     defaultExternalTimeout,
+		FailureMode: FailureModeDrop,
+	}
+	switch p := externalProtocol(parsedConfig.Protocol); p {
+	case "":
+	case ProtocolGRPC, ProtocolConnect:
+		conf.Protocol = p
+	default:
+		return externalConfig{}, serrors.New("invalid protocol", "protocol", p)
+	}
+	if parsedConfig.Timeout != "" {
+		conf.Timeout, err = time.ParseDuration(parsedConfig.Timeout)
+		if err != nil {
+			return externalConfig{}, serrors.Wrap("parsing timeout", err,
+				"value", parsedConfig.Timeout)
+		}
+		if conf.Timeout <= 0 {
+			return externalConfig{}, serrors.New("timeout must be positive",
+				"value", parsedConfig.Timeout)
+		}
+	}
+	switch m := failureMode(parsedConfig.FailureMode); m {
+	case "":
+	case FailureModeDrop, FailureModeFallback:
+		conf.FailureMode = m
+	default:
+		return externalConfig{}, serrors.New("invalid failure mode", "mode", m)
+	}
+	return conf, nil
+}
+
+// ExternalSegmentRegistrationPlugin forwards the segments to an external registrar that
+// implements the SegmentRegistrationPluginService over gRPC or connect. This allows to register
+// segments in other systems without changing the control service.
+type ExternalSegmentRegistrationPlugin struct {
+	// HTTPClient is used for the connect protocol. If nil, http.DefaultClient is used.
+	HTTPClient connect.HTTPClient
+}
+
+var _ SegmentRegistrationPlugin = (*ExternalSegmentRegistrationPlugin)(nil)
+
+func (p *ExternalSegmentRegistrationPlugin) ID() string {
+	return ExternalPluginID
+}
+
+func (p *ExternalSegmentRegistrationPlugin) Validate(config map[string]any) error {
+	_, err := parseExternalConfig(config)
+	if err != nil {
+		return serrors.Wrap("validating plugin configuration", err)
+	}
+	return nil
+}
+
+func (p *ExternalSegmentRegistrationPlugin) New(
+	ctx context.Context,
+	policyType beacon.RegPolicyType,
+	config map[string]any,
+) (SegmentRegistrar, error) {
+	conf, err := parseExternalConfig(config)
+	if err != nil {
+		return nil, serrors.Wrap("parsing plugin configuration", err)
+	}
+	r := &ExternalSegmentRegistrar{
+		Type:        policyType.SegmentType(),
+		Address:     conf.Address,
+		Timeout:     conf.Timeout,
+		FailureMode: conf.FailureMode,
+	}
+	switch conf.Protocol {
+	case ProtocolGRPC:
+		// The connection is established lazily, the external registrar does not need to be
+		// available when the control service starts. It is not secured, the external registrar
+		// must be on the same host or reachable over a trusted local link.
+		conn, err := grpc.NewClient(conf.Address,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			libgrpc.UnaryClientInterceptor(),
+		)
+		if err != nil {
+			return nil, serrors.Wrap("creating gRPC client", err, "address", conf.Address)
+		}
+		r.conn = conn
+		r.client = grpcExternalClient{cppb.NewSegmentRegistrationPluginServiceClient(conn)}
+	case ProtocolConnect:
+		httpClient := p.HTTPClient
+		if httpClient == nil {
+			httpClient = http.DefaultClient
+		}
+		r.client = connectExternalClient{
+			control_planeconnect.NewSegmentRegistrationPluginServiceClient(
+				httpClient, conf.Address,
+			),
+		}
+	}
+	if conf.FailureMode == FailureModeFallback {
+		defaultPlugin, ok := GetDefaultSegmentRegPlugin()
+		if !ok {
+			_ = r.Close()
+			return nil, serrors.New("default plugin not registered")
+		}
+		r.Fallback, err = defaultPlugin.New(ctx, policyType, nil)
+		if err != nil {
+			_ = r.Close()
+			return nil, serrors.Wrap("creating fallback registrar", err)
+		}
+	}
+	return r, nil
+}
+
+// externalClient abstracts the protocol that is used to reach the external registrar.
+type externalClient interface {
+	RegisterSegments(
+		context.Context,
+		*cppb.RegisterSegmentsRequest,
+	) (*cppb.RegisterSegmentsResponse, error)
+}
+
+type grpcExternalClient struct {
+	client cppb.SegmentRegistrationPluginServiceClient
+}
+
+func (c grpcExternalClient) RegisterSegments(
+	ctx context.Context,
+	req *cppb.RegisterSegmentsRequest,
+) (*cppb.RegisterSegmentsResponse, error) {
+	return c.client.RegisterSegments(ctx, req)
+}
+
+type connectExternalClient struct {
+	client control_planeconnect.SegmentRegistrationPluginServiceClient
+}
+
+func (c connectExternalClient) RegisterSegments(
+	ctx context.Context,
+	req *cppb.RegisterSegmentsRequest,
+) (*cppb.RegisterSegmentsResponse, error) {
+	rep, err := c.client.RegisterSegments(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	return rep.Msg, nil
+}
+
+// ExternalSegmentRegistrar forwards the segments to an external registrar.
+type ExternalSegmentRegistrar struct {
+	// Type is the type of segment that is handled by this registrar.
+	Type seg.Type
+	// Address is the address of the external registrar.
+	Address string
+	// Timeout is the timeout of a call to the external registrar.
+	Timeout time.Duration
+	// FailureMode defines what happens to the segments if the call fails.
+	FailureMode failureMode
+	// Fallback registers the segments if the call fails in FailureModeFallback.
+	Fallback SegmentRegistrar
+
+	client externalClient
+	// conn is the connection of the gRPC protocol. It is nil for the connect protocol.
+	conn *grpc.ClientConn
+}
+
+var _ SegmentRegistrar = (*ExternalSegmentRegistrar)(nil)
+
+// Close closes the connection to the external registrar and the fallback registrar. The
+// registrar must not be used afterwards.
+func (r *ExternalSegmentRegistrar) Close() error {
+	var errs serrors.List
+	if r.conn != nil {
+		if err := r.conn.Close(); err != nil {
+			errs = append(errs, serrors.Wrap("closing gRPC client", err, "address", r.Address))
+		}
+	}
+	if r.Fallback != nil {
+		if err := closeRegistrar(r.Fallback); err != nil {
+			errs = append(errs, serrors.Wrap("closing fallback registrar", err))
+		}
+	}
+	return errs.ToError()
+}
+
+// RegisterSegments forwards the segments to the external registrar. The summary contains the
+// segments that the external registrar reported as registered. If the external registrar cannot
+// be reached and the segments are dropped, the summary is empty.
+func (r *ExternalSegmentRegistrar) RegisterSegments(
+	ctx context.Context,
+	segments []beacon.Beacon,
+	peers []uint16,
+) *RegistrationSummary {
+	if len(segments) == 0 {
+		return nil
+	}
+	logger := log.FromCtx(ctx)
+
+	req := &cppb.RegisterSegmentsRequest{
+		SegmentType: cppb.SegmentType(r.Type),
+		Beacons:     make([]*cppb.RegisterSegmentsRequest_Beacon, 0, len(segments)),
+		Peers:       make([]uint32, 0, len(peers)),
+	}
+	for _, b := range segments {
+		req.Beacons = append(req.Beacons, &cppb.RegisterSegmentsRequest_Beacon{
+			Segment:          seg.PathSegmentToPB(b.Segment),
+			IngressInterface: uint32(b.InIfID),
+		})
+	}
+	for _, peer := range peers {
+		req.Peers = append(req.Peers, uint32(peer))
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, r.Timeout)
+	defer cancel()
+	rep, err := r.client.RegisterSegments(callCtx, req)
+	if err != nil {
+		logger.Error("Unable to register segments at external registrar",
+			"address", r.Address, "seg_type", r.Type, "count", len(segments),
+			"failure_mode", r.FailureMode, "err", err)
+		if r.FailureMode == FailureModeFallback && r.Fallback != nil {
+			return r.Fallback.RegisterSegments(ctx, segments, peers)
+		}
+		return NewSummary()
+	}
+
+	summary := NewSummary()
+	recorded := make(map[uint32]struct{}, len(rep.Registered))
+	for _, idx := range rep.Registered {
+		if int(idx) >= len(segments) {
+			logger.Error("External registrar reported invalid segment index",
+				"address", r.Address, "index", idx, "count", len(segments))
+			continue
+		}
+		if _, ok := recorded[idx]; ok {
+			continue
+		}
+		recorded[idx] = struct{}{}
+		summary.RecordBeacon(&segments[idx])
+	}
+	return summary
+}
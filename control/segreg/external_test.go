@@ -0,0 +1,311 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segreg_test
+
+import (
+	"context"
+	"net"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/control/segreg"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/prom"
+	"github.com/scionproto/scion/pkg/private/xtest/graph"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	"github.com/scionproto/scion/pkg/proto/control_plane/v1/control_planeconnect"
+	seg "github.com/scionproto/scion/pkg/segment"
+)
+
+// fallbackPlugin is registered as the default plugin. Its registrars register all segments.
+type fallbackPlugin struct{}
+
+func (fallbackPlugin) ID() string                    { return segreg.DefaultPluginID }
+func (fallbackPlugin) Validate(map[string]any) error { return nil }
+func (fallbackPlugin) New(
+	context.Context,
+	beacon.RegPolicyType,
+	map[string]any,
+) (segreg.SegmentRegistrar, error) {
+	return fallbackRegistrar{}, nil
+}
+
+type fallbackRegistrar struct{}
+
+func (fallbackRegistrar) RegisterSegments(
+	_ context.Context,
+	segments []beacon.Beacon,
+	_ []uint16,
+) *segreg.RegistrationSummary {
+	summary := segreg.NewSummary()
+	for _, b := range segments {
+		summary.RecordBeacon(&b)
+	}
+	return summary
+}
+
+func init() {
+	segreg.RegisterSegmentRegPlugin(fallbackPlugin{})
+}
+
+// externalServer registers the segments at the even indices.
+type externalServer struct {
+	requests chan *cppb.RegisterSegmentsRequest
+}
+
+func (s *externalServer) RegisterSegments(
+	_ context.Context,
+	req *cppb.RegisterSegmentsRequest,
+) (*cppb.RegisterSegmentsResponse, error) {
+	s.requests <- req
+	rep := &cppb.RegisterSegmentsResponse{}
+	for i := range req.Beacons {
+		if i%2 == 0 {
+			// Duplicates are only counted once.
+			rep.Registered = append(rep.Registered, uint32(i), uint32(i))
+		}
+	}
+	// Invalid indices are ignored.
+	rep.Registered = append(rep.Registered, uint32(len(req.Beacons)))
+	return rep, nil
+}
+
+type connectExternalServer struct {
+	*externalServer
+}
+
+func (s connectExternalServer) RegisterSegments(
+	ctx context.Context,
+	req *connect.Request[cppb.RegisterSegmentsRequest],
+) (*connect.Response[cppb.RegisterSegmentsResponse], error) {
+	rep, err := s.externalServer.RegisterSegments(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(rep), nil
+}
+
+func startGRPCServer(t *testing.T, s *externalServer) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	cppb.RegisterSegmentRegistrationPluginServiceServer(server, s)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+	return lis.Addr().String()
+}
+
+func startConnectServer(t *testing.T, s *externalServer) string {
+	_, handler := control_planeconnect.NewSegmentRegistrationPluginServiceHandler(
+		connectExternalServer{s},
+	)
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func testBeacons(t *testing.T) []beacon.Beacon {
+	g := graph.NewDefaultGraph(gomock.NewController(t))
+	var beacons []beacon.Beacon
+	for _, ifIDs := range [][]uint16{
+		{graph.If_120_X_111_B},
+		{graph.If_130_B_120_A, graph.If_120_X_111_B},
+		{graph.If_130_A_110_X, graph.If_110_X_120_A, graph.If_120_X_111_B},
+	} {
+		beacons = append(beacons, beacon.Beacon{
+			Segment: g.Beacon(ifIDs),
+			InIfID:  graph.If_111_B_120_X,
+		})
+	}
+	return beacons
+}
+
+func TestExternalPluginValidate(t *testing.T) {
+	testCases := map[string]struct {
+		config    map[string]any
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"minimal": {
+			config:    map[string]any{"Address": "localhost:30300"},
+			assertErr: assert.NoError,
+		},
+		"full": {
+			config: map[string]any{
+				"Address":     "http://localhost:30300",
+				"Protocol":    "connect",
+				"Timeout":     "2s",
+				"FailureMode": "fallback",
+			},
+			assertErr: assert.NoError,
+		},
+		"no address": {
+			config:    map[string]any{"Protocol": "grpc"},
+			assertErr: assert.Error,
+		},
+		"invalid protocol": {
+			config:    map[string]any{"Address": "localhost:30300", "Protocol": "http"},
+			assertErr: assert.Error,
+		},
+		"invalid timeout": {
+			config:    map[string]any{"Address": "localhost:30300", "Timeout": "soon"},
+			assertErr: assert.Error,
+		},
+		"negative timeout": {
+			config:    map[string]any{"Address": "localhost:30300", "Timeout": "-1s"},
+			assertErr: assert.Error,
+		},
+		"invalid failure mode": {
+			config:    map[string]any{"Address": "localhost:30300", "FailureMode": "retry"},
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tc.assertErr(t, (&segreg.ExternalSegmentRegistrationPlugin{}).Validate(tc.config))
+		})
+	}
+}
+
+func TestExternalRegistrar(t *testing.T) {
+	testCases := map[string]func(*testing.T, *externalServer) map[string]any{
+		"grpc": func(t *testing.T, s *externalServer) map[string]any {
+			return map[string]any{"Address": startGRPCServer(t, s)}
+		},
+		"connect": func(t *testing.T, s *externalServer) map[string]any {
+			return map[string]any{"Address": startConnectServer(t, s), "Protocol": "connect"}
+		},
+	}
+	for name, start := range testCases {
+		t.Run(name, func(t *testing.T) {
+			s := &externalServer{requests: make(chan *cppb.RegisterSegmentsRequest, 1)}
+			registrar, err := (&segreg.ExternalSegmentRegistrationPlugin{}).New(
+				context.Background(), beacon.RegPolicyTypeUp, start(t, s),
+			)
+			require.NoError(t, err)
+
+			beacons := testBeacons(t)
+			summary := registrar.RegisterSegments(context.Background(), beacons, []uint16{5, 6})
+			require.NotNil(t, summary)
+			assert.Equal(t, 2, summary.GetCount())
+			assert.Equal(t, []uint16{graph.If_111_B_120_X}, summary.GetIfIDs())
+
+			req := <-s.requests
+			assert.Equal(t, cppb.SegmentType_SEGMENT_TYPE_UP, req.SegmentType)
+			assert.Equal(t, []uint32{5, 6}, req.Peers)
+			require.Len(t, req.Beacons, len(beacons))
+			for i, b := range req.Beacons {
+				ps, err := seg.SegmentFromPB(b.Segment)
+				require.NoError(t, err)
+				assert.Equal(t, beacons[i].Segment.ID(), ps.ID())
+				assert.Equal(t, uint32(beacons[i].InIfID), b.IngressInterface)
+			}
+		})
+	}
+}
+
+func TestExternalRegistrarFailure(t *testing.T) {
+	// Nothing listens on the address.
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := lis.Addr().String()
+	require.NoError(t, lis.Close())
+
+	testCases := map[string]struct {
+		failureMode string
+		expected    int
+	}{
+		"drop":     {failureMode: "drop", expected: 0},
+		"fallback": {failureMode: "fallback", expected: 3},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			registrar, err := (&segreg.ExternalSegmentRegistrationPlugin{}).New(
+				context.Background(), beacon.RegPolicyTypeDown, map[string]any{
+					"Address":     address,
+					"Timeout":     "1s",
+					"FailureMode": tc.failureMode,
+				},
+			)
+			require.NoError(t, err)
+			summary := registrar.RegisterSegments(context.Background(), testBeacons(t), nil)
+			require.NotNil(t, summary)
+			assert.Equal(t, tc.expected, summary.GetCount())
+		})
+	}
+}
+
+func TestExternalRegistrarClose(t *testing.T) {
+	s := &externalServer{requests: make(chan *cppb.RegisterSegmentsRequest, 1)}
+	registrar, err := (&segreg.ExternalSegmentRegistrationPlugin{}).New(
+		context.Background(), beacon.RegPolicyTypeUp,
+		map[string]any{"Address": startGRPCServer(t, s)},
+	)
+	require.NoError(t, err)
+	registrars := make(segreg.SegmentRegistrars)
+	require.NoError(t, registrars.RegisterSegmentRegistrar(beacon.RegPolicyTypeUp, "external",
+		segreg.MeteredRegistrar{SegmentRegistrar: registrar}))
+	require.NoError(t, registrars.RegisterDefaultSegmentRegistrar(beacon.RegPolicyTypeUp,
+		fallbackRegistrar{}))
+
+	require.NoError(t, registrars.Close())
+	// The connection is closed, the segments do not reach the external registrar anymore.
+	summary := registrar.RegisterSegments(context.Background(), testBeacons(t), nil)
+	require.NotNil(t, summary)
+	assert.Zero(t, summary.GetCount())
+	assert.Empty(t, s.requests)
+}
+
+func TestMeteredRegistrar(t *testing.T) {
+	s := &externalServer{requests: make(chan *cppb.RegisterSegmentsRequest, 1)}
+	registrar, err := (&segreg.ExternalSegmentRegistrationPlugin{}).New(
+		context.Background(), beacon.RegPolicyTypeUp,
+		map[string]any{"Address": startGRPCServer(t, s)},
+	)
+	require.NoError(t, err)
+	counter := metrics.NewTestCounter()
+	metered := segreg.MeteredRegistrar{SegmentRegistrar: registrar, Segments: counter}
+
+	summary := metered.RegisterSegments(context.Background(), testBeacons(t), nil)
+	require.NotNil(t, summary)
+	<-s.requests
+	assert.Equal(t, 2.0, metrics.CounterValue(counter.With(prom.LabelResult, prom.Success)))
+	assert.Equal(t, 1.0, metrics.CounterValue(
+		counter.With(prom.LabelResult, segreg.ResultNotRegistered)))
+	assert.Len(t, summary.GetSrcs(), 2)
+	assert.Contains(t, summary.GetSrcs(), addr.MustParseIA("1-ff00:0:120"))
+}
+
+func TestMeteredRegistrarNoSummary(t *testing.T) {
+	registrar, err := (&segreg.IgnoreSegmentRegistrationPlugin{}).New(
+		context.Background(), beacon.RegPolicyTypeUp, map[string]any{},
+	)
+	require.NoError(t, err)
+	counter := metrics.NewTestCounter()
+	metered := segreg.MeteredRegistrar{SegmentRegistrar: registrar, Segments: counter}
+
+	// The ignored segments are neither counted as registered nor as failures.
+	assert.Nil(t, metered.RegisterSegments(context.Background(), testBeacons(t), nil))
+	assert.Zero(t, metrics.CounterValue(counter.With(prom.LabelResult, prom.Success)))
+	assert.Zero(t, metrics.CounterValue(
+		counter.With(prom.LabelResult, segreg.ResultNotRegistered)))
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segreg
+
+import (
+	"context"
+
+	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/prom"
+)
+
+// ResultNotRegistered is the result label of the segments that a registrar did not register.
+const ResultNotRegistered = "err_not_registered"
+
+// MeteredRegistrar wraps a SegmentRegistrar and counts the segments based on the
+// RegistrationSummary of the wrapped registrar. A registrar that returns no summary, e.g., the
+// ignore registrar, reports nothing and its segments are not counted.
+type MeteredRegistrar struct {
+	SegmentRegistrar
+	// Segments counts the segments passed to the registrar. The result label indicates whether
+	// the segments were registered. If the counter is nil, nothing is counted.
+	Segments metrics.Counter
+}
+
+var _ SegmentRegistrar = MeteredRegistrar{}
+
+// Close closes the wrapped registrar, if it holds resources.
+func (r MeteredRegistrar) Close() error {
+	return closeRegistrar(r.SegmentRegistrar)
+}
+
+func (r MeteredRegistrar) RegisterSegments(
+	ctx context.Context,
+	segments []beacon.Beacon,
+	peers []uint16,
+) *RegistrationSummary {
+	summary := r.SegmentRegistrar.RegisterSegments(ctx, segments, peers)
+	if summary == nil {
+		return nil
+	}
+	registered := summary.GetCount()
+	if registered > 0 {
+		metrics.CounterAdd(metrics.CounterWith(r.Segments, prom.LabelResult, prom.Success),
+			float64(registered))
+	}
+	if missed := len(segments) - registered; missed > 0 {
+		metrics.CounterAdd(metrics.CounterWith(r.Segments, prom.LabelResult, ResultNotRegistered),
+			float64(missed))
+	}
+	return summary
+}
@@ -17,6 +17,7 @@ package segreg
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/scionproto/scion/control/beacon"
 
@@ -89,6 +90,29 @@ func (s SegmentRegistrars) RegisterDefaultSegmentRegistrar(
 	return nil
 }
 
+// Close closes the registrars that hold resources, such as the connection to an external
+// registrar. The registrars must not be used afterwards.
+func (s SegmentRegistrars) Close() error {
+	var errs serrors.List
+	for policyType, registrars := range s {
+		for name, registrar := range registrars {
+			if err := closeRegistrar(registrar); err != nil {
+				errs = append(errs, serrors.Wrap("closing segment registrar", err,
+					"policy_type", policyType, "registration_policy", name))
+			}
+		}
+	}
+	return errs.ToError()
+}
+
+// closeRegistrar closes the registrar if it holds resources, i.e., if it implements io.Closer.
+func closeRegistrar(registrar SegmentRegistrar) error {
+	if c, ok := registrar.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
 // GetSegmentRegistrar returns the segment registrar for the given policy type and registration
 // policy.
 // It should be registered with either RegisterSegmentRegistrar or RegisterDefaultRegistrar
@@ -17,6 +17,7 @@ package control
 import (
 	"context"
 	"hash"
+	"io"
 	"net"
 	"time"
 
@@ -79,6 +80,9 @@ type TasksConfig struct {
 	HiddenPathRegistrationCfg *HiddenPathRegistrationCfg
 
 	AllowIsdLoop bool
+	// BeaconingRules restrict the beacons that are originated and propagated
+	// on the egress interfaces.
+	BeaconingRules beaconing.Rules
 
 	EPIC bool
 
@@ -88,12 +92,28 @@ type TasksConfig struct {
 // InitPlugins initializes the segment registration plugins based on the provided
 // registration policies. This must be called before starting the tasks.
 func (t *TasksConfig) InitPlugins(ctx context.Context, regPolicies []beacon.Policy) error {
-	logger := log.FromCtx(ctx)
 	if t.registrars != nil {
 		return nil
 	}
-	// Initialize the segment registrars.
 	segmentRegistrars := make(segreg.SegmentRegistrars)
+	if err := t.initRegistrars(ctx, regPolicies, segmentRegistrars); err != nil {
+		// Release the resources of the registrars that were created.
+		if closeErr := segmentRegistrars.Close(); closeErr != nil {
+			log.FromCtx(ctx).Info("Failed to close segment registrars", "err", closeErr)
+		}
+		return err
+	}
+	t.registrars = segmentRegistrars
+	return nil
+}
+
+// initRegistrars creates the segment registrars and adds them to segmentRegistrars.
+func (t *TasksConfig) initRegistrars(
+	ctx context.Context,
+	regPolicies []beacon.Policy,
+	segmentRegistrars segreg.SegmentRegistrars,
+) error {
+	logger := log.FromCtx(ctx)
 	for _, policy := range regPolicies {
 		polType, ok := policy.Type.RegPolicyType()
 		if !ok {
@@ -113,9 +133,14 @@ func (t *TasksConfig) InitPlugins(ctx context.Context, regPolicies []beacon.Poli
 			if err != nil {
 				return serrors.Wrap("creating segment registrar", err)
 			}
+			registrar = t.meteredRegistrar(registrar, plugin.ID(), regPolicy.Name, polType)
 			if err := segmentRegistrars.RegisterSegmentRegistrar(
 				polType, regPolicy.Name, registrar,
 			); err != nil {
+				// The registrar is not in segmentRegistrars, close it here.
+				if c, ok := registrar.(io.Closer); ok {
+					_ = c.Close()
+				}
 				return serrors.Wrap("registering segment registrar", err,
 					"policy_type", policy.Type, "registration_policy", regPolicy.Name)
 			}
@@ -141,18 +166,45 @@ func (t *TasksConfig) InitPlugins(ctx context.Context, regPolicies []beacon.Poli
 				return serrors.Wrap("creating default segment registrar", err,
 					"policy_type", polType)
 			}
+			defaultRegistrar = t.meteredRegistrar(
+				defaultRegistrar, defaultPlugin.ID(), beacon.DefaultGroup, polType,
+			)
 			if err := segmentRegistrars.RegisterDefaultSegmentRegistrar(
 				polType, defaultRegistrar,
 			); err != nil {
+				if c, ok := defaultRegistrar.(io.Closer); ok {
+					_ = c.Close()
+				}
 				return serrors.Wrap("registering default segment registrar", err,
 					"policy_type", polType)
 			}
 		}
 	}
-	t.registrars = segmentRegistrars
 	return nil
 }
 
+// meteredRegistrar wraps the registrar such that the segments it registers are counted per
+// plugin and registration policy. If no metrics are configured, the registrar is returned as is.
+func (t *TasksConfig) meteredRegistrar(
+	registrar segreg.SegmentRegistrar,
+	plugin string,
+	regPolicy string,
+	polType beacon.RegPolicyType,
+) segreg.SegmentRegistrar {
+	if t.Metrics == nil {
+		return registrar
+	}
+	return segreg.MeteredRegistrar{
+		SegmentRegistrar: registrar,
+		Segments: metrics.CounterWith(
+			metrics.NewPromCounter(t.Metrics.BeaconingPluginRegisteredTotal),
+			"plugin", plugin,
+			"registration_policy", regPolicy,
+			"seg_type", polType.SegmentType().String(),
+		),
+	}
+}
+
 // Originator starts a periodic beacon origination task. For non-core ASes, no
 // periodic runner is started.
 func (t *TasksConfig) Originator() *periodic.Runner {
@@ -167,6 +219,7 @@ func (t *TasksConfig) Originator() *periodic.Runner {
 		IA:                    t.IA,
 		AllInterfaces:         t.AllInterfaces,
 		OriginationInterfaces: t.OriginationInterfaces,
+		Rules:                 t.BeaconingRules,
 		Tick:                  beaconing.NewTick(t.OriginationInterval),
 	}
 	if t.Metrics != nil {
@@ -188,6 +241,7 @@ func (t *TasksConfig) Propagator() *periodic.Runner {
 		AllInterfaces:         t.AllInterfaces,
 		PropagationInterfaces: t.PropagationInterfaces,
 		AllowIsdLoop:          t.AllowIsdLoop,
+		Rules:                 t.BeaconingRules,
 		Tick:                  beaconing.NewTick(t.PropagationInterval),
 	}
 	if t.Metrics != nil {
@@ -311,6 +365,10 @@ type Tasks struct {
 
 	PathCleaner   *periodic.Runner
 	DRKeyCleaners []*periodic.Runner
+
+	// segmentRegistrars are used by the Registrars tasks. They are closed when the tasks are
+	// killed.
+	segmentRegistrars segreg.SegmentRegistrars
 }
 
 func StartTasks(cfg TasksConfig) (*Tasks, error) {
@@ -333,8 +391,9 @@ func StartTasks(cfg TasksConfig) (*Tasks, error) {
 			10*time.Second,
 			10*time.Second,
 		),
-		DRKeyPrefetcher: cfg.DRKeyPrefetcher(),
-		DRKeyCleaners:   cfg.DRKeyCleaners(),
+		DRKeyPrefetcher:   cfg.DRKeyPrefetcher(),
+		DRKeyCleaners:     cfg.DRKeyCleaners(),
+		segmentRegistrars: cfg.registrars,
 	}, nil
 
 }
@@ -352,12 +411,16 @@ func (t *Tasks) Kill() {
 	})
 	killRunners(t.Registrars)
 	killRunners(t.DRKeyCleaners)
+	if err := t.segmentRegistrars.Close(); err != nil {
+		log.Info("Failed to close segment registrars", "err", err)
+	}
 	t.Originator = nil
 	t.Propagator = nil
 	t.PathCleaner = nil
 	t.Registrars = nil
 	t.DRKeyPrefetcher = nil
 	t.DRKeyCleaners = nil
+	t.segmentRegistrars = nil
 }
 
 func killRunners(runners []*periodic.Runner) {
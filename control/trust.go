@@ -27,6 +27,7 @@ import (
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/private/ca/renewal"
+	"github.com/scionproto/scion/private/pkcs11"
 	"github.com/scionproto/scion/private/trust"
 )
 
@@ -66,21 +67,45 @@ func LoadTrustMaterial(ctx context.Context, configDir string, db trust.DB) error
 	return nil
 }
 
+// NewKeyRing creates the key ring that provides the private keys. If uri is
+// empty, the keys are loaded from dir. Otherwise, uri is a PKCS#11 URI that
+// selects the keys on a token, and the keys never leave the token.
+func NewKeyRing(dir, uri string) (trust.KeyRing, error) {
+	if uri == "" {
+		return cstrust.LoadingRing{Dir: dir}, nil
+	}
+	parsed, err := pkcs11.ParseURI(uri)
+	if err != nil {
+		return nil, serrors.Wrap("parsing PKCS#11 URI", err)
+	}
+	return &pkcs11.KeyRing{URI: parsed}, nil
+}
+
+// NewTLSCertificateLoader creates a TLS certificate loader. If keys is nil,
+// the private keys are loaded from the crypto/as directory.
 func NewTLSCertificateLoader(
 	ia addr.IA,
 	extKeyUsage x509.ExtKeyUsage,
 	db trust.DB,
 	cfgDir string,
+	keys trust.KeyRing,
 ) cstrust.TLSCertificateLoader {
 	return cstrust.TLSCertificateLoader{
-		SignerGen: newCachingSignerGen(ia, extKeyUsage, db, cfgDir),
+		SignerGen: newCachingSignerGen(ia, extKeyUsage, db, cfgDir, keys),
 	}
 }
 
-// NewSigner creates a renewing signer backed by a certificate chain.
-func NewSigner(ctx context.Context, ia addr.IA, db trust.DB, cfgDir string) cstrust.RenewingSigner {
+// NewSigner creates a renewing signer backed by a certificate chain. If keys
+// is nil, the private keys are loaded from the crypto/as directory.
+func NewSigner(
+	ctx context.Context,
+	ia addr.IA,
+	db trust.DB,
+	cfgDir string,
+	keys trust.KeyRing,
+) cstrust.RenewingSigner {
 	signer := cstrust.RenewingSigner{
-		SignerGen: newCachingSignerGen(ia, x509.ExtKeyUsageAny, db, cfgDir),
+		SignerGen: newCachingSignerGen(ia, x509.ExtKeyUsageAny, db, cfgDir, keys),
 	}
 	if _, err := signer.SignerGen.Generate(ctx); err != nil {
 		log.Debug("Initial signer generation failed", "err", err)
@@ -96,7 +121,11 @@ func newCachingSignerGen(
 	extKeyUsage x509.ExtKeyUsage,
 	db trust.DB,
 	cfgDir string,
+	keys trust.KeyRing,
 ) *cstrust.CachingSignerGen {
+	if keys == nil {
+		keys = cstrust.LoadingRing{Dir: filepath.Join(cfgDir, "crypto/as")}
+	}
 	gen := trust.SignerGen{
 		IA: ia,
 		DB: &cstrust.CryptoLoader{
@@ -104,9 +133,7 @@ func newCachingSignerGen(
 			TRCDirs: []string{filepath.Join(cfgDir, "certs")},
 			DB:      db,
 		},
-		KeyRing: cstrust.LoadingRing{
-			Dir: filepath.Join(cfgDir, "crypto/as"),
-		},
+		KeyRing:     keys,
 		ExtKeyUsage: extKeyUsage,
 	}
 	return &cstrust.CachingSignerGen{
@@ -129,10 +156,23 @@ type ChainBuilderConfig struct {
 	//
 	// Experimental: This field is experimental and will be subject to change.
 	ForceECDSAWithSHA512 bool
+
+	// Ledger records the issued chains. If nil, the issued chains are not
+	// recorded.
+	Ledger renewal.Ledger
+	// IssuanceLimit limits the number of chains issued per ISD-AS.
+	IssuanceLimit renewal.IssuanceLimit
+	// KeyRing provides the CA private keys. If nil, the keys are loaded from
+	// the crypto/ca directory.
+	KeyRing trust.KeyRing
 }
 
 // NewChainBuilder creates a renewing chain builder.
 func NewChainBuilder(cfg ChainBuilderConfig) renewal.ChainBuilder {
+	keys := cfg.KeyRing
+	if keys == nil {
+		keys = cstrust.LoadingRing{Dir: filepath.Join(cfg.ConfigDir, "crypto/ca")}
+	}
 	return renewal.ChainBuilder{
 		PolicyGen: &renewal.CachingPolicyGen{
 			PolicyGen: renewal.LoadingPolicyGen{
@@ -142,9 +182,7 @@ func NewChainBuilder(cfg ChainBuilderConfig) renewal.ChainBuilder {
 					DB:  cfg.DB,
 					Dir: filepath.Join(cfg.ConfigDir, "crypto/ca"),
 				},
-				KeyRing: cstrust.LoadingRing{
-					Dir: filepath.Join(cfg.ConfigDir, "crypto/ca"),
-				},
+				KeyRing:              keys,
 				ForceECDSAWithSHA512: cfg.ForceECDSAWithSHA512,
 				CASigners:            cfg.Metrics.CASigners,
 			},
@@ -152,6 +190,8 @@ func NewChainBuilder(cfg ChainBuilderConfig) renewal.ChainBuilder {
 			LastGeneratedCA: cfg.Metrics.LastGeneratedCA,
 			ExpirationCA:    cfg.Metrics.ExpirationCA,
 		},
-		SignedChains: cfg.Metrics.SignedChains,
+		SignedChains:  cfg.Metrics.SignedChains,
+		Ledger:        cfg.Ledger,
+		IssuanceLimit: cfg.IssuanceLimit,
 	}
 }
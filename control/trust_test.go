@@ -45,6 +45,7 @@ func TestNewSigner(t *testing.T) {
 		addr.MustParseIA("1-ff00:0:110"),
 		db,
 		filepath.Join(dir, "/ISD1/ASff00_0_110"),
+		nil,
 	)
 
 	_, err = signer.Sign(context.Background(), []byte("message"))
@@ -67,6 +67,7 @@ import (
 	"github.com/scionproto/scion/private/topology"
 	"github.com/scionproto/scion/private/trust"
 	"github.com/scionproto/scion/private/trust/compat"
+	"github.com/scionproto/scion/private/trust/expiry"
 	trustmetrics "github.com/scionproto/scion/private/trust/metrics"
 )
 
@@ -185,6 +186,22 @@ func realMain(ctx context.Context) error {
 	}, 10*time.Second, 10*time.Second)
 	defer trcLoaderTask.Stop()
 
+	expiryMonitor := &expiry.Monitor{
+		IA:     topo.IA(),
+		DB:     trustDB,
+		Policy: globalCfg.TrustEngine.Expiry.Policy(),
+		Metrics: expiry.Metrics{
+			Remaining: trustmetrics.ExpiryRemainingSeconds,
+		},
+	}
+	//nolint:staticcheck // SA1019: fix later (https://github.com/scionproto/scion/issues/4776).
+	expiryTask := periodic.Start(
+		expiryMonitor,
+		globalCfg.TrustEngine.Expiry.Interval.Duration,
+		10*time.Second,
+	)
+	defer expiryTask.Stop()
+
 	var drkeyClientEngine *sddrkey.ClientEngine
 	if globalCfg.DRKeyLevel2DB.Connection != "" {
 		backend, err := storage.NewDRKeyLevel2Storage(globalCfg.DRKeyLevel2DB)
@@ -323,6 +340,7 @@ func realMain(ctx context.Context) error {
 			Config:   service.NewConfigStatusPage(globalCfg).Handler,
 			Info:     service.NewInfoStatusPage().Handler,
 			LogLevel: service.NewLogLevelStatusPage().Handler,
+			Expiry:   expiryMonitor,
 		}
 		log.Info("Exposing API", "addr", globalCfg.API.Addr)
 		h := api.HandlerFromMuxWithBaseURL(&server, r, "/api/v1")
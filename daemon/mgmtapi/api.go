@@ -15,12 +15,23 @@
 package mgmtapi
 
 import (
+	"encoding/json"
 	"net/http"
 
+	api "github.com/scionproto/scion/private/mgmtapi"
 	cppkiapi "github.com/scionproto/scion/private/mgmtapi/cppki/api"
+	"github.com/scionproto/scion/private/mgmtapi/health"
+	healthapi "github.com/scionproto/scion/private/mgmtapi/health/api"
 	segapi "github.com/scionproto/scion/private/mgmtapi/segments/api"
+	"github.com/scionproto/scion/private/trust/expiry"
 )
 
+// ExpiryMonitor provides the results of the last scan of the local trust
+// material for expiration.
+type ExpiryMonitor interface {
+	Results() []expiry.Result
+}
+
 // Server implements the SCION Daemon Service API.
 type Server struct {
 	SegmentsServer segapi.Server
@@ -28,6 +39,9 @@ type Server struct {
 	Config         http.HandlerFunc
 	Info           http.HandlerFunc
 	LogLevel       http.HandlerFunc
+	// Expiry provides the expiration status of the local trust material. If
+	// nil, the expiry health check is omitted.
+	Expiry ExpiryMonitor
 }
 
 // GetConfig is an indirection to the http handler.
@@ -124,3 +138,39 @@ func (s *Server) GetTrc(w http.ResponseWriter, r *http.Request, isd int, base in
 func (s *Server) GetTrcBlob(w http.ResponseWriter, r *http.Request, isd int, base int, serial int) {
 	s.CPPKIServer.GetTrcBlob(w, r, isd, base, serial) // nolint - name from published API
 }
+
+// GetHealth returns the health status of the daemon.
+func (s *Server) GetHealth(w http.ResponseWriter, r *http.Request) {
+	var checks []Check
+	if s.Expiry != nil {
+		expiryCheck := health.ExpiryCheck(s.Expiry.Results())
+		checks = append(checks, Check{
+			Data:   CheckData(expiryCheck.Data),
+			Detail: expiryCheck.Detail,
+			Name:   expiryCheck.Name,
+			Status: Status(expiryCheck.Status),
+		})
+	}
+	statuses := make([]healthapi.Status, 0, len(checks))
+	for _, c := range checks {
+		statuses = append(statuses, healthapi.Status(c.Status))
+	}
+	rep := HealthResponse{
+		Health: Health{
+			Status: Status(healthapi.AggregateHealthStatus(statuses)),
+			Checks: checks,
+		},
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(rep); err != nil {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
+		})
+	}
+}
@@ -18,59 +18,65 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xaWXPjNhL+K11MHjYV6vKxifWmkTUTVeZwWUq2KrHXBZEtETMkwACgbK1X/30LAEnx",
-	"kkV5JrPO1k7Ng0UCjcaHrxt98NHxeBRzhkxJZ/joCJQxZxLNj1fEv8Y/EpRK//I4U8jMnySOQ+oRRTnr",
-	"fZSc6WfSCzAi+q9vBS6dofNNbye6Z9/K3kwR5hPhT4Tgwtlut67jo/QEjbUwZ6jXBJEuqt+mE7XcMQpF",
-	"l3pd1D9jwWP9xOrqU6koWyVUBujfMRKZMWoTozN0pBKUrZyt61Dp31lpT2k5lf5I6uEyWXxET919ws0d",
-	"CVdcT8QHEsWhFjsZX85GjltfpTiN+gcxsaN/xs30Us9ek5D6VG0Ozfs1G6dx0phRgb4z/L0Ji3znBfEN",
-	"26upfus6iiqz2wL8UDyzfP/czNQ7GAeEsvoZUSkTFIe2VTzmHZZHzargkYlwMw327MrTarfa2ytBtSq1",
-	"DR48azPbHnM7NKpUbD3+s1lE/R0ddtAVBBdQNHiA9ywsp5dlq1qS81PSPyOO6yy5iIhyhk6AD53UvJ46",
-	"uqmPTD8yetas8iceNxwZUyiWxMOSEmcn+Xw9YIXiaOdRRTMzv92CBfyuiApA4ipCpiDgcRNYVm4JqkFn",
-	"uez3h/3hYNB3XCcmSqHQXvSfNzf+952//U46y37n4vZx4J5th989nmzLj777tx73bQHT6eyyM5odAPIt",
-	"X73FNYZ1NMPscdmpv+WrFWUrsK9dB1kSGUeFi2RlMFlq34PmUrh1CztM31RUqGBrxd42YHYl+CLEqOG6",
-	"QEVog6YjCJKIMBBIfLIIEfAhDgkzVx3IGD1NOFAcVEAlcM9LhEDmIfAlqAAhtguCCogCKiHAMF4moZ4R",
-	"csPU4ijCfFjRNQLx11QLYRDwez04FtxD9LvwD0GVQgaUwYStQioDMyvXb8kFIFtRhiikC4lMSBhugHEF",
-	"MqEKfTOCcQYKvYBRj4QgFfmEAQ99FNJI06O1eiH9F/pdp3gAY84Yemb7ioNPFFkQiaBohD7wRDXxgzKp",
-	"CLMmVYX3l+spCFyiRc3ClJFNGnBylPei6wJ2V11YbID4vuYVgaUg1nhyYQK4AJksOrG2LXNihePZxNiF",
-	"d2QDC4REol85IMG5sotSmU+izOrHE+EheNzHMlS9dGDPyzHrGEp/o/gnZB3N5Y4+uI5Br2PRy31cImgn",
-	"R6YxqlBEJbIO6jxA+Gk+vwI7wGgGK2QoiD7/xcaozQVdUQYSxRqFIcXTFC7t7bx/6joReaCRNtzziwvX",
-	"iSizvwb9fpOzTD1KnQEy4EKTM4qI2NTsxhzMf5v0MxTGHn9hZE1oqNdsOhD7QO9wSZJQnyFZ8EQNFyFh",
-	"nxy3DfcTRv9IMNxUjaCIB3CmB1j2mSj8QRVwW1MffRhdTbvwIY55SuaiJVnvRRlcvx53fvix/4ML1Hgn",
-	"hlQFKECgx6MImW/nLnSAlylqANd4xZwypV8T6yM7+XH43Eu08dl1GBewCvnCHIndX0q3yjG3M54jTKQa",
-	"9ll7yajYdD/M7JVbvx/wIaaC2JN73CngE4XGepvoEPDYhhQKo4NRgg5Gcgo5RAiyMa7zcLZgVbYxZEik",
-	"uktirZbfXlH9XCoSxW2nNEWGOyFuEa2KTikqhVBnNp5+eA9xMeA5ECWmO94TcyPz747M6o4FGdlKBQ1R",
-	"jXmeWWK6mRKrB02OUSoi1N1nxZImMi+KcYsw5BrXAvRnY1+L0Rdn5/7ZmX8wRk/nHwgoy1WB+hFnj8v4",
-	"m9EQoZRkdZi0eXBZ32Mx/y5t88cLeHUBZxcwPoGT1/r/xRguL6F/CScjOP8BRhdwOYEfJ+bVObw+hf4F",
-	"DPpwOSgiI2Piod8pA1TFYH49ru+cJCrggmrPusY7ktZlWjmYnO1VF+Nx8aVElc6jqdpy0NDm1+MvVPQw",
-	"RlGobey26TbBWFa+YCnz6/Eho5hfj59dAEg3XFe+ZqztFLGULWuhI/Q7lkQLW1542h1R6bdIfSUKSsIm",
-	"oaf14fXU13FLSlXlVeBvcha7Tf9aYEp534yrO7JUFQWdk/7JSac/6PTP5v2L4fnF8PT0t6J5PnlXapkL",
-	"XHKBNaGDZwqtwFNYwS1soYBJtmOIUVDu10HZbtMcuuYjs0h2dDXNgzB7C1wSjCyrShezfazHa3NCIa2c",
-	"frffHWg8eIyMxNQZOqfdfvfEVh0CA3+vUP8xD1aoGm5NKpWNZE3eocINEE/bZb18JG2MTATCJ8bvWRrX",
-	"3jAdBAsemmSGetgFnQIJlEmowCNMB7BLGioUNv2xRY0uvE6EDncjLtC9YZyhGRwTKYFATISiXhISkUa6",
-	"OuCmEQJRcB9QL7BK73S8YamSWj/jeIBIoCxOVBdGsOA8RMIyffJAXXEQqBLBgIThDSti5oLAFRF+iFKm",
-	"YQUV6aHr3zoXMUTo3uiD09Q3QdfUd4bOG1TjIv76YASJUKGQzvD3R4dq9P9IUGjvaAvku6pUu+p9Ho40",
-	"SzMg3BFVktfOIpoFkjAsyUqnpdA62+2tW+5YnPT7R7UqWl1/hYpv7Q6sNzAMv3lDMdTcoGdPKpjmQN8f",
-	"11PJilwNykyZJWapo2Iz75Ip1nV1HUVWmjiOF8efqHOrp5YsvPdohnaov91r7G9wzwLGGRFT/GKQloEP",
-	"s3oPqbUH2pEm08opulklEmzL8rxG/9n0OrhK05nVytovjjd7T/U41vQWIV88gzrIPK49KZFwNXkHi41C",
-	"CVrW80j1Smvxoon10Ikx6ixpWIlBOvrfq8mb6XsYT67n09fT8Wg+MU9v2GhWJFK3271h5s3k/WXD6CdF",
-	"jUfHiHJaUNoc11+H11bdPeTmbElXBRrXuWZHHDxyhQ+qF4dp67R26+WXZW1Xs8TzUMplEsKHbPECuE1Y",
-	"5ar0Ck3+MhpXgjJlq5HzD+/egt1oYsXr+MpU8HJIeKTDSYtJFovuQ2Rquzp/LTxeEUk90FvTAY3GICYr",
-	"BFPyzUuzhajU9nCk3ItSyFe9vGG2D6q81/YnXkX5Gl8NS21pYaUpWMPIdeKkAZRZBRQj/xX3N18Fj6yV",
-	"WVx/dxNs/6dOadbmlDST02Jii6SvXoHck+Q15XayKbkzYxURyjQpkPkwmlVrsjBlMkbPqkCZT9fUT0i4",
-	"U8EGDjoxBNsZRh/WFO+7TbHDLNttLWioHIrRKu2o82Vjhbjawm/KgiqV3qNTtUq3EEVEGQnhCaVOMqVO",
-	"9ipVqjcfp9JXSdpKTYMj0raIKC/QhG9gavflZnAN2haMNX1UsdbeY/pXlsL5GKJq6Nlemud71oF7qmxt",
-	"xMbd2ePpZd14rKCs6XbAfOY7A4bpZd7wLCzdhekyNek4UbAmYYJApe0wo9COhQEpCMn6nh5nkvrGgxCI",
-	"BS7pg/EeJAx3BCg7KWJcg1bf1y6JSi0nkahdrvYe5l192oJI9IGztJaTeVOtii0qURthDU5MHpMpk26W",
-	"eKrgpiDLZvAhDrmPznBJQoluU66yO9lnZyulHphUG+MZJDUuosGGzxqKj03tJgPhSzAk1zn/2hooFNrz",
-	"zuw3GNk3sEWDftLUGi3afTp9LjzVrCK7z2vq8p+67erW+iJZ+OXCrWzfTdFWndeFtKD7YvPaw+3f9vdF",
-	"u+JNw4p7qzdPsa+5RvOXY2CLQs7VaP4TzCZv3k3ez9OCigHxiqgga6aXKzANM5xWnH3RNZh9+u4jqRJe",
-	"i/QjJAqlSoXPRSIVXHOuYFysbdh0AIkX6OB9T3pyfAuqCx+MPiQMN64JNebX4zylSdFAHyiTColp+JgP",
-	"vwp6c4ay0Uzmevft7KPeASoF8Hlw3fBFX6X7n9mC9nzOy27h5B37IzKBdNlFiOagup+fT+c01PL2lBM1",
-	"j3tU+o9U+tvO4lEHkNuOfLQN821Lj7uP2nuq4XPhtaqAW7Lsd6NPfkSQM6QsU2+wndBBa5kWrHZSm75f",
-	"+DPjivn1uIl18+tx98vU1VKCPY9fx1zr+0iWXe3ZTW8SG3PD72Vf6x7M/xn4zLhifj1Og4PfPo7uP3wc",
-	"/f3dfHI/rcQSu1FOI0WrMcPn03Rva8V+dLTOuJCI0Bk6gVLxsNd7DLhU2+FjzIXa9khMe+uB+fpLUO2v",
-	"DWJ6SPnjbPOxt3m8dR09tfz6dDA4P9GmeZtrU+X/2BQ9zcc0+GA/tV5sUmtIAwFzP2cNQ1sjrdfgJmsU",
-	"G2WqDAJD85W+4s0Vp2oke6S08dXVz1OIiDJ8LOpmcN7ebv8TAAD//yDXdgQCOgAA",
+	"H4sIAAAAAAAC/+xb63PbNhL/VzBsP1yn1MuPa61viuSkmubhsdXeTGufByJXImISYAHQsc6n//1mAZLi",
+	"06KcNOfeXCYfLAhYLHZ/u9gH9Oh4IooFB66VM350JKhYcAXmwyvqX8IfCSiNnzzBNXDzJ43jkHlUM8EH",
+	"H5XgOKa8ACKKf30rYeWMnW8GO9ID+60aXGnKfSr9cymFdLbbrev4oDzJYiTmjHFPItNN8dt0IdKdgtRs",
+	"hfsCfoyliHHE8uozpRlfJ0wF4N9yGpk5ehODM3aUloyvna3rMOXfUrWPy7nyJwqnq2T5ETx9ewebWxqu",
+	"BS6EBxrFIZI9n86uJo5b36W4jPl7ZWJn/wyb+QxX39OQ+Uxv9q37NZuHckKZMQm+M/69SRb5yQvkG45X",
+	"Y/3GdTTT5rQF8ZOizvLzC7MSTzANKON1HTGlEpD7jlVU806WB62qyCMj4WYctJzKQ7Y7ne2VZLBqOOBe",
+	"XZvVVs3dpFGFYuf5n40i5jtuXXQFwgUpGnkQ71mynM/KVrWip8d0eEId11kJGVHtjJ0AHnqpeT2lurkP",
+	"HIdA7nbbWeU0AO+uwXNQTferDby7GU40DktTFpqlJdc18X2Gf9KQMG5ZZ4L3HbdwuCa+MmdVpvaeRkDE",
+	"igRAQx0QDzko0zKKIIqtOUhC7ykL6TKEph0k0NRLl/e4NONkJaSlT1aUhYmE/TwrTXWiOnh7nFVFVuqR",
+	"Uhqu1UABTT/ZI0+zIzfgJlMHXka52C8KetUygR3F1xIAjxmR3WyC25qz6wBqYq7taZmqw8esUHXZvmVK",
+	"V/WnkDLTEKlOgHO2OR9USrr5bMHnEk+ZLsj8KokiKjcFju1kQrlfYL5FLJdp2FAXT5CL7Sl+U+FW+U0X",
+	"F9kEec+8XF0VO6tzJ+I6S4xrkCvqQcnvnBzl63HCGuTB8ULVgWY37m7DwkkuKMoY1hFwTQIRN7Fv6Ra5",
+	"dEa91Wo4HA/Ho9HQcZ2Yag0S8fbP62v/+97ffqe91bB3dvM4ck+24+8ej7bloe/+jfO+LbjR+dWsN7na",
+	"4zvfivVbuIewLs0wG67AX6zXjK+J/dp1gCeRiU1gmayNTFYCh00ceFN0N+k3FRYqsrVkbxpkdiHFMoSo",
+	"wc+3uW0SJBHlRAL10YESeIhDyg2oiIrBwzuGaEF0wBQRnpdICdwzzhk9R2w3JDqgmjBFAgjjVRLiilCY",
+	"y6k4C81pze6BUN8AWXASiE84OZbCA/D75B+SaQ2cME7O+TpkKjCrcv7QZQFfMw4glUsSldAw3BAuNFEJ",
+	"0+CbGVxwosELOPNoiMZ8B4EIfZDWpHE2sheyf4Ff9vdTwTl45vhaGC+5pAqIZhH4RCS6CR+MK025B03i",
+	"/eVyTiSswErNiikDmzLCyaXcKl2XQH/dJ8uNceB8TShZSWqNJycmiZBEJctejLalRZEAQZb75B3dkCWQ",
+	"RIFfUZAUQttNmcoXMW75E4n0gHjCr1yNg3TiwMtl1jOQ/kaLO+A9xHIPFdcz0utZ6eVhTSJZL5fM09ds",
+	"WaiLAMhPi8VF5qSRM7IGDpKi/pcbw7aQbM04USDvQaY33VMQLp3tdHjsOhF9YBEa7unZmetEjNtPo+Gw",
+	"yVmmHqWOABUIieDMr5i6Yv7boM8ull/4k5GUHcATrmgSog7pUiR6vAwpv3PcLthPOPsjgXBTNYKiPIjg",
+	"4SZDn0m8H3RBbvfMB59MLuZ98iGORQrmoiVZ78U4uXw97f3w4/AHlzDjnTgwHYAkEjwRRcB9u3YJxIeM",
+	"USNwlFcsGNf4NbU+sperwxdegsZn9+FCknUolkYl9nx5YFVSczfjOcBE2gIcC8Wm++HKXrn1+wEeYiap",
+	"1dzjjgGfajDW2wSHQMRmbaeYDoORhoiuQ4HAsmzTxpAqfZvEyJbfnVEcV5pGcdclTcngjohblFaFp1Qq",
+	"xaBtOv/wnsTFgGdPYpieuCXNBu7fHljIOVTIwNc2aq1ENWY8s8T0MCVUj5oco9JU6tvPiiV9p0LGLYoh",
+	"57iWkz9b9rW0fHly6p+c+HvT8nT9noCyXAisqzgbLsvfzCYRKEXX+0GbB5f1M+b3ahaXxlQpew4f1pL6",
+	"BsiYDuNgKT7dzazkq+ldnIPDXCj9xsMXK34lKf94Rl6dkZMzMj0iR6/x/9mUzGZkOCNHE3L6A5mckdk5",
+	"+fHcfHVKXh+T4RkZDclsVFSMiqkHfq+snyoXi8tpXfA00YGQDB37PdxSBd39W25sVQ/nCfmlSJXg0FTf",
+	"3Wvni8vpFyqzGpssVFN3x3SbxFhmvmCoi8vpPptcXE6fXXJMD1xnvuYrujEyn9W5wAThlifREmQJz6OW",
+	"nLpD5q1AMho2ET2uT69n3o5bYqpKryL+Jl+1O/SvBaSUz82FvqUrXWHQORoeHfWGo97wZDE8G5+ejY+P",
+	"fyua55NXNdJcwkpIqBEdPZNotf6228EtHKEgk+zEJAbJhF8XynabpvA1F50F0pOLeR4D2ktoRiGyqCrF",
+	"BXYY56M5gVSWzrA/7I9QHiIGTmPmjJ3j/rB/ZIsegRH/oFBxNgNr0C2VOBNIm7RHhxtCPbTLesFa2RCd",
+	"SiB3XHziaVh9zTEGlyLMvTrBDEyCSkJNPMoxfl6xUIO02ZetqfTJ60RitB0JCe41FxzMZLxBCCUxlZp5",
+	"SUhlGmhjvM8iIFSTTwHzAsv0jsdrnjKJ/NnqL1WE8TjRfTIhSyFCoDzjJ88TtCASdCI5oWF4zYsyc4mE",
+	"NZV+CCq7uJhMlY6fMRUyQOhfo+IQ+ibmm/vO2HkDelqUPypG0gg0SOWMf390GEr/jwQkekdb5d4Vxbr1",
+	"C/NoqJmaEcIt1SV63SyimSANwxKtdFkqWme7vXHLPdKj4fCg5mjH6m/eY6rdgfWWaVZprqMZV588yWCa",
+	"gn1/WBc3q7E1MDPnFpilHq5N/EumWOfVdTRdI3AcL47vmHODS0sWPng0U3vM37Ya+xto2cA4I2pqb5yk",
+	"jaf9qG4BNXqgHWgyrpyim7UNiG7yzLuCnw2vvbs06azWSHtxuGnV6mGoGSxDsXwGdIBjgc1424vzd2S5",
+	"0aAI0noeqF4hFy8aWA+9GKLeioWVGKSH/16dv5m/J9Pzy8X89Xw6WZyb0Ws+uSoCqd/vX3Pzzfn7WcPs",
+	"J0lNJ4eQcjpA2qjrr4Nry24LuAVfsXUBxnWs2Rl7VY5lxUEcpo81ardeflnWTnWVeB4ohW2OD9nmBeE2",
+	"ySpnZVB4VlSWxoVkXNti6OLDu7fEHjSx5DG+gn5RJCLCcNLKZNdmbDTtCwkKuC52estpOqGh4GvyiWkb",
+	"dcEDeIkGv96+rQk77V3+iY670mNt0scTbdHPVsuc+yxvk6jSTkV9ZM1ao48sN2hD6Nw2+f5a+HxFFfOK",
+	"wiUxXQMxHYC8Ul/IEmxLT6lW1IZiPcj7p22iyluvfyLC8j2+mizR84WVHnFNRq4TJw1CuaoIxdB/JfzN",
+	"V5FH1tku7r+7mbf/U1q66qIlRHJaW+6QhNcL0i1Jd1OurZqSbTNXU6lNzwq4TyZX1RI9mXMVg2dZYNxn",
+	"98xPaJh9r9JADhN1Yh8KgE/uGXxqdPlX2WlrQVxFKYar9IGFWDU2DKovOpqy0krh/+DUudI8BhkxfAD1",
+	"BFNHGVNHrUyV2g+HsfRVkuhSD+mANDqi2gsQ8A1I7b/cjLqB24KxpkMVax08pn9lKbUPIeiGFv7MjLfs",
+	"s4uabB6UDc9ndeOxhFLV7DOfxc6AyXyW978LW/fJfJWadJxorIklQJiyDw4Ai4+UE1ogkrXBPcEV840H",
+	"oSSWsGIPxnvQMNwBoOykqHENyL6PLokppJMoQJeL3sN8V1+GVWgfn/nY2lrmTZEVW+RjNuIdHZm8MmMm",
+	"PSz1dMFNkSy7xCdKwgdnvKKhArcpd9xp9tnZY6klqvTGeAbFjItosOGThmJwU/fRiPAlGJLrnH5tDjRI",
+	"9LxX9klO9iuIokE/aWqNFu0+Xc4ojCKq6O61VZ3+U7dd3VpfJAq/XLiVnbsp2qrjuiXnell1hv2vAbrf",
+	"F92KaQ07tlbTnkJfc83sL4fADoW1i8niJ3J1/ubd+ftFWuAyQsQHwyknlYpYwwqnE2ZfdE2sjd82kGrp",
+	"dUg/QqpB6ZT4QiZKk0shNJkWa002HQDqBRi8t6Qnh7cE8bWe/e1BuHFNqLG4nOYpTSoN8AnjSgM1DTjz",
+	"DrDAt+DQXIVa4Om72Ue9I+e4TcF1wwPP2o8RrC2g53Nedkstf0FxQCaQbovvHVFRX6CUlsMQ6bWUdxHH",
+	"A6b8R6b8bW/5iAHktqce7QOGbUeP2wbtlu7EQnqdOhIWLO1u9MlHHVu3kSYesBvRUWeaVljdqDa9J/kz",
+	"4wp8d9WAusXltP9l6mopwJ6Hr0Ou9TaQZVd7dtObxMbc8K3o69wT+z8CnxlXLC6naXDw28fJpw8fJ39/",
+	"tzj/NK/EErtZTiNEqzHD58O0tdW1NY+27jMsJDJ0xk6gdTweDB4DofR2/BgLqbcDGrPB/ci8xpMM/bX9",
+	"6ZlQuvxW37z9N8NYWhay8vXxaHR6hKZ5k3NTxf9UROljJfyZkHl5v9yk1pAGAqq/A0FaI63X4M7vQW60",
+	"qTJICM2PNrRorjhVI9kDqU0vLn6eY03D4LHIm5FzAzHum+dIqkir0DjbPXNtOHCQ/pDvZvufAQB4TsoU",
+	"WkAAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -14,6 +14,13 @@ const (
 	Info  LogLevelLevel = "info"
 )
 
+// Defines values for Status.
+const (
+	Degraded Status = "degraded"
+	Failing  Status = "failing"
+	Passing  Status = "passing"
+)
+
 // Certificate defines model for Certificate.
 type Certificate struct {
 	DistinguishedName string       `json:"distinguished_name"`
@@ -40,6 +47,36 @@ type ChainBrief struct {
 // ChainID defines model for ChainID.
 type ChainID = string
 
+// Check defines model for Check.
+type Check struct {
+	Data CheckData `json:"data"`
+
+	// Detail Additional information.
+	Detail *string `json:"detail,omitempty"`
+
+	// Name Name of health check.
+	Name string `json:"name"`
+
+	// Reason Reason for check failure.
+	Reason *string `json:"reason,omitempty"`
+	Status Status  `json:"status"`
+}
+
+// CheckData defines model for CheckData.
+type CheckData map[string]interface{}
+
+// Health defines model for Health.
+type Health struct {
+	// Checks List of health checks.
+	Checks []Check `json:"checks"`
+	Status Status  `json:"status"`
+}
+
+// HealthResponse defines model for HealthResponse.
+type HealthResponse struct {
+	Health Health `json:"health"`
+}
+
 // Hop defines model for Hop.
 type Hop struct {
 	Interface int   `json:"interface"`
@@ -104,6 +141,9 @@ type StandardError struct {
 	Error string `json:"error"`
 }
 
+// Status defines model for Status.
+type Status string
+
 // SubjectKeyID defines model for SubjectKeyID.
 type SubjectKeyID = string
 
@@ -31,6 +31,8 @@ import (
 	"github.com/scionproto/scion/gateway/config"
 	"github.com/scionproto/scion/gateway/dataplane"
 	api "github.com/scionproto/scion/gateway/mgmtapi"
+	"github.com/scionproto/scion/gateway/routemgr"
+	"github.com/scionproto/scion/pkg/addr"
 	dpkg "github.com/scionproto/scion/pkg/daemon"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
@@ -114,35 +116,6 @@ func realMain(ctx context.Context) error {
 	}
 	var cleanup app.Cleanup
 	g, errCtx := errgroup.WithContext(ctx)
-	if globalCfg.API.Addr != "" {
-		r := chi.NewRouter()
-		r.Use(cors.Handler(cors.Options{
-			AllowedOrigins: []string{"*"},
-		}))
-		r.Get("/", api.ServeSpecInteractive)
-		r.Get("/openapi.json", api.ServeSpecJSON)
-		server := api.Server{
-			Config:   service.NewConfigStatusPage(globalCfg).Handler,
-			Info:     service.NewInfoStatusPage().Handler,
-			LogLevel: service.NewLogLevelStatusPage().Handler,
-		}
-		log.Info("Exposing API", "addr", globalCfg.API.Addr)
-		h := api.HandlerFromMuxWithBaseURL(&server, r, "/api/v1")
-		mgmtServer := &http.Server{
-			Addr:    globalCfg.API.Addr,
-			Handler: h,
-		}
-		defer mgmtServer.Close()
-		g.Go(func() error {
-			defer log.HandlePanic()
-			err := mgmtServer.ListenAndServe()
-			if err != nil && !errors.Is(err, http.ErrServerClosed) {
-				return serrors.Wrap("serving service management API", err)
-			}
-			return nil
-		})
-		cleanup.Add(mgmtServer.Close)
-	}
 
 	httpPages := service.StatusPages{
 		"info":      service.NewInfoStatusPage(),
@@ -162,6 +135,8 @@ func realMain(ctx context.Context) error {
 		ProbeClientIP:            controlAddress.IP,
 		DataServerAddr:           dataAddress,
 		DataClientIP:             dataAddress.IP,
+		CipherSuites:             globalCfg.Gateway.CipherSuites,
+		RequireEncryption:        globalCfg.Gateway.RequireEncryption,
 		Daemon:                   daemon,
 		RouteSourceIPv4:          globalCfg.Tunnel.SrcIPv4,
 		RouteSourceIPv6:          globalCfg.Tunnel.SrcIPv6,
@@ -174,6 +149,42 @@ func realMain(ctx context.Context) error {
 		Metrics:                  gateway.NewMetrics(localIA),
 		RpcConfig:                globalCfg.RPC,
 	}
+	if globalCfg.BGP.Peer != "" {
+		if gw.BGP, err = newBGP(globalCfg.BGP); err != nil {
+			return err
+		}
+	}
+
+	if globalCfg.API.Addr != "" {
+		r := chi.NewRouter()
+		r.Use(cors.Handler(cors.Options{
+			AllowedOrigins: []string{"*"},
+		}))
+		r.Get("/", api.ServeSpecInteractive)
+		r.Get("/openapi.json", api.ServeSpecJSON)
+		server := api.Server{
+			Config:   service.NewConfigStatusPage(globalCfg).Handler,
+			Info:     service.NewInfoStatusPage().Handler,
+			LogLevel: service.NewLogLevelStatusPage().Handler,
+			Gateway:  gw,
+		}
+		log.Info("Exposing API", "addr", globalCfg.API.Addr)
+		h := api.HandlerFromMuxWithBaseURL(&server, r, "/api/v1")
+		mgmtServer := &http.Server{
+			Addr:    globalCfg.API.Addr,
+			Handler: h,
+		}
+		defer mgmtServer.Close()
+		g.Go(func() error {
+			defer log.HandlePanic()
+			err := mgmtServer.ListenAndServe()
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return serrors.Wrap("serving service management API", err)
+			}
+			return nil
+		})
+		cleanup.Add(mgmtServer.Close)
+	}
 
 	g.Go(func() error {
 		defer log.HandlePanic()
@@ -191,3 +202,32 @@ func realMain(ctx context.Context) error {
 
 	return g.Wait()
 }
+
+func newBGP(cfg config.BGP) (*routemgr.BGP, error) {
+	toAddr := func(ip net.IP) netip.Addr {
+		a, _ := netip.AddrFromSlice(ip)
+		return a.Unmap()
+	}
+	remotes := make(map[addr.IA]routemgr.BGPRemote, len(cfg.Remotes))
+	for raw, remote := range cfg.Remotes {
+		ia, err := addr.ParseIA(raw)
+		if err != nil {
+			return nil, serrors.Wrap("parsing BGP remote", err, "isd_as", raw)
+		}
+		remotes[ia] = routemgr.BGPRemote{
+			Communities: remote.Communities,
+			LocalPref:   remote.LocalPref,
+		}
+	}
+	return &routemgr.BGP{
+		LocalAS:     cfg.LocalAS,
+		PeerAS:      cfg.PeerAS,
+		PeerAddr:    cfg.Peer,
+		RouterID:    toAddr(cfg.RouterID),
+		HoldTime:    cfg.HoldTime.Duration,
+		NextHopIPv4: toAddr(cfg.NextHopIPv4),
+		NextHopIPv6: toAddr(cfg.NextHopIPv6),
+		LocalPref:   cfg.LocalPref,
+		Remotes:     remotes,
+	}, nil
+}
@@ -18,8 +18,14 @@ import (
 	"io"
 	"net"
 	"strconv"
+	"time"
 
+	"github.com/scionproto/scion/gateway/framecrypto"
+	"github.com/scionproto/scion/gateway/routemgr"
+	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/util"
 	"github.com/scionproto/scion/private/config"
 	"github.com/scionproto/scion/private/env"
 	api "github.com/scionproto/scion/private/mgmtapi"
@@ -43,6 +49,8 @@ const (
 
 	DefaultTunnelName           = "sig"
 	DefaultTunnelRoutingTableID = 11
+
+	defaultBGPPort = 179
 )
 
 type Config struct {
@@ -54,6 +62,7 @@ type Config struct {
 	Daemon   env.Daemon   `toml:"sciond_connection,omitempty"`
 	Gateway  Gateway      `toml:"gateway,omitempty"`
 	Tunnel   Tunnel       `toml:"tunnel,omitempty"`
+	BGP      BGP          `toml:"bgp,omitempty"`
 }
 
 func (cfg *Config) InitDefaults() {
@@ -65,6 +74,7 @@ func (cfg *Config) InitDefaults() {
 		&cfg.Daemon,
 		&cfg.Gateway,
 		&cfg.Tunnel,
+		&cfg.BGP,
 	)
 }
 
@@ -77,6 +87,7 @@ func (cfg *Config) Validate() error {
 		&cfg.Daemon,
 		&cfg.Gateway,
 		&cfg.Tunnel,
+		&cfg.BGP,
 	)
 }
 
@@ -89,6 +100,7 @@ func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
 		&cfg.Daemon,
 		&cfg.Gateway,
 		&cfg.Tunnel,
+		&cfg.BGP,
 	)
 }
 
@@ -108,6 +120,11 @@ type Gateway struct {
 	DataAddr string `toml:"data_addr,omitempty"`
 	// Probe address, for probing paths.
 	ProbeAddr string `toml:"probe_addr,omitempty"`
+	// CipherSuites are the cipher suites that are accepted for protected
+	// frames. They are advertised to the remote gateways.
+	CipherSuites []framecrypto.CipherSuite `toml:"cipher_suites,omitempty"`
+	// RequireEncryption indicates whether unprotected frames are discarded.
+	RequireEncryption bool `toml:"require_encryption,omitempty"`
 }
 
 func (cfg *Gateway) Validate() error {
@@ -120,6 +137,14 @@ func (cfg *Gateway) Validate() error {
 	cfg.CtrlAddr = DefaultAddress(cfg.CtrlAddr, defaultCtrlPort)
 	cfg.DataAddr = DefaultAddress(cfg.DataAddr, defaultDataPort)
 	cfg.ProbeAddr = DefaultAddress(cfg.ProbeAddr, defaultProbePort)
+	for _, suite := range cfg.CipherSuites {
+		if !suite.Supported() {
+			return serrors.New("unsupported cipher suite", "cipher_suite", suite)
+		}
+	}
+	if cfg.RequireEncryption && len(cfg.CipherSuites) == 0 {
+		return serrors.New("require_encryption requires at least one cipher suite")
+	}
 	return nil
 }
 
@@ -158,6 +183,85 @@ func (cfg *Tunnel) ConfigName() string {
 	return "tunnel"
 }
 
+// BGP holds the configuration of the BGP session with a local router.
+type BGP struct {
+	config.NoDefaulter
+
+	// Peer is the address of the BGP peer. If empty, BGP is disabled.
+	Peer string `toml:"peer,omitempty"`
+	// LocalAS is the AS number of the gateway.
+	LocalAS uint32 `toml:"local_as,omitempty"`
+	// PeerAS is the AS number of the peer.
+	PeerAS uint32 `toml:"peer_as,omitempty"`
+	// RouterID is the BGP identifier of the gateway.
+	RouterID net.IP `toml:"router_id,omitempty"`
+	// HoldTime is the hold time proposed to the peer.
+	HoldTime util.DurWrap `toml:"hold_time,omitempty"`
+	// NextHopIPv4 is the next hop of the exported IPv4 routes.
+	NextHopIPv4 net.IP `toml:"next_hop_ipv4,omitempty"`
+	// NextHopIPv6 is the next hop of the exported IPv6 routes.
+	NextHopIPv6 net.IP `toml:"next_hop_ipv6,omitempty"`
+	// LocalPref is the local preference of the exported routes.
+	LocalPref uint32 `toml:"local_pref,omitempty"`
+	// Remotes holds the attributes of the exported routes per remote ISD-AS.
+	Remotes map[string]BGPRemote `toml:"remotes,omitempty"`
+}
+
+// BGPRemote holds the attributes of the routes learned from a remote ISD-AS.
+type BGPRemote struct {
+	// Communities are attached to the exported routes.
+	Communities []routemgr.Community `toml:"communities,omitempty"`
+	// LocalPref overrides the local preference of the exported routes.
+	LocalPref uint32 `toml:"local_pref,omitempty"`
+}
+
+func (cfg *BGP) Validate() error {
+	if cfg.Peer == "" {
+		return nil
+	}
+	cfg.Peer = DefaultAddress(cfg.Peer, defaultBGPPort)
+	if cfg.LocalAS == 0 {
+		return serrors.New("local_as must be set")
+	}
+	if cfg.PeerAS == 0 {
+		return serrors.New("peer_as must be set")
+	}
+	if cfg.RouterID.To4() == nil {
+		return serrors.New("router_id must be an IPv4 address", "router_id", cfg.RouterID)
+	}
+	if cfg.HoldTime.Duration == 0 {
+		cfg.HoldTime.Duration = routemgr.DefaultBGPHoldTime
+	}
+	if cfg.HoldTime.Duration < 3*time.Second {
+		return serrors.New("hold_time must be at least 3s", "hold_time", cfg.HoldTime)
+	}
+	if cfg.NextHopIPv4 != nil && cfg.NextHopIPv4.To4() == nil {
+		return serrors.New("next_hop_ipv4 must be an IPv4 address",
+			"next_hop_ipv4", cfg.NextHopIPv4)
+	}
+	if cfg.NextHopIPv6 != nil && cfg.NextHopIPv6.To4() != nil {
+		return serrors.New("next_hop_ipv6 must be an IPv6 address",
+			"next_hop_ipv6", cfg.NextHopIPv6)
+	}
+	if cfg.LocalPref == 0 {
+		cfg.LocalPref = routemgr.DefaultBGPLocalPref
+	}
+	for ia := range cfg.Remotes {
+		if _, err := addr.ParseIA(ia); err != nil {
+			return serrors.Wrap("parsing remote ISD-AS", err, "isd_as", ia)
+		}
+	}
+	return nil
+}
+
+func (cfg *BGP) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, bgpSample)
+}
+
+func (cfg *BGP) ConfigName() string {
+	return "bgp"
+}
+
 // DefaultAddress determines the default address. If port is not specified, or
 // is zero, it is set to the default port. If the input is garbage, the output
 // is garbage as well.
@@ -17,12 +17,14 @@ package config_test
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	toml "github.com/pelletier/go-toml/v2"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/scionproto/scion/gateway/config"
 	"github.com/scionproto/scion/gateway/config/configtest"
+	"github.com/scionproto/scion/gateway/routemgr"
 	"github.com/scionproto/scion/pkg/log/logtest"
 	"github.com/scionproto/scion/private/env/envtest"
 	apitest "github.com/scionproto/scion/private/mgmtapi/mgmtapitest"
@@ -45,6 +47,7 @@ func InitConfig(cfg *config.Config) {
 	apitest.InitConfig(&cfg.API)
 	configtest.InitGateway(&cfg.Gateway)
 	configtest.InitTunnel(&cfg.Tunnel)
+	configtest.InitBGP(&cfg.BGP)
 }
 
 func CheckConfig(t *testing.T, cfg *config.Config) {
@@ -53,4 +56,141 @@ func CheckConfig(t *testing.T, cfg *config.Config) {
 	configtest.CheckGateway(t, &cfg.Gateway)
 	apitest.CheckConfig(t, &cfg.API)
 	configtest.CheckTunnel(t, &cfg.Tunnel)
+	configtest.CheckBGP(t, &cfg.BGP)
+}
+
+func TestGatewayValidateCipherSuites(t *testing.T) {
+	testCases := map[string]struct {
+		input     string
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"no cipher suites": {
+			input:     ``,
+			assertErr: assert.NoError,
+		},
+		"cipher suites": {
+			input:     `cipher_suites = ["aes-128-gcm", "chacha20-poly1305"]`,
+			assertErr: assert.NoError,
+		},
+		"require encryption": {
+			input: `cipher_suites = ["aes-128-gcm"]
+require_encryption = true`,
+			assertErr: assert.NoError,
+		},
+		"require encryption without cipher suites": {
+			input:     `require_encryption = true`,
+			assertErr: assert.Error,
+		},
+		"none": {
+			input:     `cipher_suites = ["none"]`,
+			assertErr: assert.Error,
+		},
+		"unknown": {
+			input:     `cipher_suites = ["rot13"]`,
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var cfg config.Gateway
+			err := toml.Unmarshal([]byte(tc.input), &cfg)
+			if err == nil {
+				err = cfg.Validate()
+			}
+			tc.assertErr(t, err)
+		})
+	}
+}
+
+func TestBGPValidate(t *testing.T) {
+	testCases := map[string]struct {
+		input     string
+		assertErr assert.ErrorAssertionFunc
+		check     func(t *testing.T, cfg *config.BGP)
+	}{
+		"disabled": {
+			input:     ``,
+			assertErr: assert.NoError,
+		},
+		"defaults": {
+			input: `peer = "192.0.2.1"
+local_as = 64512
+peer_as = 64513
+router_id = "192.0.2.2"`,
+			assertErr: assert.NoError,
+			check: func(t *testing.T, cfg *config.BGP) {
+				assert.Equal(t, "192.0.2.1:179", cfg.Peer)
+				assert.Equal(t, 90*time.Second, cfg.HoldTime.Duration)
+				assert.Equal(t, uint32(100), cfg.LocalPref)
+			},
+		},
+		"remotes": {
+			input: `peer = "192.0.2.1:1179"
+local_as = 64512
+peer_as = 64512
+router_id = "192.0.2.2"
+[remotes."1-ff00:0:110"]
+communities = ["64512:110", "65535:1"]
+local_pref = 200`,
+			assertErr: assert.NoError,
+			check: func(t *testing.T, cfg *config.BGP) {
+				assert.Equal(t, "192.0.2.1:1179", cfg.Peer)
+				assert.Equal(t, config.BGPRemote{
+					Communities: []routemgr.Community{64512<<16 | 110, 65535<<16 | 1},
+					LocalPref:   200,
+				}, cfg.Remotes["1-ff00:0:110"])
+			},
+		},
+		"missing AS": {
+			input: `peer = "192.0.2.1"
+router_id = "192.0.2.2"`,
+			assertErr: assert.Error,
+		},
+		"IPv6 router ID": {
+			input: `peer = "192.0.2.1"
+local_as = 64512
+peer_as = 64513
+router_id = "2001:db8::1"`,
+			assertErr: assert.Error,
+		},
+		"short hold time": {
+			input: `peer = "192.0.2.1"
+local_as = 64512
+peer_as = 64513
+router_id = "192.0.2.2"
+hold_time = "2s"`,
+			assertErr: assert.Error,
+		},
+		"invalid remote": {
+			input: `peer = "192.0.2.1"
+local_as = 64512
+peer_as = 64513
+router_id = "192.0.2.2"
+[remotes.invalid]
+local_pref = 200`,
+			assertErr: assert.Error,
+		},
+		"invalid community": {
+			input: `peer = "192.0.2.1"
+local_as = 64512
+peer_as = 64513
+router_id = "192.0.2.2"
+[remotes."1-ff00:0:110"]
+communities = ["65536:1"]`,
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var cfg config.BGP
+			err := toml.Unmarshal([]byte(tc.input), &cfg)
+			if err == nil {
+				err = cfg.Validate()
+			}
+			tc.assertErr(t, err)
+			if tc.check != nil {
+				tc.check(t, &cfg)
+			}
+		})
+	}
 }
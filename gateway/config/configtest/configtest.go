@@ -31,6 +31,8 @@ func CheckGateway(t *testing.T, cfg *config.Gateway) {
 	assert.Equal(t, config.DefaultCtrlAddr, cfg.CtrlAddr)
 	assert.Equal(t, config.DefaultDataAddr, cfg.DataAddr)
 	assert.Equal(t, config.DefaultProbeAddr, cfg.ProbeAddr)
+	assert.Empty(t, cfg.CipherSuites)
+	assert.False(t, cfg.RequireEncryption)
 }
 
 func InitTunnel(cfg *config.Tunnel) {}
@@ -38,3 +40,15 @@ func InitTunnel(cfg *config.Tunnel) {}
 func CheckTunnel(t *testing.T, cfg *config.Tunnel) {
 	assert.Equal(t, config.DefaultTunnelName, cfg.Name)
 }
+
+func InitBGP(cfg *config.BGP) {}
+
+func CheckBGP(t *testing.T, cfg *config.BGP) {
+	assert.Empty(t, cfg.Peer)
+	assert.Zero(t, cfg.LocalAS)
+	assert.Zero(t, cfg.PeerAS)
+	assert.Empty(t, cfg.RouterID)
+	assert.Empty(t, cfg.NextHopIPv4)
+	assert.Empty(t, cfg.NextHopIPv6)
+	assert.Empty(t, cfg.Remotes)
+}
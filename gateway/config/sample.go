@@ -57,6 +57,20 @@ data_addr = ":30056"
 #
 # (default ":30856")
 probe_addr = ":30856"
+
+# The cipher suites that are accepted for protected frames received from other
+# gateways. They are advertised to the remote gateways in the probe replies.
+# Sessions that protect their frames with a cipher suite are only established if
+# the remote gateway accepts it. The keys are derived from DRKey host-host keys
+# that are fetched from the SCION Daemon.
+# Supported values: "aes-128-gcm", "chacha20-poly1305".
+# (default [])
+cipher_suites = []
+
+# Whether unprotected frames received from other gateways are discarded. If
+# set, at least one cipher suite must be configured.
+# (default false)
+require_encryption = false
 `
 
 const tunnelSample = `
@@ -69,3 +83,40 @@ src_ipv4 = "192.0.2.100"
 # (default "")
 src_ipv6 = "2001:db8::2:1"
 `
+
+const bgpSample = `
+# The address of the local BGP router. If the port is empty, or zero, the
+# default port 179 is used. If empty, the gateway does not speak BGP.
+# (default "")
+peer = ""
+
+# The AS number of the gateway. Required if peer is set.
+local_as = 0
+
+# The AS number of the peer. If it equals local_as, the session is iBGP.
+# Required if peer is set.
+peer_as = 0
+
+# The BGP identifier of the gateway. Must be an IPv4 address. Required if peer
+# is set.
+router_id = ""
+
+# The hold time proposed to the peer. (default "90s")
+hold_time = "90s"
+
+# The next hops of the exported routes. If empty, the local address of the BGP
+# session is used for the matching address family.
+# (default "")
+next_hop_ipv4 = ""
+next_hop_ipv6 = ""
+
+# The local preference of the exported routes on iBGP sessions. (default 100)
+local_pref = 100
+
+# The attributes of the routes learned from a remote ISD-AS. Communities are
+# written as "<asn>:<value>". A non-zero local_pref overrides the default.
+# Example:
+#   remotes = { "1-ff00:0:110" = { communities = ["64512:110"], local_pref = 200 } }
+# (default {})
+remotes = {}
+`
@@ -31,12 +31,14 @@ import (
 	"github.com/olekukonko/tablewriter/renderer"
 	"github.com/olekukonko/tablewriter/tw"
 
+	"github.com/scionproto/scion/gateway/framecrypto"
 	"github.com/scionproto/scion/gateway/pathhealth"
 	"github.com/scionproto/scion/gateway/pathhealth/policies"
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/metrics"
 	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/snet"
 	"github.com/scionproto/scion/private/worker"
 )
 
@@ -245,6 +247,63 @@ func (e *Engine) Status(w io.Writer) {
 	}
 }
 
+// Sessions returns the state of the sessions of the engine, sorted by remote
+// ISD-AS and session ID.
+func (e *Engine) Sessions() []SessionInfo {
+	e.stateMtx.RLock()
+	defer e.stateMtx.RUnlock()
+
+	healthy := make(map[uint8]bool, len(e.sessionMonitors))
+	for _, sm := range e.sessionMonitors {
+		healthy[sm.ID] = sm.sessionState().Healthy
+	}
+	paths := make(map[uint8]pathhealth.Selection, len(e.sessions))
+	for _, s := range e.sessions {
+		s.pathResultMtx.RLock()
+		paths[s.ID] = s.pathResult
+		s.pathResultMtx.RUnlock()
+	}
+	sessions := make([]SessionInfo, 0, len(e.SessionConfigs))
+	for _, sc := range e.SessionConfigs {
+		var matcher string
+		if sc.TrafficMatcher != nil {
+			matcher = sc.TrafficMatcher.String()
+		}
+		selection := paths[sc.ID]
+		inUse := make(map[snet.PathFingerprint]struct{}, len(selection.Paths))
+		for _, path := range selection.Paths {
+			inUse[path.Metadata().Fingerprint()] = struct{}{}
+		}
+		sessionPaths := make([]SessionPathInfo, 0, len(selection.PathInfo))
+		for _, entry := range selection.PathInfo {
+			_, ok := inUse[entry.Stats.Fingerprint]
+			sessionPaths = append(sessionPaths, SessionPathInfo{
+				PathInfoEntry: entry,
+				InUse:         ok,
+			})
+		}
+		sessions = append(sessions, SessionInfo{
+			ID:             sc.ID,
+			PolicyID:       sc.PolicyID,
+			RemoteIA:       sc.IA,
+			Gateway:        sc.Gateway,
+			Healthy:        healthy[sc.ID],
+			TrafficMatcher: matcher,
+			PathCount:      sc.PathCount,
+			Prefixes:       sc.Prefixes,
+			CipherSuite:    sc.CipherSuite,
+			Paths:          sessionPaths,
+		})
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		if sessions[i].RemoteIA != sessions[j].RemoteIA {
+			return sessions[i].RemoteIA < sessions[j].RemoteIA
+		}
+		return sessions[i].ID < sessions[j].ID
+	})
+	return sessions
+}
+
 func mustRenderPathInfo(p pathhealth.PathInfo, w io.Writer, indent int) {
 	paths := make([][]string, 0, len(p))
 	for _, path := range p {
@@ -344,6 +403,8 @@ func (e *Engine) initWorkers(ctx context.Context) error {
 			config.PolicyID,
 			config.IA,
 			config.Gateway.Data,
+			config.CipherSuite,
+			config.LoadBalancing,
 		)
 		remoteIA := config.IA
 		pathMonitorRegistration := e.PathMonitor.Register(
@@ -375,12 +436,13 @@ func (e *Engine) initWorkers(ctx context.Context) error {
 		}
 
 		sessionMonitor := &SessionMonitor{
-			ID:        config.ID,
-			RemoteIA:  remoteIA,
-			ProbeAddr: config.Gateway.Probe,
-			Events:    sessionMonitorEvents,
-			Paths:     pathMonitorRegistration,
-			ProbeConn: probeConn,
+			ID:          config.ID,
+			RemoteIA:    remoteIA,
+			ProbeAddr:   config.Gateway.Probe,
+			Events:      sessionMonitorEvents,
+			Paths:       pathMonitorRegistration,
+			ProbeConn:   probeConn,
+			CipherSuite: config.CipherSuite,
 			Metrics: SessionMonitorMetrics{
 				Probes: metrics.CounterWith(
 					e.Metrics.SessionMonitorMetrics.Probes, labels...),
@@ -515,9 +577,11 @@ type PktWriter interface {
 }
 
 // DataplaneSessionFactory is used to construct a data-plane session with a specific ID towards a
-// remote.
+// remote. The frames of the session are protected with the given cipher suite.
 type DataplaneSessionFactory interface {
-	New(sessID uint8, policyID int, remoteIA addr.IA, remoteAddr net.Addr) DataplaneSession
+	New(sessID uint8, policyID int, remoteIA addr.IA, remoteAddr net.Addr,
+		cipherSuite framecrypto.CipherSuite,
+		loadBalancing policies.LoadBalancing) DataplaneSession
 }
 
 // PathMonitor is used to construct registrations for path discovery.
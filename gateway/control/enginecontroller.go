@@ -134,6 +134,17 @@ func (c *EngineController) Status(w io.Writer) {
 	}
 }
 
+// Sessions returns the state of the sessions of the engine currently in use.
+// It returns nil if no engine is in use.
+func (c *EngineController) Sessions() []SessionInfo {
+	c.stateMtx.RLock()
+	defer c.stateMtx.RUnlock()
+	if se, ok := c.engine.(interface{ Sessions() []SessionInfo }); ok {
+		return se.Sessions()
+	}
+	return nil
+}
+
 func (c *EngineController) validate(ctx context.Context) error {
 	if c.ConfigurationUpdates == nil {
 		return serrors.New("configuration update channel must not be nil")
@@ -39,6 +39,10 @@ func (w *GatewayWatcher) RunOnce(ctx context.Context) {
 	w.run(ctx)
 }
 
+func (w *GatewayWatcher) Remotes() []RemoteGatewayInfo {
+	return w.remotes()
+}
+
 func (w *GatewayWatcher) RunAllPrefixWatchersOnceForTest(ctx context.Context) error {
 	var eg errgroup.Group
 	for _, wi := range w.currentWatchers {
@@ -20,6 +20,7 @@ import (
 
 	"google.golang.org/protobuf/proto"
 
+	"github.com/scionproto/scion/gateway/framecrypto"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/common"
 	"github.com/scionproto/scion/pkg/private/serrors"
@@ -29,6 +30,9 @@ import (
 // ProbeDispatcher handles incoming gateway protocol messages.
 // Currently, it only supports probe requests, and immediately replies to them.
 type ProbeDispatcher struct {
+	// CipherSuites are the cipher suites that the local gateway accepts for
+	// protected frames. They are advertised in the probe replies.
+	CipherSuites []framecrypto.CipherSuite
 }
 
 // Listen handles the received control requests.
@@ -68,8 +72,9 @@ func (d *ProbeDispatcher) dispatch(conn net.PacketConn, raw []byte, addr net.Add
 		reply := &gpb.ControlResponse{
 			Response: &gpb.ControlResponse_Probe{
 				Probe: &gpb.ProbeResponse{
-					SessionId: c.Probe.SessionId,
-					Data:      c.Probe.Data,
+					SessionId:    c.Probe.SessionId,
+					Data:         c.Probe.Data,
+					CipherSuites: d.cipherSuites(),
 				},
 			},
 		}
@@ -83,3 +88,14 @@ func (d *ProbeDispatcher) dispatch(conn net.PacketConn, raw []byte, addr net.Add
 		return serrors.New("unexpected control request", "type", common.TypeOf(ctrl.Request))
 	}
 }
+
+func (d *ProbeDispatcher) cipherSuites() []gpb.CipherSuite {
+	if len(d.CipherSuites) == 0 {
+		return nil
+	}
+	suites := make([]gpb.CipherSuite, 0, len(d.CipherSuites))
+	for _, s := range d.CipherSuites {
+		suites = append(suites, gpb.CipherSuite(s))
+	}
+	return suites
+}
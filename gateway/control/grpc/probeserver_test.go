@@ -26,6 +26,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/scionproto/scion/gateway/control/grpc"
+	"github.com/scionproto/scion/gateway/framecrypto"
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/private/mocks/net/mock_net"
 	"github.com/scionproto/scion/pkg/private/serrors"
@@ -94,3 +95,54 @@ func TestControlDispatcher(t *testing.T) {
 	// Make sure that the dispatcher shuts down.
 	<-done
 }
+
+func TestControlDispatcherCipherSuites(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	src := &snet.UDPAddr{IA: addr.MustParseIA("1-ff00:0:110")}
+	request, err := proto.Marshal(&gpb.ControlRequest{
+		Request: &gpb.ControlRequest_Probe{
+			Probe: &gpb.ProbeRequest{SessionId: 1},
+		},
+	})
+	require.NoError(t, err)
+	expected, err := proto.Marshal(&gpb.ControlResponse{
+		Response: &gpb.ControlResponse_Probe{
+			Probe: &gpb.ProbeResponse{
+				SessionId: 1,
+				CipherSuites: []gpb.CipherSuite{
+					gpb.CipherSuite_CIPHER_SUITE_CHACHA20_POLY1305,
+					gpb.CipherSuite_CIPHER_SUITE_AES_128_GCM,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	conn := mock_net.NewMockPacketConn(ctrl)
+	gomock.InOrder(
+		conn.EXPECT().ReadFrom(gomock.Any()).DoAndReturn(
+			func(buf []byte) (int, net.Addr, error) {
+				copy(buf, request)
+				return len(request), src, nil
+			},
+		),
+		conn.EXPECT().WriteTo(expected, src),
+		conn.EXPECT().ReadFrom(gomock.Any()).DoAndReturn(
+			func(buf []byte) (int, net.Addr, error) {
+				cancel()
+				return 0, nil, serrors.New("closed")
+			},
+		),
+	)
+
+	dispatcher := &grpc.ProbeDispatcher{
+		CipherSuites: []framecrypto.CipherSuite{
+			framecrypto.ChaCha20Poly1305,
+			framecrypto.AES128GCM,
+		},
+	}
+	err = dispatcher.Listen(ctx, conn)
+	assert.NoError(t, err)
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/scionproto/scion/gateway/control (interfaces: DataplaneSession,Discoverer,RoutingTable,RoutingTableSwapper,RoutingTableFactory,EngineFactory,PathMonitor,PathMonitorRegistration,PacketConnFactory,PrefixConsumer,PrefixFetcher,PrefixFetcherFactory,DataplaneSessionFactory,PktWriter,Worker,SessionPolicyParser,RoutingPolicyProvider,Runner,GatewayWatcherFactory,Publisher,PublisherFactory,DeviceOpener,DeviceHandle)
+// Source: github.com/scionproto/scion/gateway/control (interfaces: DataplaneSession,Discoverer,RoutingTable,RoutingTableSwapper,RoutingTableFactory,EngineFactory,PathMonitor,PathMonitorRegistration,PacketConnFactory,PrefixConsumer,PrefixFetcher,PrefixFetcherFactory,DataplaneSessionFactory,PktWriter,Worker,SessionPolicyParser,RoutingPolicyProvider,Runner,GatewayWatcherFactory,Publisher,PublisherFactory,DeviceOpener,DeviceHandle,ObservableGateway)
 
 // Package mock_control is a generated GoMock package.
 package mock_control
@@ -14,6 +14,7 @@ import (
 	gopacket "github.com/gopacket/gopacket"
 	layers "github.com/gopacket/gopacket/layers"
 	control "github.com/scionproto/scion/gateway/control"
+	framecrypto "github.com/scionproto/scion/gateway/framecrypto"
 	pathhealth "github.com/scionproto/scion/gateway/pathhealth"
 	policies "github.com/scionproto/scion/gateway/pathhealth/policies"
 	routing "github.com/scionproto/scion/gateway/routing"
@@ -599,17 +600,17 @@ func (m *MockDataplaneSessionFactory) EXPECT() *MockDataplaneSessionFactoryMockR
 }
 
 // New mocks base method.
-func (m *MockDataplaneSessionFactory) New(arg0 byte, arg1 int, arg2 addr.IA, arg3 net.Addr) control.DataplaneSession {
+func (m *MockDataplaneSessionFactory) New(arg0 byte, arg1 int, arg2 addr.IA, arg3 net.Addr, arg4 framecrypto.CipherSuite, arg5 policies.LoadBalancing) control.DataplaneSession {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "New", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "New", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].(control.DataplaneSession)
 	return ret0
 }
 
 // New indicates an expected call of New.
-func (mr *MockDataplaneSessionFactoryMockRecorder) New(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockDataplaneSessionFactoryMockRecorder) New(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "New", reflect.TypeOf((*MockDataplaneSessionFactory)(nil).New), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "New", reflect.TypeOf((*MockDataplaneSessionFactory)(nil).New), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
 // MockPktWriter is a mock of PktWriter interface.
@@ -1075,3 +1076,68 @@ func (mr *MockDeviceHandleMockRecorder) Write(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockDeviceHandle)(nil).Write), arg0)
 }
+
+// MockObservableGateway is a mock of ObservableGateway interface.
+type MockObservableGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockObservableGatewayMockRecorder
+}
+
+// MockObservableGatewayMockRecorder is the mock recorder for MockObservableGateway.
+type MockObservableGatewayMockRecorder struct {
+	mock *MockObservableGateway
+}
+
+// NewMockObservableGateway creates a new mock instance.
+func NewMockObservableGateway(ctrl *gomock.Controller) *MockObservableGateway {
+	mock := &MockObservableGateway{ctrl: ctrl}
+	mock.recorder = &MockObservableGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockObservableGateway) EXPECT() *MockObservableGatewayMockRecorder {
+	return m.recorder
+}
+
+// ListPrefixes mocks base method.
+func (m *MockObservableGateway) ListPrefixes() control.PrefixInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPrefixes")
+	ret0, _ := ret[0].(control.PrefixInfo)
+	return ret0
+}
+
+// ListPrefixes indicates an expected call of ListPrefixes.
+func (mr *MockObservableGatewayMockRecorder) ListPrefixes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPrefixes", reflect.TypeOf((*MockObservableGateway)(nil).ListPrefixes))
+}
+
+// ListRemotes mocks base method.
+func (m *MockObservableGateway) ListRemotes() []control.RemoteInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRemotes")
+	ret0, _ := ret[0].([]control.RemoteInfo)
+	return ret0
+}
+
+// ListRemotes indicates an expected call of ListRemotes.
+func (mr *MockObservableGatewayMockRecorder) ListRemotes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRemotes", reflect.TypeOf((*MockObservableGateway)(nil).ListRemotes))
+}
+
+// ListSessions mocks base method.
+func (m *MockObservableGateway) ListSessions() []control.SessionInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions")
+	ret0, _ := ret[0].([]control.SessionInfo)
+	return ret0
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockObservableGatewayMockRecorder) ListSessions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockObservableGateway)(nil).ListSessions))
+}
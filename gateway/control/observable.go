@@ -0,0 +1,108 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package control
+
+import (
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/gateway/framecrypto"
+	"github.com/scionproto/scion/gateway/pathhealth"
+	"github.com/scionproto/scion/pkg/addr"
+)
+
+// ObservableGateway is the interface through which the management API inspects
+// the state of a running gateway.
+type ObservableGateway interface {
+	// ListRemotes returns the remote gateways discovered in the remote ASes,
+	// sorted by ISD-AS.
+	ListRemotes() []RemoteInfo
+	// ListSessions returns the sessions to the remote gateways, sorted by
+	// remote ISD-AS and session ID.
+	ListSessions() []SessionInfo
+	// ListPrefixes returns the prefixes advertised to and learned from the
+	// remote gateways.
+	ListPrefixes() PrefixInfo
+}
+
+// RemoteInfo describes the remote gateways discovered in a remote AS.
+type RemoteInfo struct {
+	// IA is the ISD-AS of the remote AS.
+	IA addr.IA
+	// Gateways are the discovered remote gateways, sorted by control address.
+	Gateways []RemoteGatewayInfo
+}
+
+// RemoteGatewayInfo describes a discovered remote gateway.
+type RemoteGatewayInfo struct {
+	// Gateway contains the addresses of the remote gateway.
+	Gateway Gateway
+	// Prefixes are the IP prefixes that were last fetched from the remote
+	// gateway.
+	Prefixes []string
+	// LastUpdate is the time at which the prefixes were last fetched. It is
+	// zero if they were never fetched successfully.
+	LastUpdate time.Time
+}
+
+// SessionInfo describes a session to a remote gateway, together with the
+// session policy that led to its creation.
+type SessionInfo struct {
+	// ID is the identifier of the session.
+	ID uint8
+	// PolicyID is the ID of the session policy that led to the creation of
+	// the session.
+	PolicyID int
+	// RemoteIA is the ISD-AS of the remote gateway.
+	RemoteIA addr.IA
+	// Gateway is the remote gateway.
+	Gateway Gateway
+	// Healthy indicates whether the remote gateway answered the probes of the
+	// session recently.
+	Healthy bool
+	// TrafficMatcher is the textual representation of the traffic matcher of
+	// the session policy.
+	TrafficMatcher string
+	// PathCount is the maximum number of paths that the session uses
+	// simultaneously.
+	PathCount int
+	// Prefixes are the network prefixes that are reachable through the
+	// session.
+	Prefixes []*net.IPNet
+	// CipherSuite is the cipher suite that protects the frames of the session.
+	CipherSuite framecrypto.CipherSuite
+	// Paths are the paths considered during the last path selection, in the
+	// order of preference.
+	Paths []SessionPathInfo
+}
+
+// SessionPathInfo describes a path considered by the path selection of a
+// session.
+type SessionPathInfo struct {
+	pathhealth.PathInfoEntry
+	// InUse indicates whether the session currently sends traffic over the
+	// path.
+	InUse bool
+}
+
+// PrefixInfo describes the prefixes exchanged with the remote gateways.
+type PrefixInfo struct {
+	// Advertised are the prefixes that can be advertised to the remote
+	// gateways according to the routing policy, including the prefixes learned
+	// via BGP that are redistributed.
+	Advertised []*net.IPNet
+	// Learned are the routes to prefixes learned from the remote gateways.
+	Learned []Route
+}
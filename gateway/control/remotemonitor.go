@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 	"sync"
 
 	"github.com/scionproto/scion/pkg/addr"
@@ -206,3 +207,26 @@ func (rm *RemoteMonitor) DiagnosticsWrite(w io.Writer) {
 		return
 	}
 }
+
+// Remotes returns the remote gateways discovered in the currently monitored
+// remote ASes, sorted by ISD-AS.
+func (rm *RemoteMonitor) Remotes() []RemoteInfo {
+	rm.stateMtx.RLock()
+	defer rm.stateMtx.RUnlock()
+
+	remotes := make([]RemoteInfo, 0, len(rm.currentWatchers))
+	for ia, watcher := range rm.currentWatchers {
+		gatewayWatcher, ok := watcher.runner.(interface {
+			remotes() []RemoteGatewayInfo
+		})
+		if !ok {
+			continue
+		}
+		remotes = append(remotes, RemoteInfo{
+			IA:       ia,
+			Gateways: gatewayWatcher.remotes(),
+		})
+	}
+	sort.Slice(remotes, func(i, j int) bool { return remotes[i].IA < remotes[j].IA })
+	return remotes
+}
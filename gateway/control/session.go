@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/scionproto/scion/gateway/pathhealth"
+	"github.com/scionproto/scion/gateway/pathhealth/policies"
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/metrics"
@@ -43,6 +44,14 @@ type DataplaneSession interface {
 	Close()
 }
 
+// PathHealthObserver is implemented by data-plane sessions that take the measured
+// health of their paths into account.
+type PathHealthObserver interface {
+	// SetPathHealth sets the statistics of the paths that were considered in
+	// the last path selection.
+	SetPathHealth([]policies.Stats)
+}
+
 // Session represents a point-to-point association with a remote gateway that is subject to
 // a path policy.
 //
@@ -137,6 +146,9 @@ func (s *Session) Run(ctx context.Context) error {
 			if err := s.DataplaneSession.SetPaths(s.pathResult.Paths); err != nil {
 				logger.Error("setting paths", "err", err)
 			}
+			if o, ok := s.DataplaneSession.(PathHealthObserver); ok {
+				o.SetPathHealth(pathStats(s.pathResult.PathInfo))
+			}
 			s.pathResultMtx.Unlock()
 		}
 	}
@@ -193,6 +205,19 @@ func (s *Session) sessionPaths() sessionPaths {
 	}
 }
 
+// pathStats returns the statistics of the paths that were not rejected by the
+// path policy.
+func pathStats(info pathhealth.PathInfo) []policies.Stats {
+	stats := make([]policies.Stats, 0, len(info))
+	for _, entry := range info {
+		if entry.Rejected {
+			continue
+		}
+		stats = append(stats, entry.Stats)
+	}
+	return stats
+}
+
 type pathSelectionDiff struct {
 	old pathhealth.Selection
 	new pathhealth.Selection
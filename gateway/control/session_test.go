@@ -25,6 +25,7 @@ import (
 	"github.com/scionproto/scion/gateway/control"
 	"github.com/scionproto/scion/gateway/control/mock_control"
 	"github.com/scionproto/scion/gateway/pathhealth"
+	"github.com/scionproto/scion/gateway/pathhealth/policies"
 	"github.com/scionproto/scion/pkg/private/xtest"
 	"github.com/scionproto/scion/pkg/snet"
 	"github.com/scionproto/scion/pkg/snet/mock_snet"
@@ -67,4 +68,66 @@ func TestSessionRun(t *testing.T) {
 		close(sessionMonitorEvents)
 		xtest.AssertReadReturnsBefore(t, done, time.Second)
 	})
+	t.Run("forwards path health", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		path := mock_snet.NewMockPath(ctrl)
+		stats := policies.Stats{
+			Fingerprint: "fp",
+			Latency:     10 * time.Millisecond,
+			IsAlive:     true,
+		}
+		pathMonitorRegistration := mock_control.NewMockPathMonitorRegistration(ctrl)
+		pathMonitorRegistration.EXPECT().Get().Return(pathhealth.Selection{
+			Paths: []snet.Path{path},
+			PathInfo: pathhealth.PathInfo{
+				{Path: "fp", Stats: stats},
+				{Path: "rejected", Rejected: true},
+			},
+		}).MinTimes(1)
+
+		dataplaneSession := mock_control.NewMockDataplaneSession(ctrl)
+		dataplaneSession.EXPECT().SetPaths([]snet.Path{path}).MinTimes(1)
+		health := make(chan []policies.Stats, 10)
+
+		sessionMonitorEvents := make(chan control.SessionEvent)
+		session := &control.Session{
+			Events:                  make(chan control.SessionEvent),
+			SessionMonitorEvents:    sessionMonitorEvents,
+			PathMonitorRegistration: pathMonitorRegistration,
+			PathMonitorPollInterval: 10 * time.Millisecond,
+			DataplaneSession: healthObservingSession{
+				MockDataplaneSession: dataplaneSession,
+				health:               health,
+			},
+		}
+
+		done := make(chan struct{})
+		go func() {
+			err := session.Run(context.Background())
+			assert.NoError(t, err)
+			close(done)
+		}()
+
+		select {
+		case got := <-health:
+			assert.Equal(t, []policies.Stats{stats}, got)
+		case <-time.After(time.Second):
+			t.Fatal("path health not set")
+		}
+		close(sessionMonitorEvents)
+		xtest.AssertReadReturnsBefore(t, done, time.Second)
+	})
+}
+
+type healthObservingSession struct {
+	*mock_control.MockDataplaneSession
+	health chan []policies.Stats
+}
+
+func (s healthObservingSession) SetPathHealth(stats []policies.Stats) {
+	select {
+	case s.health <- stats:
+	default:
+	}
 }
@@ -25,6 +25,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/scionproto/scion/gateway/framecrypto"
 	"github.com/scionproto/scion/gateway/pathhealth/policies"
 	"github.com/scionproto/scion/gateway/pktcls"
 	"github.com/scionproto/scion/pkg/addr"
@@ -61,6 +62,12 @@ type SessionConfig struct {
 	// Prefixes contains the network prefixes that are reachable through this
 	// session.
 	Prefixes []*net.IPNet
+	// CipherSuite is the cipher suite that protects the frames of this
+	// session.
+	CipherSuite framecrypto.CipherSuite
+	// LoadBalancing determines how the traffic is spread over the paths of
+	// this session.
+	LoadBalancing policies.LoadBalancing
 }
 
 // SessionConfigurator builds session configurations from the static traffic
@@ -214,6 +221,8 @@ func diffSessionPolicies(a, b SessionPolicies) bool {
 func diffSessionPolicy(a, b SessionPolicy) bool {
 	if a.TrafficMatcher.String() != b.TrafficMatcher.String() ||
 		a.PathCount != b.PathCount ||
+		a.CipherSuite != b.CipherSuite ||
+		a.LoadBalancing != b.LoadBalancing ||
 		// no better way than comparing pointers here:
 		a.PerfPolicy != b.PerfPolicy ||
 		prefixesKey(a.Prefixes) != prefixesKey(b.Prefixes) {
@@ -319,6 +328,8 @@ func buildSessionConfigs(sessionPolicies SessionPolicies,
 				PathCount:      sessionPolicy.PathCount,
 				Gateway:        entry.Gateway,
 				Prefixes:       mergePrefixes(sessionPolicy.Prefixes, entry.Prefixes),
+				CipherSuite:    sessionPolicy.CipherSuite,
+				LoadBalancing:  sessionPolicy.LoadBalancing,
 			})
 			sessID++
 		}
@@ -22,6 +22,7 @@ import (
 
 	"google.golang.org/protobuf/proto"
 
+	"github.com/scionproto/scion/gateway/framecrypto"
 	"github.com/scionproto/scion/gateway/pathhealth"
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
@@ -92,6 +93,11 @@ type SessionMonitor struct {
 	// Metrics are the metrics which are modified during the operation of the
 	// monitor. If empty no metrics are reported.
 	Metrics SessionMonitorMetrics
+	// CipherSuite is the cipher suite that protects the frames of the session.
+	// If it is not framecrypto.None, probe replies only count if the remote
+	// gateway accepts the cipher suite. Thus, the session is rejected, i.e.,
+	// never considered healthy, if the remote gateway does not support it.
+	CipherSuite framecrypto.CipherSuite
 
 	// stateMtx protects the state from concurrent access.
 	stateMtx sync.RWMutex
@@ -308,7 +314,24 @@ func (m *SessionMonitor) handlePkt(raw []byte) error {
 		return serrors.New("unexpected session ID in response",
 			"response_id", probe.Probe.SessionId, "expected_id", m.ID)
 	}
+	if !m.acceptsCipherSuite(probe.Probe.CipherSuites) {
+		return serrors.New("remote gateway does not accept cipher suite",
+			"session_id", m.ID, "cipher_suite", m.CipherSuite,
+			"accepted", probe.Probe.CipherSuites)
+	}
 	metrics.CounterInc(m.Metrics.ProbeReplies)
 	m.receivedProbe <- struct{}{}
 	return nil
 }
+
+func (m *SessionMonitor) acceptsCipherSuite(accepted []gatewaypb.CipherSuite) bool {
+	if m.CipherSuite == framecrypto.None {
+		return true
+	}
+	for _, s := range accepted {
+		if framecrypto.CipherSuite(s) == m.CipherSuite {
+			return true
+		}
+	}
+	return false
+}
@@ -30,6 +30,7 @@ import (
 
 	"github.com/scionproto/scion/gateway/control"
 	"github.com/scionproto/scion/gateway/control/mock_control"
+	"github.com/scionproto/scion/gateway/framecrypto"
 	"github.com/scionproto/scion/gateway/pathhealth"
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/private/mocks/net/mock_net"
@@ -218,3 +219,102 @@ func TestSessionMonitorTestEvents(t *testing.T) {
 	}
 
 }
+
+func TestSessionMonitorCipherSuite(t *testing.T) {
+	testCases := map[string]struct {
+		suite    framecrypto.CipherSuite
+		accepted []gatewaypb.CipherSuite
+		up       bool
+	}{
+		"no protection": {
+			suite: framecrypto.None,
+			up:    true,
+		},
+		"accepted": {
+			suite: framecrypto.ChaCha20Poly1305,
+			accepted: []gatewaypb.CipherSuite{
+				gatewaypb.CipherSuite_CIPHER_SUITE_AES_128_GCM,
+				gatewaypb.CipherSuite_CIPHER_SUITE_CHACHA20_POLY1305,
+			},
+			up: true,
+		},
+		"not accepted": {
+			suite: framecrypto.ChaCha20Poly1305,
+			accepted: []gatewaypb.CipherSuite{
+				gatewaypb.CipherSuite_CIPHER_SUITE_AES_128_GCM,
+			},
+			up: false,
+		},
+		"not supported": {
+			suite: framecrypto.AES128GCM,
+			up:    false,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			conn := mock_net.NewMockPacketConn(ctrl)
+			events := make(chan control.SessionEvent, 50)
+			pathReg := mock_control.NewMockPathMonitorRegistration(ctrl)
+			pathReg.EXPECT().Get().Return(pathhealth.Selection{}).AnyTimes()
+			sessMon := control.SessionMonitor{
+				ID:               25,
+				RemoteIA:         addr.MustParseIA("1-ff00:0:110"),
+				ProbeAddr:        &net.UDPAddr{IP: net.IP{10, 0, 01}, Port: 42},
+				Events:           events,
+				ProbeConn:        conn,
+				HealthExpiration: time.Hour,
+				Paths:            pathReg,
+				ProbeInterval:    time.Hour,
+				CipherSuite:      tc.suite,
+			}
+			rawResponse, err := proto.Marshal(&gatewaypb.ControlResponse{
+				Response: &gatewaypb.ControlResponse_Probe{
+					Probe: &gatewaypb.ProbeResponse{
+						SessionId:    uint32(sessMon.ID),
+						CipherSuites: tc.accepted,
+					},
+				},
+			})
+			require.NoError(t, err)
+
+			readReturn := make(chan struct{}, 10)
+			conn.EXPECT().WriteTo(gomock.Any(), gomock.Any()).AnyTimes()
+			conn.EXPECT().ReadFrom(gomock.Any()).DoAndReturn(
+				func(buf []byte) (int, net.Addr, error) {
+					<-readReturn
+					copy(buf, rawResponse)
+					return len(rawResponse), nil, nil
+				},
+			).AnyTimes()
+
+			errChan := make(chan error)
+			go func() {
+				errChan <- sessMon.Run(context.Background())
+			}()
+			readReturn <- struct{}{}
+			readReturn <- struct{}{}
+			readReturn <- struct{}{}
+			if tc.up {
+				select {
+				case <-time.After(time.Second):
+					t.Fatalf("Test timed out")
+				case event := <-events:
+					assert.Equal(t, control.EventUp, event.Event)
+				}
+			} else {
+				time.Sleep(50 * time.Millisecond)
+				assert.Empty(t, events)
+			}
+
+			err = sessMon.Close(context.Background())
+			assert.NoError(t, err)
+			select {
+			case <-time.After(time.Second):
+				t.Fatalf("Test timed out")
+			case err := <-errChan:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"net"
 	"os"
 
+	"github.com/scionproto/scion/gateway/framecrypto"
 	"github.com/scionproto/scion/gateway/pathhealth/policies"
 	"github.com/scionproto/scion/gateway/pktcls"
 	"github.com/scionproto/scion/pkg/addr"
@@ -46,8 +47,10 @@ type LegacySessionPolicyAdapter struct{}
 func (LegacySessionPolicyAdapter) Parse(ctx context.Context, raw []byte) (SessionPolicies, error) {
 	type JSONFormat struct {
 		ASes map[addr.IA]struct {
-			Nets      []string
-			PathCount int
+			Nets          []string
+			PathCount     int
+			CipherSuite   framecrypto.CipherSuite
+			LoadBalancing policies.LoadBalancing
 		}
 		ConfigVersion uint64
 	}
@@ -73,6 +76,8 @@ func (LegacySessionPolicyAdapter) Parse(ctx context.Context, raw []byte) (Sessio
 			PathPolicy:     DefaultPathPolicy,
 			PathCount:      pathCount,
 			Prefixes:       prefixes,
+			CipherSuite:    asEntry.CipherSuite,
+			LoadBalancing:  asEntry.LoadBalancing,
 		})
 	}
 	return policies, nil
@@ -148,7 +153,8 @@ func (p SessionPolicies) Copy() SessionPolicies {
 // - a performance policy,
 // - a path count,
 // - a remote IA,
-// - a set of prefixes.
+// - a set of prefixes,
+// - a cipher suite.
 type SessionPolicy struct {
 	// IA is the ISD-AS number of the remote AS.
 	IA addr.IA
@@ -170,6 +176,12 @@ type SessionPolicy struct {
 	// Prefixes contains the network prefixes that are reachable through this
 	// session.
 	Prefixes []*net.IPNet
+	// CipherSuite is the cipher suite that protects the frames of this
+	// session. If it is framecrypto.None, the frames are sent in plaintext.
+	CipherSuite framecrypto.CipherSuite
+	// LoadBalancing determines how the traffic is spread over the paths of
+	// this session. By default, the flows are spread evenly.
+	LoadBalancing policies.LoadBalancing
 }
 
 // Copy creates a deep copy.
@@ -179,10 +191,12 @@ func (sp SessionPolicy) Copy() SessionPolicy {
 		IA:             sp.IA,
 		TrafficMatcher: copyTrafficMatcher(sp.TrafficMatcher),
 		// TODO(lukedirtwalker): find a way to properly copy perf policies.
-		PerfPolicy: sp.PerfPolicy,
-		PathPolicy: copyPathPolicy(sp.PathPolicy),
-		PathCount:  sp.PathCount,
-		Prefixes:   copyPrefixes(sp.Prefixes),
+		PerfPolicy:    sp.PerfPolicy,
+		PathPolicy:    copyPathPolicy(sp.PathPolicy),
+		PathCount:     sp.PathCount,
+		Prefixes:      copyPrefixes(sp.Prefixes),
+		CipherSuite:   sp.CipherSuite,
+		LoadBalancing: sp.LoadBalancing,
 	}
 }
 
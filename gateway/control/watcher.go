@@ -251,6 +251,8 @@ func (w *GatewayWatcher) runOnceCheck() error {
 		return ErrAlreadyRunning
 	}
 	w.runMarker = true
+	w.stateMtx.Lock()
+	defer w.stateMtx.Unlock()
 	w.currentWatchers = map[string]watcherItem{}
 	return nil
 }
@@ -284,6 +286,27 @@ func (w *GatewayWatcher) diagnostics() (remoteDiagnostics, error) {
 	return diagnostics, nil
 }
 
+// remotes returns the discovered remote gateways, sorted by control address.
+func (w *GatewayWatcher) remotes() []RemoteGatewayInfo {
+	w.stateMtx.RLock()
+	defer w.stateMtx.RUnlock()
+
+	remotes := make([]RemoteGatewayInfo, 0, len(w.currentWatchers))
+	for _, watcher := range w.currentWatchers {
+		watcher.stateMtx.RLock()
+		remotes = append(remotes, RemoteGatewayInfo{
+			Gateway:    watcher.gateway,
+			Prefixes:   watcher.prefixes,
+			LastUpdate: watcher.timestamp,
+		})
+		watcher.stateMtx.RUnlock()
+	}
+	sort.Slice(remotes, func(i, j int) bool {
+		return remotes[i].Gateway.Control.String() < remotes[j].Gateway.Control.String()
+	})
+	return remotes
+}
+
 func (w *GatewayWatcher) validateParameters() error {
 	if w.Discoverer == nil {
 		return serrors.New("discoverer must not be nil")
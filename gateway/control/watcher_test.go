@@ -29,6 +29,7 @@ import (
 	"github.com/scionproto/scion/gateway/control/mock_control"
 	"github.com/scionproto/scion/pkg/metrics"
 	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/xtest"
 )
 
 func TestGatewayWatcherRun(t *testing.T) {
@@ -118,6 +119,64 @@ func TestGatewayWatcherRun(t *testing.T) {
 	assert.Equal(t, 2, int(metrics.CounterValue(discoveryCounts)))
 }
 
+func TestGatewayWatcherRemotes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	gateway1 := control.Gateway{Control: udp(t, "127.0.0.1:30256"), Interfaces: []uint64{1}}
+	gateway2 := control.Gateway{Control: udp(t, "127.0.0.2:30256")}
+	fetcher := mock_control.NewMockPrefixFetcher(ctrl)
+	fetcherFactory := mock_control.NewMockPrefixFetcherFactory(ctrl)
+	discoverer := mock_control.NewMockDiscoverer(ctrl)
+	consumer := mock_control.NewMockPrefixConsumer(ctrl)
+
+	fetcherFactory.EXPECT().NewPrefixFetcher(gomock.Any(), gomock.Any()).AnyTimes().Return(fetcher)
+	fetcher.EXPECT().Close().AnyTimes().Return(nil)
+	consumer.EXPECT().Prefixes(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	discoverer.EXPECT().Gateways(gomock.Any()).AnyTimes().Return(
+		[]control.Gateway{gateway2, gateway1}, nil,
+	)
+	fetcher.EXPECT().Prefixes(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ any, g *net.UDPAddr) ([]*net.IPNet, error) {
+			if g.String() == gateway1.Control.String() {
+				return xtest.MustParseCIDRs(t, "10.1.0.0/16", "10.2.0.0/16"), nil
+			}
+			return nil, serrors.New("error")
+		},
+	)
+
+	w := control.GatewayWatcher{
+		Discoverer:       discoverer,
+		DiscoverInterval: 10 * time.Hour,
+		Template: control.PrefixWatcherConfig{
+			Consumer:       consumer,
+			FetcherFactory: fetcherFactory,
+			PollInterval:   10 * time.Hour,
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	var bg errgroup.Group
+	bg.Go(func() error {
+		return w.Run(ctx)
+	})
+	t.Cleanup(func() {
+		cancel()
+		assert.NoError(t, bg.Wait())
+	})
+
+	require.Eventually(t, func() bool {
+		remotes := w.Remotes()
+		return len(remotes) == 2 && !remotes[0].LastUpdate.IsZero()
+	}, time.Second, 10*time.Millisecond)
+	remotes := w.Remotes()
+
+	// The remote gateways are sorted by control address.
+	assert.Equal(t, gateway1, remotes[0].Gateway)
+	assert.Equal(t, []string{"10.1.0.0/16", "10.2.0.0/16"}, remotes[0].Prefixes)
+	assert.Equal(t, gateway2, remotes[1].Gateway)
+	assert.Empty(t, remotes[1].Prefixes)
+	assert.True(t, remotes[1].LastUpdate.IsZero())
+}
+
 func TestPrefixWatcherRun(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
@@ -0,0 +1,344 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/gateway/framecrypto"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/drkey"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/util"
+	"github.com/scionproto/scion/private/drkey/drkeyutil"
+)
+
+// Protected frames have version 1. The frame header is followed by a header
+// extension with the following format:
+//
+//  0                   1                   2                   3
+//  0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//  |  Cipher suite |                   Reserved                    |
+//  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//  |                       Key epoch start                         |
+//  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//  |                                                               |
+//  +                           Sender ID                           +
+//  |                                                               |
+//  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//
+// The key epoch start is the start of the DRKey epoch in seconds since the Unix
+// epoch. The sender ID is chosen randomly by every sender. The header
+// extension is followed by the encrypted payload and the authentication tag.
+// The frame header and the header extension are authenticated as associated
+// data. The nonce is the sequence number of the frame, left-padded with zeros.
+
+const (
+	// Frame versions.
+	plainVersion     = 0
+	protectedVersion = 1
+
+	// Length of the header extension of protected frames, in bytes.
+	extLen = 16
+	// Location of individual fields in the header extension.
+	suitePos    = hdrLen
+	keyEpochPos = hdrLen + 4
+	senderIDPos = hdrLen + 8
+	// protectedHdrLen is the length of the headers of protected frames.
+	protectedHdrLen = hdrLen + extLen
+	// protectionOverhead is the number of bytes that protecting a frame adds.
+	protectionOverhead = extLen + framecrypto.TagLen
+
+	// keyEpochTolerance is the tolerance that is applied when checking whether
+	// a key epoch is current. It accounts for clock skew between the gateways
+	// and for frames that are in flight when the key epoch changes.
+	keyEpochTolerance = 30 * time.Second
+	// keyFetchTimeout is the timeout for fetching a key.
+	keyFetchTimeout = 2 * time.Second
+	// defaultKeyEpochDuration is the assumed duration of the key epochs until
+	// the first key of the remote gateway is known.
+	defaultKeyEpochDuration = drkeyutil.DefaultEpochDuration
+)
+
+// frameSealer protects the frames of a single sender.
+type frameSealer struct {
+	suite    framecrypto.CipherSuite
+	keys     framecrypto.KeyProvider
+	remoteIA addr.IA
+	remote   net.IP
+	senderID [framecrypto.SenderIDLen]byte
+
+	// epoch is the key epoch of aead.
+	epoch drkey.Epoch
+	aead  cipher.AEAD
+	// buf is the buffer the protected frames are written to. To avoid
+	// allocations, it is reused for every frame.
+	buf []byte
+}
+
+func newFrameSealer(suite framecrypto.CipherSuite, keys framecrypto.KeyProvider,
+	remoteIA addr.IA, remote net.IP) (*frameSealer, error) {
+
+	if !suite.Supported() {
+		return nil, serrors.New("unsupported cipher suite", "suite", suite)
+	}
+	if keys == nil {
+		return nil, serrors.New("key provider must not be nil")
+	}
+	s := &frameSealer{
+		suite:    suite,
+		keys:     keys,
+		remoteIA: remoteIA,
+		remote:   remote,
+	}
+	if _, err := rand.Read(s.senderID[:]); err != nil {
+		return nil, serrors.Wrap("generating sender ID", err)
+	}
+	return s, nil
+}
+
+// Seal protects the frame. The returned frame is only valid until the next
+// call to Seal.
+func (s *frameSealer) Seal(frame []byte, now time.Time) ([]byte, error) {
+	if s.aead == nil || !s.epoch.Contains(now) {
+		if err := s.rekey(now); err != nil {
+			return nil, err
+		}
+	}
+	if cap(s.buf) < len(frame)+protectionOverhead {
+		s.buf = make([]byte, 0, len(frame)+protectionOverhead)
+	}
+	hdr := s.buf[:protectedHdrLen]
+	copy(hdr, frame[:hdrLen])
+	hdr[versionPos] = protectedVersion
+	hdr[suitePos] = byte(s.suite)
+	hdr[suitePos+1], hdr[suitePos+2], hdr[suitePos+3] = 0, 0, 0
+	binary.BigEndian.PutUint32(hdr[keyEpochPos:], util.TimeToSecs(s.epoch.NotBefore))
+	copy(hdr[senderIDPos:], s.senderID[:])
+
+	var nonce [framecrypto.NonceLen]byte
+	copy(nonce[framecrypto.NonceLen-8:], frame[seqPos:seqPos+8])
+	s.buf = s.aead.Seal(hdr, nonce[:], frame[hdrLen:], hdr)
+	return s.buf, nil
+}
+
+func (s *frameSealer) rekey(now time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), keyFetchTimeout)
+	defer cancel()
+	key, err := s.keys.EgressKey(ctx, s.remoteIA, s.remote, now)
+	if err != nil {
+		return serrors.Wrap("getting egress key", err)
+	}
+	aead, err := framecrypto.NewAEAD(s.suite, key.Key, s.senderID)
+	if err != nil {
+		return err
+	}
+	s.epoch, s.aead = key.Epoch, aead
+	return nil
+}
+
+// errReplayed indicates that a protected frame was replayed.
+var errReplayed = serrors.New("replayed frame")
+
+// frameOpener verifies and decrypts the protected frames received from a
+// single remote gateway. It keeps the replay state of all the senders of the
+// remote gateway. Because the replay state must survive the ingress workers,
+// it is owned by the ingress server and safe for concurrent use.
+type frameOpener struct {
+	suites   []framecrypto.CipherSuite
+	keys     framecrypto.KeyProvider
+	remoteIA addr.IA
+	remote   net.IP
+
+	mtx     sync.Mutex
+	senders map[senderKey]*senderState
+	// latest is the most recent key epoch of the remote gateway. It is used to
+	// determine the current and the previous key epoch.
+	latest drkey.Epoch
+}
+
+// senderKey identifies a sender of the remote gateway during a key epoch.
+type senderKey struct {
+	suite      framecrypto.CipherSuite
+	epochStart uint32
+	senderID   [framecrypto.SenderIDLen]byte
+}
+
+type senderState struct {
+	epoch  drkey.Epoch
+	aead   cipher.AEAD
+	window framecrypto.ReplayWindow
+}
+
+func newFrameOpener(suites []framecrypto.CipherSuite, keys framecrypto.KeyProvider,
+	remoteIA addr.IA, remote net.IP) *frameOpener {
+
+	return &frameOpener{
+		suites:   suites,
+		keys:     keys,
+		remoteIA: remoteIA,
+		remote:   remote,
+		senders:  make(map[senderKey]*senderState),
+	}
+}
+
+// Open verifies and decrypts the protected frame in place. It returns the
+// plaintext frame, i.e., the frame header followed by the decrypted payload.
+func (o *frameOpener) Open(ctx context.Context, frame []byte, now time.Time) ([]byte, error) {
+	if len(frame) < protectedHdrLen+framecrypto.TagLen {
+		return nil, serrors.New("protected frame too short", "length", len(frame))
+	}
+	key := senderKey{
+		suite:      framecrypto.CipherSuite(frame[suitePos]),
+		epochStart: binary.BigEndian.Uint32(frame[keyEpochPos:]),
+	}
+	copy(key.senderID[:], frame[senderIDPos:protectedHdrLen])
+	if !slices.Contains(o.suites, key.suite) {
+		return nil, serrors.New("cipher suite not accepted", "suite", key.suite)
+	}
+	seq := binary.BigEndian.Uint64(frame[seqPos : seqPos+8])
+
+	state, known, err := o.sender(ctx, key, now)
+	if err != nil {
+		return nil, err
+	}
+
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	if !known {
+		// Another frame of the same sender may have been authenticated while
+		// the key was fetched.
+		if existing, ok := o.senders[key]; ok {
+			state, known = existing, true
+		}
+	}
+	if !state.window.Check(seq) {
+		return nil, errReplayed
+	}
+	var nonce [framecrypto.NonceLen]byte
+	copy(nonce[framecrypto.NonceLen-8:], frame[seqPos:seqPos+8])
+	ciphertext := frame[protectedHdrLen:]
+	plaintext, err := state.aead.Open(ciphertext[:0], nonce[:], ciphertext,
+		frame[:protectedHdrLen])
+	if err != nil {
+		return nil, serrors.Wrap("authenticating frame", err)
+	}
+	// The state of new senders is only kept once a frame was authenticated.
+	// Otherwise, forged frames could make the opener keep arbitrary state.
+	if !known {
+		o.senders[key] = state
+		if state.epoch.NotBefore.After(o.latest.NotBefore) {
+			o.latest = state.epoch
+		}
+	}
+	state.window.Update(seq)
+	frame[versionPos] = plainVersion
+	n := copy(frame[hdrLen:], plaintext)
+	return frame[:hdrLen+n], nil
+}
+
+// sender returns the state of the sender. The returned flag indicates whether
+// the sender is already known. The state of unknown senders is not stored. The
+// key of unknown senders is fetched without holding the lock, so that frames
+// of known senders are not blocked by the fetch.
+func (o *frameOpener) sender(ctx context.Context, key senderKey,
+	now time.Time) (*senderState, bool, error) {
+
+	o.mtx.Lock()
+	state, ok := o.senders[key]
+	latest := o.latest
+	o.mtx.Unlock()
+
+	if ok {
+		if now.After(state.epoch.NotAfter.Add(keyEpochTolerance)) {
+			return nil, false, serrors.New("key epoch expired",
+				"not_after", state.epoch.NotAfter)
+		}
+		return state, true, nil
+	}
+	epochStart := util.SecsToTime(key.epochStart)
+	if err := checkKeyEpoch(epochStart, latest, now); err != nil {
+		return nil, false, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, keyFetchTimeout)
+	defer cancel()
+	k, err := o.keys.IngressKey(ctx, o.remoteIA, o.remote, epochStart)
+	if err != nil {
+		return nil, false, serrors.Wrap("getting ingress key", err)
+	}
+	if now.After(k.Epoch.NotAfter.Add(keyEpochTolerance)) {
+		return nil, false, serrors.New("key epoch expired", "not_after", k.Epoch.NotAfter)
+	}
+	aead, err := framecrypto.NewAEAD(key.suite, k.Key, key.senderID)
+	if err != nil {
+		return nil, false, err
+	}
+	return &senderState{epoch: k.Epoch, aead: aead}, false, nil
+}
+
+// checkKeyEpoch checks that the key epoch starting at epochStart is the
+// current or the previous key epoch, before its key is fetched. The epochs are
+// derived from the latest known key epoch of the remote gateway. If no key
+// epoch is known yet, only epochs that are too old or in the future are
+// rejected.
+func checkKeyEpoch(epochStart time.Time, latest drkey.Epoch, now time.Time) error {
+	if epochStart.After(now.Add(keyEpochTolerance)) {
+		return serrors.New("key epoch in the future", "start", epochStart)
+	}
+	duration := latest.NotAfter.Sub(latest.NotBefore)
+	if latest.NotBefore.IsZero() || duration <= 0 {
+		if epochStart.Before(now.Add(-2*defaultKeyEpochDuration - keyEpochTolerance)) {
+			return serrors.New("key epoch too old", "start", epochStart)
+		}
+		return nil
+	}
+	// The key epochs are consecutive and of equal duration. Thus, the epochs
+	// can be numbered relative to the latest known epoch.
+	offset := epochStart.Sub(latest.NotBefore)
+	if offset%duration != 0 {
+		return serrors.New("key epoch not aligned", "start", epochStart,
+			"latest", latest.NotBefore, "duration", duration)
+	}
+	elapsed := now.Sub(latest.NotBefore)
+	current := elapsed / duration
+	if elapsed < 0 && elapsed%duration != 0 {
+		current--
+	}
+	if offset/duration < current-1 {
+		return serrors.New("key epoch too old", "start", epochStart)
+	}
+	return nil
+}
+
+// cleanup removes the state of the senders whose key epoch has expired. It
+// returns the number of remaining senders.
+func (o *frameOpener) cleanup(now time.Time) int {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	for key, state := range o.senders {
+		if now.After(state.epoch.NotAfter.Add(keyEpochTolerance)) {
+			delete(o.senders, key)
+		}
+	}
+	return len(o.senders)
+}
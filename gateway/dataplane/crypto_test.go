@@ -0,0 +1,247 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/gateway/framecrypto"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/drkey"
+)
+
+// staticKeys provides keys with fixed, one hour long epochs.
+type staticKeys struct{}
+
+func (staticKeys) EgressKey(_ context.Context, _ addr.IA, _ net.IP,
+	t time.Time) (framecrypto.Key, error) {
+
+	return staticKeys{}.key(t.Truncate(time.Hour)), nil
+}
+
+func (staticKeys) IngressKey(_ context.Context, _ addr.IA, _ net.IP,
+	epochStart time.Time) (framecrypto.Key, error) {
+
+	return staticKeys{}.key(epochStart), nil
+}
+
+func (staticKeys) key(start time.Time) framecrypto.Key {
+	return framecrypto.Key{
+		Epoch: drkey.Epoch{NotBefore: start, NotAfter: start.Add(time.Hour)},
+		Key:   drkey.Key{byte(start.Unix())},
+	}
+}
+
+// blockingKeys provides the keys of staticKeys. The ingress keys are only
+// returned once release is closed. It counts the fetched ingress keys.
+type blockingKeys struct {
+	staticKeys
+	release chan struct{}
+	fetched atomic.Int32
+}
+
+func (k *blockingKeys) IngressKey(ctx context.Context, remoteIA addr.IA, remote net.IP,
+	epochStart time.Time) (framecrypto.Key, error) {
+
+	k.fetched.Add(1)
+	<-k.release
+	return k.staticKeys.IngressKey(ctx, remoteIA, remote, epochStart)
+}
+
+func testFrame(seq uint64, payload string) []byte {
+	frame := make([]byte, hdrLen, hdrLen+len(payload))
+	frame[sessPos] = 3
+	binary.BigEndian.PutUint32(frame[streamPos:], 7)
+	binary.BigEndian.PutUint64(frame[seqPos:], seq)
+	return append(frame, payload...)
+}
+
+func TestFrameProtection(t *testing.T) {
+	remoteIA := addr.MustParseIA("1-ff00:0:111")
+	remote := net.ParseIP("192.0.2.2")
+	now := time.Now()
+	ctx := context.Background()
+
+	for _, suite := range framecrypto.SupportedCipherSuites() {
+		t.Run(suite.String(), func(t *testing.T) {
+			opener := newFrameOpener([]framecrypto.CipherSuite{suite}, staticKeys{},
+				remoteIA, remote)
+			// Two senders, e.g., on two different paths, use the same
+			// sequence numbers.
+			sealerA, err := newFrameSealer(suite, staticKeys{}, remoteIA, remote)
+			require.NoError(t, err)
+			sealerB, err := newFrameSealer(suite, staticKeys{}, remoteIA, remote)
+			require.NoError(t, err)
+
+			seal := func(s *frameSealer, frame []byte) []byte {
+				sealed, err := s.Seal(frame, now)
+				require.NoError(t, err)
+				assert.Equal(t, len(frame)+protectionOverhead, len(sealed))
+				assert.Equal(t, byte(protectedVersion), sealed[versionPos])
+				// The sealer reuses its buffer.
+				return append([]byte(nil), sealed...)
+			}
+
+			frame := testFrame(1, "payload A")
+			sealed := seal(sealerA, frame)
+			assert.NotContains(t, string(sealed), "payload A")
+			replayed := append([]byte(nil), sealed...)
+			opened, err := opener.Open(ctx, sealed, now)
+			require.NoError(t, err)
+			assert.Equal(t, frame, opened)
+
+			opened, err = opener.Open(ctx, seal(sealerB, testFrame(1, "payload B")), now)
+			require.NoError(t, err)
+			assert.Equal(t, testFrame(1, "payload B"), opened)
+
+			_, err = opener.Open(ctx, replayed, now)
+			assert.ErrorIs(t, err, errReplayed)
+
+			// Tampering with the header or the payload is detected.
+			for _, pos := range []int{sessPos, suitePos + 1, hdrLen + extLen} {
+				tampered := seal(sealerA, testFrame(2, "payload A"))
+				tampered[pos] ^= 1
+				_, err = opener.Open(ctx, tampered, now)
+				assert.Error(t, err)
+			}
+			// Failed frames do not advance the replay window.
+			_, err = opener.Open(ctx, seal(sealerA, testFrame(2, "payload A")), now)
+			assert.NoError(t, err)
+
+			assert.Equal(t, 2, opener.cleanup(now))
+			assert.Equal(t, 0, opener.cleanup(now.Add(3*time.Hour)))
+		})
+	}
+}
+
+func TestFrameOpenerRejects(t *testing.T) {
+	remoteIA := addr.MustParseIA("1-ff00:0:111")
+	remote := net.ParseIP("192.0.2.2")
+	now := time.Now()
+	ctx := context.Background()
+
+	sealer, err := newFrameSealer(framecrypto.ChaCha20Poly1305, staticKeys{}, remoteIA, remote)
+	require.NoError(t, err)
+	opener := newFrameOpener([]framecrypto.CipherSuite{framecrypto.AES128GCM}, staticKeys{},
+		remoteIA, remote)
+
+	sealed, err := sealer.Seal(testFrame(1, "payload"), now)
+	require.NoError(t, err)
+	_, err = opener.Open(ctx, sealed, now)
+	assert.Error(t, err, "cipher suite not accepted")
+
+	_, err = opener.Open(ctx, make([]byte, protectedHdrLen), now)
+	assert.Error(t, err, "too short")
+
+	opener = newFrameOpener([]framecrypto.CipherSuite{framecrypto.ChaCha20Poly1305},
+		staticKeys{}, remoteIA, remote)
+	sealed, err = sealer.Seal(testFrame(1, "payload"), now)
+	require.NoError(t, err)
+	_, err = opener.Open(ctx, sealed, now.Add(2*time.Hour))
+	assert.Error(t, err, "expired key epoch")
+	sealed, err = sealer.Seal(testFrame(2, "payload"), now.Add(2*time.Hour))
+	require.NoError(t, err)
+	_, err = opener.Open(ctx, sealed, now)
+	assert.Error(t, err, "key epoch in the future")
+}
+
+func TestFrameOpenerKeyEpochWindow(t *testing.T) {
+	remoteIA := addr.MustParseIA("1-ff00:0:111")
+	remote := net.ParseIP("192.0.2.2")
+	now := time.Now()
+	ctx := context.Background()
+
+	keys := &blockingKeys{release: make(chan struct{})}
+	close(keys.release)
+	sealer, err := newFrameSealer(framecrypto.AES128GCM, keys, remoteIA, remote)
+	require.NoError(t, err)
+	opener := newFrameOpener([]framecrypto.CipherSuite{framecrypto.AES128GCM}, keys,
+		remoteIA, remote)
+
+	sealed, err := sealer.Seal(testFrame(1, "payload"), now)
+	require.NoError(t, err)
+	_, err = opener.Open(ctx, sealed, now)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), keys.fetched.Load())
+
+	// Frames claiming an epoch other than the current or the previous one are
+	// dropped without fetching a key.
+	for name, start := range map[string]time.Time{
+		"too old":     now.Truncate(time.Hour).Add(-2 * time.Hour),
+		"not aligned": now.Truncate(time.Hour).Add(time.Minute),
+		"future":      now.Truncate(time.Hour).Add(2 * time.Hour),
+	} {
+		frame := append([]byte(nil), sealed...)
+		binary.BigEndian.PutUint32(frame[keyEpochPos:], uint32(start.Unix()))
+		_, err = opener.Open(ctx, frame, now)
+		assert.Error(t, err, name)
+	}
+	assert.Equal(t, int32(1), keys.fetched.Load())
+}
+
+func TestFrameOpenerFetchUnlocked(t *testing.T) {
+	remoteIA := addr.MustParseIA("1-ff00:0:111")
+	remote := net.ParseIP("192.0.2.2")
+	now := time.Now()
+	ctx := context.Background()
+
+	keys := &blockingKeys{release: make(chan struct{})}
+	opener := newFrameOpener([]framecrypto.CipherSuite{framecrypto.AES128GCM}, keys,
+		remoteIA, remote)
+	seal := func(s *frameSealer, seq uint64) []byte {
+		sealed, err := s.Seal(testFrame(seq, "payload"), now)
+		require.NoError(t, err)
+		return append([]byte(nil), sealed...)
+	}
+	known, err := newFrameSealer(framecrypto.AES128GCM, keys, remoteIA, remote)
+	require.NoError(t, err)
+	unknown, err := newFrameSealer(framecrypto.AES128GCM, keys, remoteIA, remote)
+	require.NoError(t, err)
+
+	// Make the first sender known.
+	first := seal(known, 1)
+	done := make(chan error)
+	go func() {
+		_, err := opener.Open(ctx, first, now)
+		done <- err
+	}()
+	require.Eventually(t, func() bool { return keys.fetched.Load() == 1 },
+		time.Second, time.Millisecond)
+	close(keys.release)
+	require.NoError(t, <-done)
+
+	// While the key of a new sender is fetched, the frames of the known sender
+	// are still opened.
+	keys.release = make(chan struct{})
+	second := seal(unknown, 1)
+	go func() {
+		_, err := opener.Open(ctx, second, now)
+		done <- err
+	}()
+	require.Eventually(t, func() bool { return keys.fetched.Load() == 2 },
+		time.Second, time.Millisecond)
+	_, err = opener.Open(ctx, seal(known, 2), now)
+	assert.NoError(t, err)
+	close(keys.release)
+	assert.NoError(t, <-done)
+}
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/scionproto/scion/gateway/control"
+	"github.com/scionproto/scion/gateway/framecrypto"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/metrics"
 	"github.com/scionproto/scion/pkg/private/serrors"
@@ -67,12 +68,28 @@ type IngressServer struct {
 	Conn          ReadConn
 	DeviceManager control.DeviceManager
 	Metrics       IngressMetrics
+	// CipherSuites are the cipher suites that are accepted for protected
+	// frames. If empty, protected frames are discarded.
+	CipherSuites []framecrypto.CipherSuite
+	// Keys provides the keys that protect the frames. It must be set if
+	// CipherSuites is not empty.
+	Keys framecrypto.KeyProvider
+	// RequireEncryption indicates that plaintext frames are discarded.
+	RequireEncryption bool
 
 	workers map[string]*worker
+	// openers holds the frame openers, with the same keys as workers. They
+	// outlive the workers, such that the replay protection does not depend on
+	// the lifetime of a worker.
+	openers map[string]*frameOpener
 }
 
 func (d *IngressServer) Run(ctx context.Context) error {
+	if len(d.CipherSuites) > 0 && d.Keys == nil {
+		return serrors.New("key provider must be set if cipher suites are accepted")
+	}
 	d.workers = make(map[string]*worker)
+	d.openers = make(map[string]*frameOpener)
 	return d.read(ctx)
 }
 
@@ -105,11 +122,11 @@ func (d *IngressServer) read(ctx context.Context) error {
 				frame.Release()
 				continue
 			}
-			if frame.raw[0] != 0 {
+			if reason := d.checkVersion(frame.raw[:read]); reason != "" {
 				metrics.CounterInc(metrics.CounterWith(d.Metrics.FramesDiscarded,
-					"remote_isd_as", v.IA.String(), "reason", "invalid"))
-				logger.Info("IngressServer: Unsupported SIG protocol version",
-					"supported", 0, "actual", frame.raw[0])
+					"remote_isd_as", v.IA.String(), "reason", reason))
+				logger.Info("IngressServer: Discarding frame", "reason", reason,
+					"version", frame.raw[versionPos])
 				frame.Release()
 				continue
 			}
@@ -129,6 +146,26 @@ func (d *IngressServer) read(ctx context.Context) error {
 	}
 }
 
+// checkVersion checks whether frames with the version of the given frame are
+// accepted. It returns the reason for discarding the frame, or the empty string
+// if the frame is accepted.
+func (d *IngressServer) checkVersion(raw []byte) string {
+	switch raw[versionPos] {
+	case plainVersion:
+		if d.RequireEncryption {
+			return "unencrypted"
+		}
+		return ""
+	case protectedVersion:
+		if len(d.CipherSuites) == 0 || len(raw) < protectedHdrLen+framecrypto.TagLen {
+			return "invalid"
+		}
+		return ""
+	default:
+		return "invalid"
+	}
+}
+
 // dispatch dispatches a frame to the corresponding worker, spawning one if none
 // exist yet. Dispatching is done based on source ISD-AS -> source host Addr -> Sess Id.
 func (d *IngressServer) dispatch(ctx context.Context, frame *frameBuf, src *snet.UDPAddr) {
@@ -147,7 +184,7 @@ func (d *IngressServer) dispatch(ctx context.Context, frame *frameBuf, src *snet
 		}
 		// Handle will be cleaned up when worker goroutine finishes.
 
-		worker = newWorker(src, frame.sessId, handle, metrics)
+		worker = newWorker(src, frame.sessId, handle, metrics, d.opener(dispatchStr, src))
 		d.workers[dispatchStr] = worker
 		go func() {
 			defer log.HandlePanic()
@@ -164,6 +201,20 @@ func (d *IngressServer) dispatch(ctx context.Context, frame *frameBuf, src *snet
 	worker.Ring.Write(ringbuf.EntryList{frame}, true)
 }
 
+// opener returns the frame opener for the given key, creating one if none
+// exists yet. It returns nil if protected frames are not accepted.
+func (d *IngressServer) opener(key string, src *snet.UDPAddr) *frameOpener {
+	if len(d.CipherSuites) == 0 {
+		return nil
+	}
+	opener, ok := d.openers[key]
+	if !ok {
+		opener = newFrameOpener(d.CipherSuites, d.Keys, src.IA, src.Host.IP)
+		d.openers[key] = opener
+	}
+	return opener
+}
+
 func createWorkerMetrics(in IngressMetrics, remoteIALabel string) IngressMetrics {
 	labels := []string{"remote_isd_as", remoteIALabel}
 	return IngressMetrics{
@@ -192,6 +243,13 @@ func (d *IngressServer) cleanup() {
 			worker.markedForCleanup = true
 		}
 	}
+	now := time.Now()
+	for key, opener := range d.openers {
+		remaining := opener.cleanup(now)
+		if _, ok := d.workers[key]; !ok && remaining == 0 {
+			delete(d.openers, key)
+		}
+	}
 }
 
 func increaseCounterMetric(m metrics.Counter, amount float64) {
@@ -0,0 +1,170 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"math"
+	"time"
+
+	"github.com/scionproto/scion/gateway/pathhealth/policies"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+const (
+	// minHealthLatency bounds the latency used to weigh the paths by health, so
+	// that a path with a very small measured latency does not attract all flows.
+	minHealthLatency = time.Millisecond
+	// weightHysteresis is the factor by which the weight of a path must change
+	// before the new weight is used. Every weight change moves flows between
+	// paths, so the jitter of the measurements must not be passed through.
+	weightHysteresis = 1.5
+)
+
+// pickSender returns the sender for the flow with the given hash.
+//
+// The senders are chosen with weighted rendezvous hashing: every sender scores
+// the flow based on the flow hash, its own path fingerprint and its weight, and
+// the sender with the highest score wins. The share of flows of a sender is
+// proportional to its weight. Adding or removing a path only moves the flows
+// from or to that path, all other flows stay on their paths.
+func (s *Session) pickSender(flow uint64) *sender {
+	if s.LoadBalancing == policies.LoadBalancingActiveStandby && s.active != nil {
+		return s.active
+	}
+	best, bestScore := s.senders[0], math.Inf(-1)
+	for i, snd := range s.senders {
+		if s.weights[i] <= 0 {
+			continue
+		}
+		// u is uniformly distributed in (0,1). The score -w/ln(u) is the
+		// weighted variant of the rendezvous score.
+		u := (float64(mix64(flow^snd.flowKey)>>11) + 0.5) / (1 << 53)
+		score := -s.weights[i] / math.Log(u)
+		if score > bestScore {
+			best, bestScore = snd, score
+		}
+	}
+	return best
+}
+
+// updateWeights recomputes the weights of the senders for the configured load
+// balancing strategy. It must be called with the mutex held.
+func (s *Session) updateWeights() {
+	weights := make([]float64, len(s.senders))
+	for i, snd := range s.senders {
+		switch s.LoadBalancing {
+		case policies.LoadBalancingBandwidth:
+			weights[i] = pathBandwidth(snd.path)
+		case policies.LoadBalancingHealth:
+			stats, ok := s.health[snd.pathFingerprint]
+			switch {
+			case !ok:
+				weights[i] = math.NaN()
+			case stats.IsAlive:
+				latency := max(stats.Latency, minHealthLatency)
+				weights[i] = (1 - stats.DropRate) / latency.Seconds()
+			}
+		default:
+			weights[i] = 1
+		}
+	}
+	weights = fillWeights(weights)
+
+	// Keep the weight of a path unless it changed significantly. Otherwise,
+	// the flows close to the score boundaries would move between the paths on
+	// every measurement, even if the paths did not change.
+	pathWeights := make(map[snet.PathFingerprint]float64, len(s.senders))
+	for i, snd := range s.senders {
+		if old, ok := s.pathWeights[snd.pathFingerprint]; ok &&
+			!significantChange(old, weights[i]) {
+
+			weights[i] = old
+		}
+		pathWeights[snd.pathFingerprint] = weights[i]
+	}
+	s.weights = weights
+	s.pathWeights = pathWeights
+}
+
+// significantChange returns whether a weight changed by more than the
+// hysteresis factor. Any change from or to zero is significant.
+func significantChange(old, new float64) bool {
+	if old <= 0 || new <= 0 {
+		return old != new
+	}
+	ratio := new / old
+	return ratio > weightHysteresis || ratio < 1/weightHysteresis
+}
+
+// fillWeights replaces the unknown weights, marked as NaN, with the mean of the
+// known weights. If no positive weight remains, all senders are weighted
+// equally, i.e., traffic is never dropped because of the weights.
+func fillWeights(weights []float64) []float64 {
+	var sum float64
+	var known int
+	for _, w := range weights {
+		if !math.IsNaN(w) {
+			sum += w
+			known++
+		}
+	}
+	mean := 1.0
+	if known > 0 && sum > 0 {
+		mean = sum / float64(known)
+	}
+	var positive bool
+	for i, w := range weights {
+		if math.IsNaN(w) {
+			weights[i] = mean
+		}
+		positive = positive || weights[i] > 0
+	}
+	if !positive {
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+	return weights
+}
+
+// pathBandwidth returns the bottleneck bandwidth advertised in the path
+// metadata, or NaN if it is unknown.
+func pathBandwidth(path snet.Path) float64 {
+	meta := path.Metadata()
+	if meta == nil {
+		return math.NaN()
+	}
+	var bw uint64
+	for _, b := range meta.Bandwidth {
+		if b != 0 && (bw == 0 || b < bw) {
+			bw = b
+		}
+	}
+	if bw == 0 {
+		return math.NaN()
+	}
+	return float64(bw)
+}
+
+// mix64 is the finalizer of SplitMix64. It spreads the bits of the combined
+// flow and path hashes.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
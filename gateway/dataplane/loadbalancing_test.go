@@ -0,0 +1,326 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/gateway/control"
+	"github.com/scionproto/scion/gateway/pathhealth/policies"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/segment/iface"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/snet/mock_snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+var _ control.PathHealthObserver = (*Session)(nil)
+
+const testFlows = 10000
+
+func TestLoadBalancingStickiness(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sess := createSession(t, ctrl, make(chan []byte))
+	defer sess.Close()
+
+	paths := []snet.Path{
+		createMockPathWithBandwidth(ctrl, 1, 0),
+		createMockPathWithBandwidth(ctrl, 2, 0),
+		createMockPathWithBandwidth(ctrl, 3, 0),
+		createMockPathWithBandwidth(ctrl, 4, 0),
+	}
+	flows := randomFlows()
+
+	require.NoError(t, sess.SetPaths(paths))
+	before := assignFlows(sess, flows)
+	shares := flowShares(before)
+	for _, path := range paths {
+		assert.InDelta(t, 0.25, shares[path.Metadata().Fingerprint()], 0.03)
+	}
+
+	// Removing a path only moves the flows of that path.
+	removed := paths[1].Metadata().Fingerprint()
+	require.NoError(t, sess.SetPaths([]snet.Path{paths[0], paths[2], paths[3]}))
+	after := assignFlows(sess, flows)
+	for i := range flows {
+		if before[i] != removed {
+			assert.Equal(t, before[i], after[i])
+		}
+		assert.NotEqual(t, removed, after[i])
+	}
+
+	// Adding a path only moves flows to that path.
+	added := createMockPathWithBandwidth(ctrl, 5, 0)
+	require.NoError(t, sess.SetPaths([]snet.Path{paths[0], paths[2], paths[3], added}))
+	again := assignFlows(sess, flows)
+	for i := range flows {
+		if again[i] != added.Metadata().Fingerprint() {
+			assert.Equal(t, after[i], again[i])
+		}
+	}
+}
+
+func TestLoadBalancingBandwidth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sess := createSession(t, ctrl, make(chan []byte))
+	sess.LoadBalancing = policies.LoadBalancingBandwidth
+	defer sess.Close()
+
+	slow := createMockPathWithBandwidth(ctrl, 1, 1000)
+	fast := createMockPathWithBandwidth(ctrl, 2, 3000)
+	unknown := createMockPathWithBandwidth(ctrl, 3, 0)
+	flows := randomFlows()
+
+	require.NoError(t, sess.SetPaths([]snet.Path{slow, fast}))
+	shares := flowShares(assignFlows(sess, flows))
+	assert.InDelta(t, 0.25, shares[slow.Metadata().Fingerprint()], 0.03)
+	assert.InDelta(t, 0.75, shares[fast.Metadata().Fingerprint()], 0.03)
+
+	// Paths without bandwidth information get the mean weight.
+	require.NoError(t, sess.SetPaths([]snet.Path{slow, fast, unknown}))
+	shares = flowShares(assignFlows(sess, flows))
+	assert.InDelta(t, 1.0/6, shares[slow.Metadata().Fingerprint()], 0.03)
+	assert.InDelta(t, 3.0/6, shares[fast.Metadata().Fingerprint()], 0.03)
+	assert.InDelta(t, 2.0/6, shares[unknown.Metadata().Fingerprint()], 0.03)
+}
+
+func TestLoadBalancingHealth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sess := createSession(t, ctrl, make(chan []byte))
+	sess.LoadBalancing = policies.LoadBalancingHealth
+	defer sess.Close()
+
+	a := createMockPathWithBandwidth(ctrl, 1, 0)
+	b := createMockPathWithBandwidth(ctrl, 2, 0)
+	flows := randomFlows()
+
+	// Without measurements, the paths are weighted equally.
+	require.NoError(t, sess.SetPaths([]snet.Path{a, b}))
+	shares := flowShares(assignFlows(sess, flows))
+	assert.InDelta(t, 0.5, shares[a.Metadata().Fingerprint()], 0.03)
+
+	sess.SetPathHealth([]policies.Stats{
+		{Fingerprint: a.Metadata().Fingerprint(), Latency: 10 * time.Millisecond, IsAlive: true},
+		{Fingerprint: b.Metadata().Fingerprint(), Latency: 30 * time.Millisecond, IsAlive: true},
+	})
+	shares = flowShares(assignFlows(sess, flows))
+	assert.InDelta(t, 0.75, shares[a.Metadata().Fingerprint()], 0.03)
+
+	sess.SetPathHealth([]policies.Stats{
+		{Fingerprint: a.Metadata().Fingerprint(), Latency: 10 * time.Millisecond, IsAlive: true,
+			DropRate: 0.5},
+		{Fingerprint: b.Metadata().Fingerprint(), Latency: 10 * time.Millisecond, IsAlive: true},
+	})
+	shares = flowShares(assignFlows(sess, flows))
+	assert.InDelta(t, 1.0/3, shares[a.Metadata().Fingerprint()], 0.03)
+
+	// Dead paths get no flows, unless all paths are dead.
+	sess.SetPathHealth([]policies.Stats{
+		{Fingerprint: a.Metadata().Fingerprint(), Latency: 10 * time.Millisecond, IsAlive: true},
+		{Fingerprint: b.Metadata().Fingerprint()},
+	})
+	shares = flowShares(assignFlows(sess, flows))
+	assert.Equal(t, 1.0, shares[a.Metadata().Fingerprint()])
+	sess.SetPathHealth([]policies.Stats{
+		{Fingerprint: a.Metadata().Fingerprint()},
+		{Fingerprint: b.Metadata().Fingerprint()},
+	})
+	shares = flowShares(assignFlows(sess, flows))
+	assert.InDelta(t, 0.5, shares[a.Metadata().Fingerprint()], 0.03)
+}
+
+func TestLoadBalancingHealthStickiness(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sess := createSession(t, ctrl, make(chan []byte))
+	sess.LoadBalancing = policies.LoadBalancingHealth
+	defer sess.Close()
+
+	paths := []snet.Path{
+		createMockPathWithBandwidth(ctrl, 1, 0),
+		createMockPathWithBandwidth(ctrl, 2, 0),
+		createMockPathWithBandwidth(ctrl, 3, 0),
+	}
+	latencies := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond,
+		30 * time.Millisecond}
+	// The jitter is given per path, so that the ratios of the weights change.
+	health := func(jitter []float64, dropRate float64) []policies.Stats {
+		stats := make([]policies.Stats, 0, len(paths))
+		for i, path := range paths {
+			stats = append(stats, policies.Stats{
+				Fingerprint: path.Metadata().Fingerprint(),
+				Latency:     time.Duration(float64(latencies[i]) * jitter[i]),
+				DropRate:    dropRate,
+				IsAlive:     true,
+			})
+		}
+		return stats
+	}
+	flows := randomFlows()
+
+	require.NoError(t, sess.SetPaths(paths))
+	sess.SetPathHealth(health([]float64{1, 1, 1}, 0))
+	before := assignFlows(sess, flows)
+
+	// The jitter of the measurements does not move any flow, neither do the
+	// polls that set the unchanged paths again.
+	for _, jitter := range [][]float64{
+		{0.8, 1.2, 1},
+		{1.2, 0.8, 1.1},
+		{1, 1.25, 0.85},
+		{0.85, 1, 1.25},
+	} {
+		require.NoError(t, sess.SetPaths(paths))
+		sess.SetPathHealth(health(jitter, 0.05))
+		assert.Equal(t, before, assignFlows(sess, flows), "jitter %v", jitter)
+	}
+
+	// A significant change of a path moves flows.
+	latencies[0] = 100 * time.Millisecond
+	sess.SetPathHealth(health([]float64{1, 1, 1}, 0))
+	assert.NotEqual(t, before, assignFlows(sess, flows))
+}
+
+func TestSignificantChange(t *testing.T) {
+	assert.False(t, significantChange(1, 1))
+	assert.False(t, significantChange(1, 1.4))
+	assert.False(t, significantChange(1, 0.7))
+	assert.True(t, significantChange(1, 1.6))
+	assert.True(t, significantChange(1, 0.6))
+	assert.True(t, significantChange(0, 1))
+	assert.True(t, significantChange(1, 0))
+	assert.False(t, significantChange(0, 0))
+}
+
+func TestLoadBalancingActiveStandby(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sess := createSession(t, ctrl, make(chan []byte))
+	sess.LoadBalancing = policies.LoadBalancingActiveStandby
+	defer sess.Close()
+
+	a := createMockPathWithBandwidth(ctrl, 1, 0)
+	b := createMockPathWithBandwidth(ctrl, 2, 0)
+	c := createMockPathWithBandwidth(ctrl, 3, 0)
+	flows := randomFlows()
+
+	// The most preferred path becomes active.
+	require.NoError(t, sess.SetPaths([]snet.Path{b, a, c}))
+	shares := flowShares(assignFlows(sess, flows))
+	assert.Equal(t, 1.0, shares[b.Metadata().Fingerprint()])
+
+	// The active path is kept while it is in use, even if the preference
+	// changes.
+	require.NoError(t, sess.SetPaths([]snet.Path{a, b}))
+	shares = flowShares(assignFlows(sess, flows))
+	assert.Equal(t, 1.0, shares[b.Metadata().Fingerprint()])
+
+	// The standby path takes over once the active path is gone.
+	require.NoError(t, sess.SetPaths([]snet.Path{c, a}))
+	shares = flowShares(assignFlows(sess, flows))
+	assert.Equal(t, 1.0, shares[c.Metadata().Fingerprint()])
+}
+
+func TestFillWeights(t *testing.T) {
+	nan := math.NaN()
+	testCases := map[string]struct {
+		input    []float64
+		expected []float64
+	}{
+		"known": {
+			input:    []float64{1, 2, 3},
+			expected: []float64{1, 2, 3},
+		},
+		"unknown": {
+			input:    []float64{2, nan, 4},
+			expected: []float64{2, 3, 4},
+		},
+		"all unknown": {
+			input:    []float64{nan, nan},
+			expected: []float64{1, 1},
+		},
+		"zero": {
+			input:    []float64{0, 0, 2},
+			expected: []float64{0, 0, 2},
+		},
+		"all zero": {
+			input:    []float64{0, 0},
+			expected: []float64{1, 1},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, fillWeights(tc.input))
+		})
+	}
+}
+
+func randomFlows() []uint64 {
+	r := rand.New(rand.NewPCG(1, 2))
+	flows := make([]uint64, testFlows)
+	for i := range flows {
+		flows[i] = r.Uint64()
+	}
+	return flows
+}
+
+func assignFlows(sess *Session, flows []uint64) []snet.PathFingerprint {
+	sess.mutex.Lock()
+	defer sess.mutex.Unlock()
+	assigned := make([]snet.PathFingerprint, 0, len(flows))
+	for _, flow := range flows {
+		assigned = append(assigned, sess.pickSender(flow).pathFingerprint)
+	}
+	return assigned
+}
+
+func flowShares(assigned []snet.PathFingerprint) map[snet.PathFingerprint]float64 {
+	counts := make(map[snet.PathFingerprint]int)
+	for _, fp := range assigned {
+		counts[fp]++
+	}
+	shares := make(map[snet.PathFingerprint]float64, len(counts))
+	for fp, n := range counts {
+		shares[fp] = float64(n) / float64(len(assigned))
+	}
+	return shares
+}
+
+// createMockPathWithBandwidth creates a path with a unique fingerprint derived
+// from id and the given advertised bandwidth. Zero means that the bandwidth is
+// unknown.
+func createMockPathWithBandwidth(ctrl *gomock.Controller, id uint64, bw uint64) snet.Path {
+	meta := &snet.PathMetadata{
+		MTU: 1400,
+		Interfaces: []snet.PathInterface{
+			{IA: addr.MustParseIA("1-ff00:0:300"), ID: 1},
+			{IA: addr.MustParseIA("1-ff00:0:301"), ID: iface.ID(id)},
+		},
+	}
+	if bw != 0 {
+		meta.Bandwidth = []uint64{bw, 0}
+	}
+	path := mock_snet.NewMockPath(ctrl)
+	path.EXPECT().Destination().Return(addr.MustParseIA("1-ff00:0:300")).AnyTimes()
+	path.EXPECT().Metadata().Return(meta).AnyTimes()
+	path.EXPECT().Dataplane().Return(snetpath.SCION{Raw: []byte{}}).AnyTimes()
+	path.EXPECT().UnderlayNextHop().Return(nil).AnyTimes()
+	return path
+}
@@ -16,6 +16,7 @@ package dataplane
 
 import (
 	"net"
+	"time"
 
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
@@ -42,11 +43,15 @@ type sender struct {
 	path               snet.Path
 	pathFingerprint    snet.PathFingerprint
 	metrics            SessionMetrics
+	// flowKey is combined with the flow hash to choose the sender of a flow.
+	flowKey uint64
+	// sealer protects the frames. If nil, the frames are sent in plaintext.
+	sealer *frameSealer
 }
 
 func newSender(sessID uint8, conn net.PacketConn, path snet.Path,
 	gatewayAddr net.UDPAddr, pathStatsPublisher PathStatsPublisher,
-	metrics SessionMetrics) (*sender, error) {
+	metrics SessionMetrics, sealer *frameSealer) (*sender, error) {
 
 	// MTU must account for the size of the SCION header.
 	localAddr := conn.LocalAddr().(*snet.UDPAddr)
@@ -57,6 +62,9 @@ func newSender(sessID uint8, conn net.PacketConn, path snet.Path,
 	}
 	pathLen := len(scionPath.Raw)
 	mtu := int(path.Metadata().MTU) - slayers.CmnHdrLen - addrLen - pathLen - udpHdrLen
+	if sealer != nil {
+		mtu -= protectionOverhead
+	}
 	if mtu < minMTU {
 		return nil, serrors.New("insufficient MTU", "mtu", mtu, "minMTU", minMTU)
 	}
@@ -74,6 +82,7 @@ func newSender(sessID uint8, conn net.PacketConn, path snet.Path,
 		path:               path,
 		pathFingerprint:    path.Metadata().Fingerprint(),
 		metrics:            metrics,
+		sealer:             sealer,
 	}
 	go func() {
 		defer log.HandlePanic()
@@ -103,6 +112,14 @@ func (c *sender) run() {
 			// Sender was closed and all the buffered frames were sent.
 			break
 		}
+		if c.sealer != nil {
+			var err error
+			if frame, err = c.sealer.Seal(frame, time.Now()); err != nil {
+				log.Debug("Failed to protect frame", "err", err)
+				increaseCounterMetric(c.metrics.SendExternalErrors, 1)
+				continue
+			}
+		}
 		_, err := c.conn.WriteTo(frame, c.address)
 		if err != nil {
 			increaseCounterMetric(c.metrics.SendExternalErrors, 1)
@@ -26,6 +26,8 @@ import (
 	"github.com/gopacket/gopacket"
 	"github.com/gopacket/gopacket/layers"
 
+	"github.com/scionproto/scion/gateway/framecrypto"
+	"github.com/scionproto/scion/gateway/pathhealth/policies"
 	"github.com/scionproto/scion/pkg/metrics"
 	"github.com/scionproto/scion/pkg/snet"
 )
@@ -57,10 +59,28 @@ type Session struct {
 	DataPlaneConn      net.PacketConn
 	PathStatsPublisher PathStatsPublisher
 	Metrics            SessionMetrics
+	// CipherSuite is the cipher suite that protects the frames. If it is
+	// framecrypto.None, the frames are sent in plaintext.
+	CipherSuite framecrypto.CipherSuite
+	// Keys provides the keys that protect the frames. It must be set if a
+	// cipher suite is configured.
+	Keys framecrypto.KeyProvider
+	// LoadBalancing determines how the flows are spread over the paths.
+	LoadBalancing policies.LoadBalancing
 
 	mutex sync.Mutex
 	// senders is a list of currently used senders.
 	senders []*sender
+	// weights are the load balancing weights of the senders.
+	weights []float64
+	// pathWeights are the weights in use by path fingerprint. They are kept
+	// across updates to only apply significant changes.
+	pathWeights map[snet.PathFingerprint]float64
+	// active is the sender that carries all traffic with the active/standby
+	// strategy.
+	active *sender
+	// health holds the last measured path statistics by path fingerprint.
+	health map[snet.PathFingerprint]policies.Stats
 }
 
 // Close signals that the session should close up its internal Connections. Close returns as
@@ -86,8 +106,7 @@ func (s *Session) Write(packet gopacket.Packet) {
 	}
 	// Choose the path based on the packet's quintuple.
 	hash := crc64.Checksum(extractQuintuple(packet), crcTable)
-	index := hash % uint64(len(s.senders))
-	s.senders[index].Write(packet.Data())
+	s.pickSender(hash).Write(packet.Data())
 }
 
 func (s *Session) String() string {
@@ -129,14 +148,7 @@ func (s *Session) SetPaths(paths []snet.Path) error {
 			continue
 		}
 
-		newSender, err := newSender(
-			s.SessionID,
-			s.DataPlaneConn,
-			path,
-			s.GatewayAddr,
-			s.PathStatsPublisher,
-			s.Metrics,
-		)
+		newSender, err := s.newSender(path)
 		if err != nil {
 			// Collect newly created senders to avoid go routine leak.
 			for _, createdSender := range created {
@@ -163,9 +175,72 @@ func (s *Session) SetPaths(paths []snet.Path) error {
 			string(newSenders[y].pathFingerprint)) == -1
 	})
 	s.senders = newSenders
+	s.updateActive(paths)
+	s.updateWeights()
 	return nil
 }
 
+// SetPathHealth sets the measured statistics of the paths. They are used to
+// weigh the paths with the health based load balancing strategy.
+func (s *Session) SetPathHealth(stats []policies.Stats) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.health = make(map[snet.PathFingerprint]policies.Stats, len(stats))
+	for _, st := range stats {
+		s.health[st.Fingerprint] = st
+	}
+	s.updateWeights()
+}
+
+// updateActive selects the active sender for the active/standby strategy. The
+// active path is kept as long as it is used, even if its sender is replaced,
+// e.g., because the path was refreshed. Otherwise, the sender of the first
+// path, i.e., the most preferred one, becomes active.
+func (s *Session) updateActive(paths []snet.Path) {
+	if s.active != nil {
+		for _, snd := range s.senders {
+			if snd.pathFingerprint == s.active.pathFingerprint {
+				s.active = snd
+				return
+			}
+		}
+	}
+	s.active = nil
+	if len(paths) == 0 {
+		return
+	}
+	s.active, _ = findSenderWithPath(s.senders, paths[0])
+}
+
+// newSender creates a sender for the path. Every sender protects its frames
+// with its own sender ID, so the senders of a session never reuse a nonce.
+func (s *Session) newSender(path snet.Path) (*sender, error) {
+	var sealer *frameSealer
+	if s.CipherSuite != framecrypto.None {
+		var err error
+		sealer, err = newFrameSealer(s.CipherSuite, s.Keys, path.Destination(),
+			s.GatewayAddr.IP)
+		if err != nil {
+			return nil, err
+		}
+	}
+	snd, err := newSender(
+		s.SessionID,
+		s.DataPlaneConn,
+		path,
+		s.GatewayAddr,
+		s.PathStatsPublisher,
+		s.Metrics,
+		sealer,
+	)
+	if err != nil {
+		return nil, err
+	}
+	snd.flowKey = crc64.Checksum([]byte(snd.pathFingerprint), crcTable)
+	return snd, nil
+}
+
 func findSenderWithPath(senders []*sender, path snet.Path) (*sender, bool) {
 	for _, s := range senders {
 		if pathsEqual(path, s.path) {
@@ -18,11 +18,13 @@ package dataplane
 import (
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"time"
 
 	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/metrics"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/snet"
 	"github.com/scionproto/scion/private/ringbuf"
@@ -50,10 +52,13 @@ type worker struct {
 	rlists           map[int]*reassemblyList
 	markedForCleanup bool
 	tunIO            io.WriteCloser
+	// opener verifies and decrypts protected frames. If nil, protected frames
+	// are discarded.
+	opener *frameOpener
 }
 
 func newWorker(remote *snet.UDPAddr, sessID uint8,
-	tunIO io.WriteCloser, metrics IngressMetrics) *worker {
+	tunIO io.WriteCloser, metrics IngressMetrics, opener *frameOpener) *worker {
 
 	worker := &worker{
 		Remote:  remote,
@@ -62,6 +67,7 @@ func newWorker(remote *snet.UDPAddr, sessID uint8,
 		rlists:  make(map[int]*reassemblyList),
 		tunIO:   tunIO,
 		Metrics: metrics,
+		opener:  opener,
 	}
 
 	return worker
@@ -101,6 +107,10 @@ func (w *worker) Run(ctx context.Context) {
 // packets to the wire and then adding the frame to the corresponding reassembly
 // list if needed.
 func (w *worker) processFrame(ctx context.Context, frame *frameBuf) {
+	if frame.raw[versionPos] == protectedVersion && !w.openFrame(ctx, frame) {
+		frame.Release()
+		return
+	}
 	index := int(binary.BigEndian.Uint16(frame.raw[2:4]))
 	epoch := int(binary.BigEndian.Uint32(frame.raw[4:8]) & 0xfffff)
 	seqNr := binary.BigEndian.Uint64(frame.raw[8:16])
@@ -118,6 +128,27 @@ func (w *worker) processFrame(ctx context.Context, frame *frameBuf) {
 	rlist.Insert(ctx, frame)
 }
 
+// openFrame verifies and decrypts a protected frame in place. It returns false
+// if the frame must be discarded.
+func (w *worker) openFrame(ctx context.Context, frame *frameBuf) bool {
+	if w.opener == nil {
+		metrics.CounterInc(metrics.CounterWith(w.Metrics.FramesDiscarded, "reason", "invalid"))
+		return false
+	}
+	raw, err := w.opener.Open(ctx, frame.raw[:frame.frameLen], time.Now())
+	if err != nil {
+		reason := "unauthenticated"
+		if errors.Is(err, errReplayed) {
+			reason = "replayed"
+		}
+		metrics.CounterInc(metrics.CounterWith(w.Metrics.FramesDiscarded, "reason", reason))
+		log.FromCtx(ctx).Debug("Discarding protected frame", "err", err)
+		return false
+	}
+	frame.frameLen = len(raw)
+	return true
+}
+
 func (w *worker) getRlist(epoch int) *reassemblyList {
 	rlist, ok := w.rlists[epoch]
 	if !ok {
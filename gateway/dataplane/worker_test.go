@@ -74,7 +74,7 @@ func TestParsing(t *testing.T) {
 		},
 	}
 	mt := &MockTun{}
-	w := newWorker(addr, 1, mt, IngressMetrics{})
+	w := newWorker(addr, 1, mt, IngressMetrics{}, nil)
 
 	// Single frame with a single IPv4 packet inside.
 	SendFrame(t, w, []byte{
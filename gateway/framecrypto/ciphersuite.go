@@ -0,0 +1,150 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package framecrypto implements the cryptographic protection of the frames
+// that are exchanged between gateways.
+//
+// Frames are protected with an AEAD. The key of the AEAD is derived from a
+// DRKey host-host key between the sending and the receiving gateway, and a
+// random sender ID that is chosen by every sender individually. This ensures
+// that the nonces, which are built from the frame sequence numbers, are never
+// reused, even if multiple senders (e.g., one per path) use the same DRKey.
+// Rekeying follows the DRKey epochs.
+package framecrypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/sha256"
+	"slices"
+
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/scionproto/scion/pkg/drkey"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+const (
+	// SenderIDLen is the length of the sender ID in bytes.
+	SenderIDLen = 8
+	// NonceLen is the length of the AEAD nonce in bytes. It is the same for
+	// all supported cipher suites.
+	NonceLen = 12
+	// TagLen is the length of the authentication tag in bytes. It is the same
+	// for all supported cipher suites.
+	TagLen = 16
+)
+
+// CipherSuite identifies the AEAD construction that protects the frames. The
+// values are used on the wire, both in the frame header and in the probe
+// responses of the gateway control protocol.
+type CipherSuite uint8
+
+// The supported cipher suites.
+const (
+	// None indicates that frames are sent in plaintext.
+	None CipherSuite = iota
+	// AES128GCM protects frames with AES-128 in Galois/Counter Mode.
+	AES128GCM
+	// ChaCha20Poly1305 protects frames with ChaCha20-Poly1305.
+	ChaCha20Poly1305
+)
+
+var cipherSuiteNames = map[CipherSuite]string{
+	None:             "none",
+	AES128GCM:        "aes-128-gcm",
+	ChaCha20Poly1305: "chacha20-poly1305",
+}
+
+// SupportedCipherSuites returns all cipher suites that protect frames, i.e.,
+// all cipher suites except None.
+func SupportedCipherSuites() []CipherSuite {
+	return []CipherSuite{AES128GCM, ChaCha20Poly1305}
+}
+
+// ParseCipherSuite parses the name of a cipher suite.
+func ParseCipherSuite(name string) (CipherSuite, error) {
+	for s, n := range cipherSuiteNames {
+		if n == name {
+			return s, nil
+		}
+	}
+	return None, serrors.New("unknown cipher suite", "name", name)
+}
+
+func (s CipherSuite) String() string {
+	if name, ok := cipherSuiteNames[s]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s CipherSuite) MarshalText() ([]byte, error) {
+	if _, ok := cipherSuiteNames[s]; !ok {
+		return nil, serrors.New("unknown cipher suite", "value", uint8(s))
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *CipherSuite) UnmarshalText(text []byte) error {
+	parsed, err := ParseCipherSuite(string(text))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// Supported indicates whether the cipher suite protects frames and is
+// implemented by this package.
+func (s CipherSuite) Supported() bool {
+	return slices.Contains(SupportedCipherSuites(), s)
+}
+
+func (s CipherSuite) keyLen() int {
+	switch s {
+	case AES128GCM:
+		return 16
+	case ChaCha20Poly1305:
+		return chacha20poly1305.KeySize
+	default:
+		return 0
+	}
+}
+
+// NewAEAD creates the AEAD that protects the frames of a single sender. The
+// AEAD key is derived from the DRKey and the sender ID with HKDF-SHA256.
+func NewAEAD(suite CipherSuite, key drkey.Key, senderID [SenderIDLen]byte) (cipher.AEAD, error) {
+	if !suite.Supported() {
+		return nil, serrors.New("unsupported cipher suite", "suite", suite)
+	}
+	derived, err := hkdf.Key(sha256.New, key[:], senderID[:],
+		"scion gateway frames "+suite.String(), suite.keyLen())
+	if err != nil {
+		return nil, serrors.Wrap("deriving key", err)
+	}
+	switch suite {
+	case AES128GCM:
+		block, err := aes.NewCipher(derived)
+		if err != nil {
+			return nil, err
+		}
+		return cipher.NewGCM(block)
+	default:
+		return chacha20poly1305.New(derived)
+	}
+}
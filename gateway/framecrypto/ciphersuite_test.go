@@ -0,0 +1,78 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package framecrypto_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/gateway/framecrypto"
+	"github.com/scionproto/scion/pkg/drkey"
+)
+
+func TestCipherSuiteText(t *testing.T) {
+	for _, suite := range []framecrypto.CipherSuite{
+		framecrypto.None,
+		framecrypto.AES128GCM,
+		framecrypto.ChaCha20Poly1305,
+	} {
+		t.Run(suite.String(), func(t *testing.T) {
+			text, err := suite.MarshalText()
+			require.NoError(t, err)
+			var parsed framecrypto.CipherSuite
+			require.NoError(t, parsed.UnmarshalText(text))
+			assert.Equal(t, suite, parsed)
+		})
+	}
+	_, err := framecrypto.ParseCipherSuite("aes-256-gcm")
+	assert.Error(t, err)
+	_, err = framecrypto.CipherSuite(42).MarshalText()
+	assert.Error(t, err)
+}
+
+func TestNewAEAD(t *testing.T) {
+	key := drkey.Key{1, 2, 3}
+	senderA := [framecrypto.SenderIDLen]byte{1}
+	senderB := [framecrypto.SenderIDLen]byte{2}
+	nonce := make([]byte, framecrypto.NonceLen)
+	plaintext := []byte("some frame payload")
+	aad := []byte("header")
+
+	for _, suite := range framecrypto.SupportedCipherSuites() {
+		t.Run(suite.String(), func(t *testing.T) {
+			sealer, err := framecrypto.NewAEAD(suite, key, senderA)
+			require.NoError(t, err)
+			opener, err := framecrypto.NewAEAD(suite, key, senderA)
+			require.NoError(t, err)
+			assert.Equal(t, framecrypto.NonceLen, sealer.NonceSize())
+			assert.Equal(t, framecrypto.TagLen, sealer.Overhead())
+
+			ciphertext := sealer.Seal(nil, nonce, plaintext, aad)
+			opened, err := opener.Open(nil, nonce, ciphertext, aad)
+			require.NoError(t, err)
+			assert.Equal(t, plaintext, opened)
+
+			// Different sender IDs result in different keys.
+			other, err := framecrypto.NewAEAD(suite, key, senderB)
+			require.NoError(t, err)
+			_, err = other.Open(nil, nonce, ciphertext, aad)
+			assert.Error(t, err)
+		})
+	}
+	_, err := framecrypto.NewAEAD(framecrypto.None, key, senderA)
+	assert.Error(t, err)
+}
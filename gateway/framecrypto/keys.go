@@ -0,0 +1,287 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package framecrypto
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/drkey"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+const (
+	// DRKeyProtocol is the DRKey protocol identifier that is used to derive the
+	// keys that protect the frames. It is not one of the predefined protocols,
+	// i.e., the keys are derived with the generic derivation.
+	DRKeyProtocol drkey.Protocol = 0x5347
+
+	// defaultPrefetchLead is how long before the end of a key epoch the key of
+	// the next epoch is fetched.
+	defaultPrefetchLead = 5 * time.Minute
+	// defaultFailureBackoff is how long a failure to fetch a key is cached
+	// before the key is fetched again.
+	defaultFailureBackoff = 5 * time.Second
+	// prefetchTimeout is the timeout for prefetching a key.
+	prefetchTimeout = 10 * time.Second
+	// keyRetention is how long a key is kept after its epoch ended.
+	keyRetention = time.Minute
+)
+
+// Key is the key that protects the frames exchanged between two gateways in a
+// given direction during a key epoch.
+type Key struct {
+	Epoch drkey.Epoch
+	Key   drkey.Key
+}
+
+// KeyProvider provides the keys that protect the frames exchanged with remote
+// gateways. Implementations are expected to cache the keys; the methods are
+// called for every key epoch of every sender and receiver.
+type KeyProvider interface {
+	// EgressKey returns the key that protects the frames that are sent to the
+	// remote gateway at the given time.
+	EgressKey(ctx context.Context, remoteIA addr.IA, remote net.IP, t time.Time) (Key, error)
+	// IngressKey returns the key that protects the frames that are received
+	// from the remote gateway and that belong to the key epoch starting at the
+	// given time.
+	IngressKey(ctx context.Context, remoteIA addr.IA, remote net.IP,
+		epochStart time.Time) (Key, error)
+}
+
+// DRKeyFetcher fetches DRKey host-host keys. It is implemented by the daemon
+// connector.
+type DRKeyFetcher interface {
+	DRKeyGetHostHostKey(ctx context.Context, meta drkey.HostHostMeta) (drkey.HostHostKey, error)
+}
+
+// DRKeyProvider provides the keys from DRKey host-host keys that are fetched
+// from the daemon. The frames from gateway A to gateway B are protected with
+// the host-host key with A as the source and B as the destination.
+type DRKeyProvider struct {
+	// Fetcher is used to fetch the DRKey host-host keys.
+	Fetcher DRKeyFetcher
+	// LocalIA is the ISD-AS of the local gateway.
+	LocalIA addr.IA
+	// LocalIP is the IP address that the local gateway uses to send and
+	// receive frames.
+	LocalIP net.IP
+	// PrefetchLead is how long before the end of a key epoch the key of the
+	// next epoch is fetched. If zero, a default value is used.
+	PrefetchLead time.Duration
+	// FailureBackoff is how long a failure to fetch a key is remembered before
+	// the key is fetched again. If zero, a default value is used.
+	FailureBackoff time.Duration
+
+	mtx sync.Mutex
+	// keys holds the cached keys per key direction.
+	keys map[keyDirection][]Key
+	// failures holds the time of the last failed fetch per key direction and
+	// requested time.
+	failures map[keyRequest]time.Time
+	// prefetching contains the key directions for which a prefetch is ongoing.
+	prefetching map[keyDirection]struct{}
+}
+
+// keyDirection identifies the source and destination of a host-host key.
+type keyDirection struct {
+	srcIA, dstIA     addr.IA
+	srcHost, dstHost string
+}
+
+// keyRequest identifies a key request for caching failures. For ingress keys,
+// epochStart is the start of the requested key epoch in seconds; for egress
+// keys, it is zero.
+type keyRequest struct {
+	dir        keyDirection
+	epochStart int64
+}
+
+// EgressKey returns the key that protects the frames that are sent to the
+// remote gateway at the given time.
+func (p *DRKeyProvider) EgressKey(ctx context.Context, remoteIA addr.IA, remote net.IP,
+	t time.Time) (Key, error) {
+
+	dir := keyDirection{
+		srcIA:   p.LocalIA,
+		dstIA:   remoteIA,
+		srcHost: p.LocalIP.String(),
+		dstHost: remote.String(),
+	}
+	req := keyRequest{dir: dir}
+	return p.key(ctx, req, t, func(k Key) bool { return k.Epoch.Contains(t) })
+}
+
+// IngressKey returns the key that protects the frames that are received from
+// the remote gateway and that belong to the key epoch starting at the given
+// time.
+func (p *DRKeyProvider) IngressKey(ctx context.Context, remoteIA addr.IA, remote net.IP,
+	epochStart time.Time) (Key, error) {
+
+	dir := keyDirection{
+		srcIA:   remoteIA,
+		dstIA:   p.LocalIA,
+		srcHost: remote.String(),
+		dstHost: p.LocalIP.String(),
+	}
+	req := keyRequest{dir: dir, epochStart: epochStart.Unix()}
+	return p.key(ctx, req, epochStart, func(k Key) bool {
+		return k.Epoch.NotBefore.Equal(epochStart)
+	})
+}
+
+// key returns the cached key that matches the request, or fetches it. Failed
+// fetches and fetched keys that do not match the request are remembered for
+// the failure backoff, so that frames referring to unavailable keys do not
+// cause a fetch each.
+func (p *DRKeyProvider) key(ctx context.Context, req keyRequest, t time.Time,
+	match func(Key) bool) (Key, error) {
+
+	dir := req.dir
+	p.mtx.Lock()
+	p.initLocked()
+	for _, k := range p.keys[dir] {
+		if match(k) {
+			p.maybePrefetchLocked(ctx, dir, k)
+			p.mtx.Unlock()
+			return k, nil
+		}
+	}
+	if failed, ok := p.failures[req]; ok && time.Since(failed) < p.failureBackoff() {
+		p.mtx.Unlock()
+		return Key{}, serrors.New("fetching key failed recently", "at", failed)
+	}
+	p.mtx.Unlock()
+
+	key, err := p.fetch(ctx, dir, t)
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if err != nil {
+		p.expireFailuresLocked()
+		p.failures[req] = time.Now()
+		return Key{}, err
+	}
+	// The key is valid even if it does not match the request, e.g., because
+	// the requested epoch does not exist. Thus, it is cached in any case.
+	p.storeLocked(dir, key)
+	if !match(key) {
+		p.failures[req] = time.Now()
+		return Key{}, serrors.New("fetched key does not match request",
+			"time", t, "not_before", key.Epoch.NotBefore, "not_after", key.Epoch.NotAfter)
+	}
+	delete(p.failures, req)
+	return key, nil
+}
+
+func (p *DRKeyProvider) fetch(ctx context.Context, dir keyDirection, t time.Time) (Key, error) {
+	hostKey, err := p.Fetcher.DRKeyGetHostHostKey(ctx, drkey.HostHostMeta{
+		ProtoId:  DRKeyProtocol,
+		Validity: t,
+		SrcIA:    dir.srcIA,
+		DstIA:    dir.dstIA,
+		SrcHost:  dir.srcHost,
+		DstHost:  dir.dstHost,
+	})
+	if err != nil {
+		return Key{}, serrors.Wrap("fetching DRKey host-host key", err,
+			"src_isd_as", dir.srcIA, "src_host", dir.srcHost,
+			"dst_isd_as", dir.dstIA, "dst_host", dir.dstHost)
+	}
+	return Key{Epoch: hostKey.Epoch, Key: hostKey.Key}, nil
+}
+
+// maybePrefetchLocked fetches the key of the epoch following the epoch of the
+// given key in the background, if the given key is about to expire.
+func (p *DRKeyProvider) maybePrefetchLocked(ctx context.Context, dir keyDirection, k Key) {
+	next := k.Epoch.NotAfter.Add(time.Second)
+	if time.Until(k.Epoch.NotAfter) > p.prefetchLead() {
+		return
+	}
+	for _, other := range p.keys[dir] {
+		if other.Epoch.Contains(next) {
+			return
+		}
+	}
+	if _, ok := p.prefetching[dir]; ok {
+		return
+	}
+	p.prefetching[dir] = struct{}{}
+	logger := log.FromCtx(ctx)
+	go func() {
+		defer log.HandlePanic()
+		ctx, cancel := context.WithTimeout(context.Background(), prefetchTimeout)
+		defer cancel()
+		key, err := p.fetch(ctx, dir, next)
+
+		p.mtx.Lock()
+		defer p.mtx.Unlock()
+		delete(p.prefetching, dir)
+		if err != nil {
+			logger.Info("Failed to prefetch gateway frame key", "err", err)
+			return
+		}
+		p.storeLocked(dir, key)
+	}()
+}
+
+// storeLocked adds the key to the cache and removes keys whose epoch has ended
+// more than keyRetention ago.
+func (p *DRKeyProvider) storeLocked(dir keyDirection, key Key) {
+	now := time.Now()
+	keys := p.keys[dir][:0]
+	for _, k := range p.keys[dir] {
+		if k.Epoch == key.Epoch || now.Sub(k.Epoch.NotAfter) > keyRetention {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	p.keys[dir] = append(keys, key)
+	p.expireFailuresLocked()
+}
+
+func (p *DRKeyProvider) expireFailuresLocked() {
+	for req, failed := range p.failures {
+		if time.Since(failed) >= p.failureBackoff() {
+			delete(p.failures, req)
+		}
+	}
+}
+
+func (p *DRKeyProvider) initLocked() {
+	if p.keys == nil {
+		p.keys = make(map[keyDirection][]Key)
+		p.failures = make(map[keyRequest]time.Time)
+		p.prefetching = make(map[keyDirection]struct{})
+	}
+}
+
+func (p *DRKeyProvider) prefetchLead() time.Duration {
+	if p.PrefetchLead == 0 {
+		return defaultPrefetchLead
+	}
+	return p.PrefetchLead
+}
+
+func (p *DRKeyProvider) failureBackoff() time.Duration {
+	if p.FailureBackoff == 0 {
+		return defaultFailureBackoff
+	}
+	return p.FailureBackoff
+}
@@ -0,0 +1,171 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package framecrypto_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/gateway/framecrypto"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/daemon/mock_daemon"
+	"github.com/scionproto/scion/pkg/drkey"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+var (
+	localIA  = addr.MustParseIA("1-ff00:0:110")
+	remoteIA = addr.MustParseIA("1-ff00:0:111")
+	localIP  = net.ParseIP("192.0.2.1")
+	remoteIP = net.ParseIP("192.0.2.2")
+)
+
+func hostHostKey(meta drkey.HostHostMeta, epoch drkey.Epoch) drkey.HostHostKey {
+	return drkey.HostHostKey{
+		ProtoId: meta.ProtoId,
+		Epoch:   epoch,
+		SrcIA:   meta.SrcIA,
+		DstIA:   meta.DstIA,
+		SrcHost: meta.SrcHost,
+		DstHost: meta.DstHost,
+		Key:     drkey.Key{byte(epoch.NotBefore.Unix())},
+	}
+}
+
+func TestDRKeyProviderEgressKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	now := time.Now().Truncate(time.Second)
+	epoch := drkey.Epoch{NotBefore: now.Add(-time.Hour), NotAfter: now.Add(time.Hour)}
+
+	fetcher := mock_daemon.NewMockConnector(ctrl)
+	fetcher.EXPECT().DRKeyGetHostHostKey(gomock.Any(), drkey.HostHostMeta{
+		ProtoId:  framecrypto.DRKeyProtocol,
+		Validity: now,
+		SrcIA:    localIA,
+		DstIA:    remoteIA,
+		SrcHost:  localIP.String(),
+		DstHost:  remoteIP.String(),
+	}).DoAndReturn(
+		func(_ context.Context, meta drkey.HostHostMeta) (drkey.HostHostKey, error) {
+			return hostHostKey(meta, epoch), nil
+		},
+	)
+	p := &framecrypto.DRKeyProvider{Fetcher: fetcher, LocalIA: localIA, LocalIP: localIP}
+
+	key, err := p.EgressKey(context.Background(), remoteIA, remoteIP, now)
+	require.NoError(t, err)
+	assert.Equal(t, epoch, key.Epoch)
+
+	// The key is cached.
+	cached, err := p.EgressKey(context.Background(), remoteIA, remoteIP, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, key, cached)
+}
+
+func TestDRKeyProviderIngressKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	now := time.Now().Truncate(time.Second)
+	epoch := drkey.Epoch{NotBefore: now.Add(-time.Hour), NotAfter: now.Add(time.Hour)}
+
+	fetcher := mock_daemon.NewMockConnector(ctrl)
+	fetcher.EXPECT().DRKeyGetHostHostKey(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, meta drkey.HostHostMeta) (drkey.HostHostKey, error) {
+			assert.Equal(t, remoteIA, meta.SrcIA)
+			assert.Equal(t, localIA, meta.DstIA)
+			assert.Equal(t, remoteIP.String(), meta.SrcHost)
+			assert.Equal(t, localIP.String(), meta.DstHost)
+			return hostHostKey(meta, epoch), nil
+		},
+	).Times(2)
+	p := &framecrypto.DRKeyProvider{Fetcher: fetcher, LocalIA: localIA, LocalIP: localIP}
+
+	key, err := p.IngressKey(context.Background(), remoteIA, remoteIP, epoch.NotBefore)
+	require.NoError(t, err)
+	assert.Equal(t, epoch, key.Epoch)
+
+	// The returned key must belong to the requested epoch.
+	_, err = p.IngressKey(context.Background(), remoteIA, remoteIP, now)
+	assert.Error(t, err)
+	// The mismatch is cached, the key is not fetched again.
+	_, err = p.IngressKey(context.Background(), remoteIA, remoteIP, now)
+	assert.Error(t, err)
+}
+
+func TestDRKeyProviderFailureBackoff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	now := time.Now()
+
+	fetcher := mock_daemon.NewMockConnector(ctrl)
+	fetcher.EXPECT().DRKeyGetHostHostKey(gomock.Any(), gomock.Any()).Return(
+		drkey.HostHostKey{}, serrors.New("internal error"),
+	)
+	p := &framecrypto.DRKeyProvider{
+		Fetcher:        fetcher,
+		LocalIA:        localIA,
+		LocalIP:        localIP,
+		FailureBackoff: time.Hour,
+	}
+
+	_, err := p.EgressKey(context.Background(), remoteIA, remoteIP, now)
+	assert.Error(t, err)
+	// The failure is cached, the key is not fetched again.
+	_, err = p.EgressKey(context.Background(), remoteIA, remoteIP, now)
+	assert.Error(t, err)
+}
+
+func TestDRKeyProviderPrefetch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	now := time.Now().Truncate(time.Second)
+	current := drkey.Epoch{NotBefore: now.Add(-time.Hour), NotAfter: now.Add(time.Minute)}
+	next := drkey.Epoch{NotBefore: current.NotAfter, NotAfter: current.NotAfter.Add(time.Hour)}
+
+	fetched := make(chan struct{})
+	fetcher := mock_daemon.NewMockConnector(ctrl)
+	fetcher.EXPECT().DRKeyGetHostHostKey(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, meta drkey.HostHostMeta) (drkey.HostHostKey, error) {
+			return hostHostKey(meta, current), nil
+		},
+	)
+	fetcher.EXPECT().DRKeyGetHostHostKey(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, meta drkey.HostHostMeta) (drkey.HostHostKey, error) {
+			defer close(fetched)
+			assert.True(t, next.Contains(meta.Validity))
+			return hostHostKey(meta, next), nil
+		},
+	)
+	p := &framecrypto.DRKeyProvider{Fetcher: fetcher, LocalIA: localIA, LocalIP: localIP}
+
+	_, err := p.EgressKey(context.Background(), remoteIA, remoteIP, now)
+	require.NoError(t, err)
+	// Using the key close to the end of its epoch triggers the prefetch.
+	_, err = p.EgressKey(context.Background(), remoteIA, remoteIP, now)
+	require.NoError(t, err)
+	select {
+	case <-fetched:
+	case <-time.After(time.Second):
+		t.Fatal("next key not prefetched")
+	}
+	later := next.NotBefore.Add(time.Second)
+	require.Eventually(t, func() bool {
+		key, err := p.EgressKey(context.Background(), remoteIA, remoteIP, later)
+		return err == nil && key.Epoch == next
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package framecrypto
+
+const (
+	// ReplayWindowSize is the number of sequence numbers below the highest
+	// sequence number seen so far that are still accepted.
+	ReplayWindowSize = 1024
+
+	replayWindowWords = ReplayWindowSize / 64
+)
+
+// ReplayWindow detects replayed frames of a single sender. It keeps track of
+// the highest sequence number seen so far and of the sequence numbers in the
+// window below it. The zero value is ready to use.
+//
+// Check and Update are separate such that the window is only advanced for
+// frames that were successfully authenticated.
+type ReplayWindow struct {
+	initialized bool
+	highest     uint64
+	bitmap      [replayWindowWords]uint64
+}
+
+// Check indicates whether a frame with the given sequence number is acceptable,
+// i.e., it has not been seen before and is not too old.
+func (w *ReplayWindow) Check(seq uint64) bool {
+	if !w.initialized || seq > w.highest {
+		return true
+	}
+	if w.highest-seq >= ReplayWindowSize {
+		return false
+	}
+	word, bit := bitPos(seq)
+	return w.bitmap[word]&bit == 0
+}
+
+// Update marks the sequence number as seen. It must only be called after
+// Check returned true for the sequence number and the frame was authenticated.
+func (w *ReplayWindow) Update(seq uint64) {
+	switch {
+	case !w.initialized:
+		w.initialized = true
+		w.highest = seq
+	case seq > w.highest:
+		if seq-w.highest >= ReplayWindowSize {
+			w.bitmap = [replayWindowWords]uint64{}
+		} else {
+			for s := w.highest + 1; s < seq; s++ {
+				word, bit := bitPos(s)
+				w.bitmap[word] &^= bit
+			}
+		}
+		w.highest = seq
+	}
+	word, bit := bitPos(seq)
+	w.bitmap[word] |= bit
+}
+
+func bitPos(seq uint64) (int, uint64) {
+	idx := seq % ReplayWindowSize
+	return int(idx / 64), 1 << (idx % 64)
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package framecrypto_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/gateway/framecrypto"
+)
+
+func TestReplayWindow(t *testing.T) {
+	var w framecrypto.ReplayWindow
+	accept := func(seq uint64) bool {
+		if !w.Check(seq) {
+			return false
+		}
+		w.Update(seq)
+		return true
+	}
+
+	assert.True(t, accept(10))
+	assert.False(t, accept(10), "duplicate")
+	assert.True(t, accept(5), "reordered within window")
+	assert.False(t, accept(5), "reordered duplicate")
+	assert.True(t, accept(12))
+	assert.True(t, accept(11), "gap filled")
+	assert.False(t, accept(11))
+
+	// Not updating the window keeps the sequence number acceptable.
+	assert.True(t, w.Check(13))
+	assert.True(t, w.Check(13))
+
+	high := uint64(20 + framecrypto.ReplayWindowSize)
+	assert.True(t, accept(high))
+	assert.False(t, accept(10), "too old")
+	assert.True(t, accept(high-1))
+	assert.True(t, accept(high-framecrypto.ReplayWindowSize+1), "oldest in window")
+	assert.False(t, accept(high-framecrypto.ReplayWindowSize), "just outside window")
+
+	// A large jump resets the window.
+	jump := high + 10*framecrypto.ReplayWindowSize
+	assert.True(t, accept(jump))
+	assert.True(t, accept(jump-1))
+	assert.False(t, accept(jump-1))
+}
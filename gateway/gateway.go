@@ -22,17 +22,20 @@ import (
 	"net/netip"
 	"os"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	quic "github.com/quic-go/quic-go"
 	"github.com/quic-go/quic-go/http3"
+	"go4.org/netipx"
 	"google.golang.org/grpc"
 
 	"github.com/scionproto/scion/gateway/control"
 	controlconnect "github.com/scionproto/scion/gateway/control/connect"
 	controlgrpc "github.com/scionproto/scion/gateway/control/grpc"
 	"github.com/scionproto/scion/gateway/dataplane"
+	"github.com/scionproto/scion/gateway/framecrypto"
 	"github.com/scionproto/scion/gateway/pathhealth"
 	"github.com/scionproto/scion/gateway/pathhealth/policies"
 	"github.com/scionproto/scion/gateway/routemgr"
@@ -70,10 +73,13 @@ type DataplaneSessionFactory struct {
 	PacketConnFactory  PacketConnFactory
 	PathStatsPublisher dataplane.PathStatsPublisher
 	Metrics            dataplane.SessionMetrics
+	// Keys provides the keys for sessions whose frames are protected.
+	Keys framecrypto.KeyProvider
 }
 
 func (dpf DataplaneSessionFactory) New(id uint8, policyID int,
-	remoteIA addr.IA, remoteAddr net.Addr,
+	remoteIA addr.IA, remoteAddr net.Addr, cipherSuite framecrypto.CipherSuite,
+	loadBalancing policies.LoadBalancing,
 ) control.DataplaneSession {
 	conn, err := dpf.PacketConnFactory.New()
 	if err != nil {
@@ -93,6 +99,9 @@ func (dpf DataplaneSessionFactory) New(id uint8, policyID int,
 		DataPlaneConn:      conn,
 		PathStatsPublisher: dpf.PathStatsPublisher,
 		Metrics:            metrics,
+		CipherSuite:        cipherSuite,
+		Keys:               dpf.Keys,
+		LoadBalancing:      loadBalancing,
 	}
 	return sess
 }
@@ -126,10 +135,42 @@ func (rtf RoutingTableFactory) New(
 // depending on the state of the last published routing policy file.
 type SelectAdvertisedRoutes struct {
 	ConfigPublisher *control.ConfigPublisher
+	// BGP provides the prefixes learned via BGP. They are advertised according
+	// to the redistribute-bgp rules of the routing policy. If nil, no learned
+	// prefixes are advertised.
+	BGP interface{ Imported() []netip.Prefix }
 }
 
 func (a *SelectAdvertisedRoutes) AdvertiseList(from, to addr.IA) ([]netip.Prefix, error) {
-	return routing.AdvertiseList(a.ConfigPublisher.RoutingPolicy(), from, to)
+	pol := a.ConfigPublisher.RoutingPolicy()
+	nets, err := routing.AdvertiseList(pol, from, to)
+	if err != nil || a.BGP == nil {
+		return nets, err
+	}
+	learned, err := routing.RedistributeList(pol, from, to, a.BGP.Imported())
+	if err != nil {
+		return nil, err
+	}
+	return append(nets, learned...), nil
+}
+
+// Advertised returns all prefixes that can be advertised to any remote AS,
+// i.e., the static prefixes and the redistributed prefixes learned via BGP.
+// Used for reporting purposes.
+func (a *SelectAdvertisedRoutes) Advertised() ([]*net.IPNet, error) {
+	pol := a.ConfigPublisher.RoutingPolicy()
+	nets := routing.StaticAdvertised(pol)
+	if a.BGP == nil {
+		return nets, nil
+	}
+	learned, err := routing.StaticRedistributed(pol, a.BGP.Imported())
+	if err != nil {
+		return nil, err
+	}
+	for _, prefix := range learned {
+		nets = append(nets, netipx.PrefixIPNet(prefix))
+	}
+	return nets, nil
 }
 
 type RoutingPolicyPublisherAdapter struct {
@@ -173,6 +214,13 @@ type Gateway struct {
 	// DataIP is the IP that should be used for dataplane traffic.
 	DataAddr *net.UDPAddr
 
+	// CipherSuites are the cipher suites that are accepted for protected
+	// frames received from other gateways.
+	CipherSuites []framecrypto.CipherSuite
+	// RequireEncryption indicates whether unprotected frames received from
+	// other gateways are discarded.
+	RequireEncryption bool
+
 	// Daemon is the API of the SCION Daemon.
 	Daemon daemon.Connector
 
@@ -182,6 +230,10 @@ type Gateway struct {
 	RouteSourceIPv6 net.IP
 	// TunnelName is the device name for the Linux global tunnel device.
 	TunnelName string
+	// BGP is the optional BGP speaker. If set, the routes learned from remote
+	// gateways are exported to the BGP peer, and the prefixes learned from the
+	// peer are advertised according to the routing policy.
+	BGP *routemgr.BGP
 
 	// RoutingTableReader is used for routing the packets.
 	RoutingTableReader control.RoutingTableReader
@@ -201,6 +253,14 @@ type Gateway struct {
 
 	// client (happy eyeballs) and server-side rpc config
 	RpcConfig env.RPC
+
+	// stateMtx protects the components below. They are created by Run and are
+	// observed by the management API.
+	stateMtx              sync.RWMutex
+	engineController      *control.EngineController
+	remoteMonitor         *control.RemoteMonitor
+	advertiser            *SelectAdvertisedRoutes
+	routePublisherFactory control.PublisherFactory
 }
 
 func (g *Gateway) Run(ctx context.Context) error {
@@ -243,7 +303,7 @@ func (g *Gateway) Run(ctx context.Context) error {
 
 	logger.Debug("Egress started")
 
-	routePublisherFactory := createRouteManager(ctx, deviceManager)
+	routePublisherFactory := createRouteManager(ctx, deviceManager, g.BGP)
 
 	// *********************************************
 	// Initialize base SCION network information: IA
@@ -260,6 +320,15 @@ func (g *Gateway) Run(ctx context.Context) error {
 	localIA := topo.LocalIA
 	logger.Info("Learned local IA from SCION Daemon", "ia", localIA)
 
+	// The keys that protect the frames are derived from DRKey host-host keys
+	// that are fetched from the Daemon. Frames are sent and received on the
+	// same IP address.
+	frameKeys := &framecrypto.DRKeyProvider{
+		Fetcher: g.Daemon,
+		LocalIA: localIA,
+		LocalIP: g.DataClientIP,
+	}
+
 	// *************************************************************************
 	// Set up path monitoring. The path monitor runs an the SCION/UDP stack
 	// using the control address and uses traceroute packets to check if paths
@@ -575,11 +644,15 @@ func (g *Gateway) Run(ctx context.Context) error {
 		libgrpc.DefaultMaxConcurrentStreams(),
 	)
 
+	advertiser := &SelectAdvertisedRoutes{
+		ConfigPublisher: configPublisher,
+	}
+	if g.BGP != nil {
+		advertiser.BGP = g.BGP
+	}
 	prefixServer := controlgrpc.IPPrefixServer{
-		LocalIA: localIA,
-		Advertiser: &SelectAdvertisedRoutes{
-			ConfigPublisher: configPublisher,
-		},
+		LocalIA:            localIA,
+		Advertiser:         advertiser,
 		PrefixesAdvertised: paMetric,
 	}
 	prefixConnect.Handle(
@@ -639,7 +712,7 @@ func (g *Gateway) Run(ctx context.Context) error {
 	if err != nil {
 		return serrors.Wrap("creating server probe conn", err)
 	}
-	probeServer := controlgrpc.ProbeDispatcher{}
+	probeServer := controlgrpc.ProbeDispatcher{CipherSuites: g.CipherSuites}
 	probeServerCtx, probeServerCancel := context.WithCancel(ctx)
 	defer probeServerCancel()
 	go func() {
@@ -651,7 +724,11 @@ func (g *Gateway) Run(ctx context.Context) error {
 
 	// Start dataplane ingress
 	if err := StartIngress(ctx, scionNetwork, g.DataServerAddr, deviceManager,
-		g.Metrics); err != nil {
+		g.Metrics, IngressProtection{
+			CipherSuites:      g.CipherSuites,
+			Keys:              frameKeys,
+			RequireEncryption: g.RequireEncryption,
+		}); err != nil {
 		return err
 	}
 	logger.Debug("Ingress started")
@@ -701,6 +778,7 @@ func (g *Gateway) Run(ctx context.Context) error {
 					Addr:    &net.UDPAddr{IP: g.DataClientIP},
 				},
 				Metrics: CreateSessionMetrics(g.Metrics),
+				Keys:    frameKeys,
 			},
 			Metrics: CreateEngineMetrics(g.Metrics),
 		},
@@ -751,10 +829,59 @@ func (g *Gateway) Run(ctx context.Context) error {
 	if err := g.HTTPEndpoints.Register(g.HTTPServeMux, g.ID); err != nil {
 		return serrors.Wrap("registering HTTP pages", err)
 	}
+
+	g.stateMtx.Lock()
+	g.engineController = engineController
+	g.remoteMonitor = remoteMonitor
+	g.advertiser = advertiser
+	g.routePublisherFactory = routePublisherFactory
+	g.stateMtx.Unlock()
+
 	<-ctx.Done()
 	return nil
 }
 
+// ListRemotes returns the remote gateways discovered in the remote ASes. It
+// returns nil if the gateway is not running yet.
+func (g *Gateway) ListRemotes() []control.RemoteInfo {
+	g.stateMtx.RLock()
+	defer g.stateMtx.RUnlock()
+	if g.remoteMonitor == nil {
+		return nil
+	}
+	return g.remoteMonitor.Remotes()
+}
+
+// ListSessions returns the sessions to the remote gateways. It returns nil if
+// the gateway is not running yet.
+func (g *Gateway) ListSessions() []control.SessionInfo {
+	g.stateMtx.RLock()
+	defer g.stateMtx.RUnlock()
+	if g.engineController == nil {
+		return nil
+	}
+	return g.engineController.Sessions()
+}
+
+// ListPrefixes returns the prefixes advertised to and learned from the remote
+// gateways.
+func (g *Gateway) ListPrefixes() control.PrefixInfo {
+	g.stateMtx.RLock()
+	defer g.stateMtx.RUnlock()
+	var info control.PrefixInfo
+	if g.advertiser != nil {
+		advertised, err := g.advertiser.Advertised()
+		if err != nil {
+			log.Error("Listing advertised prefixes", "err", err)
+		}
+		info.Advertised = advertised
+	}
+	if p, ok := g.routePublisherFactory.(interface{ Diagnostics() control.Diagnostics }); ok {
+		info.Learned = p.Diagnostics().Routes
+	}
+	return info
+}
+
 func (g *Gateway) diagnosticsSGRP(
 	routePublisherFactory control.PublisherFactory,
 	pub *control.ConfigPublisher,
@@ -823,8 +950,19 @@ func CreateIngressMetrics(m *Metrics) dataplane.IngressMetrics {
 	}
 }
 
+// IngressProtection configures the protection of the frames received by the
+// ingress server.
+type IngressProtection struct {
+	// CipherSuites are the cipher suites that are accepted for protected frames.
+	CipherSuites []framecrypto.CipherSuite
+	// Keys provides the keys for protected frames.
+	Keys framecrypto.KeyProvider
+	// RequireEncryption indicates whether unprotected frames are discarded.
+	RequireEncryption bool
+}
+
 func StartIngress(ctx context.Context, scionNetwork *snet.SCIONNetwork, dataAddr *net.UDPAddr,
-	deviceManager control.DeviceManager, metrics *Metrics,
+	deviceManager control.DeviceManager, metrics *Metrics, protection IngressProtection,
 ) error {
 	logger := log.FromCtx(ctx)
 	//nolint:contextcheck // Unclear whether ctx can be used here.
@@ -838,9 +976,12 @@ func StartIngress(ctx context.Context, scionNetwork *snet.SCIONNetwork, dataAddr
 	}
 	ingressMetrics := CreateIngressMetrics(metrics)
 	ingressServer := &dataplane.IngressServer{
-		Conn:          dataplaneServerConn,
-		DeviceManager: deviceManager,
-		Metrics:       ingressMetrics,
+		Conn:              dataplaneServerConn,
+		DeviceManager:     deviceManager,
+		Metrics:           ingressMetrics,
+		CipherSuites:      protection.CipherSuites,
+		Keys:              protection.Keys,
+		RequireEncryption: protection.RequireEncryption,
 	}
 	go func() {
 		defer log.HandlePanic()
@@ -916,13 +1057,26 @@ func createRouterMetrics(m *Metrics) control.RouterMetrics {
 
 func createRouteManager(ctx context.Context,
 	deviceManager control.DeviceManager,
+	bgp *routemgr.BGP,
 ) control.PublisherFactory {
 	linux := &routemgr.Linux{DeviceManager: deviceManager}
 	go func() {
 		defer log.HandlePanic()
 		linux.Run(ctx)
 	}()
-	return linux
+	if bgp == nil {
+		return linux
+	}
+	go func() {
+		defer log.HandlePanic()
+		bgp.Run(ctx)
+	}()
+	go func() {
+		defer log.HandlePanic()
+		<-ctx.Done()
+		bgp.Close()
+	}()
+	return routemgr.Multi{linux, bgp}
 }
 
 type TunnelReader struct {
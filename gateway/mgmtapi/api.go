@@ -15,7 +15,13 @@
 package mgmtapi
 
 import (
+	"encoding/json"
 	"net/http"
+
+	"github.com/scionproto/scion/gateway/control"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/ptr"
+	api "github.com/scionproto/scion/private/mgmtapi"
 )
 
 // Server implements the Posix Gateway Service API.
@@ -23,6 +29,7 @@ type Server struct {
 	Config   http.HandlerFunc
 	Info     http.HandlerFunc
 	LogLevel http.HandlerFunc
+	Gateway  control.ObservableGateway
 }
 
 // GetConfig is an indirection to the http handler.
@@ -44,3 +51,157 @@ func (s *Server) GetLogLevel(w http.ResponseWriter, r *http.Request) {
 func (s *Server) SetLogLevel(w http.ResponseWriter, r *http.Request) {
 	s.LogLevel(w, r)
 }
+
+// GetRemotes lists the remote gateways discovered in the remote ASes.
+func (s *Server) GetRemotes(w http.ResponseWriter, r *http.Request) {
+	infos := s.Gateway.ListRemotes()
+	remotes := make([]RemoteAS, 0, len(infos))
+	for _, info := range infos {
+		gateways := make([]RemoteGateway, 0, len(info.Gateways))
+		for _, gw := range info.Gateways {
+			interfaces := make([]int, 0, len(gw.Gateway.Interfaces))
+			for _, intf := range gw.Gateway.Interfaces {
+				interfaces = append(interfaces, int(intf))
+			}
+			prefixes := gw.Prefixes
+			if prefixes == nil {
+				prefixes = []string{}
+			}
+			remote := RemoteGateway{
+				ControlAddress: gw.Gateway.Control.String(),
+				ProbeAddress:   gw.Gateway.Probe.String(),
+				DataAddress:    gw.Gateway.Data.String(),
+				Interfaces:     interfaces,
+				Prefixes:       prefixes,
+			}
+			if !gw.LastUpdate.IsZero() {
+				remote.LastUpdate = ptr.To(gw.LastUpdate)
+			}
+			gateways = append(gateways, remote)
+		}
+		remotes = append(remotes, RemoteAS{
+			IsdAs:    info.IA.String(),
+			Gateways: gateways,
+		})
+	}
+	writeResponse(w, RemotesResponse{Remotes: &remotes})
+}
+
+// GetSessions lists the sessions to the remote gateways.
+func (s *Server) GetSessions(w http.ResponseWriter, r *http.Request, params GetSessionsParams) {
+	var filter addr.IA
+	if params.IsdAs != nil {
+		ia, err := addr.ParseIA(*params.IsdAs)
+		if err != nil {
+			ErrorResponse(w, Problem{
+				Detail: api.StringRef(err.Error()),
+				Status: http.StatusBadRequest,
+				Title:  "invalid ISD-AS",
+				Type:   api.StringRef(api.BadRequest),
+			})
+			return
+		}
+		filter = ia
+	}
+	infos := s.Gateway.ListSessions()
+	sessions := make([]Session, 0, len(infos))
+	for _, info := range infos {
+		if params.IsdAs != nil && info.RemoteIA != filter {
+			continue
+		}
+		sessions = append(sessions, sessionFromControl(info))
+	}
+	writeResponse(w, SessionsResponse{Sessions: &sessions})
+}
+
+// GetPrefixes lists the IP prefixes advertised to and learned from the remote
+// gateways.
+func (s *Server) GetPrefixes(w http.ResponseWriter, r *http.Request) {
+	info := s.Gateway.ListPrefixes()
+	advertised := make([]string, 0, len(info.Advertised))
+	for _, prefix := range info.Advertised {
+		advertised = append(advertised, prefix.String())
+	}
+	learned := make([]LearnedPrefix, 0, len(info.Learned))
+	for _, route := range info.Learned {
+		prefix := LearnedPrefix{
+			Prefix: route.Prefix.String(),
+			IsdAs:  route.IA.String(),
+		}
+		if route.NextHop != nil {
+			prefix.NextHop = ptr.To(route.NextHop.String())
+		}
+		learned = append(learned, prefix)
+	}
+	writeResponse(w, PrefixesResponse{
+		Advertised: advertised,
+		Learned:    learned,
+	})
+}
+
+func sessionFromControl(info control.SessionInfo) Session {
+	prefixes := make([]string, 0, len(info.Prefixes))
+	for _, prefix := range info.Prefixes {
+		prefixes = append(prefixes, prefix.String())
+	}
+	paths := make([]SessionPath, 0, len(info.Paths))
+	for _, p := range info.Paths {
+		path := SessionPath{
+			Fingerprint: p.Stats.Fingerprint.String(),
+			Path:        p.Path,
+			InUse:       p.InUse,
+			Alive:       p.Stats.IsAlive,
+			Revoked:     p.Stats.IsRevoked,
+			Latency:     p.Stats.Latency.String(),
+			Jitter:      p.Stats.Jitter.String(),
+			DropRate:    p.Stats.DropRate,
+		}
+		if p.Rejected {
+			path.Rejected = ptr.To(p.RejectReason)
+		}
+		paths = append(paths, path)
+	}
+	return Session{
+		SessionId: int(info.ID),
+		IsdAs:     info.RemoteIA.String(),
+		RemoteGateway: SessionGateway{
+			ControlAddress: info.Gateway.Control.String(),
+			ProbeAddress:   info.Gateway.Probe.String(),
+			DataAddress:    info.Gateway.Data.String(),
+		},
+		Healthy: info.Healthy,
+		Policy: SessionPolicy{
+			PolicyId:       info.PolicyID,
+			TrafficMatcher: info.TrafficMatcher,
+			PathCount:      info.PathCount,
+			Prefixes:       prefixes,
+			CipherSuite:    info.CipherSuite.String(),
+		},
+		Paths: paths,
+	}
+}
+
+func writeResponse(w http.ResponseWriter, rep any) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(rep); err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+}
+
+// ErrorResponse creates a detailed error response.
+func ErrorResponse(w http.ResponseWriter, p Problem) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	// no point in catching error here, there is nothing we can do about it anymore.
+	_ = enc.Encode(p)
+}
@@ -0,0 +1,237 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgmtapi
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/gateway/control"
+	"github.com/scionproto/scion/gateway/control/mock_control"
+	"github.com/scionproto/scion/gateway/framecrypto"
+	"github.com/scionproto/scion/gateway/pathhealth"
+	"github.com/scionproto/scion/gateway/pathhealth/policies"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/xtest"
+)
+
+var update = xtest.UpdateGoldenFiles()
+
+func TestAPI(t *testing.T) {
+	testCases := map[string]struct {
+		Handler      func(t *testing.T, ctrl *gomock.Controller) http.Handler
+		RequestURL   string
+		ResponseFile string
+		Status       int
+	}{
+		"remotes": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				gateway := mock_control.NewMockObservableGateway(ctrl)
+				gateway.EXPECT().ListRemotes().Return(createRemotes())
+				return Handler(&Server{Gateway: gateway})
+			},
+			RequestURL:   "/remotes",
+			ResponseFile: "testdata/remotes.json",
+			Status:       200,
+		},
+		"remotes empty": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				gateway := mock_control.NewMockObservableGateway(ctrl)
+				gateway.EXPECT().ListRemotes().Return(nil)
+				return Handler(&Server{Gateway: gateway})
+			},
+			RequestURL:   "/remotes",
+			ResponseFile: "testdata/remotes-empty.json",
+			Status:       200,
+		},
+		"sessions": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				gateway := mock_control.NewMockObservableGateway(ctrl)
+				gateway.EXPECT().ListSessions().Return(createSessions())
+				return Handler(&Server{Gateway: gateway})
+			},
+			RequestURL:   "/sessions",
+			ResponseFile: "testdata/sessions.json",
+			Status:       200,
+		},
+		"sessions filtered": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				gateway := mock_control.NewMockObservableGateway(ctrl)
+				gateway.EXPECT().ListSessions().Return(createSessions())
+				return Handler(&Server{Gateway: gateway})
+			},
+			RequestURL:   "/sessions?isd_as=1-ff00:0:112",
+			ResponseFile: "testdata/sessions-filtered.json",
+			Status:       200,
+		},
+		"sessions bad isd_as": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				gateway := mock_control.NewMockObservableGateway(ctrl)
+				return Handler(&Server{Gateway: gateway})
+			},
+			RequestURL:   "/sessions?isd_as=invalid",
+			ResponseFile: "testdata/sessions-bad-isd-as.json",
+			Status:       400,
+		},
+		"prefixes": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				gateway := mock_control.NewMockObservableGateway(ctrl)
+				gateway.EXPECT().ListPrefixes().Return(control.PrefixInfo{
+					Advertised: []*net.IPNet{
+						xtest.MustParseCIDR(t, "10.1.0.0/16"),
+						xtest.MustParseCIDR(t, "2001:db8:1::/48"),
+					},
+					Learned: []control.Route{
+						{
+							Prefix:  xtest.MustParseCIDR(t, "10.2.0.0/16"),
+							NextHop: net.ParseIP("169.254.0.1"),
+							IA:      addr.MustParseIA("1-ff00:0:111"),
+						},
+						{
+							Prefix: xtest.MustParseCIDR(t, "10.3.0.0/16"),
+							IA:     addr.MustParseIA("1-ff00:0:112"),
+						},
+					},
+				})
+				return Handler(&Server{Gateway: gateway})
+			},
+			RequestURL:   "/prefixes",
+			ResponseFile: "testdata/prefixes.json",
+			Status:       200,
+		},
+		"prefixes empty": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				gateway := mock_control.NewMockObservableGateway(ctrl)
+				gateway.EXPECT().ListPrefixes().Return(control.PrefixInfo{})
+				return Handler(&Server{Gateway: gateway})
+			},
+			RequestURL:   "/prefixes",
+			ResponseFile: "testdata/prefixes-empty.json",
+			Status:       200,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			req, err := http.NewRequest("GET", tc.RequestURL, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			tc.Handler(t, ctrl).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.Status, rr.Result().StatusCode)
+
+			if *update {
+				require.NoError(t, os.WriteFile(tc.ResponseFile, rr.Body.Bytes(), 0o666))
+			}
+			golden, err := os.ReadFile(tc.ResponseFile)
+			require.NoError(t, err)
+			assert.Equal(t, string(golden), rr.Body.String())
+		})
+	}
+}
+
+func createRemotes() []control.RemoteInfo {
+	return []control.RemoteInfo{
+		{
+			IA: addr.MustParseIA("1-ff00:0:111"),
+			Gateways: []control.RemoteGatewayInfo{
+				{
+					Gateway:    createGateway("192.168.3.2", 2),
+					Prefixes:   []string{"10.2.0.0/16", "10.4.0.0/16"},
+					LastUpdate: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+				},
+				{
+					Gateway: createGateway("192.168.3.3"),
+				},
+			},
+		},
+		{
+			IA:       addr.MustParseIA("1-ff00:0:112"),
+			Gateways: []control.RemoteGatewayInfo{},
+		},
+	}
+}
+
+func createSessions() []control.SessionInfo {
+	return []control.SessionInfo{
+		{
+			ID:             0,
+			PolicyID:       0,
+			RemoteIA:       addr.MustParseIA("1-ff00:0:111"),
+			Gateway:        createGateway("192.168.3.2", 2),
+			Healthy:        true,
+			TrafficMatcher: "dscp=0x2",
+			PathCount:      2,
+			Prefixes: []*net.IPNet{
+				{IP: net.IP{10, 2, 0, 0}, Mask: net.CIDRMask(16, 32)},
+			},
+			CipherSuite: framecrypto.AES128GCM,
+			Paths: []control.SessionPathInfo{
+				{
+					PathInfoEntry: pathhealth.PathInfoEntry{
+						Path: "Hops: [1-ff00:0:110 1>2 1-ff00:0:111]",
+						Stats: policies.Stats{
+							Fingerprint: "path-1",
+							Latency:     12 * time.Millisecond,
+							Jitter:      1500 * time.Microsecond,
+							IsAlive:     true,
+						},
+					},
+					InUse: true,
+				},
+				{
+					PathInfoEntry: pathhealth.PathInfoEntry{
+						Path:         "Hops: [1-ff00:0:110 3>4 1-ff00:0:111]",
+						Rejected:     true,
+						RejectReason: "dead (probes are not passing through)",
+						Stats: policies.Stats{
+							Fingerprint: "path-2",
+							DropRate:    1,
+							IsRevoked:   true,
+						},
+					},
+				},
+			},
+		},
+		{
+			ID:          1,
+			PolicyID:    3,
+			RemoteIA:    addr.MustParseIA("1-ff00:0:112"),
+			Gateway:     createGateway("192.168.4.2"),
+			PathCount:   1,
+			CipherSuite: framecrypto.None,
+		},
+	}
+}
+
+func createGateway(ip string, interfaces ...uint64) control.Gateway {
+	return control.Gateway{
+		Control:    &net.UDPAddr{IP: net.ParseIP(ip), Port: 30256},
+		Probe:      &net.UDPAddr{IP: net.ParseIP(ip), Port: 30856},
+		Data:       &net.UDPAddr{IP: net.ParseIP(ip), Port: 30056},
+		Interfaces: interfaces,
+	}
+}
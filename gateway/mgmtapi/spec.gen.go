@@ -18,17 +18,58 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xVTW/bOBD9K8TsHgXL+dg96NYERWDAbYK6t8AHWhrLDCgOS1KuDUP/vRjSUmM5QYoe",
-	"ArQ3ajh88+bxcXSAkhpLBk3wUBzAobdkPMaPG1l9wW8t+sBfJZmAJi6ltVqVMigy+ZMnwzFfbrCRvPrX",
-	"4RoK+Cf/CZ2nXZ8vgjSVdNVH58hB13UZVOhLpyyDQcE1hTsW5d3jQcadUz3HLWpeW0cWXVCJqO7Dp1hz",
-	"qmtlapG2M0DTNlA8QoWrtoYMlFkThyOXZQa4k43VCEW/E/aWv3xwytSRDlNTDiuGSbDLIY1WT1gG6DI4",
-	"7fKMLvbhU7oxWzTovazxzeoD61F1zov0z/AX6LaqRPHhYSbW5ETYoHggr3biTgb8LvdcU4UowEmcT0AG",
-	"W3Q+IU0n08kFN0oWjbQKCriaTCeXkIGVYRObzEsya1XzssboGpYgemZWQQF3GG5TRnbqusvpdGS3gLuQ",
-	"Wy3VyGhjgc7MtGjLEr1ft1rc98WZ9nUq8ZJRByr5M/dHI7ZNI92etXHKBB/l+3r/aS5So22CF2ulccJK",
-	"ytrzPZXUNGRgyRh5fzGvKTJLvvuz9LiRXpWCW3NN0sDKGoVcURuiSkzdkRb+aEDriGm8qpKmOh+e9GtS",
-	"DdPgTbl+f1oNNd5NyzsMQo/G1plGGdj2BVEWI1Ei/g1V+3fRox+2z+unWRVci91fdUuLX7mleAQdT00o",
-	"Hg/QOg0FbEKwRZ4fNuRDVxwsudDl0qp8e8EjVjolVzppxClpiq9lqwMUoKmUOobZA+RG21fT6//+ZxWW",
-	"A53xP+A2sou/ANxZ8liJ1V4sbmf3n/vnGd+lkQ2PkmMz3bL7EQAA//93UAL/KQgAAA==",
+	"H4sIAAAAAAAC/+xabY/bNrb+K4TaDy0q27InM0kM3A+TtM0dIL0ZZFIUaDt3QItHEhuJVEnKM96s//vi",
+	"kHoX7fG0aLdd7DdbfDt8znPeSH4KYlmUUoAwOlh/ChToUgoN9s8ryt7DrxVog/9iKQwI+5OWZc5jargU",
+	"i1+0FPhNxxkUFH99riAJ1sFni27qhWvVixtDBaOKfaOUVMF+vw8DBjpWvMTJgjWuSVS9KLbWA3HeK80u",
+	"7Q94oEWZQ7AOlrMkiaJ1tF4uoyAMSmoMKJzm/3/+mX01++InOkui2cvbT8vw2X795afVfvjpy39iv8+D",
+	"MDDc2Bmvbr6eXd6QKwbC8ISDwrZdiU3aKC7SYB8Gb4EqAexaQcIfUKJSyRKU4Q43rtkd1Y9B4bazDwMB",
+	"D+YukyUOGILxIQOCrSSTJZEJMRkQJSsDc3K50SAM4b2PJKOaCNmOmAdhH6qLl/PV+bN5NF/6tlS2e5mK",
+	"cHVNXPNoxmi+mkfzaLG8mM64DwNUI1fAgvVPzfRhg81th/h7K7uRhIpuJZI7iEmiZEEoUVBIAySlBu7p",
+	"bt6tJze/QGysUmT6FraQT/WRN5+HW3sr05SLlLjmMABRFSgrg02VoqgikfjZUvW2v/O65fiW3bS3Hkkd",
+	"b0C/r41tKjFlW/yjgU3FHmgENDEZNSSmgmyAdOMQUMuMAXCa0DiWiuG2mw6yMvi3lDmPdyHhIs4r1yGD",
+	"bpVGH1tOyas3125VqnABxhGBTWWADQjyEzJk2TDkNgy4gcJub8K++gNViu7wf72aNaZm0DFbGhrkZMKR",
+	"Znrodkv1GVnrheRcmwaJHuSBV6dyk0MxVSUDQ7mHfZckqwoqiALK6CYHAg9lToV1qkSXEPOEx05JXBMZ",
+	"x5VSIGJo/EDpFnSK4JpkkJdJleOIXMbUwKAXFYykfGsZwnESQTJ5j51LJWMANic/KG4MCMIF+UakOdeZ",
+	"HdXKl0hFQKRcACgdkkpXNM93REhDdMUN2qpUREhBDMSZ4DHNiTb0I2QyZ6C0nQ17o3g5/8eILcFrKQTE",
+	"dvtGEkYN3VANxPACGJGV8XktLrShIgYfvN+/vyIKEnCoOZgar64tOC3KB9ENCczTOdnsCGXWJihJFE0L",
+	"EL3JFJGK6GozK6nJGrNq1bMrYU6+ozs0z6o2zJ6ClJTGLcp1O4gLJ5+sVAwklgyGUC3qjou4xWxm3dRn",
+	"Rn4EMUP/NEPFzSx6M4deIlVBTbAOKsVnLTI+WLWhptJ+1/O/Hz5cE9fBSkZSEKAo6n+zs2JLxVMuiAa1",
+	"BWVJcZzCg72dR2dhUNAHXqAzPn/5MgwKLty/ZRS1wnJhIAUV7FuznTJAZ1IhOYuCqt3Ebqxi/t2kvwFl",
+	"7fF7QbeU57imTyHuA+4woVWOOqQbWZn1JqfiYxCewv1K8F8ryHdjI+jjQaTIdw37bL73YHq4bTkDRi6v",
+	"r+bkXVnKXpRpLMl5Ly7I+29fz56/iJ6HhFvvJICbDBRREMuiAMHc2A0QBo2gFnDEq5RcGGymzkfOWnUw",
+	"GVdofG4dIRVJc7mxKnH7q+k2UvNpxvMEExkFlNpeGir6Yv57G4cvb6YBognNJwc7N9UbN8wXPZ+Uf462",
+	"Uo8NO7EGgRFXJpc3Vk0Ic9OLMK5juQUFDNXPjTdFG0o+QQIZp2R+RxlToD3+59I1NJSs+xNdG5FMPCnP",
+	"KGd9uZovL17Mz+ar9Vm0Or/wWRvGnhOEEOQ+43HmWRNpDnxbWxhSu9RVbn2kUTRJeHxMqsgvFXo8ldAY",
+	"DjjmnGozw0Lh5vXVu/8jXXdn/zqTVc7Q4kpLbOUsUAH1bmGYya082VvfA4/TN6rNXVUyajx++YcMxDC3",
+	"vAflxCcJmDhrsn6PUMOyZ+dGCrBxph6qqzgGrZMqz0e6X0Wr81l0NouWH5arNZaN0Y99m0dxbcA8XB8d",
+	"wr6fj1MhZCXiLh4eA3ZQRD0pRUYvBr+PpVToe1Au7QB9jJIvzh8v8cbWOxZxZFcDQvfwnbqbRtyhi2lr",
+	"wsubI77mSI3lhj/V7V7eeMuLo6VDK6i/dLgBrbkUUwkzoLnJdlPlXgnWxFqZkPsMbGw9qGFgbUgEbX2T",
+	"MCPjMKqCVrSNlDlQ8eRQYg9gsgMmYpsaF63dlsMm15CKgcLGsg26KN5Jeqnhu6Ym89qJrWtPncR1tsxG",
+	"IO/SLladMLwXk+sd3nFPBX/VqxwGeKC14t97roZJy3Ka945TkG69sAvio12ELaVaXBqd9cyu3oxLwE44",
+	"eBlt/r9h/Ulh/e/uxw/7a5kQ2jD7GHGs5U6PwHK+hae4vtq9lVSjfpSsUgcW8vskX8eULO+UN2dBB5Yo",
+	"GjfrOiWgH22WtTmWS0ekaf3uYN1oHp330w1ZuWqvrXaXvVq3q3RFVWxcmpVwkYIqFRdmKuK3XWNbso13",
+	"HlzQi/iCPd88j5+xs/j8pT/PvKv0k5BvvJer702+IxoE041RELmtO56sil+4MaD8eqBbUDQFwnjSFLgb",
+	"MPcAAj2HhrgyeNAlBcyQhWifIuagSQFUVxgM77nJpioc2c38vNA+eNx8O79sBTBOxWjt3aMrkx9BSUxq",
+	"hXSfyD3VfhIFy5VfrrK2oqlQmSz1YUr0r1DI6ucqis5gSXpfvdcFCtCIfcfTP2S7diU8lkZzaI6+Nrs+",
+	"X8aXGM2IpvtQTgaUkS9qa6O1naGxuyzL2vuXflG38iOwUwlNRVc6NfHYSoYqqecaSJbQXHtIPPKqfdut",
+	"ldWaWlg7u07WjmatLfT9U8/nXlvQpNCcgeogthJryKF1Wae54jZZGoVwXmag7uyRl59kroc7FHPOsFTS",
+	"QGxcsEwULWCc/g0VTEHPlqsXszQuDvH7LpaVMH4Bai9KnLvElVzGaWXpLYrU0kTzosoNFSArne8ey7Wa",
+	"bOmJ2ZwbZI2+znPtF94BMShhukDhleDkArR3NUPjzJ6b9eOhD/zfUYfWTv6uoFh/H/DZsRSM43/d3KXU",
+	"w0hRaUM0NVwnO2IkaucwRZiOy/+JHlaPXze26poKOKBSD9dwyHJPQlzr0+Kbd4efsQL6JCs7UpTWo0+v",
+	"Suspn16Utiv55Bzc0k+EhObzUNG2NylAa5o+fmbaXquOVt/v65vXyfzNWfnl9VV7zHstNX8gb9r6pnWL",
+	"/e84IgiDLShXZweRvQXfh4EsQdCSB+vgbB7NV0GvgsXj4YSn+DMF63MQAqvoKxasgzdgXrse4fDVxCqK",
+	"Rs8l8Bh9UeaUjx5KjAGaPIa4ac+yyLtmcRT7WRQd4kUryqL3egNnrq9BEBsMQs4QP7z77i1xG63c9CTh",
+	"ua09DU21KweKQorgFudYNIo5hMiVuxj/e+HximoeE9yaKhwGJeaX9obFW5DaK1OtD6KUy3TRvjk4BFX7",
+	"XOFRuH77a5t2jT8NyzdgSD56VzHBKAzKygPKzQgUO/8ryXZ/Ch7Na5D++s5XYZGy/4/S0s0pWkIm95OO",
+	"msgj1Lg24+cRXcrVHlA0Dy70b36W0lw44fdumv6qgxdDnjXQXCdWeN1F/z9Mv5PnPh49X3YPd3CnzV56",
+	"+5uj2s+PilVfbH71VPHsKJ9UV8KAEjQnNljPRyzy6b5HJPepJlLvsP04j8bE6M5Vhsf/o3N1mxC4AzI6",
+	"zr+5bkMcsJAYmbpKry3Gfdzdtfc4Xt7Utwt/JG3GFxg+n4WoyWQARG0oXLUYhkRLVb/PcM8b/8JcGum/",
+	"xyfXUvOpnycfJ1TT84Dn8fFhwB8Oepzxc9Xl/I1f6hWblq29kpxVqkm67SXnsDb30uumS85LiqWzAYUg",
+	"jHf5Dt9r5P6tct3stVF6ePjlLKZiwa8VKEyiBS2wS3uLcJrum/cEt3+gTUwKqCNG0aDRp/8AEKu7RtdX",
+	"X897cfXPM4otzXn74vkvbJf9crE2yPoTWiSOsc+9HEkrlQfrIDOmXC8WnzKpzX79qZTK7Be05IstHilu",
+	"qeJ4NuFuOqU2w/dN9r2U/WxPX9So+Sx6dn6B27lt5ZmUo1tQOzx5SYmC+pZGHkoMasq7lmAfnj7ZI/6l",
+	"N7luS/XTZ++HJniIMyrS4THyocXK5inseK3XNr+zRTQ8uEdcm139ZqQucPrz1Ong/nb/rwEABaYsGysw",
+	"AAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
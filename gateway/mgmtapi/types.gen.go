@@ -3,6 +3,10 @@
 // Code generated by unknown module path version unknown version DO NOT EDIT.
 package mgmtapi
 
+import (
+	"time"
+)
+
 // Defines values for LogLevelLevel.
 const (
 	Debug LogLevelLevel = "debug"
@@ -10,6 +14,20 @@ const (
 	Info  LogLevelLevel = "info"
 )
 
+// IsdAs defines model for IsdAs.
+type IsdAs = string
+
+// LearnedPrefix defines model for LearnedPrefix.
+type LearnedPrefix struct {
+	IsdAs IsdAs `json:"isd_as"`
+
+	// NextHop The next hop of the route. Absent if the route has no next hop.
+	NextHop *string `json:"next_hop,omitempty"`
+
+	// Prefix The IP prefix.
+	Prefix string `json:"prefix"`
+}
+
 // LogLevel defines model for LogLevel.
 type LogLevel struct {
 	// Level Logging level
@@ -19,6 +37,143 @@ type LogLevel struct {
 // LogLevelLevel Logging level
 type LogLevelLevel string
 
+// PrefixesResponse defines model for PrefixesResponse.
+type PrefixesResponse struct {
+	// Advertised The IP prefixes that can be advertised to the remote gateways according to the routing policy, including the prefixes learned via BGP that are redistributed.
+	Advertised []string        `json:"advertised"`
+	Learned    []LearnedPrefix `json:"learned"`
+}
+
+// Problem defines model for Problem.
+type Problem struct {
+	// Detail A human readable explanation specific to this occurrence of the problem that is helpful to locate the problem and give advice on how to proceed. Written in English and readable for engineers, usually not suited for non technical stakeholders and not localized.
+	Detail *string `json:"detail,omitempty"`
+
+	// Instance A URI reference that identifies the specific occurrence of the problem, e.g. by adding a fragment identifier or sub-path to the problem type. May be used to locate the root of this problem in the source code.
+	Instance *string `json:"instance,omitempty"`
+
+	// Status The HTTP status code generated by the origin server for this occurrence of the problem.
+	Status int `json:"status"`
+
+	// Title A short summary of the problem type. Written in English and readable for engineers, usually not suited for non technical stakeholders and not localized.
+	Title string `json:"title"`
+
+	// Type A URI reference that uniquely identifies the problem type only in the context of the provided API. Opposed to the specification in RFC-7807, it is neither recommended to be dereferencable and point to a human-readable documentation nor globally unique for the problem type.
+	Type *string `json:"type,omitempty"`
+}
+
+// RemoteAS defines model for RemoteAS.
+type RemoteAS struct {
+	Gateways []RemoteGateway `json:"gateways"`
+	IsdAs    IsdAs           `json:"isd_as"`
+}
+
+// RemoteGateway defines model for RemoteGateway.
+type RemoteGateway struct {
+	// ControlAddress Address of the control service of the remote gateway.
+	ControlAddress string `json:"control_address"`
+
+	// DataAddress Address on which the remote gateway receives the encapsulated traffic.
+	DataAddress string `json:"data_address"`
+
+	// Interfaces The last-hop SCION interfaces that should be preferred to reach the remote gateway.
+	Interfaces []int `json:"interfaces"`
+
+	// LastUpdate When the prefixes were last fetched from the remote gateway. Absent if they were never fetched successfully.
+	LastUpdate *time.Time `json:"last_update,omitempty"`
+
+	// Prefixes The IP prefixes announced by the remote gateway.
+	Prefixes []string `json:"prefixes"`
+
+	// ProbeAddress Address on which the remote gateway answers probes.
+	ProbeAddress string `json:"probe_address"`
+}
+
+// RemotesResponse defines model for RemotesResponse.
+type RemotesResponse struct {
+	Remotes *[]RemoteAS `json:"remotes,omitempty"`
+}
+
+// Session defines model for Session.
+type Session struct {
+	// Healthy Indication of whether the remote gateway answered the probes recently.
+	Healthy bool  `json:"healthy"`
+	IsdAs   IsdAs `json:"isd_as"`
+
+	// Paths The paths of the session, in the order of preference.
+	Paths         []SessionPath  `json:"paths"`
+	Policy        SessionPolicy  `json:"policy"`
+	RemoteGateway SessionGateway `json:"remote_gateway"`
+
+	// SessionId Identifier of the session on the wire.
+	SessionId int `json:"session_id"`
+}
+
+// SessionGateway defines model for SessionGateway.
+type SessionGateway struct {
+	// ControlAddress Address of the control service of the remote gateway.
+	ControlAddress string `json:"control_address"`
+
+	// DataAddress Address on which the remote gateway receives the encapsulated traffic.
+	DataAddress string `json:"data_address"`
+
+	// ProbeAddress Address on which the remote gateway answers probes.
+	ProbeAddress string `json:"probe_address"`
+}
+
+// SessionPath defines model for SessionPath.
+type SessionPath struct {
+	// Alive Indication of whether the probes pass through the path.
+	Alive bool `json:"alive"`
+
+	// DropRate The fraction of the recent probes that were not answered.
+	DropRate float64 `json:"drop_rate"`
+
+	// Fingerprint Fingerprint of the path.
+	Fingerprint string `json:"fingerprint"`
+
+	// InUse Indication of whether the session currently sends traffic over the path.
+	InUse bool `json:"in_use"`
+
+	// Jitter The average difference between consecutive one-way latencies measured with the recent probes.
+	Jitter string `json:"jitter"`
+
+	// Latency The median one-way latency measured with the recent probes. Zero if no probe was answered.
+	Latency string `json:"latency"`
+
+	// Path The hops of the path.
+	Path string `json:"path"`
+
+	// Rejected Why the path cannot be used by the session. Absent if the path can be used.
+	Rejected *string `json:"rejected,omitempty"`
+
+	// Revoked Indication of whether an interface on the path was revoked.
+	Revoked bool `json:"revoked"`
+}
+
+// SessionPolicy defines model for SessionPolicy.
+type SessionPolicy struct {
+	// CipherSuite The cipher suite that protects the frames of the session.
+	CipherSuite string `json:"cipher_suite"`
+
+	// PathCount The maximum number of paths that the session uses simultaneously.
+	PathCount int `json:"path_count"`
+
+	// PolicyId Identifier of the session policy within the policies of the remote AS.
+	PolicyId int `json:"policy_id"`
+
+	// Prefixes The IP prefixes that are reachable through the session.
+	Prefixes []string `json:"prefixes"`
+
+	// TrafficMatcher The conditions the IP traffic must satisfy to use the session.
+	TrafficMatcher string `json:"traffic_matcher"`
+}
+
+// SessionsResponse defines model for SessionsResponse.
+type SessionsResponse struct {
+	Sessions *[]Session `json:"sessions,omitempty"`
+}
+
 // StandardError defines model for StandardError.
 type StandardError struct {
 	// Error Error message
@@ -28,5 +183,11 @@ type StandardError struct {
 // BadRequest defines model for BadRequest.
 type BadRequest = StandardError
 
+// GetSessionsParams defines parameters for GetSessions.
+type GetSessionsParams struct {
+	// IsdAs Only list the sessions to this remote ISD-AS.
+	IsdAs *IsdAs `form:"isd_as,omitempty" json:"isd_as,omitempty"`
+}
+
 // SetLogLevelJSONRequestBody defines body for SetLogLevel for application/json ContentType.
 type SetLogLevelJSONRequestBody = LogLevel
@@ -16,9 +16,10 @@ package pathhealth
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"net"
 	"net/netip"
+	"slices"
 	"sync"
 	"time"
 
@@ -26,16 +27,20 @@ import (
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/metrics"
 	metrics2 "github.com/scionproto/scion/pkg/metrics/v2"
-	"github.com/scionproto/scion/pkg/private/common"
 	"github.com/scionproto/scion/pkg/private/serrors"
-	"github.com/scionproto/scion/pkg/slayers/path/scion"
 	"github.com/scionproto/scion/pkg/snet"
-	snetpath "github.com/scionproto/scion/pkg/snet/path"
+	snetprobe "github.com/scionproto/scion/pkg/snet/probe"
 )
 
 const (
 	// defaultProbeInterval specifies how often should path probes be sent.
 	defaultProbeInterval = 500 * time.Millisecond
+	// probeTimeout is the time after which an unanswered probe is considered
+	// lost.
+	probeTimeout = 2 * defaultProbeInterval
+	// probeHistory is the number of the most recent probes that are used to
+	// compute the path statistics.
+	probeHistory = 20
 )
 
 // DefaultPathWatcherFactory creates PathWatchers.
@@ -73,7 +78,6 @@ func (f *DefaultPathWatcherFactory) New(
 	path snet.Path,
 ) (PathWatcher, error) {
 
-	pktChan := make(chan traceroutePkt, 10)
 	createCounter := func(
 		create func(addr.IA) metrics.Counter, remote addr.IA,
 	) metrics.Counter {
@@ -82,30 +86,21 @@ func (f *DefaultPathWatcherFactory) New(
 		}
 		return create(remote)
 	}
-	conn, err := (&snet.SCIONNetwork{
-		SCMPHandler: scmpHandler{
-			wrappedHandler: snet.DefaultSCMPHandler{
-				RevocationHandler: f.RevocationHandler,
-				SCMPErrors:        f.SCMPErrors,
-			},
-			pkts: pktChan,
-		},
-		PacketConnMetrics: f.SCIONPacketConnMetrics,
+	prober, err := snetprobe.New(ctx, snetprobe.Config{
 		Topology:          f.Topology,
-	}).OpenRaw(ctx, &net.UDPAddr{IP: f.LocalIP.AsSlice()})
+		LocalIA:           f.LocalIA,
+		LocalIP:           f.LocalIP,
+		RevocationHandler: f.RevocationHandler,
+		SCMPErrors:        f.SCMPErrors,
+		PacketConnMetrics: f.SCIONPacketConnMetrics,
+	})
 	if err != nil {
-		return nil, serrors.Wrap("creating connection for probing", err)
+		return nil, serrors.Wrap("creating prober", err)
 	}
 	return &pathWatcher{
-		remote:        remote,
-		probeInterval: f.ProbeInterval,
-		conn:          conn,
-		id:            uint16(conn.LocalAddr().(*net.UDPAddr).Port),
-		localAddr: snet.SCIONAddress{
-			IA:   f.LocalIA,
-			Host: addr.HostIP(f.LocalIP),
-		},
-		pktChan:          pktChan,
+		remote:           remote,
+		probeInterval:    f.ProbeInterval,
+		prober:           prober,
 		probesSent:       createCounter(f.ProbesSent, remote),
 		probesReceived:   createCounter(f.ProbesReceived, remote),
 		probesSendErrors: createCounter(f.ProbesSendErrors, remote),
@@ -119,31 +114,22 @@ type pathWatcher struct {
 	// probeInterval defines the interval at which probes are sent. If it is not
 	// set a default is used.
 	probeInterval time.Duration
-	// conn is the packet conn used to send probes on. The pathwatcher takes
-	// ownership and will close it on termination.
-	conn snet.PacketConn
-	// id is used as SCMP traceroute ID. Since each pathwatcher should have it's
-	// own high port this value can be random.
-	id uint16
-	// localAddr is the local address used in the probe packet.
-	localAddr snet.SCIONAddress
-	// pktChan is the channel which provides the incoming packets on the
-	// connection.
-	pktChan <-chan traceroutePkt
+	// prober sends the probes. The pathwatcher takes ownership and will close
+	// it on termination.
+	prober *snetprobe.Prober
 
 	probesSent       metrics.Counter
 	probesReceived   metrics.Counter
 	probesSendErrors metrics.Counter
 
-	// nextSeq is the sequence number to use for the next probe.
-	// Assuming 2 probes a second, this will wrap over in ~9hrs.
+	// nextSeq is the sequence number to use for the next probe in the path
+	// state. Assuming 2 probes a second, this will wrap over in ~9hrs.
 	nextSeq   uint16
 	pathState pathState
 	pathMtx   sync.RWMutex
 	path      pathWrap
-	// packet is the snet packet used to send probes. It is re-used so that we
-	// don't allocate a fresh one (and a buffer internally) for every send.
-	packet *snet.Packet
+	// probes tracks the probes in flight.
+	probes sync.WaitGroup
 }
 
 func (w *pathWatcher) Run(ctx context.Context) {
@@ -151,16 +137,7 @@ func (w *pathWatcher) Run(ctx context.Context) {
 	ctx, logger := log.WithLabels(
 		ctx,
 		"debug_id", log.NewDebugID().String(),
-		"id", w.id,
 	)
-	var wg sync.WaitGroup
-	wg.Add(1)
-	go func() {
-		defer log.HandlePanic()
-		defer wg.Done()
-
-		w.drainConn(ctx)
-	}()
 
 	logger.Info("Starting path watcher", "path", fmt.Sprint(w.path.Path))
 	defer logger.Info("Stopped path watcher")
@@ -169,16 +146,13 @@ func (w *pathWatcher) Run(ctx context.Context) {
 	defer probeTicker.Stop()
 	for {
 		select {
-		case <-w.pktChan:
-			metrics.CounterInc(w.probesReceived)
-			w.pathState.receiveProbe(time.Now())
 		case <-probeTicker.C:
 			w.sendProbe(ctx)
 		case <-ctx.Done():
-			// signal termination to connection drainer and then wait for it to
-			// finish
-			w.conn.Close()
-			wg.Wait()
+			// wait for the probes in flight to be cancelled and then close the
+			// prober.
+			w.probes.Wait()
+			w.prober.Close()
 			return
 		}
 	}
@@ -218,76 +192,68 @@ func (w *pathWatcher) State() State {
 			IsExpired: true,
 		}
 	}
+	latency, jitter, dropRate := w.pathState.stats(now)
 	return State{
-		IsAlive: w.pathState.active(),
+		IsAlive:  w.pathState.active(),
+		Latency:  latency,
+		Jitter:   jitter,
+		DropRate: dropRate,
 	}
 }
 
 func (w *pathWatcher) initDefaults() {
 	w.probeInterval = defaultProbeInterval
-	w.packet = &snet.Packet{}
-}
-
-func (w *pathWatcher) drainConn(ctx context.Context) {
-	logger := log.FromCtx(ctx)
-	var pkt snet.Packet
-	var ov net.UDPAddr
-	for {
-		err := w.conn.ReadFrom(&pkt, &ov)
-		// This avoids logging errors for closing connections.
-		if ctx.Err() != nil {
-			return
-		}
-		if err != nil {
-			if _, ok := err.(*snet.OpError); ok {
-				// ignore SCMP errors they are already dealt with in the SCMP
-				// handler.
-				continue
-			}
-			logger.Info("Unexpected error when reading probe reply", "err", err)
-		}
-	}
 }
 
+// sendProbe sends a probe on the path in the background. The answer is
+// recorded in the path state; a probe that is not answered within probeTimeout
+// is lost.
 func (w *pathWatcher) sendProbe(ctx context.Context) {
 	w.pathMtx.RLock()
 	defer w.pathMtx.RUnlock()
 
-	w.pathState.sendProbe(time.Now())
 	w.nextSeq++
+	seq := w.nextSeq
+	w.pathState.sendProbe(time.Now(), seq)
 	metrics.CounterInc(w.probesSent)
 	logger := log.FromCtx(ctx)
-	if err := w.prepareProbePacket(); err != nil {
+	if err := w.checkPath(); err != nil {
 		metrics.CounterInc(w.probesSendErrors)
 		logger.Info("Failed to create path probe packet", "err", err)
 		return
 	}
-	if err := w.conn.WriteTo(w.packet, w.path.UnderlayNextHop()); err != nil {
-		metrics.CounterInc(w.probesSendErrors)
-		logger.Error("Failed to send path probe", "err", err)
-	}
+	path := w.path.Path
+	w.probes.Add(1)
+	go func() {
+		defer log.HandlePanic()
+		defer w.probes.Done()
+
+		probeCtx, cancel := context.WithTimeout(ctx, probeTimeout)
+		defer cancel()
+		_, err := w.prober.Probe(probeCtx, path)
+		var opErr *snet.OpError
+		switch {
+		case err == nil:
+			metrics.CounterInc(w.probesReceived)
+			w.pathState.receiveProbe(time.Now(), seq)
+		case ctx.Err() != nil, errors.Is(err, context.DeadlineExceeded):
+			// The probe is lost.
+		case errors.As(err, &opErr):
+			// The revocation is already dealt with by the revocation handler.
+		default:
+			metrics.CounterInc(w.probesSendErrors)
+			logger.Error("Failed to send path probe", "err", err)
+		}
+	}()
 }
 
-func (w *pathWatcher) prepareProbePacket() error {
+func (w *pathWatcher) checkPath() error {
 	if err := w.path.err; err != nil {
 		return err
 	}
 	if w.path.expiry.Before(time.Now()) {
 		return serrors.New("expired path", "expiration", w.path.expiry)
 	}
-	w.packet.PacketInfo = snet.PacketInfo{
-		Destination: snet.SCIONAddress{
-			IA: w.remote,
-			// The host doesn't really matter because it's terminated at the router.
-			Host: addr.HostSVC(addr.SvcNone),
-		},
-		Source: w.localAddr,
-		Path:   w.path.dpPath,
-		Payload: snet.SCMPTracerouteRequest{
-			Identifier: w.id,
-			Sequence:   w.nextSeq,
-		},
-	}
 	return nil
 }
 
@@ -295,25 +261,48 @@ type pathState struct {
 	mu                sync.Mutex
 	consecutiveProbes int
 	lastReceived      time.Time
+	// probes is a ring buffer of the most recently sent probes. They are used
+	// to compute the path statistics.
+	probes [probeHistory]probe
+	// next is the index in probes at which the next probe is recorded.
+	next int
+}
+
+// probe is a probe that was sent on the path.
+type probe struct {
+	seq      uint16
+	sent     time.Time
+	received bool
+	rtt      time.Duration
 }
 
-func (s *pathState) sendProbe(now time.Time) {
+func (s *pathState) sendProbe(now time.Time, seq uint16) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.probes[s.next] = probe{seq: seq, sent: now}
+	s.next = (s.next + 1) % probeHistory
 	// Probe timed out.
-	if s.lastReceived.Add(defaultProbeInterval * 2).Before(now) {
+	if s.lastReceived.Add(probeTimeout).Before(now) {
 		s.consecutiveProbes = 0
 		return
 	}
 }
 
-func (s *pathState) receiveProbe(now time.Time) {
+func (s *pathState) receiveProbe(now time.Time, seq uint16) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.lastReceived = now
 	if s.consecutiveProbes < 3 {
 		s.consecutiveProbes++
 	}
+	for i := range s.probes {
+		p := &s.probes[i]
+		if p.seq == seq && !p.sent.IsZero() && !p.received {
+			p.received = true
+			p.rtt = now.Sub(p.sent)
+			return
+		}
+	}
 }
 
 func (s *pathState) active() bool {
@@ -322,6 +311,51 @@ func (s *pathState) active() bool {
 	return s.consecutiveProbes == 3
 }
 
+// stats computes the latency, the jitter, and the drop rate of the path from
+// the most recent probes. The latency is the median of the round-trip times
+// halved, the jitter is the average difference between consecutive round-trip
+// times halved. Probes that were sent less than probeTimeout ago and are not
+// answered yet are ignored.
+func (s *pathState) stats(now time.Time) (time.Duration, time.Duration, float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rtts := make([]time.Duration, 0, probeHistory)
+	var answered, lost int
+	// Iterate from the oldest to the most recent probe.
+	for i := range probeHistory {
+		p := s.probes[(s.next+i)%probeHistory]
+		switch {
+		case p.sent.IsZero():
+		case p.received:
+			answered++
+			rtts = append(rtts, p.rtt)
+		case now.Sub(p.sent) >= probeTimeout:
+			lost++
+		}
+	}
+	var dropRate float64
+	if answered+lost > 0 {
+		dropRate = float64(lost) / float64(answered+lost)
+	}
+	if len(rtts) == 0 {
+		return 0, 0, dropRate
+	}
+	var jitter time.Duration
+	for i := 1; i < len(rtts); i++ {
+		d := rtts[i] - rtts[i-1]
+		if d < 0 {
+			d = -d
+		}
+		jitter += d
+	}
+	if len(rtts) > 1 {
+		jitter /= time.Duration(2 * (len(rtts) - 1))
+	}
+	slices.Sort(rtts)
+	return rtts[len(rtts)/2] / 2, jitter, dropRate
+}
+
 // pathWrap is the monitored pathWrap it already contains a few precalculated values to
 // prevent too much repeated work.
 type pathWrap struct {
@@ -329,7 +363,6 @@ type pathWrap struct {
 	snet.Path
 	fingerprint snet.PathFingerprint
 	expiry      time.Time
-	dpPath      snet.DataplanePath
 	err         error
 }
 
@@ -339,32 +372,8 @@ func createPathWrap(path snet.Path) pathWrap {
 		fingerprint: path.Metadata().Fingerprint(),
 		expiry:      path.Metadata().Expiry,
 	}
-
-	original, ok := p.Dataplane().(snetpath.SCION)
-	if !ok {
-		p.err = serrors.New("not a scion path", "type", common.TypeOf(p.Dataplane()))
-		return p
-	}
-
-	var decoded scion.Decoded
-	if err := decoded.DecodeFromBytes(original.Raw); err != nil {
-		p.err = serrors.Wrap("decoding path", err)
-		return p
-	}
-	if len(decoded.InfoFields) > 0 {
-		info := decoded.InfoFields[len(decoded.InfoFields)-1]
-		if info.ConsDir {
-			decoded.HopFields[len(decoded.HopFields)-1].IngressRouterAlert = true
-		} else {
-			decoded.HopFields[len(decoded.HopFields)-1].EgressRouterAlert = true
-		}
-	}
-
-	alert, err := snetpath.NewSCIONFromDecoded(decoded)
-	if err != nil {
-		p.err = serrors.Wrap("serializing path", err)
-		return p
+	if _, err := snetprobe.WithRouterAlert(p.Dataplane()); err != nil {
+		p.err = err
 	}
-	p.dpPath = alert
 	return p
 }
@@ -0,0 +1,64 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathhealth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathStateStats(t *testing.T) {
+	var s pathState
+	now := time.Now()
+
+	latency, jitter, dropRate := s.stats(now)
+	assert.Zero(t, latency)
+	assert.Zero(t, jitter)
+	assert.Zero(t, dropRate)
+
+	// Round-trip times of 10ms, 20ms, 30ms, one lost probe and one probe in
+	// flight.
+	rtts := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 30 * time.Millisecond}
+	for i, rtt := range rtts {
+		sent := now.Add(time.Duration(i) * time.Second)
+		s.sendProbe(sent, uint16(i))
+		s.receiveProbe(sent.Add(rtt), uint16(i))
+	}
+	s.sendProbe(now.Add(3*time.Second), 3)
+	s.sendProbe(now.Add(10*time.Second), 4)
+
+	latency, jitter, dropRate = s.stats(now.Add(10 * time.Second))
+	assert.Equal(t, 10*time.Millisecond, latency)
+	assert.Equal(t, 5*time.Millisecond, jitter)
+	assert.Equal(t, 0.25, dropRate)
+
+	// Late and duplicate replies do not change the statistics.
+	s.receiveProbe(now.Add(20*time.Second), 2)
+	latency, _, _ = s.stats(now.Add(10 * time.Second))
+	assert.Equal(t, 10*time.Millisecond, latency)
+
+	// Only the most recent probes are taken into account.
+	for i := range probeHistory {
+		sent := now.Add(time.Duration(20+i) * time.Second)
+		s.sendProbe(sent, uint16(100+i))
+		s.receiveProbe(sent.Add(2*time.Millisecond), uint16(100+i))
+	}
+	latency, jitter, dropRate = s.stats(now.Add(time.Minute))
+	assert.Equal(t, time.Millisecond, latency)
+	assert.Zero(t, jitter)
+	assert.Zero(t, dropRate)
+}
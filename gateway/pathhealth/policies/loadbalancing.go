@@ -0,0 +1,82 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policies
+
+import (
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// LoadBalancing determines how the traffic of a session is spread over the
+// paths of the session. All strategies keep a flow, i.e., the packets with the
+// same quintuple, on the same path as long as that path is in use.
+type LoadBalancing uint8
+
+// The supported load balancing strategies.
+const (
+	// LoadBalancingHash spreads the flows evenly over the paths.
+	LoadBalancingHash LoadBalancing = iota
+	// LoadBalancingBandwidth spreads the flows proportionally to the bandwidth
+	// advertised in the path metadata.
+	LoadBalancingBandwidth
+	// LoadBalancingHealth spreads the flows proportionally to the measured
+	// health of the paths, i.e., paths with a lower latency and drop rate get
+	// more flows.
+	LoadBalancingHealth
+	// LoadBalancingActiveStandby sends all flows over a single path. The other
+	// paths are only used once the active path is no longer available.
+	LoadBalancingActiveStandby
+)
+
+var loadBalancingNames = map[LoadBalancing]string{
+	LoadBalancingHash:          "hash",
+	LoadBalancingBandwidth:     "bandwidth",
+	LoadBalancingHealth:        "health",
+	LoadBalancingActiveStandby: "active-standby",
+}
+
+// ParseLoadBalancing parses the name of a load balancing strategy.
+func ParseLoadBalancing(name string) (LoadBalancing, error) {
+	for lb, n := range loadBalancingNames {
+		if n == name {
+			return lb, nil
+		}
+	}
+	return LoadBalancingHash, serrors.New("unknown load balancing strategy", "name", name)
+}
+
+func (lb LoadBalancing) String() string {
+	if name, ok := loadBalancingNames[lb]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (lb LoadBalancing) MarshalText() ([]byte, error) {
+	if _, ok := loadBalancingNames[lb]; !ok {
+		return nil, serrors.New("unknown load balancing strategy", "value", uint8(lb))
+	}
+	return []byte(lb.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (lb *LoadBalancing) UnmarshalText(text []byte) error {
+	parsed, err := ParseLoadBalancing(string(text))
+	if err != nil {
+		return err
+	}
+	*lb = parsed
+	return nil
+}
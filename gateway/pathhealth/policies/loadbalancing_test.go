@@ -0,0 +1,45 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policies_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/gateway/pathhealth/policies"
+)
+
+func TestLoadBalancingText(t *testing.T) {
+	for _, lb := range []policies.LoadBalancing{
+		policies.LoadBalancingHash,
+		policies.LoadBalancingBandwidth,
+		policies.LoadBalancingHealth,
+		policies.LoadBalancingActiveStandby,
+	} {
+		t.Run(lb.String(), func(t *testing.T) {
+			text, err := lb.MarshalText()
+			require.NoError(t, err)
+			var parsed policies.LoadBalancing
+			require.NoError(t, parsed.UnmarshalText(text))
+			assert.Equal(t, lb, parsed)
+		})
+	}
+	_, err := policies.ParseLoadBalancing("round-robin")
+	assert.Error(t, err)
+	_, err = policies.LoadBalancing(42).MarshalText()
+	assert.Error(t, err)
+}
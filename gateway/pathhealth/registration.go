@@ -16,7 +16,9 @@ package pathhealth
 
 import (
 	"sync"
+	"time"
 
+	"github.com/scionproto/scion/gateway/pathhealth/policies"
 	"github.com/scionproto/scion/pkg/snet"
 )
 
@@ -27,6 +29,13 @@ type State struct {
 	// IsExpired indicates that the path is expired. IsExpired == true implies IsAlive == false but
 	// not vice versa.
 	IsExpired bool
+	// Latency is the median one-way latency measured with the recent probes.
+	Latency time.Duration
+	// Jitter is the average difference between consecutive one-way latencies
+	// measured with the recent probes.
+	Jitter time.Duration
+	// DropRate is the fraction of the recent probes that were not answered.
+	DropRate float64
 }
 
 // Selectable is a subset of the PathWatcher that is used for path selection.
@@ -49,6 +58,8 @@ type PathInfoEntry struct {
 	RejectReason string
 	Current      bool
 	Revoked      bool
+	// Stats are the statistics of the path at the time of the selection.
+	Stats policies.Stats
 }
 
 // PathInfo contains debug info about onging path monitoring.
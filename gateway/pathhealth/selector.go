@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/scionproto/scion/gateway/pathhealth/policies"
 	"github.com/scionproto/scion/pkg/snet"
 )
 
@@ -51,31 +52,45 @@ func (f *FilteringPathSelector) Select(selectables []Selectable, current Fingerp
 		Selectable  Selectable
 		IsCurrent   bool
 		IsRevoked   bool
+		Stats       policies.Stats
+	}
+	type Excluded struct {
+		Path  snet.Path
+		Stats policies.Stats
 	}
 
 	// Sort out the paths allowed by the path policy.
 	var allowed []Allowed
-	var dead []snet.Path
-	var rejected []snet.Path
+	var dead []Excluded
+	var rejected []Excluded
 	for _, selectable := range selectables {
 		path := selectable.Path()
+		state := selectable.State()
+		fingerprint := path.Metadata().Fingerprint()
+		_, isCurrent := current[fingerprint]
+		stats := policies.Stats{
+			Fingerprint: fingerprint,
+			Latency:     state.Latency,
+			Jitter:      state.Jitter,
+			DropRate:    state.DropRate,
+			IsAlive:     state.IsAlive,
+			IsCurrent:   isCurrent,
+			IsRevoked:   f.RevocationStore.IsRevoked(path),
+		}
 		if !isPathAllowed(f.PathPolicy, path) {
-			rejected = append(rejected, path)
+			rejected = append(rejected, Excluded{Path: path, Stats: stats})
 			continue
 		}
-
-		state := selectable.State()
 		if !state.IsAlive {
-			dead = append(dead, path)
+			dead = append(dead, Excluded{Path: path, Stats: stats})
 			continue
 		}
-		fingerprint := path.Metadata().Fingerprint()
-		_, isCurrent := current[fingerprint]
 		allowed = append(allowed, Allowed{
 			Path:        path,
 			Fingerprint: fingerprint,
 			IsCurrent:   isCurrent,
-			IsRevoked:   f.RevocationStore.IsRevoked(path),
+			IsRevoked:   stats.IsRevoked,
+			Stats:       stats,
 		})
 	}
 	// Sort the allowed paths according the the perf policy.
@@ -100,20 +115,23 @@ func (f *FilteringPathSelector) Select(selectables []Selectable, current Fingerp
 			Current: a.IsCurrent,
 			Revoked: a.IsRevoked,
 			Path:    fmt.Sprintf("%s", a.Path),
+			Stats:   a.Stats,
 		})
 	}
-	for _, path := range dead {
+	for _, e := range dead {
 		pathInfo = append(pathInfo, PathInfoEntry{
 			Rejected:     true,
 			RejectReason: deadInfo,
-			Path:         fmt.Sprintf("%s", path),
+			Path:         fmt.Sprintf("%s", e.Path),
+			Stats:        e.Stats,
 		})
 	}
-	for _, path := range rejected {
+	for _, e := range rejected {
 		pathInfo = append(pathInfo, PathInfoEntry{
 			Rejected:     true,
 			RejectReason: rejectedInfo,
-			Path:         fmt.Sprintf("%s", path),
+			Path:         fmt.Sprintf("%s", e.Path),
+			Stats:        e.Stats,
 		})
 	}
 
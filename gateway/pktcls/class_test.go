@@ -75,6 +75,43 @@ func TestClassMapMarshalUnMarshal(t *testing.T) {
 				),
 			},
 		},
+		{
+			Name:     "IPv6 and ICMP",
+			FileName: "class_3",
+			Classes: pktcls.ClassMap{
+				"IPv6": pktcls.NewClass(
+					"IPv6",
+					pktcls.NewCondAllOf(
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchSource{
+							Net: &net.IPNet{
+								IP:   net.ParseIP("2001:db8::"),
+								Mask: net.CIDRMask(32, 128),
+							},
+						}),
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchDestination{
+							Net: &net.IPNet{
+								IP:   net.ParseIP("fd00::"),
+								Mask: net.CIDRMask(8, 128),
+							},
+						}),
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchTrafficClass{TrafficClass: 0xb8}),
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchFlowLabel{FlowLabel: 0x12345}),
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchNextHeader{NextHeader: 6}),
+						pktcls.NewCondPorts(&pktcls.PortMatchDestination{
+							MinPort: 443,
+							MaxPort: 443,
+						}),
+					),
+				),
+				"ICMP": pktcls.NewClass(
+					"ICMP",
+					pktcls.NewCondAnyOf(
+						pktcls.NewCondIPv4(&pktcls.IPv4MatchICMPType{ICMPType: 8}),
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchICMPType{ICMPType: 128}),
+					),
+				),
+			},
+		},
 		{
 			Name:     "nil ClassMap stays nil",
 			FileName: "class_2",
@@ -228,6 +265,33 @@ func TestClassUnmarshalError(t *testing.T) {
 			},
 			"Name": "Unable to parse source operand string"
 		}
+		`, `
+		{
+			"CondIPv6": {
+				"MatchIPv6Source": {
+					"Net": "10.0.0.0/8"
+				}
+			},
+			"Name": "IPv4 network in IPv6 source operand"
+		}
+		`, `
+		{
+			"CondIPv6": {
+				"MatchFlowLabel": {
+					"FlowLabel": "0x100000"
+				}
+			},
+			"Name": "Flow label out of range"
+		}
+		`, `
+		{
+			"CondIPv6": {
+				"MatchToS": {
+					"TOS": "0x80"
+				}
+			},
+			"Name": "IPv4 predicate in IPv6 condition"
+		}
 	`}
 	for i, tc := range testCases {
 		var c pktcls.Class
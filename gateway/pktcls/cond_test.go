@@ -155,6 +155,118 @@ func TestIPCond(t *testing.T) {
 			},
 			ExpEval: false,
 		},
+		{
+			Name: "IPv4 predicate does not match IPv6",
+			Cond: pktcls.NewCondIPv4(
+				&pktcls.IPv4MatchSource{
+					Net: &net.IPNet{
+						IP:   net.IP{0, 0, 0, 0},
+						Mask: net.IPv4Mask(0, 0, 0, 0),
+					},
+				},
+			),
+			Packet: &layers.IPv6{
+				SrcIP: net.ParseIP("2001:db8::1"),
+			},
+			ExpEval: false,
+		},
+		{
+			Name: "Match IPv6 source and destination",
+			Cond: pktcls.NewCondAllOf(
+				pktcls.NewCondIPv6(
+					&pktcls.IPv6MatchSource{
+						Net: &net.IPNet{
+							IP:   net.ParseIP("2001:db8::"),
+							Mask: net.CIDRMask(32, 128),
+						},
+					},
+				),
+				pktcls.NewCondIPv6(
+					&pktcls.IPv6MatchDestination{
+						Net: &net.IPNet{
+							IP:   net.ParseIP("fd00::"),
+							Mask: net.CIDRMask(8, 128),
+						},
+					},
+				),
+			),
+			Packet: &layers.IPv6{
+				SrcIP: net.ParseIP("2001:db8::1"),
+				DstIP: net.ParseIP("fd12::2"),
+			},
+			ExpEval: true,
+		},
+		{
+			Name: "Match IPv6 traffic class and flow label",
+			Cond: pktcls.NewCondAllOf(
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchTrafficClass{TrafficClass: 0xb8}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchFlowLabel{FlowLabel: 0x12345}),
+			),
+			Packet: &layers.IPv6{
+				TrafficClass: 0xb8,
+				FlowLabel:    0x12345,
+			},
+			ExpEval: true,
+		},
+		{
+			Name: "Do not match IPv6 flow label",
+			Cond: pktcls.NewCondIPv6(&pktcls.IPv6MatchFlowLabel{FlowLabel: 0x12345}),
+			Packet: &layers.IPv6{
+				FlowLabel: 0x54321,
+			},
+			ExpEval: false,
+		},
+		{
+			Name: "Match IPv6 next header",
+			Cond: pktcls.NewCondIPv6(&pktcls.IPv6MatchNextHeader{NextHeader: 6}),
+			Packet: &layers.IPv6{
+				NextHeader: layers.IPProtocolTCP,
+			},
+			ExpEval: true,
+		},
+		{
+			Name: "Match IPv6 next header after hop-by-hop options",
+			Cond: pktcls.NewCondIPv6(&pktcls.IPv6MatchNextHeader{NextHeader: 17}),
+			Packet: &layers.IPv6{
+				NextHeader: layers.IPProtocolIPv6HopByHop,
+				HopByHop: func() *layers.IPv6HopByHop {
+					hbh := &layers.IPv6HopByHop{}
+					hbh.NextHeader = layers.IPProtocolUDP
+					return hbh
+				}(),
+			},
+			ExpEval: true,
+		},
+		{
+			Name:    "Match ICMPv4 type",
+			Cond:    pktcls.NewCondIPv4(&pktcls.IPv4MatchICMPType{ICMPType: 8}),
+			Packet:  createICMPv4Packet(layers.ICMPv4TypeEchoRequest),
+			ExpEval: true,
+		},
+		{
+			Name:    "Do not match ICMPv4 type",
+			Cond:    pktcls.NewCondIPv4(&pktcls.IPv4MatchICMPType{ICMPType: 8}),
+			Packet:  createICMPv4Packet(layers.ICMPv4TypeEchoReply),
+			ExpEval: false,
+		},
+		{
+			Name:    "Do not match ICMPv4 type on UDP",
+			Cond:    pktcls.NewCondIPv4(&pktcls.IPv4MatchICMPType{ICMPType: 0}),
+			Packet:  createUDPPacket(false, 0, 0),
+			ExpEval: false,
+		},
+		{
+			Name:    "Match ICMPv6 type",
+			Cond:    pktcls.NewCondIPv6(&pktcls.IPv6MatchICMPType{ICMPType: 128}),
+			Packet:  createICMPv6Packet(layers.ICMPv6TypeEchoRequest),
+			ExpEval: true,
+		},
+		{
+			Name:    "Do not match ICMPv6 type",
+			Cond:    pktcls.NewCondIPv6(&pktcls.IPv6MatchICMPType{ICMPType: 128}),
+			Packet:  createICMPv6Packet(layers.ICMPv6TypeEchoReply),
+			ExpEval: false,
+		},
 	}
 
 	for _, test := range testCases {
@@ -167,6 +279,8 @@ func TestIPCond(t *testing.T) {
 func TestPortCond(t *testing.T) {
 	testCases := map[string]struct {
 		Cond    pktcls.Cond
+		IPv6    bool
+		TCP     bool
 		SrcPort uint16
 		DstPort uint16
 		ExpEval bool
@@ -195,32 +309,136 @@ func TestPortCond(t *testing.T) {
 			DstPort: 200,
 			ExpEval: false,
 		},
+		"Match IPv6 UDP src port": {
+			Cond: pktcls.NewCondPorts(
+				&pktcls.PortMatchSource{
+					MinPort: 100,
+					MaxPort: 199,
+				},
+			),
+			IPv6:    true,
+			SrcPort: 100,
+			ExpEval: true,
+		},
+		"Match IPv4 TCP dst port": {
+			Cond: pktcls.NewCondPorts(
+				&pktcls.PortMatchDestination{
+					MinPort: 443,
+					MaxPort: 443,
+				},
+			),
+			TCP:     true,
+			DstPort: 443,
+			ExpEval: true,
+		},
+		"Match IPv6 TCP dst port": {
+			Cond: pktcls.NewCondPorts(
+				&pktcls.PortMatchDestination{
+					MinPort: 1000,
+					MaxPort: 2000,
+				},
+			),
+			IPv6:    true,
+			TCP:     true,
+			DstPort: 2000,
+			ExpEval: true,
+		},
+		"Do not match IPv6 TCP dst port": {
+			Cond: pktcls.NewCondPorts(
+				&pktcls.PortMatchDestination{
+					MinPort: 1000,
+					MaxPort: 2000,
+				},
+			),
+			IPv6:    true,
+			TCP:     true,
+			DstPort: 2001,
+			ExpEval: false,
+		},
 	}
 
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
-			pkt := createUDPPacket(tc.SrcPort, tc.DstPort)
+			var pkt gopacket.Layer
+			if tc.TCP {
+				pkt = createTCPPacket(tc.IPv6, tc.SrcPort, tc.DstPort)
+			} else {
+				pkt = createUDPPacket(tc.IPv6, tc.SrcPort, tc.DstPort)
+			}
 			assert.Equal(t, tc.ExpEval, tc.Cond.Eval(pkt))
 		})
 	}
 }
 
-func createUDPPacket(src, dst uint16) gopacket.Layer {
-	ip := &layers.IPv4{
-		Version:  4,
-		IHL:      5,
-		TTL:      64,
-		SrcIP:    net.IP{192, 168, 14, 3},
-		DstIP:    net.IP{192, 168, 14, 2},
-		Protocol: layers.IPProtocolUDP,
-		Flags:    layers.IPv4DontFragment,
-	}
+func createUDPPacket(ipv6 bool, src, dst uint16) gopacket.Layer {
 	udp := &layers.UDP{
 		SrcPort: layers.UDPPort(src),
 		DstPort: layers.UDPPort(dst),
 	}
-	_ = udp.SetNetworkLayerForChecksum(ip)
+	return createPacket(ipv6, layers.IPProtocolUDP, udp)
+}
+
+func createTCPPacket(ipv6 bool, src, dst uint16) gopacket.Layer {
+	tcp := &layers.TCP{
+		SrcPort: layers.TCPPort(src),
+		DstPort: layers.TCPPort(dst),
+		Window:  1024,
+		SYN:     true,
+	}
+	return createPacket(ipv6, layers.IPProtocolTCP, tcp)
+}
+
+func createICMPv4Packet(typ uint8) gopacket.Layer {
+	icmp := &layers.ICMPv4{
+		TypeCode: layers.CreateICMPv4TypeCode(typ, 0),
+	}
+	return createPacket(false, layers.IPProtocolICMPv4, icmp)
+}
+
+func createICMPv6Packet(typ uint8) gopacket.Layer {
+	icmp := &layers.ICMPv6{
+		TypeCode: layers.CreateICMPv6TypeCode(typ, 0),
+	}
+	return createPacket(true, layers.IPProtocolICMPv6, icmp)
+}
+
+// createPacket serializes the L4 layer on top of an IPv4 or IPv6 header and
+// returns the decoded IP layer.
+func createPacket(ipv6 bool, proto layers.IPProtocol,
+	l4 gopacket.SerializableLayer) gopacket.Layer {
+
+	var ip gopacket.NetworkLayer
+	var pkt gopacket.DecodingLayer
+	if ipv6 {
+		ip = &layers.IPv6{
+			Version:    6,
+			HopLimit:   64,
+			SrcIP:      net.ParseIP("2001:db8::3"),
+			DstIP:      net.ParseIP("2001:db8::2"),
+			NextHeader: proto,
+		}
+		pkt = &layers.IPv6{}
+	} else {
+		ip = &layers.IPv4{
+			Version:  4,
+			IHL:      5,
+			TTL:      64,
+			SrcIP:    net.IP{192, 168, 14, 3},
+			DstIP:    net.IP{192, 168, 14, 2},
+			Protocol: proto,
+			Flags:    layers.IPv4DontFragment,
+		}
+		pkt = &layers.IPv4{}
+	}
+	switch l := l4.(type) {
+	case *layers.UDP:
+		_ = l.SetNetworkLayerForChecksum(ip)
+	case *layers.TCP:
+		_ = l.SetNetworkLayerForChecksum(ip)
+	case *layers.ICMPv6:
+		_ = l.SetNetworkLayerForChecksum(ip)
+	}
 	payload := []byte("payload")
 	input := gopacket.NewSerializeBuffer()
 	options := gopacket.SerializeOptions{
@@ -228,17 +446,17 @@ func createUDPPacket(src, dst uint16) gopacket.Layer {
 		ComputeChecksums: true,
 	}
 	if err := gopacket.SerializeLayers(input, options,
-		ip, udp, gopacket.Payload(payload)); err != nil {
+		ip.(gopacket.SerializableLayer), l4, gopacket.Payload(payload)); err != nil {
 		panic(err)
 	}
-	pkt := &layers.IPv4{}
 	if err := pkt.DecodeFromBytes(input.Bytes(), gopacket.NilDecodeFeedback); err != nil {
 		panic(err)
 	}
-	return pkt
+	return pkt.(gopacket.Layer)
 }
 
 func TestStringer(t *testing.T) {
+	_, net6, _ := net.ParseCIDR("2001:db8::/32")
 	_, net, _ := net.ParseCIDR("12.12.12.0/26")
 	tests := map[string]struct {
 		Cond pktcls.Cond
@@ -265,6 +483,29 @@ func TestStringer(t *testing.T) {
 				},
 			},
 		},
+		"IPv6 and ICMP": {
+			Str: "all(src=2001:db8::/32,dst=2001:db8::/32,tc=0xb8,flowlabel=0x12345," +
+				"nextheader=ICMPv6,icmp6type=128,any(protocol=ICMPv4,icmptype=8))",
+			Cond: pktcls.CondAllOf{
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchSource{Net: net6}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchDestination{Net: net6}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchTrafficClass{TrafficClass: 0xb8}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchFlowLabel{FlowLabel: 0x12345}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchNextHeader{NextHeader: 58}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchICMPType{ICMPType: 128}),
+				pktcls.CondAnyOf{
+					pktcls.NewCondIPv4(&pktcls.IPv4MatchProtocol{Protocol: 1}),
+					pktcls.NewCondIPv4(&pktcls.IPv4MatchICMPType{ICMPType: 8}),
+				},
+			},
+		},
+		"ports": {
+			Str: "any(srcport=1-1024,dstport=443-443)",
+			Cond: pktcls.CondAnyOf{
+				pktcls.NewCondPorts(&pktcls.PortMatchSource{MinPort: 1, MaxPort: 1024}),
+				pktcls.NewCondPorts(&pktcls.PortMatchDestination{MinPort: 443, MaxPort: 443}),
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
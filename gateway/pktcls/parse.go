@@ -71,24 +71,28 @@ func (l *classListener) EnterTrafficClass(ctx *traffic_class.TrafficClassContext
 
 func (l *classListener) EnterMatchDst(ctx *traffic_class.MatchDstContext) {
 	// Push Selector as Predicate on stack and update the number of Conds on the stack
-	var err error
-	mdst := &IPv4MatchDestination{}
-	_, mdst.Net, err = net.ParseCIDR(ctx.GetStop().GetText())
+	network, err := parseNetwork(ctx.GetStop().GetText(), ctx.NET6() != nil)
 	if err != nil {
-		l.err = serrors.Wrap("CIDR parsing failed!", err, "cidr", ctx.GetStop().GetText())
+		l.err = err
 	}
-	l.pushCond(NewCondIPv4(mdst))
+	if ctx.NET6() != nil {
+		l.pushCond(NewCondIPv6(&IPv6MatchDestination{Net: network}))
+		return
+	}
+	l.pushCond(NewCondIPv4(&IPv4MatchDestination{Net: network}))
 }
 
 func (l *classListener) EnterMatchSrc(ctx *traffic_class.MatchSrcContext) {
 	// Push Selector as Predicate on stack and update the number of Conds on the stack
-	var err error
-	msrc := &IPv4MatchSource{}
-	_, msrc.Net, err = net.ParseCIDR(ctx.GetStop().GetText())
+	network, err := parseNetwork(ctx.GetStop().GetText(), ctx.NET6() != nil)
 	if err != nil {
-		l.err = serrors.Wrap("CIDR parsing failed!", err, "cidr", ctx.GetStop().GetText())
+		l.err = err
+	}
+	if ctx.NET6() != nil {
+		l.pushCond(NewCondIPv6(&IPv6MatchSource{Net: network}))
+		return
 	}
-	l.pushCond(NewCondIPv4(msrc))
+	l.pushCond(NewCondIPv4(&IPv4MatchSource{Net: network}))
 }
 
 func (l *classListener) EnterMatchDSCP(ctx *traffic_class.MatchDSCPContext) {
@@ -126,6 +130,65 @@ func (l *classListener) EnterMatchProtocol(ctx *traffic_class.MatchProtocolConte
 	l.pushCond(NewCondIPv4(prot))
 }
 
+func (l *classListener) EnterMatchICMPType(ctx *traffic_class.MatchICMPTypeContext) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	micmp := &IPv4MatchICMPType{}
+	icmpType, err := strconv.ParseUint(ctx.GetStop().GetText(), 10, 8)
+	if err != nil {
+		l.err = serrors.Wrap("ICMPTYPE parsing failed!", err,
+			"icmptype", ctx.GetStop().GetText())
+	}
+	micmp.ICMPType = uint8(icmpType)
+	l.pushCond(NewCondIPv4(micmp))
+}
+
+func (l *classListener) EnterMatchTC(ctx *traffic_class.MatchTCContext) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	mtc := &IPv6MatchTrafficClass{}
+	tc, err := strconv.ParseUint(ctx.GetStop().GetText(), 16, 8)
+	if err != nil {
+		l.err = serrors.Wrap("TC parsing failed!", err, "tc", ctx.GetStop().GetText())
+	}
+	mtc.TrafficClass = uint8(tc)
+	l.pushCond(NewCondIPv6(mtc))
+}
+
+func (l *classListener) EnterMatchFlowLabel(ctx *traffic_class.MatchFlowLabelContext) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	mfl := &IPv6MatchFlowLabel{}
+	fl, err := strconv.ParseUint(ctx.GetStop().GetText(), 16, 20)
+	if err != nil {
+		l.err = serrors.Wrap("FLOWLABEL parsing failed!", err,
+			"flowlabel", ctx.GetStop().GetText())
+	}
+	mfl.FlowLabel = uint32(fl)
+	l.pushCond(NewCondIPv6(mfl))
+}
+
+func (l *classListener) EnterMatchNextHeader(ctx *traffic_class.MatchNextHeaderContext) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	mnh := &IPv6MatchNextHeader{}
+	number, err := protocolNameToNumber(ctx.GetStop().GetText())
+	if err != nil {
+		l.err = serrors.Wrap("NEXTHEADER parsing failed!", err,
+			"nextheader", ctx.GetStop().GetText())
+	}
+	mnh.NextHeader = number
+	l.pushCond(NewCondIPv6(mnh))
+}
+
+func (l *classListener) EnterMatchICMP6Type(ctx *traffic_class.MatchICMP6TypeContext) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	micmp := &IPv6MatchICMPType{}
+	icmpType, err := strconv.ParseUint(ctx.GetStop().GetText(), 10, 8)
+	if err != nil {
+		l.err = serrors.Wrap("ICMP6TYPE parsing failed!", err,
+			"icmp6type", ctx.GetStop().GetText())
+	}
+	micmp.ICMPType = uint8(icmpType)
+	l.pushCond(NewCondIPv6(micmp))
+}
+
 func (l *classListener) EnterMatchSrcPort(ctx *traffic_class.MatchSrcPortContext) {
 	// Push Selector as Predicate on stack and update the number of Conds on the stack
 	src := &PortMatchSource{}
@@ -286,6 +349,19 @@ func buildTrafficClassParser(class string) *traffic_class.TrafficClassParser {
 	return parser
 }
 
+// parseNetwork parses a CIDR network and checks that its address family matches
+// the lexer token it was parsed from.
+func parseNetwork(cidr string, ipv6 bool) (*net.IPNet, error) {
+	ip, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, serrors.Wrap("CIDR parsing failed!", err, "cidr", cidr)
+	}
+	if ipv6 && ip.To4() != nil {
+		return nil, serrors.New("IPv4-mapped IPv6 networks are not supported", "cidr", cidr)
+	}
+	return network, nil
+}
+
 // protocolNameToNumber converts protocol name (e.g. "TCP") to IP protcol
 // number. The function is case insensitive.
 func protocolNameToNumber(name string) (uint8, error) {
@@ -119,6 +119,76 @@ func TestTrafficClassValidation(t *testing.T) {
 			Class: "ANY(dscp=0x2,ALL(dst=12.12.12.0/24,dscp=0x2, NOT(src=2.2.2.0/28)))",
 			Valid: true,
 		},
+		{
+			Name:  "src IPv6Cond",
+			Class: "src=2001:db8::/32",
+			Valid: true,
+		},
+		{
+			Name:  "dst IPv6Cond",
+			Class: "dst=::1/128",
+			Valid: true,
+		},
+		{
+			Name:  "bad dst IPv6Cond",
+			Class: "dst=2001:db8::",
+			Valid: false,
+		},
+		{
+			Name:  "IPv4-mapped dst IPv6Cond",
+			Class: "dst=::ffff:10.0.0.1/128",
+			Valid: false,
+		},
+		{
+			Name:  "tc IPv6Cond",
+			Class: "tc=0xb8",
+			Valid: true,
+		},
+		{
+			Name:  "bad tc IPv6Cond",
+			Class: "tc=0x100",
+			Valid: false,
+		},
+		{
+			Name:  "flowlabel IPv6Cond",
+			Class: "flowlabel=0xfffff",
+			Valid: true,
+		},
+		{
+			Name:  "bad flowlabel IPv6Cond",
+			Class: "flowlabel=0x100000",
+			Valid: false,
+		},
+		{
+			Name:  "nextheader IPv6Cond",
+			Class: "nextheader=ICMPv6",
+			Valid: true,
+		},
+		{
+			Name:  "nextheader IPv6Cond invalid",
+			Class: "nextheader=FOO",
+			Valid: false,
+		},
+		{
+			Name:  "icmptype IPv4Cond",
+			Class: "icmptype=8",
+			Valid: true,
+		},
+		{
+			Name:  "bad icmptype IPv4Cond",
+			Class: "icmptype=256",
+			Valid: false,
+		},
+		{
+			Name:  "icmp6type IPv6Cond",
+			Class: "icmp6type=128",
+			Valid: true,
+		},
+		{
+			Name:  "ANY IPv4 IPv6",
+			Class: "ANY(src=10.0.0.0/8,ALL(src=fd00::/8,dstport=443))",
+			Valid: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -134,6 +204,7 @@ func TestTrafficClassValidation(t *testing.T) {
 }
 
 func TestTrafficClassTree(t *testing.T) {
+	_, net6, _ := net.ParseCIDR("2001:db8::/32")
 	_, net, _ := net.ParseCIDR("12.12.12.0/26")
 	testCases := []struct {
 		Name  string
@@ -257,6 +328,60 @@ func TestTrafficClassTree(t *testing.T) {
 			Class: "protocol=udp",
 			Tree:  pktcls.NewCondIPv4(&pktcls.IPv4MatchProtocol{Protocol: uint8(17)}),
 		},
+		{
+			Name:  "protocol ICMPv4",
+			Class: "protocol=ICMPv4",
+			Tree:  pktcls.NewCondIPv4(&pktcls.IPv4MatchProtocol{Protocol: uint8(1)}),
+		},
+		{
+			Name:  "icmptype",
+			Class: "icmptype=8",
+			Tree:  pktcls.NewCondIPv4(&pktcls.IPv4MatchICMPType{ICMPType: 8}),
+		},
+		{
+			Name:  "src IPv6Cond",
+			Class: "src=2001:db8::/32",
+			Tree:  pktcls.NewCondIPv6(&pktcls.IPv6MatchSource{Net: net6}),
+		},
+		{
+			Name:  "dst IPv6Cond",
+			Class: "dst=2001:db8::/32",
+			Tree:  pktcls.NewCondIPv6(&pktcls.IPv6MatchDestination{Net: net6}),
+		},
+		{
+			Name:  "tc IPv6Cond",
+			Class: "tc=0xb8",
+			Tree:  pktcls.NewCondIPv6(&pktcls.IPv6MatchTrafficClass{TrafficClass: 0xb8}),
+		},
+		{
+			Name:  "flowlabel IPv6Cond",
+			Class: "flowlabel=0x12345",
+			Tree:  pktcls.NewCondIPv6(&pktcls.IPv6MatchFlowLabel{FlowLabel: 0x12345}),
+		},
+		{
+			Name:  "nextheader IPv6Cond",
+			Class: "nextheader=udp",
+			Tree:  pktcls.NewCondIPv6(&pktcls.IPv6MatchNextHeader{NextHeader: uint8(17)}),
+		},
+		{
+			Name:  "icmp6type IPv6Cond",
+			Class: "icmp6type=128",
+			Tree:  pktcls.NewCondIPv6(&pktcls.IPv6MatchICMPType{ICMPType: 128}),
+		},
+		{
+			Name:  "ALL IPv4 IPv6 ports",
+			Class: "ALL(NOT(src=12.12.12.0/26),src=2001:db8::/32,dstport=100-199)",
+			Tree: pktcls.CondAllOf{
+				pktcls.CondNot{Operand: pktcls.NewCondIPv4(
+					&pktcls.IPv4MatchSource{Net: net},
+				)},
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchSource{Net: net6}),
+				pktcls.NewCondPorts(&pktcls.PortMatchDestination{
+					MinPort: 100,
+					MaxPort: 199,
+				}),
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net"
 
+	"github.com/gopacket/gopacket"
 	"github.com/gopacket/gopacket/layers"
 
 	"github.com/scionproto/scion/pkg/private/serrors"
@@ -241,3 +242,46 @@ func (m *IPv4MatchProtocol) UnmarshalJSON(b []byte) error {
 	m.Protocol = n
 	return nil
 }
+
+var _ IPv4Predicate = (*IPv4MatchICMPType)(nil)
+
+// IPv4MatchICMPType checks whether the packet is an ICMPv4 message of the specified type.
+type IPv4MatchICMPType struct {
+	ICMPType uint8
+}
+
+func (m *IPv4MatchICMPType) Type() string {
+	return "MatchICMPType"
+}
+
+func (m *IPv4MatchICMPType) Eval(p *layers.IPv4) bool {
+	if p.NextLayerType() != layers.LayerTypeICMPv4 {
+		return false
+	}
+	icmp := &layers.ICMPv4{}
+	if err := icmp.DecodeFromBytes(p.LayerPayload(), gopacket.NilDecodeFeedback); err != nil {
+		return false
+	}
+	return m.ICMPType == icmp.TypeCode.Type()
+}
+
+func (m *IPv4MatchICMPType) String() string {
+	return fmt.Sprintf("icmptype=%d", m.ICMPType)
+}
+
+func (m *IPv4MatchICMPType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		jsonContainer{
+			"ICMPType": fmt.Sprintf("%d", m.ICMPType),
+		},
+	)
+}
+
+func (m *IPv4MatchICMPType) UnmarshalJSON(b []byte) error {
+	i, err := unmarshalUintField(b, "MatchICMPType", "ICMPType", 8)
+	if err != nil {
+		return err
+	}
+	m.ICMPType = uint8(i)
+	return nil
+}
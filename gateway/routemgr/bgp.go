@@ -0,0 +1,493 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routemgr
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"sort"
+	"sync"
+	"time"
+
+	"go4.org/netipx"
+
+	"github.com/scionproto/scion/gateway/control"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+const (
+	// DefaultBGPHoldTime is the hold time proposed to the BGP peer.
+	DefaultBGPHoldTime = 90 * time.Second
+	// DefaultBGPConnectRetry is the time waited before the session is
+	// re-established after it went down.
+	DefaultBGPConnectRetry = 30 * time.Second
+	// DefaultBGPLocalPref is the local preference attached to exported routes
+	// on iBGP sessions.
+	DefaultBGPLocalPref = 100
+)
+
+// BGPRemote holds the attributes attached to routes that were learned from a
+// remote ISD-AS when they are exported to the BGP peer.
+type BGPRemote struct {
+	// Communities are attached to the exported routes.
+	Communities []Community
+	// LocalPref overrides the default local preference on iBGP sessions. Zero
+	// means that the default is used.
+	LocalPref uint32
+}
+
+// BGP is a minimal BGP speaker that maintains a single session with a local
+// router. The routes published via its publishers, i.e., the prefixes learned
+// from remote gateways, are exported to the peer. The unicast prefixes announced
+// by the peer are imported and can be retrieved with Imported.
+type BGP struct {
+	// LocalAS is the AS number of the gateway.
+	LocalAS uint32
+	// PeerAS is the AS number of the peer. If it equals LocalAS, the session is
+	// iBGP and the local preference is attached to the exported routes.
+	PeerAS uint32
+	// PeerAddr is the TCP address of the peer.
+	PeerAddr string
+	// RouterID is the BGP identifier of the gateway. It must be an IPv4 address.
+	RouterID netip.Addr
+	// HoldTime is the hold time proposed to the peer. If zero,
+	// DefaultBGPHoldTime is used.
+	HoldTime time.Duration
+	// ConnectRetry is the time waited before reconnecting. If zero,
+	// DefaultBGPConnectRetry is used.
+	ConnectRetry time.Duration
+	// NextHopIPv4 and NextHopIPv6 are the next hops of the exported routes. If
+	// not set, the local address of the session is used for the matching address
+	// family. Routes for which no next hop is known are not exported.
+	NextHopIPv4 netip.Addr
+	NextHopIPv6 netip.Addr
+	// LocalPref is the default local preference of the exported routes. If
+	// zero, DefaultBGPLocalPref is used.
+	LocalPref uint32
+	// Remotes holds the per remote ISD-AS attributes of the exported routes.
+	Remotes map[addr.IA]BGPRemote
+
+	mtx sync.Mutex
+	// exportedRoutes stores routes published by the local process.
+	exportedRoutes RouteDB
+	// exported maps the exported prefixes to the ISD-ASes they were learned
+	// from. An ISD-AS appears once per published route.
+	exported map[netip.Prefix][]addr.IA
+	// imported holds the prefixes announced by the peer.
+	imported map[netip.Prefix]struct{}
+	// conn is the established session, nil if the session is down.
+	conn        net.Conn
+	fourOctetAS bool
+	closeChan   chan struct{}
+}
+
+func (b *BGP) init() {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.closeChan == nil {
+		b.closeChan = make(chan struct{})
+		b.exported = make(map[netip.Prefix][]addr.IA)
+		b.imported = make(map[netip.Prefix]struct{})
+		go func() {
+			defer log.HandlePanic()
+			b.exportedRoutes.Run()
+		}()
+	}
+}
+
+func (b *BGP) NewPublisher() control.Publisher {
+	return b.exportedRoutes.NewPublisher()
+}
+
+// Close tears down the BGP session and stops the speaker.
+func (b *BGP) Close() {
+	b.init()
+	close(b.closeChan)
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.conn != nil {
+		n := bgpNotification{Code: bgpErrCease}
+		_ = writeBGPMsg(b.conn, bgpMsgNotification, []byte{n.Code, n.Subcode})
+		b.conn.Close()
+		b.conn = nil
+	}
+}
+
+// Run maintains the BGP session and exports the published routes until Close
+// is called.
+func (b *BGP) Run(ctx context.Context) {
+	b.init()
+	go func() {
+		defer log.HandlePanic()
+		b.runSessions(ctx)
+	}()
+	consumer := b.exportedRoutes.NewConsumer()
+Top:
+	for {
+		select {
+		case update := <-consumer.Updates():
+			b.export(ctx, update)
+		case <-b.closeChan:
+			// Closed by the user.
+			break Top
+		}
+	}
+	consumer.Close()
+	b.exportedRoutes.Close()
+}
+
+func (b *BGP) Diagnostics() control.Diagnostics {
+	return b.exportedRoutes.Diagnostics()
+}
+
+// Imported returns the prefixes that are currently announced by the peer.
+func (b *BGP) Imported() []netip.Prefix {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	prefixes := make([]netip.Prefix, 0, len(b.imported))
+	for p := range b.imported {
+		prefixes = append(prefixes, p)
+	}
+	sort.Slice(prefixes, func(i, j int) bool {
+		if c := prefixes[i].Addr().Compare(prefixes[j].Addr()); c != 0 {
+			return c < 0
+		}
+		return prefixes[i].Bits() < prefixes[j].Bits()
+	})
+	return prefixes
+}
+
+func (b *BGP) export(ctx context.Context, update control.RouteUpdate) {
+	prefix, ok := netipx.FromStdIPNet(update.Prefix)
+	if !ok {
+		return
+	}
+	prefix = prefix.Masked()
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	ias := b.exported[prefix]
+	before := ias
+	if update.IsAdd {
+		ias = append(ias, update.IA)
+	} else {
+		for i, ia := range ias {
+			if ia == update.IA {
+				ias = append(ias[:i:i], ias[i+1:]...)
+				break
+			}
+		}
+	}
+	if len(ias) == 0 {
+		delete(b.exported, prefix)
+	} else {
+		b.exported[prefix] = ias
+	}
+	if b.conn == nil {
+		return
+	}
+	var err error
+	switch {
+	case len(ias) == 0 && len(before) != 0:
+		err = b.sendLocked(bgpMsgUpdate, b.withdrawal(prefix))
+	case len(ias) != 0 && (len(before) == 0 || before[0] != ias[0]):
+		// The attributes of a route are determined by the ISD-AS of the first
+		// published route. Re-announce if that changes.
+		if u, ok := b.announcement(prefix, ias[0]); ok {
+			err = b.sendLocked(bgpMsgUpdate, u)
+		}
+	}
+	if err != nil {
+		log.FromCtx(ctx).Info("Failed to send BGP update", "prefix", prefix, "err", err)
+	}
+}
+
+// announcement returns the encoded UPDATE message that announces the prefix
+// with the attributes configured for the ISD-AS.
+func (b *BGP) announcement(prefix netip.Prefix, ia addr.IA) ([]byte, bool) {
+	u := bgpUpdate{
+		NLRI:        []netip.Prefix{prefix},
+		Communities: b.Remotes[ia].Communities,
+	}
+	local, _ := netip.AddrFromSlice(b.conn.LocalAddr().(*net.TCPAddr).IP)
+	local = local.Unmap()
+	if prefix.Addr().Is4() {
+		u.NextHopIPv4 = b.NextHopIPv4
+		if !u.NextHopIPv4.IsValid() && local.Is4() {
+			u.NextHopIPv4 = local
+		}
+		if !u.NextHopIPv4.IsValid() {
+			return nil, false
+		}
+	} else {
+		u.NextHopIPv6 = b.NextHopIPv6
+		if !u.NextHopIPv6.IsValid() && local.Is6() {
+			u.NextHopIPv6 = local
+		}
+		if !u.NextHopIPv6.IsValid() {
+			return nil, false
+		}
+	}
+	if b.LocalAS == b.PeerAS {
+		u.HasLocalPref = true
+		u.LocalPref = b.Remotes[ia].LocalPref
+		if u.LocalPref == 0 {
+			u.LocalPref = b.localPref()
+		}
+	} else {
+		u.ASPath = []uint32{b.LocalAS}
+	}
+	return u.encode(b.fourOctetAS), true
+}
+
+func (b *BGP) withdrawal(prefix netip.Prefix) []byte {
+	u := bgpUpdate{Withdrawn: []netip.Prefix{prefix}}
+	return u.encode(b.fourOctetAS)
+}
+
+func (b *BGP) sendLocked(typ uint8, body []byte) error {
+	if err := writeBGPMsg(b.conn, typ, body); err != nil {
+		// The reading side of the session notices the closed connection and
+		// tears the session down.
+		b.conn.Close()
+		return err
+	}
+	return nil
+}
+
+func (b *BGP) runSessions(ctx context.Context) {
+	logger := log.FromCtx(ctx)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		defer log.HandlePanic()
+		<-b.closeChan
+		cancel()
+	}()
+	for {
+		if err := b.session(ctx); err != nil {
+			logger.Info("BGP session down", "peer", b.PeerAddr, "err", err)
+		}
+		select {
+		case <-time.After(b.connectRetry()):
+		case <-b.closeChan:
+			return
+		}
+	}
+}
+
+// session establishes a session with the peer and handles the received
+// messages until the session goes down.
+func (b *BGP) session(ctx context.Context) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", b.PeerAddr)
+	if err != nil {
+		return serrors.Wrap("connecting to peer", err)
+	}
+	defer conn.Close()
+
+	holdTime, err := b.openSession(conn)
+	if err != nil {
+		b.notify(conn, err)
+		return err
+	}
+	defer b.sessionDown(conn)
+
+	if holdTime > 0 {
+		go func() {
+			defer log.HandlePanic()
+			b.sendKeepalives(conn, holdTime/3)
+		}()
+	}
+	log.FromCtx(ctx).Info("BGP session established", "peer", b.PeerAddr)
+	for {
+		if holdTime > 0 {
+			_ = conn.SetReadDeadline(time.Now().Add(holdTime))
+		}
+		typ, body, err := readBGPMsg(conn)
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				b.notify(conn, &bgpNotification{Code: bgpErrHoldTimer})
+				return serrors.New("hold timer expired")
+			}
+			return serrors.Wrap("reading message", err)
+		}
+		switch typ {
+		case bgpMsgKeepalive:
+		case bgpMsgUpdate:
+			var u bgpUpdate
+			if err := u.decode(body, b.isFourOctetAS()); err != nil {
+				b.notify(conn, &bgpNotification{Code: bgpErrUpdate})
+				return serrors.Wrap("decoding UPDATE", err)
+			}
+			b.importUpdate(&u)
+		case bgpMsgNotification:
+			n := &bgpNotification{}
+			if len(body) >= 2 {
+				n.Code, n.Subcode = body[0], body[1]
+			}
+			return n
+		default:
+			b.notify(conn, &bgpNotification{Code: bgpErrHeader, Subcode: 3})
+			return serrors.New("unexpected message", "type", typ)
+		}
+	}
+}
+
+// openSession exchanges the OPEN and initial KEEPALIVE messages with the peer.
+// On success, the session is marked as established, the exported routes are
+// announced, and the negotiated hold time is returned.
+func (b *BGP) openSession(conn net.Conn) (time.Duration, error) {
+	local := bgpOpen{
+		AS:          b.LocalAS,
+		HoldTime:    uint16(b.holdTime() / time.Second),
+		RouterID:    b.RouterID,
+		FourOctetAS: true,
+		IPv4:        true,
+		IPv6:        true,
+	}
+	if err := writeBGPMsg(conn, bgpMsgOpen, local.encode()); err != nil {
+		return 0, serrors.Wrap("sending OPEN", err)
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(b.holdTime()))
+	typ, body, err := readBGPMsg(conn)
+	if err != nil {
+		return 0, serrors.Wrap("reading OPEN", err)
+	}
+	if typ != bgpMsgOpen {
+		return 0, serrors.New("expected OPEN", "type", typ)
+	}
+	var remote bgpOpen
+	if err := remote.decode(body); err != nil {
+		return 0, &bgpNotification{Code: bgpErrOpen}
+	}
+	if remote.AS != b.PeerAS {
+		// Bad peer AS.
+		return 0, &bgpNotification{Code: bgpErrOpen, Subcode: 2}
+	}
+	if remote.HoldTime == 1 || remote.HoldTime == 2 {
+		// Unacceptable hold time.
+		return 0, &bgpNotification{Code: bgpErrOpen, Subcode: 6}
+	}
+	holdTime := min(b.holdTime(), time.Duration(remote.HoldTime)*time.Second)
+	if err := writeBGPMsg(conn, bgpMsgKeepalive, nil); err != nil {
+		return 0, serrors.Wrap("sending KEEPALIVE", err)
+	}
+	if typ, _, err = readBGPMsg(conn); err != nil {
+		return 0, serrors.Wrap("reading KEEPALIVE", err)
+	}
+	if typ != bgpMsgKeepalive {
+		return 0, serrors.New("expected KEEPALIVE", "type", typ)
+	}
+	_ = conn.SetReadDeadline(time.Time{})
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	select {
+	case <-b.closeChan:
+		return 0, serrors.New("closed")
+	default:
+	}
+	b.conn = conn
+	b.fourOctetAS = remote.FourOctetAS
+	for prefix, ias := range b.exported {
+		u, ok := b.announcement(prefix, ias[0])
+		if !ok {
+			continue
+		}
+		if err := b.sendLocked(bgpMsgUpdate, u); err != nil {
+			return 0, serrors.Wrap("sending UPDATE", err)
+		}
+	}
+	return holdTime, nil
+}
+
+func (b *BGP) sessionDown(conn net.Conn) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.conn == conn {
+		b.conn = nil
+	}
+	b.imported = make(map[netip.Prefix]struct{})
+}
+
+func (b *BGP) sendKeepalives(conn net.Conn, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		b.mtx.Lock()
+		if b.conn != conn {
+			b.mtx.Unlock()
+			return
+		}
+		err := b.sendLocked(bgpMsgKeepalive, nil)
+		b.mtx.Unlock()
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (b *BGP) importUpdate(u *bgpUpdate) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	for _, p := range u.Withdrawn {
+		delete(b.imported, p)
+	}
+	for _, p := range u.NLRI {
+		b.imported[p] = struct{}{}
+	}
+}
+
+func (b *BGP) isFourOctetAS() bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.fourOctetAS
+}
+
+// notify sends a NOTIFICATION to the peer if err is one.
+func (b *BGP) notify(conn net.Conn, err error) {
+	n, ok := err.(*bgpNotification)
+	if !ok {
+		return
+	}
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	_ = writeBGPMsg(conn, bgpMsgNotification, []byte{n.Code, n.Subcode})
+}
+
+func (b *BGP) holdTime() time.Duration {
+	if b.HoldTime == 0 {
+		return DefaultBGPHoldTime
+	}
+	return b.HoldTime
+}
+
+func (b *BGP) connectRetry() time.Duration {
+	if b.ConnectRetry == 0 {
+		return DefaultBGPConnectRetry
+	}
+	return b.ConnectRetry
+}
+
+func (b *BGP) localPref() uint32 {
+	if b.LocalPref == 0 {
+		return DefaultBGPLocalPref
+	}
+	return b.LocalPref
+}
@@ -0,0 +1,491 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routemgr
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// BGP message types (RFC 4271, Section 4.1).
+const (
+	bgpMsgOpen         uint8 = 1
+	bgpMsgUpdate       uint8 = 2
+	bgpMsgNotification uint8 = 3
+	bgpMsgKeepalive    uint8 = 4
+)
+
+// BGP path attribute type codes (RFC 4271, RFC 1997, RFC 4760).
+const (
+	bgpAttrOrigin      uint8 = 1
+	bgpAttrASPath      uint8 = 2
+	bgpAttrNextHop     uint8 = 3
+	bgpAttrLocalPref   uint8 = 5
+	bgpAttrCommunities uint8 = 8
+	bgpAttrMPReach     uint8 = 14
+	bgpAttrMPUnreach   uint8 = 15
+)
+
+// BGP path attribute flags.
+const (
+	bgpFlagOptional   uint8 = 0x80
+	bgpFlagTransitive uint8 = 0x40
+	bgpFlagExtended   uint8 = 0x10
+)
+
+// BGP capability codes (RFC 5492).
+const (
+	bgpCapMultiprotocol uint8 = 1
+	bgpCapFourOctetAS   uint8 = 65
+)
+
+// BGP NOTIFICATION error codes.
+const (
+	bgpErrHeader    uint8 = 1
+	bgpErrOpen      uint8 = 2
+	bgpErrUpdate    uint8 = 3
+	bgpErrHoldTimer uint8 = 4
+	bgpErrCease     uint8 = 6
+)
+
+const (
+	bgpHeaderLen  = 19
+	bgpMaxMsgLen  = 4096
+	bgpVersion    = 4
+	bgpASTrans    = 23456
+	bgpAFIIPv4    = 1
+	bgpAFIIPv6    = 2
+	bgpSAFIUnicat = 1
+	bgpASSequence = 2
+	bgpOriginIGP  = 0
+)
+
+// Community is a BGP community value (RFC 1997). Its text representation is
+// "<asn>:<value>" with two 16-bit decimal numbers.
+type Community uint32
+
+// ParseCommunity parses a community in the "<asn>:<value>" notation.
+func ParseCommunity(s string) (Community, error) {
+	asn, value, ok := strings.Cut(s, ":")
+	if !ok {
+		return 0, serrors.New("community must be of the form <asn>:<value>", "community", s)
+	}
+	a, err := strconv.ParseUint(asn, 10, 16)
+	if err != nil {
+		return 0, serrors.Wrap("parsing community ASN", err, "community", s)
+	}
+	v, err := strconv.ParseUint(value, 10, 16)
+	if err != nil {
+		return 0, serrors.Wrap("parsing community value", err, "community", s)
+	}
+	return Community(a<<16 | v), nil
+}
+
+func (c Community) String() string {
+	return fmt.Sprintf("%d:%d", uint32(c)>>16, uint32(c)&0xffff)
+}
+
+func (c Community) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func (c *Community) UnmarshalText(b []byte) error {
+	parsed, err := ParseCommunity(string(b))
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+// bgpOpen is a BGP OPEN message.
+type bgpOpen struct {
+	// AS is the (4-octet) AS number of the speaker.
+	AS       uint32
+	HoldTime uint16
+	RouterID netip.Addr
+	// FourOctetAS indicates support for 4-octet AS numbers (RFC 6793).
+	FourOctetAS bool
+	// IPv4 and IPv6 indicate support for the unicast address families.
+	IPv4 bool
+	IPv6 bool
+}
+
+func (o *bgpOpen) encode() []byte {
+	var caps []byte
+	if o.IPv4 {
+		caps = append(caps, bgpCapMultiprotocol, 4, 0, bgpAFIIPv4, 0, bgpSAFIUnicat)
+	}
+	if o.IPv6 {
+		caps = append(caps, bgpCapMultiprotocol, 4, 0, bgpAFIIPv6, 0, bgpSAFIUnicat)
+	}
+	if o.FourOctetAS {
+		caps = append(caps, bgpCapFourOctetAS, 4)
+		caps = binary.BigEndian.AppendUint32(caps, o.AS)
+	}
+	myAS := uint16(bgpASTrans)
+	if o.AS <= 0xffff {
+		myAS = uint16(o.AS)
+	}
+	b := []byte{bgpVersion}
+	b = binary.BigEndian.AppendUint16(b, myAS)
+	b = binary.BigEndian.AppendUint16(b, o.HoldTime)
+	id := o.RouterID.As4()
+	b = append(b, id[:]...)
+	b = append(b, byte(len(caps)+2), 2, byte(len(caps)))
+	b = append(b, caps...)
+	return b
+}
+
+func (o *bgpOpen) decode(b []byte) error {
+	if len(b) < 10 {
+		return serrors.New("OPEN message too short", "len", len(b))
+	}
+	if b[0] != bgpVersion {
+		return serrors.New("unsupported BGP version", "version", b[0])
+	}
+	o.AS = uint32(binary.BigEndian.Uint16(b[1:3]))
+	o.HoldTime = binary.BigEndian.Uint16(b[3:5])
+	o.RouterID = netip.AddrFrom4([4]byte(b[5:9]))
+	params := b[10:]
+	if int(b[9]) != len(params) {
+		return serrors.New("invalid optional parameters length", "len", b[9])
+	}
+	for len(params) > 0 {
+		if len(params) < 2 || len(params) < 2+int(params[1]) {
+			return serrors.New("truncated optional parameter")
+		}
+		typ, value := params[0], params[2:2+int(params[1])]
+		params = params[2+int(params[1]):]
+		if typ != 2 {
+			continue
+		}
+		for len(value) > 0 {
+			if len(value) < 2 || len(value) < 2+int(value[1]) {
+				return serrors.New("truncated capability")
+			}
+			code, capValue := value[0], value[2:2+int(value[1])]
+			value = value[2+int(value[1]):]
+			switch {
+			case code == bgpCapFourOctetAS && len(capValue) == 4:
+				o.FourOctetAS = true
+				o.AS = binary.BigEndian.Uint32(capValue)
+			case code == bgpCapMultiprotocol && len(capValue) == 4:
+				afi, safi := binary.BigEndian.Uint16(capValue), capValue[3]
+				if safi != bgpSAFIUnicat {
+					continue
+				}
+				o.IPv4 = o.IPv4 || afi == bgpAFIIPv4
+				o.IPv6 = o.IPv6 || afi == bgpAFIIPv6
+			}
+		}
+	}
+	return nil
+}
+
+// bgpUpdate is a BGP UPDATE message. IPv4 prefixes are encoded in the classic
+// NLRI fields, IPv6 prefixes in the multiprotocol attributes (RFC 4760).
+type bgpUpdate struct {
+	Withdrawn []netip.Prefix
+	NLRI      []netip.Prefix
+
+	NextHopIPv4 netip.Addr
+	NextHopIPv6 netip.Addr
+	ASPath      []uint32
+	// LocalPref is only encoded if HasLocalPref is set.
+	LocalPref    uint32
+	HasLocalPref bool
+	Communities  []Community
+}
+
+func (u *bgpUpdate) encode(fourOctetAS bool) []byte {
+	var withdrawn4, withdrawn6, nlri4, nlri6 []netip.Prefix
+	for _, p := range u.Withdrawn {
+		if p.Addr().Is4() {
+			withdrawn4 = append(withdrawn4, p)
+		} else {
+			withdrawn6 = append(withdrawn6, p)
+		}
+	}
+	for _, p := range u.NLRI {
+		if p.Addr().Is4() {
+			nlri4 = append(nlri4, p)
+		} else {
+			nlri6 = append(nlri6, p)
+		}
+	}
+
+	var attrs []byte
+	if len(withdrawn6) > 0 {
+		v := []byte{0, bgpAFIIPv6, bgpSAFIUnicat}
+		v = appendPrefixes(v, withdrawn6)
+		attrs = appendAttr(attrs, bgpFlagOptional, bgpAttrMPUnreach, v)
+	}
+	if len(u.NLRI) > 0 {
+		attrs = appendAttr(attrs, bgpFlagTransitive, bgpAttrOrigin, []byte{bgpOriginIGP})
+		var path []byte
+		if len(u.ASPath) > 0 {
+			path = []byte{bgpASSequence, byte(len(u.ASPath))}
+			for _, as := range u.ASPath {
+				if fourOctetAS {
+					path = binary.BigEndian.AppendUint32(path, as)
+				} else {
+					path = binary.BigEndian.AppendUint16(path, uint16(as))
+				}
+			}
+		}
+		attrs = appendAttr(attrs, bgpFlagTransitive, bgpAttrASPath, path)
+		if len(nlri4) > 0 {
+			nh := u.NextHopIPv4.As4()
+			attrs = appendAttr(attrs, bgpFlagTransitive, bgpAttrNextHop, nh[:])
+		}
+		if u.HasLocalPref {
+			attrs = appendAttr(attrs, bgpFlagTransitive, bgpAttrLocalPref,
+				binary.BigEndian.AppendUint32(nil, u.LocalPref))
+		}
+		if len(u.Communities) > 0 {
+			var v []byte
+			for _, c := range u.Communities {
+				v = binary.BigEndian.AppendUint32(v, uint32(c))
+			}
+			attrs = appendAttr(attrs, bgpFlagOptional|bgpFlagTransitive, bgpAttrCommunities, v)
+		}
+		if len(nlri6) > 0 {
+			nh := u.NextHopIPv6.As16()
+			v := []byte{0, bgpAFIIPv6, bgpSAFIUnicat, 16}
+			v = append(v, nh[:]...)
+			v = append(v, 0)
+			v = appendPrefixes(v, nlri6)
+			attrs = appendAttr(attrs, bgpFlagOptional, bgpAttrMPReach, v)
+		}
+	}
+
+	w := appendPrefixes(nil, withdrawn4)
+	b := binary.BigEndian.AppendUint16(nil, uint16(len(w)))
+	b = append(b, w...)
+	b = binary.BigEndian.AppendUint16(b, uint16(len(attrs)))
+	b = append(b, attrs...)
+	return appendPrefixes(b, nlri4)
+}
+
+func (u *bgpUpdate) decode(b []byte, fourOctetAS bool) error {
+	if len(b) < 2 {
+		return serrors.New("UPDATE message too short")
+	}
+	wLen := int(binary.BigEndian.Uint16(b))
+	if len(b) < 4+wLen {
+		return serrors.New("invalid withdrawn routes length", "len", wLen)
+	}
+	var err error
+	if u.Withdrawn, err = decodePrefixes(b[2:2+wLen], false); err != nil {
+		return err
+	}
+	b = b[2+wLen:]
+	aLen := int(binary.BigEndian.Uint16(b))
+	if len(b) < 2+aLen {
+		return serrors.New("invalid path attributes length", "len", aLen)
+	}
+	attrs := b[2 : 2+aLen]
+	if u.NLRI, err = decodePrefixes(b[2+aLen:], false); err != nil {
+		return err
+	}
+	for len(attrs) > 0 {
+		if len(attrs) < 3 {
+			return serrors.New("truncated path attribute")
+		}
+		flags, typ := attrs[0], attrs[1]
+		hdr, l := 3, int(attrs[2])
+		if flags&bgpFlagExtended != 0 {
+			if len(attrs) < 4 {
+				return serrors.New("truncated path attribute")
+			}
+			hdr, l = 4, int(binary.BigEndian.Uint16(attrs[2:4]))
+		}
+		if len(attrs) < hdr+l {
+			return serrors.New("truncated path attribute", "type", typ)
+		}
+		v := attrs[hdr : hdr+l]
+		attrs = attrs[hdr+l:]
+		if err := u.decodeAttr(typ, v, fourOctetAS); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *bgpUpdate) decodeAttr(typ uint8, v []byte, fourOctetAS bool) error {
+	switch typ {
+	case bgpAttrASPath:
+		asLen := 2
+		if fourOctetAS {
+			asLen = 4
+		}
+		for len(v) > 0 {
+			if len(v) < 2 || len(v) < 2+int(v[1])*asLen {
+				return serrors.New("truncated AS_PATH segment")
+			}
+			n := int(v[1])
+			for i := 0; i < n; i++ {
+				as := v[2+i*asLen : 2+(i+1)*asLen]
+				if fourOctetAS {
+					u.ASPath = append(u.ASPath, binary.BigEndian.Uint32(as))
+				} else {
+					u.ASPath = append(u.ASPath, uint32(binary.BigEndian.Uint16(as)))
+				}
+			}
+			v = v[2+n*asLen:]
+		}
+	case bgpAttrNextHop:
+		if len(v) != 4 {
+			return serrors.New("invalid NEXT_HOP length", "len", len(v))
+		}
+		u.NextHopIPv4 = netip.AddrFrom4([4]byte(v))
+	case bgpAttrLocalPref:
+		if len(v) != 4 {
+			return serrors.New("invalid LOCAL_PREF length", "len", len(v))
+		}
+		u.LocalPref, u.HasLocalPref = binary.BigEndian.Uint32(v), true
+	case bgpAttrCommunities:
+		if len(v)%4 != 0 {
+			return serrors.New("invalid COMMUNITIES length", "len", len(v))
+		}
+		for ; len(v) > 0; v = v[4:] {
+			u.Communities = append(u.Communities, Community(binary.BigEndian.Uint32(v)))
+		}
+	case bgpAttrMPReach:
+		if len(v) < 5 || len(v) < 5+int(v[3]) {
+			return serrors.New("truncated MP_REACH_NLRI")
+		}
+		afi, safi, nhLen := binary.BigEndian.Uint16(v), v[2], int(v[3])
+		if afi != bgpAFIIPv6 || safi != bgpSAFIUnicat {
+			return nil
+		}
+		if nhLen >= 16 {
+			u.NextHopIPv6 = netip.AddrFrom16([16]byte(v[4:20]))
+		}
+		prefixes, err := decodePrefixes(v[5+nhLen:], true)
+		if err != nil {
+			return err
+		}
+		u.NLRI = append(u.NLRI, prefixes...)
+	case bgpAttrMPUnreach:
+		if len(v) < 3 {
+			return serrors.New("truncated MP_UNREACH_NLRI")
+		}
+		afi, safi := binary.BigEndian.Uint16(v), v[2]
+		if afi != bgpAFIIPv6 || safi != bgpSAFIUnicat {
+			return nil
+		}
+		prefixes, err := decodePrefixes(v[3:], true)
+		if err != nil {
+			return err
+		}
+		u.Withdrawn = append(u.Withdrawn, prefixes...)
+	}
+	return nil
+}
+
+// bgpNotification is a BGP NOTIFICATION message.
+type bgpNotification struct {
+	Code    uint8
+	Subcode uint8
+}
+
+func (n *bgpNotification) Error() string {
+	return fmt.Sprintf("BGP notification (code %d, subcode %d)", n.Code, n.Subcode)
+}
+
+func appendAttr(b []byte, flags, typ uint8, v []byte) []byte {
+	if len(v) > 0xff {
+		b = append(b, flags|bgpFlagExtended, typ)
+		b = binary.BigEndian.AppendUint16(b, uint16(len(v)))
+	} else {
+		b = append(b, flags, typ, byte(len(v)))
+	}
+	return append(b, v...)
+}
+
+func appendPrefixes(b []byte, prefixes []netip.Prefix) []byte {
+	for _, p := range prefixes {
+		bits := p.Bits()
+		b = append(b, byte(bits))
+		b = append(b, p.Addr().AsSlice()[:(bits+7)/8]...)
+	}
+	return b
+}
+
+func decodePrefixes(b []byte, ipv6 bool) ([]netip.Prefix, error) {
+	addrLen := 4
+	if ipv6 {
+		addrLen = 16
+	}
+	var prefixes []netip.Prefix
+	for len(b) > 0 {
+		bits := int(b[0])
+		n := (bits + 7) / 8
+		if bits > addrLen*8 || len(b) < 1+n {
+			return nil, serrors.New("invalid prefix encoding", "bits", bits)
+		}
+		raw := make([]byte, addrLen)
+		copy(raw, b[1:1+n])
+		ip, _ := netip.AddrFromSlice(raw)
+		prefixes = append(prefixes, netip.PrefixFrom(ip, bits).Masked())
+		b = b[1+n:]
+	}
+	return prefixes, nil
+}
+
+// writeBGPMsg writes a BGP message with the given type and body.
+func writeBGPMsg(w io.Writer, typ uint8, body []byte) error {
+	if bgpHeaderLen+len(body) > bgpMaxMsgLen {
+		return serrors.New("BGP message too long", "len", bgpHeaderLen+len(body))
+	}
+	msg := make([]byte, bgpHeaderLen, bgpHeaderLen+len(body))
+	for i := 0; i < 16; i++ {
+		msg[i] = 0xff
+	}
+	binary.BigEndian.PutUint16(msg[16:], uint16(bgpHeaderLen+len(body)))
+	msg[18] = typ
+	_, err := w.Write(append(msg, body...))
+	return err
+}
+
+// readBGPMsg reads a single BGP message and returns its type and body.
+func readBGPMsg(r io.Reader) (uint8, []byte, error) {
+	var hdr [bgpHeaderLen]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return 0, nil, err
+	}
+	for _, m := range hdr[:16] {
+		if m != 0xff {
+			return 0, nil, serrors.New("invalid BGP marker")
+		}
+	}
+	l := int(binary.BigEndian.Uint16(hdr[16:]))
+	if l < bgpHeaderLen || l > bgpMaxMsgLen {
+		return 0, nil, serrors.New("invalid BGP message length", "len", l)
+	}
+	body := make([]byte, l-bgpHeaderLen)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, nil, err
+	}
+	return hdr[18], body, nil
+}
@@ -0,0 +1,173 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routemgr
+
+import (
+	"bytes"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCommunity(t *testing.T) {
+	testCases := map[string]struct {
+		input     string
+		expected  Community
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			input:     "64512:110",
+			expected:  64512<<16 | 110,
+			assertErr: assert.NoError,
+		},
+		"max": {
+			input:     "65535:65535",
+			expected:  0xffffffff,
+			assertErr: assert.NoError,
+		},
+		"no colon": {
+			input:     "64512",
+			assertErr: assert.Error,
+		},
+		"ASN too large": {
+			input:     "65536:1",
+			assertErr: assert.Error,
+		},
+		"value too large": {
+			input:     "1:65536",
+			assertErr: assert.Error,
+		},
+		"garbage": {
+			input:     "a:b",
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c, err := ParseCommunity(tc.input)
+			tc.assertErr(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.expected, c)
+			assert.Equal(t, tc.input, c.String())
+		})
+	}
+}
+
+func TestBGPOpenRoundTrip(t *testing.T) {
+	testCases := map[string]bgpOpen{
+		"2-octet AS": {
+			AS:       64512,
+			HoldTime: 90,
+			RouterID: netip.MustParseAddr("192.0.2.1"),
+			IPv4:     true,
+		},
+		"4-octet AS": {
+			AS:          4200000000,
+			HoldTime:    0,
+			RouterID:    netip.MustParseAddr("192.0.2.1"),
+			FourOctetAS: true,
+			IPv4:        true,
+			IPv6:        true,
+		},
+	}
+	for name, open := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var decoded bgpOpen
+			require.NoError(t, decoded.decode(open.encode()))
+			assert.Equal(t, open, decoded)
+		})
+	}
+}
+
+func TestBGPUpdateRoundTrip(t *testing.T) {
+	testCases := map[string]struct {
+		update      bgpUpdate
+		fourOctetAS bool
+	}{
+		"withdraw only": {
+			update: bgpUpdate{
+				Withdrawn: []netip.Prefix{
+					netip.MustParsePrefix("10.1.0.0/16"),
+					netip.MustParsePrefix("2001:db8::/32"),
+				},
+			},
+		},
+		"iBGP announcement": {
+			update: bgpUpdate{
+				NLRI: []netip.Prefix{
+					netip.MustParsePrefix("10.1.0.0/16"),
+					netip.MustParsePrefix("192.168.1.128/25"),
+					netip.MustParsePrefix("0.0.0.0/0"),
+				},
+				NextHopIPv4:  netip.MustParseAddr("192.0.2.1"),
+				LocalPref:    200,
+				HasLocalPref: true,
+				Communities:  []Community{64512<<16 | 110, 65535<<16 | 1},
+			},
+		},
+		"eBGP dual-stack announcement": {
+			update: bgpUpdate{
+				NLRI: []netip.Prefix{
+					netip.MustParsePrefix("10.1.0.0/16"),
+					netip.MustParsePrefix("2001:db8:1::/48"),
+				},
+				NextHopIPv4: netip.MustParseAddr("192.0.2.1"),
+				NextHopIPv6: netip.MustParseAddr("2001:db8::1"),
+				ASPath:      []uint32{4200000000},
+			},
+			fourOctetAS: true,
+		},
+		"2-octet AS path": {
+			update: bgpUpdate{
+				NLRI:        []netip.Prefix{netip.MustParsePrefix("2001:db8:1::/48")},
+				NextHopIPv6: netip.MustParseAddr("2001:db8::1"),
+				ASPath:      []uint32{64512, 64513},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var decoded bgpUpdate
+			require.NoError(t, decoded.decode(tc.update.encode(tc.fourOctetAS), tc.fourOctetAS))
+			assert.Equal(t, tc.update, decoded)
+		})
+	}
+}
+
+func TestBGPMsgFraming(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeBGPMsg(&buf, bgpMsgKeepalive, nil))
+	require.NoError(t, writeBGPMsg(&buf, bgpMsgNotification, []byte{bgpErrCease, 0}))
+	assert.Equal(t, bgpHeaderLen*2+2, buf.Len())
+
+	typ, body, err := readBGPMsg(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, bgpMsgKeepalive, typ)
+	assert.Empty(t, body)
+	typ, body, err = readBGPMsg(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, bgpMsgNotification, typ)
+	assert.Equal(t, []byte{bgpErrCease, 0}, body)
+
+	invalid := make([]byte, bgpHeaderLen)
+	_, _, err = readBGPMsg(bytes.NewReader(invalid))
+	assert.Error(t, err)
+
+	assert.Error(t, writeBGPMsg(&buf, bgpMsgUpdate, make([]byte, bgpMaxMsgLen)))
+}
@@ -0,0 +1,263 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routemgr
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/gateway/control"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+)
+
+// stubPeer is a stand-in BGP router that accepts a single session.
+type stubPeer struct {
+	t        *testing.T
+	listener net.Listener
+	conn     net.Conn
+	as       uint32
+}
+
+func newStubPeer(t *testing.T, as uint32) *stubPeer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	return &stubPeer{t: t, listener: listener, as: as}
+}
+
+// accept accepts the session and exchanges the OPEN and KEEPALIVE messages. It
+// returns the OPEN message sent by the gateway.
+func (p *stubPeer) accept() bgpOpen {
+	conn, err := p.listener.Accept()
+	require.NoError(p.t, err)
+	p.t.Cleanup(func() { conn.Close() })
+	p.conn = conn
+
+	typ, body := p.read()
+	require.Equal(p.t, bgpMsgOpen, typ)
+	var open bgpOpen
+	require.NoError(p.t, open.decode(body))
+
+	reply := bgpOpen{
+		AS:          p.as,
+		HoldTime:    30,
+		RouterID:    netip.MustParseAddr("192.0.2.254"),
+		FourOctetAS: true,
+		IPv4:        true,
+		IPv6:        true,
+	}
+	p.write(bgpMsgOpen, reply.encode())
+	p.write(bgpMsgKeepalive, nil)
+	typ, _ = p.read()
+	require.Equal(p.t, bgpMsgKeepalive, typ)
+	return open
+}
+
+func (p *stubPeer) read() (uint8, []byte) {
+	require.NoError(p.t, p.conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	typ, body, err := readBGPMsg(p.conn)
+	require.NoError(p.t, err)
+	return typ, body
+}
+
+func (p *stubPeer) write(typ uint8, body []byte) {
+	require.NoError(p.t, writeBGPMsg(p.conn, typ, body))
+}
+
+// readUpdate returns the next UPDATE message, skipping KEEPALIVEs.
+func (p *stubPeer) readUpdate() bgpUpdate {
+	for {
+		typ, body := p.read()
+		if typ == bgpMsgKeepalive {
+			continue
+		}
+		require.Equal(p.t, bgpMsgUpdate, typ)
+		var u bgpUpdate
+		require.NoError(p.t, u.decode(body, true))
+		return u
+	}
+}
+
+func startBGP(t *testing.T, b *BGP) {
+	b.ConnectRetry = 10 * time.Millisecond
+	b.exportedRoutes.CleanupInterval = time.Millisecond
+	go func() {
+		defer log.HandlePanic()
+		b.Run(context.Background())
+	}()
+}
+
+func route(t *testing.T, prefix string, ia addr.IA) control.Route {
+	_, network, err := net.ParseCIDR(prefix)
+	require.NoError(t, err)
+	return control.Route{Prefix: network, NextHop: net.ParseIP("10.0.0.1"), IA: ia}
+}
+
+func TestBGPExport(t *testing.T) {
+	ia1 := addr.MustParseIA("1-ff00:0:110")
+	ia2 := addr.MustParseIA("1-ff00:0:111")
+
+	peer := newStubPeer(t, 64512)
+	b := &BGP{
+		LocalAS:     64512,
+		PeerAS:      64512,
+		PeerAddr:    peer.listener.Addr().String(),
+		RouterID:    netip.MustParseAddr("192.0.2.1"),
+		NextHopIPv6: netip.MustParseAddr("2001:db8::1"),
+		Remotes: map[addr.IA]BGPRemote{
+			ia1: {Communities: []Community{64512<<16 | 110}, LocalPref: 200},
+		},
+	}
+	pub := b.NewPublisher()
+	// Routes published before the session is established are announced once
+	// it is up.
+	pub.AddRoute(route(t, "10.1.0.0/16", ia1))
+	startBGP(t, b)
+
+	open := peer.accept()
+	assert.Equal(t, uint32(64512), open.AS)
+	assert.Equal(t, netip.MustParseAddr("192.0.2.1"), open.RouterID)
+	assert.True(t, open.FourOctetAS)
+
+	assert.Equal(t, bgpUpdate{
+		NLRI:         []netip.Prefix{netip.MustParsePrefix("10.1.0.0/16")},
+		NextHopIPv4:  netip.MustParseAddr("127.0.0.1"),
+		LocalPref:    200,
+		HasLocalPref: true,
+		Communities:  []Community{64512<<16 | 110},
+	}, peer.readUpdate())
+
+	pub.AddRoute(route(t, "2001:db8:2::/48", ia2))
+	assert.Equal(t, bgpUpdate{
+		NLRI:         []netip.Prefix{netip.MustParsePrefix("2001:db8:2::/48")},
+		NextHopIPv6:  netip.MustParseAddr("2001:db8::1"),
+		LocalPref:    DefaultBGPLocalPref,
+		HasLocalPref: true,
+	}, peer.readUpdate())
+
+	pub.DeleteRoute(route(t, "10.1.0.0/16", ia1))
+	assert.Equal(t, bgpUpdate{
+		Withdrawn: []netip.Prefix{netip.MustParsePrefix("10.1.0.0/16")},
+	}, peer.readUpdate())
+
+	b.Close()
+	typ, body := peer.read()
+	assert.Equal(t, bgpMsgNotification, typ)
+	assert.Equal(t, []byte{bgpErrCease, 0}, body)
+}
+
+func TestBGPExportEBGP(t *testing.T) {
+	ia := addr.MustParseIA("1-ff00:0:110")
+
+	peer := newStubPeer(t, 64513)
+	b := &BGP{
+		LocalAS:     64512,
+		PeerAS:      64513,
+		PeerAddr:    peer.listener.Addr().String(),
+		RouterID:    netip.MustParseAddr("192.0.2.1"),
+		NextHopIPv4: netip.MustParseAddr("192.0.2.1"),
+		Remotes: map[addr.IA]BGPRemote{
+			ia: {Communities: []Community{64512<<16 | 110}, LocalPref: 200},
+		},
+	}
+	defer b.Close()
+	pub := b.NewPublisher()
+	startBGP(t, b)
+	peer.accept()
+
+	pub.AddRoute(route(t, "10.1.0.0/16", ia))
+	assert.Equal(t, bgpUpdate{
+		NLRI:        []netip.Prefix{netip.MustParsePrefix("10.1.0.0/16")},
+		NextHopIPv4: netip.MustParseAddr("192.0.2.1"),
+		ASPath:      []uint32{64512},
+		Communities: []Community{64512<<16 | 110},
+	}, peer.readUpdate())
+}
+
+func TestBGPImport(t *testing.T) {
+	peer := newStubPeer(t, 64512)
+	b := &BGP{
+		LocalAS:  64512,
+		PeerAS:   64512,
+		PeerAddr: peer.listener.Addr().String(),
+		RouterID: netip.MustParseAddr("192.0.2.1"),
+	}
+	defer b.Close()
+	startBGP(t, b)
+	peer.accept()
+
+	u := bgpUpdate{
+		NLRI: []netip.Prefix{
+			netip.MustParsePrefix("10.5.0.0/16"),
+			netip.MustParsePrefix("2001:db8:5::/48"),
+		},
+		NextHopIPv4: netip.MustParseAddr("192.0.2.254"),
+		NextHopIPv6: netip.MustParseAddr("2001:db8::254"),
+	}
+	peer.write(bgpMsgUpdate, u.encode(true))
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(u.NLRI, b.Imported())
+	}, 5*time.Second, 10*time.Millisecond)
+
+	u = bgpUpdate{Withdrawn: []netip.Prefix{netip.MustParsePrefix("10.5.0.0/16")}}
+	peer.write(bgpMsgUpdate, u.encode(true))
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(
+			[]netip.Prefix{netip.MustParsePrefix("2001:db8:5::/48")}, b.Imported())
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The imported prefixes are dropped when the session goes down, and the
+	// gateway reconnects.
+	peer.conn.Close()
+	assert.Eventually(t, func() bool {
+		return len(b.Imported()) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	peer.accept()
+}
+
+func TestBGPBadPeerAS(t *testing.T) {
+	peer := newStubPeer(t, 64513)
+	b := &BGP{
+		LocalAS:  64512,
+		PeerAS:   64512,
+		PeerAddr: peer.listener.Addr().String(),
+		RouterID: netip.MustParseAddr("192.0.2.1"),
+	}
+	defer b.Close()
+	startBGP(t, b)
+
+	conn, err := peer.listener.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+	peer.conn = conn
+	typ, _ := peer.read()
+	require.Equal(t, bgpMsgOpen, typ)
+	reply := bgpOpen{
+		AS:       64513,
+		HoldTime: 30,
+		RouterID: netip.MustParseAddr("192.0.2.254"),
+	}
+	peer.write(bgpMsgOpen, reply.encode())
+	typ, body := peer.read()
+	assert.Equal(t, bgpMsgNotification, typ)
+	assert.Equal(t, []byte{bgpErrOpen, 2}, body)
+}
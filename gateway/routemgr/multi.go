@@ -0,0 +1,59 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routemgr
+
+import "github.com/scionproto/scion/gateway/control"
+
+// Multi is a publisher factory that publishes routes to all of the contained
+// factories.
+type Multi []control.PublisherFactory
+
+func (m Multi) NewPublisher() control.Publisher {
+	publishers := make(multiPublisher, 0, len(m))
+	for _, f := range m {
+		publishers = append(publishers, f.NewPublisher())
+	}
+	return publishers
+}
+
+// Diagnostics returns the diagnostics of the first factory that provides them.
+func (m Multi) Diagnostics() control.Diagnostics {
+	for _, f := range m {
+		if d, ok := f.(interface{ Diagnostics() control.Diagnostics }); ok {
+			return d.Diagnostics()
+		}
+	}
+	return control.Diagnostics{}
+}
+
+type multiPublisher []control.Publisher
+
+func (m multiPublisher) AddRoute(route control.Route) {
+	for _, p := range m {
+		p.AddRoute(route)
+	}
+}
+
+func (m multiPublisher) DeleteRoute(route control.Route) {
+	for _, p := range m {
+		p.DeleteRoute(route)
+	}
+}
+
+func (m multiPublisher) Close() {
+	for _, p := range m {
+		p.Close()
+	}
+}
@@ -62,3 +62,54 @@ func StaticAdvertised(pol *Policy) []*net.IPNet {
 	}
 	return nets
 }
+
+// RedistributeList returns the prefixes learned via BGP that are redistributed
+// for the given policy and ISD-ASes. A learned prefix is redistributed if it is
+// a subset of the networks of a matching redistribute-bgp rule.
+func RedistributeList(
+	pol *Policy,
+	from, to addr.IA,
+	learned []netip.Prefix,
+) ([]netip.Prefix, error) {
+	return redistribute(pol, learned, func(r Rule) bool {
+		return r.From.Match(from) && r.To.Match(to)
+	})
+}
+
+// StaticRedistributed returns the prefixes learned via BGP that can be
+// redistributed to any remote AS. Used for reporting purposes.
+func StaticRedistributed(pol *Policy, learned []netip.Prefix) ([]netip.Prefix, error) {
+	return redistribute(pol, learned, func(Rule) bool { return true })
+}
+
+func redistribute(
+	pol *Policy,
+	learned []netip.Prefix,
+	match func(Rule) bool,
+) ([]netip.Prefix, error) {
+	if pol == nil {
+		return []netip.Prefix{}, nil
+	}
+	var sb netipx.IPSetBuilder
+	for _, r := range pol.Rules {
+		if r.Action != RedistributeBGP || !match(r) {
+			continue
+		}
+		set, err := r.Network.IPSet()
+		if err != nil {
+			return nil, err
+		}
+		sb.AddSet(set)
+	}
+	set, err := sb.IPSet()
+	if err != nil {
+		return nil, err
+	}
+	nets := []netip.Prefix{}
+	for _, prefix := range learned {
+		if set.ContainsPrefix(prefix) {
+			nets = append(nets, prefix)
+		}
+	}
+	return nets, nil
+}
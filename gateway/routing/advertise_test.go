@@ -81,3 +81,51 @@ func TestStaticAdvertiseList(t *testing.T) {
 		{IP: net.ParseIP("10.0.0.0").To4(), Mask: net.CIDRMask(16, 32)},
 	}, routing.StaticAdvertised(&policy))
 }
+
+func TestRedistributeList(t *testing.T) {
+	from := addr.MustIAFrom(1, 0)
+	to := addr.MustIAFrom(2, 0)
+	learned := xtest.MustParseIPPrefixes(t, "10.1.0.0/24", "10.2.0.0/16", "192.168.0.0/24")
+
+	policy := routing.Policy{DefaultAction: routing.Reject}
+
+	prefixes, err := routing.RedistributeList(nil, from, to, learned)
+	assert.NoError(t, err)
+	assert.Empty(t, prefixes)
+	prefixes, err = routing.RedistributeList(&policy, from, to, learned)
+	assert.NoError(t, err)
+	assert.Empty(t, prefixes)
+
+	policy.Rules = append(policy.Rules, routing.Rule{
+		Action:  routing.RedistributeBGP,
+		From:    routing.NewIAMatcher(t, "1-0"),
+		To:      routing.NewIAMatcher(t, "2-0"),
+		Network: routing.NewNetworkMatcher(t, "10.0.0.0/8"),
+	})
+	policy.Rules = append(policy.Rules, routing.Rule{
+		Action:  routing.Advertise,
+		From:    routing.NewIAMatcher(t, "1-0"),
+		To:      routing.NewIAMatcher(t, "2-0"),
+		Network: routing.NewNetworkMatcher(t, "192.168.0.0/16"),
+	})
+	policy.Rules = append(policy.Rules, routing.Rule{
+		Action:  routing.RedistributeBGP,
+		From:    routing.NewIAMatcher(t, "2-0"),
+		To:      routing.NewIAMatcher(t, "1-0"),
+		Network: routing.NewNetworkMatcher(t, "!10.1.0.0/24"),
+	})
+	prefixes, err = routing.RedistributeList(&policy, from, to, learned)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, xtest.MustParseIPPrefixes(t, "10.1.0.0/24", "10.2.0.0/16"), prefixes)
+	prefixes, err = routing.RedistributeList(&policy, to, from, learned)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, xtest.MustParseIPPrefixes(t, "10.2.0.0/16", "192.168.0.0/24"),
+		prefixes)
+	prefixes, err = routing.StaticRedistributed(&policy, learned)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, xtest.MustParseIPPrefixes(t, "10.1.0.0/24", "10.2.0.0/16",
+		"192.168.0.0/24"), prefixes)
+	prefixes, err = routing.StaticRedistributed(nil, learned)
+	assert.NoError(t, err)
+	assert.Empty(t, prefixes)
+}
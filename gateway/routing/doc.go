@@ -34,6 +34,8 @@
 //	accept    <a> <b> <prefixes>: <b> accepts the IP prefixes <prefixes> from <a>.
 //	reject    <a> <b> <prefixes>: <b> rejects the IP prefixes <prefixes> from <a>.
 //	advertise <a> <b> <prefixes>: <a> advertises the IP prefixes <prefixes> to <b>.
+//	redistribute-bgp <a> <b> <prefixes>: <a> advertises the IP prefixes learned via
+//	                 BGP to <b>, if they are a subset of <prefixes>.
 //
 // The remaining three columns define the matchers of a rule. The second and
 // third column are ISD-AS matchers, the forth column is a prefix matcher.
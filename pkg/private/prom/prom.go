@@ -68,6 +68,8 @@ const (
 	ErrNotFound = "err_not_found"
 	// ErrUnavailable is used for errors where a resource is not available.
 	ErrUnavailable = "err_unavailable"
+	// ErrLimit is used for errors where a limit has been reached.
+	ErrLimit = "err_limit"
 )
 
 // FIXME(roosd): remove when moving messenger to new metrics style.
@@ -27,11 +27,12 @@ const (
 )
 
 type ChainRenewalRequest struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	SignedRequest    *crypto.SignedMessage  `protobuf:"bytes,1,opt,name=signed_request,json=signedRequest,proto3" json:"signed_request,omitempty"`
-	CmsSignedRequest []byte                 `protobuf:"bytes,2,opt,name=cms_signed_request,json=cmsSignedRequest,proto3" json:"cms_signed_request,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	SignedRequest     *crypto.SignedMessage  `protobuf:"bytes,1,opt,name=signed_request,json=signedRequest,proto3" json:"signed_request,omitempty"`
+	CmsSignedRequest  []byte                 `protobuf:"bytes,2,opt,name=cms_signed_request,json=cmsSignedRequest,proto3" json:"cms_signed_request,omitempty"`
+	EnrollmentRequest *EnrollmentRequest     `protobuf:"bytes,3,opt,name=enrollment_request,json=enrollmentRequest,proto3" json:"enrollment_request,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *ChainRenewalRequest) Reset() {
@@ -78,6 +79,13 @@ func (x *ChainRenewalRequest) GetCmsSignedRequest() []byte {
 	return nil
 }
 
+func (x *ChainRenewalRequest) GetEnrollmentRequest() *EnrollmentRequest {
+	if x != nil {
+		return x.EnrollmentRequest
+	}
+	return nil
+}
+
 type ChainRenewalRequestBody struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Csr           []byte                 `protobuf:"bytes,1,opt,name=csr,proto3" json:"csr,omitempty"`
@@ -218,21 +226,77 @@ func (x *ChainRenewalResponseBody) GetChain() *Chain {
 	return nil
 }
 
+type EnrollmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Csr           []byte                 `protobuf:"bytes,2,opt,name=csr,proto3" json:"csr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollmentRequest) Reset() {
+	*x = EnrollmentRequest{}
+	mi := &file_proto_control_plane_v1_renewal_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollmentRequest) ProtoMessage() {}
+
+func (x *EnrollmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_renewal_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollmentRequest.ProtoReflect.Descriptor instead.
+func (*EnrollmentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_renewal_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EnrollmentRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *EnrollmentRequest) GetCsr() []byte {
+	if x != nil {
+		return x.Csr
+	}
+	return nil
+}
+
 var File_proto_control_plane_v1_renewal_proto protoreflect.FileDescriptor
 
 const file_proto_control_plane_v1_renewal_proto_rawDesc = "" +
 	"\n" +
-	"$proto/control_plane/v1/renewal.proto\x12\x16proto.control_plane.v1\x1a\"proto/control_plane/v1/cppki.proto\x1a\x1cproto/crypto/v1/signed.proto\"\x8a\x01\n" +
+	"$proto/control_plane/v1/renewal.proto\x12\x16proto.control_plane.v1\x1a\"proto/control_plane/v1/cppki.proto\x1a\x1cproto/crypto/v1/signed.proto\"\xe4\x01\n" +
 	"\x13ChainRenewalRequest\x12E\n" +
 	"\x0esigned_request\x18\x01 \x01(\v2\x1e.proto.crypto.v1.SignedMessageR\rsignedRequest\x12,\n" +
-	"\x12cms_signed_request\x18\x02 \x01(\fR\x10cmsSignedRequest\"+\n" +
+	"\x12cms_signed_request\x18\x02 \x01(\fR\x10cmsSignedRequest\x12X\n" +
+	"\x12enrollment_request\x18\x03 \x01(\v2).proto.control_plane.v1.EnrollmentRequestR\x11enrollmentRequest\"+\n" +
 	"\x17ChainRenewalRequestBody\x12\x10\n" +
 	"\x03csr\x18\x01 \x01(\fR\x03csr\"\x8f\x01\n" +
 	"\x14ChainRenewalResponse\x12G\n" +
 	"\x0fsigned_response\x18\x01 \x01(\v2\x1e.proto.crypto.v1.SignedMessageR\x0esignedResponse\x12.\n" +
 	"\x13cms_signed_response\x18\x02 \x01(\fR\x11cmsSignedResponse\"O\n" +
 	"\x18ChainRenewalResponseBody\x123\n" +
-	"\x05chain\x18\x01 \x01(\v2\x1d.proto.control_plane.v1.ChainR\x05chain2\x82\x01\n" +
+	"\x05chain\x18\x01 \x01(\v2\x1d.proto.control_plane.v1.ChainR\x05chain\";\n" +
+	"\x11EnrollmentRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x10\n" +
+	"\x03csr\x18\x02 \x01(\fR\x03csr2\x82\x01\n" +
 	"\x13ChainRenewalService\x12k\n" +
 	"\fChainRenewal\x12+.proto.control_plane.v1.ChainRenewalRequest\x1a,.proto.control_plane.v1.ChainRenewalResponse\"\x00B5Z3github.com/scionproto/scion/pkg/proto/control_planeb\x06proto3"
 
@@ -248,26 +312,28 @@ func file_proto_control_plane_v1_renewal_proto_rawDescGZIP() []byte {
 	return file_proto_control_plane_v1_renewal_proto_rawDescData
 }
 
-var file_proto_control_plane_v1_renewal_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto_control_plane_v1_renewal_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_proto_control_plane_v1_renewal_proto_goTypes = []any{
 	(*ChainRenewalRequest)(nil),      // 0: proto.control_plane.v1.ChainRenewalRequest
 	(*ChainRenewalRequestBody)(nil),  // 1: proto.control_plane.v1.ChainRenewalRequestBody
 	(*ChainRenewalResponse)(nil),     // 2: proto.control_plane.v1.ChainRenewalResponse
 	(*ChainRenewalResponseBody)(nil), // 3: proto.control_plane.v1.ChainRenewalResponseBody
-	(*crypto.SignedMessage)(nil),     // 4: proto.crypto.v1.SignedMessage
-	(*Chain)(nil),                    // 5: proto.control_plane.v1.Chain
+	(*EnrollmentRequest)(nil),        // 4: proto.control_plane.v1.EnrollmentRequest
+	(*crypto.SignedMessage)(nil),     // 5: proto.crypto.v1.SignedMessage
+	(*Chain)(nil),                    // 6: proto.control_plane.v1.Chain
 }
 var file_proto_control_plane_v1_renewal_proto_depIdxs = []int32{
-	4, // 0: proto.control_plane.v1.ChainRenewalRequest.signed_request:type_name -> proto.crypto.v1.SignedMessage
-	4, // 1: proto.control_plane.v1.ChainRenewalResponse.signed_response:type_name -> proto.crypto.v1.SignedMessage
-	5, // 2: proto.control_plane.v1.ChainRenewalResponseBody.chain:type_name -> proto.control_plane.v1.Chain
-	0, // 3: proto.control_plane.v1.ChainRenewalService.ChainRenewal:input_type -> proto.control_plane.v1.ChainRenewalRequest
-	2, // 4: proto.control_plane.v1.ChainRenewalService.ChainRenewal:output_type -> proto.control_plane.v1.ChainRenewalResponse
-	4, // [4:5] is the sub-list for method output_type
-	3, // [3:4] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	5, // 0: proto.control_plane.v1.ChainRenewalRequest.signed_request:type_name -> proto.crypto.v1.SignedMessage
+	4, // 1: proto.control_plane.v1.ChainRenewalRequest.enrollment_request:type_name -> proto.control_plane.v1.EnrollmentRequest
+	5, // 2: proto.control_plane.v1.ChainRenewalResponse.signed_response:type_name -> proto.crypto.v1.SignedMessage
+	6, // 3: proto.control_plane.v1.ChainRenewalResponseBody.chain:type_name -> proto.control_plane.v1.Chain
+	0, // 4: proto.control_plane.v1.ChainRenewalService.ChainRenewal:input_type -> proto.control_plane.v1.ChainRenewalRequest
+	2, // 5: proto.control_plane.v1.ChainRenewalService.ChainRenewal:output_type -> proto.control_plane.v1.ChainRenewalResponse
+	5, // [5:6] is the sub-list for method output_type
+	4, // [4:5] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_proto_control_plane_v1_renewal_proto_init() }
@@ -282,7 +348,7 @@ func file_proto_control_plane_v1_renewal_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_control_plane_v1_renewal_proto_rawDesc), len(file_proto_control_plane_v1_renewal_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
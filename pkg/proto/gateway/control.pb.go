@@ -21,6 +21,55 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type CipherSuite int32
+
+const (
+	CipherSuite_CIPHER_SUITE_NONE_UNSPECIFIED  CipherSuite = 0
+	CipherSuite_CIPHER_SUITE_AES_128_GCM       CipherSuite = 1
+	CipherSuite_CIPHER_SUITE_CHACHA20_POLY1305 CipherSuite = 2
+)
+
+// Enum value maps for CipherSuite.
+var (
+	CipherSuite_name = map[int32]string{
+		0: "CIPHER_SUITE_NONE_UNSPECIFIED",
+		1: "CIPHER_SUITE_AES_128_GCM",
+		2: "CIPHER_SUITE_CHACHA20_POLY1305",
+	}
+	CipherSuite_value = map[string]int32{
+		"CIPHER_SUITE_NONE_UNSPECIFIED":  0,
+		"CIPHER_SUITE_AES_128_GCM":       1,
+		"CIPHER_SUITE_CHACHA20_POLY1305": 2,
+	}
+)
+
+func (x CipherSuite) Enum() *CipherSuite {
+	p := new(CipherSuite)
+	*p = x
+	return p
+}
+
+func (x CipherSuite) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CipherSuite) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_gateway_v1_control_proto_enumTypes[0].Descriptor()
+}
+
+func (CipherSuite) Type() protoreflect.EnumType {
+	return &file_proto_gateway_v1_control_proto_enumTypes[0]
+}
+
+func (x CipherSuite) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CipherSuite.Descriptor instead.
+func (CipherSuite) EnumDescriptor() ([]byte, []int) {
+	return file_proto_gateway_v1_control_proto_rawDescGZIP(), []int{0}
+}
+
 type ControlRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Request:
@@ -209,6 +258,7 @@ type ProbeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SessionId     uint32                 `protobuf:"varint,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
 	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	CipherSuites  []CipherSuite          `protobuf:"varint,3,rep,packed,name=cipher_suites,json=cipherSuites,proto3,enum=proto.gateway.v1.CipherSuite" json:"cipher_suites,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -257,6 +307,13 @@ func (x *ProbeResponse) GetData() []byte {
 	return nil
 }
 
+func (x *ProbeResponse) GetCipherSuites() []CipherSuite {
+	if x != nil {
+		return x.CipherSuites
+	}
+	return nil
+}
+
 var File_proto_gateway_v1_control_proto protoreflect.FileDescriptor
 
 const file_proto_gateway_v1_control_proto_rawDesc = "" +
@@ -272,11 +329,16 @@ const file_proto_gateway_v1_control_proto_rawDesc = "" +
 	"\fProbeRequest\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\rR\tsessionId\x12\x12\n" +
-	"\x04data\x18\x02 \x01(\fR\x04data\"B\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"\x86\x01\n" +
 	"\rProbeResponse\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\rR\tsessionId\x12\x12\n" +
-	"\x04data\x18\x02 \x01(\fR\x04dataB/Z-github.com/scionproto/scion/pkg/proto/gatewayb\x06proto3"
+	"\x04data\x18\x02 \x01(\fR\x04data\x12B\n" +
+	"\rcipher_suites\x18\x03 \x03(\x0e2\x1d.proto.gateway.v1.CipherSuiteR\fcipherSuites*r\n" +
+	"\vCipherSuite\x12!\n" +
+	"\x1dCIPHER_SUITE_NONE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18CIPHER_SUITE_AES_128_GCM\x10\x01\x12\"\n" +
+	"\x1eCIPHER_SUITE_CHACHA20_POLY1305\x10\x02B/Z-github.com/scionproto/scion/pkg/proto/gatewayb\x06proto3"
 
 var (
 	file_proto_gateway_v1_control_proto_rawDescOnce sync.Once
@@ -290,21 +352,24 @@ func file_proto_gateway_v1_control_proto_rawDescGZIP() []byte {
 	return file_proto_gateway_v1_control_proto_rawDescData
 }
 
+var file_proto_gateway_v1_control_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
 var file_proto_gateway_v1_control_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_proto_gateway_v1_control_proto_goTypes = []any{
-	(*ControlRequest)(nil),  // 0: proto.gateway.v1.ControlRequest
-	(*ControlResponse)(nil), // 1: proto.gateway.v1.ControlResponse
-	(*ProbeRequest)(nil),    // 2: proto.gateway.v1.ProbeRequest
-	(*ProbeResponse)(nil),   // 3: proto.gateway.v1.ProbeResponse
+	(CipherSuite)(0),        // 0: proto.gateway.v1.CipherSuite
+	(*ControlRequest)(nil),  // 1: proto.gateway.v1.ControlRequest
+	(*ControlResponse)(nil), // 2: proto.gateway.v1.ControlResponse
+	(*ProbeRequest)(nil),    // 3: proto.gateway.v1.ProbeRequest
+	(*ProbeResponse)(nil),   // 4: proto.gateway.v1.ProbeResponse
 }
 var file_proto_gateway_v1_control_proto_depIdxs = []int32{
-	2, // 0: proto.gateway.v1.ControlRequest.probe:type_name -> proto.gateway.v1.ProbeRequest
-	3, // 1: proto.gateway.v1.ControlResponse.probe:type_name -> proto.gateway.v1.ProbeResponse
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	3, // 0: proto.gateway.v1.ControlRequest.probe:type_name -> proto.gateway.v1.ProbeRequest
+	4, // 1: proto.gateway.v1.ControlResponse.probe:type_name -> proto.gateway.v1.ProbeResponse
+	0, // 2: proto.gateway.v1.ProbeResponse.cipher_suites:type_name -> proto.gateway.v1.CipherSuite
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_proto_gateway_v1_control_proto_init() }
@@ -323,13 +388,14 @@ func file_proto_gateway_v1_control_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_gateway_v1_control_proto_rawDesc), len(file_proto_gateway_v1_control_proto_rawDesc)),
-			NumEnums:      0,
+			NumEnums:      1,
 			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_proto_gateway_v1_control_proto_goTypes,
 		DependencyIndexes: file_proto_gateway_v1_control_proto_depIdxs,
+		EnumInfos:         file_proto_gateway_v1_control_proto_enumTypes,
 		MessageInfos:      file_proto_gateway_v1_control_proto_msgTypes,
 	}.Build()
 	File_proto_gateway_v1_control_proto = out.File
@@ -0,0 +1,357 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package multipath implements a datagram connection that manages its own paths.
+//
+// A Conn sends to a fixed remote over the paths that the daemon returns and that the path
+// policy allows. The paths are probed continuously, and every packet is sent according to the
+// selection strategy over the paths that are alive and not revoked. Paths are thus switched
+// without the application noticing when they fail or when a router reports an interface down.
+// Interface down reports are picked up both from the probes and from the packets read on the
+// connection, so that a connection that only sends also fails over.
+//
+// Example:
+//
+//	prober, err := probe.New(ctx, probe.Config{
+//		Topology: topo,
+//		LocalIA:  local.IA,
+//		LocalIP:  localIP,
+//	})
+//	...
+//	conn, err := network.Listen(ctx, "udp", local.Host)
+//	...
+//	mc, err := multipath.Dial(ctx, conn, remote, multipath.Config{
+//		Connector: daemonConn,
+//		Prober:    prober,
+//		Strategy:  multipath.LowestLatency,
+//	})
+package multipath
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/daemon/types"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/private/path/pathpol"
+)
+
+const (
+	defaultProbeInterval      = 500 * time.Millisecond
+	defaultPathUpdateInterval = 10 * time.Second
+	defaultPathFetchTimeout   = 10 * time.Second
+	defaultRedundantPaths     = 2
+)
+
+// ErrNoPath is returned by Write if there is no path to the remote.
+var ErrNoPath = errors.New("no path available")
+
+// Strategy determines the paths that a packet is sent on.
+type Strategy int
+
+const (
+	// LowestLatency sends every packet on the path with the lowest probed round-trip time.
+	LowestLatency Strategy = iota
+	// RoundRobin sends the packets on the usable paths in turn.
+	RoundRobin
+	// Redundant sends every packet on several paths, the ones with the lowest round-trip
+	// time. The remote receives duplicates.
+	Redundant
+)
+
+func (s Strategy) String() string {
+	switch s {
+	case LowestLatency:
+		return "lowest_latency"
+	case RoundRobin:
+		return "round_robin"
+	case Redundant:
+		return "redundant"
+	default:
+		return "unknown"
+	}
+}
+
+// Prober measures the round-trip time of a path.
+type Prober interface {
+	// Probe returns the round-trip time of the path, or an error if no answer was received
+	// before the context is done.
+	Probe(ctx context.Context, path snet.Path) (time.Duration, error)
+}
+
+// Config configures a Conn.
+type Config struct {
+	// Connector is used to look up the paths to the remote.
+	Connector daemon.Connector
+	// Policy filters the paths to the remote. If nil, all paths are allowed.
+	Policy *pathpol.Policy
+	// Prober probes the paths, typically a *probe.Prober. If a probe fails with a
+	// *snet.OpError that carries a revocation, the revoked interface is avoided.
+	Prober Prober
+	// Strategy determines the paths that a packet is sent on.
+	Strategy Strategy
+	// RedundantPaths is the number of paths a packet is sent on with the Redundant strategy.
+	// If not set, a default of 2 is used.
+	RedundantPaths int
+	// ProbeInterval is the interval at which every path is probed. A probe that is not
+	// answered within the interval fails. If not set, a default of 500ms is used.
+	ProbeInterval time.Duration
+	// PathUpdateInterval is the interval at which the paths are looked up. If not set, a
+	// default of 10s is used.
+	PathUpdateInterval time.Duration
+}
+
+func (c *Config) initDefaults() {
+	if c.RedundantPaths == 0 {
+		c.RedundantPaths = defaultRedundantPaths
+	}
+	if c.ProbeInterval == 0 {
+		c.ProbeInterval = defaultProbeInterval
+	}
+	if c.PathUpdateInterval == 0 {
+		c.PathUpdateInterval = defaultPathUpdateInterval
+	}
+}
+
+var _ net.Conn = (*Conn)(nil)
+
+// Conn is a datagram connection to a fixed remote that selects the paths it sends on.
+type Conn struct {
+	conn   net.PacketConn
+	local  *snet.UDPAddr
+	remote *snet.UDPAddr
+	cfg    Config
+
+	paths *pathSet
+	// refresh requests an immediate path lookup, after a revocation.
+	refresh chan struct{}
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	once    sync.Once
+}
+
+// Dial returns a connection to remote that sends over conn, typically a *snet.Conn obtained
+// with snet.SCIONNetwork.Listen. The connection takes ownership of conn, but not of the prober.
+// The paths of remote are ignored. Dial fails if no path to remote is found.
+func Dial(
+	ctx context.Context,
+	conn net.PacketConn,
+	remote *snet.UDPAddr,
+	cfg Config,
+) (*Conn, error) {
+	if cfg.Connector == nil {
+		return nil, serrors.New("connector is required")
+	}
+	if cfg.Prober == nil {
+		return nil, serrors.New("prober is required")
+	}
+	local, ok := conn.LocalAddr().(*snet.UDPAddr)
+	if !ok {
+		return nil, serrors.New("local address is not a SCION address", "addr", conn.LocalAddr())
+	}
+	cfg.initDefaults()
+	c := &Conn{
+		conn:    conn,
+		local:   local,
+		remote:  remote.Copy(),
+		cfg:     cfg,
+		paths:   newPathSet(remote, cfg.Strategy, cfg.RedundantPaths),
+		refresh: make(chan struct{}, 1),
+	}
+	if err := c.updatePaths(ctx, false); err != nil {
+		conn.Close()
+		return nil, serrors.Wrap("looking up paths", err, "remote", remote.IA)
+	}
+	runCtx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.wg.Add(1)
+	go func() {
+		defer log.HandlePanic()
+		defer c.wg.Done()
+		c.run(runCtx)
+	}()
+	return c, nil
+}
+
+// Read reads a packet from the remote. Packets from other sources are dropped. Revocations
+// received on the connection are handled, and not returned.
+func (c *Conn) Read(b []byte) (int, error) {
+	for {
+		n, from, err := c.conn.ReadFrom(b)
+		if err != nil {
+			var opErr *snet.OpError
+			if errors.As(err, &opErr) && opErr.RevInfo() != nil {
+				c.revoke(opErr.RevInfo())
+				continue
+			}
+			return n, err
+		}
+		a, ok := from.(*snet.UDPAddr)
+		if !ok || !a.IA.Equal(c.remote.IA) || !a.Host.IP.Equal(c.remote.Host.IP) ||
+			a.Host.Port != c.remote.Host.Port {
+			continue
+		}
+		return n, nil
+	}
+}
+
+// Write sends a packet to the remote, on the paths chosen by the strategy. It only fails if
+// the packet could not be sent on any of them.
+func (c *Conn) Write(b []byte) (int, error) {
+	dsts := c.paths.pick()
+	if len(dsts) == 0 {
+		return 0, ErrNoPath
+	}
+	var firstErr error
+	sent := false
+	for _, dst := range dsts {
+		_, err := c.conn.WriteTo(b, dst.addr)
+		c.paths.recordSent(dst.fingerprint, len(b), err)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		sent = sent || err == nil
+	}
+	if !sent {
+		return 0, firstErr
+	}
+	return len(b), nil
+}
+
+// Close closes the connection and stops managing its paths.
+func (c *Conn) Close() error {
+	var err error
+	c.once.Do(func() {
+		c.cancel()
+		err = c.conn.Close()
+		c.wg.Wait()
+	})
+	return err
+}
+
+// Stats returns the statistics of the paths, the best ones first.
+func (c *Conn) Stats() []PathStats {
+	return c.paths.stats()
+}
+
+func (c *Conn) LocalAddr() net.Addr {
+	return c.local
+}
+
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.remote
+}
+
+func (c *Conn) SetDeadline(t time.Time) error {
+	return c.conn.SetDeadline(t)
+}
+
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	return c.conn.SetWriteDeadline(t)
+}
+
+func (c *Conn) run(ctx context.Context) {
+	c.probe(ctx)
+	updateTicker := time.NewTicker(c.cfg.PathUpdateInterval)
+	defer updateTicker.Stop()
+	probeTicker := time.NewTicker(c.cfg.ProbeInterval)
+	defer probeTicker.Stop()
+	for {
+		select {
+		case <-updateTicker.C:
+			c.logUpdateError(ctx, c.updatePaths(ctx, false))
+		case <-c.refresh:
+			c.logUpdateError(ctx, c.updatePaths(ctx, true))
+		case <-probeTicker.C:
+			c.probe(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *Conn) logUpdateError(ctx context.Context, err error) {
+	if err != nil && ctx.Err() == nil {
+		log.FromCtx(ctx).Info("Failed to update paths, keeping old paths",
+			"remote", c.remote.IA, "err", err)
+	}
+}
+
+// updatePaths looks up the paths to the remote. The statistics of the known paths are kept.
+func (c *Conn) updatePaths(ctx context.Context, refresh bool) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultPathFetchTimeout)
+	defer cancel()
+	paths, err := c.cfg.Connector.Paths(ctx, c.remote.IA, c.local.IA,
+		types.PathReqFlags{Refresh: refresh})
+	if err != nil {
+		return err
+	}
+	if c.cfg.Policy != nil {
+		paths = c.cfg.Policy.Filter(paths)
+	}
+	if len(paths) == 0 {
+		return serrors.Wrap("filtering paths", ErrNoPath)
+	}
+	c.paths.update(paths, time.Now())
+	return nil
+}
+
+// probe starts probing the paths that do not have a probe in flight.
+func (c *Conn) probe(ctx context.Context) {
+	for _, path := range c.paths.startProbes() {
+		c.wg.Add(1)
+		go func() {
+			defer log.HandlePanic()
+			defer c.wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, c.cfg.ProbeInterval)
+			defer cancel()
+			rtt, err := c.cfg.Prober.Probe(probeCtx, path)
+			if ctx.Err() != nil {
+				return
+			}
+			var opErr *snet.OpError
+			if errors.As(err, &opErr) && opErr.RevInfo() != nil {
+				c.revoke(opErr.RevInfo())
+			}
+			c.paths.recordProbe(path.Metadata().Fingerprint(), rtt, err)
+		}()
+	}
+	c.paths.rank(time.Now())
+}
+
+// revoke stops using the paths that go through the revoked interface, and requests fresh
+// paths.
+func (c *Conn) revoke(rev *path_mgmt.RevInfo) {
+	iface := snet.PathInterface{IA: rev.IA(), ID: rev.IfID}
+	if !c.paths.revoke(iface, rev.Expiration(), time.Now()) {
+		return
+	}
+	log.Debug("Path interface revoked", "remote", c.remote.IA, "isd_as", iface.IA,
+		"intf", iface.ID)
+	select {
+	case c.refresh <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,370 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multipath_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/daemon/mock_daemon"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/segment/iface"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/snet/multipath"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+	"github.com/scionproto/scion/private/path/pathpol"
+)
+
+var (
+	localIA  = addr.MustParseIA("1-ff00:0:110")
+	remoteIA = addr.MustParseIA("1-ff00:0:111")
+)
+
+// fakeConn records the next hops that packets are sent to.
+type fakeConn struct {
+	local *snet.UDPAddr
+	in    chan any
+	done  chan struct{}
+	once  sync.Once
+
+	mtx  sync.Mutex
+	sent map[string]int
+}
+
+func newFakeConn() *fakeConn {
+	return &fakeConn{
+		local: &snet.UDPAddr{IA: localIA, Host: &net.UDPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1}},
+		in:    make(chan any, 10),
+		done:  make(chan struct{}),
+		sent:  make(map[string]int),
+	}
+}
+
+func (c *fakeConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	select {
+	case v := <-c.in:
+		switch v := v.(type) {
+		case error:
+			return 0, nil, v
+		case *snet.UDPAddr:
+			return copy(b, "data"), v, nil
+		}
+		panic("unexpected value")
+	case <-c.done:
+		return 0, nil, net.ErrClosed
+	}
+}
+
+func (c *fakeConn) WriteTo(b []byte, a net.Addr) (int, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.sent[a.(*snet.UDPAddr).NextHop.String()]++
+	return len(b), nil
+}
+
+func (c *fakeConn) sentTo() map[string]int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	sent := c.sent
+	c.sent = make(map[string]int)
+	return sent
+}
+
+func (c *fakeConn) Close() error {
+	c.once.Do(func() { close(c.done) })
+	return nil
+}
+
+func (c *fakeConn) LocalAddr() net.Addr                { return c.local }
+func (c *fakeConn) SetDeadline(t time.Time) error      { return nil }
+func (c *fakeConn) SetReadDeadline(t time.Time) error  { return nil }
+func (c *fakeConn) SetWriteDeadline(t time.Time) error { return nil }
+
+// fakeProber answers the probes with the configured round-trip time or error, or not at all.
+type fakeProber struct {
+	mtx  sync.Mutex
+	rtts map[snet.PathFingerprint]time.Duration
+	errs map[snet.PathFingerprint]error
+}
+
+func (p *fakeProber) set(path snet.Path, rtt time.Duration) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.rtts[path.Metadata().Fingerprint()] = rtt
+}
+
+func (p *fakeProber) Probe(ctx context.Context, path snet.Path) (time.Duration, error) {
+	p.mtx.Lock()
+	rtt, ok := p.rtts[path.Metadata().Fingerprint()]
+	err := p.errs[path.Metadata().Fingerprint()]
+	p.mtx.Unlock()
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}
+	return rtt, nil
+}
+
+func testPath(nextHop string, ifIDs ...uint16) snet.Path {
+	var intfs []snet.PathInterface
+	for i, id := range ifIDs {
+		ia := localIA
+		if i%2 == 1 {
+			ia = remoteIA
+		}
+		intfs = append(intfs, snet.PathInterface{IA: ia, ID: iface.ID(id)})
+	}
+	return snetpath.Path{
+		Src:           localIA,
+		Dst:           remoteIA,
+		DataplanePath: snetpath.Empty{},
+		NextHop:       &net.UDPAddr{IP: net.ParseIP(nextHop), Port: 30041},
+		Meta: snet.PathMetadata{
+			Interfaces: intfs,
+			Expiry:     time.Now().Add(time.Hour),
+		},
+	}
+}
+
+var (
+	fastPath = testPath("192.168.0.1", 1, 2)
+	slowPath = testPath("192.168.0.2", 3, 4)
+	deadPath = testPath("192.168.0.3", 5, 6)
+)
+
+func nextHop(path snet.Path) string {
+	return path.UnderlayNextHop().String()
+}
+
+func remoteAddr() *snet.UDPAddr {
+	return &snet.UDPAddr{IA: remoteIA, Host: &net.UDPAddr{IP: net.ParseIP("10.0.0.2"), Port: 2}}
+}
+
+func dial(
+	t *testing.T,
+	cfg multipath.Config,
+	paths ...snet.Path,
+) (*multipath.Conn, *fakeConn, *fakeProber) {
+	prober := &fakeProber{rtts: map[snet.PathFingerprint]time.Duration{
+		fastPath.Metadata().Fingerprint(): 10 * time.Millisecond,
+		slowPath.Metadata().Fingerprint(): 50 * time.Millisecond,
+	}}
+	cfg.Connector = connectorWith(t, paths...)
+	cfg.Prober = prober
+	cfg.ProbeInterval = 10 * time.Millisecond
+	conn := newFakeConn()
+	c, err := multipath.Dial(context.Background(), conn, remoteAddr(), cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+	return c, conn, prober
+}
+
+// waitStats waits until the statistics of the paths satisfy cond.
+func waitStats(t *testing.T, c *multipath.Conn, cond func([]multipath.PathStats) bool) {
+	t.Helper()
+	require.Eventually(t, func() bool { return cond(c.Stats()) }, 5*time.Second,
+		10*time.Millisecond)
+}
+
+func probed(stats []multipath.PathStats) bool {
+	for _, s := range stats {
+		if s.Alive && s.RTT == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func write(t *testing.T, c *multipath.Conn, n int) {
+	for range n {
+		_, err := c.Write([]byte("data"))
+		require.NoError(t, err)
+	}
+}
+
+func TestConnStrategies(t *testing.T) {
+	t.Run("lowest latency", func(t *testing.T) {
+		c, conn, _ := dial(t, multipath.Config{}, slowPath, fastPath, deadPath)
+		waitStats(t, c, func(stats []multipath.PathStats) bool {
+			return probed(stats) && !stats[2].Alive
+		})
+		write(t, c, 4)
+		assert.Equal(t, map[string]int{nextHop(fastPath): 4}, conn.sentTo())
+
+		stats := c.Stats()
+		require.Len(t, stats, 3)
+		assert.Equal(t, fastPath.Metadata().Fingerprint(), stats[0].Fingerprint)
+		assert.Equal(t, uint64(4), stats[0].PacketsSent)
+		assert.Equal(t, uint64(16), stats[0].BytesSent)
+		assert.Equal(t, 10*time.Millisecond, stats[0].RTT)
+		assert.Equal(t, slowPath.Metadata().Fingerprint(), stats[1].Fingerprint)
+		assert.Equal(t, deadPath.Metadata().Fingerprint(), stats[2].Fingerprint)
+		assert.False(t, stats[2].Alive)
+		assert.NotZero(t, stats[2].ProbesLost)
+	})
+	t.Run("round robin", func(t *testing.T) {
+		c, conn, _ := dial(t, multipath.Config{Strategy: multipath.RoundRobin},
+			slowPath, fastPath, deadPath)
+		waitStats(t, c, func(stats []multipath.PathStats) bool {
+			return probed(stats) && !stats[2].Alive
+		})
+		write(t, c, 4)
+		assert.Equal(t, map[string]int{nextHop(fastPath): 2, nextHop(slowPath): 2},
+			conn.sentTo())
+	})
+	t.Run("redundant", func(t *testing.T) {
+		c, conn, _ := dial(t, multipath.Config{Strategy: multipath.Redundant},
+			slowPath, fastPath, deadPath)
+		waitStats(t, c, func(stats []multipath.PathStats) bool {
+			return probed(stats) && !stats[2].Alive
+		})
+		write(t, c, 4)
+		assert.Equal(t, map[string]int{nextHop(fastPath): 4, nextHop(slowPath): 4},
+			conn.sentTo())
+	})
+}
+
+func TestConnFailover(t *testing.T) {
+	t.Run("dead path", func(t *testing.T) {
+		c, conn, prober := dial(t, multipath.Config{}, slowPath, fastPath)
+		waitStats(t, c, probed)
+		write(t, c, 1)
+		assert.Equal(t, map[string]int{nextHop(fastPath): 1}, conn.sentTo())
+
+		prober.mtx.Lock()
+		delete(prober.rtts, fastPath.Metadata().Fingerprint())
+		prober.mtx.Unlock()
+		waitStats(t, c, func(stats []multipath.PathStats) bool {
+			return stats[0].Fingerprint == slowPath.Metadata().Fingerprint()
+		})
+		write(t, c, 1)
+		assert.Equal(t, map[string]int{nextHop(slowPath): 1}, conn.sentTo())
+
+		prober.set(fastPath, time.Millisecond)
+		waitStats(t, c, func(stats []multipath.PathStats) bool {
+			return stats[0].Fingerprint == fastPath.Metadata().Fingerprint()
+		})
+	})
+	t.Run("revocation", func(t *testing.T) {
+		c, conn, _ := dial(t, multipath.Config{}, slowPath, fastPath)
+		waitStats(t, c, probed)
+
+		// The revocation is handled by Read, which returns the next packet.
+		conn.in <- revocation(t, 2)
+		conn.in <- &snet.UDPAddr{IA: remoteIA, Host: &net.UDPAddr{IP: net.ParseIP("10.0.0.9")}}
+		conn.in <- remoteAddr()
+		n, err := c.Read(make([]byte, 10))
+		require.NoError(t, err)
+		assert.Equal(t, 4, n)
+
+		write(t, c, 1)
+		assert.Equal(t, map[string]int{nextHop(slowPath): 1}, conn.sentTo())
+		stats := c.Stats()
+		assert.True(t, stats[1].Revoked)
+		assert.True(t, stats[1].Alive)
+	})
+	t.Run("revocation on probe", func(t *testing.T) {
+		c, conn, prober := dial(t, multipath.Config{}, slowPath, fastPath)
+		waitStats(t, c, probed)
+
+		// The connection is never read from, the revocation comes from the probes.
+		prober.mtx.Lock()
+		prober.errs = map[snet.PathFingerprint]error{
+			fastPath.Metadata().Fingerprint(): revocation(t, 2),
+		}
+		prober.mtx.Unlock()
+		waitStats(t, c, func(stats []multipath.PathStats) bool {
+			return stats[0].Fingerprint == slowPath.Metadata().Fingerprint() && stats[1].Revoked
+		})
+		write(t, c, 1)
+		assert.Equal(t, map[string]int{nextHop(slowPath): 1}, conn.sentTo())
+	})
+	t.Run("all paths dead", func(t *testing.T) {
+		c, conn, prober := dial(t, multipath.Config{}, deadPath)
+		waitStats(t, c, func(stats []multipath.PathStats) bool { return !stats[0].Alive })
+		write(t, c, 1)
+		assert.Equal(t, map[string]int{nextHop(deadPath): 1}, conn.sentTo())
+		prober.set(deadPath, time.Millisecond)
+		waitStats(t, c, func(stats []multipath.PathStats) bool { return stats[0].Alive })
+	})
+}
+
+func TestConnPolicy(t *testing.T) {
+	var deny, allow pathpol.ACLEntry
+	require.NoError(t, deny.LoadFromString("- 1-ff00:0:110#1"))
+	require.NoError(t, allow.LoadFromString("+"))
+	acl, err := pathpol.NewACL(&deny, &allow)
+	require.NoError(t, err)
+
+	c, conn, _ := dial(t, multipath.Config{Policy: pathpol.NewPolicy("", acl, nil, nil)},
+		slowPath, fastPath)
+	stats := c.Stats()
+	require.Len(t, stats, 1)
+	assert.Equal(t, slowPath.Metadata().Fingerprint(), stats[0].Fingerprint)
+	write(t, c, 1)
+	assert.Equal(t, map[string]int{nextHop(slowPath): 1}, conn.sentTo())
+
+	_, err = multipath.Dial(context.Background(), newFakeConn(), remoteAddr(), multipath.Config{
+		Connector: connectorWith(t, fastPath),
+		Prober:    &fakeProber{},
+		Policy:    pathpol.NewPolicy("", acl, nil, nil),
+	})
+	assert.ErrorIs(t, err, multipath.ErrNoPath)
+}
+
+// revocation returns the error that a connection reports for an external interface down
+// message about the interface of the remote AS.
+func revocation(t *testing.T, ifID uint16) error {
+	err := snet.DefaultSCMPHandler{}.Handle(&snet.Packet{
+		PacketInfo: snet.PacketInfo{
+			Payload: snet.SCMPExternalInterfaceDown{IA: remoteIA, Interface: uint64(ifID)},
+		},
+	})
+	require.Error(t, err)
+	return err
+}
+
+func connectorWith(t *testing.T, paths ...snet.Path) *mock_daemon.MockConnector {
+	ctrl := gomock.NewController(t)
+	connector := mock_daemon.NewMockConnector(ctrl)
+	connector.EXPECT().Paths(gomock.Any(), remoteIA, localIA, gomock.Any()).
+		Return(paths, nil).AnyTimes()
+	return connector
+}
+
+func TestDialErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	connector := mock_daemon.NewMockConnector(ctrl)
+	connector.EXPECT().Paths(gomock.Any(), remoteIA, localIA, gomock.Any()).
+		Return(nil, serrors.New("daemon unavailable"))
+	_, err := multipath.Dial(context.Background(), newFakeConn(), remoteAddr(),
+		multipath.Config{Connector: connector, Prober: &fakeProber{}})
+	assert.Error(t, err)
+
+	_, err = multipath.Dial(context.Background(), newFakeConn(), remoteAddr(),
+		multipath.Config{Connector: connectorWith(t)})
+	assert.Error(t, err)
+}
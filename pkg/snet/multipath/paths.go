@@ -0,0 +1,308 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multipath
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+// deadAfter is the number of consecutive failed probes after which a path is considered dead.
+const deadAfter = 3
+
+// PathStats are the statistics of a path of a Conn.
+type PathStats struct {
+	// Path is the path.
+	Path snet.Path
+	// Fingerprint is the fingerprint of the path.
+	Fingerprint snet.PathFingerprint
+	// Alive is whether the path is alive, that is, the last probes were not all lost. Paths
+	// that were not probed yet are alive.
+	Alive bool
+	// Revoked is whether an interface of the path is revoked.
+	Revoked bool
+	// RTT is the smoothed round-trip time of the probes. It is zero if no probe was answered.
+	RTT time.Duration
+	// ProbesSent is the number of probes sent on the path.
+	ProbesSent uint64
+	// ProbesLost is the number of probes that were not answered in time.
+	ProbesLost uint64
+	// PacketsSent is the number of packets sent on the path.
+	PacketsSent uint64
+	// BytesSent is the number of payload bytes sent on the path.
+	BytesSent uint64
+	// SendErrors is the number of packets that could not be sent on the path.
+	SendErrors uint64
+}
+
+// destination is a remote address with a path set.
+type destination struct {
+	addr        *snet.UDPAddr
+	fingerprint snet.PathFingerprint
+}
+
+type pathEntry struct {
+	PathStats
+	dst destination
+	// failures is the number of consecutive failed probes.
+	failures int
+	probing  bool
+}
+
+// usable is whether packets should be sent on the path, if there is a choice.
+func (e *pathEntry) usable() bool {
+	return e.Alive && !e.Revoked
+}
+
+// pathSet holds the paths of a connection and decides which ones are used.
+type pathSet struct {
+	remote    *snet.UDPAddr
+	strategy  Strategy
+	redundant int
+
+	mtx   sync.Mutex
+	paths map[snet.PathFingerprint]*pathEntry
+	// ranked are the paths, best first.
+	ranked []*pathEntry
+	// revoked maps the revoked interfaces to the expiration of the revocation.
+	revoked map[snet.PathInterface]time.Time
+	// next is the round-robin counter.
+	next int
+}
+
+func newPathSet(remote *snet.UDPAddr, strategy Strategy, redundant int) *pathSet {
+	return &pathSet{
+		remote:    remote,
+		strategy:  strategy,
+		redundant: redundant,
+		paths:     make(map[snet.PathFingerprint]*pathEntry),
+		revoked:   make(map[snet.PathInterface]time.Time),
+	}
+}
+
+// update replaces the paths. The statistics of the paths that are already known are kept.
+func (s *pathSet) update(paths []snet.Path, now time.Time) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	fresh := make(map[snet.PathFingerprint]*pathEntry, len(paths))
+	for _, path := range paths {
+		fp := path.Metadata().Fingerprint()
+		e, ok := s.paths[fp]
+		if !ok {
+			e = &pathEntry{PathStats: PathStats{Fingerprint: fp, Alive: true}}
+		}
+		e.Path = path
+		dst := s.remote.Copy()
+		dst.Path = path.Dataplane()
+		dst.NextHop = path.UnderlayNextHop()
+		e.dst = destination{addr: dst, fingerprint: fp}
+		fresh[fp] = e
+	}
+	s.paths = fresh
+	s.rankLocked(now)
+}
+
+// startProbes returns the paths to probe, and marks them as being probed. Paths inside the
+// local AS are not probed.
+func (s *pathSet) startProbes() []snet.Path {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	var paths []snet.Path
+	for _, e := range s.paths {
+		if e.probing || len(interfaces(e.Path)) == 0 {
+			continue
+		}
+		e.probing = true
+		e.ProbesSent++
+		paths = append(paths, e.Path)
+	}
+	return paths
+}
+
+func (s *pathSet) recordProbe(fp snet.PathFingerprint, rtt time.Duration, err error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	e, ok := s.paths[fp]
+	if !ok {
+		return
+	}
+	e.probing = false
+	if err != nil {
+		e.ProbesLost++
+		e.failures++
+		e.Alive = e.failures < deadAfter
+	} else {
+		e.failures = 0
+		e.Alive = true
+		// Smoothed like the RTT of TCP (RFC 6298).
+		if e.RTT == 0 {
+			e.RTT = rtt
+		} else {
+			e.RTT = (7*e.RTT + rtt) / 8
+		}
+	}
+	s.rankLocked(time.Now())
+}
+
+// revoke records a revoked interface, and reports whether it affects a path.
+func (s *pathSet) revoke(iface snet.PathInterface, expiration, now time.Time) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if !expiration.After(now) {
+		return false
+	}
+	s.revoked[iface] = expiration
+	affected := false
+	for _, e := range s.paths {
+		if containsInterface(e.Path, iface) {
+			affected = true
+		}
+	}
+	s.rankLocked(now)
+	return affected
+}
+
+// rank sorts the paths after their state changed.
+func (s *pathSet) rank(now time.Time) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.rankLocked(now)
+}
+
+func (s *pathSet) rankLocked(now time.Time) {
+	for iface, expiration := range s.revoked {
+		if !expiration.After(now) {
+			delete(s.revoked, iface)
+		}
+	}
+	s.ranked = s.ranked[:0]
+	for _, e := range s.paths {
+		if meta := e.Path.Metadata(); meta != nil && !meta.Expiry.IsZero() &&
+			meta.Expiry.Before(now) {
+			continue
+		}
+		e.Revoked = false
+		for _, iface := range interfaces(e.Path) {
+			if _, ok := s.revoked[snet.PathInterface{IA: iface.IA, ID: iface.ID}]; ok {
+				e.Revoked = true
+				break
+			}
+		}
+		s.ranked = append(s.ranked, e)
+	}
+	sort.Slice(s.ranked, func(i, j int) bool {
+		a, b := s.ranked[i], s.ranked[j]
+		if a.usable() != b.usable() {
+			return a.usable()
+		}
+		if a.Revoked != b.Revoked {
+			return !a.Revoked
+		}
+		// Paths with a measured round-trip time come first.
+		if (a.RTT == 0) != (b.RTT == 0) {
+			return a.RTT != 0
+		}
+		if a.RTT != b.RTT {
+			return a.RTT < b.RTT
+		}
+		la, lb := len(interfaces(a.Path)), len(interfaces(b.Path))
+		if la != lb {
+			return la < lb
+		}
+		return a.Fingerprint < b.Fingerprint
+	})
+}
+
+// pick returns the destinations to send the next packet to. If no path is usable, the best of
+// the remaining ones is used, in case the probes are lost but not the packets.
+func (s *pathSet) pick() []destination {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if len(s.ranked) == 0 {
+		return nil
+	}
+	usable := 0
+	for usable < len(s.ranked) && s.ranked[usable].usable() {
+		usable++
+	}
+	if usable == 0 {
+		return []destination{s.ranked[0].dst}
+	}
+	switch s.strategy {
+	case RoundRobin:
+		s.next = (s.next + 1) % usable
+		return []destination{s.ranked[s.next].dst}
+	case Redundant:
+		n := min(s.redundant, usable)
+		dsts := make([]destination, 0, n)
+		for _, e := range s.ranked[:n] {
+			dsts = append(dsts, e.dst)
+		}
+		return dsts
+	default:
+		return []destination{s.ranked[0].dst}
+	}
+}
+
+func (s *pathSet) recordSent(fp snet.PathFingerprint, n int, err error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	e, ok := s.paths[fp]
+	if !ok {
+		return
+	}
+	if err != nil {
+		e.SendErrors++
+		return
+	}
+	e.PacketsSent++
+	e.BytesSent += uint64(n)
+}
+
+func (s *pathSet) stats() []PathStats {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	stats := make([]PathStats, 0, len(s.ranked))
+	for _, e := range s.ranked {
+		stats = append(stats, e.PathStats)
+	}
+	return stats
+}
+
+func interfaces(path snet.Path) []snet.PathInterface {
+	if meta := path.Metadata(); meta != nil {
+		return meta.Interfaces
+	}
+	return nil
+}
+
+func containsInterface(path snet.Path, iface snet.PathInterface) bool {
+	for _, i := range interfaces(path) {
+		if i.IA.Equal(iface.IA) && i.ID == iface.ID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,377 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/stun"
+)
+
+const (
+	defaultSTUNKeepaliveInterval = 15 * time.Second
+	defaultSTUNTimeout           = time.Second
+	// natQueueLen is the number of packets that are kept for the application while the
+	// connection is read to wait for a STUN response.
+	natQueueLen = 64
+	// natPollInterval is the interval at which a writer waiting for a STUN response checks
+	// whether it can read the socket itself.
+	natPollInterval = 10 * time.Millisecond
+)
+
+// STUNConfig configures the discovery of the address that a NAT maps a socket to.
+//
+// The mapped address towards a border router is discovered with a STUN binding request to the
+// router, before the first packet is sent through it. The SCION source address of the packets
+// sent through the router is then rewritten to the mapped address, and the SCION destination
+// address of the packets received on the mapped address is rewritten to the local address.
+// The mappings are kept alive, and updated if the NAT changes them.
+type STUNConfig struct {
+	// KeepaliveInterval is the interval at which the mappings are refreshed. It must be
+	// shorter than the time after which the NAT drops idle mappings. If zero, 15s is used.
+	KeepaliveInterval time.Duration
+	// Timeout is the time to wait for a STUN response. If the router does not answer, the
+	// packets are sent with the local address, and the discovery is retried after the keepalive
+	// interval. If zero, 1s is used.
+	Timeout time.Duration
+}
+
+// natMapping is the address that the socket is mapped to towards a router.
+type natMapping struct {
+	// addr is the mapped address. It is invalid until discovered.
+	addr netip.AddrPort
+	// txID is the transaction of the last request.
+	txID      stun.TxID
+	pending   bool
+	requested time.Time
+	confirmed time.Time
+	failed    time.Time
+	used      time.Time
+	// answered is closed when a response to the pending request is received.
+	answered chan struct{}
+}
+
+// inFlight is whether a request was sent recently and is not answered yet.
+func (m *natMapping) inFlight(now time.Time, timeout time.Duration) bool {
+	return m.pending && now.Sub(m.requested) < timeout
+}
+
+type natPacket struct {
+	b    []byte
+	from *net.UDPAddr
+}
+
+// natTraversal discovers and keeps alive the NAT mappings of a socket.
+type natTraversal struct {
+	conn  *net.UDPConn
+	local netip.AddrPort
+	cfg   STUNConfig
+
+	mtx      sync.Mutex
+	mappings map[netip.AddrPort]*natMapping
+
+	// readMtx serializes the reads of the socket.
+	readMtx sync.Mutex
+	// queue holds the packets that were read while waiting for a STUN response.
+	queue []natPacket
+
+	deadlineMtx sync.Mutex
+	// readDeadline is the read deadline set by the application.
+	readDeadline time.Time
+	// awaiting is whether the socket is read to wait for a STUN response, with its own
+	// deadline.
+	awaiting bool
+
+	stop chan struct{}
+	once sync.Once
+}
+
+func newNATTraversal(conn *net.UDPConn, cfg STUNConfig) *natTraversal {
+	if cfg.KeepaliveInterval == 0 {
+		cfg.KeepaliveInterval = defaultSTUNKeepaliveInterval
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultSTUNTimeout
+	}
+	local := conn.LocalAddr().(*net.UDPAddr).AddrPort()
+	n := &natTraversal{
+		conn:     conn,
+		local:    netip.AddrPortFrom(local.Addr().Unmap(), local.Port()),
+		cfg:      cfg,
+		mappings: make(map[netip.AddrPort]*natMapping),
+		stop:     make(chan struct{}),
+	}
+	go func() {
+		defer log.HandlePanic()
+		n.keepalive()
+	}()
+	return n
+}
+
+func (n *natTraversal) close() {
+	n.once.Do(func() { close(n.stop) })
+}
+
+// translateOutbound rewrites the source of a packet sent through the router to the mapped
+// address. It discovers the mapping first if needed.
+func (n *natTraversal) translateOutbound(pkt *Packet, router *net.UDPAddr) {
+	if pkt.Source.Host.Type() != addr.HostTypeIP {
+		return
+	}
+	mapped, ok := n.mapping(router.AddrPort(), time.Now())
+	if !ok {
+		return
+	}
+	pkt.Source.Host = addr.HostIP(mapped.Addr())
+	if udp, ok := pkt.Payload.(UDPPayload); ok && udp.SrcPort == n.local.Port() {
+		udp.SrcPort = mapped.Port()
+		pkt.Payload = udp
+	}
+}
+
+// translateInbound rewrites the destination of a packet received on a mapped address to the
+// local address.
+func (n *natTraversal) translateInbound(pkt *Packet) {
+	if pkt.Destination.Host.Type() != addr.HostTypeIP {
+		return
+	}
+	udp, isUDP := pkt.Payload.(UDPPayload)
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	for _, m := range n.mappings {
+		if !m.addr.IsValid() || m.addr.Addr() != pkt.Destination.Host.IP() {
+			continue
+		}
+		if isUDP && udp.DstPort != m.addr.Port() {
+			continue
+		}
+		pkt.Destination.Host = addr.HostIP(n.local.Addr())
+		if isUDP {
+			udp.DstPort = n.local.Port()
+			pkt.Payload = udp
+		}
+		return
+	}
+}
+
+// mapping returns the mapped address towards the router. If it is not known or outdated, a
+// STUN request is sent and the response awaited.
+func (n *natTraversal) mapping(router netip.AddrPort, now time.Time) (netip.AddrPort, bool) {
+	n.mtx.Lock()
+	m, ok := n.mappings[router]
+	if !ok {
+		m = &natMapping{answered: make(chan struct{})}
+		n.mappings[router] = m
+	}
+	m.used = now
+	if m.addr.IsValid() && now.Sub(m.confirmed) < n.staleAfter() {
+		if !m.inFlight(now, n.cfg.Timeout) && now.Sub(m.requested) >= n.cfg.KeepaliveInterval {
+			n.requestLocked(router, m, now)
+		}
+		mapped := m.addr
+		n.mtx.Unlock()
+		return mapped, true
+	}
+	// The router did not answer recently, do not delay every packet.
+	if now.Sub(m.failed) < n.cfg.KeepaliveInterval {
+		mapped := m.addr
+		n.mtx.Unlock()
+		return mapped, mapped.IsValid()
+	}
+	if !m.inFlight(now, n.cfg.Timeout) {
+		n.requestLocked(router, m, now)
+	}
+	answered := m.answered
+	n.mtx.Unlock()
+
+	ok = n.await(answered, now.Add(n.cfg.Timeout))
+
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	if !ok {
+		m.pending = false
+		m.failed = now
+		log.Debug("No STUN response from router", "router", router)
+	}
+	return m.addr, m.addr.IsValid()
+}
+
+// staleAfter is the time after which a mapping that was not confirmed is discovered again.
+func (n *natTraversal) staleAfter() time.Duration {
+	return 2*n.cfg.KeepaliveInterval + n.cfg.Timeout
+}
+
+func (n *natTraversal) requestLocked(router netip.AddrPort, m *natMapping, now time.Time) {
+	m.txID = stun.NewTxID()
+	m.pending = true
+	m.requested = now
+	if _, err := n.conn.WriteToUDPAddrPort(stun.Request(m.txID), router); err != nil {
+		log.Debug("Sending STUN request failed", "router", router, "err", err)
+	}
+}
+
+func (n *natTraversal) handleResponse(b []byte) {
+	txID, mapped, err := stun.ParseResponse(b)
+	if err != nil {
+		log.Debug("Ignoring invalid STUN response", "err", err)
+		return
+	}
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	for router, m := range n.mappings {
+		if !m.pending || m.txID != txID {
+			continue
+		}
+		if m.addr != mapped {
+			log.Info("NAT mapping discovered", "router", router, "local", n.local,
+				"mapped", mapped, "previous", m.addr)
+		}
+		m.addr = mapped
+		m.pending = false
+		m.confirmed = time.Now()
+		m.failed = time.Time{}
+		close(m.answered)
+		m.answered = make(chan struct{})
+		return
+	}
+}
+
+// await waits until answered is closed or the deadline passes. If nobody else reads the
+// socket, it reads it itself, and queues the packets for the application.
+func (n *natTraversal) await(answered <-chan struct{}, deadline time.Time) bool {
+	for {
+		if n.readMtx.TryLock() {
+			ok := n.readUntilLocked(answered, deadline)
+			n.readMtx.Unlock()
+			return ok
+		}
+		wait := min(natPollInterval, time.Until(deadline))
+		if wait <= 0 {
+			return false
+		}
+		select {
+		case <-answered:
+			return true
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (n *natTraversal) readUntilLocked(answered <-chan struct{}, deadline time.Time) bool {
+	n.deadlineMtx.Lock()
+	n.awaiting = true
+	err := n.conn.SetReadDeadline(deadline)
+	n.deadlineMtx.Unlock()
+	defer func() {
+		n.deadlineMtx.Lock()
+		defer n.deadlineMtx.Unlock()
+		n.awaiting = false
+		if err := n.conn.SetReadDeadline(n.readDeadline); err != nil {
+			log.Debug("Restoring read deadline failed", "err", err)
+		}
+	}()
+	if err != nil {
+		return false
+	}
+	buf := make([]byte, 1<<16)
+	for {
+		select {
+		case <-answered:
+			return true
+		default:
+		}
+		nr, from, err := n.conn.ReadFromUDP(buf)
+		if err != nil {
+			select {
+			case <-answered:
+				return true
+			default:
+				return false
+			}
+		}
+		if stun.Is(buf[:nr]) {
+			n.handleResponse(buf[:nr])
+			continue
+		}
+		if len(n.queue) == natQueueLen {
+			n.queue = n.queue[1:]
+		}
+		n.queue = append(n.queue, natPacket{b: append([]byte(nil), buf[:nr]...), from: from})
+	}
+}
+
+// read reads a packet that is not a STUN response from the socket.
+func (n *natTraversal) read(b []byte) (int, net.Addr, error) {
+	n.readMtx.Lock()
+	defer n.readMtx.Unlock()
+	for {
+		if len(n.queue) > 0 {
+			p := n.queue[0]
+			n.queue = n.queue[1:]
+			return copy(b, p.b), p.from, nil
+		}
+		nr, from, err := n.conn.ReadFrom(b)
+		if err != nil {
+			return nr, from, err
+		}
+		if stun.Is(b[:nr]) {
+			n.handleResponse(b[:nr])
+			continue
+		}
+		return nr, from, nil
+	}
+}
+
+// setReadDeadline sets the read deadline of the application. It is applied once the socket
+// is not read to wait for a STUN response anymore.
+func (n *natTraversal) setReadDeadline(t time.Time) error {
+	n.deadlineMtx.Lock()
+	defer n.deadlineMtx.Unlock()
+	n.readDeadline = t
+	if n.awaiting {
+		return nil
+	}
+	return n.conn.SetReadDeadline(t)
+}
+
+// keepalive refreshes the mappings that are in use, so that the NAT does not drop them.
+func (n *natTraversal) keepalive() {
+	ticker := time.NewTicker(n.cfg.KeepaliveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			n.mtx.Lock()
+			for router, m := range n.mappings {
+				// Mappings that are not used are left to expire.
+				if now.Sub(m.used) > 4*n.cfg.KeepaliveInterval {
+					delete(n.mappings, router)
+					continue
+				}
+				if m.addr.IsValid() && !m.inFlight(now, n.cfg.Timeout) {
+					n.requestLocked(router, m, now)
+				}
+			}
+			n.mtx.Unlock()
+		case <-n.stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package snet_test
+
+import (
+	"net"
+	"net/netip"
+	"os"
+	"os/exec"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+var (
+	masqHost    = netip.MustParsePrefix("10.201.1.2/24")
+	masqInside  = netip.MustParsePrefix("10.201.1.1/24")
+	masqOutside = netip.MustParsePrefix("10.201.2.1/24")
+	masqRouter  = netip.MustParsePrefix("10.201.2.2/24")
+)
+
+// setupMasquerade creates three network namespaces: the host, the NAT and the router. The NAT
+// forwards between the other two and masquerades the traffic of the host with iptables. The
+// returned functions run f in the host and in the router namespace. The calling goroutine is
+// locked to its thread. The test is skipped if the process lacks the privileges or if iptables
+// is not installed.
+func setupMasquerade(t *testing.T) (inHost, inRouter func(f func())) {
+	iptables, err := exec.LookPath("iptables")
+	if err != nil {
+		t.Skip("iptables not available")
+	}
+	runtime.LockOSThread()
+	orig, err := netns.Get()
+	require.NoError(t, err)
+	var handles []netns.NsHandle
+	t.Cleanup(func() {
+		// Deleting the namespaces deletes the veth pairs.
+		_ = netns.Set(orig)
+		for _, h := range handles {
+			h.Close()
+		}
+		orig.Close()
+		runtime.UnlockOSThread()
+	})
+	newNS := func() netns.NsHandle {
+		h, err := netns.New()
+		if err != nil {
+			t.Skipf("cannot create network namespace: %v", err)
+		}
+		handles = append(handles, h)
+		return h
+	}
+	hostNS, routerNS, natNS := newNS(), newNS(), newNS()
+	in := func(ns netns.NsHandle) func(f func()) {
+		return func(f func()) {
+			require.NoError(t, netns.Set(ns))
+			defer func() { require.NoError(t, netns.Set(natNS)) }()
+			f()
+		}
+	}
+	inHost, inRouter = in(hostNS), in(routerNS)
+
+	// We are in the NAT namespace.
+	addVeth := func(name, peer string, peerNS netns.NsHandle, addr, peerAddr netip.Prefix) {
+		require.NoError(t, netlink.LinkAdd(&netlink.Veth{
+			LinkAttrs: netlink.LinkAttrs{Name: name},
+			PeerName:  peer,
+		}))
+		l, err := netlink.LinkByName(name)
+		require.NoError(t, err)
+		require.NoError(t, netlink.AddrAdd(l, &netlink.Addr{IPNet: prefixToIPNet(addr)}))
+		require.NoError(t, netlink.LinkSetUp(l))
+		p, err := netlink.LinkByName(peer)
+		require.NoError(t, err)
+		require.NoError(t, netlink.LinkSetNsFd(p, int(peerNS)))
+		in(peerNS)(func() {
+			p, err := netlink.LinkByName(peer)
+			require.NoError(t, err)
+			require.NoError(t, netlink.AddrAdd(p, &netlink.Addr{IPNet: prefixToIPNet(peerAddr)}))
+			require.NoError(t, netlink.LinkSetUp(p))
+		})
+	}
+	addVeth("nat-in", "host0", hostNS, masqInside, masqHost)
+	addVeth("nat-out", "router0", routerNS, masqOutside, masqRouter)
+	inHost(func() {
+		require.NoError(t, netlink.RouteAdd(&netlink.Route{Gw: masqInside.Addr().AsSlice()}))
+	})
+	// The router has no route to the host; only the NAT can get the packets there.
+	require.NoError(t, os.WriteFile("/proc/sys/net/ipv4/ip_forward", []byte("1"), 0644))
+	out, err := exec.Command(iptables, "-t", "nat", "-A", "POSTROUTING",
+		"-o", "nat-out", "-j", "MASQUERADE").CombinedOutput()
+	if err != nil {
+		t.Skipf("cannot install masquerading rule: %v: %s", err, out)
+	}
+	return inHost, inRouter
+}
+
+func prefixToIPNet(p netip.Prefix) *net.IPNet {
+	return &net.IPNet{
+		IP:   p.Addr().AsSlice(),
+		Mask: net.CIDRMask(p.Bits(), p.Addr().BitLen()),
+	}
+}
+
+// TestNATTraversalMasquerade runs the traversal behind a NAT of the kernel. It needs
+// CAP_NET_ADMIN and CAP_SYS_ADMIN, and iptables; it is skipped otherwise.
+func TestNATTraversalMasquerade(t *testing.T) {
+	inHost, inRouter := setupMasquerade(t)
+
+	var router *echoRouter
+	inRouter(func() {
+		conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: masqRouter.Addr().AsSlice()})
+		require.NoError(t, err)
+		router = startEchoRouter(t, conn, true)
+	})
+	var conn *snet.Conn
+	var local netip.AddrPort
+	inHost(func() {
+		udpConn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: masqHost.Addr().AsSlice()})
+		require.NoError(t, err)
+		conn, local = newSTUNConn(t, udpConn, router.conn.LocalAddr().(*net.UDPAddr),
+			&snet.STUNConfig{KeepaliveInterval: 50 * time.Millisecond})
+	})
+
+	_, err := conn.Write([]byte("hello"))
+	require.NoError(t, err)
+	buf := make([]byte, 100)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(buf[:n]))
+
+	// The SCION source address is the mapping of the NAT, so the reply found its way back.
+	router.mtx.Lock()
+	src, from := router.lastSrc, router.lastFrom
+	router.mtx.Unlock()
+	assert.Equal(t, masqOutside.Addr(), from.Addr())
+	assert.Equal(t, from, src)
+	assert.NotEqual(t, local.Addr(), src.Addr())
+}
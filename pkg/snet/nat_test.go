@@ -0,0 +1,265 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+	"github.com/scionproto/scion/pkg/stun"
+)
+
+var loopback = &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)}
+
+// echoRouter answers STUN binding requests like the border router does, and sends the SCION
+// packets back to their source. It records the source of the last packet, both the one in the
+// SCION header and the one of the underlay.
+type echoRouter struct {
+	conn     *net.UDPConn
+	stun     bool
+	mtx      sync.Mutex
+	lastSrc  netip.AddrPort
+	lastFrom netip.AddrPort
+	received int
+}
+
+func newEchoRouter(t *testing.T, answerSTUN bool) *echoRouter {
+	conn, err := net.ListenUDP("udp4", loopback)
+	require.NoError(t, err)
+	return startEchoRouter(t, conn, answerSTUN)
+}
+
+func startEchoRouter(t *testing.T, conn *net.UDPConn, answerSTUN bool) *echoRouter {
+	t.Cleanup(func() { conn.Close() })
+	r := &echoRouter{conn: conn, stun: answerSTUN}
+	go r.run()
+	return r
+}
+
+func (r *echoRouter) run() {
+	buf := make([]byte, 1<<16)
+	for {
+		n, from, err := r.conn.ReadFromUDPAddrPort(buf)
+		if err != nil {
+			return
+		}
+		if stun.Is(buf[:n]) {
+			if txID, err := stun.ParseBindingRequest(buf[:n]); err == nil && r.stun {
+				r.conn.WriteToUDPAddrPort(stun.Response(txID, from), from)
+			}
+			continue
+		}
+		pkt := snet.Packet{Bytes: append([]byte(nil), buf[:n]...)}
+		if err := pkt.Decode(); err != nil {
+			continue
+		}
+		udp := pkt.Payload.(snet.UDPPayload)
+		r.mtx.Lock()
+		r.lastSrc = netip.AddrPortFrom(pkt.Source.Host.IP(), udp.SrcPort)
+		r.lastFrom = from
+		r.received++
+		r.mtx.Unlock()
+		reply := snet.Packet{
+			PacketInfo: snet.PacketInfo{
+				Source:      pkt.Destination,
+				Destination: pkt.Source,
+				Path:        snetpath.Empty{},
+				Payload: snet.UDPPayload{
+					SrcPort: udp.DstPort,
+					DstPort: udp.SrcPort,
+					Payload: udp.Payload,
+				},
+			},
+		}
+		if err := reply.Serialize(); err != nil {
+			continue
+		}
+		r.conn.WriteToUDPAddrPort(reply.Bytes, from)
+	}
+}
+
+func (r *echoRouter) last() (netip.AddrPort, int) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.lastSrc, r.received
+}
+
+// userspaceNAT forwards the datagrams between the inside and the router, from a separate
+// outside socket. It thus maps the host to a different port.
+type userspaceNAT struct {
+	inside *net.UDPConn
+	router *net.UDPAddr
+
+	mtx     sync.Mutex
+	host    *net.UDPAddr
+	outside *net.UDPConn
+}
+
+func newUserspaceNAT(t *testing.T, router *net.UDPAddr) *userspaceNAT {
+	inside, err := net.ListenUDP("udp4", loopback)
+	require.NoError(t, err)
+	n := &userspaceNAT{inside: inside, router: router}
+	n.rebind(t)
+	t.Cleanup(func() {
+		inside.Close()
+		n.mtx.Lock()
+		defer n.mtx.Unlock()
+		n.outside.Close()
+	})
+	go func() {
+		buf := make([]byte, 1<<16)
+		for {
+			nr, from, err := inside.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			n.mtx.Lock()
+			n.host = from
+			outside := n.outside
+			n.mtx.Unlock()
+			outside.WriteToUDP(buf[:nr], router)
+		}
+	}()
+	return n
+}
+
+// rebind replaces the outside socket, like a NAT that changes its mapping.
+func (n *userspaceNAT) rebind(t *testing.T) netip.AddrPort {
+	outside, err := net.ListenUDP("udp4", loopback)
+	require.NoError(t, err)
+	n.mtx.Lock()
+	old := n.outside
+	n.outside = outside
+	n.mtx.Unlock()
+	if old != nil {
+		old.Close()
+	}
+	go func() {
+		buf := make([]byte, 1<<16)
+		for {
+			nr, _, err := outside.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			n.mtx.Lock()
+			host := n.host
+			n.mtx.Unlock()
+			n.inside.WriteToUDP(buf[:nr], host)
+		}
+	}()
+	return outside.LocalAddr().(*net.UDPAddr).AddrPort()
+}
+
+func newNATConn(
+	t *testing.T,
+	nat *userspaceNAT,
+	cfg *snet.STUNConfig,
+) (*snet.Conn, netip.AddrPort) {
+
+	udpConn, err := net.ListenUDP("udp4", loopback)
+	require.NoError(t, err)
+	return newSTUNConn(t, udpConn, nat.inside.LocalAddr().(*net.UDPAddr), cfg)
+}
+
+// newSTUNConn returns a connection over udpConn to a remote host behind the router at nextHop.
+func newSTUNConn(
+	t *testing.T,
+	udpConn *net.UDPConn,
+	nextHop *net.UDPAddr,
+	cfg *snet.STUNConfig,
+) (*snet.Conn, netip.AddrPort) {
+
+	topo := snet.Topology{LocalIA: addr.MustParseIA("1-ff00:0:110")}
+	remote := &snet.UDPAddr{
+		IA:      addr.MustParseIA("1-ff00:0:111"),
+		Host:    &net.UDPAddr{IP: net.IPv4(10, 0, 0, 2), Port: 4000},
+		Path:    snetpath.Empty{},
+		NextHop: nextHop,
+	}
+	conn, err := snet.NewCookedConn(
+		&snet.SCIONPacketConn{Conn: udpConn, Topology: topo, STUN: cfg},
+		topo,
+		snet.WithRemote(remote),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn, udpConn.LocalAddr().(*net.UDPAddr).AddrPort()
+}
+
+func TestNATTraversal(t *testing.T) {
+	t.Run("mapping discovered", func(t *testing.T) {
+		router := newEchoRouter(t, true)
+		nat := newUserspaceNAT(t, router.conn.LocalAddr().(*net.UDPAddr))
+		conn, _ := newNATConn(t, nat, &snet.STUNConfig{KeepaliveInterval: 50 * time.Millisecond})
+		nat.mtx.Lock()
+		mapped := nat.outside.LocalAddr().(*net.UDPAddr).AddrPort()
+		nat.mtx.Unlock()
+
+		_, err := conn.Write([]byte("hello"))
+		require.NoError(t, err)
+		buf := make([]byte, 100)
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		n, err := conn.Read(buf)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(buf[:n]))
+		src, _ := router.last()
+		assert.Equal(t, mapped, src)
+
+		// The NAT changes the mapping, which is rediscovered with the keepalives.
+		mapped = nat.rebind(t)
+		require.Eventually(t, func() bool {
+			if _, err := conn.Write([]byte("again")); err != nil {
+				return false
+			}
+			require.NoError(t, conn.SetReadDeadline(time.Now().Add(20*time.Millisecond)))
+			for {
+				n, err := conn.Read(buf)
+				var netErr net.Error
+				if errors.As(err, &netErr) && netErr.Timeout() {
+					break
+				}
+				if err == nil && string(buf[:n]) == "again" {
+					src, _ := router.last()
+					return src == mapped
+				}
+			}
+			return false
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+	t.Run("router without STUN", func(t *testing.T) {
+		router := newEchoRouter(t, false)
+		nat := newUserspaceNAT(t, router.conn.LocalAddr().(*net.UDPAddr))
+		conn, local := newNATConn(t, nat, &snet.STUNConfig{Timeout: 50 * time.Millisecond})
+
+		_, err := conn.Write([]byte("hello"))
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			_, received := router.last()
+			return received == 1
+		}, 5*time.Second, 10*time.Millisecond)
+		src, _ := router.last()
+		assert.Equal(t, local, src)
+	})
+}
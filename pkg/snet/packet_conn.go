@@ -16,6 +16,7 @@ package snet
 
 import (
 	"net"
+	"sync"
 	"syscall"
 	"time"
 
@@ -124,6 +125,23 @@ type SCIONPacketConn struct {
 	Metrics SCIONPacketConnMetrics
 	// Topology provides interface information for the local AS.
 	Topology Topology
+	// STUN enables the discovery of the address that a NAT maps the connection to. If nil, the
+	// local address is used as the source address of the packets.
+	STUN *STUNConfig
+
+	natOnce sync.Once
+	nat     *natTraversal
+}
+
+// natTraversal returns the NAT traversal of the connection, or nil if it is disabled.
+func (c *SCIONPacketConn) natTraversal() *natTraversal {
+	if c.STUN == nil {
+		return nil
+	}
+	c.natOnce.Do(func() {
+		c.nat = newNATTraversal(c.Conn, *c.STUN)
+	})
+	return c.nat
 }
 
 func (c *SCIONPacketConn) SetReadBuffer(bytes int) error {
@@ -131,15 +149,29 @@ func (c *SCIONPacketConn) SetReadBuffer(bytes int) error {
 }
 
 func (c *SCIONPacketConn) SetDeadline(d time.Time) error {
+	if nat := c.natTraversal(); nat != nil {
+		if err := nat.setReadDeadline(d); err != nil {
+			return err
+		}
+		return c.Conn.SetWriteDeadline(d)
+	}
 	return c.Conn.SetDeadline(d)
 }
 
 func (c *SCIONPacketConn) Close() error {
 	metrics.CounterInc(c.Metrics.Closes)
+	if nat := c.natTraversal(); nat != nil {
+		nat.close()
+	}
 	return c.Conn.Close()
 }
 
 func (c *SCIONPacketConn) WriteTo(pkt *Packet, ov *net.UDPAddr) error {
+	// Packets to other ASes go through a border router, that sees the address mapped by a NAT.
+	if nat := c.natTraversal(); nat != nil && ov != nil &&
+		!pkt.Destination.IA.Equal(pkt.Source.IA) {
+		nat.translateOutbound(pkt, ov)
+	}
 	if err := pkt.Serialize(); err != nil {
 		return serrors.Wrap("serialize SCION packet", err)
 	}
@@ -204,7 +236,15 @@ func (c *SCIONPacketConn) SyscallConn() (syscall.RawConn, error) {
 
 func (c *SCIONPacketConn) readFrom(pkt *Packet) (*net.UDPAddr, error) {
 	pkt.Prepare()
-	n, remoteAddr, err := c.Conn.ReadFrom(pkt.Bytes)
+	nat := c.natTraversal()
+	var n int
+	var remoteAddr net.Addr
+	var err error
+	if nat != nil {
+		n, remoteAddr, err = nat.read(pkt.Bytes)
+	} else {
+		n, remoteAddr, err = c.Conn.ReadFrom(pkt.Bytes)
+	}
 	if err != nil {
 		metrics.CounterInc(c.Metrics.UnderlayConnectionErrors)
 		return nil, serrors.Wrap("reading underlay connection", err)
@@ -221,6 +261,9 @@ func (c *SCIONPacketConn) readFrom(pkt *Packet) (*net.UDPAddr, error) {
 		log.Debug("decoding packet", "error", err)
 		return nil, nil
 	}
+	if nat != nil {
+		nat.translateInbound(pkt)
+	}
 
 	udpRemoteAddr := remoteAddr.(*net.UDPAddr)
 	lastHop := udpRemoteAddr
@@ -242,6 +285,9 @@ func (c *SCIONPacketConn) readFrom(pkt *Packet) (*net.UDPAddr, error) {
 }
 
 func (c *SCIONPacketConn) SetReadDeadline(d time.Time) error {
+	if nat := c.natTraversal(); nat != nil {
+		return nat.setReadDeadline(d)
+	}
 	return c.Conn.SetReadDeadline(d)
 }
 
@@ -0,0 +1,271 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package probe probes the health of paths with SCMP traceroute requests.
+//
+// The requests are sent with the router alert flag set on the last hop of the path, so that
+// they are answered by the router of the destination AS where the path enters. A probe thus
+// checks the path up to the destination AS, without needing the cooperation of the remote
+// host.
+package probe
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/metrics/v2"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/slayers/path/scion"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+// Config configures a Prober.
+type Config struct {
+	// Topology is used to resolve the underlay addresses of the local AS.
+	Topology snet.Topology
+	// LocalIA is the ID of the local AS.
+	LocalIA addr.IA
+	// LocalIP is the IP address the probes are sent from.
+	LocalIP netip.Addr
+	// RevocationHandler is notified of the revocations received in answer to probes. It is
+	// optional.
+	RevocationHandler snet.RevocationHandler
+	// SCMPErrors counts the SCMP errors received on the probe socket. It is optional.
+	SCMPErrors metrics.Counter
+	// PacketConnMetrics are the metrics of the probe socket. They are optional.
+	PacketConnMetrics snet.SCIONPacketConnMetrics
+}
+
+// Prober sends SCMP traceroute probes over paths and waits for the answers. A single prober
+// can be used concurrently to probe any number of paths.
+type Prober struct {
+	conn  snet.PacketConn
+	local snet.SCIONAddress
+	// id is the SCMP identifier of the probes, the port of the socket.
+	id uint16
+
+	mtx     sync.Mutex
+	seq     uint16
+	pending map[uint16]*pending
+	done    chan struct{}
+}
+
+// pending is a probe waiting for its answer.
+type pending struct {
+	path snet.Path
+	// result receives nil when the probe is answered, or the error that the probe failed
+	// with.
+	result chan error
+}
+
+// New opens a socket on the local IP address to send probes from. The prober must be closed
+// when it is no longer used.
+func New(ctx context.Context, cfg Config) (*Prober, error) {
+	p := &Prober{
+		local:   snet.SCIONAddress{IA: cfg.LocalIA, Host: addr.HostIP(cfg.LocalIP.Unmap())},
+		pending: make(map[uint16]*pending),
+		done:    make(chan struct{}),
+	}
+	conn, err := (&snet.SCIONNetwork{
+		Topology: cfg.Topology,
+		SCMPHandler: scmpHandler{
+			prober: p,
+			wrappedHandler: snet.DefaultSCMPHandler{
+				RevocationHandler: cfg.RevocationHandler,
+				SCMPErrors:        cfg.SCMPErrors,
+			},
+		},
+		PacketConnMetrics: cfg.PacketConnMetrics,
+	}).OpenRaw(ctx, &net.UDPAddr{IP: cfg.LocalIP.AsSlice()})
+	if err != nil {
+		return nil, serrors.Wrap("opening probe socket", err)
+	}
+	p.conn = conn
+	p.id = uint16(conn.LocalAddr().(*net.UDPAddr).Port)
+	go func() {
+		defer log.HandlePanic()
+		p.drain()
+	}()
+	return p, nil
+}
+
+// Probe sends a probe on the path and waits for the answer. It returns the round-trip time.
+// If a router reports that an interface of the path is down, Probe fails with a
+// *snet.OpError that carries the revocation.
+func (p *Prober) Probe(ctx context.Context, path snet.Path) (time.Duration, error) {
+	alert, err := WithRouterAlert(path.Dataplane())
+	if err != nil {
+		return 0, err
+	}
+
+	probe := &pending{path: path, result: make(chan error, 1)}
+	p.mtx.Lock()
+	p.seq++
+	seq := p.seq
+	p.pending[seq] = probe
+	p.mtx.Unlock()
+	defer func() {
+		p.mtx.Lock()
+		defer p.mtx.Unlock()
+		delete(p.pending, seq)
+	}()
+
+	pkt := &snet.Packet{
+		PacketInfo: snet.PacketInfo{
+			Destination: snet.SCIONAddress{
+				IA: path.Destination(),
+				// The host does not matter, the probe is answered by a router.
+				Host: addr.HostSVC(addr.SvcNone),
+			},
+			Source: p.local,
+			Path:   alert,
+			Payload: snet.SCMPTracerouteRequest{
+				Identifier: p.id,
+				Sequence:   seq,
+			},
+		},
+	}
+	start := time.Now()
+	if err := p.conn.WriteTo(pkt, path.UnderlayNextHop()); err != nil {
+		return 0, serrors.Wrap("sending probe", err)
+	}
+	select {
+	case err := <-probe.result:
+		if err != nil {
+			return 0, err
+		}
+		return time.Since(start), nil
+	case <-p.done:
+		return 0, net.ErrClosed
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+// Close closes the socket of the prober. Pending probes fail with net.ErrClosed.
+func (p *Prober) Close() error {
+	return p.conn.Close()
+}
+
+// drain reads from the socket, so that the SCMP handler sees the answers.
+func (p *Prober) drain() {
+	defer close(p.done)
+	var pkt snet.Packet
+	var ov net.UDPAddr
+	for {
+		err := p.conn.ReadFrom(&pkt, &ov)
+		if err == nil {
+			continue
+		}
+		var opErr *snet.OpError
+		if errors.As(err, &opErr) {
+			if opErr.RevInfo() != nil {
+				p.revoke(opErr)
+			}
+			continue
+		}
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+		log.Debug("Reading probe answer failed", "err", err)
+	}
+}
+
+func (p *Prober) answer(id, seq uint16) {
+	if id != p.id {
+		return
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if probe, ok := p.pending[seq]; ok {
+		probe.result <- nil
+		delete(p.pending, seq)
+	}
+}
+
+// revoke fails the pending probes on paths through the revoked interface. The SCMP error
+// does not tell which probe triggered it, but every probe through the interface is lost.
+func (p *Prober) revoke(opErr *snet.OpError) {
+	rev := opErr.RevInfo()
+	iface := snet.PathInterface{IA: rev.IA(), ID: rev.IfID}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	for seq, probe := range p.pending {
+		if containsInterface(probe.path, iface) {
+			probe.result <- opErr
+			delete(p.pending, seq)
+		}
+	}
+}
+
+func containsInterface(path snet.Path, iface snet.PathInterface) bool {
+	md := path.Metadata()
+	if md == nil {
+		return false
+	}
+	for _, i := range md.Interfaces {
+		if i.IA.Equal(iface.IA) && i.ID == iface.ID {
+			return true
+		}
+	}
+	return false
+}
+
+type scmpHandler struct {
+	prober         *Prober
+	wrappedHandler snet.SCMPHandler
+}
+
+func (h scmpHandler) Handle(pkt *snet.Packet) error {
+	if tr, ok := pkt.Payload.(snet.SCMPTracerouteReply); ok {
+		h.prober.answer(tr.Identifier, tr.Sequence)
+		return nil
+	}
+	return h.wrappedHandler.Handle(pkt)
+}
+
+// WithRouterAlert returns a copy of the dataplane path with the router alert flag set on the
+// last hop, so that the router of the destination AS answers traceroute requests. Only SCION
+// paths are supported.
+func WithRouterAlert(path snet.DataplanePath) (snetpath.SCION, error) {
+	original, ok := path.(snetpath.SCION)
+	if !ok {
+		return snetpath.SCION{}, serrors.New("not a scion path", "type", common.TypeOf(path))
+	}
+	var decoded scion.Decoded
+	if err := decoded.DecodeFromBytes(original.Raw); err != nil {
+		return snetpath.SCION{}, serrors.Wrap("decoding path", err)
+	}
+	if len(decoded.InfoFields) > 0 {
+		info := decoded.InfoFields[len(decoded.InfoFields)-1]
+		if info.ConsDir {
+			decoded.HopFields[len(decoded.HopFields)-1].IngressRouterAlert = true
+		} else {
+			decoded.HopFields[len(decoded.HopFields)-1].EgressRouterAlert = true
+		}
+	}
+	alert, err := snetpath.NewSCIONFromDecoded(decoded)
+	if err != nil {
+		return snetpath.SCION{}, serrors.Wrap("serializing path", err)
+	}
+	return alert, nil
+}
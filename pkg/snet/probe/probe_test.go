@@ -0,0 +1,72 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/slayers/path"
+	"github.com/scionproto/scion/pkg/slayers/path/scion"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+	"github.com/scionproto/scion/pkg/snet/probe"
+)
+
+func TestWithRouterAlert(t *testing.T) {
+	testCases := map[string]struct {
+		consDir bool
+		ingress bool
+		egress  bool
+	}{
+		"construction direction":         {consDir: true, ingress: true},
+		"against construction direction": {consDir: false, egress: true},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			original, err := snetpath.NewSCIONFromDecoded(scion.Decoded{
+				Base: scion.Base{
+					PathMeta: scion.MetaHdr{
+						SegLen: [3]uint8{2, 0, 0},
+					},
+					NumINF:  1,
+					NumHops: 2,
+				},
+				InfoFields: []path.InfoField{{ConsDir: tc.consDir}},
+				HopFields:  []path.HopField{{ConsEgress: 4}, {ConsIngress: 1}},
+			})
+			require.NoError(t, err)
+
+			alert, err := probe.WithRouterAlert(original)
+			require.NoError(t, err)
+			var decoded scion.Decoded
+			require.NoError(t, decoded.DecodeFromBytes(alert.Raw))
+			assert.False(t, decoded.HopFields[0].IngressRouterAlert)
+			assert.False(t, decoded.HopFields[0].EgressRouterAlert)
+			assert.Equal(t, tc.ingress, decoded.HopFields[1].IngressRouterAlert)
+			assert.Equal(t, tc.egress, decoded.HopFields[1].EgressRouterAlert)
+
+			// The original path is not modified.
+			require.NoError(t, decoded.DecodeFromBytes(original.Raw))
+			assert.False(t, decoded.HopFields[1].IngressRouterAlert)
+			assert.False(t, decoded.HopFields[1].EgressRouterAlert)
+		})
+	}
+	t.Run("not a scion path", func(t *testing.T) {
+		_, err := probe.WithRouterAlert(snetpath.Empty{})
+		assert.Error(t, err)
+	})
+}
@@ -34,6 +34,11 @@
 // Read. In this case, the error value is non-nil and can be type asserted to
 // *OpError. Method SCMP() can be called on the error to extract the SCMP
 // header.
+//
+// Reliable, ordered byte streams can be run over a connection with DialStream
+// and ListenStream. The resulting StreamConn implements net.Conn; it adapts its
+// segment size to the MTU of the path in use, and switches to another path when
+// an interface on its path is reported down.
 package snet
 
 import (
@@ -89,6 +94,9 @@ type SCIONNetwork struct {
 	// SCMPHandler describes the network behaviour upon receiving SCMP traffic.
 	SCMPHandler       SCMPHandler
 	PacketConnMetrics SCIONPacketConnMetrics
+	// STUN enables the discovery of the addresses that a NAT maps the connections to, with
+	// the STUN responder of the border routers. If nil, the local addresses are used.
+	STUN *STUNConfig
 }
 
 // OpenRaw returns a PacketConn which listens on the specified address.
@@ -125,6 +133,7 @@ func (n *SCIONNetwork) OpenRaw(ctx context.Context, addr *net.UDPAddr) (PacketCo
 		SCMPHandler: n.SCMPHandler,
 		Metrics:     n.PacketConnMetrics,
 		Topology:    n.Topology,
+		STUN:        n.STUN,
 	}, nil
 }
 
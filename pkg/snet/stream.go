@@ -0,0 +1,907 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/netip"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/slayers"
+)
+
+const (
+	// streamBufferSize is the size of the send and receive buffers of a stream connection.
+	streamBufferSize = 1 << 20
+	// initialRTO is the retransmission timeout before the round-trip time is known.
+	initialRTO = time.Second
+	minRTO     = 200 * time.Millisecond
+	maxRTO     = time.Minute
+	// maxRetransmissions is the number of consecutive retransmission timeouts after which a
+	// connection is given up.
+	maxRetransmissions = 10
+	// initialWindowSegments is the initial congestion window, in segments.
+	initialWindowSegments = 10
+	// lingerTimeout is how long a closed connection keeps delivering its data.
+	lingerTimeout = 2 * time.Minute
+	// pathQueryTimeout bounds the path lookups done to migrate a connection.
+	pathQueryTimeout = 5 * time.Second
+	// udpHeaderLen is the length of the UDP header that carries the segments.
+	udpHeaderLen = 8
+)
+
+var (
+	errStreamReset   = errors.New("stream connection reset by peer")
+	errStreamTimeout = errors.New("stream connection timed out")
+)
+
+// StreamOption is a functional option type for configuring a StreamConn.
+type StreamOption func(o *streamOptions)
+
+// WithStreamRouter sets the router used to find the paths of a connection. When dialing, the
+// connection uses the first path returned by the router, instead of the path of the remote
+// address. Whenever an SCMP message reports that an interface of the current path is down, the
+// connection moves to another path returned by the router.
+func WithStreamRouter(router Router) StreamOption {
+	return func(o *streamOptions) {
+		o.router = router
+	}
+}
+
+type streamOptions struct {
+	router Router
+}
+
+func applyStreamOptions(opts []StreamOption) streamOptions {
+	var o streamOptions
+	for _, option := range opts {
+		option(&o)
+	}
+	return o
+}
+
+var _ net.Conn = (*StreamConn)(nil)
+
+// StreamConn is a reliable and ordered byte stream between two SCION hosts. It is carried in
+// UDP datagrams, over a datagram socket such as Conn, and provides retransmission, flow control
+// and congestion control. Segments are sized to fit the MTU of the path. The connection moves to
+// another path when the current one goes down, if it knows a router; see WithStreamRouter.
+type StreamConn struct {
+	mux    *streamMux
+	key    streamKey
+	connID uint32
+	local  *UDPAddr
+	router Router
+	// dialer is whether the connection was opened locally.
+	dialer bool
+
+	mtx sync.Mutex
+	// remote is the address of the peer, with the path in use.
+	remote *UDPAddr
+	// path is the path in use, if known. An accepted connection replies on the reverse of the
+	// path of the last segment it received, and does not know the path.
+	path Path
+	// revoked are the interfaces that are known to be down, with the time until which they are.
+	revoked   map[PathInterface]time.Time
+	migrating bool
+	// established is whether the handshake completed. connected is closed then, or when the
+	// handshake fails.
+	established bool
+	connected   chan struct{}
+	// err is why the connection failed, if it did.
+	err error
+	// closed is whether Close was called.
+	closed bool
+	// readable and writable are closed, and renewed, to wake up the blocked readers and
+	// writers.
+	readable chan struct{}
+	writable chan struct{}
+
+	readDeadline  time.Time
+	writeDeadline time.Time
+
+	// Send state. sendBuf holds the data from sndUna on, sent or not.
+	sndUna     uint32
+	sndNxt     uint32
+	sndMax     uint32
+	sendBuf    []byte
+	finSent    bool
+	finAcked   bool
+	peerWindow int
+	peerMSS    int
+	pathMSS    int
+	cwnd       int
+	ssthresh   int
+	dupAcks    int
+	retries    int
+	srtt       time.Duration
+	rttvar     time.Duration
+	rto        time.Duration
+	rttTiming  bool
+	rttSeq     uint32
+	rttStart   time.Time
+	rtoTimer   *time.Timer
+	rtoArmed   bool
+
+	// Receive state.
+	rcvNxt       uint32
+	recvBuf      []byte
+	outOfOrder   map[uint32][]byte
+	oooBytes     int
+	peerFin      bool
+	peerFinKnown bool
+	peerFinSeq   uint32
+	lastWindow   int
+
+	// scratch is where the segments are serialized.
+	scratch []byte
+}
+
+// DialStream opens a stream connection to remote over conn, typically a *Conn obtained with
+// SCIONNetwork.Listen. The connection takes ownership of conn, which must not be used for anything
+// else; it is closed once the connection is closed.
+func DialStream(
+	ctx context.Context,
+	conn net.PacketConn,
+	remote *UDPAddr,
+	opts ...StreamOption,
+) (*StreamConn, error) {
+	local, ok := conn.LocalAddr().(*UDPAddr)
+	if !ok {
+		conn.Close()
+		return nil, serrors.New("local address is not a SCION address",
+			"addr", conn.LocalAddr())
+	}
+	m := newStreamMux(conn, local, applyStreamOptions(opts), false)
+	connID := rand.Uint32()
+	s := newStreamConn(m, m.key(remote, connID), remote.Copy(), connID, true)
+	if s.router != nil {
+		if err := s.usePath(ctx, nil); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	m.register(s)
+	go func() {
+		defer log.HandlePanic()
+		m.run()
+	}()
+
+	rto := initialRTO
+	timer := time.NewTimer(rto)
+	defer timer.Stop()
+	for {
+		s.mtx.Lock()
+		s.sendControl(flagSYN)
+		s.mtx.Unlock()
+		select {
+		case <-s.connected:
+			s.mtx.Lock()
+			defer s.mtx.Unlock()
+			if s.err != nil {
+				return nil, s.err
+			}
+			return s, nil
+		case <-timer.C:
+			rto = min(2*rto, maxRTO)
+			timer.Reset(rto)
+		case <-ctx.Done():
+			s.abort()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func newStreamConn(
+	m *streamMux,
+	key streamKey,
+	remote *UDPAddr,
+	connID uint32,
+	dialer bool,
+) *StreamConn {
+	s := &StreamConn{
+		mux:         m,
+		key:         key,
+		connID:      connID,
+		local:       m.local,
+		router:      m.opts.router,
+		dialer:      dialer,
+		remote:      remote,
+		revoked:     make(map[PathInterface]time.Time),
+		established: !dialer,
+		connected:   make(chan struct{}),
+		readable:    make(chan struct{}),
+		writable:    make(chan struct{}),
+		// Data starts after the SYN.
+		sndUna:     1,
+		sndNxt:     1,
+		sndMax:     1,
+		rcvNxt:     1,
+		peerWindow: streamBufferSize,
+		ssthresh:   math.MaxInt,
+		rto:        initialRTO,
+		outOfOrder: make(map[uint32][]byte),
+		lastWindow: streamBufferSize,
+		scratch:    make([]byte, common.SupportedMTU),
+	}
+	s.pathMSS = s.segmentSize(nil)
+	s.peerMSS = s.pathMSS
+	s.cwnd = initialWindowSegments * s.pathMSS
+	if !dialer {
+		close(s.connected)
+	}
+	s.rtoTimer = time.AfterFunc(time.Hour, s.onTimeout)
+	s.rtoTimer.Stop()
+	return s
+}
+
+// Read reads data from the connection. It returns io.EOF once the peer closed the connection
+// and all its data was read.
+func (s *StreamConn) Read(b []byte) (int, error) {
+	for {
+		s.mtx.Lock()
+		if s.closed {
+			s.mtx.Unlock()
+			return 0, net.ErrClosed
+		}
+		if len(s.recvBuf) > 0 {
+			n := copy(b, s.recvBuf)
+			s.recvBuf = s.recvBuf[n:]
+			if len(s.recvBuf) == 0 {
+				s.recvBuf = nil
+			}
+			// Tell the peer once there is room again; it may be waiting for it.
+			if s.lastWindow < s.mss() && s.recvWindow() >= s.mss() {
+				s.sendControl(flagACK)
+			}
+			s.mtx.Unlock()
+			return n, nil
+		}
+		if s.peerFin {
+			s.mtx.Unlock()
+			return 0, io.EOF
+		}
+		if s.err != nil {
+			err := s.err
+			s.mtx.Unlock()
+			return 0, err
+		}
+		ch, deadline := s.readable, s.readDeadline
+		s.mtx.Unlock()
+		if err := s.wait(ch, deadline); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Write writes data to the connection. It blocks while the send buffer is full.
+func (s *StreamConn) Write(b []byte) (int, error) {
+	written := 0
+	for len(b) > 0 {
+		s.mtx.Lock()
+		if s.closed {
+			s.mtx.Unlock()
+			return written, net.ErrClosed
+		}
+		if s.err != nil {
+			err := s.err
+			s.mtx.Unlock()
+			return written, err
+		}
+		if space := streamBufferSize - len(s.sendBuf); space > 0 {
+			n := min(space, len(b))
+			s.sendBuf = append(s.sendBuf, b[:n]...)
+			b = b[n:]
+			written += n
+			s.trySend()
+			s.mtx.Unlock()
+			continue
+		}
+		ch, deadline := s.writable, s.writeDeadline
+		s.mtx.Unlock()
+		if err := s.wait(ch, deadline); err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// Close closes the connection. The data written before is still delivered, in the background.
+func (s *StreamConn) Close() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.closed {
+		return net.ErrClosed
+	}
+	s.closed = true
+	s.recvBuf = nil
+	broadcast(&s.readable)
+	broadcast(&s.writable)
+	if s.err != nil {
+		return nil
+	}
+	s.trySend()
+	s.finishIfDone()
+	time.AfterFunc(lingerTimeout, func() {
+		s.terminate(errStreamTimeout)
+	})
+	return nil
+}
+
+// LocalAddr returns the local address.
+func (s *StreamConn) LocalAddr() net.Addr {
+	return s.local
+}
+
+// RemoteAddr returns the address of the peer, with the path in use.
+func (s *StreamConn) RemoteAddr() net.Addr {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.remote.Copy()
+}
+
+// Path returns the path in use, or nil if it is not known. It is known if the connection was
+// dialed with a router.
+func (s *StreamConn) Path() Path {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.path
+}
+
+func (s *StreamConn) SetDeadline(t time.Time) error {
+	if err := s.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return s.SetWriteDeadline(t)
+}
+
+func (s *StreamConn) SetReadDeadline(t time.Time) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.readDeadline = t
+	broadcast(&s.readable)
+	return nil
+}
+
+func (s *StreamConn) SetWriteDeadline(t time.Time) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.writeDeadline = t
+	broadcast(&s.writable)
+	return nil
+}
+
+// wait blocks until ch is closed or the deadline passes.
+func (s *StreamConn) wait(ch <-chan struct{}, deadline time.Time) error {
+	if deadline.IsZero() {
+		<-ch
+		return nil
+	}
+	d := time.Until(deadline)
+	if d <= 0 {
+		return os.ErrDeadlineExceeded
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ch:
+		return nil
+	case <-timer.C:
+		return os.ErrDeadlineExceeded
+	}
+}
+
+// broadcast wakes up the goroutines waiting on ch, and renews it.
+func broadcast(ch *chan struct{}) {
+	close(*ch)
+	*ch = make(chan struct{})
+}
+
+// handleSegment processes a segment received from the peer.
+func (s *StreamConn) handleSegment(remote *UDPAddr, seg *segment) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.err != nil {
+		return
+	}
+	if seg.flags&flagRST != 0 {
+		s.fail(errStreamReset)
+		return
+	}
+	if !s.dialer {
+		// Reply on the reverse of the path the peer currently uses.
+		s.remote = remote
+	}
+	if seg.mss != 0 {
+		s.peerMSS = int(seg.mss)
+	}
+	if seg.flags&flagSYN != 0 {
+		if !s.dialer {
+			s.sendControl(flagSYN | flagACK)
+			return
+		}
+		if seg.flags&flagACK == 0 {
+			return
+		}
+	}
+	if seg.flags&flagACK == 0 {
+		return
+	}
+	if !s.established {
+		// The peer acknowledges the SYN with any segment.
+		if seg.ack != s.sndUna {
+			return
+		}
+		s.established = true
+		s.peerWindow = int(seg.window)
+		close(s.connected)
+		if seg.flags&flagSYN != 0 {
+			s.sendControl(flagACK)
+			return
+		}
+	}
+	s.processAck(seg)
+	if len(seg.payload) > 0 || seg.flags&flagFIN != 0 {
+		s.processData(seg)
+	}
+	s.trySend()
+	s.finishIfDone()
+}
+
+// processAck processes the acknowledgment and the window of a segment.
+func (s *StreamConn) processAck(seg *segment) {
+	if seqLess(seg.ack, s.sndUna) || seqLess(s.sndMax, seg.ack) {
+		return
+	}
+	inflight := int(s.sndNxt - s.sndUna)
+	if seg.ack == s.sndUna {
+		window := int(seg.window)
+		if inflight > 0 && len(seg.payload) == 0 && seg.flags&flagFIN == 0 &&
+			window == s.peerWindow {
+
+			s.dupAcks++
+			if s.dupAcks == 3 {
+				s.fastRetransmit()
+			}
+		}
+		s.peerWindow = window
+		return
+	}
+	s.peerWindow = int(seg.window)
+	acked := int(seg.ack - s.sndUna)
+	dataAcked := min(acked, len(s.sendBuf))
+	if acked > len(s.sendBuf) {
+		s.finSent = true
+		s.finAcked = true
+	}
+	s.sendBuf = s.sendBuf[dataAcked:]
+	if len(s.sendBuf) == 0 {
+		s.sendBuf = nil
+	}
+	s.sndUna = seg.ack
+	if seqLess(s.sndNxt, s.sndUna) {
+		// Data that was sent before a retransmission timeout arrived after all.
+		s.sndNxt = s.sndUna
+	}
+	if s.rttTiming && seqLessEq(s.rttSeq, seg.ack) {
+		s.rttTiming = false
+		s.updateRTT(time.Since(s.rttStart))
+	}
+	mss := s.mss()
+	if s.cwnd < s.ssthresh {
+		s.cwnd += min(acked, mss)
+	} else {
+		s.cwnd += max(1, mss*mss/s.cwnd)
+	}
+	s.dupAcks = 0
+	s.retries = 0
+	if s.sndNxt == s.sndUna {
+		s.stopTimer()
+	} else {
+		s.armTimer(true)
+	}
+	broadcast(&s.writable)
+}
+
+// updateRTT updates the round-trip time estimate and the retransmission timeout, as in RFC 6298.
+func (s *StreamConn) updateRTT(sample time.Duration) {
+	if s.srtt == 0 {
+		s.srtt = sample
+		s.rttvar = sample / 2
+	} else {
+		delta := s.srtt - sample
+		if delta < 0 {
+			delta = -delta
+		}
+		s.rttvar = (3*s.rttvar + delta) / 4
+		s.srtt = (7*s.srtt + sample) / 8
+	}
+	s.rto = min(max(s.srtt+4*s.rttvar, minRTO), maxRTO)
+}
+
+// processData processes the data and the FIN of a segment, and acknowledges it.
+func (s *StreamConn) processData(seg *segment) {
+	seq, data := seg.seq, seg.payload
+	if seqLess(seq, s.rcvNxt) {
+		skip := s.rcvNxt - seq
+		if int(skip) >= len(data) {
+			data = nil
+		} else {
+			data = data[skip:]
+		}
+		seq = s.rcvNxt
+	}
+	window := s.recvWindow()
+	if seq == s.rcvNxt {
+		s.deliver(data[:min(len(data), window)])
+		for {
+			next, ok := s.outOfOrder[s.rcvNxt]
+			if !ok {
+				break
+			}
+			delete(s.outOfOrder, s.rcvNxt)
+			s.oooBytes -= len(next)
+			s.deliver(next)
+		}
+	} else if len(data) > 0 && int(seq-s.rcvNxt)+len(data) <= window {
+		if _, ok := s.outOfOrder[seq]; !ok {
+			s.outOfOrder[seq] = slices.Clone(data)
+			s.oooBytes += len(data)
+		}
+	}
+	if seg.flags&flagFIN != 0 {
+		s.peerFinKnown = true
+		s.peerFinSeq = seg.seq + uint32(len(seg.payload))
+	}
+	if s.peerFinKnown && !s.peerFin && s.rcvNxt == s.peerFinSeq {
+		s.peerFin = true
+		s.rcvNxt++
+		broadcast(&s.readable)
+	}
+	s.sendControl(flagACK)
+}
+
+// deliver appends in-order data to the receive buffer. After Close, the data is dropped.
+func (s *StreamConn) deliver(data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	if !s.closed {
+		s.recvBuf = append(s.recvBuf, data...)
+		broadcast(&s.readable)
+	}
+	s.rcvNxt += uint32(len(data))
+}
+
+func (s *StreamConn) recvWindow() int {
+	return max(0, streamBufferSize-len(s.recvBuf)-s.oooBytes)
+}
+
+// trySend sends as much of the pending data as the windows allow, then the FIN once the
+// connection is closed and all data was sent.
+func (s *StreamConn) trySend() {
+	if s.err != nil || !s.established {
+		return
+	}
+	mss := s.mss()
+	for {
+		inflight := int(s.sndNxt - s.sndUna)
+		unsent := len(s.sendBuf) - inflight
+		if unsent > 0 {
+			limit := min(s.cwnd, s.peerWindow) - inflight
+			if limit <= 0 {
+				// If the peer has no room, the timer probes it until it has.
+				s.armTimer(false)
+				return
+			}
+			n := min(unsent, mss, limit)
+			s.sendData(inflight, n)
+			continue
+		}
+		if s.closed && !s.finSent && unsent == 0 {
+			s.finSent = true
+			s.sendSegment(flagFIN|flagACK, s.sndNxt, nil)
+			s.sndNxt++
+			if seqLess(s.sndMax, s.sndNxt) {
+				s.sndMax = s.sndNxt
+			}
+			s.armTimer(false)
+		}
+		return
+	}
+}
+
+// sendData sends n bytes of the send buffer, starting at the given offset from sndUna.
+func (s *StreamConn) sendData(offset, n int) {
+	seq := s.sndUna + uint32(offset)
+	s.sendSegment(flagACK, seq, s.sendBuf[offset:offset+n])
+	if seq == s.sndNxt {
+		if !s.rttTiming {
+			s.rttTiming = true
+			s.rttSeq = seq + uint32(n)
+			s.rttStart = time.Now()
+		}
+		s.sndNxt += uint32(n)
+		if seqLess(s.sndMax, s.sndNxt) {
+			s.sndMax = s.sndNxt
+		}
+	}
+	s.armTimer(false)
+}
+
+func (s *StreamConn) fastRetransmit() {
+	mss := s.mss()
+	inflight := int(s.sndNxt - s.sndUna)
+	s.ssthresh = max(inflight/2, 2*mss)
+	s.cwnd = s.ssthresh
+	s.rttTiming = false
+	if len(s.sendBuf) > 0 {
+		s.sendData(0, min(len(s.sendBuf), mss))
+	}
+}
+
+// onTimeout retransmits the unacknowledged data, or probes a peer that has no room.
+func (s *StreamConn) onTimeout() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.rtoArmed = false
+	if s.err != nil {
+		return
+	}
+	inflight := int(s.sndNxt - s.sndUna)
+	unsent := len(s.sendBuf) - inflight
+	if inflight == 0 && unsent <= 0 {
+		return
+	}
+	s.retries++
+	if s.retries > maxRetransmissions {
+		s.fail(errStreamTimeout)
+		return
+	}
+	s.rto = min(2*s.rto, maxRTO)
+	mss := s.mss()
+	if inflight > 0 {
+		// Go back to the first unacknowledged byte.
+		s.ssthresh = max(inflight/2, 2*mss)
+		s.cwnd = mss
+		s.sndNxt = s.sndUna
+		s.finSent = false
+		s.rttTiming = false
+		s.dupAcks = 0
+		s.trySend()
+		return
+	}
+	// The peer has no room; send a byte to learn when it has.
+	s.sendData(0, 1)
+}
+
+func (s *StreamConn) armTimer(restart bool) {
+	if s.rtoArmed && !restart {
+		return
+	}
+	s.rtoArmed = true
+	s.rtoTimer.Reset(s.rto)
+}
+
+func (s *StreamConn) stopTimer() {
+	s.rtoArmed = false
+	s.rtoTimer.Stop()
+}
+
+// sendControl sends a segment without data.
+func (s *StreamConn) sendControl(flags segmentFlags) {
+	seq := s.sndNxt
+	if flags&flagSYN != 0 {
+		seq = 0
+	}
+	s.sendSegment(flags, seq, nil)
+}
+
+func (s *StreamConn) sendSegment(flags segmentFlags, seq uint32, payload []byte) {
+	window := s.recvWindow()
+	seg := segment{
+		flags:   flags,
+		mss:     uint16(min(s.pathMSS, math.MaxUint16)),
+		connID:  s.connID,
+		seq:     seq,
+		ack:     s.rcvNxt,
+		window:  uint32(window),
+		payload: payload,
+	}
+	s.lastWindow = window
+	if err := s.mux.write(seg.encode(s.scratch), s.remote); err != nil {
+		log.Debug("Sending stream segment failed", "remote", s.remote, "flags", flags, "err", err)
+	}
+}
+
+// mss returns the largest payload that can be sent to the peer.
+func (s *StreamConn) mss() int {
+	return max(1, min(s.pathMSS, s.peerMSS))
+}
+
+// segmentSize returns the largest payload that fits the MTU of the given path, or of the path of
+// the remote address if nil.
+func (s *StreamConn) segmentSize(path Path) int {
+	mtu := common.MinMTU
+	dataplanePath := s.remote.Path
+	if path != nil {
+		dataplanePath = path.Dataplane()
+		if md := path.Metadata(); md != nil && md.MTU != 0 {
+			mtu = int(md.MTU)
+		}
+	}
+	scn := slayers.SCION{SrcIA: s.local.IA, DstIA: s.remote.IA}
+	if err := scn.SetSrcAddr(hostOf(s.local)); err != nil {
+		return mtu / 2
+	}
+	if err := scn.SetDstAddr(hostOf(s.remote)); err != nil {
+		return mtu / 2
+	}
+	if dataplanePath == nil || dataplanePath.SetPath(&scn) != nil || scn.Path == nil {
+		return mtu / 2
+	}
+	overhead := slayers.CmnHdrLen + scn.AddrHdrLen() + scn.Path.Len() + udpHeaderLen +
+		streamHeaderLen
+	return max(1, mtu-overhead)
+}
+
+func hostOf(a *UDPAddr) addr.Host {
+	ip, _ := netip.AddrFromSlice(a.Host.IP)
+	return addr.HostIP(ip.Unmap())
+}
+
+// usePath switches to the first path of the router that avoids the interfaces that are down.
+// Paths in the given list are preferred to the others.
+func (s *StreamConn) usePath(ctx context.Context, current Path) error {
+	paths, err := s.router.AllRoutes(ctx, s.remote.IA)
+	if err != nil {
+		return serrors.Wrap("looking up paths", err, "isd_as", s.remote.IA)
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	now := time.Now()
+	for _, p := range paths {
+		if current != nil && p.Metadata() != nil && current.Metadata() != nil &&
+			p.Metadata().Fingerprint() == current.Metadata().Fingerprint() {
+			continue
+		}
+		if s.isRevoked(p, now) {
+			continue
+		}
+		s.path = p
+		s.remote.Path = p.Dataplane()
+		s.remote.NextHop = p.UnderlayNextHop()
+		s.pathMSS = s.segmentSize(p)
+		return nil
+	}
+	return serrors.New("no usable path", "isd_as", s.remote.IA, "paths", len(paths))
+}
+
+func (s *StreamConn) isRevoked(p Path, now time.Time) bool {
+	md := p.Metadata()
+	if md == nil {
+		return false
+	}
+	for _, intf := range md.Interfaces {
+		if until, ok := s.revoked[intf]; ok && now.Before(until) {
+			return true
+		}
+	}
+	return false
+}
+
+// handleRevocation moves the connection to another path if an interface of its path is down.
+func (s *StreamConn) handleRevocation(rev *path_mgmt.RevInfo) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.revoked[PathInterface{IA: rev.IA(), ID: rev.IfID}] = rev.Expiration()
+	if s.err != nil || s.router == nil || s.path == nil || s.migrating ||
+		!s.isRevoked(s.path, time.Now()) {
+		return
+	}
+	s.migrating = true
+	go func() {
+		defer log.HandlePanic()
+		s.migrate()
+	}()
+}
+
+func (s *StreamConn) migrate() {
+	ctx, cancel := context.WithTimeout(context.Background(), pathQueryTimeout)
+	defer cancel()
+	s.mtx.Lock()
+	current := s.path
+	s.mtx.Unlock()
+	err := s.usePath(ctx, current)
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.migrating = false
+	if err != nil {
+		log.Info("Keeping stream on a path that is down", "remote", s.remote, "err", err)
+		return
+	}
+	log.Debug("Moved stream to another path", "remote", s.remote,
+		"path", s.path.Metadata().Fingerprint())
+	// The new path has unknown properties; start over.
+	s.cwnd = initialWindowSegments * s.mss()
+	s.ssthresh = math.MaxInt
+	s.srtt, s.rttvar, s.rto = 0, 0, initialRTO
+	s.rttTiming = false
+	s.dupAcks = 0
+	s.retries = 0
+	s.sndNxt = s.sndUna
+	s.finSent = false
+	s.trySend()
+	if !s.established {
+		s.sendControl(flagSYN)
+	}
+}
+
+// finishIfDone forgets the connection once both directions are closed and acknowledged.
+func (s *StreamConn) finishIfDone() {
+	if s.closed && s.finAcked && s.peerFin {
+		s.stopTimer()
+		go s.mux.remove(s)
+	}
+}
+
+// fail terminates the connection with the given error. The mutex must be held.
+func (s *StreamConn) fail(err error) {
+	if s.err != nil {
+		return
+	}
+	s.err = err
+	s.stopTimer()
+	broadcast(&s.readable)
+	broadcast(&s.writable)
+	select {
+	case <-s.connected:
+	default:
+		close(s.connected)
+	}
+	go s.mux.remove(s)
+}
+
+// terminate fails the connection, unless it already failed.
+func (s *StreamConn) terminate(err error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.fail(err)
+}
+
+// abort resets the connection.
+func (s *StreamConn) abort() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.err == nil {
+		s.sendSegment(flagRST, s.sndNxt, nil)
+	}
+	s.closed = true
+	s.fail(net.ErrClosed)
+}
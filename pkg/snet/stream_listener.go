@@ -0,0 +1,289 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"sync"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// acceptBacklog is the number of connections that can wait to be accepted. Connection attempts
+// beyond that are ignored.
+const acceptBacklog = 128
+
+var _ net.Listener = (*StreamListener)(nil)
+
+// StreamListener accepts stream connections on a SCION datagram socket.
+type StreamListener struct {
+	mux *streamMux
+}
+
+// ListenStream returns a listener that accepts stream connections on conn, typically a *Conn
+// obtained with SCIONNetwork.Listen. The listener takes ownership of conn; it is closed once the
+// listener and all the accepted connections are closed. The options apply to the accepted
+// connections.
+func ListenStream(conn net.PacketConn, opts ...StreamOption) (*StreamListener, error) {
+	local, ok := conn.LocalAddr().(*UDPAddr)
+	if !ok {
+		return nil, serrors.New("local address is not a SCION address",
+			"addr", conn.LocalAddr())
+	}
+	m := newStreamMux(conn, local, applyStreamOptions(opts), true)
+	go func() {
+		defer log.HandlePanic()
+		m.run()
+	}()
+	return &StreamListener{mux: m}, nil
+}
+
+// Accept waits for and returns the next connection.
+func (l *StreamListener) Accept() (net.Conn, error) {
+	return l.AcceptStream()
+}
+
+// AcceptStream waits for and returns the next connection.
+func (l *StreamListener) AcceptStream() (*StreamConn, error) {
+	select {
+	case s := <-l.mux.accept:
+		return s, nil
+	case <-l.mux.stopped:
+		return nil, net.ErrClosed
+	}
+}
+
+// Close stops accepting connections. The connections accepted before are not affected.
+func (l *StreamListener) Close() error {
+	return l.mux.stopListening()
+}
+
+// Addr returns the local address of the listener.
+func (l *StreamListener) Addr() net.Addr {
+	return l.mux.local
+}
+
+// streamKey identifies a connection on a socket.
+type streamKey struct {
+	ia     addr.IA
+	host   netip.AddrPort
+	connID uint32
+}
+
+// streamMux dispatches the segments received on a socket to the connections that use it. A
+// dialing socket has a single connection; a listening socket has all those it accepted.
+type streamMux struct {
+	conn  net.PacketConn
+	local *UDPAddr
+	opts  streamOptions
+
+	mtx     sync.Mutex
+	streams map[streamKey]*StreamConn
+	// listening is whether new connections are accepted.
+	listening bool
+	// accept queues the new connections. It is nil for a dialing socket.
+	accept chan *StreamConn
+	// stopped is closed when the socket stops accepting connections.
+	stopped chan struct{}
+	closed  bool
+}
+
+func newStreamMux(
+	conn net.PacketConn,
+	local *UDPAddr,
+	opts streamOptions,
+	listening bool,
+) *streamMux {
+	m := &streamMux{
+		conn:      conn,
+		local:     local,
+		opts:      opts,
+		streams:   make(map[streamKey]*StreamConn),
+		listening: listening,
+		stopped:   make(chan struct{}),
+	}
+	if listening {
+		m.accept = make(chan *StreamConn, acceptBacklog)
+	} else {
+		close(m.stopped)
+	}
+	return m
+}
+
+// key returns the key of the connection with the given peer. A dialing socket only has one peer,
+// so that it does not need to recognize its address.
+func (m *streamMux) key(remote *UDPAddr, connID uint32) streamKey {
+	if m.accept == nil {
+		return streamKey{connID: connID}
+	}
+	return streamKey{ia: remote.IA, host: remote.Host.AddrPort(), connID: connID}
+}
+
+func (m *streamMux) register(s *StreamConn) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.streams[s.key] = s
+}
+
+// remove forgets the given connection. The socket is closed once it has no connection left and
+// does not accept new ones.
+func (m *streamMux) remove(s *StreamConn) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.streams[s.key] == s {
+		delete(m.streams, s.key)
+	}
+	m.closeIfUnused()
+}
+
+func (m *streamMux) stopListening() error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if !m.listening {
+		return net.ErrClosed
+	}
+	m.listening = false
+	close(m.stopped)
+	// The connections that were never accepted will never be closed by the application.
+	for {
+		select {
+		case s := <-m.accept:
+			delete(m.streams, s.key)
+			go s.abort()
+			continue
+		default:
+		}
+		break
+	}
+	m.closeIfUnused()
+	return nil
+}
+
+func (m *streamMux) closeIfUnused() {
+	if m.closed || m.listening || len(m.streams) > 0 {
+		return
+	}
+	m.closed = true
+	if err := m.conn.Close(); err != nil {
+		log.Debug("Closing stream socket failed", "err", err)
+	}
+}
+
+// run reads the segments from the socket until it is closed.
+func (m *streamMux) run() {
+	buf := make([]byte, common.SupportedMTU)
+	for {
+		n, a, err := m.conn.ReadFrom(buf)
+		if err != nil {
+			var opErr *OpError
+			if errors.As(err, &opErr) {
+				if rev := opErr.RevInfo(); rev != nil {
+					m.revoke(rev)
+				}
+				continue
+			}
+			m.mtx.Lock()
+			closed := m.closed
+			m.mtx.Unlock()
+			if closed || errors.Is(err, net.ErrClosed) {
+				m.fail(err)
+				return
+			}
+			log.Debug("Reading from stream socket failed", "err", err)
+			continue
+		}
+		remote, ok := a.(*UDPAddr)
+		if !ok {
+			continue
+		}
+		var seg segment
+		if err := seg.decode(buf[:n]); err != nil {
+			log.Debug("Ignoring invalid segment", "remote", remote, "err", err)
+			continue
+		}
+		m.dispatch(remote, &seg)
+	}
+}
+
+func (m *streamMux) dispatch(remote *UDPAddr, seg *segment) {
+	key := m.key(remote, seg.connID)
+	m.mtx.Lock()
+	s, ok := m.streams[key]
+	if !ok && seg.flags == flagSYN && m.listening {
+		if len(m.accept) == cap(m.accept) {
+			m.mtx.Unlock()
+			return
+		}
+		s = newStreamConn(m, key, remote, seg.connID, false)
+		m.streams[key] = s
+		// The connection is queued before its first segment is handled, so that it cannot be
+		// removed before it is queued.
+		m.accept <- s
+	}
+	m.mtx.Unlock()
+	if s == nil {
+		if seg.flags&flagRST == 0 {
+			m.reset(remote, seg)
+		}
+		return
+	}
+	s.handleSegment(remote, seg)
+}
+
+// reset answers a segment for an unknown connection.
+func (m *streamMux) reset(remote *UDPAddr, seg *segment) {
+	rst := segment{flags: flagRST, connID: seg.connID, seq: seg.ack}
+	buf := make([]byte, streamHeaderLen)
+	if _, err := m.conn.WriteTo(rst.encode(buf), remote); err != nil {
+		log.Debug("Sending stream reset failed", "remote", remote, "err", err)
+	}
+}
+
+// revoke informs the connections that an interface is down.
+func (m *streamMux) revoke(rev *path_mgmt.RevInfo) {
+	m.mtx.Lock()
+	streams := make([]*StreamConn, 0, len(m.streams))
+	for _, s := range m.streams {
+		streams = append(streams, s)
+	}
+	m.mtx.Unlock()
+	for _, s := range streams {
+		s.handleRevocation(rev)
+	}
+}
+
+// fail terminates the connections once the socket is unusable.
+func (m *streamMux) fail(err error) {
+	m.mtx.Lock()
+	streams := make([]*StreamConn, 0, len(m.streams))
+	for _, s := range m.streams {
+		streams = append(streams, s)
+	}
+	m.mtx.Unlock()
+	for _, s := range streams {
+		s.terminate(serrors.Wrap("reading from socket", err))
+	}
+}
+
+func (m *streamMux) write(b []byte, remote *UDPAddr) error {
+	_, err := m.conn.WriteTo(b, remote)
+	return err
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"encoding/binary"
+	"strings"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// streamHeaderLen is the length of the header of a stream segment. The header is carried in the
+// UDP payload:
+//
+//	 0                   1                   2                   3
+//	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|    Version    |     Flags     |              MSS              |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|                         Connection ID                         |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|                        Sequence Number                        |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|                     Acknowledgment Number                     |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|                             Window                            |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//
+// The MSS is the largest payload that the sender of the segment can receive, given the MTU of
+// its path. The window is the number of bytes, starting at the acknowledgment number, that the
+// sender of the segment can buffer.
+const streamHeaderLen = 20
+
+// streamVersion is the version of the stream protocol.
+const streamVersion = 0
+
+type segmentFlags uint8
+
+const (
+	// flagSYN opens a connection. It takes sequence number 0.
+	flagSYN segmentFlags = 1 << iota
+	// flagACK marks the acknowledgment number as valid.
+	flagACK
+	// flagFIN ends the stream of the sender. It takes the sequence number after the data.
+	flagFIN
+	// flagRST aborts the connection.
+	flagRST
+)
+
+func (f segmentFlags) String() string {
+	var names []string
+	for _, n := range []struct {
+		flag segmentFlags
+		name string
+	}{{flagSYN, "SYN"}, {flagACK, "ACK"}, {flagFIN, "FIN"}, {flagRST, "RST"}} {
+		if f&n.flag != 0 {
+			names = append(names, n.name)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// segment is a unit of the stream protocol. Sequence numbers count bytes, modulo 2^32.
+type segment struct {
+	flags   segmentFlags
+	mss     uint16
+	connID  uint32
+	seq     uint32
+	ack     uint32
+	window  uint32
+	payload []byte
+}
+
+// encode serializes the segment into buf, which must be large enough, and returns the result.
+func (s *segment) encode(buf []byte) []byte {
+	buf[0] = streamVersion
+	buf[1] = uint8(s.flags)
+	binary.BigEndian.PutUint16(buf[2:4], s.mss)
+	binary.BigEndian.PutUint32(buf[4:8], s.connID)
+	binary.BigEndian.PutUint32(buf[8:12], s.seq)
+	binary.BigEndian.PutUint32(buf[12:16], s.ack)
+	binary.BigEndian.PutUint32(buf[16:20], s.window)
+	n := copy(buf[streamHeaderLen:], s.payload)
+	return buf[:streamHeaderLen+n]
+}
+
+// decode parses the segment from b. The payload references b.
+func (s *segment) decode(b []byte) error {
+	if len(b) < streamHeaderLen {
+		return serrors.New("segment too short", "len", len(b))
+	}
+	if b[0] != streamVersion {
+		return serrors.New("unsupported stream version", "version", b[0])
+	}
+	s.flags = segmentFlags(b[1])
+	s.mss = binary.BigEndian.Uint16(b[2:4])
+	s.connID = binary.BigEndian.Uint32(b[4:8])
+	s.seq = binary.BigEndian.Uint32(b[8:12])
+	s.ack = binary.BigEndian.Uint32(b[12:16])
+	s.window = binary.BigEndian.Uint32(b[16:20])
+	s.payload = b[streamHeaderLen:]
+	return nil
+}
+
+// seqLess reports whether sequence number a comes before b.
+func seqLess(a, b uint32) bool {
+	return int32(a-b) < 0
+}
+
+// seqLessEq reports whether sequence number a comes before or is b.
+func seqLessEq(a, b uint32) bool {
+	return int32(a-b) <= 0
+}
@@ -0,0 +1,314 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand/v2"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/segment/iface"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+// memNetwork delivers datagrams between memConns. It loses some of them, and those sent through
+// the next hops that are down.
+type memNetwork struct {
+	mtx   sync.Mutex
+	conns map[string]*memConn
+	loss  float64
+	rng   *rand.Rand
+	down  map[string]bool
+}
+
+func newMemNetwork(loss float64) *memNetwork {
+	return &memNetwork{
+		conns: make(map[string]*memConn),
+		loss:  loss,
+		rng:   rand.New(rand.NewPCG(1, 2)),
+		down:  make(map[string]bool),
+	}
+}
+
+func (n *memNetwork) listen(t *testing.T, address string) *memConn {
+	a, err := snet.ParseUDPAddr(address)
+	require.NoError(t, err)
+	c := &memConn{
+		net:    n,
+		local:  a,
+		in:     make(chan memDatagram, 1024),
+		errs:   make(chan error, 1),
+		closed: make(chan struct{}),
+	}
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.conns[a.Host.String()] = c
+	return c
+}
+
+func (n *memNetwork) setDown(nextHop *net.UDPAddr, down bool) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.down[nextHop.String()] = down
+}
+
+type memDatagram struct {
+	b    []byte
+	from *snet.UDPAddr
+}
+
+type memConn struct {
+	net    *memNetwork
+	local  *snet.UDPAddr
+	in     chan memDatagram
+	errs   chan error
+	closed chan struct{}
+	once   sync.Once
+
+	mtx       sync.Mutex
+	maxLen    int
+	nextHops  map[string]int
+	datagrams int
+}
+
+func (c *memConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	select {
+	case d := <-c.in:
+		return copy(b, d.b), d.from, nil
+	case err := <-c.errs:
+		return 0, nil, err
+	case <-c.closed:
+		return 0, nil, net.ErrClosed
+	}
+}
+
+func (c *memConn) WriteTo(b []byte, a net.Addr) (int, error) {
+	dst := a.(*snet.UDPAddr)
+	c.mtx.Lock()
+	c.maxLen = max(c.maxLen, len(b))
+	c.datagrams++
+	if dst.NextHop != nil {
+		if c.nextHops == nil {
+			c.nextHops = make(map[string]int)
+		}
+		c.nextHops[dst.NextHop.String()]++
+	}
+	c.mtx.Unlock()
+
+	n := c.net
+	n.mtx.Lock()
+	peer := n.conns[dst.Host.String()]
+	lost := n.rng.Float64() < n.loss || (dst.NextHop != nil && n.down[dst.NextHop.String()])
+	n.mtx.Unlock()
+	if peer == nil || lost {
+		return len(b), nil
+	}
+	from := c.local.Copy()
+	from.Path = snetpath.Empty{}
+	select {
+	case peer.in <- memDatagram{b: bytes.Clone(b), from: from}:
+	default:
+	}
+	return len(b), nil
+}
+
+func (c *memConn) Close() error {
+	c.once.Do(func() { close(c.closed) })
+	return nil
+}
+
+func (c *memConn) LocalAddr() net.Addr                { return c.local }
+func (c *memConn) SetDeadline(t time.Time) error      { return nil }
+func (c *memConn) SetReadDeadline(t time.Time) error  { return nil }
+func (c *memConn) SetWriteDeadline(t time.Time) error { return nil }
+
+func (c *memConn) stats() (int, map[string]int) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	hops := make(map[string]int, len(c.nextHops))
+	for k, v := range c.nextHops {
+		hops[k] = v
+	}
+	return c.maxLen, hops
+}
+
+type staticRouter []snet.Path
+
+func (r staticRouter) Route(ctx context.Context, dst addr.IA) (snet.Path, error) {
+	return r[0], nil
+}
+
+func (r staticRouter) AllRoutes(ctx context.Context, dst addr.IA) ([]snet.Path, error) {
+	return r, nil
+}
+
+func testPath(nextHop string, mtu uint16, ifIDs ...uint16) snetpath.Path {
+	var intfs []snet.PathInterface
+	for i, id := range ifIDs {
+		ia := addr.MustParseIA("1-ff00:0:110")
+		if i%2 == 1 {
+			ia = addr.MustParseIA("1-ff00:0:111")
+		}
+		intfs = append(intfs, snet.PathInterface{IA: ia, ID: iface.ID(id)})
+	}
+	hop, _ := net.ResolveUDPAddr("udp", nextHop)
+	return snetpath.Path{
+		Src:           addr.MustParseIA("1-ff00:0:110"),
+		Dst:           addr.MustParseIA("1-ff00:0:111"),
+		DataplanePath: snetpath.Empty{},
+		NextHop:       hop,
+		Meta:          snet.PathMetadata{Interfaces: intfs, MTU: mtu},
+	}
+}
+
+// transfer sends data from the client to the server, and checks that it arrives intact.
+func transfer(t *testing.T, client, server net.Conn, data []byte) {
+	t.Helper()
+	received := make(chan []byte, 1)
+	go func() {
+		b, err := io.ReadAll(server)
+		assert.NoError(t, err)
+		received <- b
+	}()
+	_, err := client.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, client.Close())
+	select {
+	case b := <-received:
+		assert.True(t, bytes.Equal(data, b), "received %d of %d bytes", len(b), len(data))
+	case <-time.After(30 * time.Second):
+		t.Fatal("transfer timed out")
+	}
+}
+
+func randomData(n int) []byte {
+	data := make([]byte, n)
+	rng := rand.New(rand.NewPCG(3, 4))
+	for i := range data {
+		data[i] = byte(rng.Uint32())
+	}
+	return data
+}
+
+func dialAndAccept(
+	t *testing.T,
+	n *memNetwork,
+	opts ...snet.StreamOption,
+) (*snet.StreamConn, *snet.StreamConn, *memConn) {
+	t.Helper()
+	listener, err := snet.ListenStream(n.listen(t, "1-ff00:0:111,127.0.0.2:2000"))
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	remote, err := snet.ParseUDPAddr("1-ff00:0:111,127.0.0.2:2000")
+	require.NoError(t, err)
+	remote.Path = snetpath.Empty{}
+
+	clientConn := n.listen(t, "1-ff00:0:110,127.0.0.1:1000")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := snet.DialStream(ctx, clientConn, remote, opts...)
+	require.NoError(t, err)
+	server, err := listener.AcceptStream()
+	require.NoError(t, err)
+	return client, server, clientConn
+}
+
+func TestStreamTransfer(t *testing.T) {
+	t.Run("reliable network", func(t *testing.T) {
+		client, server, _ := dialAndAccept(t, newMemNetwork(0))
+		transfer(t, client, server, randomData(3<<20))
+	})
+	t.Run("lossy network", func(t *testing.T) {
+		client, server, _ := dialAndAccept(t, newMemNetwork(0.02))
+		transfer(t, client, server, randomData(256<<10))
+	})
+}
+
+func TestStreamSegmentSize(t *testing.T) {
+	router := staticRouter{testPath("10.0.0.1:30041", 600, 1, 2)}
+	client, server, clientConn := dialAndAccept(t, newMemNetwork(0), snet.WithStreamRouter(router))
+	transfer(t, client, server, randomData(64<<10))
+
+	// IPv4 hosts, an empty path and the UDP header.
+	overhead := slayers.CmnHdrLen + 2*addr.IABytes + 2*4 + 8
+	maxLen, _ := clientConn.stats()
+	assert.Equal(t, 600-overhead, maxLen)
+}
+
+func TestStreamMigration(t *testing.T) {
+	n := newMemNetwork(0)
+	pathA := testPath("10.0.0.1:30041", 1400, 1, 2)
+	pathB := testPath("10.0.0.2:30041", 1400, 3, 4)
+	client, server, clientConn := dialAndAccept(t, n,
+		snet.WithStreamRouter(staticRouter{pathA, pathB}))
+	assert.Equal(t, pathA.NextHop.String(), client.Path().UnderlayNextHop().String())
+
+	first := randomData(100 << 10)
+	_, err := client.Write(first)
+	require.NoError(t, err)
+	b := make([]byte, len(first))
+	_, err = io.ReadFull(server, b)
+	require.NoError(t, err)
+
+	// The link of path A goes down, and the router reports it.
+	n.setDown(pathA.NextHop, true)
+	err = snet.DefaultSCMPHandler{}.Handle(&snet.Packet{
+		PacketInfo: snet.PacketInfo{
+			Payload: snet.SCMPExternalInterfaceDown{
+				IA:        addr.MustParseIA("1-ff00:0:110"),
+				Interface: 1,
+			},
+		},
+	})
+	require.Error(t, err)
+	clientConn.errs <- err
+
+	transfer(t, client, server, randomData(100<<10))
+	assert.Equal(t, pathB.NextHop.String(), client.Path().UnderlayNextHop().String())
+	_, hops := clientConn.stats()
+	assert.NotZero(t, hops[pathB.NextHop.String()])
+}
+
+func TestStreamDialTimeout(t *testing.T) {
+	n := newMemNetwork(0)
+	remote, err := snet.ParseUDPAddr("1-ff00:0:111,127.0.0.2:2000")
+	require.NoError(t, err)
+	remote.Path = snetpath.Empty{}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = snet.DialStream(ctx, n.listen(t, "1-ff00:0:110,127.0.0.1:1000"), remote)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestStreamDeadline(t *testing.T) {
+	client, server, _ := dialAndAccept(t, newMemNetwork(0))
+	defer client.Close()
+	require.NoError(t, server.SetReadDeadline(time.Now().Add(50*time.Millisecond)))
+	_, err := server.Read(make([]byte, 10))
+	var netErr net.Error
+	require.ErrorAs(t, err, &netErr)
+	assert.True(t, netErr.Timeout())
+}
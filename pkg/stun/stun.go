@@ -5,19 +5,25 @@
 // Modifications:
 // - Remove requirement for "software" attribute
 // - Remove unused methods
+// - Add NewTxID, Request and ParseResponse for the client side
 
-// Package STUN parses STUN binding request packets and generates response packets.
+// Package STUN parses STUN binding request packets and generates response packets. On the
+// client side, it generates binding requests and parses the responses.
 package stun
 
 import (
+	"bytes"
+	crand "crypto/rand"
 	"encoding/binary"
 	"errors"
 	"hash/crc32"
+	"net"
 	"net/netip"
 )
 
 const (
 	attrNumFingerprint   = 0x8028
+	attrMappedAddress    = 0x0001
 	attrXorMappedAddress = 0x0020
 	bindingRequest       = "\x00\x01"
 	magicCookie          = "\x21\x12\xa4\x42"
@@ -34,6 +40,38 @@ func appendU16(b []byte, v uint16) []byte {
 	return append(b, byte(v>>8), byte(v))
 }
 
+func appendU32(b []byte, v uint32) []byte {
+	return append(b, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+// NewTxID returns a new random transaction ID.
+func NewTxID() TxID {
+	var tx TxID
+	if _, err := crand.Read(tx[:]); err != nil {
+		panic(err)
+	}
+	return tx
+}
+
+// Request generates a binding request STUN packet.
+// The transaction ID, tID, should be a random sequence of bytes.
+func Request(tID TxID) []byte {
+	// STUN header, RFC5389 Section 6.
+	b := make([]byte, 0, headerLen+lenFingerprint)
+	b = append(b, bindingRequest...)
+	b = appendU16(b, uint16(lenFingerprint)) // number of bytes following header
+	b = append(b, magicCookie...)
+	b = append(b, tID[:]...)
+
+	// Attribute FINGERPRINT, RFC5389 Section 15.5.
+	fp := fingerPrint(b)
+	b = appendU16(b, attrNumFingerprint)
+	b = appendU16(b, 4)
+	b = appendU32(b, fp)
+
+	return b
+}
+
 // ParseBindingRequest parses a STUN binding request.
 func ParseBindingRequest(b []byte) (TxID, error) {
 	if !Is(b) {
@@ -71,6 +109,8 @@ var (
 	ErrNotBindingRequest = errors.New("STUN request not a binding request")
 	ErrNoFingerprint     = errors.New("STUN request didn't end in fingerprint")
 	ErrWrongFingerprint  = errors.New("STUN request had bogus fingerprint")
+
+	ErrNotSuccessResponse = errors.New("STUN packet is not a response")
 )
 
 func foreachAttr(b []byte, fn func(attrType uint16, a []byte) error) error {
@@ -132,6 +172,120 @@ func Response(txID TxID, addrPort netip.AddrPort) []byte {
 	return b
 }
 
+// ParseResponse parses a successful binding response STUN packet.
+// The IP address is extracted from the XOR-MAPPED-ADDRESS attribute.
+func ParseResponse(b []byte) (tID TxID, addr netip.AddrPort, err error) {
+	if !Is(b) {
+		return tID, netip.AddrPort{}, ErrNotSTUN
+	}
+	copy(tID[:], b[8:8+len(tID)])
+	if b[0] != 0x01 || b[1] != 0x01 {
+		return tID, netip.AddrPort{}, ErrNotSuccessResponse
+	}
+	attrsLen := int(binary.BigEndian.Uint16(b[2:4]))
+	b = b[headerLen:] // remove STUN header
+	if attrsLen > len(b) {
+		return tID, netip.AddrPort{}, ErrMalformedAttrs
+	} else if len(b) > attrsLen {
+		b = b[:attrsLen] // trim trailing packet bytes
+	}
+
+	var fallbackAddr netip.AddrPort
+
+	// Read through the attributes.
+	// The the addr+port reported by XOR-MAPPED-ADDRESS
+	// as the canonical value. If the attribute is not
+	// present but the STUN server responds with
+	// MAPPED-ADDRESS we fall back to it.
+	if err := foreachAttr(b, func(attrType uint16, attr []byte) error {
+		switch attrType {
+		case attrXorMappedAddress:
+			ipSlice, port, err := xorMappedAddress(tID, attr)
+			if err != nil {
+				return err
+			}
+			if ip, ok := netip.AddrFromSlice(ipSlice); ok {
+				addr = netip.AddrPortFrom(ip.Unmap(), port)
+			}
+		case attrMappedAddress:
+			ipSlice, port, err := mappedAddress(attr)
+			if err != nil {
+				return ErrMalformedAttrs
+			}
+			if ip, ok := netip.AddrFromSlice(ipSlice); ok {
+				fallbackAddr = netip.AddrPortFrom(ip.Unmap(), port)
+			}
+		}
+		return nil
+
+	}); err != nil {
+		return TxID{}, netip.AddrPort{}, err
+	}
+
+	if addr.IsValid() {
+		return tID, addr, nil
+	}
+	if fallbackAddr.IsValid() {
+		return tID, fallbackAddr, nil
+	}
+	return tID, netip.AddrPort{}, ErrMalformedAttrs
+}
+
+func xorMappedAddress(tID TxID, b []byte) (addr []byte, port uint16, err error) {
+	// XOR-MAPPED-ADDRESS attribute, RFC5389 Section 15.2
+	if len(b) < 4 {
+		return nil, 0, ErrMalformedAttrs
+	}
+	xorPort := binary.BigEndian.Uint16(b[2:4])
+	addrField := b[4:]
+	port = xorPort ^ 0x2112 // first half of magicCookie
+
+	addrLen := familyAddrLen(b[1])
+	if addrLen == 0 {
+		return nil, 0, ErrMalformedAttrs
+	}
+	if len(addrField) < addrLen {
+		return nil, 0, ErrMalformedAttrs
+	}
+	xorAddr := addrField[:addrLen]
+	addr = make([]byte, addrLen)
+	for i := range xorAddr {
+		if i < len(magicCookie) {
+			addr[i] = xorAddr[i] ^ magicCookie[i]
+		} else {
+			addr[i] = xorAddr[i] ^ tID[i-len(magicCookie)]
+		}
+	}
+	return addr, port, nil
+}
+
+func familyAddrLen(fam byte) int {
+	switch fam {
+	case 0x01: // IPv4
+		return net.IPv4len
+	case 0x02: // IPv6
+		return net.IPv6len
+	default:
+		return 0
+	}
+}
+
+func mappedAddress(b []byte) (addr []byte, port uint16, err error) {
+	if len(b) < 4 {
+		return nil, 0, ErrMalformedAttrs
+	}
+	port = uint16(b[2])<<8 | uint16(b[3])
+	addrField := b[4:]
+	addrLen := familyAddrLen(b[1])
+	if addrLen == 0 {
+		return nil, 0, ErrMalformedAttrs
+	}
+	if len(addrField) < addrLen {
+		return nil, 0, ErrMalformedAttrs
+	}
+	return bytes.Clone(addrField[:addrLen]), port, nil
+}
+
 // Is reports whether b is a STUN message.
 func Is(b []byte) bool {
 	return len(b) >= headerLen &&
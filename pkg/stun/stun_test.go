@@ -427,3 +427,34 @@ func is(b []byte) bool {
 		b[0]&0b11000000 == 0 && // top two bits must be zero
 		string(b[4:8]) == magicCookie
 }
+
+func TestRequestRoundTrip(t *testing.T) {
+	tx := stun.NewTxID()
+	got, err := stun.ParseBindingRequest(stun.Request(tx))
+	if err != nil {
+		t.Fatalf("parsing request: %v", err)
+	}
+	if got != tx {
+		t.Errorf("got TxID = %x; want %x", got, tx)
+	}
+}
+
+func TestParseResponse(t *testing.T) {
+	tx := stun.NewTxID()
+	want := netip.MustParseAddrPort("[2001:db8::1]:31000")
+	gotTx, got, err := stun.ParseResponse(stun.Response(tx, want))
+	if err != nil {
+		t.Fatalf("parsing response: %v", err)
+	}
+	if gotTx != tx || got != want {
+		t.Errorf("got %x %v; want %x %v", gotTx, got, tx, want)
+	}
+
+	_, _, err = stun.ParseResponse(stun.Request(tx))
+	if err != stun.ErrNotSuccessResponse {
+		t.Errorf("parsing request: got error %v; want %v", err, stun.ErrNotSuccessResponse)
+	}
+	if _, _, err := stun.ParseResponse([]byte("not stun")); err != stun.ErrNotSTUN {
+		t.Errorf("parsing garbage: got error %v; want %v", err, stun.ErrNotSTUN)
+	}
+}
@@ -17,6 +17,7 @@ package path
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/rand/v2"
 	"net"
@@ -71,6 +72,22 @@ func Filter(seq string, paths []snet.Path) ([]snet.Path, error) {
 	return s.Eval(paths), nil
 }
 
+// LoadPolicy loads a path policy from a JSON file. The policy must not extend other policies.
+func LoadPolicy(file string) (*pathpol.Policy, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, serrors.Wrap("reading policy file", err)
+	}
+	var ext pathpol.ExtPolicy
+	if err := json.Unmarshal(raw, &ext); err != nil {
+		return nil, serrors.Wrap("parsing policy file", err, "file", file)
+	}
+	if len(ext.Extends) != 0 {
+		return nil, serrors.New("extending policies is not supported", "extends", ext.Extends)
+	}
+	return pathpol.PolicyFromExtPolicy(&ext, nil)
+}
+
 // Choose selects a path to the remote.
 func Choose(
 	ctx context.Context,
@@ -79,7 +96,40 @@ func Choose(
 	opts ...Option,
 ) (snet.Path, error) {
 	o := applyOption(opts)
-	paths, err := fetchPaths(ctx, conn, remote, o.refresh, o.seq)
+	paths, err := fetchAndFilter(ctx, conn, remote, o)
+	if err != nil {
+		return nil, err
+	}
+	if o.interactive {
+		return printAndChoose(paths, remote, o.colorScheme)
+	}
+
+	return paths[rand.IntN(len(paths))], nil
+}
+
+// Fetch returns all the paths to the remote that match the options, sorted. The interactive
+// option is ignored.
+func Fetch(
+	ctx context.Context,
+	conn daemon.Connector,
+	remote addr.IA,
+	opts ...Option,
+) ([]snet.Path, error) {
+	paths, err := fetchAndFilter(ctx, conn, remote, applyOption(opts))
+	if err != nil {
+		return nil, err
+	}
+	Sort(paths)
+	return paths, nil
+}
+
+func fetchAndFilter(
+	ctx context.Context,
+	conn daemon.Connector,
+	remote addr.IA,
+	o options,
+) ([]snet.Path, error) {
+	paths, err := fetchPaths(ctx, conn, remote, o.refresh, o.seq, o.policy)
 	if err != nil {
 		return nil, serrors.Wrap("fetching paths", err)
 	}
@@ -114,11 +164,7 @@ func Choose(
 			return nil, serrors.New("no healthy paths available")
 		}
 	}
-	if o.interactive {
-		return printAndChoose(paths, remote, o.colorScheme)
-	}
-
-	return paths[rand.IntN(len(paths))], nil
+	return paths, nil
 }
 
 func filterUnhealthy(
@@ -173,13 +219,14 @@ func fetchPaths(
 	remote addr.IA,
 	refresh bool,
 	seq string,
+	policy *pathpol.Policy,
 ) ([]snet.Path, error) {
 	allPaths, err := conn.Paths(ctx, remote, 0, daemontypes.PathReqFlags{Refresh: refresh})
 	if err != nil {
 		return nil, serrors.Wrap("retrieving paths", err)
 	}
 
-	paths, err := Filter(seq, allPaths)
+	paths, err := Filter(seq, policy.Filter(allPaths))
 	if err != nil {
 		return nil, err
 	}
@@ -317,6 +364,7 @@ type options struct {
 	interactive bool
 	refresh     bool
 	seq         string
+	policy      *pathpol.Policy
 	colorScheme ColorScheme
 	probeCfg    *ProbeConfig
 	epic        bool
@@ -350,6 +398,14 @@ func WithSequence(seq string) Option {
 	}
 }
 
+// WithPolicy only considers the paths that match the policy. It can be combined with a
+// sequence.
+func WithPolicy(policy *pathpol.Policy) Option {
+	return func(o *options) {
+		o.policy = policy
+	}
+}
+
 func WithColorScheme(cs ColorScheme) Option {
 	return func(o *options) {
 		o.colorScheme = cs
@@ -15,9 +15,12 @@
 package path_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/snet"
@@ -62,3 +65,64 @@ func TestFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadPolicy(t *testing.T) {
+	paths := []snet.Path{
+		path.Path{
+			Dst: addr.MustParseIA("1-ff00:0:112"),
+			Meta: snet.PathMetadata{
+				Interfaces: []snet.PathInterface{
+					{IA: addr.MustParseIA("1-ff00:0:110"), ID: 1},
+					{IA: addr.MustParseIA("1-ff00:0:112"), ID: 2},
+				},
+			},
+		},
+		path.Path{
+			Dst: addr.MustParseIA("1-ff00:0:112"),
+			Meta: snet.PathMetadata{
+				Interfaces: []snet.PathInterface{
+					{IA: addr.MustParseIA("1-ff00:0:110"), ID: 3},
+					{IA: addr.MustParseIA("1-ff00:0:111"), ID: 4},
+					{IA: addr.MustParseIA("1-ff00:0:111"), ID: 5},
+					{IA: addr.MustParseIA("1-ff00:0:112"), ID: 6},
+				},
+			},
+		},
+	}
+	testCases := map[string]struct {
+		content   string
+		want      []snet.Path
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"acl": {
+			content:   `{"acl": ["- 1-ff00:0:111", "+"]}`,
+			want:      paths[:1],
+			assertErr: assert.NoError,
+		},
+		"sequence": {
+			content:   `{"sequence": "1-ff00:0:110#3 1-ff00:0:111 1-ff00:0:112"}`,
+			want:      paths[1:],
+			assertErr: assert.NoError,
+		},
+		"extends": {
+			content:   `{"extends": ["other"]}`,
+			assertErr: assert.Error,
+		},
+		"invalid": {
+			content:   `{"acl": ["+ 42"]}`,
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "policy.json")
+			require.NoError(t, os.WriteFile(file, []byte(tc.content), 0o644))
+			policy, err := apppath.LoadPolicy(file)
+			tc.assertErr(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.want, policy.Filter(paths))
+		})
+	}
+}
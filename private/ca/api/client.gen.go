@@ -97,6 +97,11 @@ type ClientInterface interface {
 	// GetHealthcheck request
 	GetHealthcheck(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PostCertificateEnrollmentWithBody request with any body
+	PostCertificateEnrollmentWithBody(ctx context.Context, isdNumber int, asNumber AS, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostCertificateEnrollment(ctx context.Context, isdNumber int, asNumber AS, body PostCertificateEnrollmentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostCertificateRenewalWithBody request with any body
 	PostCertificateRenewalWithBody(ctx context.Context, isdNumber int, asNumber AS, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -139,6 +144,30 @@ func (c *Client) GetHealthcheck(ctx context.Context, reqEditors ...RequestEditor
 	return c.Client.Do(req)
 }
 
+func (c *Client) PostCertificateEnrollmentWithBody(ctx context.Context, isdNumber int, asNumber AS, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostCertificateEnrollmentRequestWithBody(c.Server, isdNumber, asNumber, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostCertificateEnrollment(ctx context.Context, isdNumber int, asNumber AS, body PostCertificateEnrollmentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostCertificateEnrollmentRequest(c.Server, isdNumber, asNumber, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) PostCertificateRenewalWithBody(ctx context.Context, isdNumber int, asNumber AS, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewPostCertificateRenewalRequestWithBody(c.Server, isdNumber, asNumber, contentType, body)
 	if err != nil {
@@ -230,6 +259,60 @@ func NewGetHealthcheckRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
+// NewPostCertificateEnrollmentRequest calls the generic PostCertificateEnrollment builder with application/json body
+func NewPostCertificateEnrollmentRequest(server string, isdNumber int, asNumber AS, body PostCertificateEnrollmentJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostCertificateEnrollmentRequestWithBody(server, isdNumber, asNumber, "application/json", bodyReader)
+}
+
+// NewPostCertificateEnrollmentRequestWithBody generates requests for PostCertificateEnrollment with any type of body
+func NewPostCertificateEnrollmentRequestWithBody(server string, isdNumber int, asNumber AS, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "isd-number", runtime.ParamLocationPath, isdNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "as-number", runtime.ParamLocationPath, asNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/ra/isds/%s/ases/%s/certificates/enrollment", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 // NewPostCertificateRenewalRequest calls the generic PostCertificateRenewal builder with application/json body
 func NewPostCertificateRenewalRequest(server string, isdNumber int, asNumber AS, body PostCertificateRenewalJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -335,6 +418,11 @@ type ClientWithResponsesInterface interface {
 	// GetHealthcheckWithResponse request
 	GetHealthcheckWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthcheckResponse, error)
 
+	// PostCertificateEnrollmentWithBodyWithResponse request with any body
+	PostCertificateEnrollmentWithBodyWithResponse(ctx context.Context, isdNumber int, asNumber AS, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostCertificateEnrollmentResponse, error)
+
+	PostCertificateEnrollmentWithResponse(ctx context.Context, isdNumber int, asNumber AS, body PostCertificateEnrollmentJSONRequestBody, reqEditors ...RequestEditorFn) (*PostCertificateEnrollmentResponse, error)
+
 	// PostCertificateRenewalWithBodyWithResponse request with any body
 	PostCertificateRenewalWithBodyWithResponse(ctx context.Context, isdNumber int, asNumber AS, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostCertificateRenewalResponse, error)
 
@@ -391,6 +479,34 @@ func (r GetHealthcheckResponse) StatusCode() int {
 	return 0
 }
 
+type PostCertificateEnrollmentResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *RenewalResponse
+	ApplicationproblemJSON400 *N400BadRequest
+	ApplicationproblemJSON401 *N401UnauthorizedError
+	ApplicationproblemJSON403 *N403Forbidden
+	ApplicationproblemJSON404 *N404NotFound
+	ApplicationproblemJSON500 *N500InternalServerError
+	ApplicationproblemJSON503 *N503ServiceUnavailable
+}
+
+// Status returns HTTPResponse.Status
+func (r PostCertificateEnrollmentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostCertificateEnrollmentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type PostCertificateRenewalResponse struct {
 	Body                      []byte
 	HTTPResponse              *http.Response
@@ -444,6 +560,23 @@ func (c *ClientWithResponses) GetHealthcheckWithResponse(ctx context.Context, re
 	return ParseGetHealthcheckResponse(rsp)
 }
 
+// PostCertificateEnrollmentWithBodyWithResponse request with arbitrary body returning *PostCertificateEnrollmentResponse
+func (c *ClientWithResponses) PostCertificateEnrollmentWithBodyWithResponse(ctx context.Context, isdNumber int, asNumber AS, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostCertificateEnrollmentResponse, error) {
+	rsp, err := c.PostCertificateEnrollmentWithBody(ctx, isdNumber, asNumber, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostCertificateEnrollmentResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostCertificateEnrollmentWithResponse(ctx context.Context, isdNumber int, asNumber AS, body PostCertificateEnrollmentJSONRequestBody, reqEditors ...RequestEditorFn) (*PostCertificateEnrollmentResponse, error) {
+	rsp, err := c.PostCertificateEnrollment(ctx, isdNumber, asNumber, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostCertificateEnrollmentResponse(rsp)
+}
+
 // PostCertificateRenewalWithBodyWithResponse request with arbitrary body returning *PostCertificateRenewalResponse
 func (c *ClientWithResponses) PostCertificateRenewalWithBodyWithResponse(ctx context.Context, isdNumber int, asNumber AS, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostCertificateRenewalResponse, error) {
 	rsp, err := c.PostCertificateRenewalWithBody(ctx, isdNumber, asNumber, contentType, body, reqEditors...)
@@ -555,6 +688,74 @@ func ParseGetHealthcheckResponse(rsp *http.Response) (*GetHealthcheckResponse, e
 	return response, nil
 }
 
+// ParsePostCertificateEnrollmentResponse parses an HTTP response from a PostCertificateEnrollmentWithResponse call
+func ParsePostCertificateEnrollmentResponse(rsp *http.Response) (*PostCertificateEnrollmentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostCertificateEnrollmentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RenewalResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest N400BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest N401UnauthorizedError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest N403Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest N404NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest N500InternalServerError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest N503ServiceUnavailable
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParsePostCertificateRenewalResponse parses an HTTP response from a PostCertificateRenewalWithResponse call
 func ParsePostCertificateRenewalResponse(rsp *http.Response) (*PostCertificateRenewalResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
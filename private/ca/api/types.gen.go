@@ -67,6 +67,18 @@ type CertificateChain struct {
 // CA certificate encoded in a degenerate PKCS#7 data structure.
 type CertificateChainPKCS7 = []byte
 
+// EnrollmentRequest defines model for EnrollmentRequest.
+type EnrollmentRequest struct {
+	// Csr Base64 encoded ASN.1 DER encoded PKCS#10 defining the parameters of
+	// the requested certificate. The PKCS#10 MUST be signed with the key
+	// of the requested certificate, and its subject MUST contain the
+	// ISD-AS that the enrollment token was issued for.
+	Csr []byte `json:"csr"`
+
+	// Token Single-use enrollment token that the CA issued for the AS.
+	Token string `json:"token"`
+}
+
 // HealthCheckStatus defines model for HealthCheckStatus.
 type HealthCheckStatus struct {
 	Status HealthCheckStatusStatus `json:"status"`
@@ -141,6 +153,10 @@ type N400BadRequest = Problem
 // [RFC7807](https://tools.ietf.org/html/rfc7807)
 type N401UnauthorizedError = Problem
 
+// N403Forbidden Error message encoded as specified in
+// [RFC7807](https://tools.ietf.org/html/rfc7807)
+type N403Forbidden = Problem
+
 // N404NotFound Error message encoded as specified in
 // [RFC7807](https://tools.ietf.org/html/rfc7807)
 type N404NotFound = Problem
@@ -156,6 +172,9 @@ type N503ServiceUnavailable = Problem
 // PostAuthTokenJSONRequestBody defines body for PostAuthToken for application/json ContentType.
 type PostAuthTokenJSONRequestBody = AccessCredentials
 
+// PostCertificateEnrollmentJSONRequestBody defines body for PostCertificateEnrollment for application/json ContentType.
+type PostCertificateEnrollmentJSONRequestBody = EnrollmentRequest
+
 // PostCertificateRenewalJSONRequestBody defines body for PostCertificateRenewal for application/json ContentType.
 type PostCertificateRenewalJSONRequestBody = RenewalRequest
 
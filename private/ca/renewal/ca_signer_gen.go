@@ -62,16 +62,43 @@ type PolicyGen interface {
 	Generate(context.Context) (cppki.CAPolicy, error)
 }
 
+// ErrIssuanceLimit indicates that the subject has reached the issuance limit.
+var ErrIssuanceLimit = serrors.New("issuance limit reached")
+
 // ChainBuilder creates a certificate chain with the generated policy.
 type ChainBuilder struct {
 	PolicyGen    PolicyGen
 	SignedChains func(string) metrics.Counter
+	// Ledger records the issued chains. If nil, the issued chains are not
+	// recorded and the issuance limit is not enforced.
+	Ledger Ledger
+	// IssuanceLimit limits the number of chains issued per subject.
+	IssuanceLimit IssuanceLimit
 }
 
 // CreateChain creates a certificate chain with the latest available CA policy.
+// If a ledger is configured, a record is reserved in the ledger before the
+// chain is created, such that concurrent requests cannot exceed the issuance
+// limit. The chain is only returned if it was recorded successfully.
 func (c ChainBuilder) CreateChain(ctx context.Context,
 	csr *x509.CertificateRequest) ([]*x509.Certificate, error) {
 
+	reservation, err := c.reserve(ctx, csr)
+	if err != nil {
+		return nil, err
+	}
+	chain, err := c.createChain(ctx, csr, reservation)
+	if err != nil {
+		c.release(ctx, reservation)
+		return nil, err
+	}
+	c.incSignedChains("ok_success")
+	return chain, nil
+}
+
+func (c ChainBuilder) createChain(ctx context.Context, csr *x509.CertificateRequest,
+	reservation int64) ([]*x509.Certificate, error) {
+
 	policy, err := c.PolicyGen.Generate(ctx)
 	if err != nil {
 		c.incSignedChains("err_inactive")
@@ -82,10 +109,55 @@ func (c ChainBuilder) CreateChain(ctx context.Context,
 		c.incSignedChains("err_internal")
 		return nil, err
 	}
-	c.incSignedChains("ok_success")
+	if c.Ledger != nil {
+		record, err := NewIssuedChain(chain, requestSource(ctx), time.Now())
+		if err != nil {
+			c.incSignedChains("err_internal")
+			return nil, err
+		}
+		if err := c.Ledger.CompleteIssuedChain(ctx, reservation, record); err != nil {
+			c.incSignedChains("err_db")
+			return nil, serrors.Wrap("recording issued chain", err)
+		}
+	}
 	return chain, nil
 }
 
+// reserve reserves the ledger record for the chain requested by the CSR. The
+// issuance limit is enforced when reserving.
+func (c ChainBuilder) reserve(ctx context.Context, csr *x509.CertificateRequest) (int64, error) {
+	if c.Ledger == nil {
+		return 0, nil
+	}
+	subject, err := cppki.ExtractIA(csr.Subject)
+	if err != nil {
+		c.incSignedChains("err_internal")
+		return 0, serrors.Wrap("extracting ISD-AS from request", err)
+	}
+	reservation, err := c.Ledger.ReserveIssuedChain(ctx, subject, c.IssuanceLimit, time.Now())
+	switch {
+	case errors.Is(err, ErrIssuanceLimit):
+		c.incSignedChains("err_limit")
+		return 0, err
+	case err != nil:
+		c.incSignedChains("err_db")
+		return 0, serrors.Wrap("reserving issued chain", err)
+	}
+	return reservation, nil
+}
+
+// release releases the ledger record of a chain that was not issued. It is
+// released even if the request was canceled.
+func (c ChainBuilder) release(ctx context.Context, reservation int64) {
+	if c.Ledger == nil {
+		return
+	}
+	if err := c.Ledger.ReleaseIssuedChain(context.WithoutCancel(ctx), reservation); err != nil {
+		log.FromCtx(ctx).Info("Failed to release reserved issued chain",
+			"reservation", reservation, "err", err)
+	}
+}
+
 func (c ChainBuilder) incSignedChains(result string) {
 	if c.SignedChains != nil {
 		metrics.CounterInc(c.SignedChains(result))
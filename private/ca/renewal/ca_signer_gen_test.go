@@ -21,6 +21,7 @@ import (
 	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/sha256"
 	"crypto/x509"
 	"os"
 	"path/filepath"
@@ -44,6 +45,141 @@ import (
 	"github.com/scionproto/scion/scion-pki/testcrypto"
 )
 
+func TestChainBuilderCreateChain(t *testing.T) {
+	dir := genCrypto(t)
+	ca := xtest.LoadChain(t, filepath.Join(dir, "certs/ISD1-ASff00_0_110.ca.crt"))
+	key := loadKey(t, filepath.Join(dir, "ASff00_0_110/crypto/ca/cp-ca.key"))
+	csr := loadCSR(t, filepath.Join(dir, "ASff00_0_111/crypto/as/cp-as1.csr"))
+	ia111 := addr.MustParseIA("1-ff00:0:111")
+	policy := cppki.CAPolicy{Validity: time.Hour, Certificate: ca[0], Signer: key}
+
+	testCases := map[string]struct {
+		Ledger       func(mctrl *gomock.Controller) renewal.Ledger
+		Limit        renewal.IssuanceLimit
+		PolicyErr    error
+		ErrAssertion assert.ErrorAssertionFunc
+		Metric       string
+	}{
+		"no ledger": {
+			Ledger:       func(*gomock.Controller) renewal.Ledger { return nil },
+			Limit:        renewal.IssuanceLimit{MaxChains: 1, Window: time.Hour},
+			ErrAssertion: assert.NoError,
+			Metric:       "ok_success",
+		},
+		"recorded": {
+			Ledger: func(mctrl *gomock.Controller) renewal.Ledger {
+				l := mock_renewal.NewMockLedger(mctrl)
+				gomock.InOrder(
+					l.EXPECT().ReserveIssuedChain(gomock.Any(), ia111,
+						renewal.IssuanceLimit{}, gomock.Any()).Return(int64(42), nil),
+					l.EXPECT().CompleteIssuedChain(gomock.Any(), int64(42),
+						gomock.Any()).DoAndReturn(
+						func(_ context.Context, _ int64, c renewal.IssuedChain) error {
+							assert.Equal(t, ia111, c.Subject)
+							assert.NotNil(t, c.Serial)
+							assert.Equal(t, time.Hour, c.NotAfter.Sub(c.NotBefore))
+							fingerprint := sha256.Sum256(ca[0].Raw)
+							assert.Equal(t, fingerprint[:], c.CAFingerprint)
+							assert.Equal(t, "unknown", c.Source)
+							return nil
+						},
+					),
+				)
+				return l
+			},
+			ErrAssertion: assert.NoError,
+			Metric:       "ok_success",
+		},
+		"below limit": {
+			Ledger: func(mctrl *gomock.Controller) renewal.Ledger {
+				l := mock_renewal.NewMockLedger(mctrl)
+				l.EXPECT().ReserveIssuedChain(gomock.Any(), ia111,
+					renewal.IssuanceLimit{MaxChains: 2, Window: time.Hour},
+					gomock.Any()).Return(int64(1), nil)
+				l.EXPECT().CompleteIssuedChain(gomock.Any(), int64(1), gomock.Any())
+				return l
+			},
+			Limit:        renewal.IssuanceLimit{MaxChains: 2, Window: time.Hour},
+			ErrAssertion: assert.NoError,
+			Metric:       "ok_success",
+		},
+		"limit reached": {
+			Ledger: func(mctrl *gomock.Controller) renewal.Ledger {
+				l := mock_renewal.NewMockLedger(mctrl)
+				l.EXPECT().ReserveIssuedChain(gomock.Any(), ia111, gomock.Any(),
+					gomock.Any()).Return(int64(0), renewal.ErrIssuanceLimit)
+				return l
+			},
+			Limit: renewal.IssuanceLimit{MaxChains: 2, Window: time.Hour},
+			ErrAssertion: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, renewal.ErrIssuanceLimit)
+			},
+			Metric: "err_limit",
+		},
+		"reserve fails": {
+			Ledger: func(mctrl *gomock.Controller) renewal.Ledger {
+				l := mock_renewal.NewMockLedger(mctrl)
+				l.EXPECT().ReserveIssuedChain(gomock.Any(), ia111, gomock.Any(),
+					gomock.Any()).Return(int64(0), serrors.New("internal"))
+				return l
+			},
+			ErrAssertion: assert.Error,
+			Metric:       "err_db",
+		},
+		"signing fails": {
+			Ledger: func(mctrl *gomock.Controller) renewal.Ledger {
+				l := mock_renewal.NewMockLedger(mctrl)
+				gomock.InOrder(
+					l.EXPECT().ReserveIssuedChain(gomock.Any(), ia111, gomock.Any(),
+						gomock.Any()).Return(int64(7), nil),
+					l.EXPECT().ReleaseIssuedChain(gomock.Any(), int64(7)),
+				)
+				return l
+			},
+			PolicyErr:    serrors.New("inactive"),
+			ErrAssertion: assert.Error,
+			Metric:       "err_inactive",
+		},
+		"complete fails": {
+			Ledger: func(mctrl *gomock.Controller) renewal.Ledger {
+				l := mock_renewal.NewMockLedger(mctrl)
+				gomock.InOrder(
+					l.EXPECT().ReserveIssuedChain(gomock.Any(), ia111, gomock.Any(),
+						gomock.Any()).Return(int64(7), nil),
+					l.EXPECT().CompleteIssuedChain(gomock.Any(), int64(7),
+						gomock.Any()).Return(serrors.New("internal")),
+					l.EXPECT().ReleaseIssuedChain(gomock.Any(), int64(7)),
+				)
+				return l
+			},
+			ErrAssertion: assert.Error,
+			Metric:       "err_db",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			mctrl := gomock.NewController(t)
+			gen := mock_renewal.NewMockPolicyGen(mctrl)
+			gen.EXPECT().Generate(gomock.Any()).Return(policy, tc.PolicyErr).AnyTimes()
+			ctr := metrics.NewTestCounter()
+			b := renewal.ChainBuilder{
+				PolicyGen: gen,
+				SignedChains: func(result string) metrics.Counter {
+					return ctr.With("result", result)
+				},
+				Ledger:        tc.Ledger(mctrl),
+				IssuanceLimit: tc.Limit,
+			}
+			chain, err := b.CreateChain(context.Background(), csr)
+			if tc.ErrAssertion(t, err) && err == nil {
+				assert.Len(t, chain, 2)
+			}
+			assert.Equal(t, float64(1), metrics.CounterValue(ctr.With("result", tc.Metric)))
+		})
+	}
+}
+
 func TestCachingPolicyGenGenerate(t *testing.T) {
 	dir := genCrypto(t)
 
@@ -0,0 +1,185 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package renewal
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+)
+
+// ErrInvalidEnrollmentToken indicates that the enrollment token is unknown,
+// expired, already redeemed, or bound to a different subject.
+var ErrInvalidEnrollmentToken = serrors.New("invalid enrollment token")
+
+const (
+	// DefaultEnrollmentTokenValidity is the default validity of an enrollment
+	// token.
+	DefaultEnrollmentTokenValidity = time.Hour
+	// MaxEnrollmentTokenValidity is the maximum validity of an enrollment token.
+	MaxEnrollmentTokenValidity = 7 * 24 * time.Hour
+
+	// enrollmentTokenSize is the number of random bytes in an enrollment token.
+	enrollmentTokenSize = 32
+)
+
+// EnrollmentToken is the record of a single-use token that authenticates the
+// initial certificate chain request of an AS. Only the hash of the token is
+// stored.
+type EnrollmentToken struct {
+	// Hash is the SHA-256 hash of the token.
+	Hash []byte
+	// Subject is the ISD-AS that the token is bound to.
+	Subject addr.IA
+	// CreatedAt is the time the token was created.
+	CreatedAt time.Time
+	// Expiration is the time after which the token can no longer be redeemed.
+	Expiration time.Time
+}
+
+// NewEnrollmentToken creates a random token for the subject that is valid for
+// the given duration. It returns the token that is handed to the operator of
+// the AS, and the record that is stored by the CA.
+func NewEnrollmentToken(
+	subject addr.IA,
+	validity time.Duration,
+	now time.Time,
+) (string, EnrollmentToken, error) {
+
+	if subject.IsWildcard() {
+		return "", EnrollmentToken{}, serrors.New("wildcard subject", "isd_as", subject)
+	}
+	if validity <= 0 || validity > MaxEnrollmentTokenValidity {
+		return "", EnrollmentToken{}, serrors.New("validity out of range",
+			"validity", validity, "max", MaxEnrollmentTokenValidity)
+	}
+	raw := make([]byte, enrollmentTokenSize)
+	if _, err := rand.Read(raw); err != nil {
+		return "", EnrollmentToken{}, serrors.Wrap("generating token", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	return token, EnrollmentToken{
+		Hash:       HashEnrollmentToken(token),
+		Subject:    subject,
+		CreatedAt:  now,
+		Expiration: now.Add(validity),
+	}, nil
+}
+
+// HashEnrollmentToken returns the hash under which the token is stored.
+func HashEnrollmentToken(token string) []byte {
+	h := sha256.Sum256([]byte(token))
+	return h[:]
+}
+
+// EnrollmentTokenStore stores the enrollment tokens issued by the CA.
+type EnrollmentTokenStore interface {
+	// InsertEnrollmentToken inserts the record of an enrollment token.
+	InsertEnrollmentToken(ctx context.Context, token EnrollmentToken) error
+	// RedeemEnrollmentToken marks the token with the given hash as redeemed.
+	// If there is no token with the hash that is bound to the subject, has not
+	// expired at the given time, and has not been redeemed yet, an error
+	// wrapping ErrInvalidEnrollmentToken is returned.
+	RedeemEnrollmentToken(ctx context.Context, hash []byte, subject addr.IA,
+		now time.Time) error
+	// ReleaseEnrollmentToken marks the redeemed token with the given hash as
+	// not redeemed, such that it can be redeemed again. It is used if no chain
+	// was issued for the redemption.
+	ReleaseEnrollmentToken(ctx context.Context, hash []byte, subject addr.IA) error
+}
+
+// EnrollmentVerifier verifies the enrollment requests of ASes that do not have
+// a certificate chain yet.
+type EnrollmentVerifier struct {
+	// ISD is the ISD of the CA. Only ASes in this ISD can enroll.
+	ISD    addr.ISD
+	Tokens EnrollmentTokenStore
+}
+
+// VerifyEnrollmentRequest verifies an enrollment request. It checks that the
+// contained CSR is valid, correctly self-signed and for an AS in the ISD of the
+// CA, and redeems the token for the subject of the CSR. Redeeming the token
+// before the chain is issued ensures that concurrent requests with the same
+// token can not both be granted. If no chain is issued afterwards,
+// ReleaseEnrollmentRequest must be called, so that the token is not lost.
+func (v EnrollmentVerifier) VerifyEnrollmentRequest(
+	ctx context.Context,
+	req *cppb.EnrollmentRequest,
+) (*x509.CertificateRequest, error) {
+
+	if req.Token == "" {
+		return nil, serrors.New("token missing")
+	}
+	csr, err := x509.ParseCertificateRequest(req.Csr)
+	if err != nil {
+		return nil, serrors.Wrap("parsing CSR", err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, serrors.Wrap("invalid CSR signature", err)
+	}
+	subject, err := cppki.ExtractIA(csr.Subject)
+	if err != nil {
+		return nil, serrors.Wrap("extracting ISD-AS from CSR", err)
+	}
+	if subject.ISD() != v.ISD {
+		return nil, serrors.New("subject is not part of the ISD", "isd_as", subject,
+			"isd", v.ISD)
+	}
+	err = v.Tokens.RedeemEnrollmentToken(ctx, HashEnrollmentToken(req.Token), subject,
+		time.Now())
+	if err != nil {
+		return nil, serrors.Wrap("redeeming enrollment token", err, "isd_as", subject)
+	}
+	return csr, nil
+}
+
+// ReleaseEnrollmentRequest releases the token of a request that was verified
+// with VerifyEnrollmentRequest, but for which no chain was issued. The token
+// can then be used again until it expires.
+func (v EnrollmentVerifier) ReleaseEnrollmentRequest(
+	ctx context.Context,
+	req *cppb.EnrollmentRequest,
+	csr *x509.CertificateRequest,
+) error {
+
+	subject, err := cppki.ExtractIA(csr.Subject)
+	if err != nil {
+		return serrors.Wrap("extracting ISD-AS from CSR", err)
+	}
+	err = v.Tokens.ReleaseEnrollmentToken(ctx, HashEnrollmentToken(req.Token), subject)
+	if err != nil {
+		return serrors.Wrap("releasing enrollment token", err, "isd_as", subject)
+	}
+	return nil
+}
+
+// NewEnrollmentRequest builds a ChainRenewalRequest given a serialized CSR and
+// the enrollment token.
+func NewEnrollmentRequest(csr []byte, token string) *cppb.ChainRenewalRequest {
+	return &cppb.ChainRenewalRequest{
+		EnrollmentRequest: &cppb.EnrollmentRequest{
+			Token: token,
+			Csr:   csr,
+		},
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package renewal_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	"github.com/scionproto/scion/private/ca/renewal"
+	"github.com/scionproto/scion/private/ca/renewal/mock_renewal"
+)
+
+func TestNewEnrollmentToken(t *testing.T) {
+	ia := addr.MustParseIA("1-ff00:0:110")
+	now := time.Now()
+	token, record, err := renewal.NewEnrollmentToken(ia, time.Hour, now)
+	require.NoError(t, err)
+	assert.Equal(t, renewal.HashEnrollmentToken(token), record.Hash)
+	assert.Equal(t, ia, record.Subject)
+	assert.Equal(t, now, record.CreatedAt)
+	assert.Equal(t, now.Add(time.Hour), record.Expiration)
+
+	other, _, err := renewal.NewEnrollmentToken(ia, time.Hour, now)
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+
+	_, _, err = renewal.NewEnrollmentToken(addr.MustParseIA("1-0"), time.Hour, now)
+	assert.Error(t, err)
+	_, _, err = renewal.NewEnrollmentToken(ia, 0, now)
+	assert.Error(t, err)
+	_, _, err = renewal.NewEnrollmentToken(ia, renewal.MaxEnrollmentTokenValidity+time.Second,
+		now)
+	assert.Error(t, err)
+}
+
+func TestVerifyEnrollmentRequest(t *testing.T) {
+	dir := genCrypto(t)
+	csr := loadCSR(t, filepath.Join(dir, "ASff00_0_110/crypto/as/cp-as1.csr"))
+	ia := addr.MustParseIA("1-ff00:0:110")
+
+	testCases := map[string]struct {
+		request    *cppb.EnrollmentRequest
+		isd        addr.ISD
+		tokens     func(ctrl *gomock.Controller) renewal.EnrollmentTokenStore
+		assertFunc assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			request: &cppb.EnrollmentRequest{Token: "token", Csr: csr.Raw},
+			tokens: func(ctrl *gomock.Controller) renewal.EnrollmentTokenStore {
+				s := mock_renewal.NewMockEnrollmentTokenStore(ctrl)
+				s.EXPECT().RedeemEnrollmentToken(gomock.Any(),
+					renewal.HashEnrollmentToken("token"), ia, gomock.Any())
+				return s
+			},
+			assertFunc: assert.NoError,
+		},
+		"invalid token": {
+			request: &cppb.EnrollmentRequest{Token: "token", Csr: csr.Raw},
+			tokens: func(ctrl *gomock.Controller) renewal.EnrollmentTokenStore {
+				s := mock_renewal.NewMockEnrollmentTokenStore(ctrl)
+				s.EXPECT().RedeemEnrollmentToken(gomock.Any(),
+					renewal.HashEnrollmentToken("token"), ia, gomock.Any(),
+				).Return(renewal.ErrInvalidEnrollmentToken)
+				return s
+			},
+			assertFunc: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, renewal.ErrInvalidEnrollmentToken)
+			},
+		},
+		"other ISD": {
+			request: &cppb.EnrollmentRequest{Token: "token", Csr: csr.Raw},
+			isd:     2,
+			tokens: func(ctrl *gomock.Controller) renewal.EnrollmentTokenStore {
+				return mock_renewal.NewMockEnrollmentTokenStore(ctrl)
+			},
+			assertFunc: assert.Error,
+		},
+		"missing token": {
+			request: &cppb.EnrollmentRequest{Csr: csr.Raw},
+			tokens: func(ctrl *gomock.Controller) renewal.EnrollmentTokenStore {
+				return mock_renewal.NewMockEnrollmentTokenStore(ctrl)
+			},
+			assertFunc: assert.Error,
+		},
+		"malformed CSR": {
+			request: &cppb.EnrollmentRequest{Token: "token", Csr: []byte("dummy")},
+			tokens: func(ctrl *gomock.Controller) renewal.EnrollmentTokenStore {
+				return mock_renewal.NewMockEnrollmentTokenStore(ctrl)
+			},
+			assertFunc: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			isd := tc.isd
+			if isd == 0 {
+				isd = ia.ISD()
+			}
+			v := renewal.EnrollmentVerifier{ISD: isd, Tokens: tc.tokens(ctrl)}
+			got, err := v.VerifyEnrollmentRequest(context.Background(), tc.request)
+			tc.assertFunc(t, err)
+			if err == nil {
+				assert.Equal(t, csr.Raw, got.Raw)
+			}
+		})
+	}
+}
+
+func TestReleaseEnrollmentRequest(t *testing.T) {
+	dir := genCrypto(t)
+	csr := loadCSR(t, filepath.Join(dir, "ASff00_0_110/crypto/as/cp-as1.csr"))
+	ia := addr.MustParseIA("1-ff00:0:110")
+
+	ctrl := gomock.NewController(t)
+	s := mock_renewal.NewMockEnrollmentTokenStore(ctrl)
+	s.EXPECT().ReleaseEnrollmentToken(gomock.Any(), renewal.HashEnrollmentToken("token"), ia)
+	v := renewal.EnrollmentVerifier{ISD: ia.ISD(), Tokens: s}
+	err := v.ReleaseEnrollmentRequest(context.Background(),
+		&cppb.EnrollmentRequest{Token: "token", Csr: csr.Raw}, csr)
+	assert.NoError(t, err)
+}
@@ -17,6 +17,7 @@ package grpc
 import (
 	"context"
 	"crypto/x509"
+	"errors"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -26,6 +27,7 @@ import (
 	"github.com/scionproto/scion/pkg/metrics"
 	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
 	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/ca/renewal"
 )
 
 // ChainBuilder creates a chain for the given CSR.
@@ -44,6 +46,7 @@ type CMSHandlerMetrics struct {
 
 	DatabaseError metrics.Counter
 	InternalError metrics.Counter
+	LimitError    metrics.Counter
 	NotFoundError metrics.Counter
 	ParseError    metrics.Counter
 	VerifyError   metrics.Counter
@@ -86,6 +89,11 @@ func (s CMS) HandleCMSRequest(
 	}
 
 	newClientChain, err := s.ChainBuilder.CreateChain(ctx, csr)
+	if errors.Is(err, renewal.ErrIssuanceLimit) {
+		logger.Info("Certificate chain issuance limit reached", "err", err)
+		metrics.CounterInc(s.Metrics.LimitError)
+		return nil, status.Error(codes.ResourceExhausted, "issuance limit reached")
+	}
 	if err != nil {
 		logger.Info("Failed to create renewed certificate chain", "err", err)
 		metrics.CounterInc(s.Metrics.InternalError)
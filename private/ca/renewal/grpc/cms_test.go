@@ -175,6 +175,33 @@ func TestCMSHandleCMSRequest(t *testing.T) {
 			Code:      codes.Unavailable,
 			Metric:    "err_internal",
 		},
+		"issuance limit": {
+			Request: func(t *testing.T) *cppb.ChainRenewalRequest {
+				return signedReq
+			},
+			Verifier: func(ctrl *gomock.Controller) grpc.RenewalRequestVerifier {
+				v := mock_grpc.NewMockRenewalRequestVerifier(ctrl)
+				v.EXPECT().VerifyCMSSignedRenewalRequest(
+					context.Background(),
+					signedReq.CmsSignedRequest,
+				).Return(mockCSR, nil)
+				return v
+			},
+			ChainBuilder: func(ctrl *gomock.Controller) grpc.ChainBuilder {
+				cb := mock_grpc.NewMockChainBuilder(ctrl)
+				cb.EXPECT().CreateChain(gomock.Any(), gomock.Any()).Return(
+					nil, serrors.JoinNoStack(renewal.ErrIssuanceLimit, nil),
+				)
+				return cb
+			},
+			CMSSigner: func(ctrl *gomock.Controller) grpc.CMSSigner {
+				return mock_grpc.NewMockCMSSigner(ctrl)
+			},
+			IA:        addr.MustParseIA("1-ff00:0:110"),
+			Assertion: assert.Error,
+			Code:      codes.ResourceExhausted,
+			Metric:    "err_limit",
+		},
 		"valid": {
 			Request: func(t *testing.T) *cppb.ChainRenewalRequest {
 				return signedReq
@@ -213,6 +240,7 @@ func TestCMSHandleCMSRequest(t *testing.T) {
 				Metrics: grpc.CMSHandlerMetrics{
 					DatabaseError: ctr.With("result", "err_database"),
 					InternalError: ctr.With("result", "err_internal"),
+					LimitError:    ctr.With("result", "err_limit"),
 					NotFoundError: ctr.With("result", "err_notfound"),
 					ParseError:    ctr.With("result", "err_parse"),
 					VerifyError:   ctr.With("result", "err_verify"),
@@ -225,6 +253,7 @@ func TestCMSHandleCMSRequest(t *testing.T) {
 			for _, res := range []string{
 				"err_database",
 				"err_internal",
+				"err_limit",
 				"err_unavailable",
 				"err_notfound",
 				"err_parse",
@@ -43,6 +43,13 @@ type CAServiceClient interface {
 		body api.PostCertificateRenewalJSONRequestBody,
 		reqEditors ...api.RequestEditorFn,
 	) (*http.Response, error)
+	PostCertificateEnrollment(
+		ctx context.Context,
+		isd int,
+		as api.AS,
+		body api.PostCertificateEnrollmentJSONRequestBody,
+		reqEditors ...api.RequestEditorFn,
+	) (*http.Response, error)
 }
 
 // DelegatingHandlerMetrics contains the counters for the DelegatingHandler
@@ -100,6 +107,61 @@ func (h *DelegatingHandler) HandleCMSRequest(
 			Csr: req.CmsSignedRequest,
 		},
 	)
+	return h.handleResponse(rep, err, logger)
+}
+
+// HandleEnrollmentRequest handles an enrollment request that is authenticated
+// with an enrollment token by delegating it to the CA Service. The token is
+// verified by the CA Service.
+func (h *DelegatingHandler) HandleEnrollmentRequest(
+	ctx context.Context,
+	req *cppb.ChainRenewalRequest,
+) ([]*x509.Certificate, error) {
+
+	logger := log.FromCtx(ctx)
+
+	csr, err := x509.ParseCertificateRequest(req.EnrollmentRequest.Csr)
+	if err != nil {
+		logger.Info("Failed to parse CSR", "err", err)
+		metrics.CounterInc(h.Metrics.BadRequests)
+		return nil, status.Error(
+			codes.InvalidArgument,
+			"malformed request: cannot parse CSR",
+		)
+	}
+	subject, err := cppki.ExtractIA(csr.Subject)
+	if err != nil {
+		logger.Info("Failed to extract IA from CSR",
+			"err", err,
+			"subject", csr.Subject,
+		)
+		metrics.CounterInc(h.Metrics.BadRequests)
+		return nil, status.Error(
+			codes.InvalidArgument,
+			"malformed request: cannot extract ISD-AS from subject",
+		)
+	}
+
+	rep, err := h.Client.PostCertificateEnrollment(
+		ctx,
+		int(subject.ISD()),
+		subject.AS().String(),
+		api.PostCertificateEnrollmentJSONRequestBody{
+			Token: req.EnrollmentRequest.Token,
+			Csr:   req.EnrollmentRequest.Csr,
+		},
+	)
+	return h.handleResponse(rep, err, logger)
+}
+
+// handleResponse extracts the certificate chain from the response of the CA
+// Service. The error is the error returned by the request.
+func (h *DelegatingHandler) handleResponse(
+	rep *http.Response,
+	err error,
+	logger log.Logger,
+) ([]*x509.Certificate, error) {
+
 	if err != nil {
 		logger.Info("Request to CA service failed", "err", err)
 		metrics.CounterInc(h.Metrics.InternalError)
@@ -194,6 +256,13 @@ func (h *DelegatingHandler) handleErrors(code int, body []byte, logger log.Logge
 		logger.Info("Unauthorized certificate renewal request", "err", string(body))
 		metrics.CounterInc(h.Metrics.Unavailable)
 		return status.Error(codes.Unavailable, "service unavailable")
+	case http.StatusForbidden:
+		logger.Info("Forbidden certificate request", "err", string(body))
+		metrics.CounterInc(h.Metrics.BadRequests)
+		return status.Error(
+			codes.PermissionDenied,
+			msgWithDetail("permission denied", problem.Detail),
+		)
 	case http.StatusNotFound:
 		logger.Info("Resource not found in certificate renewal request", "err", string(body))
 		metrics.CounterInc(h.Metrics.BadRequests)
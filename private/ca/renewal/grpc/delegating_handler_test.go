@@ -236,6 +236,18 @@ func TestDelegatingHandler(t *testing.T) {
 			// Check internal detail is not leaked.
 			ExpectedError: status.Error(codes.Unavailable, "service unavailable"),
 		},
+		"Forbidden": {
+			Code:          http.StatusForbidden,
+			Body:          `{}`,
+			Metric:        "err_bad_request",
+			ExpectedError: status.Error(codes.PermissionDenied, "permission denied"),
+		},
+		"ForbiddenWithDetail": {
+			Code:          http.StatusForbidden,
+			Body:          `{"detail": "detail"}`,
+			Metric:        "err_bad_request",
+			ExpectedError: status.Error(codes.PermissionDenied, "permission denied: detail"),
+		},
 		"NotFound": {
 			Code:          http.StatusNotFound,
 			Body:          `{}`,
@@ -337,3 +349,124 @@ func TestDelegatingHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestDelegatingHandlerEnrollment(t *testing.T) {
+	clientKey, chain := genChain(t)
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{ExtraNames: []pkix.AttributeTypeAndValue{{
+			Type:  cppki.OIDNameIA,
+			Value: "1-ff00:0:111",
+		}}},
+	}, clientKey)
+	require.NoError(t, err)
+	anonymousCSR, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: "hermes"},
+	}, clientKey)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		Request       *cppb.ChainRenewalRequest
+		Client        func(t *testing.T, ctrl *gomock.Controller) renewalgrpc.CAServiceClient
+		Chain         []*x509.Certificate
+		Metric        string
+		ErrAssertion  assert.ErrorAssertionFunc
+		ExpectedError error
+	}{
+		"malformed CSR": {
+			Request: renewal.NewEnrollmentRequest([]byte("dummy"), "token"),
+			Client: func(t *testing.T, ctrl *gomock.Controller) renewalgrpc.CAServiceClient {
+				return mock_grpc.NewMockCAServiceClient(ctrl)
+			},
+			Metric:       "err_bad_request",
+			ErrAssertion: assert.Error,
+		},
+		"subject without ISD-AS": {
+			Request: renewal.NewEnrollmentRequest(anonymousCSR, "token"),
+			Client: func(t *testing.T, ctrl *gomock.Controller) renewalgrpc.CAServiceClient {
+				return mock_grpc.NewMockCAServiceClient(ctrl)
+			},
+			Metric:       "err_bad_request",
+			ErrAssertion: assert.Error,
+		},
+		"invalid token": {
+			Request: renewal.NewEnrollmentRequest(csr, "token"),
+			Client: func(t *testing.T, ctrl *gomock.Controller) renewalgrpc.CAServiceClient {
+				rr := httptest.NewRecorder()
+				http.Error(rr, `{}`, http.StatusForbidden)
+
+				c := mock_grpc.NewMockCAServiceClient(ctrl)
+				c.EXPECT().PostCertificateEnrollment(
+					gomock.Any(), 1, api.AS("ff00:0:111"), gomock.Any(),
+				).Return(rr.Result(), nil)
+				return c
+			},
+			Metric:        "err_bad_request",
+			ErrAssertion:  assert.Error,
+			ExpectedError: status.Error(codes.PermissionDenied, "permission denied"),
+		},
+		"success": {
+			Request: renewal.NewEnrollmentRequest(csr, "token"),
+			Client: func(t *testing.T, ctrl *gomock.Controller) renewalgrpc.CAServiceClient {
+				var apiChain api.RenewalResponse_CertificateChain
+				err := apiChain.FromCertificateChain(api.CertificateChain{
+					AsCertificate: chain[0].Raw,
+					CaCertificate: chain[1].Raw,
+				})
+				require.NoError(t, err)
+				rep, err := json.Marshal(api.RenewalResponse{
+					CertificateChain: apiChain,
+				})
+				require.NoError(t, err)
+
+				rr := httptest.NewRecorder()
+				http.Error(rr, string(rep), http.StatusOK)
+
+				c := mock_grpc.NewMockCAServiceClient(ctrl)
+				c.EXPECT().PostCertificateEnrollment(
+					gomock.Any(), 1, api.AS("ff00:0:111"),
+					api.PostCertificateEnrollmentJSONRequestBody{Token: "token", Csr: csr},
+				).Return(rr.Result(), nil)
+				return c
+			},
+			Chain:        chain,
+			Metric:       "ok_success",
+			ErrAssertion: assert.NoError,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			ctr := metrics.NewTestCounter()
+			h := renewalgrpc.DelegatingHandler{
+				Client: tc.Client(t, ctrl),
+				Metrics: renewalgrpc.DelegatingHandlerMetrics{
+					BadRequests:   ctr.With("result", "err_bad_request"),
+					InternalError: ctr.With("result", "err_internal"),
+					Unavailable:   ctr.With("result", "err_unavailable"),
+					Success:       ctr.With("result", "ok_success"),
+				},
+			}
+			chain, err := h.HandleEnrollmentRequest(context.Background(), tc.Request)
+			tc.ErrAssertion(t, err)
+			assert.Equal(t, tc.Chain, chain)
+			if tc.ExpectedError != nil {
+				assert.Equal(t, tc.ExpectedError, err)
+			}
+
+			for _, res := range []string{
+				"err_bad_request",
+				"err_internal",
+				"err_unavailable",
+				"ok_success",
+			} {
+				expected := float64(0)
+				if res == tc.Metric {
+					expected = 1
+				}
+				assert.Equal(t, expected, metrics.CounterValue(ctr.With("result", res)), res)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/metrics"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	"github.com/scionproto/scion/private/ca/renewal"
+)
+
+// EnrollmentRequestVerifier verifies the incoming enrollment request.
+type EnrollmentRequestVerifier interface {
+	// VerifyEnrollmentRequest verifies the request and redeems its token.
+	VerifyEnrollmentRequest(context.Context,
+		*cppb.EnrollmentRequest) (*x509.CertificateRequest, error)
+	// ReleaseEnrollmentRequest releases the token of a verified request for
+	// which no chain was issued.
+	ReleaseEnrollmentRequest(context.Context, *cppb.EnrollmentRequest,
+		*x509.CertificateRequest) error
+}
+
+// EnrollmentHandlerMetrics contains the counters for the Enrollment handler.
+type EnrollmentHandlerMetrics struct {
+	Success metrics.Counter
+
+	InternalError metrics.Counter
+	LimitError    metrics.Counter
+	VerifyError   metrics.Counter
+}
+
+// Enrollment handles the enrollment requests that are authenticated with a
+// one-time enrollment token.
+type Enrollment struct {
+	Verifier     EnrollmentRequestVerifier
+	ChainBuilder ChainBuilder
+
+	// Metrics contains the counters. It is safe to pass nil-counters.
+	Metrics EnrollmentHandlerMetrics
+}
+
+// HandleEnrollmentRequest handles a request authenticated with an enrollment
+// token.
+func (s Enrollment) HandleEnrollmentRequest(
+	ctx context.Context,
+	req *cppb.ChainRenewalRequest,
+) ([]*x509.Certificate, error) {
+
+	logger := log.FromCtx(ctx)
+
+	csr, err := s.Verifier.VerifyEnrollmentRequest(ctx, req.EnrollmentRequest)
+	if errors.Is(err, renewal.ErrInvalidEnrollmentToken) {
+		logger.Info("Rejected enrollment request with invalid token", "err", err)
+		metrics.CounterInc(s.Metrics.VerifyError)
+		return nil, status.Error(codes.PermissionDenied, "invalid enrollment token")
+	}
+	if err != nil {
+		logger.Info("Failed to verify enrollment request", "err", err)
+		metrics.CounterInc(s.Metrics.VerifyError)
+		return nil, status.Error(codes.InvalidArgument, "failed to verify")
+	}
+
+	chain, err := s.ChainBuilder.CreateChain(ctx, csr)
+	if err != nil {
+		// The token must not be lost because of a failure of the CA. It is
+		// released even if the request was canceled.
+		err := s.Verifier.ReleaseEnrollmentRequest(context.WithoutCancel(ctx),
+			req.EnrollmentRequest, csr)
+		if err != nil {
+			logger.Info("Failed to release enrollment token", "err", err)
+		}
+	}
+	if errors.Is(err, renewal.ErrIssuanceLimit) {
+		logger.Info("Certificate chain issuance limit reached", "err", err)
+		metrics.CounterInc(s.Metrics.LimitError)
+		return nil, status.Error(codes.ResourceExhausted, "issuance limit reached")
+	}
+	if err != nil {
+		logger.Info("Failed to create initial certificate chain", "err", err)
+		metrics.CounterInc(s.Metrics.InternalError)
+		return nil, status.Error(codes.Unavailable, "failed to create chain")
+	}
+
+	metrics.CounterInc(s.Metrics.Success)
+	return chain, nil
+}
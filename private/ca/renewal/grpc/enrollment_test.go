@@ -0,0 +1,166 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/private/ca/renewal"
+	"github.com/scionproto/scion/private/ca/renewal/grpc"
+	"github.com/scionproto/scion/private/ca/renewal/grpc/mock_grpc"
+)
+
+func TestEnrollmentHandleEnrollmentRequest(t *testing.T) {
+	req := renewal.NewEnrollmentRequest(mockCSR.Raw, "token")
+
+	tests := map[string]struct {
+		Verifier     func(ctrl *gomock.Controller) grpc.EnrollmentRequestVerifier
+		ChainBuilder func(ctrl *gomock.Controller) grpc.ChainBuilder
+		Metric       string
+		Assertion    assert.ErrorAssertionFunc
+		Code         codes.Code
+	}{
+		"invalid token": {
+			Verifier: func(ctrl *gomock.Controller) grpc.EnrollmentRequestVerifier {
+				v := mock_grpc.NewMockEnrollmentRequestVerifier(ctrl)
+				v.EXPECT().VerifyEnrollmentRequest(gomock.Any(), req.EnrollmentRequest).Return(
+					nil, serrors.Wrap("redeeming", renewal.ErrInvalidEnrollmentToken),
+				)
+				return v
+			},
+			ChainBuilder: func(ctrl *gomock.Controller) grpc.ChainBuilder {
+				return mock_grpc.NewMockChainBuilder(ctrl)
+			},
+			Assertion: assert.Error,
+			Code:      codes.PermissionDenied,
+			Metric:    "err_verify",
+		},
+		"invalid request": {
+			Verifier: func(ctrl *gomock.Controller) grpc.EnrollmentRequestVerifier {
+				v := mock_grpc.NewMockEnrollmentRequestVerifier(ctrl)
+				v.EXPECT().VerifyEnrollmentRequest(gomock.Any(), req.EnrollmentRequest).Return(
+					nil, mockErr,
+				)
+				return v
+			},
+			ChainBuilder: func(ctrl *gomock.Controller) grpc.ChainBuilder {
+				return mock_grpc.NewMockChainBuilder(ctrl)
+			},
+			Assertion: assert.Error,
+			Code:      codes.InvalidArgument,
+			Metric:    "err_verify",
+		},
+		"issuance limit": {
+			Verifier: func(ctrl *gomock.Controller) grpc.EnrollmentRequestVerifier {
+				v := mock_grpc.NewMockEnrollmentRequestVerifier(ctrl)
+				v.EXPECT().VerifyEnrollmentRequest(gomock.Any(), req.EnrollmentRequest).Return(
+					mockCSR, nil,
+				)
+				v.EXPECT().ReleaseEnrollmentRequest(gomock.Any(), req.EnrollmentRequest,
+					mockCSR).Return(nil)
+				return v
+			},
+			ChainBuilder: func(ctrl *gomock.Controller) grpc.ChainBuilder {
+				cb := mock_grpc.NewMockChainBuilder(ctrl)
+				cb.EXPECT().CreateChain(gomock.Any(), mockCSR).Return(
+					nil, serrors.JoinNoStack(renewal.ErrIssuanceLimit, nil),
+				)
+				return cb
+			},
+			Assertion: assert.Error,
+			Code:      codes.ResourceExhausted,
+			Metric:    "err_limit",
+		},
+		"create error": {
+			Verifier: func(ctrl *gomock.Controller) grpc.EnrollmentRequestVerifier {
+				v := mock_grpc.NewMockEnrollmentRequestVerifier(ctrl)
+				v.EXPECT().VerifyEnrollmentRequest(gomock.Any(), req.EnrollmentRequest).Return(
+					mockCSR, nil,
+				)
+				v.EXPECT().ReleaseEnrollmentRequest(gomock.Any(), req.EnrollmentRequest,
+					mockCSR).Return(nil)
+				return v
+			},
+			ChainBuilder: func(ctrl *gomock.Controller) grpc.ChainBuilder {
+				cb := mock_grpc.NewMockChainBuilder(ctrl)
+				cb.EXPECT().CreateChain(gomock.Any(), mockCSR).Return(nil, mockErr)
+				return cb
+			},
+			Assertion: assert.Error,
+			Code:      codes.Unavailable,
+			Metric:    "err_internal",
+		},
+		"valid": {
+			Verifier: func(ctrl *gomock.Controller) grpc.EnrollmentRequestVerifier {
+				v := mock_grpc.NewMockEnrollmentRequestVerifier(ctrl)
+				v.EXPECT().VerifyEnrollmentRequest(gomock.Any(), req.EnrollmentRequest).Return(
+					mockCSR, nil,
+				)
+				return v
+			},
+			ChainBuilder: func(ctrl *gomock.Controller) grpc.ChainBuilder {
+				cb := mock_grpc.NewMockChainBuilder(ctrl)
+				cb.EXPECT().CreateChain(gomock.Any(), mockCSR).Return(mockIssuedChain, nil)
+				return cb
+			},
+			Assertion: assert.NoError,
+			Code:      codes.OK,
+			Metric:    "ok_success",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			ctr := metrics.NewTestCounter()
+			s := grpc.Enrollment{
+				Verifier:     tc.Verifier(ctrl),
+				ChainBuilder: tc.ChainBuilder(ctrl),
+				Metrics: grpc.EnrollmentHandlerMetrics{
+					InternalError: ctr.With("result", "err_internal"),
+					LimitError:    ctr.With("result", "err_limit"),
+					VerifyError:   ctr.With("result", "err_verify"),
+					Success:       ctr.With("result", "ok_success"),
+				},
+			}
+			chain, err := s.HandleEnrollmentRequest(context.Background(), req)
+			tc.Assertion(t, err)
+			assert.Equal(t, tc.Code, status.Code(err))
+			if err == nil {
+				assert.Equal(t, mockIssuedChain, chain)
+			}
+			for _, res := range []string{
+				"err_internal",
+				"err_limit",
+				"err_verify",
+				"ok_success",
+			} {
+				expected := float64(0)
+				if res == tc.Metric {
+					expected = 1
+				}
+				assert.Equal(t, expected, metrics.CounterValue(ctr.With("result", res)), res)
+			}
+		})
+	}
+}
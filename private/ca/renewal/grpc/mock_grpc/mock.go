@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/scionproto/scion/private/ca/renewal/grpc (interfaces: ChainBuilder,RenewalRequestVerifier,CMSSigner,CMSRequestHandler,CAServiceClient)
+// Source: github.com/scionproto/scion/private/ca/renewal/grpc (interfaces: ChainBuilder,RenewalRequestVerifier,EnrollmentRequestVerifier,CMSSigner,CMSRequestHandler,EnrollmentRequestHandler,CAServiceClient)
 
 // Package mock_grpc is a generated GoMock package.
 package mock_grpc
@@ -91,6 +91,58 @@ func (mr *MockRenewalRequestVerifierMockRecorder) VerifyCMSSignedRenewalRequest(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyCMSSignedRenewalRequest", reflect.TypeOf((*MockRenewalRequestVerifier)(nil).VerifyCMSSignedRenewalRequest), arg0, arg1)
 }
 
+// MockEnrollmentRequestVerifier is a mock of EnrollmentRequestVerifier interface.
+type MockEnrollmentRequestVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockEnrollmentRequestVerifierMockRecorder
+}
+
+// MockEnrollmentRequestVerifierMockRecorder is the mock recorder for MockEnrollmentRequestVerifier.
+type MockEnrollmentRequestVerifierMockRecorder struct {
+	mock *MockEnrollmentRequestVerifier
+}
+
+// NewMockEnrollmentRequestVerifier creates a new mock instance.
+func NewMockEnrollmentRequestVerifier(ctrl *gomock.Controller) *MockEnrollmentRequestVerifier {
+	mock := &MockEnrollmentRequestVerifier{ctrl: ctrl}
+	mock.recorder = &MockEnrollmentRequestVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEnrollmentRequestVerifier) EXPECT() *MockEnrollmentRequestVerifierMockRecorder {
+	return m.recorder
+}
+
+// ReleaseEnrollmentRequest mocks base method.
+func (m *MockEnrollmentRequestVerifier) ReleaseEnrollmentRequest(arg0 context.Context, arg1 *control_plane.EnrollmentRequest, arg2 *x509.CertificateRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseEnrollmentRequest", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseEnrollmentRequest indicates an expected call of ReleaseEnrollmentRequest.
+func (mr *MockEnrollmentRequestVerifierMockRecorder) ReleaseEnrollmentRequest(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseEnrollmentRequest", reflect.TypeOf((*MockEnrollmentRequestVerifier)(nil).ReleaseEnrollmentRequest), arg0, arg1, arg2)
+}
+
+// VerifyEnrollmentRequest mocks base method.
+func (m *MockEnrollmentRequestVerifier) VerifyEnrollmentRequest(arg0 context.Context, arg1 *control_plane.EnrollmentRequest) (*x509.CertificateRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEnrollmentRequest", arg0, arg1)
+	ret0, _ := ret[0].(*x509.CertificateRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEnrollmentRequest indicates an expected call of VerifyEnrollmentRequest.
+func (mr *MockEnrollmentRequestVerifierMockRecorder) VerifyEnrollmentRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEnrollmentRequest", reflect.TypeOf((*MockEnrollmentRequestVerifier)(nil).VerifyEnrollmentRequest), arg0, arg1)
+}
+
 // MockCMSSigner is a mock of CMSSigner interface.
 type MockCMSSigner struct {
 	ctrl     *gomock.Controller
@@ -167,6 +219,44 @@ func (mr *MockCMSRequestHandlerMockRecorder) HandleCMSRequest(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleCMSRequest", reflect.TypeOf((*MockCMSRequestHandler)(nil).HandleCMSRequest), arg0, arg1)
 }
 
+// MockEnrollmentRequestHandler is a mock of EnrollmentRequestHandler interface.
+type MockEnrollmentRequestHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockEnrollmentRequestHandlerMockRecorder
+}
+
+// MockEnrollmentRequestHandlerMockRecorder is the mock recorder for MockEnrollmentRequestHandler.
+type MockEnrollmentRequestHandlerMockRecorder struct {
+	mock *MockEnrollmentRequestHandler
+}
+
+// NewMockEnrollmentRequestHandler creates a new mock instance.
+func NewMockEnrollmentRequestHandler(ctrl *gomock.Controller) *MockEnrollmentRequestHandler {
+	mock := &MockEnrollmentRequestHandler{ctrl: ctrl}
+	mock.recorder = &MockEnrollmentRequestHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEnrollmentRequestHandler) EXPECT() *MockEnrollmentRequestHandlerMockRecorder {
+	return m.recorder
+}
+
+// HandleEnrollmentRequest mocks base method.
+func (m *MockEnrollmentRequestHandler) HandleEnrollmentRequest(arg0 context.Context, arg1 *control_plane.ChainRenewalRequest) ([]*x509.Certificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleEnrollmentRequest", arg0, arg1)
+	ret0, _ := ret[0].([]*x509.Certificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleEnrollmentRequest indicates an expected call of HandleEnrollmentRequest.
+func (mr *MockEnrollmentRequestHandlerMockRecorder) HandleEnrollmentRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleEnrollmentRequest", reflect.TypeOf((*MockEnrollmentRequestHandler)(nil).HandleEnrollmentRequest), arg0, arg1)
+}
+
 // MockCAServiceClient is a mock of CAServiceClient interface.
 type MockCAServiceClient struct {
 	ctrl     *gomock.Controller
@@ -190,6 +280,26 @@ func (m *MockCAServiceClient) EXPECT() *MockCAServiceClientMockRecorder {
 	return m.recorder
 }
 
+// PostCertificateEnrollment mocks base method.
+func (m *MockCAServiceClient) PostCertificateEnrollment(arg0 context.Context, arg1 int, arg2 string, arg3 api.EnrollmentRequest, arg4 ...api.RequestEditorFn) (*http.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PostCertificateEnrollment", varargs...)
+	ret0, _ := ret[0].(*http.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostCertificateEnrollment indicates an expected call of PostCertificateEnrollment.
+func (mr *MockCAServiceClientMockRecorder) PostCertificateEnrollment(arg0, arg1, arg2, arg3 interface{}, arg4 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3}, arg4...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostCertificateEnrollment", reflect.TypeOf((*MockCAServiceClient)(nil).PostCertificateEnrollment), varargs...)
+}
+
 // PostCertificateRenewal mocks base method.
 func (m *MockCAServiceClient) PostCertificateRenewal(arg0 context.Context, arg1 int, arg2 string, arg3 api.RenewalRequest, arg4 ...api.RequestEditorFn) (*http.Response, error) {
 	m.ctrl.T.Helper()
@@ -37,6 +37,12 @@ type CMSRequestHandler interface {
 	HandleCMSRequest(context.Context, *cppb.ChainRenewalRequest) ([]*x509.Certificate, error)
 }
 
+// EnrollmentRequestHandler handles enrollment requests.
+type EnrollmentRequestHandler interface {
+	HandleEnrollmentRequest(context.Context,
+		*cppb.ChainRenewalRequest) ([]*x509.Certificate, error)
+}
+
 // CMSSigner signs response message.
 type CMSSigner interface {
 	SignCMS(ctx context.Context, msg []byte) ([]byte, error)
@@ -53,6 +59,9 @@ type RenewalServer struct {
 	IA         addr.IA
 	CMSHandler CMSRequestHandler
 	CMSSigner  CMSSigner
+	// EnrollmentHandler handles the enrollment requests of ASes that do not
+	// have a certificate chain yet. If nil, enrollment requests are rejected.
+	EnrollmentHandler EnrollmentRequestHandler
 
 	// Metrics contains the counters. Different error are different counters.
 	Metrics RenewalServerMetrics
@@ -65,12 +74,24 @@ func (s RenewalServer) ChainRenewal(ctx context.Context,
 	logger := log.FromCtx(ctx).New("peer", peer)
 	ctx = log.CtxWith(ctx, logger)
 
-	if req.CmsSignedRequest == nil {
+	var resp []*x509.Certificate
+	var requestType string
+	var err error
+	switch {
+	case req.CmsSignedRequest != nil:
+		requestType = "cms"
+		resp, err = s.CMSHandler.HandleCMSRequest(ctx, req)
+	case req.EnrollmentRequest != nil:
+		if s.EnrollmentHandler == nil {
+			metrics.CounterInc(s.Metrics.BackendErrors)
+			return nil, status.Error(codes.Unimplemented, "enrollment not supported")
+		}
+		requestType = "enrollment"
+		resp, err = s.EnrollmentHandler.HandleEnrollmentRequest(ctx, req)
+	default:
 		metrics.CounterInc(s.Metrics.BackendErrors)
 		return nil, status.Error(codes.InvalidArgument, "signed request missing supported")
 	}
-
-	resp, err := s.CMSHandler.HandleCMSRequest(ctx, req)
 	if err != nil {
 		metrics.CounterInc(s.Metrics.BackendErrors)
 		return nil, err
@@ -92,7 +113,7 @@ func (s RenewalServer) ChainRenewal(ctx context.Context,
 			NotBefore: resp[0].NotBefore,
 			NotAfter:  resp[0].NotAfter,
 		},
-		"request_type", "cms",
+		"request_type", requestType,
 	)
 
 	metrics.CounterInc(s.Metrics.Success)
@@ -64,6 +64,7 @@ func TestRenewalServerChainRenewal(t *testing.T) {
 		request    func(t *testing.T) *cppb.ChainRenewalRequest
 		cmsHandler func(ctrl *gomock.Controller) grpc.CMSRequestHandler
 		cmsSigner  func(ctrl *gomock.Controller) grpc.CMSSigner
+		enrollment func(ctrl *gomock.Controller) grpc.EnrollmentRequestHandler
 		metric     string
 		assertion  assert.ErrorAssertionFunc
 	}{
@@ -141,6 +142,61 @@ func TestRenewalServerChainRenewal(t *testing.T) {
 			assertion: assert.Error,
 			metric:    "err_backend",
 		},
+		"enrollment": {
+			request: func(t *testing.T) *cppb.ChainRenewalRequest {
+				return renewal.NewEnrollmentRequest(mockCSR.Raw, "token")
+			},
+			cmsHandler: func(ctrl *gomock.Controller) grpc.CMSRequestHandler {
+				return mock_grpc.NewMockCMSRequestHandler(ctrl)
+			},
+			cmsSigner: func(ctrl *gomock.Controller) grpc.CMSSigner {
+				signer := mock_grpc.NewMockCMSSigner(ctrl)
+				signer.EXPECT().SignCMS(gomock.Any(), gomock.Any())
+				return signer
+			},
+			enrollment: func(ctrl *gomock.Controller) grpc.EnrollmentRequestHandler {
+				r := mock_grpc.NewMockEnrollmentRequestHandler(ctrl)
+				r.EXPECT().HandleEnrollmentRequest(
+					gomock.Any(), gomock.Any(),
+				).Return(mockChain, nil)
+				return r
+			},
+			assertion: assert.NoError,
+			metric:    "ok_success",
+		},
+		"enrollment error": {
+			request: func(t *testing.T) *cppb.ChainRenewalRequest {
+				return renewal.NewEnrollmentRequest(mockCSR.Raw, "token")
+			},
+			cmsHandler: func(ctrl *gomock.Controller) grpc.CMSRequestHandler {
+				return mock_grpc.NewMockCMSRequestHandler(ctrl)
+			},
+			cmsSigner: func(ctrl *gomock.Controller) grpc.CMSSigner {
+				return mock_grpc.NewMockCMSSigner(ctrl)
+			},
+			enrollment: func(ctrl *gomock.Controller) grpc.EnrollmentRequestHandler {
+				r := mock_grpc.NewMockEnrollmentRequestHandler(ctrl)
+				r.EXPECT().HandleEnrollmentRequest(
+					gomock.Any(), gomock.Any(),
+				).Return(nil, fmt.Errorf("dummy"))
+				return r
+			},
+			assertion: assert.Error,
+			metric:    "err_backend",
+		},
+		"enrollment not supported": {
+			request: func(t *testing.T) *cppb.ChainRenewalRequest {
+				return renewal.NewEnrollmentRequest(mockCSR.Raw, "token")
+			},
+			cmsHandler: func(ctrl *gomock.Controller) grpc.CMSRequestHandler {
+				return mock_grpc.NewMockCMSRequestHandler(ctrl)
+			},
+			cmsSigner: func(ctrl *gomock.Controller) grpc.CMSSigner {
+				return mock_grpc.NewMockCMSSigner(ctrl)
+			},
+			assertion: assert.Error,
+			metric:    "err_backend",
+		},
 	}
 
 	for name, tc := range tests {
@@ -156,6 +212,9 @@ func TestRenewalServerChainRenewal(t *testing.T) {
 					Success:       ctr.With("test_tag", "ok_success"),
 				},
 			}
+			if tc.enrollment != nil {
+				s.EnrollmentHandler = tc.enrollment(ctrl)
+			}
 			_, err := s.ChainRenewal(context.Background(), tc.request(t))
 			tc.assertion(t, err)
 			for _, res := range []string{
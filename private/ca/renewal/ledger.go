@@ -0,0 +1,120 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package renewal
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"math/big"
+	"time"
+
+	"google.golang.org/grpc/peer"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+)
+
+// IssuedChain is the ledger record of a certificate chain issued by the CA.
+type IssuedChain struct {
+	// Subject is the ISD-AS of the AS certificate.
+	Subject addr.IA
+	// Serial is the serial number of the AS certificate.
+	Serial *big.Int
+	// NotBefore is the start of the validity period of the AS certificate.
+	NotBefore time.Time
+	// NotAfter is the end of the validity period of the AS certificate.
+	NotAfter time.Time
+	// CAFingerprint is the SHA-256 fingerprint of the CA certificate that
+	// signed the AS certificate.
+	CAFingerprint []byte
+	// Source is the address of the requester.
+	Source string
+	// IssuedAt is the time the chain was issued.
+	IssuedAt time.Time
+}
+
+// NewIssuedChain creates the ledger record for the chain.
+func NewIssuedChain(
+	chain []*x509.Certificate,
+	source string,
+	issuedAt time.Time,
+) (IssuedChain, error) {
+	subject, err := cppki.ExtractIA(chain[0].Subject)
+	if err != nil {
+		return IssuedChain{}, err
+	}
+	fingerprint := sha256.Sum256(chain[1].Raw)
+	return IssuedChain{
+		Subject:       subject,
+		Serial:        chain[0].SerialNumber,
+		NotBefore:     chain[0].NotBefore,
+		NotAfter:      chain[0].NotAfter,
+		CAFingerprint: fingerprint[:],
+		Source:        source,
+		IssuedAt:      issuedAt,
+	}, nil
+}
+
+// IssuedChainsQuery selects the records in the ledger.
+type IssuedChainsQuery struct {
+	// IAs are the subjects of the records. If empty, all subjects are
+	// selected.
+	IAs []addr.IA
+	// Since selects the records of chains issued at or after the time. If
+	// zero, there is no lower bound.
+	Since time.Time
+	// Until selects the records of chains issued before the time. If zero,
+	// there is no upper bound.
+	Until time.Time
+}
+
+// Ledger records the certificate chains issued by the CA.
+type Ledger interface {
+	// IssuedChains returns the records matching the query, ordered by the
+	// time of issuance.
+	IssuedChains(ctx context.Context, query IssuedChainsQuery) ([]IssuedChain, error)
+	// ReserveIssuedChain reserves a record for a chain that is about to be
+	// issued for the subject at the given time. Reservations count as issued
+	// chains. If the subject has reached the issuance limit, no reservation is
+	// made and an error wrapping ErrIssuanceLimit is returned. Checking the
+	// limit and reserving the record is atomic.
+	ReserveIssuedChain(ctx context.Context, subject addr.IA, limit IssuanceLimit,
+		now time.Time) (int64, error)
+	// CompleteIssuedChain replaces the reservation with the record of the
+	// issued chain.
+	CompleteIssuedChain(ctx context.Context, reservation int64, chain IssuedChain) error
+	// ReleaseIssuedChain removes the reservation of a chain that was not
+	// issued.
+	ReleaseIssuedChain(ctx context.Context, reservation int64) error
+}
+
+// IssuanceLimit limits the number of chains that are issued for the same
+// subject.
+type IssuanceLimit struct {
+	// MaxChains is the maximum number of chains issued per subject in the
+	// window. Zero means no limit.
+	MaxChains int
+	// Window is the sliding window over which the issued chains are counted.
+	Window time.Duration
+}
+
+// requestSource returns the address of the requester.
+func requestSource(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return "unknown"
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/scionproto/scion/private/ca/renewal (interfaces: CACertProvider,PolicyGen)
+// Source: github.com/scionproto/scion/private/ca/renewal (interfaces: CACertProvider,EnrollmentTokenStore,Ledger,PolicyGen)
 
 // Package mock_renewal is a generated GoMock package.
 package mock_renewal
@@ -8,9 +8,12 @@ import (
 	context "context"
 	x509 "crypto/x509"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	addr "github.com/scionproto/scion/pkg/addr"
 	cppki "github.com/scionproto/scion/pkg/scrypto/cppki"
+	renewal "github.com/scionproto/scion/private/ca/renewal"
 )
 
 // MockCACertProvider is a mock of CACertProvider interface.
@@ -51,6 +54,152 @@ func (mr *MockCACertProviderMockRecorder) CACerts(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CACerts", reflect.TypeOf((*MockCACertProvider)(nil).CACerts), arg0)
 }
 
+// MockEnrollmentTokenStore is a mock of EnrollmentTokenStore interface.
+type MockEnrollmentTokenStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockEnrollmentTokenStoreMockRecorder
+}
+
+// MockEnrollmentTokenStoreMockRecorder is the mock recorder for MockEnrollmentTokenStore.
+type MockEnrollmentTokenStoreMockRecorder struct {
+	mock *MockEnrollmentTokenStore
+}
+
+// NewMockEnrollmentTokenStore creates a new mock instance.
+func NewMockEnrollmentTokenStore(ctrl *gomock.Controller) *MockEnrollmentTokenStore {
+	mock := &MockEnrollmentTokenStore{ctrl: ctrl}
+	mock.recorder = &MockEnrollmentTokenStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEnrollmentTokenStore) EXPECT() *MockEnrollmentTokenStoreMockRecorder {
+	return m.recorder
+}
+
+// InsertEnrollmentToken mocks base method.
+func (m *MockEnrollmentTokenStore) InsertEnrollmentToken(arg0 context.Context, arg1 renewal.EnrollmentToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertEnrollmentToken", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertEnrollmentToken indicates an expected call of InsertEnrollmentToken.
+func (mr *MockEnrollmentTokenStoreMockRecorder) InsertEnrollmentToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertEnrollmentToken", reflect.TypeOf((*MockEnrollmentTokenStore)(nil).InsertEnrollmentToken), arg0, arg1)
+}
+
+// RedeemEnrollmentToken mocks base method.
+func (m *MockEnrollmentTokenStore) RedeemEnrollmentToken(arg0 context.Context, arg1 []byte, arg2 addr.IA, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeemEnrollmentToken", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RedeemEnrollmentToken indicates an expected call of RedeemEnrollmentToken.
+func (mr *MockEnrollmentTokenStoreMockRecorder) RedeemEnrollmentToken(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeemEnrollmentToken", reflect.TypeOf((*MockEnrollmentTokenStore)(nil).RedeemEnrollmentToken), arg0, arg1, arg2, arg3)
+}
+
+// ReleaseEnrollmentToken mocks base method.
+func (m *MockEnrollmentTokenStore) ReleaseEnrollmentToken(arg0 context.Context, arg1 []byte, arg2 addr.IA) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseEnrollmentToken", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseEnrollmentToken indicates an expected call of ReleaseEnrollmentToken.
+func (mr *MockEnrollmentTokenStoreMockRecorder) ReleaseEnrollmentToken(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseEnrollmentToken", reflect.TypeOf((*MockEnrollmentTokenStore)(nil).ReleaseEnrollmentToken), arg0, arg1, arg2)
+}
+
+// MockLedger is a mock of Ledger interface.
+type MockLedger struct {
+	ctrl     *gomock.Controller
+	recorder *MockLedgerMockRecorder
+}
+
+// MockLedgerMockRecorder is the mock recorder for MockLedger.
+type MockLedgerMockRecorder struct {
+	mock *MockLedger
+}
+
+// NewMockLedger creates a new mock instance.
+func NewMockLedger(ctrl *gomock.Controller) *MockLedger {
+	mock := &MockLedger{ctrl: ctrl}
+	mock.recorder = &MockLedgerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLedger) EXPECT() *MockLedgerMockRecorder {
+	return m.recorder
+}
+
+// CompleteIssuedChain mocks base method.
+func (m *MockLedger) CompleteIssuedChain(arg0 context.Context, arg1 int64, arg2 renewal.IssuedChain) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteIssuedChain", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteIssuedChain indicates an expected call of CompleteIssuedChain.
+func (mr *MockLedgerMockRecorder) CompleteIssuedChain(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteIssuedChain", reflect.TypeOf((*MockLedger)(nil).CompleteIssuedChain), arg0, arg1, arg2)
+}
+
+// IssuedChains mocks base method.
+func (m *MockLedger) IssuedChains(arg0 context.Context, arg1 renewal.IssuedChainsQuery) ([]renewal.IssuedChain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssuedChains", arg0, arg1)
+	ret0, _ := ret[0].([]renewal.IssuedChain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssuedChains indicates an expected call of IssuedChains.
+func (mr *MockLedgerMockRecorder) IssuedChains(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssuedChains", reflect.TypeOf((*MockLedger)(nil).IssuedChains), arg0, arg1)
+}
+
+// ReleaseIssuedChain mocks base method.
+func (m *MockLedger) ReleaseIssuedChain(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseIssuedChain", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseIssuedChain indicates an expected call of ReleaseIssuedChain.
+func (mr *MockLedgerMockRecorder) ReleaseIssuedChain(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseIssuedChain", reflect.TypeOf((*MockLedger)(nil).ReleaseIssuedChain), arg0, arg1)
+}
+
+// ReserveIssuedChain mocks base method.
+func (m *MockLedger) ReserveIssuedChain(arg0 context.Context, arg1 addr.IA, arg2 renewal.IssuanceLimit, arg3 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveIssuedChain", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveIssuedChain indicates an expected call of ReserveIssuedChain.
+func (mr *MockLedgerMockRecorder) ReserveIssuedChain(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveIssuedChain", reflect.TypeOf((*MockLedger)(nil).ReserveIssuedChain), arg0, arg1, arg2, arg3)
+}
+
 // MockPolicyGen is a mock of PolicyGen interface.
 type MockPolicyGen struct {
 	ctrl     *gomock.Controller
@@ -15,7 +15,9 @@
 package keyconf
 
 import (
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"os"
@@ -74,6 +76,19 @@ func LoadMaster(path string) (Master, error) {
 	return m, nil
 }
 
+// LoadMasterKey loads a single master key from the given file.
+func LoadMasterKey(file string) ([]byte, error) {
+	return loadKey(file, RawKey)
+}
+
+// KeyID returns a short identifier of the given master key, which can be logged and exposed in
+// metrics without revealing the key. It is the hex encoding of the first 4 bytes of the SHA-256
+// hash of the key.
+func KeyID(key []byte) string {
+	h := sha256.Sum256(key)
+	return hex.EncodeToString(h[:4])
+}
+
 func (m Master) MarshalJSON() ([]byte, error) {
 	return []byte(`{"key0":"redacted","key1":"redacted"}`), nil
 }
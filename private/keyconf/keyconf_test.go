@@ -59,3 +59,10 @@ func TestMasterRedacted(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, `{"keys":{"key0":"redacted","key1":"redacted"}}`, string(raw))
 }
+
+func TestKeyID(t *testing.T) {
+	id := KeyID(mstr0)
+	assert.Len(t, id, 8)
+	assert.Equal(t, id, KeyID(mstr0))
+	assert.NotEqual(t, id, KeyID(mstr1))
+}
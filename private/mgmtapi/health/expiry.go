@@ -0,0 +1,73 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package health contains health checks that are shared between the
+// management APIs of the different services.
+package health
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/scionproto/scion/private/mgmtapi"
+	"github.com/scionproto/scion/private/mgmtapi/health/api"
+	"github.com/scionproto/scion/private/trust/expiry"
+)
+
+// ExpiryCheckName is the name of the trust material expiry check.
+const ExpiryCheckName = "trust material expiry"
+
+// ExpiryCheck creates the health check for the expiration of the local trust
+// material. The check is degraded if any entry is below its warning or
+// critical threshold, and failing if any entry has expired.
+func ExpiryCheck(results []expiry.Result) api.Check {
+	check := api.Check{
+		Name:   ExpiryCheckName,
+		Status: api.Passing,
+	}
+	entries := make([]map[string]any, 0, len(results))
+	var worst *expiry.Result
+	for i, r := range results {
+		entries = append(entries, map[string]any{
+			"type":              string(r.Kind),
+			"subject":           r.Subject,
+			"id":                r.ID,
+			"not_after":         r.NotAfter.Format(time.RFC3339),
+			"remaining_seconds": int64(r.Remaining.Seconds()),
+			"level":             r.Level.String(),
+		})
+		if worst == nil || r.Level > worst.Level ||
+			(r.Level == worst.Level && r.Remaining < worst.Remaining) {
+			worst = &results[i]
+		}
+	}
+	check.Data = api.CheckData{"entries": entries}
+	if worst == nil {
+		check.Detail = mgmtapi.StringRef("no trust material found")
+		return check
+	}
+	switch worst.Level {
+	case expiry.LevelOK:
+		return check
+	case expiry.LevelExpired:
+		check.Status = api.Failing
+		check.Detail = mgmtapi.StringRef(fmt.Sprintf("%s of %s has expired at %s",
+			worst.Kind, worst.Subject, worst.NotAfter.Format(time.RFC3339)))
+	default:
+		check.Status = api.Degraded
+		check.Detail = mgmtapi.StringRef(fmt.Sprintf("%s of %s expires in %s",
+			worst.Kind, worst.Subject, worst.Remaining.Truncate(time.Second)))
+	}
+	return check
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/private/mgmtapi/health"
+	"github.com/scionproto/scion/private/mgmtapi/health/api"
+	"github.com/scionproto/scion/private/trust/expiry"
+)
+
+func TestExpiryCheck(t *testing.T) {
+	result := func(kind expiry.Kind, level expiry.Level, remaining time.Duration) expiry.Result {
+		return expiry.Result{
+			Entry: expiry.Entry{
+				Kind:     kind,
+				Subject:  "1-ff00:0:110",
+				NotAfter: time.Now().Add(remaining),
+			},
+			Remaining: remaining,
+			Level:     level,
+		}
+	}
+	testCases := map[string]struct {
+		Results        []expiry.Result
+		ExpectedStatus api.Status
+		ExpectedDetail string
+	}{
+		"no results": {
+			ExpectedStatus: api.Passing,
+			ExpectedDetail: "no trust material found",
+		},
+		"ok": {
+			Results: []expiry.Result{
+				result(expiry.KindAS, expiry.LevelOK, 48*time.Hour),
+				result(expiry.KindTRC, expiry.LevelOK, 48*time.Hour),
+			},
+			ExpectedStatus: api.Passing,
+		},
+		"warning": {
+			Results: []expiry.Result{
+				result(expiry.KindAS, expiry.LevelOK, 48*time.Hour),
+				result(expiry.KindCA, expiry.LevelWarning, 72*time.Hour),
+			},
+			ExpectedStatus: api.Degraded,
+			ExpectedDetail: "cp-ca of 1-ff00:0:110 expires in 72h0m0s",
+		},
+		"critical": {
+			Results: []expiry.Result{
+				result(expiry.KindCA, expiry.LevelWarning, 72*time.Hour),
+				result(expiry.KindAS, expiry.LevelCritical, time.Hour),
+			},
+			ExpectedStatus: api.Degraded,
+			ExpectedDetail: "cp-as of 1-ff00:0:110 expires in 1h0m0s",
+		},
+		"expired": {
+			Results: []expiry.Result{
+				result(expiry.KindCA, expiry.LevelWarning, 72*time.Hour),
+				result(expiry.KindAS, expiry.LevelExpired, -time.Hour),
+			},
+			ExpectedStatus: api.Failing,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			check := health.ExpiryCheck(tc.Results)
+			assert.Equal(t, health.ExpiryCheckName, check.Name)
+			assert.Equal(t, tc.ExpectedStatus, check.Status)
+			assert.Len(t, check.Data["entries"], len(tc.Results))
+			switch {
+			case tc.ExpectedStatus == api.Failing:
+				assert.Contains(t, *check.Detail, "has expired")
+			case tc.ExpectedDetail != "":
+				assert.Equal(t, tc.ExpectedDetail, *check.Detail)
+			default:
+				assert.Nil(t, check.Detail)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkcs11
+
+import (
+	"context"
+	"crypto"
+	"sync"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// LoadSigner opens the token that is selected by the URI, and returns the
+// signer for the private key that is selected by the URI. Exactly one private
+// key must match. The session on the token stays open for the lifetime of the
+// process.
+func LoadSigner(uri URI) (*Signer, error) {
+	token, err := Open(uri)
+	if err != nil {
+		return nil, err
+	}
+	signers, err := token.Signers(uri)
+	if err != nil {
+		_ = token.Close()
+		return nil, err
+	}
+	if len(signers) != 1 {
+		_ = token.Close()
+		return nil, serrors.New("URI must match exactly one private key",
+			"matches", len(signers), "id", uri.ID, "object", uri.Object)
+	}
+	return signers[0], nil
+}
+
+// KeyRing provides the private keys on a PKCS#11 token that match the URI. The
+// token is opened on first use and kept open. If the keys can not be loaded,
+// the token is opened again on the next call.
+type KeyRing struct {
+	URI URI
+
+	mtx   sync.Mutex
+	token *Token
+}
+
+// PrivateKeys returns the signers for all matching private keys on the token.
+func (r *KeyRing) PrivateKeys(ctx context.Context) ([]crypto.Signer, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.token == nil {
+		token, err := Open(r.URI)
+		if err != nil {
+			return nil, serrors.Wrap("opening PKCS#11 token", err)
+		}
+		r.token = token
+	}
+	found, err := r.token.Signers(r.URI)
+	if err != nil {
+		if err := r.token.Close(); err != nil {
+			log.FromCtx(ctx).Info("Error closing PKCS#11 token", "err", err)
+		}
+		r.token = nil
+		return nil, serrors.Wrap("loading keys from PKCS#11 token", err)
+	}
+	signers := make([]crypto.Signer, 0, len(found))
+	for _, s := range found {
+		signers = append(signers, s)
+	}
+	return signers, nil
+}
+
+// Close closes the token, if it is open.
+func (r *KeyRing) Close() error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.token == nil {
+		return nil
+	}
+	err := r.token.Close()
+	r.token = nil
+	return err
+}
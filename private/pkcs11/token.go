@@ -0,0 +1,392 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package pkcs11
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"io"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+var oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+
+// modules keeps track of the loaded PKCS#11 modules. A module must only be
+// initialized once per process, thus, it is shared between all tokens.
+var modules = struct {
+	sync.Mutex
+	loaded map[string]*module
+}{loaded: make(map[string]*module)}
+
+type module struct {
+	ctx  *pkcs11.Ctx
+	refs int
+}
+
+func loadModule(path string) (*pkcs11.Ctx, error) {
+	modules.Lock()
+	defer modules.Unlock()
+	if m, ok := modules.loaded[path]; ok {
+		m.refs++
+		return m.ctx, nil
+	}
+	ctx := pkcs11.New(path)
+	if ctx == nil {
+		return nil, serrors.New("loading PKCS#11 module", "module", path)
+	}
+	if err := ctx.Initialize(); err != nil {
+		ctx.Destroy()
+		return nil, serrors.Wrap("initializing PKCS#11 module", err, "module", path)
+	}
+	modules.loaded[path] = &module{ctx: ctx, refs: 1}
+	return ctx, nil
+}
+
+func releaseModule(path string) error {
+	modules.Lock()
+	defer modules.Unlock()
+	m, ok := modules.loaded[path]
+	if !ok {
+		return nil
+	}
+	if m.refs--; m.refs > 0 {
+		return nil
+	}
+	delete(modules.loaded, path)
+	err := m.ctx.Finalize()
+	m.ctx.Destroy()
+	return err
+}
+
+// Token is a logged in session on a PKCS#11 token. It is safe for concurrent
+// use.
+type Token struct {
+	module string
+	ctx    *pkcs11.Ctx
+
+	mtx     sync.Mutex
+	session pkcs11.SessionHandle
+	closed  bool
+}
+
+// Open opens a session on the token that is selected by the URI, and logs in
+// with the PIN from the URI.
+func Open(uri URI) (*Token, error) {
+	if uri.ModulePath == "" {
+		return nil, serrors.New("module-path missing in PKCS#11 URI")
+	}
+	ctx, err := loadModule(uri.ModulePath)
+	if err != nil {
+		return nil, err
+	}
+	t, err := open(ctx, uri)
+	if err != nil {
+		_ = releaseModule(uri.ModulePath)
+		return nil, err
+	}
+	return t, nil
+}
+
+func open(ctx *pkcs11.Ctx, uri URI) (*Token, error) {
+	slot, err := findSlot(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	session, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return nil, serrors.Wrap("opening session", err, "slot", slot)
+	}
+	// The login state is shared by all sessions of the application on the
+	// token. Thus, another token handle might already be logged in.
+	err = ctx.Login(session, pkcs11.CKU_USER, uri.PIN)
+	if err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN)) {
+		_ = ctx.CloseSession(session)
+		return nil, serrors.Wrap("logging in", err, "slot", slot)
+	}
+	return &Token{
+		module:  uri.ModulePath,
+		ctx:     ctx,
+		session: session,
+	}, nil
+}
+
+func findSlot(ctx *pkcs11.Ctx, uri URI) (uint, error) {
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return 0, serrors.Wrap("listing slots", err)
+	}
+	var found []uint
+	for _, slot := range slots {
+		if uri.HasSlotID && slot != uri.SlotID {
+			continue
+		}
+		info, err := ctx.GetTokenInfo(slot)
+		if err != nil {
+			return 0, serrors.Wrap("reading token info", err, "slot", slot)
+		}
+		if uri.Token != "" && strings.TrimRight(info.Label, " ") != uri.Token {
+			continue
+		}
+		if uri.Serial != "" && strings.TrimRight(info.SerialNumber, " ") != uri.Serial {
+			continue
+		}
+		found = append(found, slot)
+	}
+	switch len(found) {
+	case 0:
+		return 0, serrors.New("no matching token found", "token", uri.Token,
+			"serial", uri.Serial)
+	case 1:
+		return found[0], nil
+	default:
+		return 0, serrors.New("multiple matching tokens found", "token", uri.Token,
+			"serial", uri.Serial, "slots", found)
+	}
+}
+
+// Close closes the session. Signers that were created by the token can no
+// longer be used afterwards.
+func (t *Token) Close() error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.closed {
+		return nil
+	}
+	t.closed = true
+	// Logging out would log out all other sessions of the application on the
+	// same token. The session is closed instead, the login state ends with the
+	// last session.
+	var errs serrors.List
+	if err := t.ctx.CloseSession(t.session); err != nil {
+		errs = append(errs, serrors.Wrap("closing session", err))
+	}
+	if err := releaseModule(t.module); err != nil {
+		errs = append(errs, serrors.Wrap("finalizing module", err))
+	}
+	return errs.ToError()
+}
+
+// Signers returns the signers for all ECDSA private keys on the token that
+// match the ID and object label of the URI. If neither is set, all private
+// keys are returned.
+func (t *Token) Signers(uri URI) ([]*Signer, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.closed {
+		return nil, serrors.New("token closed")
+	}
+
+	tmpl := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+	}
+	if len(uri.ID) > 0 {
+		tmpl = append(tmpl, pkcs11.NewAttribute(pkcs11.CKA_ID, uri.ID))
+	}
+	if uri.Object != "" {
+		tmpl = append(tmpl, pkcs11.NewAttribute(pkcs11.CKA_LABEL, uri.Object))
+	}
+	handles, err := t.findObjects(tmpl)
+	if err != nil {
+		return nil, serrors.Wrap("searching private keys", err)
+	}
+	signers := make([]*Signer, 0, len(handles))
+	for _, handle := range handles {
+		attrs, err := t.ctx.GetAttributeValue(t.session, handle, []*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_ID, nil),
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, nil),
+		})
+		if err != nil {
+			return nil, serrors.Wrap("reading private key attributes", err)
+		}
+		id, label := attrs[0].Value, string(attrs[1].Value)
+		pub, err := t.publicKey(id)
+		if err != nil {
+			return nil, serrors.Wrap("loading public key", err, "object", label)
+		}
+		signers = append(signers, &Signer{
+			ID:     id,
+			Label:  label,
+			token:  t,
+			handle: handle,
+			pub:    pub,
+		})
+	}
+	return signers, nil
+}
+
+// publicKey loads the public key that belongs to the private key with the
+// given ID. The public key is either read from the public key object, or from
+// a certificate object with the same ID.
+func (t *Token) publicKey(id []byte) (*ecdsa.PublicKey, error) {
+	handles, err := t.findObjects([]*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PUBLIC_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_ID, id),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(handles) > 0 {
+		attrs, err := t.ctx.GetAttributeValue(t.session, handles[0], []*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, nil),
+			pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil),
+		})
+		if err != nil {
+			return nil, serrors.Wrap("reading public key attributes", err)
+		}
+		return parseECPublicKey(attrs[0].Value, attrs[1].Value)
+	}
+
+	handles, err = t.findObjects([]*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_CERTIFICATE),
+		pkcs11.NewAttribute(pkcs11.CKA_ID, id),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(handles) == 0 {
+		return nil, serrors.New("neither public key nor certificate found")
+	}
+	attrs, err := t.ctx.GetAttributeValue(t.session, handles[0], []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_VALUE, nil),
+	})
+	if err != nil {
+		return nil, serrors.Wrap("reading certificate", err)
+	}
+	cert, err := x509.ParseCertificate(attrs[0].Value)
+	if err != nil {
+		return nil, serrors.Wrap("parsing certificate", err)
+	}
+	pub, ok := cert.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, serrors.New("certificate does not contain ECDSA public key")
+	}
+	return pub, nil
+}
+
+func (t *Token) findObjects(tmpl []*pkcs11.Attribute) ([]pkcs11.ObjectHandle, error) {
+	if err := t.ctx.FindObjectsInit(t.session, tmpl); err != nil {
+		return nil, err
+	}
+	var handles []pkcs11.ObjectHandle
+	for {
+		found, _, err := t.ctx.FindObjects(t.session, 16)
+		if err != nil {
+			_ = t.ctx.FindObjectsFinal(t.session)
+			return nil, err
+		}
+		if len(found) == 0 {
+			break
+		}
+		handles = append(handles, found...)
+	}
+	return handles, t.ctx.FindObjectsFinal(t.session)
+}
+
+func (t *Token) sign(handle pkcs11.ObjectHandle, digest []byte) ([]byte, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.closed {
+		return nil, serrors.New("token closed")
+	}
+	mech := []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)}
+	if err := t.ctx.SignInit(t.session, mech, handle); err != nil {
+		return nil, serrors.Wrap("initializing signature", err)
+	}
+	sig, err := t.ctx.Sign(t.session, digest)
+	if err != nil {
+		return nil, serrors.Wrap("signing", err)
+	}
+	return sig, nil
+}
+
+// Signer is a crypto.Signer for an ECDSA private key on a PKCS#11 token.
+type Signer struct {
+	// ID is the CKA_ID of the private key.
+	ID []byte
+	// Label is the CKA_LABEL of the private key.
+	Label string
+
+	token  *Token
+	handle pkcs11.ObjectHandle
+	pub    *ecdsa.PublicKey
+}
+
+// Public returns the public key.
+func (s *Signer) Public() crypto.PublicKey {
+	return s.pub
+}
+
+// Sign signs the digest on the token. The signature is returned in the ASN.1
+// format that is also returned by ecdsa.PrivateKey.
+func (s *Signer) Sign(_ io.Reader, digest []byte, _ crypto.SignerOpts) ([]byte, error) {
+	raw, err := s.token.sign(s.handle, digest)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw)%2 != 0 {
+		return nil, serrors.New("invalid signature length", "length", len(raw))
+	}
+	half := len(raw) / 2
+	return asn1.Marshal(struct{ R, S *big.Int }{
+		R: new(big.Int).SetBytes(raw[:half]),
+		S: new(big.Int).SetBytes(raw[half:]),
+	})
+}
+
+// parseECPublicKey parses the public key from the CKA_EC_PARAMS and
+// CKA_EC_POINT attributes. The point is a DER encoded octet string. Some
+// tokens return the raw point instead, which is accepted too.
+func parseECPublicKey(params, point []byte) (*ecdsa.PublicKey, error) {
+	var raw []byte
+	if rest, err := asn1.Unmarshal(point, &raw); err != nil || len(rest) != 0 {
+		raw = point
+	}
+	spki, err := asn1.Marshal(struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}{
+		Algorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPublicKeyECDSA,
+			Parameters: asn1.RawValue{FullBytes: params},
+		},
+		PublicKey: asn1.BitString{Bytes: raw, BitLength: 8 * len(raw)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	pub, err := x509.ParsePKIXPublicKey(spki)
+	if err != nil {
+		return nil, serrors.Wrap("parsing EC public key", err)
+	}
+	ecPub, ok := pub.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, serrors.New("not an ECDSA public key")
+	}
+	return ecPub, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !cgo
+
+package pkcs11
+
+import (
+	"crypto"
+	"io"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+var errNoCgo = serrors.New("PKCS#11 support requires a binary built with cgo")
+
+// Token is a logged in session on a PKCS#11 token. Without cgo, tokens can not
+// be opened.
+type Token struct{}
+
+// Open returns an error, PKCS#11 modules can only be loaded with cgo.
+func Open(uri URI) (*Token, error) {
+	return nil, errNoCgo
+}
+
+// Close closes the session.
+func (t *Token) Close() error {
+	return nil
+}
+
+// Signers returns the signers for the private keys matching the URI.
+func (t *Token) Signers(uri URI) ([]*Signer, error) {
+	return nil, errNoCgo
+}
+
+// Signer is a crypto.Signer for an ECDSA private key on a PKCS#11 token.
+type Signer struct {
+	// ID is the CKA_ID of the private key.
+	ID []byte
+	// Label is the CKA_LABEL of the private key.
+	Label string
+}
+
+// Public returns the public key.
+func (s *Signer) Public() crypto.PublicKey {
+	return nil
+}
+
+// Sign signs the digest on the token.
+func (s *Signer) Sign(_ io.Reader, _ []byte, _ crypto.SignerOpts) ([]byte, error) {
+	return nil, errNoCgo
+}
@@ -0,0 +1,199 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package pkcs11_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/asn1"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	p11 "github.com/miekg/pkcs11"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/private/pkcs11"
+)
+
+const (
+	tokenLabel = "scion"
+	userPIN    = "1234"
+	soPIN      = "5678"
+)
+
+// softHSMModules are the locations of the SoftHSM module on common
+// distributions. The location can be overwritten with the SOFTHSM2_MODULE
+// environment variable.
+var softHSMModules = []string{
+	"/usr/lib/softhsm/libsofthsm2.so",
+	"/usr/lib/x86_64-linux-gnu/softhsm/libsofthsm2.so",
+	"/usr/lib64/pkcs11/libsofthsm2.so",
+	"/usr/local/lib/softhsm/libsofthsm2.so",
+}
+
+func TestSigner(t *testing.T) {
+	module := setupSoftHSM(t, "cp-as", "cp-ca")
+	uri := func(t *testing.T, attrs string) pkcs11.URI {
+		u, err := pkcs11.ParseURI(fmt.Sprintf("pkcs11:token=%s;%s?module-path=%s&pin-value=%s",
+			tokenLabel, attrs, module, userPIN))
+		require.NoError(t, err)
+		return u
+	}
+
+	t.Run("sign", func(t *testing.T) {
+		signer, err := pkcs11.LoadSigner(uri(t, "object=cp-as"))
+		require.NoError(t, err)
+		assert.Equal(t, "cp-as", signer.Label)
+
+		digest := sha256.Sum256([]byte("message"))
+		sig, err := signer.Sign(nil, digest[:], nil)
+		require.NoError(t, err)
+		pub, ok := signer.Public().(*ecdsa.PublicKey)
+		require.True(t, ok)
+		assert.True(t, ecdsa.VerifyASN1(pub, digest[:], sig))
+	})
+	t.Run("ambiguous key", func(t *testing.T) {
+		_, err := pkcs11.LoadSigner(uri(t, "type=private"))
+		assert.Error(t, err)
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := pkcs11.LoadSigner(uri(t, "object=unknown"))
+		assert.Error(t, err)
+	})
+	t.Run("unknown token", func(t *testing.T) {
+		u := uri(t, "object=cp-as")
+		u.Token = "unknown"
+		_, err := pkcs11.LoadSigner(u)
+		assert.Error(t, err)
+	})
+	t.Run("key ring", func(t *testing.T) {
+		ring := &pkcs11.KeyRing{URI: uri(t, "type=private")}
+		defer ring.Close()
+		keys, err := ring.PrivateKeys(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, keys, 2)
+		// Subsequent calls reuse the open token.
+		keys, err = ring.PrivateKeys(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, keys, 2)
+	})
+}
+
+// setupSoftHSM initializes a fresh SoftHSM token with one P-256 key pair per
+// label, and returns the path to the module. The test is skipped if SoftHSM is
+// not installed.
+func setupSoftHSM(t *testing.T, labels ...string) string {
+	t.Helper()
+	module := os.Getenv("SOFTHSM2_MODULE")
+	if module == "" {
+		for _, candidate := range softHSMModules {
+			if _, err := os.Stat(candidate); err == nil {
+				module = candidate
+				break
+			}
+		}
+	}
+	if module == "" {
+		t.Skip("SoftHSM not installed")
+	}
+
+	dir := t.TempDir()
+	tokens := filepath.Join(dir, "tokens")
+	require.NoError(t, os.Mkdir(tokens, 0o700))
+	conf := filepath.Join(dir, "softhsm2.conf")
+	require.NoError(t, os.WriteFile(conf, []byte(fmt.Sprintf(
+		"directories.tokendir = %s\nobjectstore.backend = file\n", tokens)), 0o600))
+	t.Setenv("SOFTHSM2_CONF", conf)
+
+	ctx := p11.New(module)
+	require.NotNil(t, ctx)
+	defer ctx.Destroy()
+	require.NoError(t, ctx.Initialize())
+	// The module must be finalized before the package under test initializes
+	// it again.
+	defer func() { require.NoError(t, ctx.Finalize()) }()
+
+	slots, err := ctx.GetSlotList(false)
+	require.NoError(t, err)
+	require.NotEmpty(t, slots)
+	require.NoError(t, ctx.InitToken(slots[0], soPIN, tokenLabel))
+
+	// SoftHSM reassigns the slot of the initialized token.
+	slots, err = ctx.GetSlotList(true)
+	require.NoError(t, err)
+	slot, found := uint(0), false
+	for _, s := range slots {
+		info, err := ctx.GetTokenInfo(s)
+		require.NoError(t, err)
+		if strings.TrimRight(info.Label, " ") == tokenLabel {
+			slot, found = s, true
+			break
+		}
+	}
+	require.True(t, found)
+
+	session, err := ctx.OpenSession(slot, p11.CKF_SERIAL_SESSION|p11.CKF_RW_SESSION)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ctx.CloseSession(session)) }()
+	require.NoError(t, ctx.Login(session, p11.CKU_SO, soPIN))
+	require.NoError(t, ctx.InitPIN(session, userPIN))
+	require.NoError(t, ctx.Logout(session))
+	require.NoError(t, ctx.Login(session, p11.CKU_USER, userPIN))
+	defer func() { require.NoError(t, ctx.Logout(session)) }()
+
+	curve, err := asn1.Marshal(asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7})
+	require.NoError(t, err)
+	for i, label := range labels {
+		id := []byte{byte(i + 1)}
+		pubHandle, _, err := ctx.GenerateKeyPair(session,
+			[]*p11.Mechanism{p11.NewMechanism(p11.CKM_EC_KEY_PAIR_GEN, nil)},
+			[]*p11.Attribute{
+				p11.NewAttribute(p11.CKA_TOKEN, true),
+				p11.NewAttribute(p11.CKA_VERIFY, true),
+				p11.NewAttribute(p11.CKA_EC_PARAMS, curve),
+				p11.NewAttribute(p11.CKA_ID, id),
+				p11.NewAttribute(p11.CKA_LABEL, label),
+			},
+			[]*p11.Attribute{
+				p11.NewAttribute(p11.CKA_TOKEN, true),
+				p11.NewAttribute(p11.CKA_PRIVATE, true),
+				p11.NewAttribute(p11.CKA_SIGN, true),
+				p11.NewAttribute(p11.CKA_SENSITIVE, true),
+				p11.NewAttribute(p11.CKA_EXTRACTABLE, false),
+				p11.NewAttribute(p11.CKA_ID, id),
+				p11.NewAttribute(p11.CKA_LABEL, label),
+			},
+		)
+		require.NoError(t, err)
+		// Sanity check that the public key is a P-256 key.
+		attrs, err := ctx.GetAttributeValue(session, pubHandle, []*p11.Attribute{
+			p11.NewAttribute(p11.CKA_EC_POINT, nil),
+		})
+		require.NoError(t, err)
+		var point []byte
+		_, err = asn1.Unmarshal(attrs[0].Value, &point)
+		require.NoError(t, err)
+		require.Len(t, point, 1+2*(elliptic.P256().Params().BitSize/8))
+	}
+	return module
+}
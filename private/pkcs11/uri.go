@@ -0,0 +1,160 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pkcs11 implements crypto.Signer for private keys that are stored on a
+// PKCS#11 token, e.g., a hardware security module. The private keys never leave
+// the token, all signatures are created by the token.
+//
+// Tokens and keys are identified with PKCS#11 URIs as defined in RFC 7512, for
+// example:
+//
+//	pkcs11:token=scion;object=cp-as?module-path=/usr/lib/libsofthsm2.so&pin-source=/etc/pin
+//
+// The module-path attribute is accepted both as path and query attribute to be
+// compatible with the URIs used by step-kms-plugin.
+package pkcs11
+
+import (
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// Scheme is the scheme of PKCS#11 URIs.
+const Scheme = "pkcs11:"
+
+// IsURI reports whether s is a PKCS#11 URI.
+func IsURI(s string) bool {
+	return strings.HasPrefix(s, Scheme)
+}
+
+// URI is a parsed PKCS#11 URI. Only the attributes that are relevant to
+// select a token and a private key are supported.
+type URI struct {
+	// ModulePath is the path to the PKCS#11 module, i.e., the shared library.
+	ModulePath string
+	// Token is the label of the token.
+	Token string
+	// Serial is the serial number of the token.
+	Serial string
+	// SlotID is the slot of the token. It is only considered if HasSlotID is
+	// set.
+	SlotID    uint
+	HasSlotID bool
+	// ID is the CKA_ID of the key.
+	ID []byte
+	// Object is the CKA_LABEL of the key.
+	Object string
+	// PIN is the user PIN. It is either set directly with pin-value, or read
+	// from the file referenced by pin-source.
+	PIN string
+}
+
+// ParseURI parses a PKCS#11 URI. Unknown attributes are rejected, unless they
+// are vendor specific attributes starting with "x-".
+func ParseURI(raw string) (URI, error) {
+	if !IsURI(raw) {
+		return URI{}, serrors.New("missing scheme", "scheme", Scheme)
+	}
+	var u URI
+	if err := u.merge(raw); err != nil {
+		return URI{}, err
+	}
+	return u, nil
+}
+
+// Merge returns a copy of the URI with the attributes of the other URI
+// applied on top. This is useful to combine a URI that selects the token with
+// a URI that selects the key.
+func (u URI) Merge(other string) (URI, error) {
+	if !IsURI(other) {
+		return URI{}, serrors.New("missing scheme", "scheme", Scheme)
+	}
+	merged := u
+	merged.ID = append([]byte(nil), u.ID...)
+	if err := merged.merge(other); err != nil {
+		return URI{}, err
+	}
+	return merged, nil
+}
+
+func (u *URI) merge(raw string) error {
+	rest := strings.TrimPrefix(raw, Scheme)
+	path, query, _ := strings.Cut(rest, "?")
+	if path != "" {
+		for _, attr := range strings.Split(path, ";") {
+			if err := u.setAttr(attr, false); err != nil {
+				return err
+			}
+		}
+	}
+	if query != "" {
+		for _, attr := range strings.Split(query, "&") {
+			if err := u.setAttr(attr, true); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (u *URI) setAttr(attr string, query bool) error {
+	rawKey, rawValue, ok := strings.Cut(attr, "=")
+	if !ok {
+		return serrors.New("invalid attribute", "attribute", attr)
+	}
+	key := strings.ToLower(rawKey)
+	value, err := url.PathUnescape(rawValue)
+	if err != nil {
+		return serrors.Wrap("unescaping attribute", err, "attribute", key)
+	}
+	switch {
+	case key == "module-path":
+		u.ModulePath = value
+	case key == "token" && !query:
+		u.Token = value
+	case key == "serial" && !query:
+		u.Serial = value
+	case key == "slot-id" && !query:
+		id, err := strconv.ParseUint(value, 10, 0)
+		if err != nil {
+			return serrors.Wrap("parsing slot-id", err)
+		}
+		u.SlotID, u.HasSlotID = uint(id), true
+	case key == "id" && !query:
+		u.ID = []byte(value)
+	case key == "object" && !query:
+		u.Object = value
+	case key == "type" && !query:
+		if value != "private" {
+			return serrors.New("unsupported object type", "type", value)
+		}
+	case key == "pin-value" && query:
+		u.PIN = value
+	case key == "pin-source" && query:
+		file := strings.TrimPrefix(value, "file:")
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			return serrors.Wrap("reading pin-source", err, "file", file)
+		}
+		u.PIN = strings.TrimRight(string(raw), "\r\n")
+	case strings.HasPrefix(key, "x-"):
+	default:
+		return serrors.New("unsupported attribute", "attribute", key, "query", query)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkcs11_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/private/pkcs11"
+)
+
+func TestParseURI(t *testing.T) {
+	pinFile := filepath.Join(t.TempDir(), "pin")
+	require.NoError(t, os.WriteFile(pinFile, []byte("1234\n"), 0o600))
+
+	testCases := map[string]struct {
+		Input        string
+		Expected     pkcs11.URI
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"full": {
+			Input: "pkcs11:token=scion;serial=42;slot-id=3;id=%01%02;object=cp%20as;" +
+				"type=private?module-path=/usr/lib/p11.so&pin-value=1234",
+			Expected: pkcs11.URI{
+				ModulePath: "/usr/lib/p11.so",
+				Token:      "scion",
+				Serial:     "42",
+				SlotID:     3,
+				HasSlotID:  true,
+				ID:         []byte{1, 2},
+				Object:     "cp as",
+				PIN:        "1234",
+			},
+			ErrAssertion: assert.NoError,
+		},
+		"module path as path attribute": {
+			Input: "pkcs11:module-path=/usr/lib/p11.so;token=scion",
+			Expected: pkcs11.URI{
+				ModulePath: "/usr/lib/p11.so",
+				Token:      "scion",
+			},
+			ErrAssertion: assert.NoError,
+		},
+		"pin source": {
+			Input: "pkcs11:token=scion?pin-source=file:" + pinFile,
+			Expected: pkcs11.URI{
+				Token: "scion",
+				PIN:   "1234",
+			},
+			ErrAssertion: assert.NoError,
+		},
+		"vendor attribute": {
+			Input:        "pkcs11:token=scion;x-vendor=foo",
+			Expected:     pkcs11.URI{Token: "scion"},
+			ErrAssertion: assert.NoError,
+		},
+		"empty": {
+			Input:        "pkcs11:",
+			ErrAssertion: assert.NoError,
+		},
+		"missing scheme": {
+			Input:        "token=scion",
+			ErrAssertion: assert.Error,
+		},
+		"unknown attribute": {
+			Input:        "pkcs11:tokn=scion",
+			ErrAssertion: assert.Error,
+		},
+		"pin in path": {
+			Input:        "pkcs11:pin-value=1234",
+			ErrAssertion: assert.Error,
+		},
+		"public key": {
+			Input:        "pkcs11:type=public",
+			ErrAssertion: assert.Error,
+		},
+		"invalid slot": {
+			Input:        "pkcs11:slot-id=abc",
+			ErrAssertion: assert.Error,
+		},
+		"missing pin source": {
+			Input:        "pkcs11:?pin-source=" + filepath.Join(t.TempDir(), "missing"),
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			uri, err := pkcs11.ParseURI(tc.Input)
+			tc.ErrAssertion(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.Expected, uri)
+		})
+	}
+}
+
+func TestURIMerge(t *testing.T) {
+	token, err := pkcs11.ParseURI("pkcs11:module-path=/usr/lib/p11.so;token=scion?pin-value=1")
+	require.NoError(t, err)
+	key, err := token.Merge("pkcs11:id=%01;object=cp-as")
+	require.NoError(t, err)
+	assert.Equal(t, pkcs11.URI{
+		ModulePath: "/usr/lib/p11.so",
+		Token:      "scion",
+		ID:         []byte{1},
+		Object:     "cp-as",
+		PIN:        "1",
+	}, key)
+	assert.Empty(t, token.Object, "original must not be modified")
+
+	_, err = token.Merge("cp-as")
+	assert.Error(t, err)
+}
@@ -0,0 +1,378 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqlite implements the ledger of issued certificate chains and the
+// store of enrollment tokens with an SQLite backend.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/private/ca/renewal"
+	"github.com/scionproto/scion/private/storage/db"
+)
+
+const (
+	// SchemaVersion is the version of the SQLite schema understood by this backend.
+	// Whenever changes to the schema are made, this version number should be increased
+	// to prevent data corruption between incompatible database schemas.
+	SchemaVersion = 1
+	// Schema is the SQLite database layout.
+	Schema = `
+	CREATE TABLE issued_chains(
+		row_id INTEGER PRIMARY KEY,
+		isd_id INTEGER NOT NULL,
+		as_id INTEGER NOT NULL,
+		serial TEXT NOT NULL,
+		not_before INTEGER NOT NULL,
+		not_after INTEGER NOT NULL,
+		ca_fingerprint DATA NOT NULL,
+		source TEXT NOT NULL,
+		issued_at INTEGER NOT NULL,
+		reserved INTEGER NOT NULL DEFAULT 0
+	);
+	CREATE INDEX issued_chains_subject ON issued_chains(isd_id, as_id, issued_at);
+	CREATE INDEX issued_chains_issued_at ON issued_chains(issued_at);
+	CREATE TABLE enrollment_tokens(
+		token_hash DATA NOT NULL,
+		isd_id INTEGER NOT NULL,
+		as_id INTEGER NOT NULL,
+		created_at INTEGER NOT NULL,
+		expiration INTEGER NOT NULL,
+		redeemed_at INTEGER,
+		PRIMARY KEY (token_hash)
+	);
+	`
+)
+
+var (
+	_ renewal.Ledger               = (*DB)(nil)
+	_ renewal.EnrollmentTokenStore = (*DB)(nil)
+)
+
+// DB implements the ledger of issued certificate chains and the store of
+// enrollment tokens with an SQLite backend.
+type DB struct {
+	db *db.Sqlite
+	*executor
+}
+
+// New returns a new SQLite backend opening a database at the given path. If
+// no database exists a new database is created. If the schema version of the
+// stored database is different from the one in this file, an error is
+// returned.
+func New(path string, cfg *db.SqliteConfig) (*DB, error) {
+	db, err := db.NewSqlite(path, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Setup(Schema, SchemaVersion); err != nil {
+		return nil, err
+	}
+	return &DB{
+		db: db,
+		executor: &executor{
+			write: db.Full,
+			read:  db.ReadOnly,
+		},
+	}, nil
+}
+
+func (d *DB) DB() *db.Sqlite {
+	return d.db
+}
+
+// Close closes the database.
+func (d *DB) Close() error {
+	return d.db.Close()
+}
+
+type executor struct {
+	write db.Sqler
+	read  interface {
+		QueryContext(context.Context, string, ...any) (*sql.Rows, error)
+		QueryRowContext(context.Context, string, ...any) *sql.Row
+	}
+}
+
+// IssuedChains returns the records matching the query, ordered by the time of
+// issuance.
+func (e *executor) IssuedChains(
+	ctx context.Context,
+	q renewal.IssuedChainsQuery,
+) ([]renewal.IssuedChain, error) {
+
+	var where []string
+	var args []any
+	if len(q.IAs) > 0 {
+		subWhere := make([]string, 0, len(q.IAs))
+		for _, ia := range q.IAs {
+			subWhere = append(subWhere, "(isd_id=? AND as_id=?)")
+			args = append(args, ia.ISD(), ia.AS())
+		}
+		where = append(where, "("+strings.Join(subWhere, " OR ")+")")
+	}
+	if !q.Since.IsZero() {
+		where = append(where, "issued_at>=?")
+		args = append(args, q.Since.UnixNano())
+	}
+	if !q.Until.IsZero() {
+		where = append(where, "issued_at<?")
+		args = append(args, q.Until.UnixNano())
+	}
+	// Reservations are not records of issued chains, yet.
+	where = append(where, "reserved=0")
+	query := `SELECT isd_id, as_id, serial, not_before, not_after, ca_fingerprint,
+		source, issued_at FROM issued_chains WHERE ` + strings.Join(where, " AND ")
+	query += " ORDER BY issued_at, row_id"
+
+	rows, err := e.read.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, db.NewReadError("looking up issued chains", err)
+	}
+	defer rows.Close()
+	var res []renewal.IssuedChain
+	for rows.Next() {
+		var isd addr.ISD
+		var as addr.AS
+		var serial string
+		var notBefore, notAfter, issuedAt int64
+		var c renewal.IssuedChain
+		err := rows.Scan(&isd, &as, &serial, &notBefore, &notAfter, &c.CAFingerprint,
+			&c.Source, &issuedAt)
+		if err != nil {
+			return nil, db.NewReadError("reading issued chain", err)
+		}
+		var ok bool
+		if c.Serial, ok = new(big.Int).SetString(serial, 16); !ok {
+			return nil, db.NewDataError("parsing serial", nil, "serial", serial)
+		}
+		if c.Subject, err = addr.IAFrom(isd, as); err != nil {
+			return nil, db.NewDataError("parsing subject", err)
+		}
+		c.NotBefore = time.Unix(notBefore, 0).UTC()
+		c.NotAfter = time.Unix(notAfter, 0).UTC()
+		c.IssuedAt = time.Unix(0, issuedAt).UTC()
+		res = append(res, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, db.NewReadError("reading issued chains", err)
+	}
+	return res, nil
+}
+
+const countIssuedChainsStmt = `
+SELECT COUNT(*) FROM issued_chains WHERE isd_id=? AND as_id=? AND issued_at>=?
+`
+
+const insertReservationStmt = `
+INSERT INTO issued_chains (isd_id, as_id, serial, not_before, not_after,
+	ca_fingerprint, source, issued_at, reserved)
+VALUES (?, ?, '', 0, 0, x'', '', ?, 1)
+`
+
+// ReserveIssuedChain reserves a record for a chain that is about to be issued
+// for the subject. The reservations are counted against the issuance limit.
+// Counting the records and inserting the reservation happen in the same
+// transaction.
+func (e *executor) ReserveIssuedChain(
+	ctx context.Context,
+	subject addr.IA,
+	limit renewal.IssuanceLimit,
+	now time.Time,
+) (int64, error) {
+
+	var id int64
+	err := db.DoInTx(ctx, e.write, func(ctx context.Context, tx *sql.Tx) error {
+		if limit.MaxChains > 0 {
+			var issued int
+			err := tx.QueryRowContext(ctx, countIssuedChainsStmt, subject.ISD(), subject.AS(),
+				now.Add(-limit.Window).UnixNano()).Scan(&issued)
+			if err != nil {
+				return db.NewReadError("counting issued chains", err)
+			}
+			if issued >= limit.MaxChains {
+				return serrors.JoinNoStack(renewal.ErrIssuanceLimit, nil,
+					"isd_as", subject,
+					"issued", issued,
+					"window", limit.Window,
+				)
+			}
+		}
+		res, err := tx.ExecContext(ctx, insertReservationStmt,
+			subject.ISD(), subject.AS(), now.UnixNano())
+		if err != nil {
+			return db.NewWriteError("inserting reservation", err)
+		}
+		if id, err = res.LastInsertId(); err != nil {
+			return db.NewWriteError("inserting reservation", err)
+		}
+		return nil
+	})
+	return id, err
+}
+
+const completeReservationStmt = `
+UPDATE issued_chains SET serial=?, not_before=?, not_after=?, ca_fingerprint=?,
+	source=?, issued_at=?, reserved=0
+WHERE row_id=? AND isd_id=? AND as_id=? AND reserved=1
+`
+
+// CompleteIssuedChain replaces the reservation with the record of the issued
+// chain.
+func (e *executor) CompleteIssuedChain(
+	ctx context.Context,
+	reservation int64,
+	c renewal.IssuedChain,
+) error {
+
+	if c.Serial == nil {
+		return db.NewInputDataError("serial must be set", nil)
+	}
+	return db.DoInTx(ctx, e.write, func(ctx context.Context, tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, completeReservationStmt,
+			c.Serial.Text(16),
+			c.NotBefore.Unix(),
+			c.NotAfter.Unix(),
+			c.CAFingerprint,
+			c.Source,
+			c.IssuedAt.UnixNano(),
+			reservation,
+			c.Subject.ISD(),
+			c.Subject.AS(),
+		)
+		if err != nil {
+			return db.NewWriteError("completing reservation", err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return db.NewWriteError("completing reservation", err)
+		}
+		if n == 0 {
+			return db.NewInputDataError("reservation not found", nil,
+				"reservation", reservation, "isd_as", c.Subject)
+		}
+		return nil
+	})
+}
+
+const deleteReservationStmt = `
+DELETE FROM issued_chains WHERE row_id=? AND reserved=1
+`
+
+// ReleaseIssuedChain removes the reservation.
+func (e *executor) ReleaseIssuedChain(ctx context.Context, reservation int64) error {
+	return db.DoInTx(ctx, e.write, func(ctx context.Context, tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, deleteReservationStmt, reservation); err != nil {
+			return db.NewWriteError("deleting reservation", err)
+		}
+		return nil
+	})
+}
+
+const insertEnrollmentTokenStmt = `
+INSERT INTO enrollment_tokens (token_hash, isd_id, as_id, created_at, expiration)
+VALUES (?, ?, ?, ?, ?)
+`
+
+// InsertEnrollmentToken inserts the record of an enrollment token.
+func (e *executor) InsertEnrollmentToken(
+	ctx context.Context,
+	t renewal.EnrollmentToken,
+) error {
+
+	if len(t.Hash) == 0 {
+		return db.NewInputDataError("hash must be set", nil)
+	}
+	return db.DoInTx(ctx, e.write, func(ctx context.Context, tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, insertEnrollmentTokenStmt,
+			t.Hash,
+			t.Subject.ISD(),
+			t.Subject.AS(),
+			t.CreatedAt.UnixNano(),
+			t.Expiration.UnixNano(),
+		)
+		if err != nil {
+			return db.NewWriteError("inserting enrollment token", err)
+		}
+		return nil
+	})
+}
+
+const redeemEnrollmentTokenStmt = `
+UPDATE enrollment_tokens SET redeemed_at=?
+WHERE token_hash=? AND isd_id=? AND as_id=? AND expiration>? AND redeemed_at IS NULL
+`
+
+// RedeemEnrollmentToken marks the token with the given hash as redeemed.
+func (e *executor) RedeemEnrollmentToken(
+	ctx context.Context,
+	hash []byte,
+	subject addr.IA,
+	now time.Time,
+) error {
+
+	return db.DoInTx(ctx, e.write, func(ctx context.Context, tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, redeemEnrollmentTokenStmt,
+			now.UnixNano(),
+			hash,
+			subject.ISD(),
+			subject.AS(),
+			now.UnixNano(),
+		)
+		if err != nil {
+			return db.NewWriteError("redeeming enrollment token", err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return db.NewWriteError("redeeming enrollment token", err)
+		}
+		if n == 0 {
+			return renewal.ErrInvalidEnrollmentToken
+		}
+		return nil
+	})
+}
+
+const releaseEnrollmentTokenStmt = `
+UPDATE enrollment_tokens SET redeemed_at=NULL
+WHERE token_hash=? AND isd_id=? AND as_id=? AND redeemed_at IS NOT NULL
+`
+
+// ReleaseEnrollmentToken marks the redeemed token with the given hash as not
+// redeemed.
+func (e *executor) ReleaseEnrollmentToken(
+	ctx context.Context,
+	hash []byte,
+	subject addr.IA,
+) error {
+
+	return db.DoInTx(ctx, e.write, func(ctx context.Context, tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, releaseEnrollmentTokenStmt,
+			hash,
+			subject.ISD(),
+			subject.AS(),
+		)
+		if err != nil {
+			return db.NewWriteError("releasing enrollment token", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,224 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite_test
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/private/ca/renewal"
+	"github.com/scionproto/scion/private/storage/ca/sqlite"
+)
+
+func TestLedger(t *testing.T) {
+	ctx := context.Background()
+	db, err := sqlite.New(filepath.Join(t.TempDir(), "ca.db"), nil)
+	require.NoError(t, err)
+	defer db.Close()
+
+	ia110 := addr.MustParseIA("1-ff00:0:110")
+	ia111 := addr.MustParseIA("1-ff00:0:111")
+	now := time.Now().UTC().Truncate(time.Second)
+	record := func(ia addr.IA, serial int64, issuedAt time.Time) renewal.IssuedChain {
+		return renewal.IssuedChain{
+			Subject:       ia,
+			Serial:        big.NewInt(serial),
+			NotBefore:     issuedAt.Truncate(time.Second),
+			NotAfter:      issuedAt.Add(72 * time.Hour).Truncate(time.Second),
+			CAFingerprint: []byte("fingerprint"),
+			Source:        "1-ff00:0:111,127.0.0.1:31000",
+			IssuedAt:      issuedAt,
+		}
+	}
+	first := record(ia111, 1, now.Add(-2*time.Hour))
+	second := record(ia110, 2, now.Add(-time.Hour))
+	third := record(ia111, 0xdeadbeef, now)
+	for _, c := range []renewal.IssuedChain{third, first, second} {
+		id, err := db.ReserveIssuedChain(ctx, c.Subject, renewal.IssuanceLimit{}, c.IssuedAt)
+		require.NoError(t, err)
+		require.NoError(t, db.CompleteIssuedChain(ctx, id, c))
+	}
+	id, err := db.ReserveIssuedChain(ctx, ia110, renewal.IssuanceLimit{}, now)
+	require.NoError(t, err)
+	assert.Error(t, db.CompleteIssuedChain(ctx, id, renewal.IssuedChain{Subject: ia110}))
+	require.NoError(t, db.ReleaseIssuedChain(ctx, id))
+
+	testCases := map[string]struct {
+		Query    renewal.IssuedChainsQuery
+		Expected []renewal.IssuedChain
+	}{
+		"all": {
+			Expected: []renewal.IssuedChain{first, second, third},
+		},
+		"by IA": {
+			Query:    renewal.IssuedChainsQuery{IAs: []addr.IA{ia111}},
+			Expected: []renewal.IssuedChain{first, third},
+		},
+		"by IAs": {
+			Query:    renewal.IssuedChainsQuery{IAs: []addr.IA{ia110, ia111}},
+			Expected: []renewal.IssuedChain{first, second, third},
+		},
+		"since": {
+			Query:    renewal.IssuedChainsQuery{Since: second.IssuedAt},
+			Expected: []renewal.IssuedChain{second, third},
+		},
+		"until": {
+			Query:    renewal.IssuedChainsQuery{Until: second.IssuedAt},
+			Expected: []renewal.IssuedChain{first},
+		},
+		"IA and time range": {
+			Query: renewal.IssuedChainsQuery{
+				IAs:   []addr.IA{ia111},
+				Since: first.IssuedAt.Add(time.Second),
+				Until: now.Add(time.Second),
+			},
+			Expected: []renewal.IssuedChain{third},
+		},
+		"no match": {
+			Query: renewal.IssuedChainsQuery{IAs: []addr.IA{addr.MustParseIA("1-ff00:0:112")}},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			chains, err := db.IssuedChains(ctx, tc.Query)
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, chains)
+		})
+	}
+
+	// Only the records in the window count against the limit.
+	_, err = db.ReserveIssuedChain(ctx, ia111,
+		renewal.IssuanceLimit{MaxChains: 2, Window: 3 * time.Hour}, now)
+	assert.ErrorIs(t, err, renewal.ErrIssuanceLimit)
+	_, err = db.ReserveIssuedChain(ctx, ia111,
+		renewal.IssuanceLimit{MaxChains: 2, Window: time.Hour}, now)
+	assert.NoError(t, err)
+}
+
+func TestLedgerReservation(t *testing.T) {
+	ctx := context.Background()
+	db, err := sqlite.New(filepath.Join(t.TempDir(), "ca.db"), nil)
+	require.NoError(t, err)
+	defer db.Close()
+
+	ia111 := addr.MustParseIA("1-ff00:0:111")
+	now := time.Now().UTC().Truncate(time.Second)
+	limit := renewal.IssuanceLimit{MaxChains: 2, Window: time.Hour}
+
+	// Concurrent reservations do not exceed the limit.
+	var wg sync.WaitGroup
+	var reserved atomic.Int32
+	reservations := make(chan int64, 10)
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id, err := db.ReserveIssuedChain(ctx, ia111, limit, now)
+			if err != nil {
+				assert.ErrorIs(t, err, renewal.ErrIssuanceLimit)
+				return
+			}
+			reserved.Add(1)
+			reservations <- id
+		}()
+	}
+	wg.Wait()
+	close(reservations)
+	require.Equal(t, int32(2), reserved.Load())
+	first, second := <-reservations, <-reservations
+
+	// Reservations are counted, but not listed.
+	chains, err := db.IssuedChains(ctx, renewal.IssuedChainsQuery{})
+	require.NoError(t, err)
+	assert.Empty(t, chains)
+
+	// Completed reservations are listed.
+	record := renewal.IssuedChain{
+		Subject:       ia111,
+		Serial:        big.NewInt(1),
+		NotBefore:     now,
+		NotAfter:      now.Add(72 * time.Hour),
+		CAFingerprint: []byte("fingerprint"),
+		Source:        "1-ff00:0:111,127.0.0.1:31000",
+		IssuedAt:      now,
+	}
+	require.NoError(t, db.CompleteIssuedChain(ctx, first, record))
+	assert.Error(t, db.CompleteIssuedChain(ctx, first, record))
+	chains, err = db.IssuedChains(ctx, renewal.IssuedChainsQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, []renewal.IssuedChain{record}, chains)
+
+	// Released reservations free up the limit. Completed records cannot be
+	// released.
+	require.NoError(t, db.ReleaseIssuedChain(ctx, second))
+	require.NoError(t, db.ReleaseIssuedChain(ctx, first))
+	_, err = db.ReserveIssuedChain(ctx, ia111, limit, now)
+	assert.NoError(t, err)
+	_, err = db.ReserveIssuedChain(ctx, ia111, limit, now)
+	assert.ErrorIs(t, err, renewal.ErrIssuanceLimit)
+
+	// Without limit, reservations always succeed.
+	_, err = db.ReserveIssuedChain(ctx, ia111, renewal.IssuanceLimit{}, now)
+	assert.NoError(t, err)
+}
+
+func TestEnrollmentTokens(t *testing.T) {
+	ctx := context.Background()
+	db, err := sqlite.New(filepath.Join(t.TempDir(), "ca.db"), nil)
+	require.NoError(t, err)
+	defer db.Close()
+
+	ia110 := addr.MustParseIA("1-ff00:0:110")
+	ia111 := addr.MustParseIA("1-ff00:0:111")
+	now := time.Now()
+	token, record, err := renewal.NewEnrollmentToken(ia111, time.Hour, now)
+	require.NoError(t, err)
+	require.NoError(t, db.InsertEnrollmentToken(ctx, record))
+	expired, expiredRecord, err := renewal.NewEnrollmentToken(ia111, time.Minute,
+		now.Add(-time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, db.InsertEnrollmentToken(ctx, expiredRecord))
+	assert.Error(t, db.InsertEnrollmentToken(ctx, record))
+
+	hash := renewal.HashEnrollmentToken(token)
+	err = db.RedeemEnrollmentToken(ctx, renewal.HashEnrollmentToken("unknown"), ia111, now)
+	assert.ErrorIs(t, err, renewal.ErrInvalidEnrollmentToken)
+	err = db.RedeemEnrollmentToken(ctx, renewal.HashEnrollmentToken(expired), ia111, now)
+	assert.ErrorIs(t, err, renewal.ErrInvalidEnrollmentToken)
+	err = db.RedeemEnrollmentToken(ctx, hash, ia110, now)
+	assert.ErrorIs(t, err, renewal.ErrInvalidEnrollmentToken)
+	err = db.RedeemEnrollmentToken(ctx, hash, ia111, now.Add(2*time.Hour))
+	assert.ErrorIs(t, err, renewal.ErrInvalidEnrollmentToken)
+
+	require.NoError(t, db.RedeemEnrollmentToken(ctx, hash, ia111, now))
+	err = db.RedeemEnrollmentToken(ctx, hash, ia111, now)
+	assert.ErrorIs(t, err, renewal.ErrInvalidEnrollmentToken)
+
+	// A released token can be redeemed again.
+	require.NoError(t, db.ReleaseEnrollmentToken(ctx, hash, ia110))
+	err = db.RedeemEnrollmentToken(ctx, hash, ia111, now)
+	assert.ErrorIs(t, err, renewal.ErrInvalidEnrollmentToken)
+	require.NoError(t, db.ReleaseEnrollmentToken(ctx, hash, ia111))
+	require.NoError(t, db.RedeemEnrollmentToken(ctx, hash, ia111, now))
+}
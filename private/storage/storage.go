@@ -26,6 +26,7 @@ import (
 	"github.com/scionproto/scion/pkg/drkey"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/private/ca/renewal"
 	"github.com/scionproto/scion/private/config"
 	"github.com/scionproto/scion/private/pathdb"
 	"github.com/scionproto/scion/private/periodic"
@@ -33,6 +34,7 @@ import (
 	"github.com/scionproto/scion/private/revcache/memrevcache"
 	beaconstorage "github.com/scionproto/scion/private/storage/beacon"
 	sqlitebeacondb "github.com/scionproto/scion/private/storage/beacon/sqlite"
+	sqlitecaledger "github.com/scionproto/scion/private/storage/ca/sqlite"
 	"github.com/scionproto/scion/private/storage/cleaner"
 	"github.com/scionproto/scion/private/storage/db"
 	sqlitelevel1 "github.com/scionproto/scion/private/storage/drkey/level1/sqlite"
@@ -57,6 +59,7 @@ const (
 	DefaultDRKeyLevel1DBPath = "/share/cache/%s.drkey_level1.db"
 	DefaultDRKeyLevel2DBPath = "/share/cache/%s.drkey_level2.db"
 	DefaultDRKeySVDBPath     = "/share/cache/%s.drkey_secret_value.db"
+	DefaultCALedgerDBPath    = "/share/data/%s.ca_ledger.db"
 )
 
 // Default samples for various databases.
@@ -79,6 +82,9 @@ var (
 	SampleDRKeySecretValueDB = DBConfig{
 		Connection: DefaultDRKeySVDBPath,
 	}
+	SampleCALedgerDB = DBConfig{
+		Connection: DefaultCALedgerDBPath,
+	}
 )
 
 // SetID returns a clone of the configuration that has the ID set on the connection string.
@@ -106,6 +112,14 @@ type PathDB interface {
 	pathdb.DB
 }
 
+// CALedgerDB records the certificate chains issued by the CA, and stores the
+// enrollment tokens issued by the CA.
+type CALedgerDB interface {
+	io.Closer
+	renewal.Ledger
+	renewal.EnrollmentTokenStore
+}
+
 var _ (config.Config) = (*DBConfig)(nil)
 
 // DBConfig is the configuration for the connection to a database.
@@ -264,6 +278,21 @@ func NewTrustStorage(c DBConfig) (TrustDB, error) {
 	return db, nil
 }
 
+func NewCALedgerStorage(c DBConfig) (CALedgerDB, error) {
+	log.Info("Connecting CALedgerDB", "backend", BackendSqlite, "connection", c.Connection)
+	db, err := sqlitecaledger.New(
+		c.Connection,
+		&db.SqliteConfig{
+			MaxOpenReadConns: c.MaxOpenReadConns,
+			MaxIdleReadConns: c.MaxIdleReadConns,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
 func NewDRKeySecretValueStorage(c DBConfig) (drkey.SecretValueDB, error) {
 	log.Info("Connecting DRKeySecretValueDB", "	", BackendSqlite, "connection", c.Connection)
 	db, err := sqlitesecret.NewBackend(
@@ -20,26 +20,39 @@ import (
 
 	"github.com/patrickmn/go-cache"
 
+	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/util"
 	"github.com/scionproto/scion/private/config"
+	"github.com/scionproto/scion/private/trust/expiry"
 )
 
-const defaultExpiration = time.Minute
+const (
+	defaultExpiration     = time.Minute
+	defaultExpiryInterval = time.Minute
+)
 
 type Config struct {
-	config.NoValidator
-	Cache Cache `toml:"cache"`
+	Cache  Cache  `toml:"cache"`
+	Expiry Expiry `toml:"expiry"`
 }
 
 func (cfg *Config) InitDefaults() {
 	config.InitAll(
 		&cfg.Cache,
+		&cfg.Expiry,
+	)
+}
+
+func (cfg *Config) Validate() error {
+	return config.ValidateAll(
+		&cfg.Expiry,
 	)
 }
 
 func (cfg *Config) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
 	config.WriteSample(dst, path, ctx,
 		&cfg.Cache,
+		&cfg.Expiry,
 	)
 }
 
@@ -78,3 +91,113 @@ expiration = "1m"
 func (cfg *Cache) ConfigName() string {
 	return "cache"
 }
+
+// Expiry configures the monitoring of the expiration of the local trust
+// material.
+type Expiry struct {
+	// Interval is the interval between two scans of the trust material.
+	Interval util.DurWrap `toml:"interval,omitempty"`
+	// ASWarning and ASCritical are the thresholds for AS certificates.
+	ASWarning  util.DurWrap `toml:"as_warning,omitempty"`
+	ASCritical util.DurWrap `toml:"as_critical,omitempty"`
+	// CAWarning and CACritical are the thresholds for CA certificates.
+	CAWarning  util.DurWrap `toml:"ca_warning,omitempty"`
+	CACritical util.DurWrap `toml:"ca_critical,omitempty"`
+	// TRCWarning and TRCCritical are the thresholds for TRCs.
+	TRCWarning  util.DurWrap `toml:"trc_warning,omitempty"`
+	TRCCritical util.DurWrap `toml:"trc_critical,omitempty"`
+	// TRCGraceWarning and TRCGraceCritical are the thresholds for the end of
+	// the grace period of a TRC update.
+	TRCGraceWarning  util.DurWrap `toml:"trc_grace_warning,omitempty"`
+	TRCGraceCritical util.DurWrap `toml:"trc_grace_critical,omitempty"`
+}
+
+func (cfg *Expiry) InitDefaults() {
+	initDur(&cfg.Interval, defaultExpiryInterval)
+	defaults := expiry.DefaultPolicy()
+	initDur(&cfg.ASWarning, defaults[expiry.KindAS].Warning)
+	initDur(&cfg.ASCritical, defaults[expiry.KindAS].Critical)
+	initDur(&cfg.CAWarning, defaults[expiry.KindCA].Warning)
+	initDur(&cfg.CACritical, defaults[expiry.KindCA].Critical)
+	initDur(&cfg.TRCWarning, defaults[expiry.KindTRC].Warning)
+	initDur(&cfg.TRCCritical, defaults[expiry.KindTRC].Critical)
+	initDur(&cfg.TRCGraceWarning, defaults[expiry.KindTRCGrace].Warning)
+	initDur(&cfg.TRCGraceCritical, defaults[expiry.KindTRCGrace].Critical)
+}
+
+func (cfg *Expiry) Validate() error {
+	if cfg.Interval.Duration <= 0 {
+		return serrors.New("interval must be positive", "interval", cfg.Interval)
+	}
+	for kind, t := range cfg.Policy() {
+		if t.Warning < 0 || t.Critical < 0 {
+			return serrors.New("thresholds must not be negative", "type", kind)
+		}
+		if t.Critical > t.Warning {
+			return serrors.New("critical threshold must not exceed warning threshold",
+				"type", kind, "warning", t.Warning, "critical", t.Critical)
+		}
+	}
+	return nil
+}
+
+// Policy returns the expiry policy. The thresholds for the kinds that are not
+// configurable are taken from the default policy.
+func (cfg *Expiry) Policy() expiry.Policy {
+	policy := expiry.DefaultPolicy()
+	policy[expiry.KindAS] = expiry.Thresholds{
+		Warning:  cfg.ASWarning.Duration,
+		Critical: cfg.ASCritical.Duration,
+	}
+	policy[expiry.KindCA] = expiry.Thresholds{
+		Warning:  cfg.CAWarning.Duration,
+		Critical: cfg.CACritical.Duration,
+	}
+	policy[expiry.KindTRC] = expiry.Thresholds{
+		Warning:  cfg.TRCWarning.Duration,
+		Critical: cfg.TRCCritical.Duration,
+	}
+	policy[expiry.KindTRCGrace] = expiry.Thresholds{
+		Warning:  cfg.TRCGraceWarning.Duration,
+		Critical: cfg.TRCGraceCritical.Duration,
+	}
+	return policy
+}
+
+func (cfg *Expiry) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
+	config.WriteString(dst, `
+# The interval between two scans of the local trust material. (default 1m)
+interval = "1m"
+
+# The remaining validity of the AS certificate below which a warning is logged
+# and the health check is degraded. (default 1d)
+as_warning = "1d"
+
+# The remaining validity of the AS certificate below which an error is logged.
+# (default 6h)
+as_critical = "6h"
+
+# The thresholds for CA certificates. (default 7d, 2d)
+ca_warning = "7d"
+ca_critical = "2d"
+
+# The thresholds for the TRC of the local ISD. (default 30d, 7d)
+trc_warning = "30d"
+trc_critical = "7d"
+
+# The thresholds for the end of the grace period of a TRC update, after which
+# the predecessor TRC is no longer active. (default 1d, 6h)
+trc_grace_warning = "1d"
+trc_grace_critical = "6h"
+`)
+}
+
+func (cfg *Expiry) ConfigName() string {
+	return "expiry"
+}
+
+func initDur(wrap *util.DurWrap, d time.Duration) {
+	if wrap.Duration == 0 {
+		wrap.Duration = d
+	}
+}
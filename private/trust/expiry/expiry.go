@@ -0,0 +1,260 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package expiry determines how long the trust material, i.e., certificates
+// and TRCs, remains valid, and classifies the remaining time according to
+// configurable thresholds.
+//
+// The same evaluation is used by the services that monitor their trust
+// material periodically, and by the offline tooling that inspects a directory
+// of certificates and TRCs.
+package expiry
+
+import (
+	"crypto/x509"
+	"sort"
+	"time"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+)
+
+// Kind is the kind of trust material.
+type Kind string
+
+const (
+	KindAS        Kind = "cp-as"
+	KindCA        Kind = "cp-ca"
+	KindRoot      Kind = "cp-root"
+	KindSensitive Kind = "sensitive-voting"
+	KindRegular   Kind = "regular-voting"
+	KindTRC       Kind = "trc"
+	// KindTRCGrace is the end of the grace period of a TRC update. After the
+	// grace period, the predecessor TRC is no longer active.
+	KindTRCGrace Kind = "trc-grace"
+)
+
+var certKinds = map[cppki.CertType]Kind{
+	cppki.AS:        KindAS,
+	cppki.CA:        KindCA,
+	cppki.Root:      KindRoot,
+	cppki.Sensitive: KindSensitive,
+	cppki.Regular:   KindRegular,
+}
+
+// Entry is a piece of trust material that expires.
+type Entry struct {
+	Kind Kind
+	// Subject identifies the trust material. For certificates, it is the
+	// ISD-AS of the subject. For TRCs, it is the ISD.
+	Subject string
+	// ID identifies the specific object, e.g., the serial number of the
+	// certificate or the TRC ID.
+	ID string
+	// Source describes where the trust material was found, e.g., the file
+	// name.
+	Source   string
+	NotAfter time.Time
+}
+
+// FromCertificate creates the entry for a SCION control-plane certificate.
+func FromCertificate(cert *x509.Certificate, source string) (Entry, error) {
+	ct, err := cppki.ValidateCert(cert)
+	if err != nil {
+		return Entry{}, serrors.Wrap("classifying certificate", err)
+	}
+	ia, err := cppki.ExtractIA(cert.Subject)
+	if err != nil {
+		return Entry{}, serrors.Wrap("extracting ISD-AS", err)
+	}
+	return Entry{
+		Kind:     certKinds[ct],
+		Subject:  ia.String(),
+		ID:       cert.SerialNumber.String(),
+		Source:   source,
+		NotAfter: cert.NotAfter,
+	}, nil
+}
+
+// FromCertificates creates the entries for a list of certificates, e.g., a
+// certificate chain.
+func FromCertificates(certs []*x509.Certificate, source string) ([]Entry, error) {
+	entries := make([]Entry, 0, len(certs))
+	for i, cert := range certs {
+		e, err := FromCertificate(cert, source)
+		if err != nil {
+			return nil, serrors.Wrap("creating entry", err, "index", i)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// FromTRC creates the entries for a TRC. Next to the validity of the TRC
+// itself, an entry for the grace period is created if the TRC is in its grace
+// period at the given time.
+func FromTRC(trc *cppki.TRC, source string, now time.Time) []Entry {
+	entries := []Entry{{
+		Kind:     KindTRC,
+		Subject:  trc.ID.ISD.String(),
+		ID:       trc.ID.String(),
+		Source:   source,
+		NotAfter: trc.Validity.NotAfter,
+	}}
+	if trc.InGracePeriod(now) {
+		entries = append(entries, Entry{
+			Kind:     KindTRCGrace,
+			Subject:  trc.ID.ISD.String(),
+			ID:       trc.ID.String(),
+			Source:   source,
+			NotAfter: trc.GracePeriodEnd(),
+		})
+	}
+	return entries
+}
+
+// Latest reduces the entries to the one with the latest expiration time per
+// kind and subject. This is the relevant entry if the trust material has been
+// renewed. The result is sorted by kind and subject.
+func Latest(entries []Entry) []Entry {
+	type key struct {
+		kind    Kind
+		subject string
+	}
+	latest := make(map[key]Entry, len(entries))
+	for _, e := range entries {
+		k := key{kind: e.Kind, subject: e.Subject}
+		if l, ok := latest[k]; !ok || e.NotAfter.After(l.NotAfter) {
+			latest[k] = e
+		}
+	}
+	reduced := make([]Entry, 0, len(latest))
+	for _, e := range latest {
+		reduced = append(reduced, e)
+	}
+	sort.Slice(reduced, func(i, j int) bool {
+		if reduced[i].Kind != reduced[j].Kind {
+			return reduced[i].Kind < reduced[j].Kind
+		}
+		return reduced[i].Subject < reduced[j].Subject
+	})
+	return reduced
+}
+
+// Level classifies the remaining validity of an entry.
+type Level int
+
+const (
+	// LevelOK indicates that the remaining validity is above the warning
+	// threshold.
+	LevelOK Level = iota
+	// LevelWarning indicates that the remaining validity is below the warning
+	// threshold.
+	LevelWarning
+	// LevelCritical indicates that the remaining validity is below the
+	// critical threshold.
+	LevelCritical
+	// LevelExpired indicates that the entry has expired.
+	LevelExpired
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelOK:
+		return "ok"
+	case LevelWarning:
+		return "warning"
+	case LevelCritical:
+		return "critical"
+	case LevelExpired:
+		return "expired"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// Thresholds are the remaining validity below which an entry is classified
+// as warning or critical. A zero threshold is disabled.
+type Thresholds struct {
+	Warning  time.Duration
+	Critical time.Duration
+}
+
+// Policy defines the thresholds per kind. Kinds without thresholds are only
+// classified as expired or ok.
+type Policy map[Kind]Thresholds
+
+// DefaultPolicy returns the default thresholds. They are chosen according to
+// the typical lifetime of the respective trust material.
+func DefaultPolicy() Policy {
+	return Policy{
+		KindAS:        {Warning: 24 * time.Hour, Critical: 6 * time.Hour},
+		KindCA:        {Warning: 7 * 24 * time.Hour, Critical: 2 * 24 * time.Hour},
+		KindRoot:      {Warning: 30 * 24 * time.Hour, Critical: 7 * 24 * time.Hour},
+		KindSensitive: {Warning: 30 * 24 * time.Hour, Critical: 7 * 24 * time.Hour},
+		KindRegular:   {Warning: 30 * 24 * time.Hour, Critical: 7 * 24 * time.Hour},
+		KindTRC:       {Warning: 30 * 24 * time.Hour, Critical: 7 * 24 * time.Hour},
+		// Grace periods are short, typically in the order of days.
+		KindTRCGrace: {Warning: 24 * time.Hour, Critical: 6 * time.Hour},
+	}
+}
+
+// Result is an entry evaluated at a point in time.
+type Result struct {
+	Entry
+	Remaining time.Duration
+	Level     Level
+}
+
+// Evaluate classifies the entries at the given time.
+func (p Policy) Evaluate(entries []Entry, now time.Time) []Result {
+	results := make([]Result, 0, len(entries))
+	for _, e := range entries {
+		results = append(results, p.evaluate(e, now))
+	}
+	return results
+}
+
+func (p Policy) evaluate(e Entry, now time.Time) Result {
+	r := Result{Entry: e, Remaining: e.NotAfter.Sub(now)}
+	t := p[e.Kind]
+	switch {
+	case r.Remaining <= 0:
+		r.Level = LevelExpired
+	case t.Critical > 0 && r.Remaining <= t.Critical:
+		r.Level = LevelCritical
+	case t.Warning > 0 && r.Remaining <= t.Warning:
+		r.Level = LevelWarning
+	default:
+		r.Level = LevelOK
+	}
+	return r
+}
+
+// Worst returns the highest level of the results. If there are no results,
+// LevelOK is returned.
+func Worst(results []Result) Level {
+	worst := LevelOK
+	for _, r := range results {
+		if r.Level > worst {
+			worst = r.Level
+		}
+	}
+	return worst
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expiry_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/trust/expiry"
+)
+
+func TestPolicyEvaluate(t *testing.T) {
+	now := time.Now()
+	policy := expiry.Policy{
+		expiry.KindAS: {Warning: 24 * time.Hour, Critical: 6 * time.Hour},
+	}
+	testCases := map[string]struct {
+		Entry    expiry.Entry
+		Expected expiry.Level
+	}{
+		"ok": {
+			Entry:    expiry.Entry{Kind: expiry.KindAS, NotAfter: now.Add(48 * time.Hour)},
+			Expected: expiry.LevelOK,
+		},
+		"warning": {
+			Entry:    expiry.Entry{Kind: expiry.KindAS, NotAfter: now.Add(12 * time.Hour)},
+			Expected: expiry.LevelWarning,
+		},
+		"critical": {
+			Entry:    expiry.Entry{Kind: expiry.KindAS, NotAfter: now.Add(time.Hour)},
+			Expected: expiry.LevelCritical,
+		},
+		"expired": {
+			Entry:    expiry.Entry{Kind: expiry.KindAS, NotAfter: now.Add(-time.Hour)},
+			Expected: expiry.LevelExpired,
+		},
+		"no thresholds": {
+			Entry:    expiry.Entry{Kind: expiry.KindCA, NotAfter: now.Add(time.Hour)},
+			Expected: expiry.LevelOK,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			results := policy.Evaluate([]expiry.Entry{tc.Entry}, now)
+			assert.Len(t, results, 1)
+			assert.Equal(t, tc.Expected, results[0].Level)
+			assert.Equal(t, tc.Entry.NotAfter.Sub(now), results[0].Remaining)
+		})
+	}
+}
+
+func TestDefaultPolicy(t *testing.T) {
+	policy := expiry.DefaultPolicy()
+	kinds := []expiry.Kind{
+		expiry.KindAS, expiry.KindCA, expiry.KindRoot, expiry.KindSensitive,
+		expiry.KindRegular, expiry.KindTRC, expiry.KindTRCGrace,
+	}
+	for _, kind := range kinds {
+		thresholds, ok := policy[kind]
+		assert.True(t, ok, kind)
+		assert.Positive(t, thresholds.Critical, kind)
+		assert.Greater(t, thresholds.Warning, thresholds.Critical, kind)
+	}
+}
+
+func TestWorst(t *testing.T) {
+	assert.Equal(t, expiry.LevelOK, expiry.Worst(nil))
+	assert.Equal(t, expiry.LevelCritical, expiry.Worst([]expiry.Result{
+		{Level: expiry.LevelWarning},
+		{Level: expiry.LevelCritical},
+		{Level: expiry.LevelOK},
+	}))
+}
+
+func TestLatest(t *testing.T) {
+	now := time.Now()
+	entries := []expiry.Entry{
+		{Kind: expiry.KindAS, Subject: "1-ff00:0:110", ID: "1", NotAfter: now},
+		{Kind: expiry.KindCA, Subject: "1-ff00:0:110", ID: "2", NotAfter: now},
+		{Kind: expiry.KindAS, Subject: "1-ff00:0:110", ID: "3", NotAfter: now.Add(time.Hour)},
+		{Kind: expiry.KindAS, Subject: "1-ff00:0:111", ID: "4", NotAfter: now},
+	}
+	latest := expiry.Latest(entries)
+	assert.Equal(t, []expiry.Entry{entries[2], entries[3], entries[1]}, latest)
+}
+
+func TestFromTRC(t *testing.T) {
+	now := time.Now()
+	trc := &cppki.TRC{
+		ID: cppki.TRCID{ISD: 1, Base: 1, Serial: 2},
+		Validity: cppki.Validity{
+			NotBefore: now.Add(-time.Hour),
+			NotAfter:  now.Add(365 * 24 * time.Hour),
+		},
+		GracePeriod: 2 * time.Hour,
+	}
+	entries := expiry.FromTRC(trc, "file", now)
+	assert.Equal(t, []expiry.Entry{
+		{
+			Kind:     expiry.KindTRC,
+			Subject:  addr.ISD(1).String(),
+			ID:       trc.ID.String(),
+			Source:   "file",
+			NotAfter: trc.Validity.NotAfter,
+		},
+		{
+			Kind:     expiry.KindTRCGrace,
+			Subject:  addr.ISD(1).String(),
+			ID:       trc.ID.String(),
+			Source:   "file",
+			NotAfter: now.Add(time.Hour),
+		},
+	}, entries)
+
+	// Outside of the grace period, only the TRC validity is reported.
+	entries = expiry.FromTRC(trc, "file", now.Add(3*time.Hour))
+	assert.Len(t, entries, 1)
+}
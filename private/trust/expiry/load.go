@@ -0,0 +1,92 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expiry
+
+import (
+	"encoding/pem"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+)
+
+// LoadResult contains the entries loaded from a directory, and the files that
+// were ignored because they do not contain valid trust material.
+type LoadResult struct {
+	Entries []Entry
+	Ignored map[string]error
+}
+
+// LoadDir recursively loads the certificates (*.pem, *.crt) and TRCs (*.trc)
+// in the directory. Files that cannot be parsed are ignored and reported in
+// the result. The time is used to determine whether a TRC is in its grace
+// period.
+func LoadDir(dir string, now time.Time) (LoadResult, error) {
+	res := LoadResult{Ignored: map[string]error{}}
+	err := filepath.WalkDir(dir, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		var entries []Entry
+		switch filepath.Ext(file) {
+		case ".pem", ".crt":
+			entries, err = loadCertificates(file)
+		case ".trc":
+			entries, err = loadTRC(file, now)
+		default:
+			return nil
+		}
+		if err != nil {
+			res.Ignored[file] = err
+			return nil
+		}
+		res.Entries = append(res.Entries, entries...)
+		return nil
+	})
+	if err != nil {
+		return LoadResult{}, serrors.Wrap("walking directory", err, "dir", dir)
+	}
+	return res, nil
+}
+
+func loadCertificates(file string) ([]Entry, error) {
+	certs, err := cppki.ReadPEMCerts(file)
+	if err != nil {
+		return nil, err
+	}
+	return FromCertificates(certs, file)
+}
+
+func loadTRC(file string, now time.Time) ([]Entry, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(raw)
+	if block != nil && block.Type == "TRC" {
+		raw = block.Bytes
+	}
+	trc, err := cppki.DecodeSignedTRC(raw)
+	if err != nil {
+		return nil, err
+	}
+	return FromTRC(&trc.TRC, file, now), nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expiry_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/private/app/command"
+	"github.com/scionproto/scion/private/trust/expiry"
+	"github.com/scionproto/scion/scion-pki/testcrypto"
+)
+
+// genCrypto generates the crypto material for the test topology. The AS
+// certificates are valid for the given duration.
+func genCrypto(t *testing.T, asValidity string) string {
+	dir := t.TempDir()
+	var buf bytes.Buffer
+	cmd := testcrypto.Cmd(command.StringPather(""))
+	cmd.SetArgs([]string{
+		"-t", "testdata/golden.topo",
+		"-o", dir,
+		"--isd-dir",
+		"--as-validity", asValidity,
+	})
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	require.NoError(t, cmd.Execute(), buf.String())
+	return dir
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := genCrypto(t, "3d")
+	invalid := filepath.Join(dir, "ISD1", "invalid.pem")
+	require.NoError(t, os.WriteFile(invalid, []byte("garbage"), 0o644))
+
+	res, err := expiry.LoadDir(dir, time.Now())
+	require.NoError(t, err)
+	assert.Contains(t, res.Ignored, invalid)
+
+	kinds := map[expiry.Kind][]string{}
+	for _, e := range expiry.Latest(res.Entries) {
+		kinds[e.Kind] = append(kinds[e.Kind], e.Subject)
+	}
+	assert.ElementsMatch(t, []string{"1-ff00:0:110", "1-ff00:0:111", "1-ff00:0:112"},
+		kinds[expiry.KindAS])
+	assert.Contains(t, kinds[expiry.KindCA], "1-ff00:0:110")
+	assert.Equal(t, []string{"1-ff00:0:110"}, kinds[expiry.KindRoot])
+	assert.Equal(t, []string{"1-ff00:0:110"}, kinds[expiry.KindSensitive])
+	assert.Equal(t, []string{"1-ff00:0:110"}, kinds[expiry.KindRegular])
+	assert.Equal(t, []string{"1"}, kinds[expiry.KindTRC])
+
+	_, err = expiry.LoadDir(filepath.Join(dir, "missing"), time.Now())
+	assert.Error(t, err)
+}
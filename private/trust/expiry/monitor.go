@@ -0,0 +1,178 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expiry
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scrypto"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/trust"
+)
+
+// Metrics are the metrics exported by the monitor.
+type Metrics struct {
+	// Remaining is the remaining validity in seconds. It is labeled with the
+	// type and the subject of the trust material. The label sets of the trust
+	// material that is no longer found are deleted, so that stale values do
+	// not keep alerts firing. If nil, no metrics are exported.
+	Remaining *prometheus.GaugeVec
+}
+
+// Monitor periodically scans the trust material of the local AS, exports the
+// remaining validity as metrics, and logs a message whenever the level of an
+// entry escalates. Monitor implements periodic.Task.
+type Monitor struct {
+	// IA is the local ISD-AS. The certificate chains of the local AS and the
+	// latest TRC of the local ISD are looked up in the DB.
+	IA addr.IA
+	// DB is the trust database.
+	DB trust.DB
+	// Dirs are additionally scanned for certificates and TRCs, e.g., the
+	// directory that holds the CA certificates.
+	Dirs []string
+	// Policy defines the thresholds. If nil, the default policy is used.
+	Policy  Policy
+	Metrics Metrics
+
+	mtx     sync.Mutex
+	results []Result
+	levels  map[string]Level
+	// exported are the label values of the exported gauges.
+	exported map[[2]string]struct{}
+}
+
+// Name returns the task name.
+func (m *Monitor) Name() string {
+	return "trust_expiry_monitor"
+}
+
+// Run scans the trust material once.
+func (m *Monitor) Run(ctx context.Context) {
+	logger := log.FromCtx(ctx)
+	now := time.Now()
+	entries, scanErr := m.scan(ctx, now)
+	if scanErr != nil {
+		logger.Info("Incomplete scan of trust material for expiry", "err", scanErr)
+	}
+	policy := m.Policy
+	if policy == nil {
+		policy = DefaultPolicy()
+	}
+	results := policy.Evaluate(Latest(entries), now)
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	levels := make(map[string]Level, len(results))
+	exported := make(map[[2]string]struct{}, len(results))
+	for _, r := range results {
+		if m.Metrics.Remaining != nil {
+			labels := [2]string{string(r.Kind), r.Subject}
+			m.Metrics.Remaining.WithLabelValues(labels[:]...).Set(r.Remaining.Seconds())
+			exported[labels] = struct{}{}
+		}
+
+		key := string(r.Kind) + " " + r.Subject
+		levels[key] = r.Level
+		if prev, ok := m.levels[key]; ok && prev >= r.Level {
+			continue
+		}
+		ctx := []any{
+			"type", r.Kind,
+			"subject", r.Subject,
+			"id", r.ID,
+			"not_after", r.NotAfter,
+			"remaining", r.Remaining.Truncate(time.Second),
+		}
+		switch r.Level {
+		case LevelWarning:
+			logger.Info("Trust material is close to expiration", ctx...)
+		case LevelCritical:
+			logger.Error("Trust material is about to expire", ctx...)
+		case LevelExpired:
+			logger.Error("Trust material has expired", ctx...)
+		}
+	}
+	m.levels = levels
+	m.results = results
+
+	// Delete the gauges of the trust material that is gone, e.g., a TRC whose
+	// grace period ended. After an incomplete scan, the missing entries might
+	// still exist, and their last values are kept until the next full scan.
+	for labels := range m.exported {
+		if _, ok := exported[labels]; ok {
+			continue
+		}
+		if scanErr != nil {
+			exported[labels] = struct{}{}
+			continue
+		}
+		m.Metrics.Remaining.DeleteLabelValues(labels[:]...)
+	}
+	m.exported = exported
+}
+
+// Results returns the results of the last scan.
+func (m *Monitor) Results() []Result {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return append([]Result(nil), m.results...)
+}
+
+func (m *Monitor) scan(ctx context.Context, now time.Time) ([]Entry, error) {
+	var entries []Entry
+	var errs serrors.List
+	if m.DB != nil {
+		chains, err := m.DB.Chains(ctx, trust.ChainQuery{IA: m.IA})
+		if err != nil {
+			errs = append(errs, serrors.Wrap("looking up certificate chains", err))
+		}
+		for _, chain := range chains {
+			e, err := FromCertificates(chain, "trust_db")
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			entries = append(entries, e...)
+		}
+		trc, err := m.DB.SignedTRC(ctx, cppki.TRCID{
+			ISD:    m.IA.ISD(),
+			Base:   scrypto.LatestVer,
+			Serial: scrypto.LatestVer,
+		})
+		switch {
+		case err != nil:
+			errs = append(errs, serrors.Wrap("looking up latest TRC", err))
+		case !trc.IsZero():
+			entries = append(entries, FromTRC(&trc.TRC, "trust_db", now)...)
+		}
+	}
+	for _, dir := range m.Dirs {
+		res, err := LoadDir(dir, now)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		entries = append(entries, res.Entries...)
+	}
+	return entries, errs.ToError()
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expiry_test
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/trust"
+	"github.com/scionproto/scion/private/trust/expiry"
+	"github.com/scionproto/scion/private/trust/mock_trust"
+)
+
+func TestMonitor(t *testing.T) {
+	dir := genCrypto(t, "1h")
+	ia := addr.MustParseIA("1-ff00:0:111")
+
+	chain, err := cppki.ReadPEMCerts(
+		filepath.Join(dir, "ISD1/ASff00_0_111/crypto/as/ISD1-ASff00_0_111.pem"))
+	require.NoError(t, err)
+	raw, err := os.ReadFile(filepath.Join(dir, "ISD1/trcs/ISD1-B1-S1.trc"))
+	require.NoError(t, err)
+	block, _ := pem.Decode(raw)
+	require.NotNil(t, block)
+	trc, err := cppki.DecodeSignedTRC(block.Bytes)
+	require.NoError(t, err)
+
+	t.Run("scan", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		db := mock_trust.NewMockDB(ctrl)
+		db.EXPECT().Chains(gomock.Any(), trust.ChainQuery{IA: ia}).
+			Return([][]*x509.Certificate{chain}, nil).Times(2)
+		db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil).Times(2)
+
+		remaining := newRemainingGauge()
+		m := &expiry.Monitor{
+			IA:      ia,
+			DB:      db,
+			Dirs:    []string{filepath.Join(dir, "ISD1/ASff00_0_110/crypto/ca")},
+			Metrics: expiry.Metrics{Remaining: remaining},
+		}
+		m.Run(context.Background())
+		m.Run(context.Background())
+
+		levels := map[expiry.Kind]expiry.Level{}
+		for _, r := range m.Results() {
+			levels[r.Kind] = r.Level
+		}
+		assert.Equal(t, map[expiry.Kind]expiry.Level{
+			expiry.KindAS:   expiry.LevelCritical,
+			expiry.KindCA:   expiry.LevelOK,
+			expiry.KindRoot: expiry.LevelOK,
+			expiry.KindTRC:  expiry.LevelOK,
+		}, levels)
+		asRemaining := testutil.ToFloat64(
+			remaining.WithLabelValues(string(expiry.KindAS), ia.String()))
+		assert.InDelta(t, 3600, asRemaining, 60)
+	})
+	t.Run("stale gauges", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		db := mock_trust.NewMockDB(ctrl)
+		chains := db.EXPECT().Chains(gomock.Any(), trust.ChainQuery{IA: ia}).
+			Return([][]*x509.Certificate{chain}, nil).Times(2)
+		db.EXPECT().Chains(gomock.Any(), trust.ChainQuery{IA: ia}).
+			Return(nil, serrors.New("internal")).After(chains)
+		db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil).Times(3)
+
+		remaining := newRemainingGauge()
+		m := &expiry.Monitor{
+			IA:      ia,
+			DB:      db,
+			Dirs:    []string{filepath.Join(dir, "ISD1/ASff00_0_110/crypto/ca")},
+			Metrics: expiry.Metrics{Remaining: remaining},
+		}
+		m.Run(context.Background())
+		before := labelSets(m.Results())
+		require.Equal(t, before, testutil.CollectAndCount(remaining))
+
+		// The CA directory is no longer scanned, the gauges of the material
+		// that was only found there are deleted.
+		m.Dirs = nil
+		m.Run(context.Background())
+		after := labelSets(m.Results())
+		require.Less(t, after, before)
+		assert.Equal(t, after, testutil.CollectAndCount(remaining))
+
+		// After an incomplete scan, the gauges of the missing chain are kept.
+		m.Run(context.Background())
+		assert.Equal(t, after, testutil.CollectAndCount(remaining))
+	})
+	t.Run("db error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		db := mock_trust.NewMockDB(ctrl)
+		db.EXPECT().Chains(gomock.Any(), gomock.Any()).Return(nil, serrors.New("internal"))
+		db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil)
+
+		m := &expiry.Monitor{IA: ia, DB: db}
+		m.Run(context.Background())
+		results := m.Results()
+		require.Len(t, results, 1)
+		assert.Equal(t, expiry.KindTRC, results[0].Kind)
+	})
+}
+
+func newRemainingGauge() *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "test_expiry_remaining_seconds",
+	}, []string{"type", "subject"})
+}
+
+// labelSets returns the number of distinct label sets of the results.
+func labelSets(results []expiry.Result) int {
+	sets := map[[2]string]struct{}{}
+	for _, r := range results {
+		sets[[2]string{string(r.Kind), r.Subject}] = struct{}{}
+	}
+	return len(sets)
+}
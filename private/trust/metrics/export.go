@@ -33,4 +33,11 @@ var (
 		},
 		[]string{"type", prom.LabelResult},
 	)
+	ExpiryRemainingSeconds = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "trustengine_expiry_remaining_seconds",
+			Help: "Remaining validity of the local trust material in seconds.",
+		},
+		[]string{"type", "subject"},
+	)
 )
@@ -110,3 +110,52 @@ func betweenOrEqual(t *testing.T, lower, upper, v float64) {
 	assert.GreaterOrEqual(t, v, lower)
 	assert.LessOrEqual(t, v, upper)
 }
+
+func TestMetricsClose(t *testing.T) {
+	up := prometheus.NewGauge(prometheus.GaugeOpts{Name: "up"})
+	sessionA := &bfd.Session{
+		DetectMult:            1,
+		DesiredMinTxInterval:  50 * time.Millisecond,
+		RequiredMinRxInterval: 50 * time.Millisecond,
+		LocalDiscriminator:    1,
+		ReceiveQueueSize:      10,
+		Metrics:               bfd.Metrics{Up: up},
+	}
+	sessionB := &bfd.Session{
+		DetectMult:            1,
+		DesiredMinTxInterval:  50 * time.Millisecond,
+		RequiredMinRxInterval: 50 * time.Millisecond,
+		LocalDiscriminator:    2,
+		ReceiveQueueSize:      10,
+	}
+	linkAToB := &redirectSender{Destination: sessionB}
+	linkBToA := &redirectSender{Destination: sessionA}
+	sessionA.Sender = linkAToB
+	sessionB.Sender = linkBToA
+
+	doneA := make(chan struct{})
+	go func() {
+		defer close(doneA)
+		assert.NoError(t, sessionA.Run(context.Background()))
+	}()
+	doneB := make(chan struct{})
+	go func() {
+		defer close(doneB)
+		assert.NoError(t, sessionB.Run(context.Background()))
+	}()
+	linkAToB.Sending(true)
+	linkBToA.Sending(true)
+	require.Eventually(t, func() bool { return promtest.ToFloat64(up) == 1.0 },
+		5*time.Second, 10*time.Millisecond)
+
+	// Tearing down the session reports the link as down, even though the peer is still up.
+	linkBToA.Sending(false)
+	require.NoError(t, sessionA.Close())
+	require.NoError(t, sessionA.Close())
+	<-doneA
+	assert.Equal(t, 0.0, promtest.ToFloat64(up))
+	assert.False(t, sessionA.IsUp())
+
+	linkAToB.Close()
+	<-doneB
+}
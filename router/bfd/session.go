@@ -126,6 +126,7 @@ type Session struct {
 	ReceiveQueueSize int
 
 	messagesOnce sync.Once
+	closeOnce    sync.Once
 	// messages is the channel on which the session receives BFD packets.
 	messages chan bfdMessage
 
@@ -314,12 +315,21 @@ MainLoop:
 			}
 		}
 	}
+	// The link is no longer monitored, so it must not keep being reported as up.
+	s.setLocalState(stateDown)
+	if s.Metrics.Up != nil {
+		s.Metrics.Up.Set(0)
+	}
 	return nil
 }
 
+// Close stops the session. Run returns once the messages queued before have been processed.
+// Calling Close more than once has no effect. ReceiveMessage must not be called afterwards.
 func (s *Session) Close() error {
 	s.initMessages()
-	close(s.messages)
+	s.closeOnce.Do(func() {
+		close(s.messages)
+	})
 	return nil
 }
 
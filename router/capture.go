@@ -0,0 +1,240 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/router/control"
+)
+
+const (
+	// maxCaptures is the maximum number of concurrent captures.
+	maxCaptures = 4
+	// captureQueueSize is the number of packets that a capture can buffer.
+	captureQueueSize = 256
+)
+
+var errTooManyCaptures = errors.New("too many concurrent captures")
+
+// linkScopes maps the link scopes to their names in the control API.
+var linkScopes = [...]control.LinkScope{
+	Internal: control.ScopeInternal,
+	Sibling:  control.ScopeSibling,
+	External: control.ScopeExternal,
+}
+
+// packetCapture is a running capture. Its records are preallocated and circulate between the free
+// and the ready queues. The processors take free records, fill them, and put them on the ready
+// queue. The consumer takes them from the ready queue and returns them to the free queue. If the
+// consumer is too slow, the free queue runs dry and the packets are not captured.
+type packetCapture struct {
+	d       *dataPlane
+	filter  control.CaptureFilter
+	snapLen int
+	free    chan *control.CapturedPacket
+	ready   chan *control.CapturedPacket
+	last    *control.CapturedPacket // The record handed out by Next.
+	lost    atomic.Uint64
+}
+
+// captureSet is the set of running captures. It is never modified; it is replaced as a whole
+// when a capture starts or stops. So, processors can use it without locking.
+type captureSet struct {
+	captures []*packetCapture
+}
+
+// stagedCaptures holds the records that a processor filled for one packet, before it knows the
+// disposition of that packet. It lives on the processor's stack.
+type stagedCaptures struct {
+	n       int
+	records [maxCaptures]stagedCapture
+}
+
+type stagedCapture struct {
+	c   *packetCapture
+	rec *control.CapturedPacket
+}
+
+// StartCapture starts capturing the packets that match the given filter. Packets are captured
+// when the processors have decided their disposition. Packets dropped by the underlay before
+// reaching a processor are not captured.
+func (d *dataPlane) StartCapture(
+	filter control.CaptureFilter, snapLen int,
+) (control.PacketCapture, error) {
+
+	if filter.Reason != "" {
+		if _, ok := parseDropReason(filter.Reason); !ok {
+			return nil, serrors.JoinNoStack(control.ErrUnknownDropReason, nil,
+				"reason", filter.Reason)
+		}
+	}
+	if snapLen <= 0 || snapLen > bufSize {
+		snapLen = bufSize
+	}
+	c := &packetCapture{
+		d:       d,
+		filter:  filter,
+		snapLen: snapLen,
+		free:    make(chan *control.CapturedPacket, captureQueueSize),
+		ready:   make(chan *control.CapturedPacket, captureQueueSize),
+	}
+	for i := 0; i < captureQueueSize; i++ {
+		c.free <- &control.CapturedPacket{Data: make([]byte, 0, snapLen)}
+	}
+
+	d.captureMtx.Lock()
+	defer d.captureMtx.Unlock()
+	var running []*packetCapture
+	if cs := d.captures.Load(); cs != nil {
+		running = cs.captures
+	}
+	if len(running) >= maxCaptures {
+		return nil, errTooManyCaptures
+	}
+	d.captures.Store(&captureSet{captures: append(slices.Clone(running), c)})
+	return c, nil
+}
+
+// Next returns the next captured packet.
+func (c *packetCapture) Next(ctx context.Context) (*control.CapturedPacket, error) {
+	if c.last != nil {
+		c.free <- c.last
+		c.last = nil
+	}
+	select {
+	case rec := <-c.ready:
+		c.last = rec
+		return rec, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close stops the capture. The processors may still fill a few records, which are never read.
+func (c *packetCapture) Close() {
+	c.d.captureMtx.Lock()
+	defer c.d.captureMtx.Unlock()
+	cs := c.d.captures.Load()
+	if cs == nil {
+		return
+	}
+	remaining := slices.DeleteFunc(slices.Clone(cs.captures), func(x *packetCapture) bool {
+		return x == c
+	})
+	if len(remaining) == 0 {
+		c.d.captures.Store(nil)
+		return
+	}
+	c.d.captures.Store(&captureSet{captures: remaining})
+}
+
+// Lost returns the number of matching packets that were not captured.
+func (c *packetCapture) Lost() uint64 {
+	return c.lost.Load()
+}
+
+// SnapLen returns the maximum number of bytes kept of each packet.
+func (c *packetCapture) SnapLen() int {
+	return c.snapLen
+}
+
+// stage copies the given packet into a record of each capture whose filter matches it, as far as
+// can be known before the packet's disposition is. The egress link is nil if it hasn't been
+// determined.
+func (cs *captureSet) stage(p *Packet, egressLink Link, egress uint16, staged *stagedCaptures) {
+	ingress := p.Link.IfID()
+	ingressScope := linkScopes[p.Link.Scope()]
+	var egressScope control.LinkScope
+	if egressLink != nil {
+		egressScope = linkScopes[egressLink.Scope()]
+	}
+	var now time.Time
+	for _, c := range cs.captures {
+		f := &c.filter
+		if f.IfID != nil && *f.IfID != ingress && (egressLink == nil || *f.IfID != egress) {
+			continue
+		}
+		if f.Scope != "" && f.Scope != ingressScope && f.Scope != egressScope {
+			continue
+		}
+		var rec *control.CapturedPacket
+		select {
+		case rec = <-c.free:
+		default:
+			c.lost.Add(1)
+			continue
+		}
+		if now.IsZero() {
+			now = time.Now()
+		}
+		rec.Time = now
+		rec.Ingress = ingress
+		rec.IngressScope = ingressScope
+		rec.Egress = egress
+		rec.EgressScope = egressScope
+		rec.Length = len(p.RawPacket)
+		rec.Data = append(rec.Data[:0], p.RawPacket[:min(len(p.RawPacket), c.snapLen)]...)
+		staged.records[staged.n] = stagedCapture{c: c, rec: rec}
+		staged.n++
+	}
+}
+
+// publish delivers the staged records to their capture, if the disposition matches the filter.
+func (s *stagedCaptures) publish(disp control.Disposition, reason DropReason) {
+	for i := 0; i < s.n; i++ {
+		c, rec := s.records[i].c, s.records[i].rec
+		f := &c.filter
+		if (f.Disposition != "" && f.Disposition != disp) ||
+			(f.Reason != "" && f.Reason != reason.String()) {
+
+			c.free <- rec
+			continue
+		}
+		rec.Disposition = disp
+		rec.Reason = reason.String()
+		// The ready queue can hold all the records, so this never blocks.
+		c.ready <- rec
+	}
+	s.n = 0
+}
+
+// stageCapture stages the packet with all running captures, if any. The egress link is nil if it
+// hasn't been determined.
+func (d *dataPlane) stageCapture(
+	p *Packet, egressLink Link, egress uint16, staged *stagedCaptures,
+) {
+	if cs := d.captures.Load(); cs != nil {
+		cs.stage(p, egressLink, egress, staged)
+	}
+}
+
+// capture reports the packet, with the given disposition, to all running captures, if any.
+func (d *dataPlane) capture(
+	p *Packet, egressLink Link, egress uint16, disp control.Disposition, reason DropReason,
+) {
+	cs := d.captures.Load()
+	if cs == nil {
+		return
+	}
+	var staged stagedCaptures
+	cs.stage(p, egressLink, egress, &staged)
+	staged.publish(disp, reason)
+}
@@ -0,0 +1,171 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/private/ptr"
+	"github.com/scionproto/scion/router/control"
+)
+
+// externalMockLink is a mock link with the External scope.
+type externalMockLink struct {
+	MockLink
+}
+
+func (l *externalMockLink) Scope() LinkScope { return External }
+
+// nextCaptured returns the next captured packet, or nil if there is none.
+func nextCaptured(t *testing.T, c control.PacketCapture) *control.CapturedPacket {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	p, err := c.Next(ctx)
+	if err != nil {
+		return nil
+	}
+	return p
+}
+
+func TestCaptureFilter(t *testing.T) {
+	d := &dataPlane{}
+	all, err := d.StartCapture(control.CaptureFilter{}, 4)
+	require.NoError(t, err)
+	defer all.Close()
+	dropped, err := d.StartCapture(control.CaptureFilter{Disposition: control.Dropped}, 0)
+	require.NoError(t, err)
+	defer dropped.Close()
+	egress2, err := d.StartCapture(control.CaptureFilter{IfID: ptr.To[uint16](2)}, 0)
+	require.NoError(t, err)
+	defer egress2.Close()
+	external, err := d.StartCapture(control.CaptureFilter{Scope: control.ScopeExternal}, 0)
+	require.NoError(t, err)
+	defer external.Close()
+
+	raw := []byte("0123456789")
+	egressLink := &externalMockLink{MockLink{ifID: 2}}
+
+	// Forwarded to interface 2.
+	d.capture(NewPacket(raw, nil, nil, 0, 2), egressLink, 2, control.Forwarded, dropNone)
+	p := nextCaptured(t, all)
+	require.NotNil(t, p)
+	assert.Equal(t, []byte("0123"), p.Data)
+	assert.Equal(t, len(raw), p.Length)
+	assert.Equal(t, uint16(0), p.Ingress)
+	assert.Equal(t, control.ScopeInternal, p.IngressScope)
+	assert.Equal(t, uint16(2), p.Egress)
+	assert.Equal(t, control.ScopeExternal, p.EgressScope)
+	assert.Equal(t, control.Forwarded, p.Disposition)
+	assert.Nil(t, nextCaptured(t, dropped))
+	p = nextCaptured(t, egress2)
+	require.NotNil(t, p)
+	assert.Equal(t, raw, p.Data)
+	assert.NotNil(t, nextCaptured(t, external))
+
+	// Dropped before the egress interface is known.
+	d.capture(NewPacket(raw, nil, nil, 0, 0), nil, 0, control.Dropped, DropInvalid)
+	assert.NotNil(t, nextCaptured(t, all))
+	p = nextCaptured(t, dropped)
+	require.NotNil(t, p)
+	assert.Equal(t, "invalid", p.Reason)
+	assert.Equal(t, control.LinkScope(""), p.EgressScope)
+	assert.Nil(t, nextCaptured(t, egress2))
+	assert.Nil(t, nextCaptured(t, external))
+}
+
+func TestCaptureStaged(t *testing.T) {
+	d := &dataPlane{}
+	c, err := d.StartCapture(control.CaptureFilter{Reason: "busy_forwarder"}, 0)
+	require.NoError(t, err)
+	defer c.Close()
+
+	// The packet is copied when staged, so later changes to it are not captured.
+	pkt := NewPacket([]byte("abc"), nil, nil, 0, 1)
+	var staged stagedCaptures
+	d.stageCapture(pkt, nil, 1, &staged)
+	assert.Equal(t, 1, staged.n)
+	copy(pkt.RawPacket, "xyz")
+	staged.publish(control.Dropped, DropBusyForwarder)
+	assert.Equal(t, 0, staged.n)
+	p := nextCaptured(t, c)
+	require.NotNil(t, p)
+	assert.Equal(t, []byte("abc"), p.Data)
+
+	// Records that don't match the disposition are recycled.
+	for i := 0; i < 2*captureQueueSize; i++ {
+		d.stageCapture(pkt, nil, 1, &staged)
+		staged.publish(control.Forwarded, dropNone)
+	}
+	assert.Zero(t, c.Lost())
+	assert.Nil(t, nextCaptured(t, c))
+}
+
+func TestCaptureLost(t *testing.T) {
+	d := &dataPlane{}
+	c, err := d.StartCapture(control.CaptureFilter{}, 0)
+	require.NoError(t, err)
+	defer c.Close()
+
+	pkt := NewPacket([]byte("abc"), nil, nil, 0, 0)
+	for i := 0; i < captureQueueSize+3; i++ {
+		d.capture(pkt, nil, 0, control.SlowPath, dropNone)
+	}
+	assert.Equal(t, uint64(3), c.Lost())
+	for i := 0; i < captureQueueSize; i++ {
+		require.NotNil(t, nextCaptured(t, c))
+	}
+	assert.Nil(t, nextCaptured(t, c))
+}
+
+func TestCaptureLimit(t *testing.T) {
+	d := &dataPlane{}
+	var captures []control.PacketCapture
+	for i := 0; i < maxCaptures; i++ {
+		c, err := d.StartCapture(control.CaptureFilter{}, 0)
+		require.NoError(t, err)
+		captures = append(captures, c)
+	}
+	_, err := d.StartCapture(control.CaptureFilter{}, 0)
+	assert.ErrorIs(t, err, errTooManyCaptures)
+
+	captures[0].Close()
+	c, err := d.StartCapture(control.CaptureFilter{}, 0)
+	require.NoError(t, err)
+	c.Close()
+	for _, c := range captures[1:] {
+		c.Close()
+	}
+	assert.Nil(t, d.captures.Load())
+}
+
+func TestStartCapture(t *testing.T) {
+	d := &dataPlane{}
+	_, err := d.StartCapture(control.CaptureFilter{Reason: "bad_luck"}, 0)
+	assert.ErrorIs(t, err, control.ErrUnknownDropReason)
+	assert.Nil(t, d.captures.Load())
+
+	// The snap length is clamped to the size of the packet buffers.
+	for snapLen, expected := range map[int]int{0: bufSize, 16: 16, bufSize + 1: bufSize} {
+		c, err := d.StartCapture(control.CaptureFilter{Reason: "rate_limited"}, snapLen)
+		require.NoError(t, err)
+		assert.Equal(t, expected, c.SnapLen())
+		c.Close()
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"net/http"
 	_ "net/http/pprof"
+	"path/filepath"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
@@ -32,12 +33,13 @@ import (
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/private/app"
 	"github.com/scionproto/scion/private/app/launcher"
+	"github.com/scionproto/scion/private/keyconf"
 	"github.com/scionproto/scion/private/service"
-	"github.com/scionproto/scion/private/topology"
 	"github.com/scionproto/scion/router"
 	"github.com/scionproto/scion/router/config"
 	"github.com/scionproto/scion/router/control"
 	api "github.com/scionproto/scion/router/mgmtapi"
+	_ "github.com/scionproto/scion/router/underlayproviders/afpacketudpip"
 	_ "github.com/scionproto/scion/router/underlayproviders/udpip"
 )
 
@@ -72,7 +74,7 @@ func realMain(ctx context.Context) error {
 		"info":      service.NewInfoStatusPage(),
 		"config":    service.NewConfigStatusPage(globalCfg),
 		"log/level": service.NewLogLevelStatusPage(),
-		"topology":  topologyHandler(iaCtx.Config.Topo),
+		"topology":  topologyHandler(iaCtx),
 	}
 	if err := statusPages.Register(http.DefaultServeMux, globalCfg.General.ID); err != nil {
 		return err
@@ -98,7 +100,12 @@ func realMain(ctx context.Context) error {
 			Info:      service.NewInfoStatusPage().Handler,
 			LogLevel:  service.NewLogLevelStatusPage().Handler,
 			Dataplane: dp,
+			Tunables:  dp,
 		}
+		if globalCfg.Router.EnableCapture {
+			server.Capturer = dp
+		}
+		server.Reloader = topologyReloader{iaCtx: iaCtx}
 		log.Info("Exposing API", "addr", globalCfg.API.Addr)
 		h := api.HandlerFromMuxWithBaseURL(&server, r, "/api/v1")
 		mgmtServer := &http.Server{
@@ -119,6 +126,19 @@ func realMain(ctx context.Context) error {
 		defer log.HandlePanic()
 		return globalCfg.Metrics.ServePrometheus(errCtx)
 	})
+	g.Go(func() error {
+		defer log.HandlePanic()
+		sighup := app.SIGHUPChannel(errCtx)
+		for {
+			select {
+			case <-sighup:
+				log.Info("Received SIGHUP, reloading keys and interfaces")
+				reload(iaCtx)
+			case <-errCtx.Done():
+				return nil
+			}
+		}
+	})
 	g.Go(func() error {
 		defer log.HandlePanic()
 		if err := dp.DataPlane.Run(errCtx); err != nil {
@@ -135,14 +155,56 @@ func loadControlConfig() (*control.Config, error) {
 	if err != nil {
 		return nil, serrors.Wrap("loading topology", err)
 	}
+	newConf.KeyGracePeriod = globalCfg.Router.KeyGracePeriod.Duration
+	for _, k := range globalCfg.Router.GraceKeys {
+		file := k.File
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(globalCfg.General.ConfigDir, "keys", file)
+		}
+		master, err := keyconf.LoadMasterKey(file)
+		if err != nil {
+			return nil, serrors.Wrap("loading grace key", err, "file", file)
+		}
+		newConf.GraceKeys = append(newConf.GraceKeys,
+			control.GraceKey{Master: master, NotAfter: k.NotAfter})
+	}
 	return newConf, nil
 }
 
-func topologyHandler(topo topology.Topology) service.StatusPage {
+// reload reloads the master keys and the interfaces. The keys are reloaded first, so that the
+// new key is accepted as soon as possible.
+func reload(iaCtx *control.IACtx) {
+	newConf, err := loadControlConfig()
+	if err != nil {
+		log.Error("Reloading configuration failed", "err", err)
+		return
+	}
+	if _, err := iaCtx.ReloadKeys(newConf); err != nil {
+		log.Error("Reloading keys failed", "err", err)
+	}
+	if _, err := iaCtx.Reload(newConf); err != nil {
+		log.Error("Reloading interfaces failed", "err", err)
+	}
+}
+
+// topologyReloader reloads the interfaces from the topology file.
+type topologyReloader struct {
+	iaCtx *control.IACtx
+}
+
+func (r topologyReloader) ReloadInterfaces() (control.InterfaceChanges, error) {
+	newConf, err := loadControlConfig()
+	if err != nil {
+		return control.InterfaceChanges{}, err
+	}
+	return r.iaCtx.Reload(newConf)
+}
+
+func topologyHandler(iaCtx *control.IACtx) service.StatusPage {
 	handler := func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 
-		bytes, err := json.MarshalIndent(topo, "", "    ")
+		bytes, err := json.MarshalIndent(iaCtx.CurrentConfig().Topo, "", "    ")
 		if err != nil {
 			http.Error(w, "Unable to marshal topology", http.StatusInternalServerError)
 			return
@@ -25,6 +25,7 @@ import (
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/util"
+	"github.com/scionproto/scion/pkg/slayers/path"
 	"github.com/scionproto/scion/private/config"
 	"github.com/scionproto/scion/private/env"
 	api "github.com/scionproto/scion/private/mgmtapi"
@@ -48,6 +49,17 @@ type RouterConfig struct {
 	NumSlowPathProcessors int `toml:"num_slow_processors,omitempty"`
 	BatchSize             int `toml:"batch_size,omitempty"`
 	BFD                   BFD `toml:"bfd,omitempty"`
+	// RateLimits are the initial rate limits of the external interfaces. They can be changed
+	// at run time through the http API.
+	RateLimits []RateLimit `toml:"rate_limits,omitempty"`
+	// EnableCapture enables the packet capture endpoint of the http API.
+	EnableCapture bool `toml:"enable_capture,omitempty"`
+	// KeyGracePeriod is how long the former master key is still accepted for hop field MAC
+	// verification after a key rollover by reload.
+	KeyGracePeriod util.DurWrap `toml:"key_grace_period,omitempty"`
+	// GraceKeys are former master keys that are still accepted for hop field MAC verification,
+	// each until its own time.
+	GraceKeys []GraceKey `toml:"grace_keys,omitempty"`
 	// TODO: These two values were introduced to override the port range for
 	// configured router in the context of acceptance tests. However, this
 	// introduces two sources for the port configuration. We should remove this
@@ -65,6 +77,32 @@ type BFD struct {
 	RequiredMinRxInterval util.DurWrap `toml:"required_min_rx_interval,omitempty"`
 }
 
+// RateLimit configures a token-bucket limit on the traffic of one external interface in one
+// direction.
+type RateLimit struct {
+	// Interface is the ID of the external interface.
+	Interface uint16 `toml:"interface"`
+	// Direction is either "ingress" or "egress".
+	Direction string `toml:"direction"`
+	// TrafficClass restricts the limit to the packets with the given SCION traffic class. If
+	// unset, the limit applies to all the traffic of the interface in that direction.
+	TrafficClass *uint8 `toml:"traffic_class,omitempty"`
+	// Rate is the sustained rate in bits per second.
+	Rate uint64 `toml:"rate"`
+	// Burst is the size of the bucket in bytes. If unset, the router picks 100ms worth of
+	// traffic at Rate, but no less than the largest possible packet.
+	Burst uint64 `toml:"burst,omitempty"`
+}
+
+// GraceKey is a former master key that is still accepted for hop field MAC verification.
+type GraceKey struct {
+	// File is the file of the key. A relative path is relative to the keys directory of the
+	// configuration.
+	File string `toml:"file"`
+	// NotAfter is the time after which the key is no longer accepted.
+	NotAfter time.Time `toml:"not_after"`
+}
+
 func (cfg *RouterConfig) ConfigName() string {
 	return "router"
 }
@@ -106,6 +144,58 @@ func (cfg *RouterConfig) Validate() error {
 				"EndHostStartPort is nil; EndHostEndPort isn't")
 		}
 	}
+	if cfg.KeyGracePeriod.Duration < 0 {
+		return serrors.New("provided router config is invalid. KeyGracePeriod < 0")
+	}
+	if err := validateGraceKeys(cfg.GraceKeys); err != nil {
+		return err
+	}
+	return validateRateLimits(cfg.RateLimits)
+}
+
+func validateGraceKeys(keys []GraceKey) error {
+	for _, k := range keys {
+		if k.File == "" {
+			return serrors.New("provided router config is invalid. GraceKey file is empty")
+		}
+		if k.NotAfter.IsZero() {
+			return serrors.New("provided router config is invalid. GraceKey not_after is unset",
+				"file", k.File)
+		}
+	}
+	return nil
+}
+
+func validateRateLimits(limits []RateLimit) error {
+	type key struct {
+		intf      uint16
+		direction string
+		class     int
+	}
+	seen := make(map[key]struct{}, len(limits))
+	for _, l := range limits {
+		if l.Interface == 0 {
+			return serrors.New("provided router config is invalid. RateLimit interface is 0")
+		}
+		if l.Direction != "ingress" && l.Direction != "egress" {
+			return serrors.New("provided router config is invalid. "+
+				"RateLimit direction must be ingress or egress",
+				"interface", l.Interface, "direction", l.Direction)
+		}
+		if l.Rate == 0 {
+			return serrors.New("provided router config is invalid. RateLimit rate is 0",
+				"interface", l.Interface, "direction", l.Direction)
+		}
+		k := key{intf: l.Interface, direction: l.Direction, class: -1}
+		if l.TrafficClass != nil {
+			k.class = int(*l.TrafficClass)
+		}
+		if _, ok := seen[k]; ok {
+			return serrors.New("provided router config is invalid. Duplicate RateLimit",
+				"interface", l.Interface, "direction", l.Direction)
+		}
+		seen[k] = struct{}{}
+	}
 	return nil
 }
 
@@ -147,6 +237,10 @@ func (cfg *RouterConfig) InitDefaults() {
 	if cfg.BFD.RequiredMinRxInterval.Duration == 0 {
 		cfg.BFD.RequiredMinRxInterval = util.DurWrap{Duration: 200 * time.Millisecond}
 	}
+	if cfg.KeyGracePeriod.Duration == 0 {
+		// Hop fields made with the previous key expire after at most that long.
+		cfg.KeyGracePeriod = util.DurWrap{Duration: path.MaxTTL}
+	}
 }
 
 func (cfg *RouterConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
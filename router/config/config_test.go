@@ -17,9 +17,11 @@ package config_test
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/pelletier/go-toml/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/pkg/log/logtest"
 	"github.com/scionproto/scion/private/env/envtest"
@@ -38,6 +40,139 @@ func TestConfigSample(t *testing.T) {
 	CheckTestConfig(t, &cfg, config.IDSample)
 }
 
+func TestRateLimits(t *testing.T) {
+	testCases := map[string]struct {
+		limits    string
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			limits: `
+[[router.rate_limits]]
+interface = 1
+direction = "ingress"
+rate = 100000000
+
+[[router.rate_limits]]
+interface = 1
+direction = "ingress"
+traffic_class = 184
+rate = 1000000
+burst = 9000
+
+[[router.rate_limits]]
+interface = 1
+direction = "egress"
+rate = 100000000
+`,
+			assertErr: assert.NoError,
+		},
+		"bad direction": {
+			limits: `
+[[router.rate_limits]]
+interface = 1
+direction = "both"
+rate = 100000000
+`,
+			assertErr: assert.Error,
+		},
+		"internal interface": {
+			limits: `
+[[router.rate_limits]]
+interface = 0
+direction = "ingress"
+rate = 100000000
+`,
+			assertErr: assert.Error,
+		},
+		"zero rate": {
+			limits: `
+[[router.rate_limits]]
+interface = 1
+direction = "ingress"
+rate = 0
+`,
+			assertErr: assert.Error,
+		},
+		"duplicate": {
+			limits: `
+[[router.rate_limits]]
+interface = 1
+direction = "ingress"
+traffic_class = 0
+rate = 100000000
+
+[[router.rate_limits]]
+interface = 1
+direction = "ingress"
+traffic_class = 0
+rate = 1000000
+`,
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var cfg config.Config
+			err := toml.NewDecoder(bytes.NewReader([]byte(tc.limits))).
+				DisallowUnknownFields().Decode(&cfg)
+			require.NoError(t, err)
+			cfg.Router.InitDefaults()
+			tc.assertErr(t, cfg.Router.Validate())
+		})
+	}
+}
+
+func TestGraceKeys(t *testing.T) {
+	testCases := map[string]struct {
+		keys      string
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			keys: `
+[[router.grace_keys]]
+file = "master1.key"
+not_after = 2025-01-02T15:04:05Z
+
+[[router.grace_keys]]
+file = "/etc/scion/old.key"
+not_after = 2025-01-01T00:00:00Z
+`,
+			assertErr: assert.NoError,
+		},
+		"no file": {
+			keys: `
+[[router.grace_keys]]
+not_after = 2025-01-02T15:04:05Z
+`,
+			assertErr: assert.Error,
+		},
+		"no time": {
+			keys: `
+[[router.grace_keys]]
+file = "master1.key"
+`,
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var cfg config.Config
+			err := toml.NewDecoder(bytes.NewReader([]byte(tc.keys))).
+				DisallowUnknownFields().Decode(&cfg)
+			require.NoError(t, err)
+			cfg.Router.InitDefaults()
+			tc.assertErr(t, cfg.Router.Validate())
+		})
+	}
+	var cfg config.Config
+	err := toml.NewDecoder(bytes.NewReader([]byte(testCases["valid"].keys))).Decode(&cfg)
+	require.NoError(t, err)
+	require.Len(t, cfg.Router.GraceKeys, 2)
+	assert.Equal(t, "master1.key", cfg.Router.GraceKeys[0].File)
+	assert.Equal(t, time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC),
+		cfg.Router.GraceKeys[0].NotAfter.UTC())
+}
+
 func InitTestConfig(cfg *config.Config) {
 	apitest.InitConfig(&cfg.API)
 	envtest.InitTest(&cfg.General, &cfg.Metrics, nil, nil)
@@ -35,4 +35,37 @@ num_slow_processors = 1
 # read or write from / to the network socket.
 # (default 256)
 batch_size = 256
+
+# Enable the packet capture endpoint of the http API. Captured packets
+# are streamed in pcapng format to anyone with access to the API.
+# (default false)
+enable_capture = false
+
+# How long the former master0.key is still accepted for hop field MAC
+# verification after a reload changed it. Hop fields are valid for at most 24
+# hours. (default 24h)
+key_grace_period = "24h"
+
+# Former master keys that are still accepted for hop field MAC verification
+# until their not_after time. A relative file is relative to the keys directory
+# of the configuration. (default none)
+#
+# [[router.grace_keys]]
+# file = "master1.key"
+# not_after = 2025-01-02T15:04:05Z
+
+# Token-bucket rate limits of the external interfaces. Each entry applies to one
+# interface in one direction ("ingress" or "egress"). If traffic_class is set,
+# the entry only applies to the packets with that SCION traffic class; such
+# packets must conform to both the class limit and the interface limit. The
+# rate is in bits per second and the burst in bytes. The burst defaults to
+# 100ms worth of traffic at the given rate, but no less than 9000 bytes. The
+# limits can be changed at run time through the http API. (default none)
+#
+# [[router.rate_limits]]
+# interface = 1
+# direction = "ingress"
+# traffic_class = 0
+# rate = 100000000
+# burst = 1250000
 `
@@ -15,6 +15,8 @@
 package router
 
 import (
+	"cmp"
+	"slices"
 	"sync"
 
 	"github.com/scionproto/scion/pkg/addr"
@@ -36,6 +38,7 @@ type Connector struct {
 	internalInterfaces []control.InternalInterface
 	externalInterfaces map[uint16]control.ExternalInterface
 	siblingInterfaces  map[uint16]control.SiblingInterface
+	rateLimits         map[rateLimitKey]control.RateLimit
 	ReceiveBufferSize  int
 	SendBufferSize     int
 
@@ -44,11 +47,41 @@ type Connector struct {
 	DispatchedPortEnd   *int
 }
 
+// rateLimitKey identifies a rate limit. The class is -1 for an interface-wide limit.
+type rateLimitKey struct {
+	ifID      uint16
+	direction control.Direction
+	class     int
+}
+
+func keyOf(l control.RateLimit) rateLimitKey {
+	k := rateLimitKey{ifID: l.IfID, direction: l.Direction, class: -1}
+	if l.TrafficClass != nil {
+		k.class = int(*l.TrafficClass)
+	}
+	return k
+}
+
 var errMultiIA = serrors.New("different IA not allowed")
 
 // NewConnector returns a new connector: a data plane decorated with
 // a configuration interface.
 func NewConnector(config config.RouterConfig, features env.Features) *Connector {
+	// The configured limits are applied when the corresponding interfaces are added.
+	rateLimits := make(map[rateLimitKey]control.RateLimit, len(config.RateLimits))
+	for _, l := range config.RateLimits {
+		limit := control.RateLimit{
+			IfID:         l.Interface,
+			Direction:    control.Direction(l.Direction),
+			TrafficClass: l.TrafficClass,
+			Rate:         l.Rate,
+			Burst:        l.Burst,
+		}
+		if limit.Burst == 0 {
+			limit.Burst = DefaultBurst(limit.Rate)
+		}
+		rateLimits[keyOf(limit)] = limit
+	}
 	return &Connector{
 		DataPlane: makeDataPlane(
 			RunConfig{
@@ -65,6 +98,7 @@ func NewConnector(config config.RouterConfig, features env.Features) *Connector
 		BFD:                 config.BFD,
 		DispatchedPortStart: config.DispatchedPortStart,
 		DispatchedPortEnd:   config.DispatchedPortEnd,
+		rateLimits:          rateLimits,
 	}
 }
 
@@ -144,7 +178,57 @@ func (c *Connector) AddExternalInterface(
 		Link:  link,
 		State: control.InterfaceDown,
 	}
-	return c.DataPlane.AddExternalInterface(intf, link, localHost, remoteHost)
+	if err := c.DataPlane.AddExternalInterface(intf, link, localHost, remoteHost); err != nil {
+		return err
+	}
+	for _, limit := range c.rateLimits {
+		if limit.IfID != intf {
+			continue
+		}
+		if err := c.DataPlane.SetRateLimit(limit); err != nil {
+			return serrors.Wrap("setting rate limit", err, "if_id", localIfID)
+		}
+	}
+	return nil
+}
+
+// RemoveExternalInterface removes the given external or sibling interface. On a running router,
+// the removal takes effect when CommitInterfaces is called.
+func (c *Connector) RemoveExternalInterface(localIfID iface.ID) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	intf := uint16(localIfID)
+	log.Debug("Removing external interface", "interface", localIfID)
+
+	if err := c.DataPlane.RemoveInterface(intf); err != nil {
+		return serrors.Wrap("removing interface", err, "if_id", localIfID)
+	}
+	delete(c.externalInterfaces, intf)
+	delete(c.siblingInterfaces, intf)
+	// The limits go with the interface. They must neither be listed nor be applied to an
+	// interface that is added later with the same ID.
+	for k := range c.rateLimits {
+		if k.ifID == intf {
+			delete(c.rateLimits, k)
+		}
+	}
+	return nil
+}
+
+// CommitInterfaces applies the interface changes made since the router started or since the last
+// call.
+func (c *Connector) CommitInterfaces() error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	log.Debug("Committing interface changes")
+	c.DataPlane.CommitInterfaces()
+	return nil
+}
+
+// HasUnderlayProvider returns whether the router has the named underlay provider.
+func (c *Connector) HasUnderlayProvider(name string) bool {
+	_, ok := underlayProviders[name]
+	return ok
 }
 
 // AddSvc adds the service address for the given ISD-AS.
@@ -169,18 +253,15 @@ func (c *Connector) DelSvc(ia addr.IA, svc addr.SVC, a addr.Host, p uint16) erro
 	return c.DataPlane.DelSvc(svc, a, p)
 }
 
-// SetKey sets the key for the given ISD-AS at the given index.
-func (c *Connector) SetKey(ia addr.IA, index int, key []byte) error {
+// SetKeys sets the MAC keys for the given ISD-AS. The first one is the current key.
+func (c *Connector) SetKeys(ia addr.IA, keys []control.MACKey) error {
 	c.mtx.Lock()
 	defer c.mtx.Unlock()
-	log.Debug("Setting key", "isd_as", ia, "index", index)
+	log.Debug("Setting keys", "isd_as", ia, "count", len(keys))
 	if !c.ia.Equal(ia) {
 		return serrors.JoinNoStack(errMultiIA, nil, "current", c.ia, "new", ia)
 	}
-	if index != 0 {
-		return serrors.New("currently only index 0 key is supported")
-	}
-	return c.DataPlane.SetKey(key)
+	return c.DataPlane.SetKeys(keys)
 }
 
 func (c *Connector) ListInternalInterfaces() ([]control.InternalInterface, error) {
@@ -217,6 +298,82 @@ func (c *Connector) ListSiblingInterfaces() ([]control.SiblingInterface, error)
 	return siblingInterfaceList, nil
 }
 
+// ListDroppedPackets returns the last few sampled packets dropped for the given reason, or for any
+// reason if it is empty.
+func (c *Connector) ListDroppedPackets(reason string) ([]control.DroppedPacket, error) {
+	r := dropNone
+	if reason != "" {
+		var ok bool
+		if r, ok = parseDropReason(reason); !ok {
+			return nil, serrors.New("unknown drop reason", "reason", reason)
+		}
+	}
+	return c.DataPlane.droppedSamples.list(r), nil
+}
+
+// ListRateLimits returns the rate limits, ordered by interface, direction, and traffic class.
+func (c *Connector) ListRateLimits() ([]control.RateLimit, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	keys := make([]rateLimitKey, 0, len(c.rateLimits))
+	for k := range c.rateLimits {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b rateLimitKey) int {
+		return cmp.Or(
+			cmp.Compare(a.ifID, b.ifID),
+			cmp.Compare(a.direction, b.direction),
+			cmp.Compare(a.class, b.class),
+		)
+	})
+	rateLimitList := make([]control.RateLimit, 0, len(keys))
+	for _, k := range keys {
+		rateLimitList = append(rateLimitList, c.rateLimits[k])
+	}
+	return rateLimitList, nil
+}
+
+// SetRateLimit adds, replaces, or (if the rate is zero) removes a rate limit. It can be called
+// while the dataplane is running.
+func (c *Connector) SetRateLimit(limit control.RateLimit) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	log.Debug("Setting rate limit", "interface", limit.IfID, "direction", limit.Direction,
+		"traffic_class", limit.TrafficClass, "rate", limit.Rate, "burst", limit.Burst)
+
+	if limit.Direction != control.Ingress && limit.Direction != control.Egress {
+		return serrors.New("invalid direction", "direction", limit.Direction)
+	}
+	if _, ok := c.externalInterfaces[limit.IfID]; !ok {
+		return serrors.New("unknown external interface", "if_id", limit.IfID)
+	}
+	if limit.Rate == 0 {
+		limit.Burst = 0
+	} else if limit.Burst == 0 {
+		limit.Burst = DefaultBurst(limit.Rate)
+	}
+	if err := c.DataPlane.SetRateLimit(limit); err != nil {
+		return err
+	}
+	if limit.Rate == 0 {
+		delete(c.rateLimits, keyOf(limit))
+		return nil
+	}
+	c.rateLimits[keyOf(limit)] = limit
+	return nil
+}
+
+// StartCapture starts capturing the packets processed by the dataplane.
+func (c *Connector) StartCapture(
+	filter control.CaptureFilter, snapLen int,
+) (control.PacketCapture, error) {
+
+	log.Debug("Starting packet capture", "interface", filter.IfID, "scope", filter.Scope,
+		"disposition", filter.Disposition, "reason", filter.Reason, "snap_len", snapLen)
+	return c.DataPlane.StartCapture(filter, snapLen)
+}
+
 // applyBFDDefaults updates the given cfg object with the global default BFD settings.
 // Link-specific settings, if configured, remain unchanged.  IMPORTANT: cfg.Disable isn't a boolean
 // but a pointer to boolean, allowing a simple representation of the unconfigured state: nil. This
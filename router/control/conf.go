@@ -17,15 +17,19 @@
 package control
 
 import (
+	"context"
 	"crypto/sha256"
+	"errors"
 	"net/netip"
 	"sort"
+	"time"
 
 	"golang.org/x/crypto/pbkdf2"
 
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/segment/iface"
+	"github.com/scionproto/scion/private/keyconf"
 	"github.com/scionproto/scion/private/topology"
 )
 
@@ -38,8 +42,16 @@ type Dataplane interface {
 		localIfID iface.ID, info LinkInfo, localHost, remoteHost addr.Host, owned bool) error
 	AddSvc(ia addr.IA, svc addr.SVC, a addr.Host, port uint16) error
 	DelSvc(ia addr.IA, svc addr.SVC, a addr.Host, port uint16) error
-	SetKey(ia addr.IA, index int, key []byte) error
+	// SetKeys sets the keys used for MAC verification. The first one is the current key, the
+	// others are grace keys. It can be called on a running dataplane.
+	SetKeys(ia addr.IA, keys []MACKey) error
 	SetPortRange(start, end uint16)
+	// RemoveExternalInterface removes an external or sibling interface. On a running dataplane,
+	// the removal, as well as any later addition, takes effect once CommitInterfaces is called.
+	RemoveExternalInterface(localIfID iface.ID) error
+	CommitInterfaces() error
+	// HasUnderlayProvider returns whether the router has the named underlay provider.
+	HasUnderlayProvider(name string) bool
 }
 
 // BFD is the configuration for the BFD sessions.
@@ -68,6 +80,151 @@ type ObservableDataplane interface {
 	ListInternalInterfaces() ([]InternalInterface, error)
 	ListExternalInterfaces() ([]ExternalInterface, error)
 	ListSiblingInterfaces() ([]SiblingInterface, error)
+	// ListDroppedPackets returns the last few sampled packets dropped for the given reason, or for
+	// any reason if it is empty. The most recent packets come first.
+	ListDroppedPackets(reason string) ([]DroppedPacket, error)
+}
+
+// TunableDataplane is the interface through which the http API adjusts the settings of a
+// running dataplane.
+type TunableDataplane interface {
+	ListRateLimits() ([]RateLimit, error)
+	SetRateLimit(limit RateLimit) error
+}
+
+// InterfaceReloader is the interface through which the http API makes the router reload its
+// interfaces from the topology file.
+type InterfaceReloader interface {
+	ReloadInterfaces() (InterfaceChanges, error)
+}
+
+// InterfaceChanges lists the interfaces that a reload changed.
+type InterfaceChanges struct {
+	Added    []uint16
+	Removed  []uint16
+	Modified []uint16
+}
+
+// Direction designates the traffic that enters or leaves the router through an interface.
+type Direction string
+
+const (
+	Ingress Direction = "ingress"
+	Egress  Direction = "egress"
+)
+
+// RateLimit is a token-bucket limit on the traffic of one external interface in one direction.
+type RateLimit struct {
+	// IfID is the identifier of the external interface.
+	IfID uint16
+	// Direction is the direction of the traffic to which the limit applies.
+	Direction Direction
+	// TrafficClass restricts the limit to the packets with the given SCION traffic class. If nil,
+	// the limit applies to all the traffic of the interface in that direction.
+	TrafficClass *uint8
+	// Rate is the sustained rate in bits per second. Zero means no limit.
+	Rate uint64
+	// Burst is the size of the bucket in bytes. Zero means the dataplane's default.
+	Burst uint64
+}
+
+// ErrUnknownDropReason is returned when a filter names a drop reason that the dataplane does
+// not know.
+var ErrUnknownDropReason = errors.New("unknown drop reason")
+
+// PacketCapturer is the interface through which the http API captures the packets that the
+// dataplane processes.
+type PacketCapturer interface {
+	// StartCapture starts capturing the packets that match the filter. At most snapLen bytes of
+	// each packet are kept; zero means the whole packet. If the filter names an unknown drop
+	// reason, it returns an error wrapping ErrUnknownDropReason.
+	StartCapture(filter CaptureFilter, snapLen int) (PacketCapture, error)
+}
+
+// PacketCapture is a running capture.
+type PacketCapture interface {
+	// Next blocks until a packet is captured or the context is done. The returned packet is only
+	// valid until the next call to Next or Close.
+	Next(ctx context.Context) (*CapturedPacket, error)
+	// Close stops the capture.
+	Close()
+	// Lost returns the number of matching packets that could not be captured because the
+	// consumer was too slow.
+	Lost() uint64
+	// SnapLen returns the maximum number of bytes kept of each packet. It can be smaller than
+	// requested.
+	SnapLen() int
+}
+
+// LinkScope is the scope of the link through which a packet enters or leaves the router.
+type LinkScope string
+
+const (
+	ScopeInternal LinkScope = "internal"
+	ScopeSibling  LinkScope = "sibling"
+	ScopeExternal LinkScope = "external"
+)
+
+// Disposition is the fate of a processed packet.
+type Disposition string
+
+const (
+	Forwarded Disposition = "forwarded"
+	Dropped   Disposition = "dropped"
+	SlowPath  Disposition = "slow_path"
+)
+
+// CaptureFilter selects the packets that a capture reports. Empty fields match all packets.
+type CaptureFilter struct {
+	// IfID matches the packets that enter or leave through the given interface.
+	IfID *uint16
+	// Scope matches the packets that enter or leave through a link of the given scope.
+	Scope LinkScope
+	// Disposition matches the packets with the given disposition.
+	Disposition Disposition
+	// Reason matches the packets dropped for the given reason.
+	Reason string
+}
+
+// CapturedPacket is a packet reported by a capture, along with what the router did with it.
+type CapturedPacket struct {
+	// Time is when the packet was captured.
+	Time time.Time
+	// Ingress is the interface through which the packet was received. It is 0 for the internal
+	// and sibling links.
+	Ingress uint16
+	// IngressScope is the scope of the link through which the packet was received.
+	IngressScope LinkScope
+	// Egress is the interface through which the packet is sent, if it has been determined.
+	Egress uint16
+	// EgressScope is the scope of the egress link, if it has been determined.
+	EgressScope LinkScope
+	// Disposition is what the router did with the packet.
+	Disposition Disposition
+	// Reason is why the packet was not forwarded. For the slow path disposition, it is the error
+	// reported to the sender, if any. Empty for forwarded packets.
+	Reason string
+	// Length is the length of the packet.
+	Length int
+	// Data is the SCION packet, possibly truncated. Forwarded packets are captured as sent.
+	Data []byte
+}
+
+// DroppedPacket is a packet that the router dropped.
+type DroppedPacket struct {
+	// Time is when the packet was dropped.
+	Time time.Time
+	// Reason is why the packet was dropped; the same as in the dropped packets metric.
+	Reason string
+	// Ingress is the interface through which the packet was received. It is 0 for the internal
+	// and sibling links.
+	Ingress uint16
+	// Egress is the interface through which the packet was to be sent, or 0 if not determined.
+	Egress uint16
+	// Length is the length of the packet.
+	Length int
+	// Header is the SCION header of the packet, with the path but without the extensions.
+	Header []byte
 }
 
 // InternalInterface represents the internal underlay interface of a router.
@@ -121,6 +278,10 @@ const (
 
 // ConfigDataplane configures the data-plane with the new configuration.
 func ConfigDataplane(dp Dataplane, cfg *Config) error {
+	return configDataplane(dp, cfg, macKeys(cfg, nil, time.Now()))
+}
+
+func configDataplane(dp Dataplane, cfg *Config, keys []MACKey) error {
 	if cfg == nil {
 		// No configuration, nothing to do
 		return serrors.New("empty configuration")
@@ -130,11 +291,9 @@ func ConfigDataplane(dp Dataplane, cfg *Config) error {
 		return err
 	}
 	// Set Keys
-	// XXX HSR currently only support 1 key, so use Key0
 	// Should it be an error if no key is set?
-	if len(cfg.MasterKeys.Key0) > 0 {
-		key0 := DeriveHFMacKey(cfg.MasterKeys.Key0)
-		if err := dp.SetKey(cfg.IA, 0, key0); err != nil {
+	if len(keys) > 0 {
+		if err := dp.SetKeys(cfg.IA, keys); err != nil {
 			return err
 		}
 	}
@@ -178,12 +337,100 @@ func DeriveHFMacKey(k []byte) []byte {
 	return pbkdf2.Key(k, hfMacSalt, 1000, 16, sha256.New)
 }
 
+// MACKey is a key for the hop field MACs, as given to the dataplane.
+type MACKey struct {
+	// ID identifies the master key from which the key is derived; see keyconf.KeyID.
+	ID string
+	// Key is the key derived with DeriveHFMacKey.
+	Key []byte
+	// NotAfter is the time until which a grace key is accepted. It is ignored for the current
+	// key.
+	NotAfter time.Time
+}
+
+// GraceKey is a former master key that is accepted for MAC verification until NotAfter.
+type GraceKey struct {
+	Master   []byte
+	NotAfter time.Time
+}
+
+func newMACKey(master []byte, notAfter time.Time) MACKey {
+	return MACKey{
+		ID:       keyconf.KeyID(master),
+		Key:      DeriveHFMacKey(master),
+		NotAfter: notAfter,
+	}
+}
+
+// macKeys returns the keys that the dataplane should use, given the configuration and the keys
+// that the dataplane used until now, if any. The current key is Key0. The grace keys are:
+//   - the configured grace keys that have not expired,
+//   - the former current key, for the grace period after a reload changed Key0,
+//   - the former grace keys that have not expired.
+//
+// Key1 is not accepted unless it is configured as a grace key. A restart does not extend the
+// acceptance of any key.
+func macKeys(cfg *Config, previous []MACKey, now time.Time) []MACKey {
+	if len(cfg.MasterKeys.Key0) == 0 {
+		return nil
+	}
+	current := newMACKey(cfg.MasterKeys.Key0, time.Time{})
+	keys := []MACKey{current}
+	add := func(k MACKey) {
+		if !now.Before(k.NotAfter) {
+			return
+		}
+		for _, existing := range keys {
+			if existing.ID == k.ID {
+				return
+			}
+		}
+		keys = append(keys, k)
+	}
+	for _, k := range cfg.GraceKeys {
+		add(newMACKey(k.Master, k.NotAfter))
+	}
+	for i, k := range previous {
+		if i == 0 {
+			k.NotAfter = now.Add(cfg.KeyGracePeriod)
+		}
+		add(k)
+	}
+	return keys
+}
+
+// externalInterfaceConf is the configuration of one external or sibling interface, as given to
+// the dataplane.
+type externalInterfaceConf struct {
+	ifID       iface.ID
+	link       LinkInfo
+	localHost  addr.Host
+	remoteHost addr.Host
+	owned      bool
+}
+
 func confExternalInterfaces(dp Dataplane, cfg *Config) error {
+	confs, err := externalInterfaceConfs(cfg)
+	if err != nil {
+		return err
+	}
+	for _, c := range confs {
+		if err := dp.AddExternalInterface(
+			c.ifID, c.link, c.localHost, c.remoteHost, c.owned); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// externalInterfaceConfs returns the configuration of the external and sibling interfaces, by
+// increasing interface ID.
+func externalInterfaceConfs(cfg *Config) ([]externalInterfaceConf, error) {
 	// Sort out keys/ifIDs to get deterministic order for unit testing
 	infoMap := cfg.Topo.IFInfoMap()
 	if len(infoMap) == 0 {
 		// nothing to do
-		return nil
+		return nil, nil
 	}
 	ifIDs := []iface.ID{}
 	for k := range infoMap {
@@ -191,6 +438,7 @@ func confExternalInterfaces(dp Dataplane, cfg *Config) error {
 	}
 	sort.Slice(ifIDs, func(i, j int) bool { return ifIDs[i] < ifIDs[j] })
 	// External interfaces
+	confs := make([]externalInterfaceConf, 0, len(ifIDs))
 	for _, ifID := range ifIDs {
 		iface := infoMap[ifID]
 		linkInfo := LinkInfo{
@@ -221,13 +469,13 @@ func confExternalInterfaces(dp Dataplane, cfg *Config) error {
 
 		localAddr, err := netip.ParseAddrPort(linkInfo.Local.Addr)
 		if err != nil {
-			return serrors.Wrap("unparsable remote address", err)
+			return nil, serrors.Wrap("unparsable remote address", err)
 		}
 		localHost := addr.HostIP(localAddr.Addr())
 
 		remoteAddr, err := netip.ParseAddrPort(linkInfo.Remote.Addr)
 		if err != nil {
-			return serrors.Wrap("unparsable remote address", err)
+			return nil, serrors.Wrap("unparsable remote address", err)
 		}
 		remoteHost := addr.HostIP(remoteAddr.Addr())
 
@@ -252,12 +500,15 @@ func confExternalInterfaces(dp Dataplane, cfg *Config) error {
 			linkInfo.BFD = BFD{}
 		}
 
-		if err := dp.AddExternalInterface(
-			ifID, linkInfo, localHost, remoteHost, owned); err != nil {
-			return err
-		}
+		confs = append(confs, externalInterfaceConf{
+			ifID:       ifID,
+			link:       linkInfo,
+			localHost:  localHost,
+			remoteHost: remoteHost,
+			owned:      owned,
+		})
 	}
-	return nil
+	return confs, nil
 }
 
 var svcTypes = []addr.SVC{
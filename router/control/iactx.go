@@ -18,10 +18,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/segment/iface"
 	"github.com/scionproto/scion/private/keyconf"
 	"github.com/scionproto/scion/private/topology"
 )
@@ -37,6 +42,12 @@ type Config struct {
 	BR *topology.BRInfo
 	// MasterKeys holds the local AS master keys.
 	MasterKeys keyconf.Master
+	// KeyGracePeriod is how long a master key is still accepted for MAC verification after a
+	// reload made it stop being the current key.
+	KeyGracePeriod time.Duration
+	// GraceKeys are former master keys that are accepted for MAC verification until their
+	// NotAfter time, in addition to the current key.
+	GraceKeys []GraceKey
 }
 
 // LoadConfig sets up the configuration, loading it from the supplied config directory.
@@ -94,14 +105,24 @@ func (cfg *Config) loadMasterKeys(confDir string) error {
 
 // IACtx is the context for the router for a given IA.
 type IACtx struct {
-	// Config is the router topology configuration
+	// Config is the router topology configuration. After a successful reload, it is the
+	// reloaded configuration; use CurrentConfig to read it concurrently with reloads.
 	Config *Config
 	// DP is the underlying data plane.
 	DP Dataplane
+
+	mtx sync.Mutex
+	// interfaces is the configuration of the external and sibling interfaces, as applied to the
+	// dataplane.
+	interfaces map[iface.ID]externalInterfaceConf
+	// keys are the MAC keys, as applied to the dataplane.
+	keys []MACKey
 }
 
 // Configure configures the dataplane for the given context.
 func (iac *IACtx) Configure() error {
+	iac.mtx.Lock()
+	defer iac.mtx.Unlock()
 	cfg := iac.Config
 	if cfg == nil {
 		// Nothing to do
@@ -109,17 +130,165 @@ func (iac *IACtx) Configure() error {
 	}
 
 	log.Debug("Configuring Dataplane")
-	if err := ConfigDataplane(iac.DP, cfg); err != nil {
+	keys := macKeys(cfg, nil, time.Now())
+	if err := configDataplane(iac.DP, cfg, keys); err != nil {
 		brConfDump, errDump := dumpConfig(cfg)
 		if errDump != nil {
 			brConfDump = errDump.Error()
 		}
 		return serrors.Wrap("config setup", err, "config", brConfDump)
 	}
+	confs, err := externalInterfaceConfs(cfg)
+	if err != nil {
+		return err
+	}
+	iac.interfaces = make(map[iface.ID]externalInterfaceConf, len(confs))
+	for _, c := range confs {
+		iac.interfaces[c.ifID] = c
+	}
+	iac.keys = keys
 	log.Debug("Dataplane configured successfully", "config", cfg)
 	return nil
 }
 
+// CurrentConfig returns the configuration in effect.
+func (iac *IACtx) CurrentConfig() *Config {
+	iac.mtx.Lock()
+	defer iac.mtx.Unlock()
+	return iac.Config
+}
+
+// Reload applies the external and sibling interfaces of the given configuration to the
+// dataplane, which may be running: the interfaces that are no longer configured are removed, the
+// new ones are added, and the ones whose configuration changed are replaced. The other
+// interfaces are left alone, so their traffic is not disrupted. The rest of the configuration,
+// such as the services, is not reloaded; the keys are reloaded by ReloadKeys. The ISD-AS and the
+// internal address of the router cannot change.
+//
+// If an error occurs, the changes that were made until then remain in effect and the
+// configuration is not replaced; reloading again retries the remaining changes.
+func (iac *IACtx) Reload(cfg *Config) (InterfaceChanges, error) {
+	iac.mtx.Lock()
+	defer iac.mtx.Unlock()
+	var changes InterfaceChanges
+	if cfg == nil || cfg.BR == nil {
+		return changes, serrors.New("empty configuration")
+	}
+	if !cfg.IA.Equal(iac.Config.IA) {
+		return changes, serrors.New("ISD-AS changed", "current", iac.Config.IA, "new", cfg.IA)
+	}
+	if cfg.BR.InternalAddr != iac.Config.BR.InternalAddr {
+		return changes, serrors.New("internal address changed",
+			"current", iac.Config.BR.InternalAddr, "new", cfg.BR.InternalAddr)
+	}
+	confs, err := externalInterfaceConfs(cfg)
+	if err != nil {
+		return changes, err
+	}
+	wanted := make(map[iface.ID]externalInterfaceConf, len(confs))
+	for _, c := range confs {
+		wanted[c.ifID] = c
+	}
+	var remove []iface.ID
+	for ifID := range iac.interfaces {
+		if _, ok := wanted[ifID]; !ok {
+			remove = append(remove, ifID)
+			changes.Removed = append(changes.Removed, uint16(ifID))
+		}
+	}
+	var add []externalInterfaceConf
+	for _, c := range confs {
+		current, ok := iac.interfaces[c.ifID]
+		switch {
+		case !ok:
+			changes.Added = append(changes.Added, uint16(c.ifID))
+		case !reflect.DeepEqual(current, c):
+			remove = append(remove, c.ifID)
+			changes.Modified = append(changes.Modified, uint16(c.ifID))
+		default:
+			continue
+		}
+		add = append(add, c)
+	}
+	slices.Sort(changes.Removed)
+	// An interface that cannot be added is rejected before anything is changed. Otherwise, a
+	// modified interface would be removed and not added back.
+	for _, c := range add {
+		if !iac.DP.HasUnderlayProvider(c.link.Provider) {
+			return InterfaceChanges{}, serrors.New("no provider for underlay",
+				"if_id", c.ifID, "provider", c.link.Provider)
+		}
+	}
+
+	// Whatever was done is committed, even if not everything could be, so that the dataplane
+	// matches what iac.interfaces records.
+	err = iac.applyInterfaces(remove, add)
+	if commitErr := iac.DP.CommitInterfaces(); err == nil {
+		err = commitErr
+	}
+	if err != nil {
+		return changes, err
+	}
+	iac.Config = cfg
+	log.Info("Interfaces reloaded", "added", changes.Added, "removed", changes.Removed,
+		"modified", changes.Modified)
+	return changes, nil
+}
+
+// ReloadKeys applies the master keys of the given configuration to the dataplane, which may be
+// running. If the current key changed, the former one is still accepted during the grace period
+// of the configuration, so that the hop fields that were made with it remain valid. It returns
+// whether the keys used by the dataplane changed, which includes grace keys that expired.
+//
+// The control service must not use a new key before all routers of the AS accept it. Therefore,
+// on a key rollover, the routers must be reloaded before the control services.
+func (iac *IACtx) ReloadKeys(cfg *Config) (bool, error) {
+	iac.mtx.Lock()
+	defer iac.mtx.Unlock()
+	if cfg == nil {
+		return false, serrors.New("empty configuration")
+	}
+	if !cfg.IA.Equal(iac.Config.IA) {
+		return false, serrors.New("ISD-AS changed", "current", iac.Config.IA, "new", cfg.IA)
+	}
+	keys := macKeys(cfg, iac.keys, time.Now())
+	if len(keys) == 0 {
+		return false, serrors.New("no master key")
+	}
+	if slices.EqualFunc(keys, iac.keys, func(a, b MACKey) bool {
+		return a.ID == b.ID && a.NotAfter.Equal(b.NotAfter)
+	}) {
+		return false, nil
+	}
+	if err := iac.DP.SetKeys(cfg.IA, keys); err != nil {
+		return false, err
+	}
+	iac.keys = keys
+	grace := make([]string, 0, len(keys)-1)
+	for _, k := range keys[1:] {
+		grace = append(grace, k.ID)
+	}
+	log.Info("MAC keys reloaded", "current", keys[0].ID, "grace", grace)
+	return true, nil
+}
+
+func (iac *IACtx) applyInterfaces(remove []iface.ID, add []externalInterfaceConf) error {
+	for _, ifID := range remove {
+		if err := iac.DP.RemoveExternalInterface(ifID); err != nil {
+			return err
+		}
+		delete(iac.interfaces, ifID)
+	}
+	for _, c := range add {
+		if err := iac.DP.AddExternalInterface(
+			c.ifID, c.link, c.localHost, c.remoteHost, c.owned); err != nil {
+			return err
+		}
+		iac.interfaces[c.ifID] = c
+	}
+	return nil
+}
+
 func dumpConfig(cfg *Config) (string, error) {
 	if cfg == nil {
 		return "", serrors.New("empty configuration")
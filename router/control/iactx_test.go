@@ -0,0 +1,301 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package control_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/segment/iface"
+	"github.com/scionproto/scion/private/keyconf"
+	"github.com/scionproto/scion/private/topology"
+	"github.com/scionproto/scion/router/control"
+)
+
+// fakeDataplane records the interface changes and the keys that it is asked to make.
+type fakeDataplane struct {
+	calls   []string
+	failAdd iface.ID
+	keys    []control.MACKey
+}
+
+func (d *fakeDataplane) CreateIACtx(addr.IA) error { return nil }
+
+func (d *fakeDataplane) AddInternalInterface(addr.IA, addr.Host, string, string) error {
+	return nil
+}
+
+func (d *fakeDataplane) AddExternalInterface(
+	ifID iface.ID, info control.LinkInfo, _, _ addr.Host, owned bool) error {
+
+	if ifID == d.failAdd {
+		return errors.New("test error")
+	}
+	d.calls = append(d.calls, fmt.Sprintf("add %d %s owned=%t", ifID, info.Remote.Addr, owned))
+	return nil
+}
+
+func (d *fakeDataplane) AddSvc(addr.IA, addr.SVC, addr.Host, uint16) error { return nil }
+func (d *fakeDataplane) DelSvc(addr.IA, addr.SVC, addr.Host, uint16) error { return nil }
+func (d *fakeDataplane) SetPortRange(uint16, uint16)                       {}
+
+func (d *fakeDataplane) SetKeys(_ addr.IA, keys []control.MACKey) error {
+	d.keys = keys
+	return nil
+}
+
+func (d *fakeDataplane) RemoveExternalInterface(ifID iface.ID) error {
+	d.calls = append(d.calls, fmt.Sprintf("remove %d", ifID))
+	return nil
+}
+
+func (d *fakeDataplane) CommitInterfaces() error {
+	d.calls = append(d.calls, "commit")
+	return nil
+}
+
+func (d *fakeDataplane) HasUnderlayProvider(name string) bool { return name == "udpip" }
+
+// testConfig returns the configuration of br1-ff00_0_110-2, with the given interfaces. If sibling
+// is true, br1-ff00_0_110-1 owns interface 1. The interfaces are given as "id": {...} JSON
+// members.
+func testConfig(
+	t *testing.T, ia, internal string, sibling bool, interfaces ...string,
+) *control.Config {
+	var siblingInterfaces string
+	if sibling {
+		siblingInterfaces = `"1": {
+			"underlay": {"local": "127.0.0.1:50001", "remote": "127.0.0.3:50000"},
+			"isd_as": "1-ff00:0:120",
+			"link_to": "CHILD",
+			"mtu": 1472
+		}`
+	}
+	raw := fmt.Sprintf(`{
+		"isd_as": %q,
+		"mtu": 1472,
+		"border_routers": {
+			"br1-ff00_0_110-1": {
+				"internal_addr": "127.0.0.1:50000",
+				"interfaces": {%s}
+			},
+			"br1-ff00_0_110-2": {
+				"internal_addr": %q,
+				"interfaces": {%s}
+			}
+		}
+	}`, ia, siblingInterfaces, internal, strings.Join(interfaces, ","))
+	topo, err := topology.FromJSONBytes([]byte(raw))
+	require.NoError(t, err)
+	br, ok := topo.BR("br1-ff00_0_110-2")
+	require.True(t, ok)
+	return &control.Config{Topo: topo, IA: topo.IA(), BR: &br}
+}
+
+func testInterface(ifID int, remote string) string {
+	return fmt.Sprintf(`"%d": {
+		"underlay": {"local": "127.0.0.2:5000%d", "remote": %q},
+		"isd_as": "1-ff00:0:130",
+		"link_to": "CHILD",
+		"mtu": 1472
+	}`, ifID, ifID, remote)
+}
+
+func TestIACtxReload(t *testing.T) {
+	ia := "1-ff00:0:110"
+	internal := "127.0.0.2:50000"
+	prepare := func(t *testing.T) (*control.IACtx, *fakeDataplane) {
+		dp := &fakeDataplane{}
+		iaCtx := &control.IACtx{
+			Config: testConfig(t, ia, internal, true,
+				testInterface(2, "127.0.0.4:50000"),
+				testInterface(3, "127.0.0.5:50000"),
+			),
+			DP: dp,
+		}
+		require.NoError(t, iaCtx.Configure())
+		assert.Equal(t, []string{
+			"add 1 127.0.0.1:50000 owned=false",
+			"add 2 127.0.0.4:50000 owned=true",
+			"add 3 127.0.0.5:50000 owned=true",
+		}, dp.calls)
+		dp.calls = nil
+		return iaCtx, dp
+	}
+
+	t.Run("applies changes", func(t *testing.T) {
+		iaCtx, dp := prepare(t)
+		cfg := testConfig(t, ia, internal, false,
+			testInterface(2, "127.0.0.4:50000"),
+			testInterface(3, "127.0.0.6:50000"),
+			testInterface(4, "127.0.0.7:50000"),
+		)
+		changes, err := iaCtx.Reload(cfg)
+		require.NoError(t, err)
+		assert.Equal(t, control.InterfaceChanges{
+			Added:    []uint16{4},
+			Removed:  []uint16{1},
+			Modified: []uint16{3},
+		}, changes)
+		assert.Equal(t, []string{
+			"remove 1",
+			"remove 3",
+			"add 3 127.0.0.6:50000 owned=true",
+			"add 4 127.0.0.7:50000 owned=true",
+			"commit",
+		}, dp.calls)
+		assert.Same(t, cfg, iaCtx.CurrentConfig())
+
+		// Reloading the same configuration changes nothing.
+		dp.calls = nil
+		changes, err = iaCtx.Reload(cfg)
+		require.NoError(t, err)
+		assert.Equal(t, control.InterfaceChanges{}, changes)
+		assert.Equal(t, []string{"commit"}, dp.calls)
+	})
+	t.Run("ISD-AS cannot change", func(t *testing.T) {
+		iaCtx, dp := prepare(t)
+		old := iaCtx.CurrentConfig()
+		_, err := iaCtx.Reload(testConfig(t, "1-ff00:0:111", internal, true))
+		assert.Error(t, err)
+		assert.Empty(t, dp.calls)
+		assert.Same(t, old, iaCtx.CurrentConfig())
+	})
+	t.Run("internal address cannot change", func(t *testing.T) {
+		iaCtx, dp := prepare(t)
+		_, err := iaCtx.Reload(testConfig(t, ia, "127.0.0.2:50001", true))
+		assert.Error(t, err)
+		assert.Empty(t, dp.calls)
+	})
+	t.Run("unknown underlay provider is rejected", func(t *testing.T) {
+		iaCtx, dp := prepare(t)
+		old := iaCtx.CurrentConfig()
+		modified := strings.Replace(testInterface(3, "127.0.0.6:50000"),
+			`"underlay": {`, `"underlay": {"provider": "udpipp", `, 1)
+		_, err := iaCtx.Reload(testConfig(t, ia, internal, true,
+			testInterface(2, "127.0.0.4:50000"),
+			modified,
+		))
+		assert.ErrorContains(t, err, "no provider for underlay")
+		assert.Empty(t, dp.calls)
+		assert.Same(t, old, iaCtx.CurrentConfig())
+	})
+	t.Run("partial changes are committed", func(t *testing.T) {
+		iaCtx, dp := prepare(t)
+		old := iaCtx.CurrentConfig()
+		dp.failAdd = 3
+		cfg := testConfig(t, ia, internal, true,
+			testInterface(3, "127.0.0.6:50000"),
+		)
+		_, err := iaCtx.Reload(cfg)
+		assert.Error(t, err)
+		assert.Equal(t, []string{"remove 2", "remove 3", "commit"}, dp.calls)
+		assert.Same(t, old, iaCtx.CurrentConfig())
+
+		// The next reload retries what is left to do.
+		dp.failAdd = 0
+		dp.calls = nil
+		_, err = iaCtx.Reload(cfg)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"add 3 127.0.0.6:50000 owned=true", "commit"}, dp.calls)
+	})
+}
+
+func TestIACtxReloadKeys(t *testing.T) {
+	ia := "1-ff00:0:110"
+	internal := "127.0.0.2:50000"
+	keyA, keyB, keyC := []byte("master key A"), []byte("master key B"), []byte("master key C")
+	ids := func(keys []control.MACKey) []string {
+		var ids []string
+		for _, k := range keys {
+			ids = append(ids, k.ID)
+		}
+		return ids
+	}
+	graceB := []control.GraceKey{{Master: keyB, NotAfter: time.Now().Add(2 * time.Hour)}}
+	prepare := func(
+		t *testing.T, grace time.Duration, graceKeys []control.GraceKey,
+	) (*control.IACtx, *fakeDataplane) {
+		cfg := testConfig(t, ia, internal, false)
+		cfg.MasterKeys = keyconf.Master{Key0: keyA, Key1: keyB}
+		cfg.KeyGracePeriod = grace
+		cfg.GraceKeys = graceKeys
+		dp := &fakeDataplane{}
+		iaCtx := &control.IACtx{Config: cfg, DP: dp}
+		require.NoError(t, iaCtx.Configure())
+		return iaCtx, dp
+	}
+	rolled := func(t *testing.T, grace time.Duration, graceKeys []control.GraceKey) *control.Config {
+		cfg := testConfig(t, ia, internal, false)
+		cfg.MasterKeys = keyconf.Master{Key0: keyC, Key1: keyA}
+		cfg.KeyGracePeriod = grace
+		cfg.GraceKeys = graceKeys
+		return cfg
+	}
+
+	t.Run("rollover keeps grace keys", func(t *testing.T) {
+		iaCtx, dp := prepare(t, time.Hour, graceB)
+		assert.Equal(t, []string{keyconf.KeyID(keyA), keyconf.KeyID(keyB)}, ids(dp.keys))
+		assert.Equal(t, control.DeriveHFMacKey(keyA), dp.keys[0].Key)
+		assert.Equal(t, graceB[0].NotAfter, dp.keys[1].NotAfter)
+
+		changed, err := iaCtx.ReloadKeys(iaCtx.CurrentConfig())
+		require.NoError(t, err)
+		assert.False(t, changed)
+
+		changed, err = iaCtx.ReloadKeys(rolled(t, time.Hour, graceB))
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, []string{
+			keyconf.KeyID(keyC), keyconf.KeyID(keyB), keyconf.KeyID(keyA),
+		}, ids(dp.keys))
+		assert.WithinDuration(t, time.Now().Add(time.Hour), dp.keys[2].NotAfter, time.Minute)
+	})
+	t.Run("grace keys expire", func(t *testing.T) {
+		// Only the configured time counts; a restart doesn't extend it.
+		_, dp := prepare(t, time.Hour, []control.GraceKey{
+			{Master: keyB, NotAfter: time.Now().Add(-time.Second)},
+		})
+		assert.Equal(t, []string{keyconf.KeyID(keyA)}, ids(dp.keys))
+	})
+	t.Run("previous key is not a grace key", func(t *testing.T) {
+		_, dp := prepare(t, time.Hour, nil)
+		assert.Equal(t, []string{keyconf.KeyID(keyA)}, ids(dp.keys))
+	})
+	t.Run("no grace period", func(t *testing.T) {
+		iaCtx, dp := prepare(t, 0, nil)
+		assert.Equal(t, []string{keyconf.KeyID(keyA)}, ids(dp.keys))
+
+		changed, err := iaCtx.ReloadKeys(rolled(t, 0, nil))
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, []string{keyconf.KeyID(keyC)}, ids(dp.keys))
+	})
+	t.Run("ISD-AS cannot change", func(t *testing.T) {
+		iaCtx, dp := prepare(t, time.Hour, nil)
+		cfg := testConfig(t, "1-ff00:0:111", internal, false)
+		cfg.MasterKeys = keyconf.Master{Key0: keyC}
+		_, err := iaCtx.ReloadKeys(cfg)
+		assert.Error(t, err)
+		assert.Equal(t, keyconf.KeyID(keyA), dp.keys[0].ID)
+	})
+}
@@ -1,16 +1,55 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/scionproto/scion/router/control (interfaces: ObservableDataplane)
+// Source: github.com/scionproto/scion/router/control (interfaces: InterfaceReloader,ObservableDataplane,PacketCapture,PacketCapturer,TunableDataplane)
 
 // Package mock_api is a generated GoMock package.
 package mock_api
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
 	control "github.com/scionproto/scion/router/control"
 )
 
+// MockInterfaceReloader is a mock of InterfaceReloader interface.
+type MockInterfaceReloader struct {
+	ctrl     *gomock.Controller
+	recorder *MockInterfaceReloaderMockRecorder
+}
+
+// MockInterfaceReloaderMockRecorder is the mock recorder for MockInterfaceReloader.
+type MockInterfaceReloaderMockRecorder struct {
+	mock *MockInterfaceReloader
+}
+
+// NewMockInterfaceReloader creates a new mock instance.
+func NewMockInterfaceReloader(ctrl *gomock.Controller) *MockInterfaceReloader {
+	mock := &MockInterfaceReloader{ctrl: ctrl}
+	mock.recorder = &MockInterfaceReloaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInterfaceReloader) EXPECT() *MockInterfaceReloaderMockRecorder {
+	return m.recorder
+}
+
+// ReloadInterfaces mocks base method.
+func (m *MockInterfaceReloader) ReloadInterfaces() (control.InterfaceChanges, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReloadInterfaces")
+	ret0, _ := ret[0].(control.InterfaceChanges)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReloadInterfaces indicates an expected call of ReloadInterfaces.
+func (mr *MockInterfaceReloaderMockRecorder) ReloadInterfaces() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadInterfaces", reflect.TypeOf((*MockInterfaceReloader)(nil).ReloadInterfaces))
+}
+
 // MockObservableDataplane is a mock of ObservableDataplane interface.
 type MockObservableDataplane struct {
 	ctrl     *gomock.Controller
@@ -34,6 +73,21 @@ func (m *MockObservableDataplane) EXPECT() *MockObservableDataplaneMockRecorder
 	return m.recorder
 }
 
+// ListDroppedPackets mocks base method.
+func (m *MockObservableDataplane) ListDroppedPackets(arg0 string) ([]control.DroppedPacket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDroppedPackets", arg0)
+	ret0, _ := ret[0].([]control.DroppedPacket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDroppedPackets indicates an expected call of ListDroppedPackets.
+func (mr *MockObservableDataplaneMockRecorder) ListDroppedPackets(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDroppedPackets", reflect.TypeOf((*MockObservableDataplane)(nil).ListDroppedPackets), arg0)
+}
+
 // ListExternalInterfaces mocks base method.
 func (m *MockObservableDataplane) ListExternalInterfaces() ([]control.ExternalInterface, error) {
 	m.ctrl.T.Helper()
@@ -78,3 +132,171 @@ func (mr *MockObservableDataplaneMockRecorder) ListSiblingInterfaces() *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSiblingInterfaces", reflect.TypeOf((*MockObservableDataplane)(nil).ListSiblingInterfaces))
 }
+
+// MockPacketCapture is a mock of PacketCapture interface.
+type MockPacketCapture struct {
+	ctrl     *gomock.Controller
+	recorder *MockPacketCaptureMockRecorder
+}
+
+// MockPacketCaptureMockRecorder is the mock recorder for MockPacketCapture.
+type MockPacketCaptureMockRecorder struct {
+	mock *MockPacketCapture
+}
+
+// NewMockPacketCapture creates a new mock instance.
+func NewMockPacketCapture(ctrl *gomock.Controller) *MockPacketCapture {
+	mock := &MockPacketCapture{ctrl: ctrl}
+	mock.recorder = &MockPacketCaptureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPacketCapture) EXPECT() *MockPacketCaptureMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockPacketCapture) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockPacketCaptureMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockPacketCapture)(nil).Close))
+}
+
+// Lost mocks base method.
+func (m *MockPacketCapture) Lost() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lost")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// Lost indicates an expected call of Lost.
+func (mr *MockPacketCaptureMockRecorder) Lost() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lost", reflect.TypeOf((*MockPacketCapture)(nil).Lost))
+}
+
+// Next mocks base method.
+func (m *MockPacketCapture) Next(arg0 context.Context) (*control.CapturedPacket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Next", arg0)
+	ret0, _ := ret[0].(*control.CapturedPacket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Next indicates an expected call of Next.
+func (mr *MockPacketCaptureMockRecorder) Next(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Next", reflect.TypeOf((*MockPacketCapture)(nil).Next), arg0)
+}
+
+// SnapLen mocks base method.
+func (m *MockPacketCapture) SnapLen() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapLen")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// SnapLen indicates an expected call of SnapLen.
+func (mr *MockPacketCaptureMockRecorder) SnapLen() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapLen", reflect.TypeOf((*MockPacketCapture)(nil).SnapLen))
+}
+
+// MockPacketCapturer is a mock of PacketCapturer interface.
+type MockPacketCapturer struct {
+	ctrl     *gomock.Controller
+	recorder *MockPacketCapturerMockRecorder
+}
+
+// MockPacketCapturerMockRecorder is the mock recorder for MockPacketCapturer.
+type MockPacketCapturerMockRecorder struct {
+	mock *MockPacketCapturer
+}
+
+// NewMockPacketCapturer creates a new mock instance.
+func NewMockPacketCapturer(ctrl *gomock.Controller) *MockPacketCapturer {
+	mock := &MockPacketCapturer{ctrl: ctrl}
+	mock.recorder = &MockPacketCapturerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPacketCapturer) EXPECT() *MockPacketCapturerMockRecorder {
+	return m.recorder
+}
+
+// StartCapture mocks base method.
+func (m *MockPacketCapturer) StartCapture(arg0 control.CaptureFilter, arg1 int) (control.PacketCapture, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartCapture", arg0, arg1)
+	ret0, _ := ret[0].(control.PacketCapture)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartCapture indicates an expected call of StartCapture.
+func (mr *MockPacketCapturerMockRecorder) StartCapture(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartCapture", reflect.TypeOf((*MockPacketCapturer)(nil).StartCapture), arg0, arg1)
+}
+
+// MockTunableDataplane is a mock of TunableDataplane interface.
+type MockTunableDataplane struct {
+	ctrl     *gomock.Controller
+	recorder *MockTunableDataplaneMockRecorder
+}
+
+// MockTunableDataplaneMockRecorder is the mock recorder for MockTunableDataplane.
+type MockTunableDataplaneMockRecorder struct {
+	mock *MockTunableDataplane
+}
+
+// NewMockTunableDataplane creates a new mock instance.
+func NewMockTunableDataplane(ctrl *gomock.Controller) *MockTunableDataplane {
+	mock := &MockTunableDataplane{ctrl: ctrl}
+	mock.recorder = &MockTunableDataplaneMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTunableDataplane) EXPECT() *MockTunableDataplaneMockRecorder {
+	return m.recorder
+}
+
+// ListRateLimits mocks base method.
+func (m *MockTunableDataplane) ListRateLimits() ([]control.RateLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRateLimits")
+	ret0, _ := ret[0].([]control.RateLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRateLimits indicates an expected call of ListRateLimits.
+func (mr *MockTunableDataplaneMockRecorder) ListRateLimits() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRateLimits", reflect.TypeOf((*MockTunableDataplane)(nil).ListRateLimits))
+}
+
+// SetRateLimit mocks base method.
+func (m *MockTunableDataplane) SetRateLimit(arg0 control.RateLimit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRateLimit", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRateLimit indicates an expected call of SetRateLimit.
+func (mr *MockTunableDataplaneMockRecorder) SetRateLimit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRateLimit", reflect.TypeOf((*MockTunableDataplane)(nil).SetRateLimit), arg0)
+}
@@ -22,8 +22,6 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
-	"hash"
-	"math"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -40,7 +38,6 @@ import (
 	"github.com/scionproto/scion/pkg/private/processmetrics"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/util"
-	"github.com/scionproto/scion/pkg/scrypto"
 	"github.com/scionproto/scion/pkg/slayers"
 	"github.com/scionproto/scion/pkg/slayers/path"
 	"github.com/scionproto/scion/pkg/slayers/path/empty"
@@ -223,12 +220,13 @@ func makePacketPool(poolSize, headroom int) PacketPool {
 // (after updating the path, if that is needed).
 type dataPlane struct {
 	underlays           map[string]UnderlayProvider
-	interfaces          [math.MaxUint16 + 1]Link
+	fwd                 *atomic.Pointer[forwardingTable]
+	captures            atomic.Pointer[captureSet]
+	captureMtx          sync.Mutex
+	droppedSamples      *droppedSamples
 	numInterfaces       int
-	linkTypes           [math.MaxUint16 + 1]topology.LinkType
-	neighborIAs         [math.MaxUint16 + 1]addr.IA
 	localHost           addr.Host
-	macFactory          func() hash.Hash
+	macKeys             atomic.Pointer[macKeySet]
 	localIA             addr.IA
 	mtx                 sync.Mutex
 	running             atomic.Bool
@@ -256,6 +254,19 @@ type dataPlane struct {
 	// link layer header. Underlay providers may use the preceding part of the packet buffer to
 	// receive the link layer header.
 	underlayHeadroom int
+
+	// What Run set up, so that links can be added to the running dataplane.
+	runCtx     context.Context
+	procQs     []chan *Packet
+	slowQs     []chan *Packet
+	numPackets int
+
+	// Changes to the interfaces of the running dataplane; see CommitInterfaces.
+	staged        *forwardingTable
+	linksToStart  []Link
+	linksToRemove []Link
+	linkUnderlays map[Link]UnderlayProvider
+	barrierDone   sync.WaitGroup
 }
 
 var (
@@ -284,6 +295,10 @@ var (
 	errIngressInterfaceInvalid       = errors.New("ingress interface invalid")
 	errMacVerificationFailed         = errors.New("MAC verification failed")
 	errBadPacketSize                 = errors.New("bad packet size")
+	errNotExternal                   = errors.New("not an external interface")
+	errNoSuchInterface               = errors.New("no such interface")
+	errRemoveInternal                = errors.New("the internal interface cannot be removed")
+	errInsufficientHeadroom          = errors.New("insufficient headroom for underlay")
 
 	// zeroBuffer will be used to reset the Authenticator option in the
 	// scionPacketProcessor.OptAuth
@@ -305,7 +320,7 @@ type drkeyProvider interface {
 // newDataPlane returns a zero-valued data plane structure. The difference between
 // that and &dataPlane{} is that there are no nil pointers (i.e. maps are empty but exist and some
 // key objects like the underlay provider have been created) except for such things that cannot be
-// initialized at the beginning (i.e. packet pool and macFactory). Do not use a true zero valued
+// initialized at the beginning (i.e. packet pool and MAC keys). Do not use a true zero valued
 // struct for anything. Support for lazy initialization has been removed. It was much too
 // bug-friendly.
 func newDataPlane(runConfig RunConfig, authSCMP bool) *dataPlane {
@@ -329,6 +344,9 @@ func makeDataPlane(runConfig RunConfig, authSCMP bool) dataPlane {
 				runConfig.ReceiveBufferSize,
 			),
 		},
+		fwd:                            newForwarding(),
+		droppedSamples:                 &droppedSamples{},
+		linkUnderlays:                  make(map[Link]UnderlayProvider),
 		Metrics:                        metrics,
 		ExperimentalSCMPAuthentication: authSCMP,
 		RunConfig:                      runConfig,
@@ -384,7 +402,8 @@ func (d *dataPlane) SetIA(ia addr.IA) error {
 }
 
 // SetKey sets the key used for MAC verification. The key provided here should
-// already be derived as in scrypto.HFMacFactory.
+// already be derived as in scrypto.HFMacFactory. Use SetKeys to replace the key, or to add
+// grace keys.
 func (d *dataPlane) SetKey(key []byte) error {
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
@@ -394,17 +413,14 @@ func (d *dataPlane) SetKey(key []byte) error {
 	if len(key) == 0 {
 		return errEmptyValue
 	}
-	if d.macFactory != nil {
+	if d.macKeys.Load() != nil {
 		return errAlreadySet
 	}
-	// First check for MAC creation errors.
-	if _, err := scrypto.InitMac(key); err != nil {
+	set, err := d.newMACKeySet([]control.MACKey{{Key: key}})
+	if err != nil {
 		return err
 	}
-	d.macFactory = func() hash.Hash {
-		mac, _ := scrypto.InitMac(key)
-		return mac
-	}
+	d.macKeys.Store(set)
 	return nil
 }
 
@@ -424,7 +440,8 @@ func (d *dataPlane) AddInternalInterface(localHost addr.Host, provider, localAdd
 	if d.isRunning() {
 		return errModifyExisting
 	}
-	if d.interfaces[0] != nil {
+	t := d.fwd.Load()
+	if t.interfaces[0] != nil {
 		return serrors.JoinNoStack(errAlreadySet, nil, "ifID", 0)
 	}
 
@@ -434,12 +451,13 @@ func (d *dataPlane) AddInternalInterface(localHost addr.Host, provider, localAdd
 	if internalUnderlay == nil {
 		return serrors.JoinNoStack(errNoSuchUnderlay, nil, "provider", provider)
 	}
-	iMetrics := newInterfaceMetrics(d.Metrics, 0, d.localIA, "", d.neighborIAs[0])
+	iMetrics := newInterfaceMetrics(d.Metrics, 0, d.localIA, "", t.neighborIAs[0])
 	lk, err := internalUnderlay.NewInternalLink(localAddr, d.RunConfig.BatchSize, iMetrics)
 	if err != nil {
 		return err
 	}
-	d.interfaces[0] = lk
+	t.interfaces[0] = lk
+	d.linkUnderlays[lk] = internalUnderlay
 	d.numInterfaces++
 	d.localHost = localHost
 
@@ -448,43 +466,34 @@ func (d *dataPlane) AddInternalInterface(localHost addr.Host, provider, localAdd
 
 // AddExternalInterface adds the inter AS connection for the given interface ID.
 // If a connection for the given ID is already set this method will return an
-// error. This can only be called on a not yet running dataplane.
+// error. On a running dataplane, the interface is only used once the change is committed; see
+// CommitInterfaces.
 func (d *dataPlane) AddExternalInterface(
 	ifID uint16, link control.LinkInfo, localHost, remoteHost addr.Host,
 ) error {
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
 
-	if d.isRunning() {
-		return errModifyExisting
-	}
+	// The link being replaced, if any, must be gone before its addresses can be reused.
+	d.commitRemovals()
+	t := d.editTable()
 	bfd, err := d.newExternalInterfaceBFD(ifID, link, localHost, remoteHost)
 	if err != nil {
 		return serrors.Wrap("adding external BFD", err, "if_id", ifID)
 	}
-	if d.interfaces[ifID] != nil {
+	if t.interfaces[ifID] != nil {
 		return serrors.JoinNoStack(errAlreadySet, nil, "ifID", ifID)
 	}
 	if link.Remote.Addr == "" {
 		return errEmptyValue
 	}
-
-	underlay, instantiated := d.underlays[link.Provider]
-	if !instantiated {
-		underlayProvider, exists := underlayProviders[link.Provider]
-		if !exists {
-			panic(fmt.Sprintf("no provider for underlay: %q", link.Provider))
-		}
-		underlay = underlayProvider(
-			d.RunConfig.BatchSize,
-			d.RunConfig.SendBufferSize,
-			d.RunConfig.ReceiveBufferSize,
-		)
-		d.underlays[link.Provider] = underlay
+	underlay, err := d.getUnderlay(link.Provider)
+	if err != nil {
+		return err
 	}
-	d.linkTypes[ifID] = link.LinkTo
+	t.linkTypes[ifID] = link.LinkTo
 
-	iMetrics := newInterfaceMetrics(d.Metrics, ifID, d.localIA, "", d.neighborIAs[ifID])
+	iMetrics := newInterfaceMetrics(d.Metrics, ifID, d.localIA, "", t.neighborIAs[ifID])
 	lk, err := underlay.NewExternalLink(
 		d.RunConfig.BatchSize,
 		bfd,
@@ -495,27 +504,46 @@ func (d *dataPlane) AddExternalInterface(
 	if err != nil {
 		return err
 	}
-	d.interfaces[ifID] = lk
-	d.numInterfaces++
+	d.addLink(t, ifID, lk, underlay)
+	t.rateLimits[ifID] = &interfaceRateLimits{}
+	return nil
+}
+
+// SetRateLimit installs the given rate limit on the given external interface. A zero rate removes
+// the limit. Unlike most settings, rate limits can be changed while the dataplane is running.
+func (d *dataPlane) SetRateLimit(limit control.RateLimit) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	// The limiters are safe for concurrent use and an interface keeps its limiters until it is
+	// removed. So, they can be changed in place, even in the forwarding table in use.
+	limits := d.editTable().rateLimits[limit.IfID]
+	if limits == nil {
+		return serrors.JoinNoStack(errNotExternal, nil, "ifID", limit.IfID)
+	}
+	dl := limits.direction(limit.Direction)
+	l := &dl.all
+	if limit.TrafficClass != nil {
+		l = &dl.classes[*limit.TrafficClass]
+	}
+	l.set(limit.Rate, limit.Burst)
 	return nil
 }
 
 // AddNeighborIA adds the neighboring IA for a given interface ID. If an IA for
-// the given ID is already set, this method will return an error. This can only
-// be called on a not yet running dataplane.
+// the given ID is already set, this method will return an error. On a running dataplane, this
+// takes effect when the change is committed; see CommitInterfaces.
 func (d *dataPlane) AddNeighborIA(ifID uint16, remote addr.IA) error {
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
-	if d.isRunning() {
-		return errModifyExisting
-	}
 	if remote.IsZero() {
 		return errEmptyValue
 	}
-	if !d.neighborIAs[ifID].IsZero() {
+	d.commitRemovals()
+	t := d.editTable()
+	if !t.neighborIAs[ifID].IsZero() {
 		return serrors.JoinNoStack(errAlreadySet, nil, "ifID", ifID)
 	}
-	d.neighborIAs[ifID] = remote
+	t.neighborIAs[ifID] = remote
 	return nil
 }
 
@@ -540,7 +568,7 @@ func (d *dataPlane) newExternalInterfaceBFD(
 			PacketsReceived: d.Metrics.BFDPacketsReceived.With(labels),
 		}
 	}
-	s, err := newBFDSend(d, link, localHost, remoteHost, ifID, false, d.macFactory())
+	s, err := newBFDSend(d, link, localHost, remoteHost, ifID, false)
 	if err != nil {
 		return nil, err
 	}
@@ -551,7 +579,7 @@ func (d *dataPlane) newExternalInterfaceBFD(
 // returns InterfaceUp if the relevant BFDSession state is up, or if there is no BFD
 // session. Otherwise, it returns InterfaceDown.
 func (d *dataPlane) getInterfaceState(ifID uint16) control.InterfaceState {
-	if link := d.interfaces[ifID]; link != nil && !link.IsUp() {
+	if link := d.fwd.Load().interfaces[ifID]; link != nil && !link.IsUp() {
 		return control.InterfaceDown
 	}
 	return control.InterfaceUp
@@ -592,8 +620,8 @@ func (d *dataPlane) DelSvc(svc addr.SVC, host addr.Host, port uint16) error {
 }
 
 // AddNextHop sets the next hop address for the given interface ID. If the
-// interface ID already has an address associated this operation fails. This can
-// only be called on a not yet running dataplane.
+// interface ID already has an address associated this operation fails. On a running dataplane,
+// the interface is only used once the change is committed; see CommitInterfaces.
 func (d *dataPlane) AddNextHop(
 	ifID uint16,
 	link control.LinkInfo,
@@ -602,46 +630,36 @@ func (d *dataPlane) AddNextHop(
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
 
-	if d.isRunning() {
-		return errModifyExisting
-	}
+	// A sibling link being removed must be gone before a new one to the same router is made.
+	d.commitRemovals()
+	t := d.editTable()
 	bfd, err := d.newNextHopBFD(ifID, link, localHost, remoteHost)
 	if err != nil {
 		return serrors.Wrap("adding next hop BFD", err, "if_id", ifID)
 	}
-	if d.interfaces[ifID] != nil {
+	if t.interfaces[ifID] != nil {
 		return serrors.JoinNoStack(errAlreadySet, nil, "ifID", ifID)
 	}
 	if link.Remote.Addr == "" {
 		return errEmptyValue
 	}
-	underlay, instantiated := d.underlays[link.Provider]
-	if !instantiated {
-		underlayProvider, exists := underlayProviders[link.Provider]
-		if !exists {
-			panic(fmt.Sprintf("no provider for underlay: %q", link.Provider))
-		}
-		underlay = underlayProvider(
-			d.RunConfig.BatchSize,
-			d.RunConfig.SendBufferSize,
-			d.RunConfig.ReceiveBufferSize,
-		)
-		d.underlays[link.Provider] = underlay
+	underlay, err := d.getUnderlay(link.Provider)
+	if err != nil {
+		return err
 	}
-	d.linkTypes[ifID] = link.LinkTo
+	t.linkTypes[ifID] = link.LinkTo
 
 	// Note that a link to the same sibling router might already exist. If so, it will be
 	// returned instead of creating a new one. As a result, the bfd session and metrics will be
 	// ignored and simply garbage collected.
 	iMetrics := newInterfaceMetrics(
-		d.Metrics, ifID, d.localIA, link.Remote.Addr, d.neighborIAs[ifID])
+		d.Metrics, ifID, d.localIA, link.Remote.Addr, t.neighborIAs[ifID])
 	lk, err := underlay.NewSiblingLink(
 		d.RunConfig.BatchSize, bfd, link.Local.Addr, link.Remote.Addr, iMetrics)
 	if err != nil {
 		return err
 	}
-	d.interfaces[ifID] = lk
-	d.numInterfaces++
+	d.addLink(t, ifID, lk, underlay)
 	return nil
 }
 
@@ -665,7 +683,7 @@ func (d *dataPlane) newNextHopBFD(
 		}
 	}
 
-	s, err := newBFDSend(d, link, localHost, remoteHost, ifID, true, d.macFactory())
+	s, err := newBFDSend(d, link, localHost, remoteHost, ifID, true)
 	if err != nil {
 		return nil, err
 	}
@@ -713,7 +731,14 @@ func (d *dataPlane) Run(ctx context.Context) error {
 	)
 	d.initPacketPool(processorQueueSize)
 	procQs, slowQs := d.initQueues(processorQueueSize)
+	d.runCtx = ctx
+	d.procQs = procQs
+	d.slowQs = slowQs
 	d.setRunning()
+	go func() {
+		defer log.HandlePanic()
+		d.droppedSamples.run(ctx)
+	}()
 	for _, u := range d.underlays {
 		u.Start(ctx, d.packetPool, procQs)
 	}
@@ -758,12 +783,22 @@ func (d *dataPlane) initPacketPool(processorQueueSize int) {
 		headroom = minHeadroom
 	}
 	log.Debug("Initialize packet pool", "poolSize", poolSize, "headroom", headroom)
-	d.packetPool = makePacketPool(poolSize, headroom)
-	pktBuffers := make([][bufSize]byte, poolSize)
-	pktStructs := make([]Packet, poolSize)
-	for i := 0; i < poolSize; i++ {
+	// The pool can grow to twice its initial size as links are added to the running dataplane.
+	d.packetPool = makePacketPool(2*poolSize, headroom)
+	d.addPackets(poolSize)
+}
+
+// addPackets allocates n more packets and adds them to the pool, as far as it has room for them.
+// It returns the number of packets actually added.
+func (d *dataPlane) addPackets(n int) int {
+	n = min(n, cap(d.packetPool.pool)-d.numPackets)
+	pktBuffers := make([][bufSize]byte, n)
+	pktStructs := make([]Packet, n)
+	for i := 0; i < n; i++ {
 		d.packetPool.Put(pktStructs[i].init(&pktBuffers[i]))
 	}
+	d.numPackets += n
+	return n
 }
 
 // initializes the processing routines and queues
@@ -782,12 +817,24 @@ func (d *dataPlane) initQueues(processorQueueSize int) ([]chan *Packet, []chan *
 func (d *dataPlane) runProcessor(id int, q <-chan *Packet, slowQ chan<- *Packet) {
 	log.Debug("Initialize processor with", "id", id)
 	processor := newPacketProcessor(d)
+	// Captures are staged here when the packet has to be captured before its fate is known.
+	var staged stagedCaptures
+	recorder := d.droppedSamples.newRecorder()
 	for d.isRunning() {
+		if len(q) == 0 {
+			// About to wait for packets; report the MAC verifications counted so far.
+			processor.macs.flush()
+		}
 		p, ok := <-q
 		if !ok {
 			continue
 		}
+		if p == barrier {
+			d.barrierDone.Done()
+			continue
+		}
 		disp := processor.processPkt(p)
+		reason := processor.dropReason
 
 		sc := ClassOfSize(len(p.RawPacket))
 		metrics := p.Link.Metrics()
@@ -797,11 +844,23 @@ func (d *dataPlane) runProcessor(id int, q <-chan *Packet, slowQ chan<- *Packet)
 		case pForward:
 			// Normal processing proceeds.
 		case pSlowPath:
-			// Not an error, processing continues on the slow path.
+			// Processing continues on the slow path. If that is to report an error to the sender,
+			// the packet itself is dropped and accounted for here. The packet must be accounted
+			// for and captured before it is queued; it belongs to the slow path processor after
+			// that.
+			if reason != dropNone {
+				d.countDrop(p, metrics, sc, reason, recorder)
+			}
+			d.stageCapture(p, nil, 0, &staged)
 			select {
 			case slowQ <- p:
+				staged.publish(control.SlowPath, reason)
 			default:
-				metrics[sc].DroppedPacketsBusySlowPath.Inc()
+				if reason == dropNone {
+					reason = DropBusySlowPath
+					d.countDrop(p, metrics, sc, reason, recorder)
+				}
+				staged.publish(control.Dropped, reason)
 				d.packetPool.Put(p)
 			}
 			continue
@@ -809,7 +868,12 @@ func (d *dataPlane) runProcessor(id int, q <-chan *Packet, slowQ chan<- *Packet)
 			d.packetPool.Put(p)
 			continue
 		case pDiscard: // Everything else
-			metrics[sc].DroppedPacketsInvalid.Inc()
+			if reason == dropNone {
+				// All drops should have a reason, but we can't afford to panic.
+				reason = DropInvalid
+			}
+			d.capture(p, nil, 0, control.Dropped, reason)
+			d.countDrop(p, metrics, sc, reason, recorder)
 			d.packetPool.Put(p)
 			continue
 		default: // Newly added dispositions need to be handled.
@@ -817,33 +881,77 @@ func (d *dataPlane) runProcessor(id int, q <-chan *Packet, slowQ chan<- *Packet)
 			d.packetPool.Put(p)
 			continue
 		}
-		fwLink := d.interfaces[p.egress]
+		fwLink := processor.fwd.interfaces[p.egress]
 		if fwLink == nil {
 			log.Debug("Error determining forwarder. Egress is invalid", "egress", p.egress)
+			d.capture(p, nil, 0, control.Dropped, DropInvalidEgress)
+			d.countDrop(p, metrics, sc, DropInvalidEgress, recorder)
+			d.packetPool.Put(p)
+			continue
+		}
+		// Only the traffic that transits through an external interface is subject to the rate
+		// limits. Internal and sibling links have none.
+		// A packet that is dropped by the egress limit is not charged to the ingress limit.
+		tc := processor.scionLayer.TrafficClass
+		ingressLimits := processor.fwd.rateLimits[processor.ingressFromLink]
+		if ingressLimits != nil && !ingressLimits.ingress.allow(tc, len(p.RawPacket)) {
+			d.capture(p, fwLink, p.egress, control.Dropped, DropRateLimited)
+			d.countDrop(p, metrics, sc, DropRateLimited, recorder)
+			d.packetPool.Put(p)
+			continue
+		}
+		egressLimits := processor.fwd.rateLimits[p.egress]
+		if egressLimits != nil && !egressLimits.egress.allow(tc, len(p.RawPacket)) {
+			if ingressLimits != nil {
+				ingressLimits.ingress.refund(tc, len(p.RawPacket))
+			}
+			d.capture(p, fwLink, p.egress, control.Dropped, DropRateLimited)
+			d.countDrop(p, fwLink.Metrics(), sc, DropRateLimited, recorder)
 			d.packetPool.Put(p)
-			metrics[sc].DroppedPacketsInvalid.Inc()
 			continue
 		}
+		d.stageCapture(p, fwLink, p.egress, &staged)
 		if !fwLink.Send(p) {
+			// The packet is not sent, so it does not count against the limits.
+			if ingressLimits != nil {
+				ingressLimits.ingress.refund(tc, len(p.RawPacket))
+			}
+			if egressLimits != nil {
+				egressLimits.egress.refund(tc, len(p.RawPacket))
+			}
+			staged.publish(control.Dropped, DropBusyForwarder)
+			d.countDrop(p, metrics, sc, DropBusyForwarder, recorder)
 			d.packetPool.Put(p)
-			metrics[sc].DroppedPacketsBusyForwarder.Inc()
+			continue
 		}
+		staged.publish(control.Forwarded, dropNone)
 	}
 }
 
 func (d *dataPlane) runSlowPathProcessor(id int, q <-chan *Packet) {
 	log.Debug("Initialize slow-path processor with", "id", id)
 	processor := newSlowPathProcessor(d)
+	var staged stagedCaptures
+	recorder := d.droppedSamples.newRecorder()
 	for d.isRunning() {
 		p, ok := <-q
 		if !ok {
 			continue
 		}
+		if p == barrier {
+			d.barrierDone.Done()
+			continue
+		}
 		err := processor.processPacket(p)
 		if err != nil {
 			log.Debug("Error processing packet", "err", err)
-			sc := ClassOfSize(len(p.RawPacket))
-			p.Link.Metrics()[sc].DroppedPacketsInvalid.Inc()
+			d.capture(p, nil, 0, control.Dropped, DropInvalid)
+			// Packets that were to be answered with an SCMP error have been accounted for as
+			// dropped already.
+			if p.slowPathRequest.spType < slowPathSCMP {
+				sc := ClassOfSize(len(p.RawPacket))
+				d.countDrop(p, p.Link.Metrics(), sc, DropInvalid, recorder)
+			}
 			d.packetPool.Put(p)
 			continue
 		}
@@ -857,9 +965,15 @@ func (d *dataPlane) runSlowPathProcessor(id int, q <-chan *Packet) {
 			d.packetPool.Put(p)
 			continue
 		}
+		d.stageCapture(p, egressLink, egressLink.IfID(), &staged)
 		if !egressLink.Send(p) {
+			staged.publish(control.Dropped, DropBusyForwarder)
+			sc := ClassOfSize(len(p.RawPacket))
+			d.countDrop(p, egressLink.Metrics(), sc, DropBusyForwarder, recorder)
 			d.packetPool.Put(p)
+			continue
 		}
+		staged.publish(control.Forwarded, dropNone)
 	}
 }
 
@@ -975,9 +1089,9 @@ func (p *slowPathPacketProcessor) processPacket(pkt *Packet) error {
 func newPacketProcessor(d *dataPlane) *scionPacketProcessor {
 	p := &scionPacketProcessor{
 		d:              d,
-		mac:            d.macFactory(),
 		macInputBuffer: make([]byte, max(path.MACBufferSize, libepic.MACBufferSize)),
 	}
+	p.macs.update(d.macKeys.Load())
 	p.scionLayer.RecyclePaths()
 	return p
 }
@@ -991,8 +1105,8 @@ func (p *scionPacketProcessor) reset() error {
 	p.infoField = path.InfoField{}
 	p.effectiveXover = false
 	p.peering = false
-	p.mac.Reset()
 	p.cachedMac = nil
+	p.dropReason = dropNone
 	// Reset hbh layer
 	p.hbhLayer = slayers.HopByHopExtnSkipper{}
 	// Reset e2e layer
@@ -1000,25 +1114,28 @@ func (p *scionPacketProcessor) reset() error {
 	return nil
 }
 
-// Convenience function to log an error and return the pDiscard disposition.
-// We do almost nothing with errors, so, we shouldn't invest in creating them.
-func errorDiscard(ctx ...any) disposition {
-	log.Debug("Discarding packet", ctx...)
+// Convenience function to log an error, record the reason for the drop, and return the pDiscard
+// disposition. We do almost nothing with errors, so, we shouldn't invest in creating them.
+func (p *scionPacketProcessor) discard(reason DropReason, err error) disposition {
+	log.Debug("Discarding packet", "reason", reason, "error", err)
+	p.dropReason = reason
 	return pDiscard
 }
 
 func (p *scionPacketProcessor) processPkt(pkt *Packet) disposition {
 	if err := p.reset(); err != nil {
-		return errorDiscard("error", err)
+		return p.discard(DropInvalid, err)
 	}
 	p.pkt = pkt
 	p.ingressFromLink = pkt.Link.IfID()
+	p.fwd = p.d.fwd.Load()
+	p.macs.update(p.d.macKeys.Load())
 
 	// parse SCION header and skip extensions;
 	var err error
 	p.lastLayer, err = decodeLayers(pkt.RawPacket, &p.scionLayer, &p.hbhLayer, &p.e2eLayer)
 	if err != nil {
-		return errorDiscard("error", err)
+		return p.discard(DropMalformedHeader, err)
 	}
 
 	pld := p.lastLayer.LayerPayload()
@@ -1029,13 +1146,13 @@ func (p *scionPacketProcessor) processPkt(pkt *Packet) disposition {
 		if p.lastLayer.NextLayerType() == layers.LayerTypeBFD {
 			return p.processBFD(pld)
 		}
-		return errorDiscard("error", errUnsupportedPathTypeNextHeader)
+		return p.discard(DropUnsupportedPathType, errUnsupportedPathTypeNextHeader)
 
 	case onehop.PathType:
 		if p.lastLayer.NextLayerType() == layers.LayerTypeBFD {
 			_, ok := p.scionLayer.Path.(*onehop.Path)
 			if !ok {
-				return errorDiscard("error", errMalformedPath)
+				return p.discard(DropMalformedPath, errMalformedPath)
 			}
 			return p.processBFD(pld)
 		}
@@ -1045,18 +1162,18 @@ func (p *scionPacketProcessor) processPkt(pkt *Packet) disposition {
 	case epic.PathType:
 		return p.processEPIC()
 	default:
-		return errorDiscard("error", errUnsupportedPathType)
+		return p.discard(DropUnsupportedPathType, errUnsupportedPathType)
 	}
 }
 
 func (p *scionPacketProcessor) processBFD(data []byte) disposition {
 	session := p.pkt.Link.BFDSession()
 	if session == nil {
-		return errorDiscard("error", errNoBFDSessionFound)
+		return p.discard(DropNoBFDSession, errNoBFDSessionFound)
 	}
 	bfd := &p.bfdLayer
 	if err := bfd.DecodeFromBytes(data, gopacket.NilDecodeFeedback); err != nil {
-		return errorDiscard("error", err)
+		return p.discard(DropMalformedHeader, err)
 	}
 	session.ReceiveMessage(bfd)
 	return pDone // All's fine. That packet's journey ends here.
@@ -1067,7 +1184,7 @@ func (p *scionPacketProcessor) processSCION() disposition {
 	p.path, ok = p.scionLayer.Path.(*scion.Raw)
 	if !ok {
 		// TODO(lukedirtwalker) parameter problem invalid path?
-		return errorDiscard("error", errMalformedPath)
+		return p.discard(DropMalformedPath, errMalformedPath)
 	}
 	return p.process()
 }
@@ -1075,12 +1192,12 @@ func (p *scionPacketProcessor) processSCION() disposition {
 func (p *scionPacketProcessor) processEPIC() disposition {
 	epicPath, ok := p.scionLayer.Path.(*epic.Path)
 	if !ok {
-		return errorDiscard("error", errMalformedPath)
+		return p.discard(DropMalformedPath, errMalformedPath)
 	}
 
 	p.path = epicPath.ScionPath
 	if p.path == nil {
-		return errorDiscard("error", errMalformedPath)
+		return p.discard(DropMalformedPath, errMalformedPath)
 	}
 
 	isPenultimate := p.path.IsPenultimateHop()
@@ -1094,14 +1211,14 @@ func (p *scionPacketProcessor) processEPIC() disposition {
 	if isPenultimate || isLast {
 		firstInfo, err := p.path.GetInfoField(0)
 		if err != nil {
-			return errorDiscard("error", err)
+			return p.discard(DropMalformedPath, err)
 		}
 
 		timestamp := time.Unix(int64(firstInfo.Timestamp), 0)
 		err = libepic.VerifyTimestamp(timestamp, epicPath.PktID.Timestamp, time.Now())
 		if err != nil {
 			// TODO(mawyss): Send back SCMP packet
-			return errorDiscard("error", err)
+			return p.discard(DropInvalidEPIC, err)
 		}
 
 		HVF := epicPath.PHVF
@@ -1112,7 +1229,7 @@ func (p *scionPacketProcessor) processEPIC() disposition {
 			&p.scionLayer, firstInfo.Timestamp, HVF, p.macInputBuffer[:libepic.MACBufferSize])
 		if err != nil {
 			// TODO(mawyss): Send back SCMP packet
-			return errorDiscard("error", err)
+			return p.discard(DropInvalidEPIC, err)
 		}
 	}
 
@@ -1123,11 +1240,12 @@ func (p *scionPacketProcessor) processEPIC() disposition {
 // scionPacketProcessor processes packets. It contains pre-allocated per-packet
 // mutable state and context information which should be reused.
 type scionPacketProcessor struct {
-	d               *dataPlane    // The dataplane instance that initiated this processor.
-	pkt             *Packet       // Packet currently being processed by this processor.
-	ingressFromLink uint16        // IfID associated with the ingress link, if any.
-	mac             hash.Hash     // hasher for the MAC computation.
-	scionLayer      slayers.SCION // scionLayer is the SCION gopacket layer.
+	d               *dataPlane       // The dataplane instance that initiated this processor.
+	fwd             *forwardingTable // The forwarding table in use for the current packet.
+	pkt             *Packet          // Packet currently being processed by this processor.
+	ingressFromLink uint16           // IfID associated with the ingress link, if any.
+	macs            macHashes        // hashers for the MAC computation, one per key.
+	scionLayer      slayers.SCION    // scionLayer is the SCION gopacket layer.
 	hbhLayer        slayers.HopByHopExtnSkipper
 	e2eLayer        slayers.EndToEndExtnSkipper
 	lastLayer       gopacket.DecodingLayer // Last parsed layer: &scionLayer, &hbhLayer or &e2eLayer
@@ -1139,6 +1257,7 @@ type scionPacketProcessor struct {
 	cachedMac       []byte                 // Full MAC. For a Xover, that of the down segment.
 	macInputBuffer  []byte                 // Reusable buffer for MAC computation.
 	bfdLayer        layers.BFD             // Reusable buffer for parsing BFD messages
+	dropReason      DropReason             // Why the packet isn't forwarded, if it isn't.
 }
 
 type slowPathType int8
@@ -1187,12 +1306,12 @@ func (p *scionPacketProcessor) parsePath() disposition {
 	p.hopField, err = p.path.GetCurrentHopField()
 	if err != nil {
 		// TODO(lukedirtwalker) parameter problem invalid path?
-		return errorDiscard("error", err)
+		return p.discard(DropMalformedPath, err)
 	}
 	p.infoField, err = p.path.GetCurrentInfoField()
 	if err != nil {
 		// TODO(lukedirtwalker) parameter problem invalid path?
-		return errorDiscard("error", err)
+		return p.discard(DropMalformedPath, err)
 	}
 	// Segments without the Peering flag must consist of at least two HFs:
 	// https://github.com/scionproto/scion/issues/4524
@@ -1200,10 +1319,10 @@ func (p *scionPacketProcessor) parsePath() disposition {
 		p.path.PathMeta.SegLen[1] == 1 ||
 		p.path.PathMeta.SegLen[2] == 1
 	if !p.infoField.Peer && hasSingletonSegment {
-		return errorDiscard("error", errMalformedPath)
+		return p.discard(DropMalformedPath, errMalformedPath)
 	}
 	if !p.path.CurrINFMatchesCurrHF() {
-		return errorDiscard("error", errMalformedPath)
+		return p.discard(DropMalformedPath, errMalformedPath)
 	}
 	return pForward
 }
@@ -1236,7 +1355,7 @@ func (p *scionPacketProcessor) determinePeer() disposition {
 	peer, err := determinePeer(p.path.PathMeta, p.infoField)
 	p.peering = peer
 	if err != nil {
-		return errorDiscard("error", err)
+		return p.discard(DropMalformedPath, err)
 	}
 	return pForward
 }
@@ -1251,6 +1370,7 @@ func (p *scionPacketProcessor) validateHopExpiry() disposition {
 	log.Debug("SCMP response", "cause", errExpiredHop,
 		"cons_dir", p.infoField.ConsDir, "if_id", p.ingressFromLink,
 		"curr_inf", p.path.PathMeta.CurrINF, "curr_hf", p.path.PathMeta.CurrHF)
+	p.dropReason = DropExpiredHop
 	p.pkt.slowPathRequest = slowPathRequest{
 		spType:  slowPathType(slayers.SCMPTypeParameterProblem),
 		code:    slayers.SCMPCodePathExpired,
@@ -1269,6 +1389,7 @@ func (p *scionPacketProcessor) validateIngressID() disposition {
 	if p.ingressFromLink != 0 && p.ingressFromLink != hdrIngressID {
 		log.Debug("SCMP response", "cause", errIngressInterfaceInvalid,
 			"pkt_ingress", hdrIngressID, "router_ingress", p.ingressFromLink)
+		p.dropReason = DropInvalidIngress
 		p.pkt.slowPathRequest = slowPathRequest{
 			spType:  slowPathType(slayers.SCMPTypeParameterProblem),
 			code:    errCode,
@@ -1308,6 +1429,7 @@ func (p *scionPacketProcessor) validateSrcDstIA() disposition {
 // invalidSrcIA is a helper to return an SCMP error for an invalid SrcIA.
 func (p *scionPacketProcessor) respInvalidSrcIA() disposition {
 	log.Debug("SCMP response", "cause", errInvalidSrcIA)
+	p.dropReason = DropInvalidSrcIA
 	p.pkt.slowPathRequest = slowPathRequest{
 		spType:  slowPathType(slayers.SCMPTypeParameterProblem),
 		code:    slayers.SCMPCodeInvalidSourceAddress,
@@ -1319,6 +1441,7 @@ func (p *scionPacketProcessor) respInvalidSrcIA() disposition {
 // invalidDstIA is a helper to return an SCMP error for an invalid DstIA.
 func (p *scionPacketProcessor) respInvalidDstIA() disposition {
 	log.Debug("SCMP response", "cause", errInvalidDstIA)
+	p.dropReason = DropInvalidDstIA
 	p.pkt.slowPathRequest = slowPathRequest{
 		spType:  slowPathType(slayers.SCMPTypeParameterProblem),
 		code:    slayers.SCMPCodeInvalidDestinationAddress,
@@ -1336,14 +1459,14 @@ func (p *scionPacketProcessor) validateTransitUnderlaySrc() disposition {
 		// Locally originated traffic, or came in via an external link. Not our concern.
 		return pForward
 	}
-	pktIngressID := p.ingressInterface()        // Where this was *supposed* to enter the AS
-	ingressLink := p.d.interfaces[pktIngressID] // Our own link to *that* sibling router
+	pktIngressID := p.ingressInterface()          // Where this was *supposed* to enter the AS
+	ingressLink := p.fwd.interfaces[pktIngressID] // Our own link to *that* sibling router
 
 	// Is that the link that the packet came through (e.g. not the internal link)? The
 	// comparison should be cheap. Links are implemented by pointers.
 	if ingressLink != p.pkt.Link {
 		// Drop
-		return errorDiscard("error", errInvalidSrcAddrForTransit)
+		return p.discard(DropInvalidSrcAddr, errInvalidSrcAddrForTransit)
 	}
 	return pForward
 }
@@ -1357,7 +1480,7 @@ func (p *scionPacketProcessor) validateTransitUnderlaySrc() disposition {
 // to another AS directly, or via a sibling router.
 func (p *scionPacketProcessor) validateEgressID() disposition {
 	egressID := p.pkt.egress
-	egressLink := p.d.interfaces[egressID]
+	egressLink := p.fwd.interfaces[egressID]
 
 	// egress interface must be a known interface
 	// egress is never the internal interface (already checked)
@@ -1369,6 +1492,7 @@ func (p *scionPacketProcessor) validateEgressID() disposition {
 			errCode = slayers.SCMPCodeUnknownHopFieldIngress
 		}
 		log.Debug("SCMP response", "cause", errCannotRoute)
+		p.dropReason = DropInvalidEgress
 		p.pkt.slowPathRequest = slowPathRequest{
 			spType:  slowPathType(slayers.SCMPTypeParameterProblem),
 			code:    errCode,
@@ -1377,7 +1501,7 @@ func (p *scionPacketProcessor) validateEgressID() disposition {
 		return pSlowPath
 	}
 
-	ingressLT, egressLT := p.d.linkTypes[p.ingressFromLink], p.d.linkTypes[egressID]
+	ingressLT, egressLT := p.fwd.linkTypes[p.ingressFromLink], p.fwd.linkTypes[egressID]
 	if !p.effectiveXover {
 		// No check required if the packet is received from an internal interface because that
 		// check was done by the ingress router.
@@ -1402,6 +1526,7 @@ func (p *scionPacketProcessor) validateEgressID() disposition {
 			log.Debug("SCMP response", "cause", errCannotRoute,
 				"ingress_id", p.ingressFromLink, "ingress_type", ingressLT,
 				"egress_id", egressID, "egress_type", egressLT)
+			p.dropReason = DropInvalidEgress
 			p.pkt.slowPathRequest = slowPathRequest{
 				spType:  slowPathType(slayers.SCMPTypeParameterProblem),
 				code:    slayers.SCMPCodeInvalidPath, // XXX(matzf) new code InvalidHop?,
@@ -1426,6 +1551,7 @@ func (p *scionPacketProcessor) validateEgressID() disposition {
 		log.Debug("SCMP response", "cause", errCannotRoute,
 			"ingress_id", p.ingressFromLink, "ingress_type", ingressLT,
 			"egress_id", egressID, "egress_type", egressLT)
+		p.dropReason = DropInvalidSegmentChange
 		p.pkt.slowPathRequest = slowPathRequest{
 			spType:  slowPathType(slayers.SCMPTypeParameterProblem),
 			code:    slayers.SCMPCodeInvalidSegmentChange,
@@ -1442,7 +1568,7 @@ func (p *scionPacketProcessor) updateNonConsDirIngressSegID() disposition {
 	if !p.infoField.ConsDir && p.ingressFromLink != 0 && !p.peering {
 		p.infoField.UpdateSegID(p.hopField.Mac)
 		if err := p.path.SetInfoField(p.infoField, int(p.path.PathMeta.CurrINF)); err != nil {
-			return errorDiscard("error", err)
+			return p.discard(DropMalformedPath, err)
 		}
 	}
 	return pForward
@@ -1459,14 +1585,17 @@ func (p *scionPacketProcessor) currentHopPointer() uint16 {
 }
 
 func (p *scionPacketProcessor) verifyCurrentMAC() disposition {
-	fullMac := path.FullMAC(p.mac, p.infoField, p.hopField, p.macInputBuffer[:path.MACBufferSize])
-	if subtle.ConstantTimeCompare(p.hopField.Mac[:path.MacLen], fullMac[:path.MacLen]) == 0 {
+	fullMac := p.macs.verify(p.infoField, p.hopField, p.macInputBuffer[:path.MACBufferSize])
+	if fullMac == nil {
+		expected := path.MAC(p.macs.current(), p.infoField, p.hopField,
+			p.macInputBuffer[:path.MACBufferSize])
 		log.Debug("SCMP response", "cause", errMacVerificationFailed,
-			"expected", fullMac[:path.MacLen],
+			"expected", expected[:],
 			"actual", p.hopField.Mac[:path.MacLen],
 			"cons_dir", p.infoField.ConsDir,
 			"if_id", p.ingressFromLink, "curr_inf", p.path.PathMeta.CurrINF,
 			"curr_hf", p.path.PathMeta.CurrHF, "seg_id", p.infoField.SegID)
+		p.dropReason = DropInvalidMAC
 		p.pkt.slowPathRequest = slowPathRequest{
 			spType:  slowPathType(slayers.SCMPTypeParameterProblem),
 			code:    slayers.SCMPCodeInvalidHopFieldMAC,
@@ -1490,6 +1619,7 @@ func (p *scionPacketProcessor) resolveInbound() disposition {
 		return pForward
 	case ErrNoSVCBackend:
 		log.Debug("SCMP response", "cause", err)
+		p.dropReason = DropNoSVCBackend
 		p.pkt.slowPathRequest = slowPathRequest{
 			spType: slowPathType(slayers.SCMPTypeDestinationUnreachable),
 			code:   slayers.SCMPCodeNoRoute,
@@ -1497,13 +1627,14 @@ func (p *scionPacketProcessor) resolveInbound() disposition {
 		return pSlowPath
 	case errInvalidDstAddr, ErrUnsupportedV4MappedV6Address, ErrUnsupportedUnspecifiedAddress:
 		log.Debug("SCMP response", "cause", err)
+		p.dropReason = DropInvalidDstAddr
 		p.pkt.slowPathRequest = slowPathRequest{
 			spType: slowPathType(slayers.SCMPTypeParameterProblem),
 			code:   slayers.SCMPCodeInvalidDestinationAddress,
 		}
 		return pSlowPath
 	default:
-		return errorDiscard("error", err)
+		return p.discard(DropInvalidDstAddr, err)
 	}
 }
 
@@ -1517,12 +1648,12 @@ func (p *scionPacketProcessor) processEgress() disposition {
 		p.infoField.UpdateSegID(p.hopField.Mac)
 		if err := p.path.SetInfoField(p.infoField, int(p.path.PathMeta.CurrINF)); err != nil {
 			// TODO parameter problem invalid path
-			return errorDiscard("error", err)
+			return p.discard(DropMalformedPath, err)
 		}
 	}
 	if err := p.path.IncPath(); err != nil {
 		// TODO parameter problem invalid path
-		return errorDiscard("error", err)
+		return p.discard(DropMalformedPath, err)
 	}
 	return pForward
 }
@@ -1531,16 +1662,16 @@ func (p *scionPacketProcessor) doXover() disposition {
 	p.effectiveXover = true
 	if err := p.path.IncPath(); err != nil {
 		// TODO parameter problem invalid path
-		return errorDiscard("error", err)
+		return p.discard(DropMalformedPath, err)
 	}
 	var err error
 	if p.hopField, err = p.path.GetCurrentHopField(); err != nil {
 		// TODO parameter problem invalid path
-		return errorDiscard("error", err)
+		return p.discard(DropMalformedPath, err)
 	}
 	if p.infoField, err = p.path.GetCurrentInfoField(); err != nil {
 		// TODO parameter problem invalid path
-		return errorDiscard("error", err)
+		return p.discard(DropMalformedPath, err)
 	}
 	return pForward
 }
@@ -1574,9 +1705,10 @@ func (p *scionPacketProcessor) egressInterface() uint16 {
 
 func (p *scionPacketProcessor) validateEgressUp() disposition {
 	egressID := p.pkt.egress
-	egressLink := p.d.interfaces[egressID]
+	egressLink := p.fwd.interfaces[egressID]
 	if !egressLink.IsUp() {
 		log.Debug("SCMP response", "cause", errBFDSessionDown)
+		p.dropReason = DropInterfaceDown
 		if egressLink.Scope() != External {
 			p.pkt.slowPathRequest = slowPathRequest{
 				spType: slowPathType(slayers.SCMPTypeInternalConnectivityDown),
@@ -1603,7 +1735,7 @@ func (p *scionPacketProcessor) handleIngressRouterAlert() disposition {
 	}
 	*alert = false
 	if err := p.path.SetHopField(p.hopField, int(p.path.PathMeta.CurrHF)); err != nil {
-		return errorDiscard("error", err)
+		return p.discard(DropMalformedPath, err)
 	}
 	p.pkt.slowPathRequest = slowPathRequest{
 		spType: slowPathRouterAlertIngress,
@@ -1623,13 +1755,13 @@ func (p *scionPacketProcessor) handleEgressRouterAlert() disposition {
 	if !*alert {
 		return pForward
 	}
-	if p.d.interfaces[p.pkt.egress].Scope() != External {
+	if p.fwd.interfaces[p.pkt.egress].Scope() != External {
 		// the egress router is not this one.
 		return pForward
 	}
 	*alert = false
 	if err := p.path.SetHopField(p.hopField, int(p.path.PathMeta.CurrHF)); err != nil {
-		return errorDiscard("error", err)
+		return p.discard(DropMalformedPath, err)
 	}
 	p.pkt.slowPathRequest = slowPathRequest{
 		spType: slowPathRouterAlertEgress,
@@ -1680,6 +1812,7 @@ func (p *scionPacketProcessor) validatePktLen() disposition {
 	}
 	log.Debug("SCMP response", "cause", errBadPacketSize, "header", p.scionLayer.PayloadLen,
 		"actual", len(p.scionLayer.Payload))
+	p.dropReason = DropBadPacketSize
 	p.pkt.slowPathRequest = slowPathRequest{
 		spType:  slowPathType(slayers.SCMPTypeParameterProblem),
 		code:    slayers.SCMPCodeInvalidPacketSize,
@@ -1702,6 +1835,7 @@ func (p *scionPacketProcessor) validateSrcHost() disposition {
 	}
 
 	log.Debug("SCMP response", "cause", err)
+	p.dropReason = DropInvalidSrcAddr
 	p.pkt.slowPathRequest = slowPathRequest{
 		spType: slowPathType(slayers.SCMPTypeParameterProblem),
 		code:   slayers.SCMPCodeInvalidSourceAddress,
@@ -1792,7 +1926,7 @@ func (p *scionPacketProcessor) process() disposition {
 	if disp := p.validateEgressUp(); disp != pForward {
 		return disp
 	}
-	if p.d.interfaces[egressID].Scope() == External {
+	if p.fwd.interfaces[egressID].Scope() == External {
 		// Not ASTransit in
 		if disp := p.processEgress(); disp != pForward {
 			return disp
@@ -1824,36 +1958,37 @@ func (p *scionPacketProcessor) processOHP() disposition {
 	ohp, ok := s.Path.(*onehop.Path)
 	if !ok {
 		// TODO parameter problem -> invalid path
-		return errorDiscard("error", errMalformedPath)
+		return p.discard(DropMalformedPath, errMalformedPath)
 	}
 	if !ohp.Info.ConsDir {
 		// TODO parameter problem -> invalid path
-		return errorDiscard("error", errMalformedPath)
+		return p.discard(DropMalformedPath, errMalformedPath)
 	}
 
 	// OHP leaving our IA
 	if p.ingressFromLink == 0 {
 		if !p.d.localIA.Equal(s.SrcIA) {
 			// TODO parameter problem -> invalid path
-			return errorDiscard("error", errCannotRoute)
+			return p.discard(DropCannotRoute, errCannotRoute)
 		}
-		neighborIA := p.d.neighborIAs[ohp.FirstHop.ConsEgress]
+		neighborIA := p.fwd.neighborIAs[ohp.FirstHop.ConsEgress]
 		if neighborIA.IsZero() {
 			// TODO parameter problem invalid interface
-			return errorDiscard("error", errCannotRoute)
+			return p.discard(DropCannotRoute, errCannotRoute)
 		}
 		if !neighborIA.Equal(s.DstIA) {
-			return errorDiscard("error", errCannotRoute)
+			return p.discard(DropCannotRoute, errCannotRoute)
 		}
-		mac := path.MAC(p.mac, ohp.Info, ohp.FirstHop, p.macInputBuffer[:path.MACBufferSize])
-		if subtle.ConstantTimeCompare(ohp.FirstHop.Mac[:], mac[:]) == 0 {
+		// The hop field was made by the local control service, which may still be using the
+		// previous key during a rollover.
+		if p.macs.verify(ohp.Info, ohp.FirstHop, p.macInputBuffer[:path.MACBufferSize]) == nil {
 			// TODO parameter problem -> invalid MAC
-			return errorDiscard("error", errMacVerificationFailed)
+			return p.discard(DropInvalidMAC, errMacVerificationFailed)
 		}
 		ohp.Info.UpdateSegID(ohp.FirstHop.Mac)
 
 		if err := updateSCIONLayer(p.pkt.RawPacket, s); err != nil {
-			return errorDiscard("error", err)
+			return p.discard(DropInvalid, err)
 		}
 		p.pkt.egress = ohp.FirstHop.ConsEgress
 		return pForward
@@ -1861,11 +1996,11 @@ func (p *scionPacketProcessor) processOHP() disposition {
 
 	// OHP entering our IA
 	if !p.d.localIA.Equal(s.DstIA) {
-		return errorDiscard("error", errCannotRoute)
+		return p.discard(DropCannotRoute, errCannotRoute)
 	}
-	neighborIA := p.d.neighborIAs[p.ingressFromLink]
+	neighborIA := p.fwd.neighborIAs[p.ingressFromLink]
 	if !neighborIA.Equal(s.SrcIA) {
-		return errorDiscard("error", errCannotRoute)
+		return p.discard(DropCannotRoute, errCannotRoute)
 	}
 
 	ohp.SecondHop = path.HopField{
@@ -1875,15 +2010,15 @@ func (p *scionPacketProcessor) processOHP() disposition {
 	// XXX(roosd): Here we leak the buffer into the SCION packet header.
 	// This is okay because we do not operate on the buffer or the packet
 	// for the rest of processing.
-	ohp.SecondHop.Mac = path.MAC(p.mac, ohp.Info, ohp.SecondHop,
+	ohp.SecondHop.Mac = path.MAC(p.macs.current(), ohp.Info, ohp.SecondHop,
 		p.macInputBuffer[:path.MACBufferSize])
 
 	if err := updateSCIONLayer(p.pkt.RawPacket, s); err != nil {
-		return errorDiscard("error", err)
+		return p.discard(DropInvalid, err)
 	}
 	err := p.d.resolveLocalDst(p.pkt, s, p.lastLayer)
 	if err != nil {
-		return errorDiscard("error", err)
+		return p.discard(DropInvalidDstAddr, err)
 	}
 
 	return pForward
@@ -1913,7 +2048,7 @@ func (d *dataPlane) resolveLocalDst(
 	}
 
 	// Let the internal (it better be) link resolve the destination to an underlay address.
-	return d.interfaces[packet.egress].Resolve(packet, a, p)
+	return d.fwd.Load().interfaces[packet.egress].Resolve(packet, a, p)
 }
 
 func (d *dataPlane) dstScionPort(
@@ -2081,7 +2216,7 @@ type bfdSend struct {
 	ifID      uint16
 	scn       *slayers.SCION
 	ohp       *onehop.Path
-	mac       hash.Hash
+	macs      macHashes
 	macBuffer []byte
 }
 
@@ -2092,7 +2227,6 @@ func newBFDSend(
 	localHost, remoteHost addr.Host,
 	ifID uint16,
 	isIntraAS bool,
-	mac hash.Hash,
 ) (*bfdSend, error) {
 	scn := &slayers.SCION{
 		Version:      0,
@@ -2142,7 +2276,6 @@ func newBFDSend(
 		ifID:      ifID,
 		scn:       scn,
 		ohp:       ohp,
-		mac:       mac,
 		macBuffer: make([]byte, path.MACBufferSize),
 	}, nil
 }
@@ -2159,7 +2292,8 @@ func (b *bfdSend) Send(bfd *layers.BFD) error {
 		// Subtract 10 seconds to deal with possible clock drift.
 		ohp := b.ohp
 		ohp.Info.Timestamp = uint32(time.Now().Unix() - 10)
-		ohp.FirstHop.Mac = path.MAC(b.mac, ohp.Info, ohp.FirstHop, b.macBuffer)
+		b.macs.update(b.dataPlane.macKeys.Load())
+		ohp.FirstHop.Mac = path.MAC(b.macs.current(), ohp.Info, ohp.FirstHop, b.macBuffer)
 	}
 
 	p := b.dataPlane.packetPool.Get()
@@ -2177,10 +2311,12 @@ func (b *bfdSend) Send(bfd *layers.BFD) error {
 	// metadata.
 	p.RawPacket = serBuf.Bytes()
 
-	// BfdControllers and fwQs are initialized from the same set of ifIDs. So not finding
-	// the forwarding queue is an serious internal error. Let that panic.
-	fwLink := b.dataPlane.interfaces[b.ifID]
-
+	// The interface may have been removed while its BFD session was being torn down.
+	fwLink := b.dataPlane.fwd.Load().interfaces[b.ifID]
+	if fwLink == nil {
+		b.dataPlane.packetPool.Put(p)
+		return serrors.JoinNoStack(errNoSuchInterface, nil, "ifID", b.ifID)
+	}
 	if !fwLink.Send(p) {
 		// We do not care if some BFD packets get bounced under high load. If it becomes a problem,
 		// the solution is do use BFD's demand-mode. To be considered in a future refactoring.
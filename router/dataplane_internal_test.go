@@ -229,8 +229,8 @@ func TestForwarder(t *testing.T) {
 	dp := prepareDP(ctrl)
 	dp.initPacketPool(64)
 	procQs, _ := dp.initQueues(64)
-	intf := dp.interfaces[0]
-	extf := dp.interfaces[42]
+	intf := dp.fwd.Load().interfaces[0]
+	extf := dp.fwd.Load().interfaces[42]
 	initialPoolSize := len(dp.packetPool.pool)
 	dp.setRunning()
 	dp.underlays["udpip"].Start(context.Background(), dp.packetPool, procQs)
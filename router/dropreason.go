@@ -0,0 +1,299 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/router/control"
+)
+
+// DropReason is the reason why a packet was not forwarded. The reasons are used as the value of
+// the reason label of the dropped packets metric, and to select the dropped packets reported
+// through the management API.
+type DropReason uint8
+
+const (
+	// dropNone is the zero value; the packet wasn't dropped.
+	dropNone DropReason = iota
+	// DropInvalid is for packets that are malformed in some way not covered by a more specific
+	// reason.
+	DropInvalid
+	// DropBusyProcessor is for packets dropped because the processors' queue was full.
+	DropBusyProcessor
+	// DropBusyForwarder is for packets dropped because the egress link's queue was full.
+	DropBusyForwarder
+	// DropBusySlowPath is for packets dropped because the slow-path queue was full.
+	DropBusySlowPath
+	// DropRateLimited is for packets in excess of the rate limit of an interface.
+	DropRateLimited
+	// DropMalformedHeader is for packets whose SCION header or extensions cannot be decoded.
+	DropMalformedHeader
+	// DropMalformedPath is for packets whose path is inconsistent or cannot be decoded.
+	DropMalformedPath
+	// DropUnsupportedPathType is for packets with a path type that the router doesn't support.
+	DropUnsupportedPathType
+	// DropNoBFDSession is for BFD messages received on a link that has no BFD session.
+	DropNoBFDSession
+	// DropExpiredHop is for packets whose current hop field has expired.
+	DropExpiredHop
+	// DropInvalidIngress is for packets that arrived through an interface other than the one
+	// designated by the current hop field.
+	DropInvalidIngress
+	// DropInvalidEgress is for packets whose egress interface is unknown or not a valid
+	// egress for the ingress interface.
+	DropInvalidEgress
+	// DropInvalidSegmentChange is for packets that change path segments between interfaces that
+	// don't permit it.
+	DropInvalidSegmentChange
+	// DropInvalidMAC is for packets whose current hop field has an invalid MAC.
+	DropInvalidMAC
+	// DropInvalidEPIC is for EPIC packets with an invalid timestamp or hop validation field.
+	DropInvalidEPIC
+	// DropInvalidSrcIA is for packets whose source ISD-AS is not valid where they entered.
+	DropInvalidSrcIA
+	// DropInvalidDstIA is for packets whose destination ISD-AS is inconsistent with the path.
+	DropInvalidDstIA
+	// DropInvalidSrcAddr is for packets with an invalid source host address or that entered
+	// through the wrong sibling link.
+	DropInvalidSrcAddr
+	// DropInvalidDstAddr is for packets to the local AS that have an invalid destination address.
+	DropInvalidDstAddr
+	// DropNoSVCBackend is for packets to a service for which there is no known instance.
+	DropNoSVCBackend
+	// DropBadPacketSize is for packets whose payload length disagrees with the header.
+	DropBadPacketSize
+	// DropInterfaceDown is for packets to an interface whose BFD session is down.
+	DropInterfaceDown
+	// DropCannotRoute is for one-hop path packets that don't come from or go to a neighbor.
+	DropCannotRoute
+
+	numDropReasons
+)
+
+var dropReasonNames = [numDropReasons]string{
+	dropNone:                 "",
+	DropInvalid:              "invalid",
+	DropBusyProcessor:        "busy_processor",
+	DropBusyForwarder:        "busy_forwarder",
+	DropBusySlowPath:         "busy_slow_path",
+	DropRateLimited:          "rate_limited",
+	DropMalformedHeader:      "malformed_header",
+	DropMalformedPath:        "malformed_path",
+	DropUnsupportedPathType:  "unsupported_path_type",
+	DropNoBFDSession:         "no_bfd_session",
+	DropExpiredHop:           "expired_hop",
+	DropInvalidIngress:       "invalid_ingress",
+	DropInvalidEgress:        "invalid_egress",
+	DropInvalidSegmentChange: "invalid_segment_change",
+	DropInvalidMAC:           "invalid_mac",
+	DropInvalidEPIC:          "invalid_epic",
+	DropInvalidSrcIA:         "invalid_src_ia",
+	DropInvalidDstIA:         "invalid_dst_ia",
+	DropInvalidSrcAddr:       "invalid_src_addr",
+	DropInvalidDstAddr:       "invalid_dst_addr",
+	DropNoSVCBackend:         "no_svc_backend",
+	DropBadPacketSize:        "bad_packet_size",
+	DropInterfaceDown:        "interface_down",
+	DropCannotRoute:          "cannot_route",
+}
+
+// String returns the value of the reason label of the dropped packets metric.
+func (r DropReason) String() string {
+	if r >= numDropReasons {
+		return "unknown"
+	}
+	return dropReasonNames[r]
+}
+
+// parseDropReason returns the reason with the given name.
+func parseDropReason(s string) (DropReason, bool) {
+	for r := DropInvalid; r < numDropReasons; r++ {
+		if dropReasonNames[r] == s {
+			return r, true
+		}
+	}
+	return dropNone, false
+}
+
+const (
+	// droppedSamplesPerReason is the number of dropped packets that are kept for each reason.
+	droppedSamplesPerReason = 8
+	// droppedSampleWindow is the period during which each processor records the first
+	// droppedSamplesPerReason packets dropped for a reason.
+	droppedSampleWindow = time.Second
+	// droppedSampleInterval is the number of packets dropped for a reason by a processor for which
+	// one is recorded, once the processor has recorded droppedSamplesPerReason in the current
+	// window. Recording copies the header and reads the clock, which is too costly to do for every
+	// packet of a flood that is being dropped. The packets of the rarer reasons are all recorded.
+	droppedSampleInterval = 32
+)
+
+// droppedSamples keeps the headers of the last few packets dropped for each reason. Each
+// processor records in its own dropRecorder, so that recording doesn't contend with the other
+// processors.
+type droppedSamples struct {
+	// window is advanced every droppedSampleWindow by run.
+	window atomic.Uint32
+
+	mtx       sync.Mutex
+	recorders []*dropRecorder
+}
+
+// newRecorder returns a recorder for a processor.
+func (s *droppedSamples) newRecorder() *dropRecorder {
+	r := &dropRecorder{window: &s.window}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.recorders = append(s.recorders, r)
+	return r
+}
+
+// run advances the sampling window until the context is done.
+func (s *droppedSamples) run(ctx context.Context) {
+	ticker := time.NewTicker(droppedSampleWindow)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.window.Add(1)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// list returns the last packets that were dropped for the given reason, or for any reason if
+// the reason is dropNone. The most recent packets come first.
+func (s *droppedSamples) list(reason DropReason) []control.DroppedPacket {
+	s.mtx.Lock()
+	recorders := slices.Clone(s.recorders)
+	s.mtx.Unlock()
+
+	var result []control.DroppedPacket
+	for r := DropInvalid; r < numDropReasons; r++ {
+		if reason != dropNone && r != reason {
+			continue
+		}
+		var packets []control.DroppedPacket
+		for _, recorder := range recorders {
+			packets = recorder.rings[r].appendTo(packets, r)
+		}
+		sortDroppedPackets(packets)
+		result = append(result, packets[:min(len(packets), droppedSamplesPerReason)]...)
+	}
+	sortDroppedPackets(result)
+	return result
+}
+
+func sortDroppedPackets(packets []control.DroppedPacket) {
+	slices.SortStableFunc(packets, func(a, b control.DroppedPacket) int {
+		return b.Time.Compare(a.Time)
+	})
+}
+
+// dropRecorder keeps the headers of the last few packets dropped by one processor for each
+// reason. Space for all of them is allocated upfront, so that recording a packet never
+// allocates. Only the processor records, others only read the samples.
+type dropRecorder struct {
+	window *atomic.Uint32
+	rings  [numDropReasons]droppedRing
+}
+
+type droppedRing struct {
+	// window and drops count the packets dropped for the reason in the current window.
+	window  uint32
+	drops   uint32
+	next    int
+	samples [droppedSamplesPerReason]droppedSample
+}
+
+type droppedSample struct {
+	mtx     sync.Mutex
+	time    time.Time
+	ingress uint16
+	egress  uint16
+	length  int
+	hdrLen  int
+	hdr     [slayers.MaxHdrLen]byte
+}
+
+// record keeps a copy of the packet's SCION header, or of as much of the packet as there is if it
+// is too short to make sense of the header, unless the packet is sampled out. If the slot is being
+// read, the packet is not recorded; there is no point in waiting for it.
+func (r *dropRecorder) record(p *Packet, reason DropReason) {
+	ring := &r.rings[reason]
+	if window := r.window.Load(); window != ring.window {
+		ring.window = window
+		ring.drops = 0
+	}
+	ring.drops++
+	if ring.drops > droppedSamplesPerReason && ring.drops%droppedSampleInterval != 0 {
+		return
+	}
+	sample := &ring.samples[ring.next]
+	ring.next = (ring.next + 1) % droppedSamplesPerReason
+	if !sample.mtx.TryLock() {
+		return
+	}
+	raw := p.RawPacket
+	hdrLen := min(len(raw), slayers.MaxHdrLen)
+	if len(raw) >= slayers.CmnHdrLen {
+		// The 6th byte of the common header is the header length in units of 4 bytes.
+		hdrLen = min(hdrLen, int(raw[5])*slayers.LineLen)
+	}
+	sample.time = time.Now()
+	sample.ingress = p.Link.IfID()
+	sample.egress = p.egress
+	sample.length = len(raw)
+	sample.hdrLen = copy(sample.hdr[:], raw[:hdrLen])
+	sample.mtx.Unlock()
+}
+
+// appendTo appends the recorded packets to packets.
+func (ring *droppedRing) appendTo(
+	packets []control.DroppedPacket, reason DropReason,
+) []control.DroppedPacket {
+	for i := range ring.samples {
+		sample := &ring.samples[i]
+		sample.mtx.Lock()
+		if !sample.time.IsZero() {
+			packets = append(packets, control.DroppedPacket{
+				Time:    sample.time,
+				Reason:  reason.String(),
+				Ingress: sample.ingress,
+				Egress:  sample.egress,
+				Length:  sample.length,
+				Header:  slices.Clone(sample.hdr[:sample.hdrLen]),
+			})
+		}
+		sample.mtx.Unlock()
+	}
+	return packets
+}
+
+// countDrop counts the packet as dropped for the given reason in the given interface metrics, and
+// passes it to the recorder of the processor.
+func (d *dataPlane) countDrop(
+	p *Packet, metrics *InterfaceMetrics, sc sizeClass, reason DropReason, recorder *dropRecorder,
+) {
+	metrics[sc].DroppedPackets.Inc(reason)
+	recorder.record(p, reason)
+}
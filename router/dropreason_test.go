@@ -0,0 +1,179 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+)
+
+func TestDropReasonNames(t *testing.T) {
+	names := map[string]DropReason{}
+	for r := DropInvalid; r < numDropReasons; r++ {
+		name := r.String()
+		require.NotEmpty(t, name, "reason %d has no name", r)
+		require.NotContains(t, names, name, "duplicate name")
+		names[name] = r
+
+		parsed, ok := parseDropReason(name)
+		assert.True(t, ok)
+		assert.Equal(t, r, parsed)
+	}
+	_, ok := parseDropReason("")
+	assert.False(t, ok)
+	_, ok = parseDropReason("bad_luck")
+	assert.False(t, ok)
+}
+
+func TestDroppedSamples(t *testing.T) {
+	var s droppedSamples
+	r := s.newRecorder()
+
+	// A packet with a 36 bytes header (HdrLen = 9) and 4 bytes of payload.
+	raw := make([]byte, 40)
+	raw[5] = 9
+	for i := 0; i < droppedSamplesPerReason+2; i++ {
+		raw[0] = byte(i)
+		// Each in its own window, so that none is sampled out.
+		s.window.Add(1)
+		r.record(NewPacket(raw, nil, nil, 1, 2), DropInvalidMAC)
+	}
+	r.record(NewPacket([]byte{1, 2, 3}, nil, nil, 0, 0), DropMalformedHeader)
+
+	mac := s.list(DropInvalidMAC)
+	require.Len(t, mac, droppedSamplesPerReason)
+	for i, p := range mac {
+		assert.Equal(t, "invalid_mac", p.Reason)
+		assert.Equal(t, uint16(1), p.Ingress)
+		assert.Equal(t, uint16(2), p.Egress)
+		assert.Equal(t, 40, p.Length)
+		require.Len(t, p.Header, 36)
+		if i > 0 {
+			assert.False(t, p.Time.After(mac[i-1].Time), "most recent first")
+		}
+	}
+	// The two oldest packets have been overwritten.
+	var firstBytes []byte
+	for _, p := range mac {
+		firstBytes = append(firstBytes, p.Header[0])
+	}
+	assert.NotContains(t, firstBytes, byte(0))
+	assert.NotContains(t, firstBytes, byte(1))
+
+	short := s.list(DropMalformedHeader)
+	require.Len(t, short, 1)
+	assert.Equal(t, []byte{1, 2, 3}, short[0].Header)
+
+	assert.Len(t, s.list(dropNone), droppedSamplesPerReason+1)
+	assert.Empty(t, s.list(DropExpiredHop))
+
+	// The packets recorded by the other processors are merged, and only the last ones are kept.
+	other := s.newRecorder()
+	raw[0] = 0xff
+	other.record(NewPacket(raw, nil, nil, 1, 2), DropInvalidMAC)
+	mac = s.list(DropInvalidMAC)
+	require.Len(t, mac, droppedSamplesPerReason)
+	assert.Equal(t, byte(0xff), mac[0].Header[0])
+}
+
+func TestDroppedSamplesSampling(t *testing.T) {
+	var s droppedSamples
+	r := s.newRecorder()
+	pkt := NewPacket(make([]byte, 100), nil, nil, 4242, 0)
+	recorded := func() int {
+		n := 0
+		for i := range r.rings[DropInvalidMAC].samples {
+			if !r.rings[DropInvalidMAC].samples[i].time.IsZero() {
+				n++
+			}
+		}
+		return n
+	}
+
+	// The first packets of a window are all recorded, then only one in droppedSampleInterval.
+	for range droppedSamplesPerReason {
+		r.record(pkt, DropInvalidMAC)
+	}
+	assert.Equal(t, droppedSamplesPerReason, recorded())
+	first := r.rings[DropInvalidMAC].next
+	for range droppedSampleInterval - droppedSamplesPerReason - 1 {
+		r.record(pkt, DropInvalidMAC)
+	}
+	assert.Equal(t, first, r.rings[DropInvalidMAC].next)
+	r.record(pkt, DropInvalidMAC)
+	assert.Equal(t, (first+1)%droppedSamplesPerReason, r.rings[DropInvalidMAC].next)
+
+	// In the next window, a rare drop is recorded again.
+	s.window.Add(1)
+	r.record(pkt, DropInvalidMAC)
+	assert.Equal(t, (first+2)%droppedSamplesPerReason, r.rings[DropInvalidMAC].next)
+
+	// Other reasons are not affected by a flood.
+	r.record(pkt, DropExpiredHop)
+	assert.Len(t, s.list(DropExpiredHop), 1)
+}
+
+func TestCountDrop(t *testing.T) {
+	d := newDataPlane(RunConfig{}, false)
+	metrics := newInterfaceMetrics(d.Metrics, 4242, addr.MustParseIA("1-ff00:0:110"), "",
+		addr.MustParseIA("1-ff00:0:111"))
+	pkt := NewPacket(make([]byte, 100), nil, nil, 4242, 0)
+	sc := ClassOfSize(len(pkt.RawPacket))
+	recorder := d.droppedSamples.newRecorder()
+	counters := metrics[sc].DroppedPackets
+
+	// The counters are only created when a packet is dropped.
+	for r := DropInvalid; r < numDropReasons; r++ {
+		assert.Nil(t, counters.counters[r].Load(), r.String())
+	}
+	d.countDrop(pkt, metrics, sc, DropExpiredHop, recorder)
+	require.NotNil(t, counters.counters[DropExpiredHop].Load())
+	assert.Nil(t, counters.counters[DropInvalidMAC].Load())
+	expired := testutil.ToFloat64(counters.Counter(DropExpiredHop))
+	assert.GreaterOrEqual(t, expired, 1.0)
+	assert.Len(t, d.droppedSamples.list(DropExpiredHop), 1)
+
+	d.countDrop(pkt, metrics, sc, DropInvalidMAC, recorder)
+	mac := testutil.ToFloat64(counters.Counter(DropInvalidMAC))
+	allocs := testing.AllocsPerRun(100, func() {
+		d.countDrop(pkt, metrics, sc, DropInvalidMAC, recorder)
+	})
+	assert.Zero(t, allocs)
+	assert.Equal(t, mac+101, testutil.ToFloat64(counters.Counter(DropInvalidMAC)))
+	assert.Equal(t, expired, testutil.ToFloat64(counters.Counter(DropExpiredHop)))
+}
+
+func BenchmarkCountDrop(b *testing.B) {
+	d := newDataPlane(RunConfig{}, false)
+	metrics := newInterfaceMetrics(d.Metrics, 4243, addr.MustParseIA("1-ff00:0:110"), "",
+		addr.MustParseIA("1-ff00:0:111"))
+	raw := make([]byte, 1000)
+	raw[5] = 30
+	pkt := NewPacket(raw, nil, nil, 4243, 0)
+	sc := ClassOfSize(len(pkt.RawPacket))
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		recorder := d.droppedSamples.newRecorder()
+		for pb.Next() {
+			d.countDrop(pkt, metrics, sc, DropInvalidMAC, recorder)
+		}
+	})
+}
@@ -44,7 +44,11 @@ func GetMetrics() *Metrics {
 
 type Disposition disposition
 
-const PDiscard = Disposition(pDiscard)
+const (
+	PDiscard  = Disposition(pDiscard)
+	PForward  = Disposition(pForward)
+	PSlowPath = Disposition(pSlowPath)
+)
 
 // Implements the link interface minimally
 type MockLink struct {
@@ -265,13 +269,33 @@ func (d *DataPlane) MockStart() {
 	d.setRunning()
 }
 
-func (d *DataPlane) ProcessPkt(pkt *Packet) Disposition {
+// InterfaceLink returns the link of the given interface in the forwarding table in use.
+func (d *DataPlane) InterfaceLink(ifID uint16) Link {
+	return d.fwd.Load().interfaces[ifID]
+}
+
+func (d *DataPlane) ProcessPkt(pkt *Packet) (Disposition, DropReason) {
 
 	p := newPacketProcessor(&d.dataPlane)
 	disp := p.processPkt(pkt)
 	// Erase trafficType; we don't set it in the expected results.
 	pkt.trafficType = ttOther
-	return Disposition(disp)
+	return Disposition(disp), p.dropReason
+}
+
+// PacketProcessor is a packet processor as an exported type, for use by benchmarks that need to
+// process many packets with the same processor.
+type PacketProcessor struct {
+	p *scionPacketProcessor
+}
+
+func (d *DataPlane) NewPacketProcessor() PacketProcessor {
+	return PacketProcessor{p: newPacketProcessor(&d.dataPlane)}
+}
+
+func (pp PacketProcessor) ProcessPkt(pkt *Packet) (Disposition, DropReason) {
+	disp := pp.p.processPkt(pkt)
+	return Disposition(disp), pp.p.dropReason
 }
 
 func ExtractServices(s *Services[netip.AddrPort]) map[addr.SVC][]netip.AddrPort {
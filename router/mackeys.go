@@ -0,0 +1,174 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"crypto/subtle"
+	"hash"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scrypto"
+	"github.com/scionproto/scion/pkg/slayers/path"
+	"github.com/scionproto/scion/router/control"
+)
+
+// macKey is a key with which the MACs of hop fields are verified.
+type macKey struct {
+	key []byte
+	// notAfter is when a grace key stops being accepted. It is zero for the current key.
+	notAfter time.Time
+	// verified counts the hop fields that were verified with the key. The processors add to it in
+	// batches; see macHashes.
+	verified prometheus.Counter
+}
+
+// macKeySet is the set of keys in use. The first one is the current key: the one with which the
+// router creates MACs, and the first one that it tries when verifying them. The others are grace
+// keys: keys that were current until recently, and that are still accepted for a while so that
+// the hop fields issued with them remain valid. A set is never modified; it is replaced.
+type macKeySet struct {
+	keys []macKey
+}
+
+// newMACKeySet returns the set of the given keys. The first one is the current key.
+func (d *dataPlane) newMACKeySet(keys []control.MACKey) (*macKeySet, error) {
+	if len(keys) == 0 || len(keys[0].Key) == 0 {
+		return nil, errEmptyValue
+	}
+	set := &macKeySet{keys: make([]macKey, 0, len(keys))}
+	for i, k := range keys {
+		// First check for MAC creation errors.
+		if _, err := scrypto.InitMac(k.Key); err != nil {
+			return nil, serrors.Wrap("initializing MAC", err, "key_id", k.ID)
+		}
+		role := "grace"
+		if i == 0 {
+			role = "current"
+		}
+		// Without metrics, count anyway so that verification needs no special case.
+		verified := prometheus.NewCounter(prometheus.CounterOpts{Name: "unregistered"})
+		if d.Metrics != nil {
+			verified = d.Metrics.MACVerifiedTotal.WithLabelValues(d.localIA.String(), k.ID, role)
+		}
+		set.keys = append(set.keys, macKey{
+			key:      k.Key,
+			notAfter: k.NotAfter,
+			verified: verified,
+		})
+	}
+	// The current key never expires.
+	set.keys[0].notAfter = time.Time{}
+	return set, nil
+}
+
+// SetKeys replaces the keys used for MAC verification. The first one is the current key and the
+// others are grace keys, which are accepted until their NotAfter time. The keys provided here
+// should already be derived as in scrypto.HFMacFactory. Unlike SetKey, this can be called while
+// the dataplane is running; the processors switch to the new keys with the next packet.
+func (d *dataPlane) SetKeys(keys []control.MACKey) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	set, err := d.newMACKeySet(keys)
+	if err != nil {
+		return err
+	}
+	d.macKeys.Store(set)
+	return nil
+}
+
+// macVerifiedBatch is the number of hop fields that a processor verifies with a key before it adds
+// them to the metric of the key. A processor that is idle adds them sooner.
+const macVerifiedBatch = 256
+
+// macHashes holds a hasher for each key of a key set. Hashers aren't safe for concurrent use, so
+// each processor has its own.
+type macHashes struct {
+	keys   *macKeySet
+	hashes []hash.Hash
+	// verified counts the hop fields verified with each key that are not yet added to the metrics.
+	// The metrics are shared by all processors; adding to them for every packet would make the
+	// processors contend on them.
+	verified []uint64
+}
+
+// update switches to the given key set, unless it is the one in use already.
+func (h *macHashes) update(keys *macKeySet) {
+	if keys == h.keys {
+		return
+	}
+	h.flush()
+	h.keys = keys
+	h.hashes = h.hashes[:0]
+	for _, k := range keys.keys {
+		// The key was checked when the set was made.
+		mac, _ := scrypto.InitMac(k.key)
+		h.hashes = append(h.hashes, mac)
+	}
+	h.verified = make([]uint64, len(keys.keys))
+}
+
+// flush adds the hop fields verified so far to the metrics.
+func (h *macHashes) flush() {
+	for i, n := range h.verified {
+		if n > 0 {
+			h.keys.keys[i].verified.Add(float64(n))
+			h.verified[i] = 0
+		}
+	}
+}
+
+// count counts a hop field verified with the key of the given index.
+func (h *macHashes) count(i int) {
+	h.verified[i]++
+	if h.verified[i] >= macVerifiedBatch {
+		h.keys.keys[i].verified.Add(float64(h.verified[i]))
+		h.verified[i] = 0
+	}
+}
+
+// current returns the hasher of the current key.
+func (h *macHashes) current() hash.Hash {
+	return h.hashes[0]
+}
+
+// verify checks the MAC of the given hop field against the current key and, failing that,
+// against the grace keys that have not expired. It returns the full MAC, stored in the given
+// buffer, or nil if no key matches.
+func (h *macHashes) verify(info path.InfoField, hf path.HopField, buffer []byte) []byte {
+	keys := h.keys.keys
+	fullMac := path.FullMAC(h.hashes[0], info, hf, buffer)
+	if subtle.ConstantTimeCompare(hf.Mac[:path.MacLen], fullMac[:path.MacLen]) == 1 {
+		h.count(0)
+		return fullMac
+	}
+	if len(keys) == 1 {
+		return nil
+	}
+	now := time.Now()
+	for i := 1; i < len(keys); i++ {
+		if now.After(keys[i].notAfter) {
+			continue
+		}
+		fullMac = path.FullMAC(h.hashes[i], info, hf, buffer)
+		if subtle.ConstantTimeCompare(hf.Mac[:path.MacLen], fullMac[:path.MacLen]) == 1 {
+			h.count(i)
+			return fullMac
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/slayers/path"
+	"github.com/scionproto/scion/router/control"
+)
+
+func TestMACKeys(t *testing.T) {
+	current := []byte("current_key_xxxx")
+	grace := []byte("grace_key_xxxxxx")
+	expired := []byte("expired_key_xxxx")
+	unknown := []byte("unknown_key_xxxx")
+
+	d := newDataPlane(RunConfig{}, false)
+	require.NoError(t, d.SetIA(addr.MustParseIA("1-ff00:0:110")))
+	require.NoError(t, d.SetKeys([]control.MACKey{
+		{ID: "test-current", Key: current},
+		{ID: "test-grace", Key: grace, NotAfter: time.Now().Add(time.Hour)},
+		{ID: "test-expired", Key: expired, NotAfter: time.Now().Add(-time.Second)},
+	}))
+	keys := d.macKeys.Load()
+
+	var h macHashes
+	h.update(keys)
+	buffer := make([]byte, path.MACBufferSize)
+	info := path.InfoField{ConsDir: true, SegID: 0x111, Timestamp: 0x100}
+	hopField := func(key []byte) path.HopField {
+		hf := path.HopField{ConsIngress: 1, ConsEgress: 2, ExpTime: 63}
+		hf.Mac = computeMAC(t, key, info, hf)
+		return hf
+	}
+
+	fullMac := h.verify(info, hopField(current), buffer)
+	require.NotNil(t, fullMac)
+	assert.Equal(t, computeMAC(t, current, info, hopField(current)), [path.MacLen]byte(fullMac))
+	assert.NotNil(t, h.verify(info, hopField(grace), buffer))
+	assert.Nil(t, h.verify(info, hopField(expired), buffer))
+	assert.Nil(t, h.verify(info, hopField(unknown), buffer))
+
+	// The verifications are added to the metrics in batches, or when flushed.
+	assert.Equal(t, 0.0, testutil.ToFloat64(keys.keys[0].verified))
+	h.flush()
+	assert.Equal(t, 1.0, testutil.ToFloat64(keys.keys[0].verified))
+	assert.Equal(t, 1.0, testutil.ToFloat64(keys.keys[1].verified))
+	assert.Equal(t, 0.0, testutil.ToFloat64(keys.keys[2].verified))
+	for range macVerifiedBatch {
+		h.verify(info, hopField(grace), buffer)
+	}
+	assert.Equal(t, float64(1+macVerifiedBatch), testutil.ToFloat64(keys.keys[1].verified))
+
+	// The counts of the replaced keys are flushed.
+	h.verify(info, hopField(current), buffer)
+
+	// The hashers follow the keys once they are replaced.
+	require.NoError(t, d.SetKeys([]control.MACKey{{ID: "test-unknown", Key: unknown}}))
+	h.update(d.macKeys.Load())
+	assert.Equal(t, 2.0, testutil.ToFloat64(keys.keys[0].verified))
+	assert.Nil(t, h.verify(info, hopField(current), buffer))
+	assert.NotNil(t, h.verify(info, hopField(unknown), buffer))
+
+	assert.Error(t, d.SetKeys(nil))
+
+	hf := hopField(unknown)
+	allocs := testing.AllocsPerRun(100, func() {
+		h.verify(info, hf, buffer)
+	})
+	assert.Zero(t, allocs)
+}
@@ -19,6 +19,7 @@ import (
 	"math/bits"
 	"strconv"
 	"strings"
+	"sync/atomic"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -44,6 +45,7 @@ type Metrics struct {
 	SiblingBFDPacketsSent     *prometheus.CounterVec
 	SiblingBFDPacketsReceived *prometheus.CounterVec
 	SiblingBFDStateChanges    *prometheus.CounterVec
+	MACVerifiedTotal          *prometheus.CounterVec
 }
 
 // NewMetrics initializes the metrics for the Border Router, and registers them with the default
@@ -164,6 +166,13 @@ func NewMetrics() *Metrics {
 			},
 			[]string{"sibling", "isd_as"},
 		),
+		MACVerifiedTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "router_mac_verified_total",
+				Help: "Total number of hop field MACs verified, by key.",
+			},
+			[]string{"isd_as", "key_id", "role"},
+		),
 	}
 }
 
@@ -261,14 +270,35 @@ type InterfaceMetrics [maxSizeClass]trafficMetrics
 // trafficMetrics groups all the metrics instances that all share the same interface AND
 // sizeClass label values (but have different names - i.e. they count different things).
 type trafficMetrics struct {
-	InputBytesTotal             prometheus.Counter
-	InputPacketsTotal           prometheus.Counter
-	DroppedPacketsInvalid       prometheus.Counter
-	DroppedPacketsBusyProcessor prometheus.Counter
-	DroppedPacketsBusyForwarder prometheus.Counter
-	DroppedPacketsBusySlowPath  prometheus.Counter
-	ProcessedPackets            prometheus.Counter
-	Output                      [ttMax]outputMetrics
+	InputBytesTotal   prometheus.Counter
+	InputPacketsTotal prometheus.Counter
+	ProcessedPackets  prometheus.Counter
+	DroppedPackets    *DropCounters
+	Output            [ttMax]outputMetrics
+}
+
+// DropCounters are the counters of the packets dropped for each reason. The counter of a reason
+// is only created when a packet is first dropped for it, so that there isn't a series for every
+// combination of interface, size class, and reason.
+type DropCounters struct {
+	vec      *prometheus.CounterVec
+	counters [numDropReasons]atomic.Pointer[prometheus.Counter]
+}
+
+// Inc counts a packet dropped for the given reason.
+func (c *DropCounters) Inc(reason DropReason) {
+	c.Counter(reason).Inc()
+}
+
+// Counter returns the counter of the given reason, and creates it if needed.
+func (c *DropCounters) Counter(reason DropReason) prometheus.Counter {
+	if counter := c.counters[reason].Load(); counter != nil {
+		return *counter
+	}
+	// If several goroutines get here, they get the same counter from the vector.
+	counter := c.vec.With(prometheus.Labels{"reason": reason.String()})
+	c.counters[reason].Store(&counter)
+	return counter
 }
 
 // outputMetrics groups all the metrics about traffic that has reached the output stage. Metrics
@@ -312,31 +342,13 @@ func newTrafficMetrics(
 		c.Output[t] = newOutputMetrics(metrics, ifLabels, scLabels, ttLabels)
 	}
 
-	// Dropped metrics have the extra "Reason" label.
-	reasonMap := map[string]string{}
-
-	reasonMap["reason"] = "invalid"
-	c.DroppedPacketsInvalid =
-		metrics.DroppedPacketsTotal.MustCurryWith(ifLabels).MustCurryWith(scLabels).With(reasonMap)
-
-	reasonMap["reason"] = "busy_processor"
-	c.DroppedPacketsBusyProcessor =
-		metrics.DroppedPacketsTotal.MustCurryWith(ifLabels).MustCurryWith(scLabels).With(reasonMap)
-
-	reasonMap["reason"] = "busy_forwarder"
-	c.DroppedPacketsBusyForwarder =
-		metrics.DroppedPacketsTotal.MustCurryWith(ifLabels).MustCurryWith(scLabels).With(reasonMap)
-
-	reasonMap["reason"] = "busy_slow_path"
-	c.DroppedPacketsBusySlowPath =
-		metrics.DroppedPacketsTotal.MustCurryWith(ifLabels).MustCurryWith(scLabels).With(reasonMap)
+	// Dropped metrics have the extra "Reason" label, they are created lazily.
+	c.DroppedPackets = &DropCounters{
+		vec: metrics.DroppedPacketsTotal.MustCurryWith(ifLabels).MustCurryWith(scLabels),
+	}
 
 	c.InputBytesTotal.Add(0)
 	c.InputPacketsTotal.Add(0)
-	c.DroppedPacketsInvalid.Add(0)
-	c.DroppedPacketsBusyProcessor.Add(0)
-	c.DroppedPacketsBusyForwarder.Add(0)
-	c.DroppedPacketsBusySlowPath.Add(0)
 	c.ProcessedPackets.Add(0)
 	return c
 }
@@ -17,9 +17,14 @@ package mgmtapi
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
 
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/ptr"
+	"github.com/scionproto/scion/pkg/private/serrors"
 	api "github.com/scionproto/scion/private/mgmtapi"
 	"github.com/scionproto/scion/router/control"
 )
@@ -30,6 +35,11 @@ type Server struct {
 	Info      http.HandlerFunc
 	LogLevel  http.HandlerFunc
 	Dataplane control.ObservableDataplane
+	Tunables  control.TunableDataplane
+	// Capturer is nil if packet capture is disabled.
+	Capturer control.PacketCapturer
+	// Reloader is nil if the interfaces cannot be reloaded.
+	Reloader control.InterfaceReloader
 }
 
 // GetConfig is an indirection to the http handler.
@@ -150,6 +160,328 @@ func (s *Server) GetInterfaces(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetDroppedPackets lists the last packets dropped by the router.
+func (s *Server) GetDroppedPackets(
+	w http.ResponseWriter, r *http.Request, params GetDroppedPacketsParams,
+) {
+	var reason string
+	if params.Reason != nil {
+		reason = *params.Reason
+	}
+	packets, err := s.Dataplane.ListDroppedPackets(reason)
+	if err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusBadRequest,
+			Title:  "error getting dropped packets",
+			Type:   api.StringRef(api.BadRequest),
+		})
+		return
+	}
+	dropped := make([]DroppedPacket, 0, len(packets))
+	for _, p := range packets {
+		dropped = append(dropped, DroppedPacket{
+			Timestamp:          p.Time,
+			Reason:             p.Reason,
+			IngressInterfaceId: int(p.Ingress),
+			EgressInterfaceId:  int(p.Egress),
+			Length:             p.Length,
+			Header:             p.Header,
+		})
+	}
+	rep := DroppedPacketsResponse{
+		DroppedPackets: &dropped,
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(rep); err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+}
+
+// ReloadInterfaces reloads the external and sibling interfaces from the topology file.
+func (s *Server) ReloadInterfaces(w http.ResponseWriter, r *http.Request) {
+	if s.Reloader == nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef("reloading the interfaces is not supported"),
+			Status: http.StatusForbidden,
+			Title:  "reload not supported",
+			Type:   api.StringRef(api.Forbidden),
+		})
+		return
+	}
+	changes, err := s.Reloader.ReloadInterfaces()
+	if err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "error reloading interfaces",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+	ifIDs := func(l []uint16) []int {
+		ids := make([]int, 0, len(l))
+		for _, id := range l {
+			ids = append(ids, int(id))
+		}
+		return ids
+	}
+	rep := InterfaceChanges{
+		Added:    ifIDs(changes.Added),
+		Removed:  ifIDs(changes.Removed),
+		Modified: ifIDs(changes.Modified),
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(rep); err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+}
+
+// GetRateLimits lists the rate limits of the external interfaces.
+func (s *Server) GetRateLimits(w http.ResponseWriter, r *http.Request) {
+	limits, err := s.Tunables.ListRateLimits()
+	if err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "error getting rate limits",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+	rateLimits := make([]RateLimit, 0, len(limits))
+	for _, l := range limits {
+		rateLimits = append(rateLimits, rateLimitFromControl(l))
+	}
+	rep := RateLimitsResponse{
+		RateLimits: &rateLimits,
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(rep); err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+}
+
+// SetRateLimit adds, replaces, or removes the rate limit of an external interface.
+func (s *Server) SetRateLimit(w http.ResponseWriter, r *http.Request) {
+	var req RateLimit
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusBadRequest,
+			Title:  "malformed rate limit",
+			Type:   api.StringRef(api.BadRequest),
+		})
+		return
+	}
+	limit, err := rateLimitToControl(req)
+	if err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusBadRequest,
+			Title:  "invalid rate limit",
+			Type:   api.StringRef(api.BadRequest),
+		})
+		return
+	}
+	if err := s.Tunables.SetRateLimit(limit); err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusBadRequest,
+			Title:  "unable to set rate limit",
+			Type:   api.StringRef(api.BadRequest),
+		})
+		return
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(req); err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+}
+
+// GetCapture streams the packets processed by the router in pcapng format.
+func (s *Server) GetCapture(w http.ResponseWriter, r *http.Request, params GetCaptureParams) {
+	if s.Capturer == nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef("packet capture is disabled in the router configuration"),
+			Status: http.StatusForbidden,
+			Title:  "packet capture disabled",
+			Type:   api.StringRef(api.Forbidden),
+		})
+		return
+	}
+	filter, err := captureFilter(params)
+	if err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusBadRequest,
+			Title:  "invalid capture filter",
+			Type:   api.StringRef(api.BadRequest),
+		})
+		return
+	}
+	snapLen := 0
+	if params.SnapLen != nil {
+		snapLen = *params.SnapLen
+	}
+	capture, err := s.Capturer.StartCapture(filter, snapLen)
+	if errors.Is(err, control.ErrUnknownDropReason) {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusBadRequest,
+			Title:  "invalid capture filter",
+			Type:   api.StringRef(api.BadRequest),
+		})
+		return
+	}
+	if err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusServiceUnavailable,
+			Title:  "unable to start capture",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+	defer capture.Close()
+
+	w.Header().Set("Content-Type", "application/x-pcapng")
+	flush := func() {}
+	if f, ok := w.(http.Flusher); ok {
+		flush = f.Flush
+	}
+	pw, err := newPcapngWriter(w, capture.SnapLen())
+	if err != nil {
+		return
+	}
+	flush()
+	for n := 0; params.Count == nil || n < *params.Count; n++ {
+		p, err := capture.Next(r.Context())
+		if err != nil {
+			// The client went away.
+			break
+		}
+		if err := pw.writePacket(p); err != nil {
+			break
+		}
+		flush()
+	}
+	if lost := capture.Lost(); lost > 0 {
+		log.Debug("Packets lost during capture", "lost", lost)
+	}
+}
+
+func captureFilter(params GetCaptureParams) (control.CaptureFilter, error) {
+	var filter control.CaptureFilter
+	if params.InterfaceId != nil {
+		if *params.InterfaceId < 0 || *params.InterfaceId > math.MaxUint16 {
+			return filter, serrors.New("invalid interface_id",
+				"interface_id", *params.InterfaceId)
+		}
+		filter.IfID = ptr.To(uint16(*params.InterfaceId))
+	}
+	if params.Scope != nil {
+		switch *params.Scope {
+		case Internal, Sibling, External:
+			filter.Scope = control.LinkScope(*params.Scope)
+		default:
+			return filter, serrors.New("invalid scope", "scope", *params.Scope)
+		}
+	}
+	if params.Disposition != nil {
+		switch *params.Disposition {
+		case Forwarded, Dropped, SlowPath:
+			filter.Disposition = control.Disposition(*params.Disposition)
+		default:
+			return filter, serrors.New("invalid disposition",
+				"disposition", *params.Disposition)
+		}
+	}
+	if params.Reason != nil {
+		filter.Reason = *params.Reason
+	}
+	if params.SnapLen != nil && *params.SnapLen < 0 {
+		return filter, serrors.New("negative snap_len", "snap_len", *params.SnapLen)
+	}
+	if params.Count != nil && *params.Count < 1 {
+		return filter, serrors.New("invalid count", "count", *params.Count)
+	}
+	return filter, nil
+}
+
+func rateLimitFromControl(l control.RateLimit) RateLimit {
+	rl := RateLimit{
+		InterfaceId: int(l.IfID), // nolint - name from published API.
+		Direction:   RateLimitDirection(l.Direction),
+		Rate:        int64(l.Rate),
+		Burst:       ptr.To(int64(l.Burst)),
+	}
+	if l.TrafficClass != nil {
+		rl.TrafficClass = ptr.To(int(*l.TrafficClass))
+	}
+	return rl
+}
+
+func rateLimitToControl(rl RateLimit) (control.RateLimit, error) {
+	if rl.InterfaceId <= 0 || rl.InterfaceId > math.MaxUint16 {
+		return control.RateLimit{}, serrors.New("invalid interface_id",
+			"interface_id", rl.InterfaceId)
+	}
+	if rl.Direction != Ingress && rl.Direction != Egress {
+		return control.RateLimit{}, serrors.New("invalid direction", "direction", rl.Direction)
+	}
+	if rl.Rate < 0 {
+		return control.RateLimit{}, serrors.New("negative rate", "rate", rl.Rate)
+	}
+	l := control.RateLimit{
+		IfID:      uint16(rl.InterfaceId),
+		Direction: control.Direction(rl.Direction),
+		Rate:      uint64(rl.Rate),
+	}
+	if rl.TrafficClass != nil {
+		if *rl.TrafficClass < 0 || *rl.TrafficClass > math.MaxUint8 {
+			return control.RateLimit{}, serrors.New("invalid traffic_class",
+				"traffic_class", *rl.TrafficClass)
+		}
+		l.TrafficClass = ptr.To(uint8(*rl.TrafficClass))
+	}
+	if rl.Burst != nil {
+		if *rl.Burst < 0 {
+			return control.RateLimit{}, serrors.New("negative burst", "burst", *rl.Burst)
+		}
+		l.Burst = uint64(*rl.Burst)
+	}
+	return l, nil
+}
+
 // Error creates an detailed error response.
 func ErrorResponse(w http.ResponseWriter, p Problem) {
 	w.Header().Set("Content-Type", "application/problem+json")
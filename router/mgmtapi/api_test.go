@@ -18,6 +18,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -39,7 +40,9 @@ var update = xtest.UpdateGoldenFiles()
 func TestAPI(t *testing.T) {
 	testCases := map[string]struct {
 		Handler            func(t *testing.T, ctrl *gomock.Controller) http.Handler
+		Method             string // GET if empty.
 		RequestURL         string
+		RequestBody        string
 		ResponseFile       string
 		Status             int
 		IgnoreResponseBody bool
@@ -120,6 +123,238 @@ func TestAPI(t *testing.T) {
 			ResponseFile: "testdata/interfaces-sibling-error.json",
 			Status:       500,
 		},
+		"rate limits": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				tunables := mock_api.NewMockTunableDataplane(ctrl)
+				s := &Server{
+					Tunables: tunables,
+				}
+				tunables.EXPECT().ListRateLimits().Return(createRateLimits(t), nil)
+				return Handler(s)
+			},
+			RequestURL:   "/rate-limits",
+			ResponseFile: "testdata/rate-limits.json",
+			Status:       200,
+		},
+		"set rate limit": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				tunables := mock_api.NewMockTunableDataplane(ctrl)
+				s := &Server{
+					Tunables: tunables,
+				}
+				tunables.EXPECT().SetRateLimit(control.RateLimit{
+					IfID:         1,
+					Direction:    control.Egress,
+					TrafficClass: ptr.To[uint8](184),
+					Rate:         1000000,
+				}).Return(nil)
+				return Handler(s)
+			},
+			Method:     "PUT",
+			RequestURL: "/rate-limits",
+			RequestBody: `{"interface_id": 1, "direction": "egress", "traffic_class": 184,
+				"rate": 1000000}`,
+			ResponseFile: "testdata/set-rate-limit.json",
+			Status:       200,
+		},
+		"set rate limit bad direction": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				s := &Server{
+					Tunables: mock_api.NewMockTunableDataplane(ctrl),
+				}
+				return Handler(s)
+			},
+			Method:       "PUT",
+			RequestURL:   "/rate-limits",
+			RequestBody:  `{"interface_id": 1, "direction": "sideways", "rate": 1000000}`,
+			ResponseFile: "testdata/set-rate-limit-bad-direction.json",
+			Status:       400,
+		},
+		"set rate limit error": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				tunables := mock_api.NewMockTunableDataplane(ctrl)
+				s := &Server{
+					Tunables: tunables,
+				}
+				tunables.EXPECT().SetRateLimit(gomock.Any()).Return(
+					serrors.New("unknown external interface"),
+				)
+				return Handler(s)
+			},
+			Method:       "PUT",
+			RequestURL:   "/rate-limits",
+			RequestBody:  `{"interface_id": 9, "direction": "ingress", "rate": 1000000}`,
+			ResponseFile: "testdata/set-rate-limit-error.json",
+			Status:       400,
+		},
+		"dropped packets": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				dataplane := mock_api.NewMockObservableDataplane(ctrl)
+				s := &Server{
+					Dataplane: dataplane,
+				}
+				dataplane.EXPECT().ListDroppedPackets("invalid_mac").Return(
+					[]control.DroppedPacket{
+						{
+							Time:    time.Date(2025, 3, 1, 12, 0, 1, 0, time.UTC),
+							Reason:  "invalid_mac",
+							Ingress: 1,
+							Egress:  2,
+							Length:  1300,
+							Header:  []byte{0x00, 0x00, 0x00, 0x01, 0x11, 0x09},
+						},
+						{
+							Time:    time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+							Reason:  "invalid_mac",
+							Ingress: 1,
+							Length:  3,
+							Header:  []byte{0x00, 0x00, 0x00},
+						},
+					}, nil,
+				)
+				return Handler(s)
+			},
+			RequestURL:   "/dropped-packets?reason=invalid_mac",
+			ResponseFile: "testdata/dropped-packets.json",
+			Status:       200,
+		},
+		"dropped packets bad reason": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				dataplane := mock_api.NewMockObservableDataplane(ctrl)
+				s := &Server{
+					Dataplane: dataplane,
+				}
+				dataplane.EXPECT().ListDroppedPackets("bad_luck").Return(
+					nil, serrors.New("unknown drop reason", "reason", "bad_luck"),
+				)
+				return Handler(s)
+			},
+			RequestURL:   "/dropped-packets?reason=bad_luck",
+			ResponseFile: "testdata/dropped-packets-bad-reason.json",
+			Status:       400,
+		},
+		"capture": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				capturer := mock_api.NewMockPacketCapturer(ctrl)
+				capture := mock_api.NewMockPacketCapture(ctrl)
+				s := &Server{
+					Capturer: capturer,
+				}
+				capturer.EXPECT().StartCapture(control.CaptureFilter{
+					IfID:        ptr.To[uint16](1),
+					Disposition: control.Dropped,
+				}, 16).Return(capture, nil)
+				capture.EXPECT().Next(gomock.Any()).Return(&control.CapturedPacket{
+					Time:         time.Unix(1700000000, 42),
+					Ingress:      1,
+					IngressScope: control.ScopeExternal,
+					Egress:       2,
+					EgressScope:  control.ScopeExternal,
+					Disposition:  control.Dropped,
+					Reason:       "rate_limited",
+					Length:       100,
+					Data:         []byte("0123456789abcdef"),
+				}, nil)
+				capture.EXPECT().SnapLen().Return(16)
+				capture.EXPECT().Lost().Return(uint64(0))
+				capture.EXPECT().Close()
+				return Handler(s)
+			},
+			RequestURL:   "/capture?interface_id=1&disposition=dropped&snap_len=16&count=1",
+			ResponseFile: "testdata/capture.pcapng",
+			Status:       200,
+		},
+		"reload interfaces": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				reloader := mock_api.NewMockInterfaceReloader(ctrl)
+				s := &Server{
+					Reloader: reloader,
+				}
+				reloader.EXPECT().ReloadInterfaces().Return(control.InterfaceChanges{
+					Added:    []uint16{4, 5},
+					Modified: []uint16{3},
+				}, nil)
+				return Handler(s)
+			},
+			Method:       "POST",
+			RequestURL:   "/interfaces/reload",
+			ResponseFile: "testdata/reload-interfaces.json",
+			Status:       200,
+		},
+		"reload interfaces error": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				reloader := mock_api.NewMockInterfaceReloader(ctrl)
+				s := &Server{
+					Reloader: reloader,
+				}
+				reloader.EXPECT().ReloadInterfaces().Return(control.InterfaceChanges{},
+					serrors.New("internal address changed"))
+				return Handler(s)
+			},
+			Method:       "POST",
+			RequestURL:   "/interfaces/reload",
+			ResponseFile: "testdata/reload-interfaces-error.json",
+			Status:       500,
+		},
+		"reload interfaces not supported": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				return Handler(&Server{})
+			},
+			Method:       "POST",
+			RequestURL:   "/interfaces/reload",
+			ResponseFile: "testdata/reload-interfaces-unsupported.json",
+			Status:       403,
+		},
+		"capture disabled": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				return Handler(&Server{})
+			},
+			RequestURL:   "/capture",
+			ResponseFile: "testdata/capture-disabled.json",
+			Status:       403,
+		},
+		"capture bad scope": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				s := &Server{
+					Capturer: mock_api.NewMockPacketCapturer(ctrl),
+				}
+				return Handler(s)
+			},
+			RequestURL:   "/capture?scope=everywhere",
+			ResponseFile: "testdata/capture-bad-scope.json",
+			Status:       400,
+		},
+		"capture bad reason": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				capturer := mock_api.NewMockPacketCapturer(ctrl)
+				s := &Server{
+					Capturer: capturer,
+				}
+				capturer.EXPECT().StartCapture(control.CaptureFilter{Reason: "bad_luck"}, 0).Return(
+					nil, serrors.JoinNoStack(control.ErrUnknownDropReason, nil,
+						"reason", "bad_luck"),
+				)
+				return Handler(s)
+			},
+			RequestURL:   "/capture?reason=bad_luck",
+			ResponseFile: "testdata/capture-bad-reason.json",
+			Status:       400,
+		},
+		"capture too many": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				capturer := mock_api.NewMockPacketCapturer(ctrl)
+				s := &Server{
+					Capturer: capturer,
+				}
+				capturer.EXPECT().StartCapture(gomock.Any(), 0).Return(
+					nil, serrors.New("too many concurrent captures"),
+				)
+				return Handler(s)
+			},
+			RequestURL:   "/capture",
+			ResponseFile: "testdata/capture-too-many.json",
+			Status:       503,
+		},
 	}
 
 	for name, tc := range testCases {
@@ -127,7 +362,11 @@ func TestAPI(t *testing.T) {
 			t.Parallel()
 			ctrl := gomock.NewController(t)
 
-			req, err := http.NewRequest("GET", tc.RequestURL, nil)
+			method := tc.Method
+			if method == "" {
+				method = "GET"
+			}
+			req, err := http.NewRequest(method, tc.RequestURL, strings.NewReader(tc.RequestBody))
 			require.NoError(t, err)
 
 			rr := httptest.NewRecorder()
@@ -270,3 +509,27 @@ func createSiblingIntfs(t *testing.T) []control.SiblingInterface {
 		},
 	}
 }
+
+func createRateLimits(t *testing.T) []control.RateLimit {
+	return []control.RateLimit{
+		{
+			IfID:      1,
+			Direction: control.Ingress,
+			Rate:      100000000,
+			Burst:     1250000,
+		},
+		{
+			IfID:         1,
+			Direction:    control.Ingress,
+			TrafficClass: ptr.To[uint8](184),
+			Rate:         1000000,
+			Burst:        9000,
+		},
+		{
+			IfID:      2,
+			Direction: control.Egress,
+			Rate:      10000000,
+			Burst:     125000,
+		},
+	}
+}
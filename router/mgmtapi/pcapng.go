@@ -0,0 +1,145 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgmtapi
+
+import (
+	"encoding/binary"
+	"io"
+	"strconv"
+
+	"github.com/scionproto/scion/router/control"
+)
+
+// The subset of pcapng (https://www.ietf.org/archive/id/draft-ietf-opsawg-pcapng-02.html) that
+// we need to write a capture with per-packet comments. The pcapng writer of gopacket cannot add
+// options to packets.
+const (
+	ngBlockSectionHeader    = 0x0a0d0d0a
+	ngBlockInterfaceDesc    = 0x00000001
+	ngBlockEnhancedPacket   = 0x00000006
+	ngByteOrderMagic        = 0x1a2b3c4d
+	ngOptionEnd             = 0
+	ngOptionComment         = 1
+	ngOptionIfName          = 2
+	ngOptionIfTsResol       = 9
+	ngOptionShbUserAppl     = 4
+	ngTsResolNanoseconds    = 9
+	linkTypeUser0           = 147 // No standard link type for raw SCION; Wireshark maps USER0.
+	ngBlockHeaderAndTrailer = 12
+)
+
+var le = binary.LittleEndian
+
+// pcapngWriter writes a pcapng stream with a single section and a single interface: the router.
+type pcapngWriter struct {
+	w   io.Writer
+	buf []byte
+}
+
+// newPcapngWriter writes the section header and the interface description to w. snapLen is the
+// maximum length of the captured packets. Zero means no limit.
+func newPcapngWriter(w io.Writer, snapLen int) (*pcapngWriter, error) {
+	pw := &pcapngWriter{w: w}
+
+	// Section header.
+	body := le.AppendUint32(nil, ngByteOrderMagic)
+	body = le.AppendUint16(body, 1) // Major version.
+	body = le.AppendUint16(body, 0) // Minor version.
+	body = le.AppendUint64(body, 0xffffffffffffffff)
+	body = appendOption(body, ngOptionShbUserAppl, []byte("SCION router"))
+	body = appendOption(body, ngOptionEnd, nil)
+	if err := pw.writeBlock(ngBlockSectionHeader, body); err != nil {
+		return nil, err
+	}
+
+	// Interface description. Time stamps are in nanoseconds.
+	body = le.AppendUint16(body[:0], linkTypeUser0)
+	body = le.AppendUint16(body, 0)
+	body = le.AppendUint32(body, uint32(snapLen))
+	body = appendOption(body, ngOptionIfName, []byte("scion"))
+	body = appendOption(body, ngOptionIfTsResol, []byte{ngTsResolNanoseconds})
+	body = appendOption(body, ngOptionEnd, nil)
+	if err := pw.writeBlock(ngBlockInterfaceDesc, body); err != nil {
+		return nil, err
+	}
+	return pw, nil
+}
+
+// writePacket writes the packet in an enhanced packet block, with the metadata as comment.
+func (pw *pcapngWriter) writePacket(p *control.CapturedPacket) error {
+	ts := uint64(p.Time.UnixNano())
+	body := le.AppendUint32(pw.buf[:0], 0) // Interface ID.
+	body = le.AppendUint32(body, uint32(ts>>32))
+	body = le.AppendUint32(body, uint32(ts))
+	body = le.AppendUint32(body, uint32(len(p.Data)))
+	body = le.AppendUint32(body, uint32(p.Length))
+	body = appendPadded(body, p.Data)
+	body = appendOption(body, ngOptionComment, appendComment(nil, p))
+	body = appendOption(body, ngOptionEnd, nil)
+	pw.buf = body
+	return pw.writeBlock(ngBlockEnhancedPacket, body)
+}
+
+func (pw *pcapngWriter) writeBlock(blockType uint32, body []byte) error {
+	total := uint32(ngBlockHeaderAndTrailer + len(body))
+	var hdr [8]byte
+	le.PutUint32(hdr[0:], blockType)
+	le.PutUint32(hdr[4:], total)
+	if _, err := pw.w.Write(hdr[:]); err != nil {
+		return err
+	}
+	if _, err := pw.w.Write(body); err != nil {
+		return err
+	}
+	_, err := pw.w.Write(hdr[4:8])
+	return err
+}
+
+// appendComment formats the metadata of the packet, e.g.:
+// "ingress=1 (external) egress=0 (internal) disposition=forwarded".
+func appendComment(b []byte, p *control.CapturedPacket) []byte {
+	b = append(b, "ingress="...)
+	b = strconv.AppendUint(b, uint64(p.Ingress), 10)
+	b = append(b, " ("...)
+	b = append(b, p.IngressScope...)
+	b = append(b, ')')
+	if p.EgressScope != "" {
+		b = append(b, " egress="...)
+		b = strconv.AppendUint(b, uint64(p.Egress), 10)
+		b = append(b, " ("...)
+		b = append(b, p.EgressScope...)
+		b = append(b, ')')
+	}
+	b = append(b, " disposition="...)
+	b = append(b, p.Disposition...)
+	if p.Reason != "" {
+		b = append(b, " reason="...)
+		b = append(b, p.Reason...)
+	}
+	return b
+}
+
+func appendOption(b []byte, code uint16, value []byte) []byte {
+	b = le.AppendUint16(b, code)
+	b = le.AppendUint16(b, uint16(len(value)))
+	return appendPadded(b, value)
+}
+
+// appendPadded appends data, padded to a multiple of 4 bytes.
+func appendPadded(b []byte, data []byte) []byte {
+	b = append(b, data...)
+	var pad [3]byte
+	return append(b, pad[:(4-len(data)%4)%4]...)
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgmtapi
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gopacket/gopacket/pcapgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/router/control"
+)
+
+func TestPcapngWriter(t *testing.T) {
+	packets := []*control.CapturedPacket{
+		{
+			Time:         time.Unix(1700000000, 123456789),
+			Ingress:      1,
+			IngressScope: control.ScopeExternal,
+			EgressScope:  control.ScopeInternal,
+			Disposition:  control.Forwarded,
+			Length:       5,
+			Data:         []byte("hello"),
+		},
+		{
+			Time:         time.Unix(1700000001, 0),
+			IngressScope: control.ScopeInternal,
+			Disposition:  control.Dropped,
+			Reason:       "invalid",
+			Length:       1500,
+			Data:         []byte("truncated"),
+		},
+	}
+
+	var buf bytes.Buffer
+	pw, err := newPcapngWriter(&buf, 9)
+	require.NoError(t, err)
+	for _, p := range packets {
+		require.NoError(t, pw.writePacket(p))
+	}
+
+	r, err := pcapgo.NewNgReader(&buf, pcapgo.DefaultNgReaderOptions)
+	require.NoError(t, err)
+	assert.EqualValues(t, linkTypeUser0, r.LinkType())
+	for _, p := range packets {
+		data, ci, err := r.ReadPacketData()
+		require.NoError(t, err)
+		assert.Equal(t, p.Data, data)
+		assert.Equal(t, p.Length, ci.Length)
+		assert.Equal(t, len(p.Data), ci.CaptureLength)
+		assert.True(t, p.Time.Equal(ci.Timestamp), "timestamp %s", ci.Timestamp)
+	}
+	_, _, err = r.ReadPacketData()
+	assert.Error(t, err)
+}
+
+func TestAppendComment(t *testing.T) {
+	testCases := map[string]struct {
+		Packet   control.CapturedPacket
+		Expected string
+	}{
+		"forwarded": {
+			Packet: control.CapturedPacket{
+				Ingress:      1,
+				IngressScope: control.ScopeExternal,
+				EgressScope:  control.ScopeInternal,
+				Disposition:  control.Forwarded,
+			},
+			Expected: "ingress=1 (external) egress=0 (internal) disposition=forwarded",
+		},
+		"dropped before routing": {
+			Packet: control.CapturedPacket{
+				IngressScope: control.ScopeInternal,
+				Disposition:  control.Dropped,
+				Reason:       "invalid",
+			},
+			Expected: "ingress=0 (internal) disposition=dropped reason=invalid",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, string(appendComment(nil, &tc.Packet)))
+		})
+	}
+}
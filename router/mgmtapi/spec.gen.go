@@ -18,45 +18,76 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xZ23LjNhL9FRSSh0yFutgz2WT05rE9iapmxipfKg+J1wURLRIxCDAAKFvr1b9vNQBS",
-	"JEVfZnaT1ObJFgmgD06fbnSDDzTVRakVKGfp7IEasKVWFvyPd4yfw+8VWIe/Uq0cKP8vK0spUuaEVpPf",
-	"rFb4zKY5FAz/+9rAis7oV5Pd0pPw1k4uHFOcGX5qjDZ0u90mlINNjShxMTpDm8REo/g2TvRw3p/gn9Lo",
-	"EowTASMHKwzwm0IoUVTFjbu/EcqBWTMZX7cWv8yBxIGkHkWW4O4AFHGGKVsIa4VWRK/Iu/cnBPdstCQl",
-	"S2/BWeJy5ojLgSAE5rQhwb4dk8tcWLJmsgIiLGF8jRgtcOK0n1ECmITk+g7WYPwTlrqKyR2QCkcLS2wJ",
-	"qVgJ4GS5IY7dCpX58QW798j1Klrlo7iZkbsfNcswxf3wgEWv/A8DhXbgme1MNJCCWMMOhJ81pgmFe1aU",
-	"EuiMHk6nhaUJdZsSf1pnhMqo95yDFKm9KSrpRCkFmGHSVVUswSCYDpNFZR1Zok9sZIpDKpkB4pBNC8EZ",
-	"zBKu7xRyDKQxusO80oFQ9Fg9R1iSMplWkrlAZIS4qdns0KMg0074oR0Z7ESyCZD26XndEIODMzDIDCi2",
-	"lMD3yZgrHgMHTd/l4HIwHriwJM7yHky1WomsMsCJVsG2B7Niade+MxU0EJZaS2AKIdSubiIjuvozoyLO",
-	"4k+FA7pqYx0UxOa6kpzYqiy1cc8HRZQlxgY+EoEd6Mh95dOBSjfkGzGGcdLFOgpYGuCvGuSPAkYkaQql",
-	"Q7ZrJFKnTMZtvEj+LYrp7Jcn89AjkbKTyRPeuk6oE84DeSe4MGEZJsl7be6Y4SjnkyYkatU0CmOqK5u4",
-	"Cb38DVKHMpnXb/dT63LFn0vnmJK3CW1M3IgBzV8cz88+7WAQwUE5THDm+UDysxSTN6KNc1+/jHMD1uKW",
-	"6ymkb7dOhbpyPdP04O3h+OAfP4wPx4ez1wfT6XQo3SkQWb7U5jlSGko/1RO8VqR3is1F+dwCH4S6PW+P",
-	"9+egV4+rnj1hceDHyys/yTEHL7F24Qf2Nd1xa2v/bTSJl0ltqrfPQf+1FB08NN95SLU89KRaP7V80VVt",
-	"VMK+TK5OFpP5glSKg5Fs05YMGu1h+QJ9CMtvQqXypDosP7L7VIe5SQO/xVK9Vwz1vqZB8VIL5epdSKFu",
-	"n45zex5LvH3qmmXDLweFfbHW0UY0yoxhGy8/sZRCZTdfsO5FmPrE8tsdQfWOiBTWIUshmeMxGiGQFoQh",
-	"crxPZg9tj49Wq+l0Np0dHKCzS+ZQyHRG//nrr/zb0Te/sNFqOnp7/XCQvNnOXj0cbruPXv0bx31Ndyjn",
-	"Fyejowsyb7LfkIb2Qh9BqapAjRyfnZ/ShB7/NP9wQhO6ODo//XSJ/5yenqNeduDrIYPLX9RJoV73akET",
-	"enL286fuIv7x/go6+wBrkPvqkfXjbth90FnmfeJfJ41VDssq8xlipfGxbwg6AOKbp8/dsOz1gFMXRi8l",
-	"FEMtg2NiAOkRyauCYcnDuC8N4L6UTIWzNBblaagXhCU6TStjQO0OljIYbIqMHGS5qiTOQEHGsqYeherM",
-	"sPRmfC1C7sv1HQ4ujU4B+Jj8bIRzgCc4OVWZFDb3sxp8WPeCyoQCMDYhla2YlBuitCO2EljM4giFWRXS",
-	"XAlf4Th2C7mWHIz1q+FoHy/iX8C7We9YKxULCyzNmWNLZoE4UWBVWrnBJKisY2romD4iV+dzYmAFgbVA",
-	"Ux0N1pPTsPwouwmBcTbGepxxX/wwsjIsK0C1FjNEG2Kr5ahkLm8asNo9mxLG5CPbYOdRxWK05SCjdUyn",
-	"wjaTRDiZrK5MCiTVvHdATOLASdpwNvKS/srpW1Aj1PIIHTfy7I0CeyttCubojFZGjBpmhmjF47Wyw7XP",
-	"T5eXCxIGeGQkA4XdaWwgsVs1IhOKWDDYe4Z26SkJd/b23fR1QmMxTmffvX2b0Fik0tnBdDpUtcWUt68A",
-	"m2uD4iwKZjZ7ceMd81eL/gKMj8crxdZMSLQ55JDwAHe4YpVEH7KlrtxsKZm6pclLtF8p8XsFctMPgjYf",
-	"RCscENTnb2DuXYu3teDAydFiPiZnZalbnVUdSSy2yuT8/fHo+x+m3ydE+OykQPje00CqiwIUD3OX2GHX",
-	"QD3hyFeoMZwmLOTIUeMOrtMKgy/YUdqQTOqld0nYX9Odd9z8suD5jBDpHQsxXmopDp0PTaE83BDH7rNz",
-	"HVAp5E6R5caB9RsL9VhsL2O/a6A0YLGcqfftdKqlT6BhiW8WJ1evuoWnZBswnmthG1G3bjCYbSCdot8U",
-	"OFKyjdSMkxGZL8hPwDgYMiJXJ/WPDssHb74/HIrVvUrr8bLwL+nu5nU716vX68ruD2/nIkF/s2ZugPpH",
-	"O7xeTxeAtNu46Ir5k0V2n8d9nf33/dP/umvqXlfvIYb6cVeyfjQpwFqWPZ+qmsq3Z327jcXx/jm6mDdZ",
-	"NWztvOmY65bIPyD1YXa0mNOErsHYsMJ0PB0f4AZ1CYqVgs7o6/F0fBg6ndxvbhIuk/DfDPy1f7j0FlrN",
-	"OZ3RH8EdhxFJ97PB4XTa+16Ap9aklEz0vhT0idn7GnBRpSlYi1X0WW0cYb8JJoZ00kCZtD5f+C8Joeqg",
-	"M7owok7Ol2cfP/RuzVZChqsylln0Dx6PWtFrXGNSO+QxRuahZ/n/4uMdsyIluDU8a5GDkmVAfEHTFB5G",
-	"S19Aopx8h2LtEyy1G/7IVa8tFNa1BLybEWojZmDvCrx9dTdAfCv3PEP/l3++GrhFGfCS35te7W1t3HLV",
-	"I3BiJfTt58GqW90BLHO1ZlI039TGPdc/6oaWa1tXd967UmeTptl/LBCae4I/0BuNjT8tUn4E7Bu6Fxp7",
-	"EZDQshog5aJHil//neabP4WP+hqmbT+cQM5UsP1beeniJV7yU3xDjM8faGUkndHcuXI2mTzk2rrt7KHU",
-	"xm0nrBST9QEeoMwI7Ho8Rzik2wH6jtI/Rg1o03v9evrmzSGycN3A2asc1mA2Lvd1LYTC3+mBPJJQxYq6",
-	"mK4vSPuLHfut4tlP4D40h8tNXCxm8vZSkZnt9fY/AQAA//9q2WLTFSAAAA==",
+	"H4sIAAAAAAAC/9xbe28bN7b/KsTsAptgR7L8ahv1LydOWwFpYviBArfra1AzZzRcz5BTkmNHzfV3vzh8",
+	"zUOUbKebbHf/SizxcR6/8+TRpyQTdSM4cK2S+adEgmoEV2D+eE3zc/itBaXxr0xwDdz8lzZNxTKqmeB7",
+	"/1SC42cqK6Gm+L+/SiiSefKXve7oPfut2rvQlOdU5m+lFDJ5eHhIkxxUJlmDhyVzvJNIdyl+6zYacn44",
+	"xX8aKRqQmlkac1BMQn5TM87qtr7RH28Y1yDvaOW+7h1+WQJxC4lfRZag7wE40ZJyVTOlmOBEFOT1D6cE",
+	"eZaiIg3NbkErokuqiS6BIAlUC0ns/WpKLkumyB2tWiBMEZrfIY0KcqKF2dEAyJSU4h7uQJpPaKZbWnWE",
+	"tLiaKaIayFjBICfLNdH0lvGVWV/Tj4ZyUbhb84ljZqI/TsIxlOdmuaVFFOYPCbXQYCQ72CghA3YHHRFm",
+	"1zRJE/hI66aCZJ4czGa1StJErxv8U2nJ+CoxmtOQoWhv6rbSrKkYyLjQeVsvQSIxA0nWrdJkiTpRTlI5",
+	"ZBWVQDRKU4FVBlUkF/ccZQwkXNrRXAgrUNSY38MUyWiVtRXVVpCOxLWX5kA8HFZCM7N0AIMOJGtL0qZ4",
+	"DoNgcPEKJEoGOF1WkG8KY8FzZzh49X0JugRpCGeKuF1Gg5ngBVu1EnIiuL3bEFPQbHi/li0EEpZCVEA5",
+	"kuBVHSzDqfqZVuF25bvMAVW1VhpqokrRVjlRbdMIqR83CgfLBkDiR8xKBwZwL5AT4NmavGBTmKZDWieW",
+	"lkD4y0D5VoKRkiyDRqO0PSWVyGjl2HgS/HsiTua/7vRDWyylg8kObV2niWbaEPKa5UzaY2hFfhDynsoc",
+	"4XwaTMKjJiCM8iFsHBNi+U/INMLkVIqmgfzMCGjTvcJKglI34YwblscBFFYQXUrRrkpyX7KstK7Pquie",
+	"KpT2EogCrr8nM8IKwjQpaU64QEcA3Ji3rBlH3Eu3gCmi/RXIesX47UBHBzEbLIHm2/zRxZvFh/fErvA+",
+	"wFKZkrZBKtEGGc+qNvcOo6G6nJJFf7GhTAiEvdTGDTGt3LEpuq26zUo8n2n8C20dDQFpL4SsqUaTXWuI",
+	"OVfG/8Wi95aMgvceM4gU2VVsWSGzKF41kO9+TL4V8JUu4yTZ74aSJYwTZHZ09OFsFjtdAnWJxfD0X8r1",
+	"mLHcQnhwbML4Ha1YflPTLCZdzWpQmtZN7AbgT7riYHZwPJkdTmb7l/sH89lsPptN9w8Oj46/+Z++gnOq",
+	"YYL3PepDOqIC/1twkEYNM+gkgL/nPU48P44XG9KASNFqkI/7BnXuUsNIDmbX3Tgvix8xDbV6LBkcnJ88",
+	"BAqolHRtpOOJ93eTiimNGPVM+Csj1C+8aDYJXhb5Y7RhsmmscJf1WTcS1hCWA9esYCAHSImmCN70OhXG",
+	"TYnmOWraeBG3hYzvFcVIleHqZP/VwXT/m++mB9OD+eH+bDaLWQMHtiqXQj4mlCDS936DQXBlwo0qWfPY",
+	"Ae8Yvz3vrzcZvomLun20dsCFP19emU2aanjKbRdm4djSRmYT+O9TkxqY+KtGfEb117M2q6FFpyHe09BO",
+	"tL4pKV+B2gQtzXPYFgEC7pTHQqDKJT33IA2WRk7s16PrtDPWTZQOLTJNapGb0uRz6LgvhYJRipIZbkc0",
+	"HT6PJkwi7/6oaNwhQ0L2n0PICGJWXR11Pdn1cLLoaHGiQL9MiYRK0HwnUN73jHYDKegyNgVydXq2tzgj",
+	"Lc9BVnTd9y06ZEWLaJnxNEfCVH5DH/X7C5WfqE2btHvTQH5PTJ5XdP1j5wc8bwTj2nPhs8PtktsRyjps",
+	"PDmKhWNj2HQ51c1nnHtht+44fleAtPVMP63rkRATjtHJ/FNf45OiwKxmvr+Pym6o1iB5Mk/+9x//yP8+",
+	"efErnRSzyavrT/vp0cP85aeDh+FHL/8P1/016aH94nRyckEWwSZjGNqIEUgUb2vEyJsP52+TNHnz0+Ld",
+	"aZImZyfnb99f4n/evj1HvHTE+yXR4y989PDnXp0laXL64Zf3w0OuzqIniNU7uINqEz2V/3hodu/EamV0",
+	"Yr5Ow605LNuVCSWFwI9NT+x6mMUWYpOEkeHYY68jSj2TYllBHeuaacoilJ6Qsq0pJxJobqpj+NhUlFtf",
+	"7fpSmS2ZmSIiy1opgXcZSGMvDHV2CVVTtBXuqESo7P0qROcKu080v2M2SJbiHhc3UmQA+ZT8IpnWwAnj",
+	"5C1fVUyVZlegDwsZ4CvGAaRKSataWlVrU06qlmnIzQqO4ReykjNT5Gt6C6WocpDKnIarjb2w38c5/hvB",
+	"uautsTtFNV1SBUSzGgvUVscrN6Upj+VzJ+TqfEEkFGClZsXkrcFWuUHKW6WbEpiupiZO5KY6paSQdFUD",
+	"7x0miZBEtcsJlq2hB+nVs25gSn6mayzGW9eP6SlICuHcKVNhE7MpjBKtzDCM56MAsecW7mVBZhMD6b9o",
+	"cQt8glieoOJMMZRPrPRCmdRKNgmSiYkV87BWxaP8T5eXZ8QuMJSRFXCQvvWHZAvJVowTBRLbr7b+3QXh",
+	"AW/Hs8M0cf2oZH786lWauD5NMt+P16/O5W0iwPYKVFvXVK437MYo5t8N+guQxh6vOL2jrMI7ozX0unEc",
+	"FrStUId0KVo9X1aU3ybpU7DfcvZbC9V6bAR9eRDBq7VHn3mE+Kh7crtjOeTk5GwxJR+aRvSai96SqOsW",
+	"k/Mf3ky+/W72beqaShyYab9KyERdA8/t3iWQHDyhRuAoL5tjaEGo9ZGToI5cZC0an72HC0lWlVgalVj+",
+	"QrtloOanGc8zTGQUFpy9eCjG4sM51fCO1SzS+Fu20j77bBqbYr8Ha1m2w9YO9sfoUgHX6H9+BymMsB1E",
+	"TP9vH/up5F5I1yCStEB35x5WMB5wgqabkmWrCRekAmUydU5ezWazWA/p4Hg2m816kmJcf3OU9Kw0aqOh",
+	"nRptAhlo2ISyZpqYNy9QHlye7NAff+EaNS+N40UBvAD3gW/LDYqPaS8RcFtDW2ecBPhvI6HmS7UnpMuQ",
+	"IupvlaamQYtrjOqZVqQBZDsTfOhMsFKYfZZ2nIRvsooqtauN61VhFqJ+uvbnQHU9cKZxxdKqGih3XDB6",
+	"L2RBGuAzZPi7o16sODg+3s3n7sZEuCJxGunVRJcmqjoDxC8dP7brDx9dr2h3+z94gB01EZ59Y85+evES",
+	"zn1e1WKSj8BKtFAJXaD4O5YV/PAVr+Wsc1HGGVvguOahe6aS0EhAcIQQpEUmKpP02SNenJ1evRwWyxVd",
+	"g7SPBSoE4t7DI1WBpLfoUDho0tA1FvdkQhZn5Cf7BjEhV6f+jyGcjr6Nvm5sVIfbS9l/S+tyEV4Whj0G",
+	"X41+8V6lE9B/WacyIvqt7ctRw1LpkQNxEuoaPnF7G8lxE2d/vOfzr+70DKdMNigG//EQsmY1qUEpuno8",
+	"vQrV+uj2hwdX0G/m/meLkAla1s5DO9g7RPMB8Qn4ydkiSZM7kMqeMJvOpvvIoGiA04Yl8+RwOpse2O5M",
+	"aZjby2ijW2kgt4JICnehJdC698KlbLmtVFctWetMCeOkyWjDV8QG7yl5S7PS7SMZlZKBIpTY/FmTe6ZL",
+	"8wbqchbjGW1O0+lRpWZJzlQjFEOq0jC0Yp+9CBs8u6WEYUxb2wEQTzSVQCS9H/ry1JAgWt01Oe1TmPo+",
+	"QMZWFUyRq4u35zPyYv/o25f2aGVFI1uuSMs1s9lAVjHkLWfKJeeKOC26QSXIIwMuJVX2RVsB11Ny1qOa",
+	"C02clnJkrXdJRjl+ewvQkLZxkSngnilbDIWyDHf7qRHWf2IYttnRSOzEEhN8kSfz5EfQbxxOEDuS1qBB",
+	"qmT+6xguH7iJaGbtcI4CUKEoigroHfSy3K2zKgdoGsk8+a0FuU7ShNPah5aej+smyUIa9c3x8eGjidQf",
+	"ppxaePjGh8rEuEzzWVUSZ8TsGHDQZfhho4uA5lz34XXE2TyJG2NvhtieNQ1Jdja0heLetijdhZ0yMfu7",
+	"k1Ql7m/Q43w+5e6wrhNjLd/aof2/NRaTNNAa7ACFUKH0HD0Bkxq0ZNmUnGDCd8vFPQ/eRNk5GDMR4I12",
+	"KKUux90qqvAi30npUdb7KWnnImweGjxAA9IxMSWv175WTk01Ynmtp2Rh55Y0qYXSRI/KcGv0f1PuHLJs",
+	"iwLkuEr+bgtqOW1uKhiy9jxTu9CiIbTQ4JRZU77u0us+U8bXOSzkAhT/myZKi2ZcNMZJzUTLdZzOyIzK",
+	"w3U6HGg9mM12TLJ+nNhYN5xm7QZ1GKeGlrHWH9J4hBVFT8dOFhi8j3ZS4RpBf3/eXK3v9EeoWYxxb0g4",
+	"/JoknPl8werduisTtQw1x1+XmkshLEAdPS6VaDlnfDW1U8e2PYsPAEO/heqnKzXIzJNr3LJnI24v7dqM",
+	"uHbFo6DEDudeU1E24vdx4LUZ5nH44vLBX95DXExegZS93rT3UARnkvmi+PLDz+9GL/gFq2DaEwumgoI7",
+	"mTgfPelNBkVz0nfMOTWXrIUMnypNCrjfHMB2aU4/jACmpj6M9PNUl94K2+mVueWlYFJp8t3waGbno2yX",
+	"gLrTCNyBXLvuVshVORHcdIQOD6bExLh+Sj2ashpTYjGHbQ8cyLNxbbQlJLBLKIQEv9c8+EjXFTf+sMv9",
+	"bAhU0XRvOMz1pKyv8lr54kF7x+jeZ4bi57n+5/mWLXNxEYM0uMZ5/SHbqY3iEjLg2gLxzxEZBnYfjNJY",
+	"YWTibosj9NXvNje4sI/a/1lO8DVVLCOM23QAHV9DV0DMi1d4mZKiIsrV7s5ct7rG4UTIbq84aoQ4X4WG",
+	"Nv6ZQH8IMCL4XqPni1lHZMxmh2WMWfsz28GY1p024BftuVEu7EKJ2JPaufneXBCa9vG5HVJIYRs3WjSi",
+	"Equ1ib5m0lxwcrH48aerM+uMt4//pX7ULSXY7XIzaT6C4HkGWEbgkId2igSlqeya9AZl38deS4R5OOsR",
+	"wJTpeORMybbRkFsK3SyQb/ywLf1i386wfZElhDE5E3/u6XoT6VagXxnsfmwzlm9G9BHmHr9+Mm7FY5Cl",
+	"3MyA+bWOzzyYckJ3uflXNcXLPrgz82Mip3ck2v4oxEHTwsgKcoBy+7VPmwyqKataO+RJmRkFgKKAbMPS",
+	"e6b4DFuvxGovTH5tC3phaOwLgjHc8dWi4o+gSTWabtuIdmnStBGhXIyEYs5/LfL1V5GHn8nr329b+1q2",
+	"8PBfpaWLp2gJkSzxpyrdS+/upETH35/7yYkZrdLYsHY2qcUwzvXc4sZvGDZMqHur/pJGFHkR35G+9Pj+",
+	"M2cuozf1iCMLRjp6s8qNy5XQVPYHZvCUUYPwzDUalLDRvj+u0Xfi+FtnUM41E1bXkDOqoVpPyYm9VRRm",
+	"pMhlMKob4tjEy0UPL1/IvXTnbwlknaSmX9XDPIuwPx1o0VvRHonbIi9uMtOctn/RyiqZJ6XWzXxv71Mp",
+	"lH6Yf8K85mGPNmzvbh9fUqlk2HY0Ei9DNu7HF804pPkYzUHI0deHs6OjA2ToOlC08YSMjSJdmgEHsBMg",
+	"WkRqnM2nr2Szqf7GuGZ8BMYxbOGeZ+1hrsrsH+U8+cP1w/8PAA9ge6bVQQAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -3,6 +3,10 @@
 // Code generated by unknown module path version unknown version DO NOT EDIT.
 package mgmtapi
 
+import (
+	"time"
+)
+
 // Defines values for LinkRelationship.
 const (
 	CHILD  LinkRelationship = "CHILD"
@@ -24,6 +28,26 @@ const (
 	Info  LogLevelLevel = "info"
 )
 
+// Defines values for RateLimitDirection.
+const (
+	Egress  RateLimitDirection = "egress"
+	Ingress RateLimitDirection = "ingress"
+)
+
+// Defines values for GetCaptureParamsScope.
+const (
+	External GetCaptureParamsScope = "external"
+	Internal GetCaptureParamsScope = "internal"
+	Sibling  GetCaptureParamsScope = "sibling"
+)
+
+// Defines values for GetCaptureParamsDisposition.
+const (
+	Dropped   GetCaptureParamsDisposition = "dropped"
+	Forwarded GetCaptureParamsDisposition = "forwarded"
+	SlowPath  GetCaptureParamsDisposition = "slow_path"
+)
+
 // BFD defines model for BFD.
 type BFD struct {
 	// DesiredMinimumTxInterval The minimum interval between transmission of BFD control packets that the operator desires. This value is advertised to the peer, however the actual interval used is specified by taking the maximum of desired-minimum-tx-interval and the value of the remote required-minimum-receive interval value.
@@ -39,6 +63,32 @@ type BFD struct {
 	RequiredMinimumReceive string `json:"required_minimum_receive"`
 }
 
+// DroppedPacket defines model for DroppedPacket.
+type DroppedPacket struct {
+	// EgressInterfaceId The interface through which the packet was to be sent; 0 if it had not been determined or if it is the internal link.
+	EgressInterfaceId int `json:"egress_interface_id"`
+
+	// Header The SCION header of the packet, up to and including the path. If the packet is too short for its header, as much of it as there is.
+	Header []byte `json:"header"`
+
+	// IngressInterfaceId The interface through which the packet was received; 0 for the internal and sibling links.
+	IngressInterfaceId int `json:"ingress_interface_id"`
+
+	// Length The length of the packet in bytes.
+	Length int `json:"length"`
+
+	// Reason Why the packet was dropped.
+	Reason string `json:"reason"`
+
+	// Timestamp When the packet was dropped.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// DroppedPacketsResponse defines model for DroppedPacketsResponse.
+type DroppedPacketsResponse struct {
+	DroppedPackets *[]DroppedPacket `json:"dropped_packets,omitempty"`
+}
+
 // Interface defines model for Interface.
 type Interface struct {
 	Bfd BFD `json:"bfd"`
@@ -56,6 +106,18 @@ type Interface struct {
 	State    LinkState `json:"state"`
 }
 
+// InterfaceChanges defines model for InterfaceChanges.
+type InterfaceChanges struct {
+	// Added The identifiers of the interfaces that were added.
+	Added []int `json:"added"`
+
+	// Modified The identifiers of the interfaces whose configuration changed.
+	Modified []int `json:"modified"`
+
+	// Removed The identifiers of the interfaces that were removed.
+	Removed []int `json:"removed"`
+}
+
 // InterfaceNeighbor defines model for InterfaceNeighbor.
 type InterfaceNeighbor struct {
 	// Address UDP/IP underlay address of the SCION Interface.
@@ -105,6 +167,32 @@ type Problem struct {
 	Type *string `json:"type,omitempty"`
 }
 
+// RateLimit defines model for RateLimit.
+type RateLimit struct {
+	// Burst The size of the bucket in bytes. If absent or zero, it defaults to 100ms worth of traffic at the given rate, but no less than 9000 bytes.
+	Burst *int64 `json:"burst,omitempty"`
+
+	// Direction Whether the limit applies to the traffic received (ingress) or sent (egress) through the interface.
+	Direction RateLimitDirection `json:"direction"`
+
+	// InterfaceId SCION interface identifier.
+	InterfaceId int `json:"interface_id"`
+
+	// Rate The sustained rate in bits per second.
+	Rate int64 `json:"rate"`
+
+	// TrafficClass The SCION traffic class to which the limit applies. If absent, the limit applies to all the traffic of the interface in the given direction.
+	TrafficClass *int `json:"traffic_class,omitempty"`
+}
+
+// RateLimitDirection Whether the limit applies to the traffic received (ingress) or sent (egress) through the interface.
+type RateLimitDirection string
+
+// RateLimitsResponse defines model for RateLimitsResponse.
+type RateLimitsResponse struct {
+	RateLimits *[]RateLimit `json:"rate_limits,omitempty"`
+}
+
 // ScionMTU The maximum transmission unit in bytes for SCION packets. This represents the protocol data unit (PDU) of the SCION layer and is usually calculated as maximum Ethernet payload - IP Header - UDP Header.
 type ScionMTU = int
 
@@ -137,5 +225,41 @@ type StandardError struct {
 // BadRequest defines model for BadRequest.
 type BadRequest = StandardError
 
+// GetCaptureParams defines parameters for GetCapture.
+type GetCaptureParams struct {
+	// InterfaceId Only capture packets that enter or leave through this interface.
+	InterfaceId *int `form:"interface_id,omitempty" json:"interface_id,omitempty"`
+
+	// Scope Only capture packets that enter or leave through a link of this scope.
+	Scope *GetCaptureParamsScope `form:"scope,omitempty" json:"scope,omitempty"`
+
+	// Disposition Only capture packets with this disposition.
+	Disposition *GetCaptureParamsDisposition `form:"disposition,omitempty" json:"disposition,omitempty"`
+
+	// Reason Only capture packets dropped for this reason. The reasons are the same as those of the dropped packets metric.
+	Reason *string `form:"reason,omitempty" json:"reason,omitempty"`
+
+	// SnapLen The maximum number of bytes captured per packet. By default, all of them.
+	SnapLen *int `form:"snap_len,omitempty" json:"snap_len,omitempty"`
+
+	// Count Stop after this many packets. By default, the capture doesn't stop.
+	Count *int `form:"count,omitempty" json:"count,omitempty"`
+}
+
+// GetCaptureParamsScope defines parameters for GetCapture.
+type GetCaptureParamsScope string
+
+// GetCaptureParamsDisposition defines parameters for GetCapture.
+type GetCaptureParamsDisposition string
+
+// GetDroppedPacketsParams defines parameters for GetDroppedPackets.
+type GetDroppedPacketsParams struct {
+	// Reason Only list the packets dropped for this reason. The reasons are the same as those of the dropped packets metric.
+	Reason *string `form:"reason,omitempty" json:"reason,omitempty"`
+}
+
 // SetLogLevelJSONRequestBody defines body for SetLogLevel for application/json ContentType.
 type SetLogLevelJSONRequestBody = LogLevel
+
+// SetRateLimitJSONRequestBody defines body for SetRateLimit for application/json ContentType.
+type SetRateLimitJSONRequestBody = RateLimit
@@ -0,0 +1,170 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/router/control"
+)
+
+const (
+	// minBurst is the smallest default bucket size: one packet buffer. A smaller bucket would
+	// never let the largest packets through.
+	minBurst = bufSize
+	// defaultBurstTime is how much traffic, at the limited rate, the default bucket holds.
+	defaultBurstTime = 100 * time.Millisecond
+)
+
+// DefaultBurst returns the bucket size, in bytes, used for a limit of the given rate, in bits per
+// second, when none is configured.
+func DefaultBurst(rate uint64) uint64 {
+	burst := uint64(float64(rate) / 8 * defaultBurstTime.Seconds())
+	if burst < minBurst {
+		return minBurst
+	}
+	return burst
+}
+
+// rateLimitParams is the immutable configuration of a rateLimiter. It is replaced as a whole when
+// the limit changes.
+type rateLimitParams struct {
+	rate  int64 // Bits per second.
+	burst int64 // Bytes.
+	// tolerance is how far ahead of the present the limiter may run. It is the time it takes to
+	// fill the bucket at the given rate.
+	tolerance int64
+}
+
+// rateLimiter polices a flow of packets with a token bucket. It does not count tokens. Instead it
+// keeps the time at which the bucket would be full again if no other packet arrived (i.e. it is
+// a virtual scheduling implementation of the GCRA). A packet conforms if accounting for it does
+// not push that time further than the bucket's worth of time into the future. This requires only
+// one word of state, which can be updated with compare-and-swap. So, the limiter can be shared by
+// all the processors without a lock.
+type rateLimiter struct {
+	params atomic.Pointer[rateLimitParams] // Nil means no limit.
+	full   atomic.Int64                    // Time at which the bucket is full, in ns.
+}
+
+// set installs the given limit. A zero rate removes the limit. The bucket starts full.
+func (l *rateLimiter) set(rate, burst uint64) {
+	if rate == 0 {
+		l.params.Store(nil)
+		return
+	}
+	rate = min(rate, math.MaxInt64)
+	burst = min(burst, math.MaxInt64/8)
+	l.params.Store(&rateLimitParams{
+		rate:      int64(rate),
+		burst:     int64(burst),
+		tolerance: int64(float64(burst) * 8 * float64(time.Second) / float64(rate)),
+	})
+	l.full.Store(0)
+}
+
+// get returns the current rate and burst. The rate is zero if there is no limit.
+func (l *rateLimiter) get() (uint64, uint64) {
+	p := l.params.Load()
+	if p == nil {
+		return 0, 0
+	}
+	return uint64(p.rate), uint64(p.burst)
+}
+
+// allow accounts for a packet of the given size, sent at the given time, in ns. It returns false,
+// without accounting for the packet, if doing so would exceed the limit.
+func (l *rateLimiter) allow(now int64, size int) bool {
+	p := l.params.Load()
+	if p == nil {
+		return true
+	}
+	cost := int64(size) * 8 * int64(time.Second) / p.rate
+	for {
+		full := l.full.Load()
+		next := full + cost
+		if full < now {
+			next = now + cost
+		}
+		if next-now > p.tolerance {
+			return false
+		}
+		if l.full.CompareAndSwap(full, next) {
+			return true
+		}
+	}
+}
+
+// refund gives back the tokens taken by allow for a packet of the given size. It is used when a
+// packet that conformed to this limit is dropped because of another one. If the limit changed in
+// the meantime, the bucket was refilled anyway, and giving back more than was taken is harmless.
+func (l *rateLimiter) refund(size int) {
+	p := l.params.Load()
+	if p == nil {
+		return
+	}
+	l.full.Add(-int64(size) * 8 * int64(time.Second) / p.rate)
+}
+
+// directionRateLimits are the limits that apply to the traffic of one interface in one direction.
+// A packet must conform to both the limit of its traffic class and the limit of the interface.
+type directionRateLimits struct {
+	all     rateLimiter
+	classes [math.MaxUint8 + 1]rateLimiter
+}
+
+// interfaceRateLimits are the limits that apply to the traffic of one external interface.
+type interfaceRateLimits struct {
+	ingress directionRateLimits
+	egress  directionRateLimits
+}
+
+func (r *interfaceRateLimits) direction(d control.Direction) *directionRateLimits {
+	if d == control.Egress {
+		return &r.egress
+	}
+	return &r.ingress
+}
+
+// allow returns true if a packet of the given size and traffic class conforms to the limits.
+// A packet that does not conform is not charged to any of them. The time is only read if a limit
+// is configured.
+func (d *directionRateLimits) allow(tc uint8, size int) bool {
+	class, all := &d.classes[tc], &d.all
+	if class.params.Load() == nil && all.params.Load() == nil {
+		return true
+	}
+	now := time.Since(rateLimitEpoch).Nanoseconds()
+	if !class.allow(now, size) {
+		return false
+	}
+	if !all.allow(now, size) {
+		class.refund(size)
+		return false
+	}
+	return true
+}
+
+// refund gives back the tokens taken by allow for a packet that was dropped afterwards.
+func (d *directionRateLimits) refund(tc uint8, size int) {
+	d.classes[tc].refund(size)
+	d.all.refund(size)
+}
+
+// rateLimitEpoch is the reference of the limiters' clock. Using a duration since a fixed time
+// gives us a monotonic clock.
+var rateLimitEpoch = time.Now()
@@ -0,0 +1,153 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/private/ptr"
+	"github.com/scionproto/scion/router/control"
+)
+
+func TestRateLimiter(t *testing.T) {
+	var l rateLimiter
+	assert.True(t, l.allow(0, 1<<20), "no limit")
+
+	// 8 Mbit/s is 1 byte per µs. The bucket holds 10 packets of 1000 bytes.
+	l.set(8_000_000, 10_000)
+	rate, burst := l.get()
+	assert.Equal(t, uint64(8_000_000), rate)
+	assert.Equal(t, uint64(10_000), burst)
+
+	now := int64(time.Second)
+	for i := 0; i < 10; i++ {
+		assert.True(t, l.allow(now, 1000), "packet %d within burst", i)
+	}
+	assert.False(t, l.allow(now, 1000), "bucket is empty")
+
+	// The bucket refills at the given rate.
+	now += int64(999 * time.Microsecond)
+	assert.False(t, l.allow(now, 1000), "not quite refilled")
+	now += int64(time.Microsecond)
+	assert.True(t, l.allow(now, 1000), "refilled")
+	assert.False(t, l.allow(now, 1000), "empty again")
+
+	// An idle period doesn't fill the bucket beyond its size.
+	now += int64(time.Hour)
+	for i := 0; i < 10; i++ {
+		assert.True(t, l.allow(now, 1000), "packet %d within burst", i)
+	}
+	assert.False(t, l.allow(now, 1000), "bucket is empty")
+
+	// Setting the limit again refills the bucket. Removing it disables policing.
+	l.set(8_000_000, 10_000)
+	assert.True(t, l.allow(now, 1000))
+	l.set(0, 0)
+	assert.True(t, l.allow(now, 1<<20))
+	rate, _ = l.get()
+	assert.Zero(t, rate)
+}
+
+func TestRateLimiterConcurrent(t *testing.T) {
+	var l rateLimiter
+	l.set(8_000_000, 100_000)
+	now := int64(time.Second)
+	var wg sync.WaitGroup
+	var allowed atomic.Int64
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if l.allow(now, 1000) {
+					allowed.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(100), allowed.Load())
+}
+
+func TestDirectionRateLimits(t *testing.T) {
+	var limits interfaceRateLimits
+	ingress := limits.direction(control.Ingress)
+	require.Same(t, &limits.ingress, ingress)
+	require.Same(t, &limits.egress, limits.direction(control.Egress))
+
+	// The class limit is tighter than the interface limit. Other classes only get the latter.
+	ingress.all.set(8_000_000, 100_000)
+	ingress.classes[0xb8].set(8_000_000, 10_000)
+	for i := 0; i < 10; i++ {
+		require.True(t, ingress.allow(0xb8, 1000))
+	}
+	assert.False(t, ingress.allow(0xb8, 1000))
+	for i := 0; i < 90; i++ {
+		require.True(t, ingress.allow(0, 1000))
+	}
+	assert.False(t, ingress.allow(0, 1000))
+	assert.True(t, limits.egress.allow(0, 1000), "egress is not limited")
+
+	// A packet that the interface limit drops is not charged to its class.
+	egress := limits.direction(control.Egress)
+	egress.all.set(8_000_000, 2_000)
+	egress.classes[0xb8].set(8_000_000, 3_000)
+	require.True(t, egress.allow(0xb8, 1000))
+	require.True(t, egress.allow(0xb8, 1000))
+	for i := 0; i < 5; i++ {
+		require.False(t, egress.allow(0xb8, 1000))
+	}
+	egress.all.set(8_000_000, 2_000)
+	assert.True(t, egress.allow(0xb8, 1000), "dropped packets consumed the class budget")
+	assert.False(t, egress.allow(0xb8, 1000))
+
+	// Refunding gives back what was taken from both buckets.
+	egress.refund(0xb8, 1000)
+	assert.True(t, egress.allow(0xb8, 1000))
+}
+
+func TestSetRateLimit(t *testing.T) {
+	d := newDataPlane(RunConfig{BatchSize: 64}, false)
+	assert.ErrorIs(t, d.SetRateLimit(control.RateLimit{
+		IfID:      1,
+		Direction: control.Ingress,
+		Rate:      1000,
+	}), errNotExternal)
+
+	d.fwd.Load().rateLimits[1] = &interfaceRateLimits{}
+	require.NoError(t, d.SetRateLimit(control.RateLimit{
+		IfID:         1,
+		Direction:    control.Egress,
+		TrafficClass: ptr.To[uint8](3),
+		Rate:         1000,
+		Burst:        9000,
+	}))
+	rate, burst := d.fwd.Load().rateLimits[1].egress.classes[3].get()
+	assert.Equal(t, uint64(1000), rate)
+	assert.Equal(t, uint64(9000), burst)
+	rate, _ = d.fwd.Load().rateLimits[1].egress.all.get()
+	assert.Zero(t, rate)
+}
+
+func TestDefaultBurst(t *testing.T) {
+	assert.Equal(t, uint64(bufSize), DefaultBurst(1000))
+	assert.Equal(t, uint64(1_250_000), DefaultBurst(100_000_000))
+}
@@ -0,0 +1,207 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"math"
+	"slices"
+	"sync/atomic"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/private/topology"
+)
+
+// forwardingTable holds what the processors need to know about each interface. A table that is
+// in use by the processors is never modified, except for the state of the rate limiters.
+// Instead, a copy is modified and replaces it. That way, the processors always see a consistent
+// set of interfaces, without locking.
+type forwardingTable struct {
+	interfaces  [math.MaxUint16 + 1]Link
+	rateLimits  [math.MaxUint16 + 1]*interfaceRateLimits
+	linkTypes   [math.MaxUint16 + 1]topology.LinkType
+	neighborIAs [math.MaxUint16 + 1]addr.IA
+}
+
+// newForwarding returns a holder for the forwarding table in use, initially an empty one. It is
+// allocated separately so that the dataplane structure can be returned by value.
+func newForwarding() *atomic.Pointer[forwardingTable] {
+	fwd := &atomic.Pointer[forwardingTable]{}
+	fwd.Store(&forwardingTable{})
+	return fwd
+}
+
+// barrier is a marker that drainProcessors queues to the processors. It is never processed.
+var barrier = &Packet{}
+
+// editTable returns the forwarding table to which configuration changes apply. Until the
+// dataplane runs, that is the table in use. Afterwards, it is a copy that CommitInterfaces
+// installs. The caller must hold d.mtx.
+func (d *dataPlane) editTable() *forwardingTable {
+	if !d.isRunning() {
+		return d.fwd.Load()
+	}
+	if d.staged == nil {
+		staged := *d.fwd.Load()
+		d.staged = &staged
+	}
+	return d.staged
+}
+
+// getUnderlay returns the named underlay provider, instantiating it if needed. A provider that is
+// instantiated while the dataplane is running is started right away; it has no links yet.
+func (d *dataPlane) getUnderlay(name string) (UnderlayProvider, error) {
+	underlay, instantiated := d.underlays[name]
+	if instantiated {
+		return underlay, nil
+	}
+	underlayProvider, exists := underlayProviders[name]
+	if !exists {
+		return nil, serrors.New("no provider for underlay", "provider", name)
+	}
+	underlay = underlayProvider(
+		d.RunConfig.BatchSize,
+		d.RunConfig.SendBufferSize,
+		d.RunConfig.ReceiveBufferSize,
+	)
+	if d.isRunning() {
+		// The packets have been allocated already, with the headroom that the underlays in use
+		// at the time needed.
+		if underlay.Headroom() > d.packetPool.headroom {
+			return nil, serrors.JoinNoStack(errInsufficientHeadroom, nil, "provider", name)
+		}
+		underlay.Start(d.runCtx, d.packetPool, d.procQs)
+	}
+	d.underlays[name] = underlay
+	return underlay, nil
+}
+
+// addLink installs the given link in the given table. If the dataplane is running, the link is
+// started when the change is committed, unless it is already in use: sibling links are shared
+// by all the interfaces that are reached through the same sibling router.
+func (d *dataPlane) addLink(t *forwardingTable, ifID uint16, lk Link, underlay UnderlayProvider) {
+	if d.isRunning() && !slices.Contains(t.interfaces[:], lk) &&
+		!slices.Contains(d.linksToStart, lk) {
+
+		d.linksToStart = append(d.linksToStart, lk)
+	}
+	t.interfaces[ifID] = lk
+	d.linkUnderlays[lk] = underlay
+	d.numInterfaces++
+}
+
+// RemoveInterface removes the external or sibling interface with the given ID, along with its
+// link, unless that link is shared with other sibling interfaces. On a running dataplane, the
+// removal takes effect when the change is committed; see CommitInterfaces.
+func (d *dataPlane) RemoveInterface(ifID uint16) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if ifID == 0 {
+		return errRemoveInternal
+	}
+	t := d.editTable()
+	lk := t.interfaces[ifID]
+	if lk == nil {
+		return serrors.JoinNoStack(errNoSuchInterface, nil, "ifID", ifID)
+	}
+	t.interfaces[ifID] = nil
+	t.rateLimits[ifID] = nil
+	t.linkTypes[ifID] = topology.Unset
+	t.neighborIAs[ifID] = 0
+	d.numInterfaces--
+	if slices.Contains(t.interfaces[:], lk) {
+		return nil
+	}
+
+	// A link that hasn't been started yet isn't in use, so it can be removed right away.
+	if !d.isRunning() || slices.Contains(d.linksToStart, lk) {
+		d.linksToStart = slices.DeleteFunc(d.linksToStart, func(l Link) bool { return l == lk })
+		d.linkUnderlays[lk].RemoveLink(lk)
+		delete(d.linkUnderlays, lk)
+		return nil
+	}
+	d.linksToRemove = append(d.linksToRemove, lk)
+	return nil
+}
+
+// CommitInterfaces applies the changes made to the interfaces of the running dataplane since the
+// last commit. The processors switch to the new set of interfaces at once, so the traffic of the
+// unchanged interfaces is not disrupted. The links of the removed interfaces are stopped and
+// their BFD sessions closed. Then, the links of the new interfaces are started. On a dataplane
+// that is not running, changes take effect immediately and this has no effect.
+//
+// Links need their addresses to be free, so removals are committed before any addition to the
+// same change is made. Modifying an interface therefore interrupts its traffic briefly.
+func (d *dataPlane) CommitInterfaces() {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.commit()
+}
+
+// commitRemovals commits the pending changes if they include removals. The caller must hold
+// d.mtx.
+func (d *dataPlane) commitRemovals() {
+	if len(d.linksToRemove) > 0 {
+		d.commit()
+	}
+}
+
+func (d *dataPlane) commit() {
+	if d.staged == nil {
+		return
+	}
+	d.fwd.Store(d.staged)
+	d.staged = nil
+
+	// The removed links may still be referenced by the packets that they have already delivered,
+	// for example for an SCMP error to be sent back through them. So, they can only be stopped
+	// once these packets are gone.
+	if len(d.linksToRemove) > 0 {
+		for _, lk := range d.linksToRemove {
+			d.linkUnderlays[lk].StopReceiving(lk)
+		}
+		d.drainProcessors()
+		for _, lk := range d.linksToRemove {
+			d.linkUnderlays[lk].RemoveLink(lk)
+			delete(d.linkUnderlays, lk)
+		}
+		d.linksToRemove = nil
+	}
+
+	// Each new link holds packets in its receive batch and its send queue; see initPacketPool.
+	want := 3 * len(d.linksToStart) * d.RunConfig.BatchSize
+	if added := d.addPackets(want); added < want {
+		log.Info("Packet pool is full; new links may cause packet drops",
+			"wanted", want, "added", added)
+	}
+	for _, lk := range d.linksToStart {
+		d.linkUnderlays[lk].StartLink(lk)
+	}
+	d.linksToStart = nil
+}
+
+// drainProcessors returns once the processors have processed all the packets that were queued for
+// them when it was called, along with the packets that they queued for the slow path meanwhile.
+func (d *dataPlane) drainProcessors() {
+	for _, qs := range [][]chan *Packet{d.procQs, d.slowQs} {
+		d.barrierDone.Add(len(qs))
+		for _, q := range qs {
+			q <- barrier
+		}
+		d.barrierDone.Wait()
+	}
+}
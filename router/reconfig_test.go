@@ -0,0 +1,170 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/ptr"
+	"github.com/scionproto/scion/private/topology"
+	"github.com/scionproto/scion/router/control"
+	"github.com/scionproto/scion/router/mock_router"
+)
+
+func TestRemoveInterface(t *testing.T) {
+	internal := "10.10.0.1:2222"
+	localHost := addr.HostIP(netip.MustParseAddrPort(internal).Addr())
+	link := func(remote string) control.LinkInfo {
+		return control.LinkInfo{
+			Provider: "udpip",
+			Local:    control.LinkEnd{IA: addr.MustParseIA("1-ff00:0:1"), Addr: "10.0.0.100:0"},
+			Remote:   control.LinkEnd{IA: addr.MustParseIA("1-ff00:0:3"), Addr: remote},
+			LinkTo:   topology.Child,
+			BFD:      control.BFD{Disable: ptr.To(true)},
+		}
+	}
+	host := func(a string) addr.Host {
+		return addr.HostIP(netip.MustParseAddrPort(a).Addr())
+	}
+	// prepareDP returns a dataplane with the given number of connections expected to be closed.
+	prepareDP := func(t *testing.T, closed int) *dataPlane {
+		ctrl := gomock.NewController(t)
+		mConn := mock_router.NewMockBatchConn(ctrl)
+		mConn.EXPECT().Close().Return(nil).Times(closed)
+		d := newDataPlane(RunConfig{BatchSize: 64}, false)
+		d.underlays["udpip"].SetConnOpener(MockConnOpener{Ctrl: ctrl, Conn: mConn})
+		require.NoError(t, d.AddInternalInterface(localHost, "udpip", internal))
+		return d
+	}
+
+	t.Run("internal interface cannot be removed", func(t *testing.T) {
+		d := prepareDP(t, 0)
+		assert.ErrorIs(t, d.RemoveInterface(0), errRemoveInternal)
+	})
+	t.Run("unknown interface", func(t *testing.T) {
+		d := prepareDP(t, 0)
+		assert.ErrorIs(t, d.RemoveInterface(42), errNoSuchInterface)
+	})
+	t.Run("unknown underlay provider", func(t *testing.T) {
+		d := prepareDP(t, 0)
+		d.setRunning()
+		l := link("10.0.0.200:0")
+		l.Provider = "udpipp"
+		err := d.AddExternalInterface(42, l, host(l.Local.Addr), host(l.Remote.Addr))
+		assert.ErrorContains(t, err, "no provider for underlay")
+		assert.Nil(t, d.editTable().interfaces[42])
+	})
+	t.Run("removed before start", func(t *testing.T) {
+		d := prepareDP(t, 1)
+		l := link("10.0.0.200:0")
+		require.NoError(t, d.AddExternalInterface(42, l, host(l.Local.Addr), host(l.Remote.Addr)))
+		require.NoError(t, d.AddNeighborIA(42, l.Remote.IA))
+
+		require.NoError(t, d.RemoveInterface(42))
+		fwd := d.fwd.Load()
+		assert.Nil(t, fwd.interfaces[42])
+		assert.Nil(t, fwd.rateLimits[42])
+		assert.Equal(t, topology.Unset, fwd.linkTypes[42])
+		assert.True(t, fwd.neighborIAs[42].IsZero())
+		assert.Len(t, d.linkUnderlays, 1)
+
+		// The interface can be added again.
+		require.NoError(t, d.AddExternalInterface(42, l, host(l.Local.Addr), host(l.Remote.Addr)))
+		require.NoError(t, d.AddNeighborIA(42, l.Remote.IA))
+	})
+	t.Run("removed on commit after start", func(t *testing.T) {
+		d := prepareDP(t, 1)
+		l := link("10.0.0.200:0")
+		require.NoError(t, d.AddExternalInterface(42, l, host(l.Local.Addr), host(l.Remote.Addr)))
+		d.setRunning()
+		before := d.fwd.Load()
+
+		require.NoError(t, d.RemoveInterface(42))
+		assert.Same(t, before, d.fwd.Load(), "forwarding table replaced before commit")
+		assert.NotNil(t, before.interfaces[42])
+
+		d.CommitInterfaces()
+		assert.Nil(t, d.fwd.Load().interfaces[42])
+		assert.NotNil(t, before.interfaces[42], "forwarding table in use modified")
+		assert.Empty(t, d.linksToRemove)
+		assert.Len(t, d.linkUnderlays, 1)
+	})
+	t.Run("replaced after start", func(t *testing.T) {
+		d := prepareDP(t, 1)
+		l := link("10.0.0.200:0")
+		require.NoError(t, d.AddExternalInterface(42, l, host(l.Local.Addr), host(l.Remote.Addr)))
+		d.setRunning()
+		old := d.fwd.Load().interfaces[42]
+
+		require.NoError(t, d.RemoveInterface(42))
+		l = link("10.0.0.201:0")
+		require.NoError(t, d.AddExternalInterface(42, l, host(l.Local.Addr), host(l.Remote.Addr)))
+		// Adding the interface again commits the removal.
+		assert.Nil(t, d.fwd.Load().interfaces[42])
+		assert.NotContains(t, d.linkUnderlays, old)
+
+		d.CommitInterfaces()
+		assert.NotNil(t, d.fwd.Load().interfaces[42])
+		assert.NotSame(t, old, d.fwd.Load().interfaces[42])
+	})
+	t.Run("shared sibling link", func(t *testing.T) {
+		d := prepareDP(t, 1)
+		l := link("10.10.0.2:2222")
+		require.NoError(t, d.AddNextHop(43, l, localHost, host(l.Remote.Addr)))
+		require.NoError(t, d.AddNextHop(44, l, localHost, host(l.Remote.Addr)))
+		fwd := d.fwd.Load()
+		require.Same(t, fwd.interfaces[43], fwd.interfaces[44])
+
+		require.NoError(t, d.RemoveInterface(43))
+		assert.Nil(t, fwd.interfaces[43])
+		assert.Contains(t, d.linkUnderlays, fwd.interfaces[44])
+
+		require.NoError(t, d.RemoveInterface(44))
+		assert.Len(t, d.linkUnderlays, 1)
+	})
+}
+
+func TestDrainProcessors(t *testing.T) {
+	d := newDataPlane(RunConfig{NumProcessors: 2, NumSlowPathProcessors: 1}, false)
+	require.NoError(t, d.SetKey(testKey))
+	d.procQs, d.slowQs = d.initQueues(8)
+	d.setRunning()
+	for i, q := range d.procQs {
+		go d.runProcessor(i, q, d.slowQs[0])
+	}
+	go d.runSlowPathProcessor(0, d.slowQs[0])
+
+	done := make(chan struct{})
+	go func() {
+		d.drainProcessors()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("processors not drained")
+	}
+	d.setStopping()
+	for _, q := range append(d.procQs, d.slowQs...) {
+		close(q)
+	}
+}
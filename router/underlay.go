@@ -112,6 +112,23 @@ type UnderlayProvider interface {
 	// started. Calling Start has no effect on already running connections.
 	Start(ctx context.Context, pool PacketPool, proQs []chan *Packet)
 
+	// StartLink starts the given link, which was created after Start was called. It has no
+	// effect if the provider is not running yet or if the link is already running: Start starts
+	// every link in existence.
+	StartLink(l Link)
+
+	// StopReceiving makes the given link stop delivering incoming packets. It is the first step
+	// of removing a link: the link can still send, so that the packets that it delivered
+	// earlier can still be answered. The link no longer receives when this method returns.
+	StopReceiving(l Link)
+
+	// RemoveLink stops the given link, closes its connection unless that is shared with other
+	// links, and forgets the link. The caller must first stop the link from receiving and make
+	// sure that no packet refers to it and that nothing sends on it any more. A link that is
+	// shared between multiple interfaces (as sibling links may be) must only be removed once
+	// none of them use it.
+	RemoveLink(l Link)
+
 	// Stop puts the provider in the stopped state. In that state, the provider no longer delivers
 	// incoming packets and ignores packets present on its input channels. The provider is fully
 	// stopped when this method returns. Only connections in existence at the time of calling Stop
@@ -0,0 +1,608 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package afpacketudpip implements an underlay provider that carries SCION packets in UDP/IP over
+// Ethernet, like the udpip provider does, but bypasses the kernel's UDP sockets. Frames are
+// received from a TPACKET_V3 ring and sent with sendmmsg over AF_PACKET sockets. The provider
+// builds the Ethernet, IP, and UDP headers itself, in the headroom that the router reserves in
+// front of every packet.
+//
+// The provider only supports external links. The internal link and sibling links remain on the
+// udpip underlay. A link selects this provider by naming it in the topology:
+//
+//	"underlay": {"provider": "afpacketudpip", "local": "192.0.2.1:50000", ...}
+//
+// The local address must be assigned to a network interface. The hardware address of the next
+// hop is learned from the kernel's neighbor table.
+//
+// The afxdpudpip provider is the same, except that its links receive and send through AF_XDP
+// sockets where the network interface allows it:
+//
+//	"underlay": {"provider": "afxdpudpip", "local": "192.0.2.1:50000", ...}
+//
+// An XDP program, attached to the network interface of the first such link, redirects the frames of
+// all the links of that interface to one XDP socket per receive queue. Frames larger than an
+// XDP socket chunk (4KiB, minus the XDP headroom) are not received, so jumbo frames need the
+// AF_PACKET provider. If the XDP program or the sockets cannot be set up, the link falls back to
+// AF_PACKET.
+package afpacketudpip
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net"
+	"net/netip"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/private/underlay/conn"
+	"github.com/scionproto/scion/router"
+	"github.com/scionproto/scion/router/bfd"
+	"github.com/scionproto/scion/router/underlayproviders/internal/procid"
+)
+
+// neighborRefreshInterval is how often links re-read the hardware address of their next hop.
+const neighborRefreshInterval = time.Second
+
+var (
+	errResolveOnExternalLink = errors.New("unsupported address resolution on external link")
+	errDuplicateRemote       = errors.New("duplicate remote address")
+	errUnsupportedLink       = errors.New("unsupported link type for this underlay")
+	errAddressFamily         = errors.New("local and remote address families differ")
+)
+
+// FrameConn is a raw link-layer connection that sends and receives whole Ethernet frames.
+type FrameConn interface {
+	// ReadFrame returns the next received frame. The returned slice is only valid until the next
+	// call. ReadFrame returns periodically with an error, even if nothing is received, so the
+	// caller can check whether it should stop.
+	ReadFrame() ([]byte, error)
+	// WriteFrames sends the given frames and returns how many were sent.
+	WriteFrames(frames [][]byte) (int, error)
+	// Close releases the connection. ReadFrame must not be in progress.
+	Close() error
+}
+
+// An interface to enable unit testing.
+type ConnOpener interface {
+	// Open creates a connection that receives only the frames from remote to local.
+	Open(local, remote netip.AddrPort, c *conn.Config) (FrameConn, error)
+	// Neighbor returns the local hardware address to use for sending from local to remote and the
+	// hardware address of the next hop.
+	Neighbor(local, remote netip.AddrPort) (net.HardwareAddr, net.HardwareAddr, error)
+}
+
+// provider implements UnderlayProvider by making and returning AF_PACKET links.
+type provider struct {
+	mu                sync.Mutex // Prevents race between adding connections and Start/Stop.
+	batchSize         int
+	allLinks          map[netip.AddrPort]*externalLink
+	connOpener        ConnOpener // defaultOpener or defaultXDPOpener, except for unit tests
+	receiveBufferSize int
+	sendBufferSize    int
+
+	// What Start was given, so links added later can be started too.
+	running bool
+	runCtx  context.Context
+	pool    router.PacketPool
+	procQs  []chan *router.Packet
+}
+
+func init() {
+	// Register ourselves as an underlay provider. The registration consists of a constructor, not
+	// a provider object, because multiple router instances each must have their own underlay
+	// provider. The provider is not re-entrant.
+	router.AddUnderlay("afpacketudpip", newProvider)
+	router.AddUnderlay("afxdpudpip", newXDPProvider)
+}
+
+// newProvider instantiates a new instance of the provider for exclusive use by the caller.
+func newProvider(batchSize int, receiveBufferSize int, sendBufferSize int) router.UnderlayProvider {
+	return &provider{
+		batchSize:         batchSize,
+		allLinks:          make(map[netip.AddrPort]*externalLink),
+		connOpener:        defaultOpener,
+		receiveBufferSize: receiveBufferSize,
+		sendBufferSize:    sendBufferSize,
+	}
+}
+
+// newXDPProvider instantiates a new instance of the provider whose links use the AF_XDP fast path.
+func newXDPProvider(
+	batchSize int, receiveBufferSize int, sendBufferSize int,
+) router.UnderlayProvider {
+
+	u := newProvider(batchSize, receiveBufferSize, sendBufferSize).(*provider)
+	u.connOpener = defaultXDPOpener
+	return u
+}
+
+// SetConnOpener installs the given opener. opener must be an implementation of ConnOpener or
+// panic will ensue. Only for use in unit tests.
+func (u *provider) SetConnOpener(opener any) {
+	u.connOpener = opener.(ConnOpener)
+}
+
+func (u *provider) NumConnections() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return len(u.allLinks)
+}
+
+func (u *provider) Headroom() int {
+	// Ethernet + IPv6 + UDP; the largest header that we may prepend.
+	return maxHeaderLen
+}
+
+// SetDispatchPorts has no effect: this underlay has no internal link.
+func (u *provider) SetDispatchPorts(start, end, redirect uint16) {}
+
+// AddSvc has no effect: this underlay has no internal link.
+func (u *provider) AddSvc(svc addr.SVC, host addr.Host, port uint16) error {
+	return nil
+}
+
+// DelSvc has no effect: this underlay has no internal link.
+func (u *provider) DelSvc(svc addr.SVC, host addr.Host, port uint16) error {
+	return nil
+}
+
+func (u *provider) Start(
+	ctx context.Context, pool router.PacketPool, procQs []chan *router.Packet,
+) {
+	if len(procQs) == 0 {
+		// Pointless to run without any processor of incoming traffic
+		return
+	}
+	u.mu.Lock()
+	u.running = true
+	u.runCtx = ctx
+	u.pool = pool
+	u.procQs = procQs
+	linkSnapshot := slices.Collect(maps.Values(u.allLinks))
+	u.mu.Unlock()
+
+	for _, l := range linkSnapshot {
+		l.start(ctx, procQs, pool, u.batchSize)
+	}
+}
+
+func (u *provider) Stop() {
+	u.mu.Lock()
+	u.running = false
+	linkSnapshot := slices.Collect(maps.Values(u.allLinks))
+	u.mu.Unlock()
+
+	for _, l := range linkSnapshot {
+		l.stop()
+	}
+}
+
+// StartLink starts the given link if the provider is running.
+func (u *provider) StartLink(l router.Link) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if !u.running {
+		return
+	}
+	l.(*externalLink).start(u.runCtx, u.procQs, u.pool, u.batchSize)
+}
+
+// StopReceiving stops the receiver of the given link. The link keeps sending.
+func (u *provider) StopReceiving(l router.Link) {
+	l.(*externalLink).stopReceiving()
+}
+
+// RemoveLink stops the given link and closes its connection.
+func (u *provider) RemoveLink(l router.Link) {
+	el := l.(*externalLink)
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	delete(u.allLinks, el.remote)
+	if el.running.Load() {
+		el.stop()
+	} else {
+		el.conn.Close()
+	}
+}
+
+// NewExternalLink returns an external link over the AF_PACKET underlay. Each external link has
+// an exclusive connection: a receive ring filtered for the link's own traffic and a transmit
+// socket.
+func (u *provider) NewExternalLink(
+	qSize int,
+	bfd *bfd.Session,
+	local string,
+	remote string,
+	ifID uint16,
+	metrics *router.InterfaceMetrics,
+) (router.Link, error) {
+	localAddr, err := conn.ResolveAddrPort(local)
+	if err != nil {
+		return nil, serrors.Wrap("resolving local address", err)
+	}
+	remoteAddr, err := conn.ResolveAddrPort(remote)
+	if err != nil {
+		return nil, serrors.Wrap("resolving remote address", err)
+	}
+	if localAddr.Addr().Unmap().Is4() != remoteAddr.Addr().Unmap().Is4() {
+		return nil, serrors.JoinNoStack(errAddressFamily, nil, "local", local, "remote", remote)
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	// Duplicate external links are not supported. That they happen at all would denote a serious
+	// configuration error.
+	if l := u.allLinks[remoteAddr]; l != nil {
+		return nil, serrors.JoinNoStack(errDuplicateRemote, nil, "addr", remote)
+	}
+	fc, err := u.connOpener.Open(localAddr, remoteAddr,
+		&conn.Config{ReceiveBufferSize: u.receiveBufferSize, SendBufferSize: u.sendBufferSize})
+	if err != nil {
+		return nil, err
+	}
+	l := &externalLink{
+		name:       remoteAddr.String(),
+		local:      localAddr,
+		remote:     remoteAddr,
+		conn:       fc,
+		opener:     u.connOpener,
+		egressQ:    make(chan *router.Packet, qSize),
+		metrics:    metrics,
+		bfdSession: bfd,
+		seed:       procid.MakeHashSeed(),
+		ifID:       ifID,
+	}
+	// Try once now, so the link is usable from the start in the common case. Failure is not
+	// an error: the next hop may simply not be known yet.
+	l.refreshHeader()
+	u.allLinks[remoteAddr] = l
+	return l, nil
+}
+
+// NewSiblingLink is not supported. Sibling links use the udpip underlay.
+func (u *provider) NewSiblingLink(
+	qSize int,
+	bfd *bfd.Session,
+	local string,
+	remote string,
+	metrics *router.InterfaceMetrics,
+) (router.Link, error) {
+	return nil, serrors.JoinNoStack(errUnsupportedLink, nil, "scope", "sibling")
+}
+
+// NewInternalLink is not supported. The internal link uses the udpip underlay.
+func (u *provider) NewInternalLink(
+	local string, qSize int, metrics *router.InterfaceMetrics,
+) (router.Link, error) {
+	return nil, serrors.JoinNoStack(errUnsupportedLink, nil, "scope", "internal")
+}
+
+// externalLink is a point-to-point link to a router in a neighbor AS. It owns its connection.
+type externalLink struct {
+	procQs       []chan *router.Packet
+	name         string // For logs
+	local        netip.AddrPort
+	remote       netip.AddrPort
+	conn         FrameConn
+	opener       ConnOpener
+	header       atomic.Pointer[headerTemplate] // nil until the next hop is resolved.
+	egressQ      chan *router.Packet
+	metrics      *router.InterfaceMetrics
+	pool         router.PacketPool
+	bfdSession   *bfd.Session
+	running      atomic.Bool
+	receiving    atomic.Bool
+	receiverDone chan struct{}
+	senderDone   chan struct{}
+	refreshStop  chan struct{}
+	refreshDone  chan struct{}
+	seed         uint32
+	ifID         uint16
+}
+
+// start puts the link in the running state. In that state, the link delivers incoming packets to
+// the processing queues and sends the packets present on its egress queue.
+func (l *externalLink) start(
+	ctx context.Context,
+	procQs []chan *router.Packet,
+	pool router.PacketPool,
+	batchSize int,
+) {
+	if l.running.Swap(true) {
+		return
+	}
+	// procQs and pool are never known before all configured links have been instantiated. So we
+	// get them only now. We didn't need it earlier since the link has not been started yet.
+	l.procQs = procQs
+	l.pool = pool
+	l.receiverDone = make(chan struct{})
+	l.senderDone = make(chan struct{})
+	l.refreshStop = make(chan struct{})
+	l.refreshDone = make(chan struct{})
+	l.receiving.Store(true)
+
+	go func() {
+		defer log.HandlePanic()
+		l.receive()
+		close(l.receiverDone)
+	}()
+	go func() {
+		defer log.HandlePanic()
+		l.send(batchSize)
+		close(l.senderDone)
+	}()
+	go func() {
+		defer log.HandlePanic()
+		l.refreshNeighbor()
+		close(l.refreshDone)
+	}()
+
+	if l.bfdSession == nil {
+		return
+	}
+	go func() {
+		defer log.HandlePanic()
+		if err := l.bfdSession.Run(ctx); err != nil && !errors.Is(err, bfd.ErrAlreadyRunning) {
+			log.Error("BFD session failed to start", "remote address", l.name, "err", err)
+		}
+	}()
+}
+
+// stop puts the link in the stopped state. The link is fully stopped when this method returns.
+func (l *externalLink) stop() {
+	if !l.running.Swap(false) {
+		return
+	}
+	if l.bfdSession != nil {
+		l.bfdSession.Close()
+	}
+	l.stopReceiving()
+	close(l.refreshStop)
+	close(l.egressQ) // Unblock sender
+	<-l.senderDone
+	<-l.refreshDone
+	l.conn.Close()
+}
+
+// stopReceiving stops the receiver. It returns once the receiver has noticed, which takes at most
+// one poll timeout.
+func (l *externalLink) stopReceiving() {
+	if l.receiving.Swap(false) {
+		<-l.receiverDone
+	}
+}
+
+func (l *externalLink) receive() {
+	log.Debug("Receive", "connection", l.name)
+	for l.receiving.Load() {
+		frame, err := l.conn.ReadFrame()
+		if err != nil {
+			if !errors.Is(err, errTimeout) {
+				log.Debug("Error while reading frame", "connection", l.name, "err", err)
+			}
+			continue
+		}
+		src, dst, offset, ok := parseFrame(frame)
+		if !ok || src != l.remote || dst != l.local {
+			// The filter should have taken care of that. Not ours, so not counted.
+			continue
+		}
+		end := payloadEnd(frame, offset)
+		p := l.pool.Get()
+		if end-offset > len(p.RawPacket) {
+			l.pool.Put(p)
+			l.metrics[router.ClassOfSize(end-offset)].DroppedPackets.Inc(router.DropInvalid)
+			continue
+		}
+		p.RawPacket = p.RawPacket[:copy(p.RawPacket, frame[offset:end])]
+		l.deliver(p)
+	}
+}
+
+func (l *externalLink) deliver(p *router.Packet) {
+	size := len(p.RawPacket)
+	metrics := l.metrics
+	sc := router.ClassOfSize(size)
+	metrics[sc].InputPacketsTotal.Inc()
+	metrics[sc].InputBytesTotal.Add(float64(size))
+
+	p.Link = l
+	// The src address does not need to be recorded in the packet. The link has all the relevant
+	// information.
+
+	procID, ok := procid.Compute(p.RawPacket, len(l.procQs), l.seed)
+	if !ok {
+		l.pool.Put(p)
+		metrics[sc].DroppedPackets.Inc(router.DropInvalid)
+		return
+	}
+	select {
+	case l.procQs[procID] <- p:
+	default:
+		l.pool.Put(p)
+		metrics[sc].DroppedPackets.Inc(router.DropBusyProcessor)
+	}
+}
+
+func readUpTo(queue <-chan *router.Packet, n int, needsBlocking bool, pkts []*router.Packet) int {
+	i := 0
+	if needsBlocking {
+		p, ok := <-queue
+		if !ok {
+			return i
+		}
+		pkts[i] = p
+		i++
+	}
+
+	for ; i < n; i++ {
+		select {
+		case p, ok := <-queue:
+			if !ok {
+				return i
+			}
+			pkts[i] = p
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+func (l *externalLink) send(batchSize int) {
+	log.Debug("Send", "connection", l.name)
+
+	pkts := make([]*router.Packet, batchSize)
+	frames := make([][]byte, batchSize)
+	queue := l.egressQ
+	metrics := l.metrics
+	pool := l.pool
+	toWrite := 0
+
+	for l.running.Load() {
+		// Top-up our batch.
+		toWrite += readUpTo(queue, batchSize-toWrite, toWrite == 0, pkts[toWrite:])
+		if toWrite == 0 {
+			continue
+		}
+
+		hdr := l.header.Load()
+		if hdr == nil {
+			// We can't address anything yet.
+			for _, p := range pkts[:toWrite] {
+				sc := router.ClassOfSize(len(p.RawPacket))
+				metrics[sc].DroppedPackets.Inc(router.DropInvalid)
+				pool.Put(p)
+			}
+			toWrite = 0
+			continue
+		}
+
+		// Prepend the underlay header to each packet. If some packets are left over from the
+		// previous round, they already have it, but the header may have changed since; so we
+		// simply redo it.
+		for i, p := range pkts[:toWrite] {
+			payloadLen := len(p.RawPacket)
+			frame := p.WithHeader(hdr.len)
+			hdr.prepend(frame, payloadLen)
+			frames[i] = frame[:hdr.len+payloadLen]
+		}
+
+		written, _ := l.conn.WriteFrames(frames[:toWrite])
+		if written < 0 {
+			written = 0
+		}
+		router.UpdateOutputMetrics(metrics, pkts[:written])
+		for _, p := range pkts[:written] {
+			pool.Put(p)
+		}
+		if written != toWrite {
+			// Only one is dropped at this time. We'll retry the rest.
+			sc := router.ClassOfSize(len(pkts[written].RawPacket))
+			metrics[sc].DroppedPackets.Inc(router.DropInvalid)
+			pool.Put(pkts[written])
+			toWrite -= (written + 1)
+			// Shift the leftovers to the head of the buffers.
+			for i := 0; i < toWrite; i++ {
+				pkts[i] = pkts[i+written+1]
+			}
+		} else {
+			toWrite = 0
+		}
+	}
+
+	// Return whatever is left. The queue is closed, so this terminates.
+	for _, p := range pkts[:toWrite] {
+		pool.Put(p)
+	}
+	for p := range queue {
+		pool.Put(p)
+	}
+}
+
+// refreshNeighbor periodically refreshes the header template, so that a change of the next hop's
+// hardware address is noticed.
+func (l *externalLink) refreshNeighbor() {
+	ticker := time.NewTicker(neighborRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			l.refreshHeader()
+		case <-l.refreshStop:
+			return
+		}
+	}
+}
+
+// refreshHeader resolves the hardware addresses of the link and publishes a new header template if
+// they changed.
+func (l *externalLink) refreshHeader() {
+	srcMAC, dstMAC, err := l.opener.Neighbor(l.local, l.remote)
+	if err != nil {
+		log.Debug("Next hop not resolved", "connection", l.name, "err", err)
+		return
+	}
+	next := newHeaderTemplate(srcMAC, dstMAC, l.local, l.remote)
+	if cur := l.header.Load(); cur != nil && cur.buf == next.buf {
+		return
+	}
+	l.header.Store(next)
+}
+
+func (l *externalLink) IfID() uint16 {
+	return l.ifID
+}
+
+func (l *externalLink) Metrics() *router.InterfaceMetrics {
+	return l.metrics
+}
+
+func (l *externalLink) Scope() router.LinkScope {
+	return router.External
+}
+
+func (l *externalLink) BFDSession() *bfd.Session {
+	return l.bfdSession
+}
+
+func (l *externalLink) IsUp() bool {
+	return l.bfdSession == nil || l.bfdSession.IsUp()
+}
+
+// Resolve should not be useful on an external link so we don't implement it.
+func (l *externalLink) Resolve(p *router.Packet, host addr.Host, port uint16) error {
+	return errResolveOnExternalLink
+}
+
+func (l *externalLink) Send(p *router.Packet) bool {
+	select {
+	case l.egressQ <- p:
+	default:
+		return false
+	}
+	return true
+}
+
+func (l *externalLink) SendBlocking(p *router.Packet) {
+	l.egressQ <- p
+}
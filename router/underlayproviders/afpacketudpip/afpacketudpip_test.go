@@ -0,0 +1,222 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package afpacketudpip
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/gopacket/gopacket"
+	"github.com/gopacket/gopacket/layers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/bpf"
+
+	"github.com/scionproto/scion/private/underlay/conn"
+)
+
+var (
+	macA = net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0x01, 0x01}
+	macB = net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0x02, 0x02}
+)
+
+// mkFrame serializes a reference frame with gopacket.
+func mkFrame(t *testing.T, src, dst netip.AddrPort, payload []byte) []byte {
+	eth := &layers.Ethernet{SrcMAC: macA, DstMAC: macB}
+	udp := &layers.UDP{
+		SrcPort: layers.UDPPort(src.Port()),
+		DstPort: layers.UDPPort(dst.Port()),
+	}
+	var ip gopacket.SerializableLayer
+	if src.Addr().Is4() {
+		eth.EthernetType = layers.EthernetTypeIPv4
+		ip4 := &layers.IPv4{
+			Version:  4,
+			IHL:      5,
+			TTL:      defaultTTL,
+			Flags:    layers.IPv4DontFragment,
+			Protocol: layers.IPProtocolUDP,
+			SrcIP:    src.Addr().AsSlice(),
+			DstIP:    dst.Addr().AsSlice(),
+		}
+		require.NoError(t, udp.SetNetworkLayerForChecksum(ip4))
+		ip = ip4
+	} else {
+		eth.EthernetType = layers.EthernetTypeIPv6
+		ip6 := &layers.IPv6{
+			Version:    6,
+			HopLimit:   defaultTTL,
+			NextHeader: layers.IPProtocolUDP,
+			SrcIP:      src.Addr().AsSlice(),
+			DstIP:      dst.Addr().AsSlice(),
+		}
+		require.NoError(t, udp.SetNetworkLayerForChecksum(ip6))
+		ip = ip6
+	}
+	buf := gopacket.NewSerializeBuffer()
+	err := gopacket.SerializeLayers(buf,
+		gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true},
+		eth, ip, udp, gopacket.Payload(payload))
+	require.NoError(t, err)
+	return buf.Bytes()
+}
+
+func TestHeaderTemplate(t *testing.T) {
+	testCases := map[string]struct {
+		src, dst netip.AddrPort
+	}{
+		"ipv4": {
+			src: netip.MustParseAddrPort("192.0.2.1:50000"),
+			dst: netip.MustParseAddrPort("192.0.2.2:50001"),
+		},
+		"ipv6": {
+			src: netip.MustParseAddrPort("[2001:db8::1]:50000"),
+			dst: netip.MustParseAddrPort("[2001:db8::2]:50001"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			payload := []byte("a SCION packet, of odd length")
+			expected := mkFrame(t, tc.src, tc.dst, payload)
+
+			tmpl := newHeaderTemplate(macA, macB, tc.src, tc.dst)
+			buf := make([]byte, maxHeaderLen+len(payload))
+			frame := buf[maxHeaderLen-tmpl.len:]
+			copy(frame[tmpl.len:], payload)
+			tmpl.prepend(frame, len(payload))
+
+			if tc.src.Addr().Is4() {
+				// We leave the optional UDP checksum out over IPv4.
+				udpCsum := ethLen + ipv4Len + 6
+				expected[udpCsum], expected[udpCsum+1] = 0, 0
+			}
+			assert.Equal(t, expected, frame)
+
+			src, dst, offset, ok := parseFrame(frame)
+			require.True(t, ok)
+			assert.Equal(t, tc.src, src)
+			assert.Equal(t, tc.dst, dst)
+			assert.Equal(t, tmpl.len, offset)
+			assert.Equal(t, payload, frame[offset:payloadEnd(frame, offset)])
+		})
+	}
+}
+
+func TestParseFrame(t *testing.T) {
+	src := netip.MustParseAddrPort("192.0.2.1:50000")
+	dst := netip.MustParseAddrPort("192.0.2.2:50001")
+	// Long enough that gopacket doesn't pad the frame to the Ethernet minimum.
+	payload := make([]byte, 64)
+
+	// Ethernet padding must not be taken as payload.
+	padded := mkFrame(t, src, dst, []byte("short"))
+	_, _, offset, ok := parseFrame(padded)
+	require.True(t, ok)
+	assert.Equal(t, []byte("short"), padded[offset:payloadEnd(padded, offset)])
+
+	truncated := mkFrame(t, src, dst, payload)
+	_, _, _, ok = parseFrame(truncated[:len(truncated)-1])
+	assert.False(t, ok)
+
+	fragment := mkFrame(t, src, dst, payload)
+	fragment[ethLen+6] |= 0x20 // More fragments
+	_, _, _, ok = parseFrame(fragment)
+	assert.False(t, ok)
+
+	notUDP := mkFrame(t, src, dst, payload)
+	notUDP[ethLen+9] = 6
+	_, _, _, ok = parseFrame(notUDP)
+	assert.False(t, ok)
+}
+
+func TestLinkFilter(t *testing.T) {
+	testCases := map[string]struct {
+		local, remote, other netip.AddrPort
+	}{
+		"ipv4": {
+			local:  netip.MustParseAddrPort("192.0.2.1:50000"),
+			remote: netip.MustParseAddrPort("192.0.2.2:50001"),
+			other:  netip.MustParseAddrPort("192.0.2.3:50001"),
+		},
+		"ipv6": {
+			local:  netip.MustParseAddrPort("[2001:db8::1]:50000"),
+			remote: netip.MustParseAddrPort("[2001:db8::2]:50001"),
+			other:  netip.MustParseAddrPort("[2001:db8::3]:50001"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// The VM doesn't support the packet type extension, so we skip the leading check.
+			// All jumps are forward and relative, so the rest of the program is unaffected.
+			prog := linkFilter(tc.local, tc.remote)
+			require.IsType(t, bpf.LoadExtension{}, prog[0])
+			vm, err := bpf.NewVM(prog[2:])
+			require.NoError(t, err)
+
+			accept := func(frame []byte) bool {
+				n, err := vm.Run(frame)
+				require.NoError(t, err)
+				return n != 0
+			}
+			otherPort := netip.AddrPortFrom(tc.local.Addr(), tc.local.Port()+1)
+			assert.True(t, accept(mkFrame(t, tc.remote, tc.local, []byte("x"))))
+			assert.False(t, accept(mkFrame(t, tc.local, tc.remote, []byte("x"))))
+			assert.False(t, accept(mkFrame(t, tc.other, tc.local, []byte("x"))))
+			assert.False(t, accept(mkFrame(t, tc.remote, otherPort, []byte("x"))))
+		})
+	}
+}
+
+type mockConn struct{}
+
+func (mockConn) ReadFrame() ([]byte, error)               { return nil, errTimeout }
+func (mockConn) WriteFrames(frames [][]byte) (int, error) { return len(frames), nil }
+func (mockConn) Close() error                             { return nil }
+
+type mockOpener struct{}
+
+func (mockOpener) Open(local, remote netip.AddrPort, c *conn.Config) (FrameConn, error) {
+	return mockConn{}, nil
+}
+
+func (mockOpener) Neighbor(
+	local, remote netip.AddrPort,
+) (net.HardwareAddr, net.HardwareAddr, error) {
+
+	return macA, macB, nil
+}
+
+func TestNewLinks(t *testing.T) {
+	u := newProvider(64, 0, 0)
+	u.SetConnOpener(mockOpener{})
+
+	l, err := u.NewExternalLink(16, nil, "192.0.2.1:50000", "192.0.2.2:50000", 1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(1), l.IfID())
+	assert.NotNil(t, l.(*externalLink).header.Load())
+	assert.Equal(t, 1, u.NumConnections())
+
+	_, err = u.NewExternalLink(16, nil, "192.0.2.1:50001", "192.0.2.2:50000", 2, nil)
+	assert.ErrorIs(t, err, errDuplicateRemote)
+
+	_, err = u.NewExternalLink(16, nil, "192.0.2.1:50002", "[2001:db8::2]:50000", 3, nil)
+	assert.ErrorIs(t, err, errAddressFamily)
+
+	_, err = u.NewSiblingLink(16, nil, "192.0.2.1:50000", "192.0.2.3:50000", nil)
+	assert.ErrorIs(t, err, errUnsupportedLink)
+	_, err = u.NewInternalLink("192.0.2.1:30042", 16, nil)
+	assert.ErrorIs(t, err, errUnsupportedLink)
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package afpacketudpip
+
+import (
+	"encoding/binary"
+	"net/netip"
+
+	"golang.org/x/net/bpf"
+)
+
+const (
+	// The value of skb->pkt_type for frames that we send ourselves. The ring would otherwise
+	// deliver a copy of them.
+	packetOutgoing = 4
+	// The snap length returned by the filter for accepted frames: everything.
+	acceptAll = 0x40000
+)
+
+// linkFilter returns a classic BPF program that accepts only the incoming frames that carry a UDP
+// datagram from remote to local. Both addresses must be of the same family.
+func linkFilter(local, remote netip.AddrPort) []bpf.Instruction {
+	var f filterBuilder
+	f.add(bpf.LoadExtension{Num: bpf.ExtType})
+	f.dropIf(bpf.JumpEqual, packetOutgoing)
+	f.add(bpf.LoadAbsolute{Off: 12, Size: 2})
+	if local.Addr().Is4() || local.Addr().Is4In6() {
+		f.dropIf(bpf.JumpNotEqual, etherTypeIPv4)
+		f.add(bpf.LoadAbsolute{Off: ethLen + 9, Size: 1})
+		f.dropIf(bpf.JumpNotEqual, protoUDP)
+		f.add(bpf.LoadAbsolute{Off: ethLen + 6, Size: 2})
+		f.dropIf(bpf.JumpBitsSet, 0x3fff) // Fragments
+		f.matchAddr(ethLen+12, remote.Addr().Unmap())
+		f.matchAddr(ethLen+16, local.Addr().Unmap())
+		// X = IP header length.
+		f.add(bpf.LoadMemShift{Off: ethLen})
+		f.add(bpf.LoadIndirect{Off: ethLen, Size: 2})
+		f.dropIf(bpf.JumpNotEqual, uint32(remote.Port()))
+		f.add(bpf.LoadIndirect{Off: ethLen + 2, Size: 2})
+		f.dropIf(bpf.JumpNotEqual, uint32(local.Port()))
+	} else {
+		f.dropIf(bpf.JumpNotEqual, etherTypeIPv6)
+		f.add(bpf.LoadAbsolute{Off: ethLen + 6, Size: 1})
+		f.dropIf(bpf.JumpNotEqual, protoUDP)
+		f.matchAddr(ethLen+8, remote.Addr())
+		f.matchAddr(ethLen+24, local.Addr())
+		f.add(bpf.LoadAbsolute{Off: ethLen + ipv6Len, Size: 2})
+		f.dropIf(bpf.JumpNotEqual, uint32(remote.Port()))
+		f.add(bpf.LoadAbsolute{Off: ethLen + ipv6Len + 2, Size: 2})
+		f.dropIf(bpf.JumpNotEqual, uint32(local.Port()))
+	}
+	return f.finish()
+}
+
+// filterBuilder assembles a linear filter program made of checks that each either fall through to
+// the next one or jump to a common drop instruction. The program accepts the packet if all checks
+// pass.
+type filterBuilder struct {
+	insts []bpf.Instruction
+	drops []int // Indices of the jumps to the drop instruction.
+}
+
+func (f *filterBuilder) add(inst bpf.Instruction) {
+	f.insts = append(f.insts, inst)
+}
+
+// dropIf adds a conditional jump to the drop instruction.
+func (f *filterBuilder) dropIf(cond bpf.JumpTest, val uint32) {
+	f.drops = append(f.drops, len(f.insts))
+	f.add(bpf.JumpIf{Cond: cond, Val: val})
+}
+
+// matchAddr adds the checks that the packet holds the given address at the given offset.
+func (f *filterBuilder) matchAddr(off uint32, a netip.Addr) {
+	b := a.AsSlice()
+	for i := 0; i < len(b); i += 4 {
+		f.add(bpf.LoadAbsolute{Off: off + uint32(i), Size: 4})
+		f.dropIf(bpf.JumpNotEqual, binary.BigEndian.Uint32(b[i:i+4]))
+	}
+}
+
+// finish completes the program and resolves the jumps.
+func (f *filterBuilder) finish() []bpf.Instruction {
+	f.add(bpf.RetConstant{Val: acceptAll})
+	drop := len(f.insts)
+	f.add(bpf.RetConstant{Val: 0})
+	for _, i := range f.drops {
+		j := f.insts[i].(bpf.JumpIf)
+		j.SkipTrue = uint8(drop - i - 1)
+		f.insts[i] = j
+	}
+	return f.insts
+}
@@ -0,0 +1,206 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package afpacketudpip
+
+import (
+	"encoding/binary"
+	"net"
+	"net/netip"
+)
+
+const (
+	ethLen  = 14
+	ipv4Len = 20
+	ipv6Len = 40
+	udpLen  = 8
+
+	// maxHeaderLen is the length of the largest underlay header that this provider prepends to a
+	// SCION packet: Ethernet + IPv6 + UDP.
+	maxHeaderLen = ethLen + ipv6Len + udpLen
+
+	etherTypeIPv4 = 0x0800
+	etherTypeIPv6 = 0x86dd
+	protoUDP      = 17
+	defaultTTL    = 64
+)
+
+// headerTemplate is a pre-built Ethernet/IP/UDP header for one link. All fields that do not depend
+// on the payload are filled-in when the template is made. When sending, the template is copied in
+// front of the payload and only the length and checksum fields are patched. A template is immutable
+// once published; changes (e.g. a new next-hop MAC address) are made by publishing a new one.
+type headerTemplate struct {
+	buf [maxHeaderLen]byte
+	len int
+	v6  bool
+	// The pseudo-header sum for the UDP checksum, without the length. Only needed for IPv6, where
+	// the UDP checksum is mandatory.
+	pseudoSum uint32
+}
+
+// newHeaderTemplate builds the header template for packets from src to dst. Both addresses must be
+// of the same family.
+func newHeaderTemplate(
+	srcMAC, dstMAC net.HardwareAddr, src, dst netip.AddrPort,
+) *headerTemplate {
+	t := &headerTemplate{v6: src.Addr().Is6() && !src.Addr().Is4In6()}
+	b := t.buf[:]
+	copy(b[0:6], dstMAC)
+	copy(b[6:12], srcMAC)
+	if t.v6 {
+		binary.BigEndian.PutUint16(b[12:14], etherTypeIPv6)
+		ip := b[ethLen : ethLen+ipv6Len]
+		ip[0] = 0x60
+		// Traffic class and flow label left at zero. Payload length is patched.
+		ip[6] = protoUDP
+		ip[7] = defaultTTL
+		s, d := src.Addr().As16(), dst.Addr().As16()
+		copy(ip[8:24], s[:])
+		copy(ip[24:40], d[:])
+		t.len = ethLen + ipv6Len + udpLen
+		t.pseudoSum = sumBytes(0, ip[8:40]) + protoUDP
+	} else {
+		binary.BigEndian.PutUint16(b[12:14], etherTypeIPv4)
+		ip := b[ethLen : ethLen+ipv4Len]
+		ip[0] = 0x45
+		// Don't fragment. We never fragment and never reassemble.
+		binary.BigEndian.PutUint16(ip[6:8], 0x4000)
+		ip[8] = defaultTTL
+		ip[9] = protoUDP
+		s, d := src.Addr().Unmap().As4(), dst.Addr().Unmap().As4()
+		copy(ip[12:16], s[:])
+		copy(ip[16:20], d[:])
+		t.len = ethLen + ipv4Len + udpLen
+	}
+	udp := b[t.len-udpLen : t.len]
+	binary.BigEndian.PutUint16(udp[0:2], src.Port())
+	binary.BigEndian.PutUint16(udp[2:4], dst.Port())
+	return t
+}
+
+// prepend writes the header in front of the given payload, which must be a sub-slice of hdrBuf
+// starting exactly at offset t.len. hdrBuf is typically obtained from Packet.WithHeader.
+func (t *headerTemplate) prepend(hdrBuf []byte, payloadLen int) {
+	b := hdrBuf[:t.len]
+	copy(b, t.buf[:t.len])
+	udpTotal := udpLen + payloadLen
+	udp := b[t.len-udpLen:]
+	binary.BigEndian.PutUint16(udp[4:6], uint16(udpTotal))
+	if t.v6 {
+		binary.BigEndian.PutUint16(b[ethLen+4:ethLen+6], uint16(udpTotal))
+		// The checksum is mandatory over IPv6. The checksum field is zero in the template.
+		sum := t.pseudoSum + uint32(udpTotal)
+		sum = sumBytes(sum, hdrBuf[t.len-udpLen:t.len+payloadLen])
+		csum := foldSum(sum)
+		if csum == 0 {
+			csum = 0xffff
+		}
+		binary.BigEndian.PutUint16(udp[6:8], csum)
+		return
+	}
+	ip := b[ethLen : ethLen+ipv4Len]
+	binary.BigEndian.PutUint16(ip[2:4], uint16(ipv4Len+udpTotal))
+	// The checksum field is zero in the template. UDP checksum is optional over IPv4 and we
+	// leave it at zero.
+	binary.BigEndian.PutUint16(ip[10:12], foldSum(sumBytes(0, ip)))
+}
+
+// parseFrame decodes the Ethernet/IP/UDP headers of a received frame. It returns the source
+// address and port, the destination address and port, and the offset of the UDP payload in the
+// frame. ok is false if the frame isn't an unfragmented UDP datagram over IPv4 or IPv6, or if it
+// is truncated.
+//
+// Checksums are not verified. The kernel or the NIC has normally done so already and, regardless,
+// the SCION layer has its own integrity checks where it matters.
+func parseFrame(frame []byte) (src, dst netip.AddrPort, offset int, ok bool) {
+	if len(frame) < ethLen {
+		return src, dst, 0, false
+	}
+	var srcIP, dstIP netip.Addr
+	var udpLength int
+	switch binary.BigEndian.Uint16(frame[12:14]) {
+	case etherTypeIPv4:
+		ip := frame[ethLen:]
+		if len(ip) < ipv4Len || ip[0]>>4 != 4 || ip[9] != protoUDP {
+			return src, dst, 0, false
+		}
+		ihl := int(ip[0]&0x0f) * 4
+		if ihl < ipv4Len || len(ip) < ihl+udpLen {
+			return src, dst, 0, false
+		}
+		// Reject fragments: MF flag set or non-zero fragment offset.
+		if binary.BigEndian.Uint16(ip[6:8])&0x3fff != 0 {
+			return src, dst, 0, false
+		}
+		totalLen := int(binary.BigEndian.Uint16(ip[2:4]))
+		if totalLen > len(ip) || totalLen < ihl+udpLen {
+			return src, dst, 0, false
+		}
+		srcIP = netip.AddrFrom4([4]byte(ip[12:16]))
+		dstIP = netip.AddrFrom4([4]byte(ip[16:20]))
+		udpLength = totalLen - ihl
+		offset = ethLen + ihl
+	case etherTypeIPv6:
+		ip := frame[ethLen:]
+		// Extension headers are not supported; the next header must be UDP.
+		if len(ip) < ipv6Len+udpLen || ip[0]>>4 != 6 || ip[6] != protoUDP {
+			return src, dst, 0, false
+		}
+		payloadLen := int(binary.BigEndian.Uint16(ip[4:6]))
+		if payloadLen > len(ip)-ipv6Len || payloadLen < udpLen {
+			return src, dst, 0, false
+		}
+		srcIP = netip.AddrFrom16([16]byte(ip[8:24]))
+		dstIP = netip.AddrFrom16([16]byte(ip[24:40]))
+		udpLength = payloadLen
+		offset = ethLen + ipv6Len
+	default:
+		return src, dst, 0, false
+	}
+	udp := frame[offset:]
+	if l := int(binary.BigEndian.Uint16(udp[4:6])); l < udpLen || l > udpLength {
+		return src, dst, 0, false
+	}
+	src = netip.AddrPortFrom(srcIP, binary.BigEndian.Uint16(udp[0:2]))
+	dst = netip.AddrPortFrom(dstIP, binary.BigEndian.Uint16(udp[2:4]))
+	return src, dst, offset + udpLen, true
+}
+
+// payloadEnd returns the end offset of the UDP payload in a frame that parseFrame has accepted.
+// Ethernet frames may carry padding past the end of the IP packet, so the frame length cannot be
+// used directly.
+func payloadEnd(frame []byte, offset int) int {
+	udp := frame[offset-udpLen:]
+	return offset - udpLen + int(binary.BigEndian.Uint16(udp[4:6]))
+}
+
+// sumBytes adds b to the running one's complement sum.
+func sumBytes(sum uint32, b []byte) uint32 {
+	n := len(b)
+	for i := 0; i+1 < n; i += 2 {
+		sum += uint32(b[i])<<8 | uint32(b[i+1])
+	}
+	if n%2 == 1 {
+		sum += uint32(b[n-1]) << 8
+	}
+	return sum
+}
+
+// foldSum folds the running sum into the final 16 bits checksum.
+func foldSum(sum uint32) uint16 {
+	for sum > 0xffff {
+		sum = (sum >> 16) + (sum & 0xffff)
+	}
+	return ^uint16(sum)
+}
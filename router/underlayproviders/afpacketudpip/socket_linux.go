@@ -0,0 +1,303 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package afpacketudpip
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/gopacket/gopacket/afpacket"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/net/bpf"
+	"golang.org/x/sys/unix"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/private/underlay/conn"
+)
+
+const (
+	// The ring frame size. Large enough for jumbo frames.
+	frameSize = 1 << 14
+	// The size of one TPACKET_V3 block. The kernel retires a block to user space when it is full
+	// or when blockTimeout expires, whichever comes first.
+	blockSize = frameSize * 64
+	// Minimum number of blocks in the receive ring.
+	minBlocks = 4
+	// blockTimeout bounds the latency added by the block-oriented TPACKET_V3 ring when traffic is
+	// light.
+	blockTimeout = time.Millisecond
+	// pollTimeout bounds the time it takes for the receiver to notice that it must stop.
+	pollTimeout = 100 * time.Millisecond
+	// The UDP port used to trigger neighbor resolution by the kernel. Nothing is expected to
+	// listen there.
+	discardPort = 9
+)
+
+var (
+	errNoInterface = errors.New("no network interface has the local address")
+	errNoNeighbor  = errors.New("next-hop hardware address not resolved")
+	errTimeout     = errors.New("timeout")
+)
+
+// afpConn is the FrameConn implementation used outside of tests. It receives through a TPACKET_V3
+// ring, with a BPF filter so that only the frames of one link are delivered, and it sends batches
+// of frames through a separate AF_PACKET socket using sendmmsg.
+//
+// The kernel network stack still sees a copy of every frame that we receive. To prevent it from
+// responding with ICMP port unreachable messages, afpConn also binds a regular UDP socket to the
+// local address and installs a filter that drops everything on that socket.
+type afpConn struct {
+	rx     *afpacket.TPacket
+	txFD   int
+	claim  *net.UDPConn
+	msgs   []mmsgHdr
+	iovecs []unix.Iovec
+}
+
+// The layout of struct mmsghdr, which x/sys/unix doesn't export.
+type mmsgHdr struct {
+	hdr unix.Msghdr
+	len uint32
+	_   [4]byte
+}
+
+// The default ConnOpener for this underlay: opens an afpConn on the network interface that owns
+// the local address.
+type afpOpener struct{}
+
+var defaultOpener ConnOpener = afpOpener{}
+
+func (afpOpener) Open(local, remote netip.AddrPort, c *conn.Config) (FrameConn, error) {
+	ifc, err := interfaceOf(local.Addr())
+	if err != nil {
+		return nil, err
+	}
+	return openAFPConn(ifc, local, remote, c)
+}
+
+func (afpOpener) Neighbor(
+	local, remote netip.AddrPort,
+) (net.HardwareAddr, net.HardwareAddr, error) {
+
+	ifc, err := interfaceOf(local.Addr())
+	if err != nil {
+		return nil, nil, err
+	}
+	dst, err := resolveNeighbor(ifc, remote)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ifc.HardwareAddr, dst, nil
+}
+
+func openAFPConn(
+	ifc *net.Interface, local, remote netip.AddrPort, c *conn.Config,
+) (*afpConn, error) {
+	numBlocks := max(c.ReceiveBufferSize/blockSize, minBlocks)
+	rx, err := afpacket.NewTPacket(
+		afpacket.OptInterface(ifc.Name),
+		afpacket.OptTPacketVersion(afpacket.TPacketVersion3),
+		afpacket.OptFrameSize(frameSize),
+		afpacket.OptBlockSize(blockSize),
+		afpacket.OptNumBlocks(numBlocks),
+		afpacket.OptBlockTimeout(blockTimeout),
+		afpacket.OptPollTimeout(pollTimeout),
+	)
+	if err != nil {
+		return nil, serrors.Wrap("opening packet ring", err, "interface", ifc.Name)
+	}
+	filter, err := bpf.Assemble(linkFilter(local, remote))
+	if err != nil {
+		rx.Close()
+		return nil, serrors.Wrap("assembling packet filter", err)
+	}
+	if err := rx.SetBPF(filter); err != nil {
+		rx.Close()
+		return nil, serrors.Wrap("attaching packet filter", err)
+	}
+
+	// The transmit socket has protocol 0, so it never receives anything.
+	txFD, err := unix.Socket(unix.AF_PACKET, unix.SOCK_RAW|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		rx.Close()
+		return nil, serrors.Wrap("opening transmit socket", err)
+	}
+	if err := unix.Bind(txFD, &unix.SockaddrLinklayer{Ifindex: ifc.Index}); err != nil {
+		unix.Close(txFD)
+		rx.Close()
+		return nil, serrors.Wrap("binding transmit socket", err, "interface", ifc.Name)
+	}
+	if c.SendBufferSize != 0 {
+		// Best effort. The kernel caps the value anyway.
+		_ = unix.SetsockoptInt(txFD, unix.SOL_SOCKET, unix.SO_SNDBUF, c.SendBufferSize)
+	}
+
+	claim, err := claimPort(local)
+	if err != nil {
+		unix.Close(txFD)
+		rx.Close()
+		return nil, err
+	}
+	return &afpConn{rx: rx, txFD: txFD, claim: claim}, nil
+}
+
+func (c *afpConn) ReadFrame() ([]byte, error) {
+	data, _, err := c.rx.ZeroCopyReadPacketData()
+	if errors.Is(err, afpacket.ErrTimeout) {
+		return nil, errTimeout
+	}
+	return data, err
+}
+
+func (c *afpConn) WriteFrames(frames [][]byte) (int, error) {
+	if len(frames) == 0 {
+		return 0, nil
+	}
+	if len(c.msgs) < len(frames) {
+		c.msgs = make([]mmsgHdr, len(frames))
+		c.iovecs = make([]unix.Iovec, len(frames))
+	}
+	for i, f := range frames {
+		c.iovecs[i].Base = &f[0]
+		c.iovecs[i].SetLen(len(f))
+		c.msgs[i].hdr.Iov = &c.iovecs[i]
+		c.msgs[i].hdr.Iovlen = 1
+	}
+	for {
+		n, _, errno := unix.Syscall6(unix.SYS_SENDMMSG,
+			uintptr(c.txFD),
+			uintptr(unsafe.Pointer(&c.msgs[0])),
+			uintptr(len(frames)),
+			0, 0, 0)
+		if errno == syscall.EINTR {
+			continue
+		}
+		if errno != 0 {
+			return int(n), errno
+		}
+		return int(n), nil
+	}
+}
+
+func (c *afpConn) Close() error {
+	c.rx.Close()
+	c.claim.Close()
+	return unix.Close(c.txFD)
+}
+
+// claimPort binds a UDP socket to the given address and makes it discard everything it receives.
+func claimPort(local netip.AddrPort) (*net.UDPConn, error) {
+	uc, err := net.ListenUDP("udp", net.UDPAddrFromAddrPort(local))
+	if err != nil {
+		return nil, serrors.Wrap("claiming local port", err, "addr", local)
+	}
+	dropAll, err := bpf.Assemble([]bpf.Instruction{bpf.RetConstant{Val: 0}})
+	if err != nil {
+		uc.Close()
+		return nil, err
+	}
+	rc, err := uc.SyscallConn()
+	if err != nil {
+		uc.Close()
+		return nil, err
+	}
+	var sockErr error
+	err = rc.Control(func(fd uintptr) {
+		prog := unix.SockFprog{
+			Len:    uint16(len(dropAll)),
+			Filter: (*unix.SockFilter)(unsafe.Pointer(&dropAll[0])),
+		}
+		sockErr = unix.SetsockoptSockFprog(int(fd), unix.SOL_SOCKET, unix.SO_ATTACH_FILTER, &prog)
+	})
+	if err == nil {
+		err = sockErr
+	}
+	if err != nil {
+		uc.Close()
+		return nil, serrors.Wrap("filtering claimed port", err, "addr", local)
+	}
+	return uc, nil
+}
+
+// interfaceOf returns the network interface that has the given address.
+func interfaceOf(a netip.Addr) (*net.Interface, error) {
+	ifcs, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	a = a.Unmap()
+	for i := range ifcs {
+		addrs, err := ifcs[i].Addrs()
+		if err != nil {
+			continue
+		}
+		for _, ifAddr := range addrs {
+			ipNet, ok := ifAddr.(*net.IPNet)
+			if !ok {
+				continue
+			}
+			if ip, ok := netip.AddrFromSlice(ipNet.IP); ok && ip.Unmap() == a {
+				return &ifcs[i], nil
+			}
+		}
+	}
+	return nil, serrors.JoinNoStack(errNoInterface, nil, "addr", a)
+}
+
+// resolveNeighbor returns the hardware address of the next hop towards remote, as known to the
+// kernel's neighbor table. If the entry is missing, the kernel is prompted to resolve it and the
+// call fails; the caller is expected to try again later.
+func resolveNeighbor(ifc *net.Interface, remote netip.AddrPort) (net.HardwareAddr, error) {
+	nextHop := remote.Addr().Unmap()
+	routes, err := netlink.RouteGet(nextHop.AsSlice())
+	if err == nil && len(routes) > 0 && routes[0].Gw != nil {
+		if gw, ok := netip.AddrFromSlice(routes[0].Gw); ok {
+			nextHop = gw.Unmap()
+		}
+	}
+	family := netlink.FAMILY_V4
+	if nextHop.Is6() {
+		family = netlink.FAMILY_V6
+	}
+	neighs, err := netlink.NeighList(ifc.Index, family)
+	if err != nil {
+		return nil, serrors.Wrap("listing neighbors", err)
+	}
+	for _, n := range neighs {
+		a, ok := netip.AddrFromSlice(n.IP)
+		if !ok || a.Unmap() != nextHop || len(n.HardwareAddr) == 0 {
+			continue
+		}
+		if n.State&(netlink.NUD_INCOMPLETE|netlink.NUD_FAILED) != 0 {
+			continue
+		}
+		return n.HardwareAddr, nil
+	}
+
+	// Let the kernel do the resolution for us: sending anything to the remote address will do. We
+	// send an empty datagram to the discard port.
+	if c, err := net.DialUDP("udp", nil, net.UDPAddrFromAddrPort(
+		netip.AddrPortFrom(remote.Addr(), discardPort))); err == nil {
+		_, _ = c.Write(nil)
+		c.Close()
+	}
+	return nil, serrors.JoinNoStack(errNoNeighbor, nil, "next_hop", nextHop)
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package afpacketudpip
+
+import (
+	"net"
+	"net/netip"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+
+	"github.com/scionproto/scion/private/underlay/conn"
+)
+
+// setupVeth creates a veth pair between two new network namespaces. Side A gets addrA and side B
+// gets addrB. The calling goroutine is locked to its thread and left in namespace A. The returned
+// function runs f in namespace B. The test is skipped if the process lacks the privileges.
+func setupVeth(t *testing.T, addrA, addrB netip.Prefix) func(f func()) {
+	runtime.LockOSThread()
+	orig, err := netns.Get()
+	require.NoError(t, err)
+	nsB, err := netns.New()
+	if err != nil {
+		runtime.UnlockOSThread()
+		t.Skipf("cannot create network namespace: %v", err)
+	}
+	nsA, err := netns.New()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		// Deleting the namespaces deletes the veth pair.
+		_ = netns.Set(orig)
+		nsA.Close()
+		nsB.Close()
+		orig.Close()
+		runtime.UnlockOSThread()
+	})
+
+	veth := &netlink.Veth{
+		LinkAttrs: netlink.LinkAttrs{Name: "afpA", MTU: 1500},
+		PeerName:  "afpB",
+	}
+	require.NoError(t, netlink.LinkAdd(veth))
+	linkA, err := netlink.LinkByName("afpA")
+	require.NoError(t, err)
+	linkB, err := netlink.LinkByName("afpB")
+	require.NoError(t, err)
+	require.NoError(t, netlink.LinkSetNsFd(linkB, int(nsB)))
+	require.NoError(t, netlink.AddrAdd(linkA, &netlink.Addr{IPNet: prefixToIPNet(addrA)}))
+	require.NoError(t, netlink.LinkSetUp(linkA))
+
+	inB := func(f func()) {
+		require.NoError(t, netns.Set(nsB))
+		defer func() { require.NoError(t, netns.Set(nsA)) }()
+		f()
+	}
+	inB(func() {
+		linkB, err := netlink.LinkByName("afpB")
+		require.NoError(t, err)
+		require.NoError(t, netlink.AddrAdd(linkB, &netlink.Addr{IPNet: prefixToIPNet(addrB)}))
+		require.NoError(t, netlink.LinkSetUp(linkB))
+	})
+	return inB
+}
+
+func prefixToIPNet(p netip.Prefix) *net.IPNet {
+	return &net.IPNet{
+		IP:   p.Addr().AsSlice(),
+		Mask: net.CIDRMask(p.Bits(), p.Addr().BitLen()),
+	}
+}
+
+// TestVethExchange exchanges datagrams between an afpConn on one side of a veth pair and a
+// regular UDP socket on the other side. It needs CAP_NET_ADMIN and CAP_NET_RAW; it is skipped
+// otherwise.
+func TestVethExchange(t *testing.T) {
+	local := netip.MustParseAddrPort("10.123.0.1:50000")
+	remote := netip.MustParseAddrPort("10.123.0.2:50000")
+	inB := setupVeth(t,
+		netip.PrefixFrom(local.Addr(), 24), netip.PrefixFrom(remote.Addr(), 24))
+
+	var peer *net.UDPConn
+	inB(func() {
+		var err error
+		peer, err = net.ListenUDP("udp4", net.UDPAddrFromAddrPort(remote))
+		require.NoError(t, err)
+	})
+	defer peer.Close()
+
+	opener := afpOpener{}
+	fc, err := opener.Open(local, remote, &conn.Config{})
+	require.NoError(t, err)
+	defer fc.Close()
+
+	// The neighbor may take a moment to be resolved. We can't use require.Eventually: it would
+	// look from a different thread; so, likely, a different namespace.
+	var srcMAC, dstMAC net.HardwareAddr
+	for i := 0; ; i++ {
+		srcMAC, dstMAC, err = opener.Neighbor(local, remote)
+		if err == nil {
+			break
+		}
+		require.Less(t, i, 100, "neighbor not resolved: %v", err)
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	// A to B.
+	tmpl := newHeaderTemplate(srcMAC, dstMAC, local, remote)
+	payload := []byte("hello")
+	frame := make([]byte, tmpl.len+len(payload))
+	copy(frame[tmpl.len:], payload)
+	tmpl.prepend(frame, len(payload))
+	n, err := fc.WriteFrames([][]byte{frame})
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	buf := make([]byte, 256)
+	require.NoError(t, peer.SetReadDeadline(time.Now().Add(2*time.Second)))
+	n, from, err := peer.ReadFromUDPAddrPort(buf)
+	require.NoError(t, err)
+	assert.Equal(t, payload, buf[:n])
+	assert.Equal(t, local, from)
+
+	// B to A. The frame must come through the ring and nothing else.
+	_, err = peer.WriteToUDPAddrPort([]byte("world"), local)
+	require.NoError(t, err)
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		require.True(t, time.Now().Before(deadline), "nothing received")
+		frame, err := fc.ReadFrame()
+		if err == errTimeout {
+			continue
+		}
+		require.NoError(t, err)
+		src, dst, offset, ok := parseFrame(frame)
+		require.True(t, ok)
+		assert.Equal(t, remote, src)
+		assert.Equal(t, local, dst)
+		assert.Equal(t, []byte("world"), frame[offset:payloadEnd(frame, offset)])
+		break
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+
+package afpacketudpip
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+
+	"github.com/scionproto/scion/private/underlay/conn"
+)
+
+var (
+	errUnsupportedPlatform = errors.New("AF_PACKET underlay is only available on linux")
+	errTimeout             = errors.New("timeout")
+)
+
+// On other platforms, the provider registers but cannot open any link.
+type unsupportedOpener struct{}
+
+var (
+	defaultOpener    ConnOpener = unsupportedOpener{}
+	defaultXDPOpener ConnOpener = unsupportedOpener{}
+)
+
+func (unsupportedOpener) Open(local, remote netip.AddrPort, c *conn.Config) (FrameConn, error) {
+	return nil, errUnsupportedPlatform
+}
+
+func (unsupportedOpener) Neighbor(
+	local, remote netip.AddrPort,
+) (net.HardwareAddr, net.HardwareAddr, error) {
+
+	return nil, nil, errUnsupportedPlatform
+}
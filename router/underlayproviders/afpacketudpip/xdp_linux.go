@@ -0,0 +1,760 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package afpacketudpip
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"net/netip"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/asm"
+	"github.com/cilium/ebpf/link"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/private/underlay/conn"
+)
+
+const (
+	// The size of one UMEM chunk. A chunk holds one frame. Received frames start after the
+	// XDP_PACKET_HEADROOM, so the largest frame that can be received is xdpChunkSize minus that
+	// headroom.
+	xdpChunkSize = 4096
+	// The number of chunks of each XDP socket used for receiving and for sending. They are also the
+	// sizes of the rings, so they must be powers of two.
+	xdpRxChunks = 2048
+	xdpTxChunks = 2048
+	// The number of received frames that can wait for a link's receiver.
+	xdpLinkQueueLen = 256
+	// The maximum number of links per network interface.
+	xdpMaxLinks = 1024
+	// The size of the keys of the link table: source and destination address (16 bytes each, IPv4
+	// addresses use the first 4 bytes), source and destination port, address family, padding.
+	xdpKeyLen = 40
+)
+
+var errDuplicateLink = errors.New("link already open on this network interface")
+
+// xdpOpener is the ConnOpener of the afxdpudpip provider. It opens an xdpConn if the network
+// interface supports it and falls back to an afpConn otherwise.
+type xdpOpener struct {
+	afpOpener
+}
+
+var defaultXDPOpener ConnOpener = xdpOpener{}
+
+func (xdpOpener) Open(local, remote netip.AddrPort, c *conn.Config) (FrameConn, error) {
+	ifc, err := interfaceOf(local.Addr())
+	if err != nil {
+		return nil, err
+	}
+	fc, err := openXDPConn(ifc, local, remote)
+	if err == nil {
+		return fc, nil
+	}
+	log.Info("AF_XDP not available, falling back to AF_PACKET",
+		"interface", ifc.Name, "local", local, "remote", remote, "err", err)
+	return openAFPConn(ifc, local, remote, c)
+}
+
+// xdpConn is the FrameConn implementation of the AF_XDP fast path. The frames of all the links of
+// a network interface are steered to the XDP sockets of that interface by an XDP program and
+// demultiplexed to the links by the xdpInterface. The xdpConn only sees the frames of its link.
+//
+// As with afpConn, a regular UDP socket is bound to the local address. It keeps the port reserved
+// and swallows the frames that the XDP program passes to the kernel, such as IPv4 packets with
+// options.
+type xdpConn struct {
+	ifc    *xdpInterface
+	key    [2]netip.AddrPort
+	txq    *xdpQueue
+	frames chan xdpFrame
+	cur    xdpFrame // The frame last returned by ReadFrame.
+	timer  *time.Timer
+	claim  *net.UDPConn
+}
+
+// xdpFrame is a received frame in the UMEM of a queue.
+type xdpFrame struct {
+	q    *xdpQueue
+	addr uint64
+	len  uint32
+}
+
+func openXDPConn(ifc *net.Interface, local, remote netip.AddrPort) (*xdpConn, error) {
+	claim, err := claimPort(local)
+	if err != nil {
+		return nil, err
+	}
+	x, err := acquireXDPInterface(ifc)
+	if err != nil {
+		claim.Close()
+		return nil, err
+	}
+	c := &xdpConn{
+		ifc:    x,
+		key:    [2]netip.AddrPort{unmapAddrPort(remote), unmapAddrPort(local)},
+		frames: make(chan xdpFrame, xdpLinkQueueLen),
+		timer:  time.NewTimer(pollTimeout),
+		claim:  claim,
+	}
+	if err := x.addConn(c); err != nil {
+		x.release()
+		claim.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *xdpConn) ReadFrame() ([]byte, error) {
+	if c.cur.q != nil {
+		c.cur.q.release(c.cur.addr)
+		c.cur.q = nil
+	}
+	var f xdpFrame
+	select {
+	case f = <-c.frames:
+	default:
+		c.timer.Reset(pollTimeout)
+		select {
+		case f = <-c.frames:
+			c.timer.Stop()
+		case <-c.timer.C:
+			return nil, errTimeout
+		}
+	}
+	c.cur = f
+	return f.q.umem[f.addr : f.addr+uint64(f.len)], nil
+}
+
+func (c *xdpConn) WriteFrames(frames [][]byte) (int, error) {
+	return c.txq.write(frames)
+}
+
+func (c *xdpConn) Close() error {
+	c.ifc.removeConn(c)
+	if c.cur.q != nil {
+		c.cur.q.release(c.cur.addr)
+		c.cur.q = nil
+	}
+	// The interface no longer delivers to us, so this terminates.
+	for len(c.frames) > 0 {
+		f := <-c.frames
+		f.q.release(f.addr)
+	}
+	c.ifc.release()
+	return c.claim.Close()
+}
+
+// xdpInterface is the AF_XDP state of one network interface, shared by all its links: the XDP
+// program, its maps, and one XDP socket per receive queue. It exists as long as it has links.
+type xdpInterface struct {
+	index   int
+	name    string
+	refs    int // Protected by xdpInterfacesMu.
+	prog    *ebpf.Program
+	xdpLink link.Link
+	links   *ebpf.Map // The link table of the program: the 4-tuples to redirect.
+	xsks    *ebpf.Map // The XDP socket of each receive queue.
+	queues  []*xdpQueue
+
+	mu     sync.RWMutex // Protects conns and nextTx.
+	conns  map[[2]netip.AddrPort]*xdpConn
+	nextTx int
+
+	stopping atomic.Bool
+	wg       sync.WaitGroup
+}
+
+var (
+	xdpInterfacesMu sync.Mutex
+	xdpInterfaces   = make(map[int]*xdpInterface)
+)
+
+// acquireXDPInterface returns the xdpInterface of the given network interface, setting it up if it
+// is the first user. Every successful call must be paired with a call to release.
+func acquireXDPInterface(ifc *net.Interface) (*xdpInterface, error) {
+	xdpInterfacesMu.Lock()
+	defer xdpInterfacesMu.Unlock()
+	if x, ok := xdpInterfaces[ifc.Index]; ok {
+		x.refs++
+		return x, nil
+	}
+	x, err := newXDPInterface(ifc)
+	if err != nil {
+		return nil, err
+	}
+	x.refs = 1
+	xdpInterfaces[ifc.Index] = x
+	return x, nil
+}
+
+func (x *xdpInterface) release() {
+	xdpInterfacesMu.Lock()
+	defer xdpInterfacesMu.Unlock()
+	x.refs--
+	if x.refs > 0 {
+		return
+	}
+	delete(xdpInterfaces, x.index)
+	x.close()
+}
+
+func newXDPInterface(ifc *net.Interface) (*xdpInterface, error) {
+	// The queue count is taken from netlink rather than sysfs, which might belong to another
+	// network namespace.
+	nl, err := netlink.LinkByIndex(ifc.Index)
+	if err != nil {
+		return nil, serrors.Wrap("looking up interface", err, "interface", ifc.Name)
+	}
+	numQueues := max(nl.Attrs().NumRxQueues, 1)
+
+	x := &xdpInterface{
+		index: ifc.Index,
+		name:  ifc.Name,
+		conns: make(map[[2]netip.AddrPort]*xdpConn),
+	}
+	if err := x.setup(numQueues); err != nil {
+		x.close()
+		return nil, err
+	}
+	for _, q := range x.queues {
+		x.wg.Add(1)
+		go func() {
+			defer log.HandlePanic()
+			defer x.wg.Done()
+			x.receive(q)
+		}()
+	}
+	return x, nil
+}
+
+func (x *xdpInterface) setup(numQueues int) error {
+	var err error
+	x.links, err = ebpf.NewMap(&ebpf.MapSpec{
+		Type:       ebpf.Hash,
+		KeySize:    xdpKeyLen,
+		ValueSize:  1,
+		MaxEntries: xdpMaxLinks,
+	})
+	if err != nil {
+		return serrors.Wrap("creating link table", err)
+	}
+	x.xsks, err = ebpf.NewMap(&ebpf.MapSpec{
+		Type:       ebpf.XSKMap,
+		KeySize:    4,
+		ValueSize:  4,
+		MaxEntries: uint32(numQueues),
+	})
+	if err != nil {
+		return serrors.Wrap("creating socket map", err)
+	}
+	x.prog, err = ebpf.NewProgram(&ebpf.ProgramSpec{
+		Type:         ebpf.XDP,
+		Instructions: xdpProgram(x.links.FD(), x.xsks.FD()),
+		License:      "Apache-2.0",
+	})
+	if err != nil {
+		return serrors.Wrap("loading XDP program", err)
+	}
+	for i := range numQueues {
+		q, err := openXDPQueue(x.index, i)
+		if err != nil {
+			return serrors.Wrap("opening XDP socket", err, "interface", x.name, "queue", i)
+		}
+		x.queues = append(x.queues, q)
+		if err := x.xsks.Put(uint32(i), uint32(q.fd)); err != nil {
+			return serrors.Wrap("registering XDP socket", err, "interface", x.name, "queue", i)
+		}
+	}
+	// Prefer the native mode. Not all drivers support it; the generic mode works everywhere, but
+	// at a cost that is close to that of AF_PACKET.
+	x.xdpLink, err = link.AttachXDP(link.XDPOptions{
+		Program:   x.prog,
+		Interface: x.index,
+		Flags:     link.XDPDriverMode,
+	})
+	if err != nil {
+		x.xdpLink, err = link.AttachXDP(link.XDPOptions{
+			Program:   x.prog,
+			Interface: x.index,
+			Flags:     link.XDPGenericMode,
+		})
+	}
+	if err != nil {
+		return serrors.Wrap("attaching XDP program", err, "interface", x.name)
+	}
+	return nil
+}
+
+// close tears the interface down. The XDP program is detached first, so that the traffic goes
+// back to the kernel before the sockets go away.
+func (x *xdpInterface) close() {
+	if x.xdpLink != nil {
+		x.xdpLink.Close()
+	}
+	x.stopping.Store(true)
+	x.wg.Wait()
+	for _, q := range x.queues {
+		q.close()
+	}
+	if x.prog != nil {
+		x.prog.Close()
+	}
+	if x.xsks != nil {
+		x.xsks.Close()
+	}
+	if x.links != nil {
+		x.links.Close()
+	}
+}
+
+func (x *xdpInterface) addConn(c *xdpConn) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if _, ok := x.conns[c.key]; ok {
+		return serrors.JoinNoStack(errDuplicateLink, nil,
+			"interface", x.name, "local", c.key[1], "remote", c.key[0])
+	}
+	key := linkKey(c.key[0], c.key[1])
+	if err := x.links.Put(key[:], uint8(1)); err != nil {
+		return serrors.Wrap("adding link to link table", err, "interface", x.name)
+	}
+	// Spread the links over the queues for sending. Any queue can send any frame.
+	c.txq = x.queues[x.nextTx%len(x.queues)]
+	x.nextTx++
+	x.conns[c.key] = c
+	return nil
+}
+
+// removeConn stops the delivery of frames to c. Once it returns, the receivers no longer touch c.
+func (x *xdpInterface) removeConn(c *xdpConn) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	delete(x.conns, c.key)
+	key := linkKey(c.key[0], c.key[1])
+	if err := x.links.Delete(key[:]); err != nil {
+		log.Info("Removing link from link table failed", "interface", x.name, "err", err)
+	}
+}
+
+// receive reads the frames that the XDP program redirects to the socket of queue q and hands them
+// to their links. Frames that no link wants are put back on the fill ring right away.
+func (x *xdpInterface) receive(q *xdpQueue) {
+	fds := []unix.PollFd{{Fd: int32(q.fd), Events: unix.POLLIN}}
+	var unused []uint64
+	cons := atomic.LoadUint32(q.rx.consumer)
+	for !x.stopping.Load() {
+		prod := atomic.LoadUint32(q.rx.producer)
+		if cons == prod {
+			// EINTR or not, we check stopping and look at the ring again.
+			_, _ = unix.Poll(fds, int(pollTimeout/time.Millisecond))
+			continue
+		}
+		unused = unused[:0]
+		x.mu.RLock()
+		for ; cons != prod; cons++ {
+			d := *q.rx.desc(cons)
+			f := xdpFrame{q: q, addr: d.Addr, len: d.Len}
+			src, dst, _, ok := parseFrame(q.umem[d.Addr : d.Addr+uint64(d.Len)])
+			c := x.conns[[2]netip.AddrPort{src, dst}]
+			if !ok || c == nil {
+				unused = append(unused, d.Addr)
+				continue
+			}
+			select {
+			case c.frames <- f:
+			default:
+				// The link is not keeping up. Same as a full socket buffer.
+				unused = append(unused, d.Addr)
+			}
+		}
+		x.mu.RUnlock()
+		atomic.StoreUint32(q.rx.consumer, cons)
+		q.release(unused...)
+	}
+}
+
+// xdpQueue is one XDP socket, bound to one receive queue of the network interface, with its own
+// UMEM. The first xdpRxChunks chunks of the UMEM are used for receiving, the others for sending.
+type xdpQueue struct {
+	fd   int
+	umem []byte
+	rx   *xdpRing
+	tx   *xdpRing
+	fill *xdpRing
+	comp *xdpRing
+
+	fillMu sync.Mutex // Protects the producer side of the fill ring.
+
+	txMu   sync.Mutex // Protects the producer side of tx, the consumer side of comp, and txFree.
+	txFree []uint64
+}
+
+func openXDPQueue(ifIndex, queueID int) (*xdpQueue, error) {
+	fd, err := unix.Socket(unix.AF_XDP, unix.SOCK_RAW|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, serrors.Wrap("opening socket", err)
+	}
+	q := &xdpQueue{fd: fd}
+	if err := q.setup(ifIndex, queueID); err != nil {
+		q.close()
+		return nil, err
+	}
+	return q, nil
+}
+
+func (q *xdpQueue) setup(ifIndex, queueID int) error {
+	var err error
+	q.umem, err = unix.Mmap(-1, 0, (xdpRxChunks+xdpTxChunks)*xdpChunkSize,
+		unix.PROT_READ|unix.PROT_WRITE, unix.MAP_PRIVATE|unix.MAP_ANONYMOUS|unix.MAP_POPULATE)
+	if err != nil {
+		return serrors.Wrap("allocating UMEM", err)
+	}
+	reg := unix.XDPUmemReg{
+		Addr: uint64(uintptr(unsafe.Pointer(&q.umem[0]))),
+		Len:  uint64(len(q.umem)),
+		Size: xdpChunkSize,
+	}
+	if err := setsockopt(q.fd, unix.XDP_UMEM_REG, unsafe.Pointer(&reg),
+		unsafe.Sizeof(reg)); err != nil {
+
+		return serrors.Wrap("registering UMEM", err)
+	}
+	for opt, size := range map[int]int{
+		unix.XDP_UMEM_FILL_RING:       xdpRxChunks,
+		unix.XDP_UMEM_COMPLETION_RING: xdpTxChunks,
+		unix.XDP_RX_RING:              xdpRxChunks,
+		unix.XDP_TX_RING:              xdpTxChunks,
+	} {
+		if err := unix.SetsockoptInt(q.fd, unix.SOL_XDP, opt, size); err != nil {
+			return serrors.Wrap("sizing ring", err, "option", opt)
+		}
+	}
+	var off unix.XDPMmapOffsets
+	if err := getsockopt(q.fd, unix.XDP_MMAP_OFFSETS, unsafe.Pointer(&off),
+		unsafe.Sizeof(off)); err != nil {
+
+		return serrors.Wrap("reading ring offsets", err)
+	}
+	descLen := uint32(unsafe.Sizeof(unix.XDPDesc{}))
+	if q.rx, err = mapRing(q.fd, unix.XDP_PGOFF_RX_RING, off.Rx, xdpRxChunks,
+		descLen); err != nil {
+
+		return err
+	}
+	if q.tx, err = mapRing(q.fd, unix.XDP_PGOFF_TX_RING, off.Tx, xdpTxChunks,
+		descLen); err != nil {
+
+		return err
+	}
+	if q.fill, err = mapRing(q.fd, unix.XDP_UMEM_PGOFF_FILL_RING, off.Fr, xdpRxChunks,
+		8); err != nil {
+
+		return err
+	}
+	if q.comp, err = mapRing(q.fd, unix.XDP_UMEM_PGOFF_COMPLETION_RING, off.Cr, xdpTxChunks,
+		8); err != nil {
+
+		return err
+	}
+
+	// Hand all receive chunks to the kernel. The fill ring is exactly large enough.
+	for i := range uint32(xdpRxChunks) {
+		*q.fill.addr(i) = uint64(i) * xdpChunkSize
+	}
+	atomic.StoreUint32(q.fill.producer, xdpRxChunks)
+	q.txFree = make([]uint64, 0, xdpTxChunks)
+	for i := range uint64(xdpTxChunks) {
+		q.txFree = append(q.txFree, (xdpRxChunks+i)*xdpChunkSize)
+	}
+
+	sa := &unix.SockaddrXDP{Ifindex: uint32(ifIndex), QueueID: uint32(queueID)}
+	if err := unix.Bind(q.fd, sa); err != nil {
+		return serrors.Wrap("binding socket", err)
+	}
+	return nil
+}
+
+func (q *xdpQueue) close() {
+	for _, r := range []*xdpRing{q.rx, q.tx, q.fill, q.comp} {
+		if r != nil {
+			_ = unix.Munmap(r.mem)
+		}
+	}
+	unix.Close(q.fd)
+	if q.umem != nil {
+		_ = unix.Munmap(q.umem)
+	}
+}
+
+// release puts the given receive chunks back on the fill ring.
+func (q *xdpQueue) release(addrs ...uint64) {
+	if len(addrs) == 0 {
+		return
+	}
+	q.fillMu.Lock()
+	defer q.fillMu.Unlock()
+	// There is always room: the ring can hold all receive chunks.
+	prod := atomic.LoadUint32(q.fill.producer)
+	for _, a := range addrs {
+		*q.fill.addr(prod) = a &^ (xdpChunkSize - 1)
+		prod++
+	}
+	atomic.StoreUint32(q.fill.producer, prod)
+}
+
+// write copies the frames to free send chunks and queues them on the transmit ring. It returns
+// the number of frames queued. If it is short, the next frame is too large or no chunk is free.
+func (q *xdpQueue) write(frames [][]byte) (int, error) {
+	q.txMu.Lock()
+	defer q.txMu.Unlock()
+	q.reclaim()
+	if len(q.txFree) < len(frames) {
+		// Let the kernel catch up with what we sent before.
+		q.kick()
+		q.reclaim()
+	}
+	prod := atomic.LoadUint32(q.tx.producer)
+	n := 0
+	for _, f := range frames {
+		if len(f) > xdpChunkSize || len(q.txFree) == 0 {
+			break
+		}
+		a := q.txFree[len(q.txFree)-1]
+		q.txFree = q.txFree[:len(q.txFree)-1]
+		copy(q.umem[a:a+xdpChunkSize], f)
+		*q.tx.desc(prod) = unix.XDPDesc{Addr: a, Len: uint32(len(f))}
+		prod++
+		n++
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	atomic.StoreUint32(q.tx.producer, prod)
+	return n, q.kick()
+}
+
+// kick makes the kernel process the transmit ring.
+func (q *xdpQueue) kick() error {
+	err := unix.Sendto(q.fd, nil, unix.MSG_DONTWAIT, nil)
+	switch {
+	case err == nil, errors.Is(err, syscall.EAGAIN), errors.Is(err, syscall.EBUSY),
+		errors.Is(err, syscall.ENOBUFS):
+		// The kernel is busy with what we queued before; it will get to the rest.
+		return nil
+	default:
+		return err
+	}
+}
+
+// reclaim takes the send chunks that the kernel is done with back from the completion ring.
+func (q *xdpQueue) reclaim() {
+	cons := atomic.LoadUint32(q.comp.consumer)
+	prod := atomic.LoadUint32(q.comp.producer)
+	for ; cons != prod; cons++ {
+		q.txFree = append(q.txFree, *q.comp.addr(cons))
+	}
+	atomic.StoreUint32(q.comp.consumer, cons)
+}
+
+// xdpRing is one of the rings shared with the kernel. The rings of the XDP socket hold frame
+// descriptors; the rings of the UMEM hold chunk addresses.
+type xdpRing struct {
+	mem      []byte
+	producer *uint32
+	consumer *uint32
+	descs    unsafe.Pointer
+	mask     uint32
+}
+
+func mapRing(fd int, pgoff int64, off unix.XDPRingOffset, size, entryLen uint32) (*xdpRing, error) {
+	mem, err := unix.Mmap(fd, pgoff, int(off.Desc)+int(size*entryLen),
+		unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED|unix.MAP_POPULATE)
+	if err != nil {
+		return nil, serrors.Wrap("mapping ring", err, "offset", pgoff)
+	}
+	return &xdpRing{
+		mem:      mem,
+		producer: (*uint32)(unsafe.Pointer(&mem[off.Producer])),
+		consumer: (*uint32)(unsafe.Pointer(&mem[off.Consumer])),
+		descs:    unsafe.Pointer(&mem[off.Desc]),
+		mask:     size - 1,
+	}, nil
+}
+
+func (r *xdpRing) desc(i uint32) *unix.XDPDesc {
+	return (*unix.XDPDesc)(unsafe.Add(r.descs, uintptr(i&r.mask)*unsafe.Sizeof(unix.XDPDesc{})))
+}
+
+func (r *xdpRing) addr(i uint32) *uint64 {
+	return (*uint64)(unsafe.Add(r.descs, uintptr(i&r.mask)*8))
+}
+
+func setsockopt(fd, opt int, val unsafe.Pointer, size uintptr) error {
+	_, _, errno := unix.Syscall6(unix.SYS_SETSOCKOPT, uintptr(fd), unix.SOL_XDP, uintptr(opt),
+		uintptr(val), size, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func getsockopt(fd, opt int, val unsafe.Pointer, size uintptr) error {
+	l := uint32(size)
+	_, _, errno := unix.Syscall6(unix.SYS_GETSOCKOPT, uintptr(fd), unix.SOL_XDP, uintptr(opt),
+		uintptr(val), uintptr(unsafe.Pointer(&l)), 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func unmapAddrPort(a netip.AddrPort) netip.AddrPort {
+	return netip.AddrPortFrom(a.Addr().Unmap(), a.Port())
+}
+
+// linkKey returns the key of the link table entry for the frames from src to dst. It has the
+// layout that the XDP program builds from the frames.
+func linkKey(src, dst netip.AddrPort) [xdpKeyLen]byte {
+	var k [xdpKeyLen]byte
+	if src.Addr().Is4() {
+		s, d := src.Addr().As4(), dst.Addr().As4()
+		copy(k[0:], s[:])
+		copy(k[16:], d[:])
+		k[36] = 4
+	} else {
+		s, d := src.Addr().As16(), dst.Addr().As16()
+		copy(k[0:], s[:])
+		copy(k[16:], d[:])
+		k[36] = 6
+	}
+	binary.BigEndian.PutUint16(k[32:], src.Port())
+	binary.BigEndian.PutUint16(k[34:], dst.Port())
+	return k
+}
+
+// xdpProgram returns the XDP program that redirects the frames of the links in the link table to
+// the XDP socket of the receive queue. Everything else is passed to the kernel. Only IPv4 without
+// options and IPv6 without extension headers are recognized; the same as parseFrame, except for
+// the IPv4 options.
+//
+// The program is small enough to be assembled here, so the router does not need a BPF compiler
+// to be built.
+func xdpProgram(linksFD, xsksFD int) asm.Instructions {
+	// The frame is loaded in the order of the network; the constants it is compared with must be
+	// swapped accordingly.
+	be16 := func(v uint16) int32 {
+		var b [2]byte
+		binary.BigEndian.PutUint16(b[:], v)
+		return int32(binary.NativeEndian.Uint16(b[:]))
+	}
+	const (
+		xdpPass = 2
+		// Offsets in struct xdp_md.
+		mdData         = 0
+		mdDataEnd      = 4
+		mdRxQueueIndex = 16
+		// The key is built at the bottom of the stack.
+		key = -xdpKeyLen
+	)
+	return asm.Instructions{
+		asm.Mov.Reg(asm.R6, asm.R1),
+		asm.LoadMem(asm.R2, asm.R6, mdData, asm.Word),
+		asm.LoadMem(asm.R3, asm.R6, mdDataEnd, asm.Word),
+		asm.StoreImm(asm.RFP, key, 0, asm.DWord),
+		asm.StoreImm(asm.RFP, key+8, 0, asm.DWord),
+		asm.StoreImm(asm.RFP, key+16, 0, asm.DWord),
+		asm.StoreImm(asm.RFP, key+24, 0, asm.DWord),
+		asm.StoreImm(asm.RFP, key+32, 0, asm.DWord),
+		// Ethernet, IPv4 and UDP headers.
+		asm.Mov.Reg(asm.R4, asm.R2),
+		asm.Add.Imm(asm.R4, ethLen+ipv4Len+udpLen),
+		asm.JGT.Reg(asm.R4, asm.R3, "pass"),
+		asm.LoadMem(asm.R5, asm.R2, 12, asm.Half),
+		asm.JEq.Imm(asm.R5, be16(etherTypeIPv6), "ipv6"),
+		asm.JNE.Imm(asm.R5, be16(etherTypeIPv4), "pass"),
+
+		// IPv4: version 4, no options, UDP, not a fragment.
+		asm.LoadMem(asm.R5, asm.R2, ethLen, asm.Byte),
+		asm.JNE.Imm(asm.R5, 0x45, "pass"),
+		asm.LoadMem(asm.R5, asm.R2, ethLen+9, asm.Byte),
+		asm.JNE.Imm(asm.R5, protoUDP, "pass"),
+		asm.LoadMem(asm.R5, asm.R2, ethLen+6, asm.Half),
+		asm.And.Imm(asm.R5, be16(0x3fff)),
+		asm.JNE.Imm(asm.R5, 0, "pass"),
+		asm.LoadMem(asm.R5, asm.R2, ethLen+12, asm.Word),
+		asm.StoreMem(asm.RFP, key, asm.R5, asm.Word),
+		asm.LoadMem(asm.R5, asm.R2, ethLen+16, asm.Word),
+		asm.StoreMem(asm.RFP, key+16, asm.R5, asm.Word),
+		asm.LoadMem(asm.R5, asm.R2, ethLen+ipv4Len, asm.Word),
+		asm.StoreMem(asm.RFP, key+32, asm.R5, asm.Word),
+		asm.StoreImm(asm.RFP, key+36, 4, asm.Byte),
+		asm.Ja.Label("lookup"),
+
+		// IPv6: UDP right after the fixed header.
+		asm.Mov.Reg(asm.R4, asm.R2).WithSymbol("ipv6"),
+		asm.Add.Imm(asm.R4, ethLen+ipv6Len+udpLen),
+		asm.JGT.Reg(asm.R4, asm.R3, "pass"),
+		asm.LoadMem(asm.R5, asm.R2, ethLen+6, asm.Byte),
+		asm.JNE.Imm(asm.R5, protoUDP, "pass"),
+		asm.LoadMem(asm.R5, asm.R2, ethLen+8, asm.Word),
+		asm.StoreMem(asm.RFP, key, asm.R5, asm.Word),
+		asm.LoadMem(asm.R5, asm.R2, ethLen+12, asm.Word),
+		asm.StoreMem(asm.RFP, key+4, asm.R5, asm.Word),
+		asm.LoadMem(asm.R5, asm.R2, ethLen+16, asm.Word),
+		asm.StoreMem(asm.RFP, key+8, asm.R5, asm.Word),
+		asm.LoadMem(asm.R5, asm.R2, ethLen+20, asm.Word),
+		asm.StoreMem(asm.RFP, key+12, asm.R5, asm.Word),
+		asm.LoadMem(asm.R5, asm.R2, ethLen+24, asm.Word),
+		asm.StoreMem(asm.RFP, key+16, asm.R5, asm.Word),
+		asm.LoadMem(asm.R5, asm.R2, ethLen+28, asm.Word),
+		asm.StoreMem(asm.RFP, key+20, asm.R5, asm.Word),
+		asm.LoadMem(asm.R5, asm.R2, ethLen+32, asm.Word),
+		asm.StoreMem(asm.RFP, key+24, asm.R5, asm.Word),
+		asm.LoadMem(asm.R5, asm.R2, ethLen+36, asm.Word),
+		asm.StoreMem(asm.RFP, key+28, asm.R5, asm.Word),
+		asm.LoadMem(asm.R5, asm.R2, ethLen+ipv6Len, asm.Word),
+		asm.StoreMem(asm.RFP, key+32, asm.R5, asm.Word),
+		asm.StoreImm(asm.RFP, key+36, 6, asm.Byte),
+
+		// Redirect if the 4-tuple is in the link table. Without a socket for the queue, the frame
+		// is passed.
+		asm.LoadMapPtr(asm.R1, linksFD).WithSymbol("lookup"),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, key),
+		asm.FnMapLookupElem.Call(),
+		asm.JEq.Imm(asm.R0, 0, "pass"),
+		asm.LoadMapPtr(asm.R1, xsksFD),
+		asm.LoadMem(asm.R2, asm.R6, mdRxQueueIndex, asm.Word),
+		asm.Mov.Imm(asm.R3, xdpPass),
+		asm.FnRedirectMap.Call(),
+		asm.Return(),
+
+		asm.Mov.Imm(asm.R0, xdpPass).WithSymbol("pass"),
+		asm.Return(),
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package afpacketudpip
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+// TestXDPVethExchange exchanges datagrams between two xdpConns on one side of a veth pair and
+// regular UDP sockets on the other side. Both links share the XDP program and sockets of the
+// interface. It needs CAP_NET_ADMIN, CAP_NET_RAW and CAP_BPF; it is skipped otherwise.
+func TestXDPVethExchange(t *testing.T) {
+	locals := []netip.AddrPort{
+		netip.MustParseAddrPort("10.124.0.1:50000"),
+		netip.MustParseAddrPort("10.124.0.1:50001"),
+	}
+	remotes := []netip.AddrPort{
+		netip.MustParseAddrPort("10.124.0.2:50000"),
+		netip.MustParseAddrPort("10.124.0.2:50001"),
+	}
+	inB := setupVeth(t,
+		netip.PrefixFrom(locals[0].Addr(), 24), netip.PrefixFrom(remotes[0].Addr(), 24))
+
+	peers := make([]*net.UDPConn, len(remotes))
+	inB(func() {
+		for i, r := range remotes {
+			var err error
+			peers[i], err = net.ListenUDP("udp4", net.UDPAddrFromAddrPort(r))
+			require.NoError(t, err)
+		}
+	})
+	for _, p := range peers {
+		defer p.Close()
+	}
+
+	ifc, err := interfaceOf(locals[0].Addr())
+	require.NoError(t, err)
+	conns := make([]*xdpConn, len(remotes))
+	for i, r := range remotes {
+		conns[i], err = openXDPConn(ifc, locals[i], r)
+		if errors.Is(err, unix.EPERM) || errors.Is(err, unix.EOPNOTSUPP) {
+			t.Skipf("AF_XDP not available: %v", err)
+		}
+		require.NoError(t, err)
+	}
+
+	var srcMAC, dstMAC net.HardwareAddr
+	for i := 0; ; i++ {
+		srcMAC, dstMAC, err = afpOpener{}.Neighbor(locals[0], remotes[0])
+		if err == nil {
+			break
+		}
+		require.Less(t, i, 100, "neighbor not resolved: %v", err)
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	for i, r := range remotes {
+		// A to B.
+		tmpl := newHeaderTemplate(srcMAC, dstMAC, locals[i], r)
+		payload := []byte{'a', byte(i)}
+		frame := make([]byte, tmpl.len+len(payload))
+		copy(frame[tmpl.len:], payload)
+		tmpl.prepend(frame, len(payload))
+		n, err := conns[i].WriteFrames([][]byte{frame})
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+
+		buf := make([]byte, 256)
+		require.NoError(t, peers[i].SetReadDeadline(time.Now().Add(2*time.Second)))
+		n, from, err := peers[i].ReadFromUDPAddrPort(buf)
+		require.NoError(t, err)
+		assert.Equal(t, payload, buf[:n])
+		assert.Equal(t, locals[i], from)
+	}
+
+	// B to A. Each link only gets its own frames.
+	for i, r := range remotes {
+		_, err = peers[i].WriteToUDPAddrPort([]byte{'b', byte(i)}, locals[i])
+		require.NoError(t, err)
+		frame := readXDPFrame(t, conns[i])
+		src, dst, offset, ok := parseFrame(frame)
+		require.True(t, ok)
+		assert.Equal(t, r, src)
+		assert.Equal(t, locals[i], dst)
+		assert.Equal(t, []byte{'b', byte(i)}, frame[offset:payloadEnd(frame, offset)])
+	}
+	for _, c := range conns {
+		_, err := c.ReadFrame()
+		assert.ErrorIs(t, err, errTimeout)
+	}
+
+	// The other link keeps working without the first one.
+	require.NoError(t, conns[0].Close())
+	_, err = peers[1].WriteToUDPAddrPort([]byte("again"), locals[1])
+	require.NoError(t, err)
+	frame := readXDPFrame(t, conns[1])
+	_, _, offset, ok := parseFrame(frame)
+	require.True(t, ok)
+	assert.Equal(t, []byte("again"), frame[offset:payloadEnd(frame, offset)])
+
+	// The last link tears the interface down.
+	require.NoError(t, conns[1].Close())
+	xdpInterfacesMu.Lock()
+	assert.Empty(t, xdpInterfaces)
+	xdpInterfacesMu.Unlock()
+}
+
+func readXDPFrame(t *testing.T, c *xdpConn) []byte {
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		require.True(t, time.Now().Before(deadline), "nothing received")
+		frame, err := c.ReadFrame()
+		if err == errTimeout {
+			continue
+		}
+		require.NoError(t, err)
+		return frame
+	}
+}
+
+func TestLinkKey(t *testing.T) {
+	k := linkKey(netip.MustParseAddrPort("192.0.2.1:1"), netip.MustParseAddrPort("192.0.2.2:258"))
+	assert.Equal(t, []byte{192, 0, 2, 1}, k[0:4])
+	assert.Equal(t, []byte{192, 0, 2, 2}, k[16:20])
+	assert.Equal(t, []byte{0, 1, 1, 2, 4}, k[32:37])
+
+	k = linkKey(netip.MustParseAddrPort("[2001:db8::1]:1"),
+		netip.MustParseAddrPort("[2001:db8::2]:2"))
+	assert.Equal(t, netip.MustParseAddr("2001:db8::1").AsSlice(), k[0:16])
+	assert.Equal(t, netip.MustParseAddr("2001:db8::2").AsSlice(), k[16:32])
+	assert.Equal(t, []byte{0, 1, 0, 2, 6}, k[32:37])
+}
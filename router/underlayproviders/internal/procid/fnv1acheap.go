@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package udpip
+package procid
 
 // fnv1aOffset32 is an initial offset that can be used as initial state when calling
 // hashFNV1a.
@@ -0,0 +1,85 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package procid assigns the packets received by an underlay to the packet processors. The
+// packets of a flow are always assigned to the same processor, so that they are not reordered.
+// All the underlay providers use the same assignment.
+package procid
+
+import (
+	"crypto/rand"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/slayers"
+)
+
+// MakeHashSeed creates a new random number to serve as hash seed.
+// Each receive loop is associated with its own hash seed to compute
+// the proc queue where a packet should be delivered. All links that share
+// an underlying connection (therefore a receive loop) use the same hash seed.
+func MakeHashSeed() uint32 {
+	hashSeed := fnv1aOffset32
+	randomBytes := make([]byte, 4)
+	if _, err := rand.Read(randomBytes); err != nil {
+		panic("Error while generating random value")
+	}
+	for _, c := range randomBytes {
+		hashSeed = hashFNV1a(hashSeed, c)
+	}
+	return hashSeed
+}
+
+// Compute computes the processor ID for a given packet provided by the slice data. It assumes
+// that numProcRoutines is non-negative and not larger than 4294967295. hashSeed is used for hash
+// computation. If data is clearly not a valid SCION packet, it returns ok=false. Otherwise, it
+// returns a processor ID smaller than numProcRoutines and ok=true.
+// Specifically for STUN packets, the check for valid SCION packets fails since the part of the STUN
+// header that overlaps with the SCION common header NextHdr field contains value 0x21, which is not
+// a valid L4 protocol type. Therefore, STUN packets will always result in ok=false.
+// If we ever have a protocol type assigned to value 0x21, we need to revisit this function.
+func Compute(data []byte, numProcRoutines int, hashSeed uint32) (uint32, bool) {
+	if len(data) < slayers.CmnHdrLen {
+		return uint32(numProcRoutines), false
+	}
+
+	switch slayers.L4ProtocolType(data[4]) {
+	case slayers.L4TCP, slayers.L4UDP, slayers.L4SCMP, slayers.L4BFD,
+		slayers.HopByHopClass, slayers.End2EndClass,
+		slayers.ExperimentationAndTesting, slayers.ExperimentationAndTesting2:
+	default:
+		return uint32(numProcRoutines), false
+	}
+
+	dstHostAddrLen := slayers.AddrType(data[9] >> 4 & 0xf).Length()
+	srcHostAddrLen := slayers.AddrType(data[9] & 0xf).Length()
+	addrHdrLen := 2*addr.IABytes + srcHostAddrLen + dstHostAddrLen
+	if len(data) < slayers.CmnHdrLen+addrHdrLen {
+		return uint32(numProcRoutines), false
+	}
+
+	s := hashSeed
+
+	// inject the flowID
+	s = hashFNV1a(s, data[1]&0xF) // The left 4 bits aren't part of the flowID.
+	for _, c := range data[2:4] {
+		s = hashFNV1a(s, c)
+	}
+
+	// Inject the src/dst addresses
+	for _, c := range data[slayers.CmnHdrLen : slayers.CmnHdrLen+addrHdrLen] {
+		s = hashFNV1a(s, c)
+	}
+
+	return s % uint32(numProcRoutines), true
+}
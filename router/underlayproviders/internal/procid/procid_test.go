@@ -13,7 +13,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package udpip
+package procid
 
 import (
 	"crypto/rand"
@@ -87,7 +87,7 @@ func TestComputeProcId(t *testing.T) {
 	randomValueBytes := []byte{1, 2, 3, 4}
 	numProcs := 10000
 
-	// ComputeProcID expects the per-receiver random number to be pre-hashed into the seed that we
+	// Compute expects the per-receiver random number to be pre-hashed into the seed that we
 	// pass.
 	hashSeed := fnv1aOffset32
 	for _, c := range randomValueBytes {
@@ -112,7 +112,7 @@ func TestComputeProcId(t *testing.T) {
 	}
 
 	// this helper returns the procID as the router actually makes it by using the extraction
-	// from dataplane.computeProcID() along with hashFNV1a() for the seed.
+	// from Compute() along with hashFNV1a() for the seed.
 	computeProcIDHelper := func(payload []byte, s *slayers.SCION) (uint32, bool) {
 		buffer := gopacket.NewSerializeBuffer()
 		err := gopacket.SerializeLayers(buffer,
@@ -121,7 +121,7 @@ func TestComputeProcId(t *testing.T) {
 		require.NoError(t, err)
 		raw := buffer.Bytes()
 
-		return computeProcID(raw, numProcs, hashSeed)
+		return Compute(raw, numProcs, hashSeed)
 	}
 	type ret struct {
 		payload []byte
@@ -313,7 +313,7 @@ func TestComputeProcIdErrorCases(t *testing.T) {
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
 			randomValue := uint32(1234) // not a proper hash seed, but hash result is irrelevant.
-			_, ok := computeProcID(tc.data, 10000, randomValue)
+			_, ok := Compute(tc.data, 10000, randomValue)
 			assert.Equal(t, tc.expectedSuccess, ok)
 		})
 	}
@@ -16,7 +16,6 @@ package udpip
 
 import (
 	"context"
-	"crypto/rand"
 	"errors"
 	"fmt"
 	"maps"
@@ -30,11 +29,11 @@ import (
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
-	"github.com/scionproto/scion/pkg/slayers"
 	"github.com/scionproto/scion/pkg/stun"
 	"github.com/scionproto/scion/private/underlay/conn"
 	"github.com/scionproto/scion/router"
 	"github.com/scionproto/scion/router/bfd"
+	"github.com/scionproto/scion/router/underlayproviders/internal/procid"
 )
 
 var (
@@ -86,6 +85,12 @@ type provider struct {
 	dispatchStart      uint16
 	dispatchEnd        uint16
 	dispatchRedirect   uint16
+
+	// What Start was given, so links added later can be started too.
+	running bool
+	runCtx  context.Context
+	pool    router.PacketPool
+	procQs  []chan *router.Packet
 }
 
 type udpLink interface {
@@ -165,11 +170,15 @@ func (u *provider) DelSvc(svc addr.SVC, host addr.Host, port uint16) error {
 func (u *provider) Start(
 	ctx context.Context, pool router.PacketPool, procQs []chan *router.Packet,
 ) {
-	u.mu.Lock()
 	if len(procQs) == 0 {
 		// Pointless to run without any processor of incoming traffic
 		return
 	}
+	u.mu.Lock()
+	u.running = true
+	u.runCtx = ctx
+	u.pool = pool
+	u.procQs = procQs
 	connSnapshot := slices.Clone(u.allConnections)
 	linkSnapshot := slices.Collect(maps.Values(u.allLinks))
 	u.mu.Unlock()
@@ -186,6 +195,7 @@ func (u *provider) Start(
 
 func (u *provider) Stop() {
 	u.mu.Lock()
+	u.running = false
 	connSnapshot := slices.Clone(u.allConnections)
 	linkSnapshot := slices.Collect(maps.Values(u.allLinks))
 	u.mu.Unlock()
@@ -198,21 +208,93 @@ func (u *provider) Stop() {
 	}
 }
 
+// StartLink starts the given link and, unless it is shared, its connection.
+func (u *provider) StartLink(l router.Link) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if !u.running {
+		return
+	}
+	switch l := l.(type) {
+	case *connectedLink:
+		l.start(u.runCtx, u.procQs, u.pool)
+		l.connection.start(u.batchSize, u.pool)
+	case *detachedLink:
+		l.start(u.runCtx, u.procQs, u.pool)
+	}
+}
+
+// StopReceiving stops the receiver of a connected link. As that closes the socket, the link can
+// no longer actually send either; packets sent on it are dropped. A detached link is simply
+// removed from the internal connection's demultiplexer, so its traffic is henceforth treated as
+// internal traffic.
+func (u *provider) StopReceiving(l router.Link) {
+	switch l := l.(type) {
+	case *connectedLink:
+		l.connection.stopReceiving()
+	case *detachedLink:
+		u.mu.Lock()
+		defer u.mu.Unlock()
+		u.internalConnection.removeLink(l)
+	}
+}
+
+// RemoveLink stops the given link and its connection, if the link has one of its own.
+func (u *provider) RemoveLink(l router.Link) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	maps.DeleteFunc(u.allLinks, func(_ netip.AddrPort, v udpLink) bool { return v == l })
+	switch l := l.(type) {
+	case *connectedLink:
+		c := l.connection
+		u.allConnections = slices.DeleteFunc(u.allConnections, func(x *udpConnection) bool {
+			return x == c
+		})
+		if c.running.Load() {
+			c.stop()
+		} else {
+			c.conn.Close()
+		}
+		l.stop()
+	case *detachedLink:
+		u.internalConnection.removeLink(l)
+		l.stop()
+	}
+}
+
 // udpConnection is essentially a BatchConn with a sending queue and a demultiplexer. The rest is
 // about logs and metrics. This allows UDP connections to be shared between links when needed (for
 // example, only linux allows UDP connected sockets to share the same local address, which is needed
 // if sibling links are to have distinct connections).
 type udpConnection struct {
 	conn         router.BatchConn
-	name         string                     // for logs. It's more informative than ifID.
-	link         udpLink                    // Link with exclusive use of the connection.
-	links        map[netip.AddrPort]udpLink // Links that share this connection
+	name         string  // for logs. It's more informative than ifID.
+	link         udpLink // Link with exclusive use of the connection.
 	queue        chan *router.Packet
 	metrics      *router.InterfaceMetrics
 	receiverDone chan struct{}
 	senderDone   chan struct{}
 	running      atomic.Bool
+	receiving    atomic.Bool
 	connected    bool // If true, the underlying UDP socket is connected
+
+	// Links that share this connection, by remote address. The map is replaced, never modified,
+	// so the receiver can use it without locking.
+	links atomic.Pointer[map[netip.AddrPort]udpLink]
+}
+
+// addLink adds the given link to the connection's demultiplexer.
+func (u *udpConnection) addLink(remote netip.AddrPort, l udpLink) {
+	links := maps.Clone(*u.links.Load())
+	links[remote] = l
+	u.links.Store(&links)
+}
+
+// removeLink removes the given link from the connection's demultiplexer.
+func (u *udpConnection) removeLink(l udpLink) {
+	links := maps.Clone(*u.links.Load())
+	maps.DeleteFunc(links, func(_ netip.AddrPort, v udpLink) bool { return v == l })
+	u.links.Store(&links)
 }
 
 // start puts the connection in the running state. In that state, the connection can deliver
@@ -222,6 +304,7 @@ func (u *udpConnection) start(batchSize int, pool router.PacketPool) {
 	if wasRunning {
 		return
 	}
+	u.receiving.Store(true)
 
 	// Receiver task
 	go func() {
@@ -246,13 +329,21 @@ func (u *udpConnection) stop() {
 	wasRunning := u.running.Swap(false)
 
 	if wasRunning {
-		u.conn.Close() // Unblock receiver
+		u.stopReceiving()
 		close(u.queue) // Unblock sender
-		<-u.receiverDone
 		<-u.senderDone
 	}
 }
 
+// stopReceiving stops the receiver. Doing so requires closing the underlying socket, so the
+// connection can no longer send afterwards. The sender keeps draining its queue though.
+func (u *udpConnection) stopReceiving() {
+	if u.receiving.Swap(false) {
+		u.conn.Close() // Unblock receiver
+		<-u.receiverDone
+	}
+}
+
 func (u *udpConnection) receive(batchSize int, pool router.PacketPool) {
 	log.Debug("Receive", "connection", u.name)
 
@@ -265,7 +356,7 @@ func (u *udpConnection) receive(batchSize int, pool router.PacketPool) {
 	packets := make([]*router.Packet, batchSize)
 	numReusable := 0 // unused buffers from previous loop
 
-	for u.running.Load() {
+	for u.receiving.Load() {
 		// collect packets.
 
 		// Give a new buffer to the msgs elements that have been used in the previous loop.
@@ -283,6 +374,7 @@ func (u *udpConnection) receive(batchSize int, pool router.PacketPool) {
 			continue
 		}
 		numReusable -= numPkts
+		links := u.links.Load()
 		for i, msg := range msgs[:numPkts] {
 
 			// Update size; readBatch does not.
@@ -291,10 +383,10 @@ func (u *udpConnection) receive(batchSize int, pool router.PacketPool) {
 			p.RawPacket = p.RawPacket[:size]
 
 			// Demultiplex to a link.
-			if u.links != nil {
+			if links != nil {
 				// For a shared connection we have a map of links by remote address.
 				srcAddr := msg.Addr.(*net.UDPAddr).AddrPort()
-				l, found := u.links[srcAddr]
+				l, found := (*links)[srcAddr]
 				if found {
 					l.receive(size, msg.Addr.(*net.UDPAddr), p)
 					continue
@@ -387,7 +479,7 @@ func (u *udpConnection) send(batchSize int, pool router.PacketPool) {
 		if written != toWrite {
 			// Only one is dropped at this time. We'll retry the rest.
 			sc := router.ClassOfSize(len(pkts[written].RawPacket))
-			metrics[sc].DroppedPacketsInvalid.Inc()
+			metrics[sc].DroppedPackets.Inc(router.DropInvalid)
 			pool.Put(pkts[written])
 			toWrite -= (written + 1)
 			// Shift the leftovers to the head of the buffers.
@@ -400,28 +492,13 @@ func (u *udpConnection) send(batchSize int, pool router.PacketPool) {
 	}
 }
 
-// makeHashSeed creates a new random number to serve as hash seed.
-// Each receive loop is associated with its own hash seed to compute
-// the proc queue where a packet should be delivered. All links that share
-// an underlying connection (therefore a receive loop) use the same hash seed.
-func makeHashSeed() uint32 {
-	hashSeed := fnv1aOffset32
-	randomBytes := make([]byte, 4)
-	if _, err := rand.Read(randomBytes); err != nil {
-		panic("Error while generating random value")
-	}
-	for _, c := range randomBytes {
-		hashSeed = hashFNV1a(hashSeed, c)
-	}
-	return hashSeed
-}
-
 // A connectedLink creates an exclusive underlying point-to-point connection. Such a link does not
 // need to specify a destination address and receives all the traffic from that connection. Such a
 // link is used as an external link and, under some conditions, as a sibling link.
 type connectedLink struct {
 	procQs     []chan *router.Packet
 	name       string // For logs
+	connection *udpConnection
 	egressQ    chan<- *router.Packet
 	metrics    *router.InterfaceMetrics
 	pool       router.PacketPool
@@ -482,7 +559,7 @@ func (u *provider) newConnectedLink(
 		egressQ:    queue,
 		metrics:    metrics,
 		bfdSession: bfd,
-		seed:       makeHashSeed(),
+		seed:       procid.MakeHashSeed(),
 		ifID:       ifID,
 		scope:      scope,
 	}
@@ -497,6 +574,7 @@ func (u *provider) newConnectedLink(
 		senderDone:   make(chan struct{}),
 		connected:    true,
 	}
+	el.connection = c
 	u.allConnections = append(u.allConnections, c)
 	u.allLinks[remoteAddr] = el
 	return el, nil
@@ -578,17 +656,17 @@ func (l *connectedLink) receive(size int, srcAddr *net.UDPAddr, p *router.Packet
 	// The src address does not need to be recorded in the packet. The link has all the relevant
 	// information.
 
-	procID, ok := computeProcID(p.RawPacket, len(l.procQs), l.seed)
+	procID, ok := procid.Compute(p.RawPacket, len(l.procQs), l.seed)
 	if !ok {
 		l.pool.Put(p)
-		metrics[sc].DroppedPacketsInvalid.Inc()
+		metrics[sc].DroppedPackets.Inc(router.DropInvalid)
 		return
 	}
 	select {
 	case l.procQs[procID] <- p:
 	default:
 		l.pool.Put(p)
-		metrics[sc].DroppedPacketsBusyProcessor.Inc()
+		metrics[sc].DroppedPackets.Inc(router.DropBusyProcessor)
 	}
 }
 
@@ -666,7 +744,7 @@ func (u *provider) newDetachedLink(
 		remote:     net.UDPAddrFromAddrPort(remoteAddr),
 		seed:       u.internalHashSeed,
 	}
-	c.links[remoteAddr] = sl
+	c.addLink(remoteAddr, sl)
 	u.allLinks[remoteAddr] = sl
 	return sl, nil
 }
@@ -753,17 +831,17 @@ func (l *detachedLink) receive(size int, srcAddr *net.UDPAddr, p *router.Packet)
 	// The src address does not need to be recorded in the packet. The link has all the relevant
 	// information.
 
-	procID, ok := computeProcID(p.RawPacket, len(l.procQs), l.seed)
+	procID, ok := procid.Compute(p.RawPacket, len(l.procQs), l.seed)
 	if !ok {
 		l.pool.Put(p)
-		metrics[sc].DroppedPacketsInvalid.Inc()
+		metrics[sc].DroppedPackets.Inc(router.DropInvalid)
 		return
 	}
 	select {
 	case l.procQs[procID] <- p:
 	default:
 		l.pool.Put(p)
-		metrics[sc].DroppedPacketsBusyProcessor.Inc()
+		metrics[sc].DroppedPackets.Inc(router.DropBusyProcessor)
 	}
 }
 
@@ -806,7 +884,7 @@ func (u *provider) NewInternalLink(
 	if err != nil {
 		return nil, err
 	}
-	u.internalHashSeed = makeHashSeed()
+	u.internalHashSeed = procid.MakeHashSeed()
 	queue := make(chan *router.Packet, qSize)
 	il := &internalLink{
 		egressQ:          queue,
@@ -832,7 +910,7 @@ func (u *provider) NewInternalLink(
 	if !u.connOpener.UDPCanReuseLocal() {
 		// In this case we will share this connection with sibling links, so the connection has a
 		// demux map.
-		c.links = make(map[netip.AddrPort]udpLink)
+		c.links.Store(&map[netip.AddrPort]udpLink{})
 	}
 
 	u.allLinks[netip.AddrPort{}] = il
@@ -873,20 +951,20 @@ func (l *internalLink) runProcessor() {
 			if err != nil {
 				log.Debug("Error processing packet", "err", err)
 				sc := router.ClassOfSize(len(p.RawPacket))
-				l.metrics[sc].DroppedPacketsInvalid.Inc()
+				l.metrics[sc].DroppedPackets.Inc(router.DropInvalid)
 				l.pool.Put(p)
 				continue
 			}
 			egressLink := p.Link
 			if egressLink == nil {
 				sc := router.ClassOfSize(len(p.RawPacket))
-				l.metrics[sc].DroppedPacketsInvalid.Inc()
+				l.metrics[sc].DroppedPackets.Inc(router.DropInvalid)
 				l.pool.Put(p)
 				continue
 			}
 			if !egressLink.Send(p) {
 				sc := router.ClassOfSize(len(p.RawPacket))
-				l.metrics[sc].DroppedPacketsBusyForwarder.Inc()
+				l.metrics[sc].DroppedPackets.Inc(router.DropBusyForwarder)
 				l.pool.Put(p)
 				continue
 			}
@@ -895,7 +973,7 @@ func (l *internalLink) runProcessor() {
 				select {
 				case p := <-l.procQ:
 					sc := router.ClassOfSize(len(p.RawPacket))
-					l.metrics[sc].DroppedPacketsBusyProcessor.Inc()
+					l.metrics[sc].DroppedPackets.Inc(router.DropBusyProcessor)
 					l.pool.Put(p)
 				default:
 					close(l.procDone)
@@ -1020,7 +1098,7 @@ func (l *internalLink) receive(size int, srcAddr *net.UDPAddr, p *router.Packet)
 	p.RemoteAddr = unsafe.Pointer(srcAddr)
 
 	var q chan *router.Packet
-	procID, ok := computeProcID(p.RawPacket, len(l.procQs), l.seed)
+	procID, ok := procid.Compute(p.RawPacket, len(l.procQs), l.seed)
 	if ok {
 		q = l.procQs[procID]
 	} else {
@@ -1030,50 +1108,6 @@ func (l *internalLink) receive(size int, srcAddr *net.UDPAddr, p *router.Packet)
 	case q <- p:
 	default:
 		l.pool.Put(p)
-		metrics[sc].DroppedPacketsBusyProcessor.Inc()
-	}
-}
-
-// computeProcID computes the processor ID for a given packet provided by the slice data. It assumes
-// that numProcRoutines is non-negative and not larger than 4294967295. hashSeed is used for hash
-// computation. If data is clearly not a valid SCION packet, it returns ok=false. Otherwise, it
-// returns a processor ID smaller than numProcRoutines and ok=true.
-// Specifically for STUN packets, the check for valid SCION packets fails since the part of the STUN
-// header that overlaps with the SCION common header NextHdr field contains value 0x21, which is not
-// a valid L4 protocol type. Therefore, STUN packets will always result in ok=false.
-// If we ever have a protocol type assigned to value 0x21, we need to revisit this function.
-func computeProcID(data []byte, numProcRoutines int, hashSeed uint32) (uint32, bool) {
-	if len(data) < slayers.CmnHdrLen {
-		return uint32(numProcRoutines), false
-	}
-
-	switch slayers.L4ProtocolType(data[4]) {
-	case slayers.L4TCP, slayers.L4UDP, slayers.L4SCMP, slayers.L4BFD,
-		slayers.HopByHopClass, slayers.End2EndClass,
-		slayers.ExperimentationAndTesting, slayers.ExperimentationAndTesting2:
-	default:
-		return uint32(numProcRoutines), false
-	}
-
-	dstHostAddrLen := slayers.AddrType(data[9] >> 4 & 0xf).Length()
-	srcHostAddrLen := slayers.AddrType(data[9] & 0xf).Length()
-	addrHdrLen := 2*addr.IABytes + srcHostAddrLen + dstHostAddrLen
-	if len(data) < slayers.CmnHdrLen+addrHdrLen {
-		return uint32(numProcRoutines), false
+		metrics[sc].DroppedPackets.Inc(router.DropBusyProcessor)
 	}
-
-	s := hashSeed
-
-	// inject the flowID
-	s = hashFNV1a(s, data[1]&0xF) // The left 4 bits aren't part of the flowID.
-	for _, c := range data[2:4] {
-		s = hashFNV1a(s, c)
-	}
-
-	// Inject the src/dst addresses
-	for _, c := range data[slayers.CmnHdrLen : slayers.CmnHdrLen+addrHdrLen] {
-		s = hashFNV1a(s, c)
-	}
-
-	return s % uint32(numProcRoutines), true
 }
@@ -54,11 +54,13 @@ func Cmd(pather command.Pather) *cobra.Command {
 		newValidateCmd(joined),
 		newVerifyCmd(joined),
 		newRenewCmd(joined),
+		newEnrollCmd(joined),
 		newMatchCmd(joined),
 		newSignCmd(joined),
 		newFingerprintCmd(joined),
 		newInspectCmd(joined),
 		newTemplateCmd(joined),
+		newExpiryCmd(joined),
 	)
 	return cmd
 }
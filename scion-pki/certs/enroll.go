@@ -0,0 +1,345 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certs
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/private/app"
+	"github.com/scionproto/scion/private/app/command"
+	"github.com/scionproto/scion/private/app/flag"
+	"github.com/scionproto/scion/private/app/path"
+	"github.com/scionproto/scion/private/ca/renewal"
+	"github.com/scionproto/scion/private/tracing"
+	"github.com/scionproto/scion/scion-pki/file"
+	"github.com/scionproto/scion/scion-pki/key"
+)
+
+func newEnrollCmd(pather command.Pather) *cobra.Command {
+	var envFlags flag.SCIONEnvironment
+	var flags struct {
+		token      string
+		subject    string
+		commonName string
+		trcFiles   []string
+		ca         []string
+		remotes    []string
+		curve      string
+
+		timeout  time.Duration
+		tracer   string
+		logLevel string
+
+		force bool
+
+		interactive bool
+		noColor     bool
+		refresh     bool
+		noProbe     bool
+		sequence    string
+	}
+	cmd := &cobra.Command{
+		Use:   "enroll [flags] <chain-file> <key-file>",
+		Short: "Request the initial AS certificate with an enrollment token",
+		Example: fmt.Sprintf(
+			`  %[1]s enroll --trc ISD1-B1-S1.trc --token $TOKEN --subject subject.json \
+  	--ca 1-ff00:0:110 cp-as.pem cp-as.key
+  %[1]s enroll --trc ISD1-B1-S1.trc --token $TOKEN --subject subject.json \
+  	--remote 1-ff00:0:110,10.0.0.3 cp-as.pem cp-as.key
+`, pather.CommandPath()),
+		Long: `'enroll' requests the initial AS certificate from a remote CA control service.
+
+Unlike 'renew', this command does not require an existing certificate chain.
+The request is authenticated with a single-use enrollment token instead. The
+token is bound to the ISD-AS of the enrolling AS, and is created by the operator
+of the CA through the management API of the CA control service.
+
+A fresh private key is created for the request. The subject of the CSR is
+created from the template that is specified with the \--subject flag. The
+ISD-AS in the template must match the ISD-AS that the token is bound to.
+
+The target CA for the request must be specified either with the \--ca flag or
+the \--remote flag. Note that the token is consumed by the first CA that
+accepts it, even if it does not issue a certificate chain afterwards. Thus,
+multiple CAs are only useful if they share the same enrollment token store.
+
+The TRCs are used to validate and verify the issued certificate chain. The
+certificate chain and the private key are only written to <chain-file> and
+<key-file>, if the certificate chain is verifiable with any of the active TRCs.
+
+Files are not allowed to be overwritten, unless the \--force flag is set.
+
+The template is expressed in JSON. A valid example::
+` + subjectHelp,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			certFile := args[0]
+			keyFile := args[1]
+			printErr := func(f string, ctx ...any) {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), f, ctx...)
+			}
+			printf := func(f string, ctx ...any) {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), f, ctx...)
+			}
+
+			if len(flags.ca) > 0 && len(flags.remotes) > 0 {
+				return serrors.New("--ca and --remote must not both be set")
+			}
+			if len(flags.ca) == 0 && len(flags.remotes) == 0 {
+				return serrors.New("either --ca or --remote must be set")
+			}
+
+			cmd.SilenceUsage = true
+
+			opts := []file.Option{file.WithForce(flags.force)}
+
+			// Set up observability tooling.
+			if err := app.SetupLog(flags.logLevel); err != nil {
+				return err
+			}
+			closer, err := setupTracer("scion-pki", flags.tracer)
+			if err != nil {
+				return serrors.Wrap("setting up tracing", err)
+			}
+			defer closer()
+
+			span, ctx := tracing.CtxWith(cmd.Context(), "certificate.enroll")
+			defer span.Finish()
+
+			if err := envFlags.LoadExternalVars(); err != nil {
+				return err
+			}
+			daemonAddr := envFlags.Daemon()
+			localIP := net.IP(envFlags.Local().AsSlice())
+			log.Debug("Resolved SCION environment flags",
+				"daemon", daemonAddr,
+				"local", localIP,
+			)
+
+			// Setup basic state.
+			daemonCtx, daemonCancel := context.WithTimeout(ctx, time.Second)
+			defer daemonCancel()
+			sd, err := daemon.NewService(daemonAddr).Connect(daemonCtx)
+			if err != nil {
+				return serrors.Wrap("connecting to SCION Daemon", err)
+			}
+			defer func() { _ = sd.Close() }()
+
+			info, err := app.QueryASInfo(daemonCtx, sd)
+			if err != nil {
+				return err
+			}
+			span.SetTag("src.isd_as", info.IA)
+
+			trcs, err := loadTRCs(flags.trcFiles)
+			if err != nil {
+				return err
+			}
+
+			subject, err := createSubject(flags.subject, flags.commonName, true)
+			if err != nil {
+				return err
+			}
+			subjectIA, err := cppki.ExtractIA(subject)
+			if err != nil {
+				return serrors.Wrap("extracting ISD-AS from subject", err)
+			}
+			if subjectIA != info.IA {
+				return serrors.New("subject does not match local AS",
+					"subject", subjectIA, "local", info.IA)
+			}
+
+			var cas []addr.IA
+			var remotes []*snet.UDPAddr
+			switch {
+			case len(flags.ca) > 0:
+				for _, raw := range flags.ca {
+					ca, err := addr.ParseIA(raw)
+					if err != nil {
+						return serrors.Wrap("parsing CA", err)
+					}
+					cas = append(cas, ca)
+				}
+			default:
+				for _, raw := range flags.remotes {
+					addr, err := snet.ParseUDPAddr(raw)
+					if err != nil {
+						return serrors.Wrap("parsing remote", err)
+					}
+					remotes = append(remotes, addr)
+				}
+			}
+			span.SetTag("ca-options", cas)
+			span.SetTag("remote-options", remotes)
+
+			priv, err := key.GeneratePrivateKey(flags.curve)
+			if err != nil {
+				return serrors.Wrap("creating private key", err)
+			}
+			pemPriv, err := key.EncodePEMPrivateKey(priv)
+			if err != nil {
+				return serrors.Wrap("encoding private key", err)
+			}
+			csr, err := CreateCSR(cppki.AS, subject, priv)
+			if err != nil {
+				return serrors.Wrap("creating CSR", err)
+			}
+			req := renewal.NewEnrollmentRequest(csr, flags.token)
+
+			r := renewer{
+				LocalIA: info.IA,
+				LocalIP: localIP,
+				Daemon:  sd,
+				Timeout: flags.timeout,
+				StdErr:  cmd.ErrOrStderr(),
+				PathOptions: func() []path.Option {
+					pathOpts := []path.Option{
+						path.WithInteractive(flags.interactive),
+						path.WithRefresh(flags.refresh),
+						path.WithSequence(flags.sequence),
+						path.WithColorScheme(path.DefaultColorScheme(flags.noColor)),
+					}
+					if !flags.noProbe {
+						pathOpts = append(pathOpts, path.WithProbing(&path.ProbeConfig{
+							LocalIA: info.IA,
+							LocalIP: localIP,
+						}))
+					}
+					return pathOpts
+				},
+			}
+
+			request := func(ca addr.IA, remote net.Addr) ([]*x509.Certificate, error) {
+				printf("Attempt enrollment with %s\n", ca)
+
+				span, ctx := tracing.CtxWith(ctx, "request")
+				span.SetTag("dst.isd_as", ca)
+
+				chain, err := r.Request(ctx, req, remote, ca)
+				if err != nil {
+					printErr("Sending request failed: %s\n", err)
+					return nil, err
+				}
+				verifyOptions := cppki.VerifyOptions{TRC: trcs}
+				if err := cppki.VerifyChain(chain, verifyOptions); err != nil {
+					printErr("Verification failed: %s\n", err)
+					return nil, serrors.Wrap("verification failed", err)
+				}
+				return chain, nil
+			}
+
+			var issued []*x509.Certificate
+			switch {
+			case len(cas) > 0:
+				for _, ca := range cas {
+					chain, err := request(ca, &snet.SVCAddr{SVC: addr.SvcCS})
+					if err != nil {
+						continue
+					}
+					issued = chain
+					break
+				}
+			default:
+				for _, remote := range remotes {
+					chain, err := request(remote.IA, remote)
+					if err != nil {
+						continue
+					}
+					issued = chain
+					break
+				}
+			}
+			if issued == nil {
+				return serrors.New("failed to request certificate chain")
+			}
+
+			if err := file.WriteFile(keyFile, pemPriv, 0o600, opts...); err != nil {
+				return serrors.Wrap("writing private key", err)
+			}
+			printf("Private key successfully written to %q\n", keyFile)
+			if err := file.WriteFile(certFile, encodeChain(issued), 0o644, opts...); err != nil {
+				return serrors.Wrap("writing certificate chain", err)
+			}
+			printf("Certificate chain successfully written to %q\n", certFile)
+			return nil
+		},
+	}
+
+	envFlags.Register(cmd.Flags())
+	cmd.Flags().StringVar(&flags.token, "token", "",
+		"The enrollment token issued by the CA (required)",
+	)
+	cmd.Flags().StringVar(&flags.subject, "subject", "",
+		"The path to the subject template for the CSR (required)",
+	)
+	cmd.Flags().StringVar(&flags.commonName, "common-name", "",
+		"The common name that replaces the common name in the subject template",
+	)
+	cmd.Flags().StringSliceVar(&flags.trcFiles, "trc", []string{},
+		"Comma-separated list of trusted TRC files or glob patterns. "+
+			"If more than two TRCs are specified,\n only up to two active TRCs "+
+			"with the highest Base version are used (required)",
+	)
+	cmd.Flags().StringSliceVar(&flags.ca, "ca", nil,
+		"Comma-separated list of ISD-AS identifiers of target CAs.\n"+
+			"The CAs are tried in order until success or all of them failed.\n"+
+			"--ca is mutually exclusive with --remote",
+	)
+	cmd.Flags().StringArrayVar(&flags.remotes, "remote", nil,
+		"The remote CA address to use for enrollment.\n"+
+			"The address is of the form <ISD-AS>,<IP>. --remote can be specified multiple times\n"+
+			"and all specified remotes are tried in order until success or all of them failed.\n"+
+			"--remote is mutually exclusive with --ca.",
+	)
+	cmd.Flags().StringVar(&flags.curve, "curve", "P-256",
+		"The elliptic curve to use (P-256|P-384|P-521)",
+	)
+	cmd.Flags().BoolVar(&flags.force, "force", false,
+		"Force overwriting existing files",
+	)
+	cmd.Flags().DurationVar(&flags.timeout, "timeout", 10*time.Second,
+		"The timeout for the enrollment request per CA",
+	)
+	cmd.Flags().StringVar(&flags.tracer, "tracing.agent", "",
+		"The tracing agent address",
+	)
+	cmd.Flags().StringVar(&flags.logLevel, "log.level", "", app.LogLevelUsage)
+
+	cmd.Flags().BoolVarP(&flags.interactive, "interactive", "i", false, "interactive mode")
+	cmd.Flags().BoolVar(&flags.noColor, "no-color", false, "disable colored output")
+	cmd.Flags().StringVar(&flags.sequence, "sequence", "", app.SequenceUsage)
+	cmd.Flags().BoolVar(&flags.noProbe, "no-probe", false, "do not probe paths for health")
+	cmd.Flags().BoolVar(&flags.refresh, "refresh", false, "set refresh flag for path request")
+
+	for _, f := range []string{"token", "subject", "trc"} {
+		if err := cmd.MarkFlagRequired(f); err != nil {
+			panic(err)
+		}
+	}
+
+	return cmd
+}
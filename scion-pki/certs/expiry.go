@@ -0,0 +1,131 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certs
+
+import (
+	"fmt"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/private/app"
+	"github.com/scionproto/scion/private/app/command"
+	"github.com/scionproto/scion/private/app/flag"
+	"github.com/scionproto/scion/private/trust/expiry"
+)
+
+func newExpiryCmd(pather command.Pather) *cobra.Command {
+	now := time.Now()
+	var flags struct {
+		currentTime flag.Time
+	}
+	flags.currentTime = flag.Time{
+		Time:    now,
+		Current: now,
+	}
+	cmd := &cobra.Command{
+		Use:   "expiry [flags] <dir> [<dir>...]",
+		Short: "Report the expiration of the certificates and TRCs in a directory",
+		Long: `'expiry' reports how long the certificates and TRCs in the provided
+directories remain valid.
+
+The directories are searched recursively for certificates (*.pem, *.crt) and
+TRCs (*.trc). For every type of trust material and subject, only the entry that
+expires last is reported, i.e., renewed certificates supersede their
+predecessors. Files that do not contain valid trust material are skipped with a
+warning.
+
+The remaining validity is classified with the same default thresholds that the
+control service and the daemon use to monitor their trust material:
+
+  cp-as:                      warning 1d,  critical 6h
+  cp-ca:                      warning 7d,  critical 2d
+  cp-root, voting, trc:       warning 30d, critical 7d
+
+The command exits with code 1 if any entry is critical or has expired.
+`,
+		Example: fmt.Sprintf(`  %[1]s expiry gen/ASff00_0_110/crypto
+  %[1]s expiry --current-time 30d gen/ASff00_0_110/crypto gen/certs`,
+			pather.CommandPath()),
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			current := flags.currentTime.Time
+			var entries []expiry.Entry
+			for _, dir := range args {
+				res, err := expiry.LoadDir(dir, current)
+				if err != nil {
+					return err
+				}
+				ignored := make([]string, 0, len(res.Ignored))
+				for file := range res.Ignored {
+					ignored = append(ignored, file)
+				}
+				sort.Strings(ignored)
+				for _, file := range ignored {
+					fmt.Fprintf(cmd.ErrOrStderr(), "WARNING: skipping %q: %s\n",
+						file, res.Ignored[file])
+				}
+				entries = append(entries, res.Entries...)
+			}
+			results := expiry.DefaultPolicy().Evaluate(expiry.Latest(entries), current)
+			if err := printExpiry(cmd, results); err != nil {
+				return err
+			}
+			switch worst := expiry.Worst(results); worst {
+			case expiry.LevelCritical, expiry.LevelExpired:
+				return app.WithExitCode(
+					serrors.New("trust material expired or about to expire", "level", worst),
+					1,
+				)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().Var(&flags.currentTime, "current-time",
+		`The time at which the remaining validity is evaluated.
+Can either be a timestamp or an offset.
+
+If the value is a timestamp, it is expected to either be an RFC 3339 formatted
+timestamp or a unix timestamp. If the value is a duration, it is used as the
+offset from the current time.`,
+	)
+	return cmd
+}
+
+func printExpiry(cmd *cobra.Command, results []expiry.Result) error {
+	if len(results) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No trust material found.")
+		return nil
+	}
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tSUBJECT\tID\tNOT AFTER\tREMAINING\tLEVEL\tFILE")
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.Kind,
+			r.Subject,
+			r.ID,
+			r.NotAfter.UTC().Format(time.RFC3339),
+			r.Remaining.Truncate(time.Second),
+			r.Level,
+			r.Source,
+		)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certs
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/private/app/command"
+)
+
+func TestExpiryCmd(t *testing.T) {
+	testCases := map[string]struct {
+		Args         []string
+		Levels       map[string]string
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"all valid": {
+			Args: []string{"--current-time", "2021-01-01T00:00:00Z", "testdata/renew"},
+			Levels: map[string]string{
+				"cp-as 1-ff00:0:110": "ok",
+				"cp-as 1-ff00:0:111": "ok",
+				"cp-ca 1-ff00:0:110": "ok",
+				"trc 1":              "ok",
+			},
+			ErrAssertion: assert.NoError,
+		},
+		"warning": {
+			Args: []string{"--current-time", "2021-05-27T12:00:00Z", "testdata/renew"},
+			Levels: map[string]string{
+				"cp-as 1-ff00:0:110": "ok",
+				"cp-as 1-ff00:0:111": "warning",
+				"cp-ca 1-ff00:0:110": "ok",
+				"trc 1":              "ok",
+			},
+			ErrAssertion: assert.NoError,
+		},
+		"critical": {
+			Args: []string{"--current-time", "2021-05-28T10:00:00Z", "testdata/renew"},
+			Levels: map[string]string{
+				"cp-as 1-ff00:0:110": "ok",
+				"cp-as 1-ff00:0:111": "critical",
+				"cp-ca 1-ff00:0:110": "ok",
+				"trc 1":              "ok",
+			},
+			ErrAssertion: assert.Error,
+		},
+		"expired": {
+			Args: []string{"--current-time", "2021-08-22T00:00:00Z", "testdata/renew"},
+			Levels: map[string]string{
+				"cp-as 1-ff00:0:110": "ok",
+				"cp-as 1-ff00:0:111": "expired",
+				"cp-ca 1-ff00:0:110": "ok",
+				"trc 1":              "expired",
+			},
+			ErrAssertion: assert.Error,
+		},
+		"empty directory": {
+			Args:         []string{t.TempDir()},
+			Levels:       map[string]string{},
+			ErrAssertion: assert.NoError,
+		},
+		"missing directory": {
+			Args:         []string{"testdata/missing"},
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cmd := newExpiryCmd(command.StringPather("test"))
+			cmd.SetArgs(tc.Args)
+			out, stderr := new(bytes.Buffer), new(bytes.Buffer)
+			cmd.SetOut(out)
+			cmd.SetErr(stderr)
+
+			err := cmd.Execute()
+			tc.ErrAssertion(t, err)
+			if tc.Levels == nil {
+				return
+			}
+			levels := map[string]string{}
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			for _, line := range lines[1:] {
+				fields := strings.Fields(line)
+				require.Len(t, fields, 7)
+				levels[fields[0]+" "+fields[1]] = fields[5]
+			}
+			assert.Equal(t, tc.Levels, levels)
+		})
+	}
+}
@@ -106,36 +106,41 @@ type SubjectVars struct {
 
 type Features struct{}
 
+// renewFlags are the flags of the renew command.
+type renewFlags struct {
+	out        string
+	outKey     string
+	outCSR     string
+	outCMS     string
+	subject    string
+	commonName string
+	trcFiles   []string
+	reuseKey   bool
+	ca         []string
+	remotes    []string
+	curve      string
+	expiresIn  string
+
+	timeout  time.Duration
+	tracer   string
+	logLevel string
+
+	force    bool
+	backup   bool
+	features []string
+
+	interactive bool
+	noColor     bool
+	refresh     bool
+	noProbe     bool
+	sequence    string
+
+	daemon renewalDaemonFlags
+}
+
 func newRenewCmd(pather command.Pather) *cobra.Command {
 	var envFlags flag.SCIONEnvironment
-	var flags struct {
-		out        string
-		outKey     string
-		outCSR     string
-		outCMS     string
-		subject    string
-		commonName string
-		trcFiles   []string
-		reuseKey   bool
-		ca         []string
-		remotes    []string
-		curve      string
-		expiresIn  string
-
-		timeout  time.Duration
-		tracer   string
-		logLevel string
-
-		force    bool
-		backup   bool
-		features []string
-
-		interactive bool
-		noColor     bool
-		refresh     bool
-		noProbe     bool
-		sequence    string
-	}
+	var flags renewFlags
 	cmd := &cobra.Command{
 		Use:   "renew [flags] <chain-file> <key-file>",
 		Short: "Renew an AS certificate",
@@ -147,6 +152,8 @@ func newRenewCmd(pather command.Pather) *cobra.Command {
   %[1]s renew --trc ISD1-B1-S1.trc --backup --ca 1-ff00:0:110,1-ff00:0:120 cp-as.pem cp-as.key
   %[1]s renew --trc ISD1-B1-S1.trc --backup \
   	--remote 1-ff00:0:110,10.0.0.3 --remote 1-ff00:0:120,172.30.200.2 cp-as.pem cp-as.key
+  %[1]s renew --trc ISD1-B1-S1.trc --force --daemon --expires-in 0.5 \
+  	--signal SIGHUP --pid-file /run/scion/control.pid cp-as.pem cp-as.key
 `, pather.CommandPath()),
 		Long: `'renew' requests a renewed AS certificate from a remote CA control service.
 
@@ -193,6 +200,24 @@ number. For example, a factor of 0.75 indicates that the certificate chain
 should be renewed after one quarter of its lifetime has passed, and it still
 has three quarters of its validity period until it expires.
 
+With the \--daemon flag, the command does not exit after the first run.
+Instead, it keeps watching the certificate chain in <chain-file> and renews it
+whenever the \--expires-in threshold is reached. If \--expires-in is not
+specified, the chain is renewed once a third of its validity period remains.
+The chain is checked at least every \--check-interval. Failed renewals are
+retried with exponential backoff, starting at \--backoff and capped at
+\--max-backoff. In each run, all CAs or remotes are tried in order.
+The renewed certificate chain and private key replace the existing files
+atomically and together, i.e., either both or neither of them are replaced.
+Daemon mode requires the \--force or \--backup flag. The \--out and \--out-key
+flags are not supported in daemon mode.
+
+After every successful renewal, the shell command provided with \--exec is
+run, and the signal provided with \--signal is sent to the process whose PID
+is stored in \--pid-file. This can be used to make a service pick up the
+renewed certificate chain. With the \--metrics flag, the state of the renewal
+daemon is exposed as Prometheus metrics.
+
 Unless a subject template is specified, the subject of the existing certificate
 chain is used as the subject for the renewal request.
 
@@ -202,12 +227,6 @@ The template is expressed in JSON. A valid example::
 		RunE: func(cmd *cobra.Command, args []string) error {
 			certFile := args[0]
 			keyFile := args[1]
-			printErr := func(f string, ctx ...any) {
-				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), f, ctx...)
-			}
-			printf := func(f string, ctx ...any) {
-				_, _ = fmt.Fprintf(cmd.OutOrStdout(), f, ctx...)
-			}
 
 			expiryChecker, err := parseExpiresIn(flags.expiresIn)
 			if err != nil {
@@ -217,6 +236,13 @@ The template is expressed in JSON. A valid example::
 			if len(flags.ca) > 0 && len(flags.remotes) > 0 {
 				return serrors.New("--ca and --remote must not both be set")
 			}
+			err = flags.daemon.validate(flags.out, flags.outKey, flags.force, flags.backup)
+			if err != nil {
+				return err
+			}
+			if flags.daemon.enabled && flags.expiresIn == "" {
+				expiryChecker.factor = defaultDaemonExpiresIn
+			}
 
 			cmd.SilenceUsage = true
 
@@ -238,13 +264,6 @@ The template is expressed in JSON. A valid example::
 
 			}
 
-			opts := []file.Option{file.WithForce(flags.force)}
-			if flags.backup {
-				opts = append(opts,
-					file.WithBackup(time.Now().Local().Format("2006-01-02-15-04-05")),
-				)
-			}
-
 			// Set up observability tooling.
 			if err := app.SetupLog(flags.logLevel); err != nil {
 				return err
@@ -255,8 +274,7 @@ The template is expressed in JSON. A valid example::
 			}
 			defer closer()
 
-			span, ctx := tracing.CtxWith(cmd.Context(), "certificate.renew")
-			defer span.Finish()
+			ctx := cmd.Context()
 
 			if err := envFlags.LoadExternalVars(); err != nil {
 				return err
@@ -268,276 +286,21 @@ The template is expressed in JSON. A valid example::
 				"local", localIP,
 			)
 
-			// Setup basic state.
-			daemonCtx, daemonCancel := context.WithTimeout(ctx, time.Second)
-			defer daemonCancel()
-			sd, err := daemon.NewService(daemonAddr).Connect(daemonCtx)
-			if err != nil {
-				return serrors.Wrap("connecting to SCION Daemon", err)
-			}
-			defer func() { _ = sd.Close() }()
-
-			info, err := app.QueryASInfo(daemonCtx, sd)
-			if err != nil {
-				return err
-			}
-			span.SetTag("src.isd_as", info.IA)
-
-			// Load cryptographic material
-			trcs, err := loadTRCs(flags.trcFiles)
-			if err != nil {
-				return err
+			renewal := &chainRenewal{
+				flags:         &flags,
+				certFile:      certFile,
+				keyFile:       keyFile,
+				expiryChecker: expiryChecker,
+				daemonAddr:    daemonAddr,
+				localIP:       localIP,
+				stdout:        cmd.OutOrStdout(),
+				stderr:        cmd.ErrOrStderr(),
 			}
-			chain, err := loadChain(trcs, certFile)
-			if err != nil {
+			if !flags.daemon.enabled {
+				_, err := renewal.Run(ctx)
 				return err
 			}
-
-			if nb, na := chain[0].NotBefore, chain[0].NotAfter; !expiryChecker.ShouldRenew(nb, na) {
-				printf("Skipping renewal, --expires-in threshold is not reached.\n")
-				printf("AS certificate validity:\n")
-				printf("    NotBefore: %s\n", nb)
-				printf("    NotAfter:  %s\n", na)
-				return nil
-			}
-
-			var cas []addr.IA
-			var remotes []*snet.UDPAddr
-			switch {
-			case len(flags.ca) > 0:
-				for _, raw := range flags.ca {
-					ca, err := addr.ParseIA(raw)
-					if err != nil {
-						return serrors.Wrap("parsing CA", err)
-					}
-					cas = append(cas, ca)
-				}
-			case len(flags.remotes) > 0:
-				for _, raw := range flags.remotes {
-					addr, err := snet.ParseUDPAddr(raw)
-					if err != nil {
-						return serrors.Wrap("parsing remote", err)
-					}
-					remotes = append(remotes, addr)
-				}
-			default:
-				ia, err := cppki.ExtractIA(chain[0].Issuer)
-				if err != nil {
-					panic(fmt.Sprintf("extracting ISD-AS from verified chain: %s", err))
-				}
-				printf("Extracted issuer from certificate chain: %s\n", ia)
-				cas = []addr.IA{ia}
-			}
-			span.SetTag("ca-options", cas)
-			span.SetTag("remote-options", remotes)
-
-			// Load private key.
-			// XXX(roosd): The renewal process does currently not support KMS.
-			// This is a bit more involved, and requires some refactoring of the
-			// flags and the key loading/creation process. For now, KMS is also
-			// not a direct use-case for AS certificates.
-			privPrev, err := key.LoadPrivateKey("", keyFile)
-			if err != nil {
-				return serrors.Wrap("reading private key", err)
-			}
-			privNext := key.PrivateKey(privPrev)
-
-			// Create fresh private key, unless requested otherwise. Encode it
-			// to PEM here to catch problems early on.
-			var pemPrivNext []byte
-			if !flags.reuseKey {
-				if privNext, err = key.GeneratePrivateKey(flags.curve); err != nil {
-					return serrors.Wrap("creating fresh private key", err)
-				}
-				if pemPrivNext, err = key.EncodePEMPrivateKey(privNext); err != nil {
-					return serrors.Wrap("encoding fresh private key", err)
-				}
-			}
-
-			template := certFile
-			if flags.subject != "" {
-				template = flags.subject
-			}
-			subject, err := createSubject(template, flags.commonName, true)
-			if err != nil {
-				return err
-			}
-
-			csr, err := CreateCSR(cppki.AS, subject, privNext)
-			if err != nil {
-				return serrors.Wrap("creating CSR", err)
-			}
-			if flags.outCSR != "" {
-				pemCSR := pem.EncodeToMemory(&pem.Block{
-					Type:  "CERTIFICATE REQUEST",
-					Bytes: csr,
-				})
-				err = file.WriteFile(flags.outCSR, pemCSR, 0o666, opts...)
-				if err != nil {
-					// The CSR is not important, carry on with execution.
-					printErr("Failed to write CSR: %s\n", err.Error())
-				}
-			}
-
-			// Sign the request.
-			algo, err := signed.SelectSignatureAlgorithm(privPrev.Public())
-			if err != nil {
-				return err
-			}
-			signer := trust.Signer{
-				PrivateKey:   privPrev,
-				Algorithm:    algo,
-				IA:           info.IA,
-				TRCID:        trcs[0].ID,
-				SubjectKeyID: chain[0].SubjectKeyId,
-				Expiration:   time.Now().Add(2 * time.Hour),
-				ChainValidity: cppki.Validity{
-					NotBefore: chain[0].NotBefore,
-					NotAfter:  chain[0].NotAfter,
-				},
-				Subject: chain[0].Subject,
-				Chain:   chain,
-			}
-			var req cppb.ChainRenewalRequest
-			cmsReq, err := renewal.NewChainRenewalRequest(ctx, csr, signer)
-			if err != nil {
-				return err
-			}
-			req.CmsSignedRequest = cmsReq.CmsSignedRequest
-			if flags.outCMS != "" {
-				if req.CmsSignedRequest == nil {
-					return serrors.New("cannot write request to file: no request created")
-				}
-				pemReq := pem.EncodeToMemory(&pem.Block{
-					Type:  "CMS",
-					Bytes: req.CmsSignedRequest,
-				})
-				err = file.WriteFile(flags.outCMS, pemReq, 0o666, opts...)
-				if err != nil {
-					// The CMS request is not important, carry on with execution.
-					printErr("Failed to write CMS request: %s\n", err.Error())
-				}
-			}
-
-			r := renewer{
-				LocalIA: info.IA,
-				LocalIP: localIP,
-				Daemon:  sd,
-				Timeout: flags.timeout,
-				StdErr:  cmd.ErrOrStderr(),
-				PathOptions: func() []path.Option {
-					pathOpts := []path.Option{
-						path.WithInteractive(flags.interactive),
-						path.WithRefresh(flags.refresh),
-						path.WithSequence(flags.sequence),
-						path.WithColorScheme(path.DefaultColorScheme(flags.noColor)),
-					}
-					if !flags.noProbe {
-						pathOpts = append(pathOpts, path.WithProbing(&path.ProbeConfig{
-							LocalIA: info.IA,
-							LocalIP: localIP,
-						}))
-					}
-					return pathOpts
-				},
-			}
-
-			outCertFile, outKeyFile := certFile, keyFile
-			if flags.out != "" {
-				outCertFile = flags.out
-			}
-			if flags.outKey != "" {
-				outKeyFile = flags.outKey
-			}
-
-			request := func(ca addr.IA, remote net.Addr) ([]*x509.Certificate, error) {
-				printf("Attempt certificate renewal with %s\n", ca)
-
-				span, ctx := tracing.CtxWith(ctx, "request")
-				span.SetTag("dst.isd_as", ca)
-
-				chain, err := r.Request(ctx, &req, remote, ca)
-				if err != nil {
-					printErr("Sending request failed: %s\n", err)
-					return nil, err
-				}
-
-				// Verify certificate chain
-				verifyOptions := cppki.VerifyOptions{TRC: trcs}
-				if verifyError := cppki.VerifyChain(chain, verifyOptions); verifyError != nil {
-					suffix := "." + addr.FormatIA(ca, addr.WithFileSeparator()) + ".unverified"
-
-					printErr("Verification failed: %s\n", verifyError)
-
-					// Write chain.
-					certFile := outCertFile + suffix
-					printErr("Writing unverified chain: %q\n", certFile)
-					pem := encodeChain(chain)
-					if err := file.WriteFile(certFile, pem, 0o644, opts...); err != nil {
-						fmt.Println("Failed to write unverified chain: ", err)
-					}
-
-					// Write private key
-					if pemPrivNext != nil {
-						keyFile := outKeyFile + suffix
-						printErr("Writing private key for unverified chain: %q\n", keyFile)
-						if err := file.WriteFile(keyFile, pemPrivNext, 0o600, opts...); err != nil {
-							fmt.Println("Failed to write private key for unverified chain: ", err)
-						}
-					}
-
-					// Output helpful info in case the TRC is in grace period.
-					if maybeMissingTRCInGrace(trcs) {
-						printErr(
-							"Current time is still in Grace Period of latest TRC.\n"+
-								"Try to verify with the predecessor TRC: "+
-								"(Base = %d, Serial = %d)\n",
-							trcs[0].ID.Base, trcs[0].ID.Serial-1,
-						)
-					}
-					return nil, serrors.Wrap("verification failed", verifyError)
-				}
-				return chain, nil
-			}
-
-			var renewed []*x509.Certificate
-			switch {
-			case len(cas) > 0:
-				for _, ca := range cas {
-					remote := &snet.SVCAddr{SVC: addr.SvcCS}
-					chain, err := request(ca, remote)
-					if err != nil {
-						continue
-					}
-					renewed = chain
-					break
-				}
-			case len(remotes) > 0:
-				for _, remote := range remotes {
-					chain, err := request(remote.IA, remote)
-					if err != nil {
-						continue
-					}
-					renewed = chain
-					break
-				}
-			}
-			if renewed == nil {
-				return serrors.New("failed to request certificate chain")
-			}
-			pemRenewed := encodeChain(renewed)
-
-			if pemPrivNext != nil {
-				if err := file.WriteFile(outKeyFile, pemPrivNext, 0o600, opts...); err != nil {
-					return serrors.Wrap("writing fresh private key", err)
-				}
-				printf("Private key successfully written to %q\n", outKeyFile)
-			}
-			if err := file.WriteFile(outCertFile, pemRenewed, 0o644, opts...); err != nil {
-				return serrors.Wrap("writing renewed certificate chain", err)
-			}
-			printf("Certificate chain successfully written to %q\n", outCertFile)
-			return nil
+			return runRenewalDaemon(ctx, cmd, renewal.Run, expiryChecker, flags.daemon)
 		},
 	}
 
@@ -607,6 +370,7 @@ The template is expressed in JSON. A valid example::
 	cmd.Flags().StringVar(&flags.sequence, "sequence", "", app.SequenceUsage)
 	cmd.Flags().BoolVar(&flags.noProbe, "no-probe", false, "do not probe paths for health")
 	cmd.Flags().BoolVar(&flags.refresh, "refresh", false, "set refresh flag for path request")
+	flags.daemon.register(cmd)
 
 	if err := cmd.MarkFlagRequired("trc"); err != nil {
 		panic(err)
@@ -615,6 +379,330 @@ The template is expressed in JSON. A valid example::
 	return cmd
 }
 
+// chainRenewal renews the AS certificate chain in <chain-file> with the private
+// key in <key-file>.
+type chainRenewal struct {
+	flags         *renewFlags
+	certFile      string
+	keyFile       string
+	expiryChecker expiryChecker
+	daemonAddr    string
+	localIP       net.IP
+	stdout        io.Writer
+	stderr        io.Writer
+}
+
+// Run executes a single renewal run. The certificate chain is only renewed if
+// the --expires-in threshold is reached.
+func (c *chainRenewal) Run(ctx context.Context) (renewResult, error) {
+	span, ctx := tracing.CtxWith(ctx, "certificate.renew")
+	defer span.Finish()
+
+	opts := []file.Option{file.WithForce(c.flags.force), file.WithAtomic(true)}
+	if c.flags.backup {
+		opts = append(opts,
+			file.WithBackup(time.Now().Local().Format("2006-01-02-15-04-05")),
+		)
+	}
+
+	// Setup basic state.
+	daemonCtx, daemonCancel := context.WithTimeout(ctx, time.Second)
+	defer daemonCancel()
+	sd, err := daemon.NewService(c.daemonAddr).Connect(daemonCtx)
+	if err != nil {
+		return renewResult{}, serrors.Wrap("connecting to SCION Daemon", err)
+	}
+	defer func() { _ = sd.Close() }()
+
+	info, err := app.QueryASInfo(daemonCtx, sd)
+	if err != nil {
+		return renewResult{}, err
+	}
+	span.SetTag("src.isd_as", info.IA)
+
+	// Load cryptographic material
+	trcs, err := loadTRCs(c.flags.trcFiles)
+	if err != nil {
+		return renewResult{}, err
+	}
+	chain, err := loadChain(trcs, c.certFile)
+	if err != nil {
+		return renewResult{}, err
+	}
+
+	validity := cppki.Validity{
+		NotBefore: chain[0].NotBefore,
+		NotAfter:  chain[0].NotAfter,
+	}
+	if !c.expiryChecker.ShouldRenew(validity.NotBefore, validity.NotAfter) {
+		c.printf("Skipping renewal, --expires-in threshold is not reached.\n")
+		c.printf("AS certificate validity:\n")
+		c.printf("    NotBefore: %s\n", validity.NotBefore)
+		c.printf("    NotAfter:  %s\n", validity.NotAfter)
+		return renewResult{Validity: validity}, nil
+	}
+
+	var cas []addr.IA
+	var remotes []*snet.UDPAddr
+	switch {
+	case len(c.flags.ca) > 0:
+		for _, raw := range c.flags.ca {
+			ca, err := addr.ParseIA(raw)
+			if err != nil {
+				return renewResult{}, serrors.Wrap("parsing CA", err)
+			}
+			cas = append(cas, ca)
+		}
+	case len(c.flags.remotes) > 0:
+		for _, raw := range c.flags.remotes {
+			addr, err := snet.ParseUDPAddr(raw)
+			if err != nil {
+				return renewResult{}, serrors.Wrap("parsing remote", err)
+			}
+			remotes = append(remotes, addr)
+		}
+	default:
+		ia, err := cppki.ExtractIA(chain[0].Issuer)
+		if err != nil {
+			panic(fmt.Sprintf("extracting ISD-AS from verified chain: %s", err))
+		}
+		c.printf("Extracted issuer from certificate chain: %s\n", ia)
+		cas = []addr.IA{ia}
+	}
+	span.SetTag("ca-options", cas)
+	span.SetTag("remote-options", remotes)
+
+	// Load private key.
+	// XXX(roosd): The renewal process does currently not support KMS.
+	// This is a bit more involved, and requires some refactoring of the
+	// flags and the key loading/creation process. For now, KMS is also
+	// not a direct use-case for AS certificates.
+	privPrev, err := key.LoadPrivateKey("", c.keyFile)
+	if err != nil {
+		return renewResult{}, serrors.Wrap("reading private key", err)
+	}
+	privNext := key.PrivateKey(privPrev)
+
+	// Create fresh private key, unless requested otherwise. Encode it
+	// to PEM here to catch problems early on.
+	var pemPrivNext []byte
+	if !c.flags.reuseKey {
+		if privNext, err = key.GeneratePrivateKey(c.flags.curve); err != nil {
+			return renewResult{}, serrors.Wrap("creating fresh private key", err)
+		}
+		if pemPrivNext, err = key.EncodePEMPrivateKey(privNext); err != nil {
+			return renewResult{}, serrors.Wrap("encoding fresh private key", err)
+		}
+	}
+
+	template := c.certFile
+	if c.flags.subject != "" {
+		template = c.flags.subject
+	}
+	subject, err := createSubject(template, c.flags.commonName, true)
+	if err != nil {
+		return renewResult{}, err
+	}
+
+	csr, err := CreateCSR(cppki.AS, subject, privNext)
+	if err != nil {
+		return renewResult{}, serrors.Wrap("creating CSR", err)
+	}
+	if c.flags.outCSR != "" {
+		pemCSR := pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE REQUEST",
+			Bytes: csr,
+		})
+		err = file.WriteFile(c.flags.outCSR, pemCSR, 0o666, opts...)
+		if err != nil {
+			// The CSR is not important, carry on with execution.
+			c.printErr("Failed to write CSR: %s\n", err.Error())
+		}
+	}
+
+	// Sign the request.
+	algo, err := signed.SelectSignatureAlgorithm(privPrev.Public())
+	if err != nil {
+		return renewResult{}, err
+	}
+	signer := trust.Signer{
+		PrivateKey:   privPrev,
+		Algorithm:    algo,
+		IA:           info.IA,
+		TRCID:        trcs[0].ID,
+		SubjectKeyID: chain[0].SubjectKeyId,
+		Expiration:   time.Now().Add(2 * time.Hour),
+		ChainValidity: cppki.Validity{
+			NotBefore: chain[0].NotBefore,
+			NotAfter:  chain[0].NotAfter,
+		},
+		Subject: chain[0].Subject,
+		Chain:   chain,
+	}
+	var req cppb.ChainRenewalRequest
+	cmsReq, err := renewal.NewChainRenewalRequest(ctx, csr, signer)
+	if err != nil {
+		return renewResult{}, err
+	}
+	req.CmsSignedRequest = cmsReq.CmsSignedRequest
+	if c.flags.outCMS != "" {
+		if req.CmsSignedRequest == nil {
+			return renewResult{}, serrors.New(
+				"cannot write request to file: no request created",
+			)
+		}
+		pemReq := pem.EncodeToMemory(&pem.Block{
+			Type:  "CMS",
+			Bytes: req.CmsSignedRequest,
+		})
+		err = file.WriteFile(c.flags.outCMS, pemReq, 0o666, opts...)
+		if err != nil {
+			// The CMS request is not important, carry on with execution.
+			c.printErr("Failed to write CMS request: %s\n", err.Error())
+		}
+	}
+
+	r := renewer{
+		LocalIA: info.IA,
+		LocalIP: c.localIP,
+		Daemon:  sd,
+		Timeout: c.flags.timeout,
+		StdErr:  c.stderr,
+		PathOptions: func() []path.Option {
+			pathOpts := []path.Option{
+				path.WithInteractive(c.flags.interactive),
+				path.WithRefresh(c.flags.refresh),
+				path.WithSequence(c.flags.sequence),
+				path.WithColorScheme(path.DefaultColorScheme(c.flags.noColor)),
+			}
+			if !c.flags.noProbe {
+				pathOpts = append(pathOpts, path.WithProbing(&path.ProbeConfig{
+					LocalIA: info.IA,
+					LocalIP: c.localIP,
+				}))
+			}
+			return pathOpts
+		},
+	}
+
+	outCertFile, outKeyFile := c.certFile, c.keyFile
+	if c.flags.out != "" {
+		outCertFile = c.flags.out
+	}
+	if c.flags.outKey != "" {
+		outKeyFile = c.flags.outKey
+	}
+
+	request := func(ca addr.IA, remote net.Addr) ([]*x509.Certificate, error) {
+		c.printf("Attempt certificate renewal with %s\n", ca)
+
+		span, ctx := tracing.CtxWith(ctx, "request")
+		span.SetTag("dst.isd_as", ca)
+
+		chain, err := r.Request(ctx, &req, remote, ca)
+		if err != nil {
+			c.printErr("Sending request failed: %s\n", err)
+			return nil, err
+		}
+
+		// Verify certificate chain
+		verifyOptions := cppki.VerifyOptions{TRC: trcs}
+		if verifyError := cppki.VerifyChain(chain, verifyOptions); verifyError != nil {
+			suffix := "." + addr.FormatIA(ca, addr.WithFileSeparator()) + ".unverified"
+
+			c.printErr("Verification failed: %s\n", verifyError)
+
+			// Write chain.
+			certFile := outCertFile + suffix
+			c.printErr("Writing unverified chain: %q\n", certFile)
+			pem := encodeChain(chain)
+			if err := file.WriteFile(certFile, pem, 0o644, opts...); err != nil {
+				fmt.Println("Failed to write unverified chain: ", err)
+			}
+
+			// Write private key
+			if pemPrivNext != nil {
+				keyFile := outKeyFile + suffix
+				c.printErr("Writing private key for unverified chain: %q\n", keyFile)
+				err := file.WriteFile(keyFile, pemPrivNext, 0o600, opts...)
+				if err != nil {
+					fmt.Println(
+						"Failed to write private key for unverified chain: ", err,
+					)
+				}
+			}
+
+			// Output helpful info in case the TRC is in grace period.
+			if maybeMissingTRCInGrace(trcs) {
+				c.printErr(
+					"Current time is still in Grace Period of latest TRC.\n"+
+						"Try to verify with the predecessor TRC: "+
+						"(Base = %d, Serial = %d)\n",
+					trcs[0].ID.Base, trcs[0].ID.Serial-1,
+				)
+			}
+			return nil, serrors.Wrap("verification failed", verifyError)
+		}
+		return chain, nil
+	}
+
+	var renewed []*x509.Certificate
+	switch {
+	case len(cas) > 0:
+		for _, ca := range cas {
+			remote := &snet.SVCAddr{SVC: addr.SvcCS}
+			chain, err := request(ca, remote)
+			if err != nil {
+				continue
+			}
+			renewed = chain
+			break
+		}
+	case len(remotes) > 0:
+		for _, remote := range remotes {
+			chain, err := request(remote.IA, remote)
+			if err != nil {
+				continue
+			}
+			renewed = chain
+			break
+		}
+	}
+	if renewed == nil {
+		return renewResult{}, serrors.New("failed to request certificate chain")
+	}
+	pemRenewed := encodeChain(renewed)
+
+	// The fresh private key and the renewed certificate chain are replaced
+	// together, such that they never get out of sync.
+	files := []file.File{{Name: outCertFile, Data: pemRenewed, Perm: 0o644}}
+	if pemPrivNext != nil {
+		files = append(files, file.File{Name: outKeyFile, Data: pemPrivNext, Perm: 0o600})
+	}
+	if err := file.WriteFiles(files, opts...); err != nil {
+		return renewResult{}, serrors.Wrap("writing renewed certificate chain", err)
+	}
+	if pemPrivNext != nil {
+		c.printf("Private key successfully written to %q\n", outKeyFile)
+	}
+	c.printf("Certificate chain successfully written to %q\n", outCertFile)
+	return renewResult{
+		Renewed: true,
+		Validity: cppki.Validity{
+			NotBefore: renewed[0].NotBefore,
+			NotAfter:  renewed[0].NotAfter,
+		},
+	}, nil
+}
+
+func (c *chainRenewal) printf(f string, ctx ...any) {
+	_, _ = fmt.Fprintf(c.stdout, f, ctx...)
+}
+
+func (c *chainRenewal) printErr(f string, ctx ...any) {
+	_, _ = fmt.Fprintf(c.stderr, f, ctx...)
+}
+
 type renewer struct {
 	LocalIA     addr.IA
 	LocalIP     net.IP
@@ -847,12 +935,18 @@ func (c expiryChecker) ShouldRenew(notBefore, notAfter time.Time) bool {
 	if c == (expiryChecker{}) {
 		return true
 	}
+	return time.Now().After(c.RenewAt(notBefore, notAfter))
+}
+
+// RenewAt returns the point in time after which a certificate chain with the
+// given validity should be renewed.
+func (c expiryChecker) RenewAt(notBefore, notAfter time.Time) time.Time {
 	leadTime := c.duration
 	if c.duration == 0 {
 		diff := notAfter.Sub(notBefore)
 		leadTime = time.Duration(diff.Seconds()*c.factor) * time.Second
 	}
-	return time.Until(notAfter) < leadTime
+	return notAfter.Add(-leadTime)
 }
 
 func loadChain(trcs []*cppki.TRC, file string) ([]*x509.Certificate, error) {
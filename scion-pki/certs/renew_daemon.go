@@ -0,0 +1,365 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/prom"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+)
+
+// defaultDaemonExpiresIn is the default renewal threshold in daemon mode. The
+// certificate chain is renewed once a third of its validity period remains.
+const defaultDaemonExpiresIn = 1.0 / 3
+
+// renewResult is the outcome of a single renewal run.
+type renewResult struct {
+	// Renewed indicates whether a renewed certificate chain was written.
+	Renewed bool
+	// Validity is the validity of the AS certificate that is in place after
+	// the run.
+	Validity cppki.Validity
+}
+
+type renewalDaemonFlags struct {
+	enabled       bool
+	checkInterval time.Duration
+	backoff       time.Duration
+	maxBackoff    time.Duration
+	exec          string
+	signal        string
+	pidFile       string
+	metrics       string
+}
+
+func (f *renewalDaemonFlags) register(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&f.enabled, "daemon", false,
+		"Keep running and renew the certificate chain whenever the\n"+
+			"--expires-in threshold is reached",
+	)
+	cmd.Flags().DurationVar(&f.checkInterval, "check-interval", 10*time.Minute,
+		"The maximum interval between two checks of the certificate chain in daemon mode",
+	)
+	cmd.Flags().DurationVar(&f.backoff, "backoff", 30*time.Second,
+		"The initial delay before a failed renewal is retried in daemon mode.\n"+
+			"The delay is doubled after every consecutive failure",
+	)
+	cmd.Flags().DurationVar(&f.maxBackoff, "max-backoff", 30*time.Minute,
+		"The maximum delay before a failed renewal is retried in daemon mode",
+	)
+	cmd.Flags().StringVar(&f.exec, "exec", "",
+		"The shell command to run after every successful renewal in daemon mode",
+	)
+	cmd.Flags().StringVar(&f.signal, "signal", "",
+		"The signal (e.g., SIGHUP) to send to the process in --pid-file\n"+
+			"after every successful renewal in daemon mode",
+	)
+	cmd.Flags().StringVar(&f.pidFile, "pid-file", "",
+		"The file containing the PID of the process that is signaled",
+	)
+	cmd.Flags().StringVar(&f.metrics, "metrics", "",
+		"The address to expose Prometheus metrics on in daemon mode (e.g., 127.0.0.1:30459)",
+	)
+}
+
+// validate checks the daemon flags in combination with the output flags of the
+// renew command. In daemon mode, the existing files are always replaced. Thus,
+// either --force or --backup is required.
+func (f *renewalDaemonFlags) validate(out, outKey string, force, backup bool) error {
+	if !f.enabled {
+		if f.exec != "" || f.signal != "" || f.pidFile != "" || f.metrics != "" {
+			return serrors.New("--exec, --signal, --pid-file and --metrics require --daemon")
+		}
+		return nil
+	}
+	if out != "" || outKey != "" {
+		return serrors.New("--out and --out-key are not supported with --daemon")
+	}
+	if !force && !backup {
+		return serrors.New("--daemon requires --force or --backup")
+	}
+	if (f.signal == "") != (f.pidFile == "") {
+		return serrors.New("--signal and --pid-file must be set together")
+	}
+	if f.signal != "" {
+		if _, err := parseSignal(f.signal); err != nil {
+			return err
+		}
+	}
+	if f.checkInterval <= 0 || f.backoff <= 0 || f.maxBackoff <= 0 {
+		return serrors.New("--check-interval, --backoff and --max-backoff must be positive")
+	}
+	return nil
+}
+
+func runRenewalDaemon(
+	ctx context.Context,
+	cmd *cobra.Command,
+	renew func(context.Context) (renewResult, error),
+	checker expiryChecker,
+	flags renewalDaemonFlags,
+) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	reg := prometheus.NewRegistry()
+	d := renewalDaemon{
+		Renew:         renew,
+		RenewAt:       checker.RenewAt,
+		CheckInterval: flags.checkInterval,
+		Backoff:       flags.backoff,
+		MaxBackoff:    flags.maxBackoff,
+		Hook:          newRenewalHook(flags, cmd.OutOrStdout(), cmd.ErrOrStderr()),
+		Metrics:       newRenewalMetrics(reg),
+	}
+	if flags.metrics != "" {
+		server := &http.Server{
+			Addr:              flags.metrics,
+			Handler:           promhttp.HandlerFor(reg, promhttp.HandlerOpts{}),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			defer log.HandlePanic()
+			err := server.ListenAndServe()
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Error("Serving metrics failed", "err", err)
+			}
+		}()
+		defer func() { _ = server.Close() }()
+	}
+	d.Run(ctx)
+	return nil
+}
+
+// renewalMetrics are the metrics exported in daemon mode.
+type renewalMetrics struct {
+	// Attempts counts the renewal runs that requested a certificate chain.
+	Attempts metrics.Counter
+	// Hooks counts the executions of the post-renewal hook.
+	Hooks metrics.Counter
+	// Failures is the number of consecutive failed renewal runs.
+	Failures metrics.Gauge
+	// NotAfter is the expiration time of the current AS certificate.
+	NotAfter metrics.Gauge
+	// LastRenewal is the time of the last successful renewal.
+	LastRenewal metrics.Gauge
+	// NextCheck is the time of the next scheduled check.
+	NextCheck metrics.Gauge
+}
+
+func newRenewalMetrics(reg prometheus.Registerer) renewalMetrics {
+	counter := func(name, help string) metrics.Counter {
+		c := prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: name,
+			Help: help,
+		}, []string{prom.LabelResult})
+		reg.MustRegister(c)
+		return metrics.NewPromCounter(c)
+	}
+	gauge := func(name, help string) metrics.Gauge {
+		g := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: name,
+			Help: help,
+		}, []string{})
+		reg.MustRegister(g)
+		return metrics.NewPromGauge(g)
+	}
+	return renewalMetrics{
+		Attempts: counter("scion_pki_renewal_attempts_total",
+			"Total number of certificate renewal attempts."),
+		Hooks: counter("scion_pki_renewal_hook_runs_total",
+			"Total number of post-renewal hook executions."),
+		Failures: gauge("scion_pki_renewal_consecutive_failures",
+			"Number of consecutive failed renewal attempts."),
+		NotAfter: gauge("scion_pki_renewal_not_after_timestamp_seconds",
+			"Expiration time of the current AS certificate as a Unix timestamp."),
+		LastRenewal: gauge("scion_pki_renewal_last_success_timestamp_seconds",
+			"Time of the last successful renewal as a Unix timestamp."),
+		NextCheck: gauge("scion_pki_renewal_next_check_timestamp_seconds",
+			"Time of the next scheduled check as a Unix timestamp."),
+	}
+}
+
+// renewalDaemon repeatedly checks the certificate chain, and renews it once the
+// renewal threshold is reached. Failed renewals are retried with exponential
+// backoff.
+type renewalDaemon struct {
+	// Renew executes a single renewal run. It checks the renewal threshold
+	// itself and only requests a new certificate chain if it is reached.
+	Renew func(context.Context) (renewResult, error)
+	// RenewAt determines when a certificate chain with the given validity
+	// must be renewed.
+	RenewAt func(notBefore, notAfter time.Time) time.Time
+	// CheckInterval is the maximum interval between two runs.
+	CheckInterval time.Duration
+	// Backoff is the initial delay after a failed run.
+	Backoff time.Duration
+	// MaxBackoff is the maximum delay after a failed run.
+	MaxBackoff time.Duration
+	// Hook is executed after every successful renewal. It may be nil.
+	Hook    func(context.Context) error
+	Metrics renewalMetrics
+}
+
+// Run runs the daemon until the context is canceled.
+func (d *renewalDaemon) Run(ctx context.Context) {
+	logger := log.FromCtx(ctx)
+	failures := 0
+	for {
+		wait := d.CheckInterval
+		res, err := d.Renew(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		switch {
+		case err != nil:
+			failures++
+			wait = d.backoff(failures)
+			metrics.CounterInc(metrics.CounterWith(d.Metrics.Attempts,
+				prom.LabelResult, prom.ErrNotClassified))
+			logger.Error("Certificate renewal failed",
+				"err", err, "failures", failures, "retry_in", wait)
+		default:
+			failures = 0
+			if res.Renewed {
+				metrics.CounterInc(metrics.CounterWith(d.Metrics.Attempts,
+					prom.LabelResult, prom.Success))
+				metrics.GaugeSetTimestamp(d.Metrics.LastRenewal, time.Now())
+				logger.Info("Certificate chain renewed",
+					"not_before", res.Validity.NotBefore, "not_after", res.Validity.NotAfter)
+				d.runHook(ctx)
+			}
+			metrics.GaugeSetTimestamp(d.Metrics.NotAfter, res.Validity.NotAfter)
+			renewAt := d.RenewAt(res.Validity.NotBefore, res.Validity.NotAfter)
+			if until := time.Until(renewAt); until < wait {
+				// Do not spin if the certificate chain could not be renewed
+				// in time, e.g., because the threshold is too large.
+				wait = max(until, d.Backoff)
+			}
+		}
+		metrics.GaugeSet(d.Metrics.Failures, float64(failures))
+		metrics.GaugeSetTimestamp(d.Metrics.NextCheck, time.Now().Add(wait))
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+func (d *renewalDaemon) backoff(failures int) time.Duration {
+	wait := d.Backoff
+	for i := 1; i < failures && wait < d.MaxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, d.MaxBackoff)
+}
+
+func (d *renewalDaemon) runHook(ctx context.Context) {
+	if d.Hook == nil {
+		return
+	}
+	if err := d.Hook(ctx); err != nil {
+		metrics.CounterInc(metrics.CounterWith(d.Metrics.Hooks,
+			prom.LabelResult, prom.ErrNotClassified))
+		log.FromCtx(ctx).Error("Post-renewal hook failed", "err", err)
+		return
+	}
+	metrics.CounterInc(metrics.CounterWith(d.Metrics.Hooks, prom.LabelResult, prom.Success))
+}
+
+// newRenewalHook creates the hook that is executed after a successful renewal.
+// It first runs the shell command, and then signals the process, if
+// configured. If neither is configured, nil is returned.
+func newRenewalHook(
+	flags renewalDaemonFlags,
+	stdout, stderr io.Writer,
+) func(context.Context) error {
+
+	if flags.exec == "" && flags.signal == "" {
+		return nil
+	}
+	return func(ctx context.Context) error {
+		if flags.exec != "" {
+			c := exec.CommandContext(ctx, "/bin/sh", "-c", flags.exec)
+			c.Stdout, c.Stderr = stdout, stderr
+			if err := c.Run(); err != nil {
+				return serrors.Wrap("running command", err, "command", flags.exec)
+			}
+		}
+		if flags.signal != "" {
+			if err := signalProcess(flags.signal, flags.pidFile); err != nil {
+				return serrors.Wrap("signaling process", err, "pid_file", flags.pidFile)
+			}
+		}
+		return nil
+	}
+}
+
+// signalProcess sends the signal to the process with the PID in the file. The
+// file is read on every invocation, such that restarts of the process are
+// picked up.
+func signalProcess(name, pidFile string) error {
+	sig, err := parseSignal(name)
+	if err != nil {
+		return err
+	}
+	raw, err := os.ReadFile(pidFile)
+	if err != nil {
+		return err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(raw)))
+	if err != nil {
+		return serrors.Wrap("parsing PID", err)
+	}
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return p.Signal(sig)
+}
+
+func parseSignal(name string) (syscall.Signal, error) {
+	name = strings.ToUpper(name)
+	if !strings.HasPrefix(name, "SIG") {
+		name = "SIG" + name
+	}
+	sig, ok := renewalSignals[name]
+	if !ok {
+		return 0, serrors.New("unsupported signal", "signal", name)
+	}
+	return sig, nil
+}
@@ -0,0 +1,208 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certs
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+)
+
+func TestRenewalDaemonRun(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The first two runs fail, the third renews the chain, the fourth finds
+	// the renewed chain and stops the daemon.
+	var runs, hooks int
+	renewed := cppki.Validity{
+		NotBefore: time.Now(),
+		NotAfter:  time.Now().Add(time.Hour),
+	}
+	d := renewalDaemon{
+		Renew: func(context.Context) (renewResult, error) {
+			runs++
+			switch runs {
+			case 1, 2:
+				return renewResult{}, serrors.New("CA unavailable")
+			case 3:
+				return renewResult{Renewed: true, Validity: renewed}, nil
+			default:
+				cancel()
+				return renewResult{Validity: renewed}, nil
+			}
+		},
+		RenewAt: func(_, _ time.Time) time.Time {
+			// Check again right away to keep the test fast.
+			return time.Now()
+		},
+		CheckInterval: time.Hour,
+		Backoff:       time.Millisecond,
+		MaxBackoff:    10 * time.Millisecond,
+		Hook: func(context.Context) error {
+			hooks++
+			return nil
+		},
+	}
+	d.Run(ctx)
+	assert.Equal(t, 4, runs)
+	assert.Equal(t, 1, hooks)
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+func TestRenewalDaemonBackoff(t *testing.T) {
+	d := renewalDaemon{Backoff: time.Second, MaxBackoff: 10 * time.Second}
+	assert.Equal(t, time.Second, d.backoff(1))
+	assert.Equal(t, 2*time.Second, d.backoff(2))
+	assert.Equal(t, 8*time.Second, d.backoff(4))
+	assert.Equal(t, 10*time.Second, d.backoff(5))
+	assert.Equal(t, 10*time.Second, d.backoff(100))
+}
+
+func TestExpiryCheckerRenewAt(t *testing.T) {
+	notBefore := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := notBefore.Add(72 * time.Hour)
+
+	factor := expiryChecker{factor: defaultDaemonExpiresIn}
+	assert.Equal(t, notAfter.Add(-24*time.Hour), factor.RenewAt(notBefore, notAfter))
+
+	duration := expiryChecker{duration: 6 * time.Hour}
+	assert.Equal(t, notAfter.Add(-6*time.Hour), duration.RenewAt(notBefore, notAfter))
+}
+
+func TestRenewalDaemonFlagsValidate(t *testing.T) {
+	valid := renewalDaemonFlags{
+		enabled:       true,
+		checkInterval: time.Minute,
+		backoff:       time.Second,
+		maxBackoff:    time.Minute,
+	}
+	testCases := map[string]struct {
+		Flags        func() renewalDaemonFlags
+		Out          string
+		NoForce      bool
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"disabled": {
+			Flags:        func() renewalDaemonFlags { return renewalDaemonFlags{} },
+			ErrAssertion: assert.NoError,
+		},
+		"disabled with hook": {
+			Flags: func() renewalDaemonFlags {
+				return renewalDaemonFlags{exec: "true"}
+			},
+			ErrAssertion: assert.Error,
+		},
+		"valid": {
+			Flags:        func() renewalDaemonFlags { return valid },
+			ErrAssertion: assert.NoError,
+		},
+		"out": {
+			Flags:        func() renewalDaemonFlags { return valid },
+			Out:          "cp-as.new.pem",
+			ErrAssertion: assert.Error,
+		},
+		"neither force nor backup": {
+			Flags:        func() renewalDaemonFlags { return valid },
+			NoForce:      true,
+			ErrAssertion: assert.Error,
+		},
+		"disabled without force": {
+			Flags:        func() renewalDaemonFlags { return renewalDaemonFlags{} },
+			NoForce:      true,
+			ErrAssertion: assert.NoError,
+		},
+		"signal without pid file": {
+			Flags: func() renewalDaemonFlags {
+				f := valid
+				f.signal = "SIGHUP"
+				return f
+			},
+			ErrAssertion: assert.Error,
+		},
+		"signal": {
+			Flags: func() renewalDaemonFlags {
+				f := valid
+				f.signal, f.pidFile = "HUP", "control.pid"
+				return f
+			},
+			ErrAssertion: assert.NoError,
+		},
+		"unknown signal": {
+			Flags: func() renewalDaemonFlags {
+				f := valid
+				f.signal, f.pidFile = "SIGFOO", "control.pid"
+				return f
+			},
+			ErrAssertion: assert.Error,
+		},
+		"zero backoff": {
+			Flags: func() renewalDaemonFlags {
+				f := valid
+				f.backoff = 0
+				return f
+			},
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			f := tc.Flags()
+			tc.ErrAssertion(t, f.validate(tc.Out, "", !tc.NoForce, false))
+		})
+	}
+}
+
+func TestRenewalHook(t *testing.T) {
+	dir := t.TempDir()
+	pidFile := filepath.Join(dir, "pid")
+	require.NoError(t, os.WriteFile(pidFile, []byte(strconv.Itoa(os.Getpid())+"\n"), 0o644))
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGHUP)
+	defer signal.Stop(sigs)
+
+	marker := filepath.Join(dir, "marker")
+	hook := newRenewalHook(renewalDaemonFlags{
+		exec:    "touch " + marker,
+		signal:  "SIGHUP",
+		pidFile: pidFile,
+	}, os.Stdout, os.Stderr)
+	require.NotNil(t, hook)
+	require.NoError(t, hook(context.Background()))
+
+	assert.FileExists(t, marker)
+	select {
+	case sig := <-sigs:
+		assert.Equal(t, syscall.SIGHUP, sig)
+	case <-time.After(time.Second):
+		t.Fatal("signal not received")
+	}
+
+	failing := newRenewalHook(renewalDaemonFlags{exec: "exit 1"}, os.Stdout, os.Stderr)
+	assert.Error(t, failing(context.Background()))
+	assert.Nil(t, newRenewalHook(renewalDaemonFlags{}, os.Stdout, os.Stderr))
+}
@@ -30,6 +30,7 @@ type Option func(o *options)
 type options struct {
 	backupPattern string
 	force         bool
+	atomic        bool
 }
 
 // WithBackup specifies the backup pattern for backing up files that already
@@ -59,6 +60,19 @@ func WithForce(force bool) Option {
 	}
 }
 
+// WithAtomic specifies whether the file should be replaced atomically. If set,
+// the data is first written to a temporary file in the same directory, which
+// is then renamed to the target file. Readers thus either observe the previous
+// or the new content, but never a partially written file.
+//
+// In combination with WithBackup, the existing file is backed up by creating a
+// hard link to it before it is replaced.
+func WithAtomic(atomic bool) Option {
+	return func(o *options) {
+		o.atomic = atomic
+	}
+}
+
 func apply(opts []Option) options {
 	var o options
 	for _, option := range opts {
@@ -86,9 +100,14 @@ func CheckDirExists(dir string) error {
 func WriteFile(filename string, data []byte, perm os.FileMode, opts ...Option) error {
 	options := apply(opts)
 
+	write := os.WriteFile
+	if options.atomic {
+		write = writeAtomic
+	}
+
 	info, err := os.Stat(filename)
 	if errors.Is(err, os.ErrNotExist) {
-		return os.WriteFile(filename, data, perm)
+		return write(filename, data, perm)
 	}
 	if err != nil {
 		return serrors.Wrap("reading stat information", err)
@@ -99,12 +118,20 @@ func WriteFile(filename string, data []byte, perm os.FileMode, opts ...Option) e
 
 	switch {
 	case options.backupPattern != "":
-		ext := filepath.Ext(filename)
-		backup := strings.TrimSuffix(filename, ext) + "." + options.backupPattern + ext
+		backup := backupName(filename, options.backupPattern)
+		if options.atomic {
+			if err := os.Link(filename, backup); err != nil {
+				return serrors.Wrap("backing up file", err)
+			}
+			break
+		}
 		if err := os.Rename(filename, backup); err != nil {
 			return serrors.Wrap("backing up file", err)
 		}
 	case options.force:
+		if options.atomic {
+			break
+		}
 		if err := os.Remove(filename); err != nil {
 			return serrors.Wrap("removing existing file", err)
 		}
@@ -112,5 +139,172 @@ func WriteFile(filename string, data []byte, perm os.FileMode, opts ...Option) e
 		return os.ErrExist
 	}
 
-	return os.WriteFile(filename, data, perm)
+	return write(filename, data, perm)
+}
+
+// File is a file that is written by WriteFiles.
+type File struct {
+	Name string
+	Data []byte
+	Perm os.FileMode
+}
+
+// WriteFiles writes all the supplied files, such that either all of them or
+// none of them are replaced. The data is first written to temporary files in
+// the directories of the target files. Only once all temporary files are
+// written, they are renamed to the target files. If renaming fails, the files
+// that were already replaced are restored.
+//
+// Existing files are handled according to the options, as in WriteFile. The
+// files are always replaced atomically, i.e., WithAtomic is implied.
+func WriteFiles(files []File, opts ...Option) error {
+	options := apply(opts)
+
+	type pending struct {
+		File
+		tmp    string
+		backup string
+		// exists indicates whether the file exists, previous and prevPerm are
+		// its content and permissions.
+		exists   bool
+		previous []byte
+		prevPerm os.FileMode
+	}
+	pendings := make([]pending, 0, len(files))
+	defer func() {
+		for _, p := range pendings {
+			if p.tmp != "" {
+				_ = os.Remove(p.tmp)
+			}
+		}
+	}()
+	for _, f := range files {
+		p := pending{File: f}
+		info, err := os.Stat(f.Name)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			return serrors.Wrap("reading stat information", err, "file", f.Name)
+		case info.IsDir():
+			return serrors.New("file is a directory", "file", f.Name)
+		case options.backupPattern == "" && !options.force:
+			return os.ErrExist
+		default:
+			if p.previous, err = os.ReadFile(f.Name); err != nil {
+				return serrors.Wrap("reading existing file", err, "file", f.Name)
+			}
+			p.exists, p.prevPerm = true, info.Mode().Perm()
+		}
+		if p.tmp, err = writeTemp(f.Name, f.Data, f.Perm); err != nil {
+			return serrors.Wrap("writing file", err, "file", f.Name)
+		}
+		pendings = append(pendings, p)
+	}
+
+	removeBackups := func() {
+		for _, p := range pendings {
+			if p.backup != "" {
+				_ = os.Remove(p.backup)
+			}
+		}
+	}
+	if options.backupPattern != "" {
+		for i, p := range pendings {
+			if !p.exists {
+				continue
+			}
+			backup := backupName(p.Name, options.backupPattern)
+			if err := os.Link(p.Name, backup); err != nil {
+				removeBackups()
+				return serrors.Wrap("backing up file", err, "file", p.Name)
+			}
+			pendings[i].backup = backup
+		}
+	}
+	for i, p := range pendings {
+		if err := rename(p.tmp, p.Name); err != nil {
+			var errs serrors.List
+			for _, replaced := range pendings[:i] {
+				if err := restore(replaced.Name, replaced.exists, replaced.previous,
+					replaced.prevPerm); err != nil {
+
+					errs = append(errs, err)
+				}
+			}
+			removeBackups()
+			if restoreErr := errs.ToError(); restoreErr != nil {
+				return serrors.Wrap("renaming temporary file", err, "file", p.Name,
+					"restore_err", restoreErr)
+			}
+			return serrors.Wrap("renaming temporary file", err, "file", p.Name)
+		}
+		pendings[i].tmp = ""
+	}
+	return nil
+}
+
+// restore restores a file that was replaced by WriteFiles to its previous
+// state.
+func restore(filename string, existed bool, previous []byte, perm os.FileMode) error {
+	if !existed {
+		if err := os.Remove(filename); err != nil {
+			return serrors.Wrap("removing file", err, "file", filename)
+		}
+		return nil
+	}
+	if err := writeAtomic(filename, previous, perm); err != nil {
+		return serrors.Wrap("restoring file", err, "file", filename)
+	}
+	return nil
+}
+
+func backupName(filename, pattern string) string {
+	ext := filepath.Ext(filename)
+	return strings.TrimSuffix(filename, ext) + "." + pattern + ext
+}
+
+// rename renames the temporary files. It is a variable such that tests can
+// inject failures.
+var rename = os.Rename
+
+// writeAtomic writes the data to a temporary file and renames it to the
+// target file.
+func writeAtomic(filename string, data []byte, perm os.FileMode) error {
+	tmp, err := writeTemp(filename, data, perm)
+	if err != nil {
+		return err
+	}
+	if err := rename(tmp, filename); err != nil {
+		_ = os.Remove(tmp)
+		return serrors.Wrap("renaming temporary file", err)
+	}
+	return nil
+}
+
+// writeTemp writes the data to a temporary file in the directory of the target
+// file and returns the name of the temporary file.
+func writeTemp(filename string, data []byte, perm os.FileMode) (string, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+".*")
+	if err != nil {
+		return "", serrors.Wrap("creating temporary file", err)
+	}
+	fail := func(msg string, err error) (string, error) {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return "", serrors.Wrap(msg, err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		return fail("writing temporary file", err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		return fail("setting file permissions", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return fail("syncing temporary file", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return "", serrors.Wrap("closing temporary file", err)
+	}
+	return tmp.Name(), nil
 }
@@ -16,6 +16,8 @@ package file_test
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -152,6 +154,74 @@ func TestWriteFile(t *testing.T) {
 				require.Equal(t, []byte("data"), original)
 			},
 		},
+		"atomic new file": {
+			Filename:     dir + "/atomic-new",
+			Perm:         0600,
+			ErrAssertion: assert.NoError,
+			Opts:         []file.Option{file.WithAtomic(true)},
+			Validate: func(t *testing.T, expected []byte) {
+				raw, err := os.ReadFile(dir + "/atomic-new")
+				require.NoError(t, err)
+				require.Equal(t, expected, raw)
+
+				info, err := os.Stat(dir + "/atomic-new")
+				require.NoError(t, err)
+				require.Equal(t, os.FileMode(0600), info.Mode())
+			},
+		},
+		"atomic file exist": {
+			Filename: dir + "/atomic-existing",
+			Prepare: func(t *testing.T) {
+				err := os.WriteFile(dir+"/atomic-existing", []byte("data"), 0666)
+				require.NoError(t, err)
+			},
+			Perm:         0600,
+			ErrAssertion: assert.Error,
+			Opts:         []file.Option{file.WithAtomic(true)},
+			Validate: func(t *testing.T, expected []byte) {
+				raw, err := os.ReadFile(dir + "/atomic-existing")
+				require.NoError(t, err)
+				require.Equal(t, []byte("data"), raw)
+			},
+		},
+		"atomic file exist force": {
+			Filename: dir + "/atomic-force",
+			Prepare: func(t *testing.T) {
+				err := os.WriteFile(dir+"/atomic-force", []byte("data"), 0666)
+				require.NoError(t, err)
+			},
+			Perm:         0600,
+			ErrAssertion: assert.NoError,
+			Opts:         []file.Option{file.WithAtomic(true), file.WithForce(true)},
+			Validate: func(t *testing.T, expected []byte) {
+				raw, err := os.ReadFile(dir + "/atomic-force")
+				require.NoError(t, err)
+				require.Equal(t, expected, raw)
+
+				info, err := os.Stat(dir + "/atomic-force")
+				require.NoError(t, err)
+				require.Equal(t, os.FileMode(0600), info.Mode())
+			},
+		},
+		"atomic file exist backup": {
+			Filename: dir + "/atomic-backup.ext",
+			Prepare: func(t *testing.T) {
+				err := os.WriteFile(dir+"/atomic-backup.ext", []byte("data"), 0666)
+				require.NoError(t, err)
+			},
+			Perm:         0600,
+			ErrAssertion: assert.NoError,
+			Opts:         []file.Option{file.WithAtomic(true), file.WithBackup("backup")},
+			Validate: func(t *testing.T, expected []byte) {
+				raw, err := os.ReadFile(dir + "/atomic-backup.ext")
+				require.NoError(t, err)
+				require.Equal(t, expected, raw)
+
+				original, err := os.ReadFile(dir + "/atomic-backup.backup.ext")
+				require.NoError(t, err)
+				require.Equal(t, []byte("data"), original)
+			},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -166,4 +236,82 @@ func TestWriteFile(t *testing.T) {
 			}
 		})
 	}
+	// The atomic writes must not leave any temporary files behind.
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	for _, e := range entries {
+		assert.False(t, strings.HasPrefix(e.Name(), "."), e.Name())
+	}
+}
+
+func TestWriteFiles(t *testing.T) {
+	files := func(dir string) []file.File {
+		return []file.File{
+			{Name: filepath.Join(dir, "cp-as.key"), Data: []byte("new key"), Perm: 0600},
+			{Name: filepath.Join(dir, "cp-as.pem"), Data: []byte("new chain"), Perm: 0644},
+		}
+	}
+	prepare := func(t *testing.T, dir string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "cp-as.key"), []byte("key"), 0600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "cp-as.pem"), []byte("chain"), 0644))
+	}
+	read := func(t *testing.T, name string) string {
+		raw, err := os.ReadFile(name)
+		require.NoError(t, err)
+		return string(raw)
+	}
+	noTemp := func(t *testing.T, dir string) {
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		for _, e := range entries {
+			assert.False(t, strings.HasPrefix(e.Name(), "."), e.Name())
+		}
+	}
+
+	t.Run("new files", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, file.WriteFiles(files(dir)))
+		assert.Equal(t, "new key", read(t, filepath.Join(dir, "cp-as.key")))
+		assert.Equal(t, "new chain", read(t, filepath.Join(dir, "cp-as.pem")))
+		noTemp(t, dir)
+	})
+	t.Run("existing files", func(t *testing.T) {
+		dir := t.TempDir()
+		prepare(t, dir)
+		// Neither file is replaced if one of them must not be overwritten.
+		assert.Error(t, file.WriteFiles(files(dir)))
+		assert.Equal(t, "key", read(t, filepath.Join(dir, "cp-as.key")))
+		assert.Equal(t, "chain", read(t, filepath.Join(dir, "cp-as.pem")))
+		noTemp(t, dir)
+	})
+	t.Run("backup", func(t *testing.T) {
+		dir := t.TempDir()
+		prepare(t, dir)
+		require.NoError(t, file.WriteFiles(files(dir), file.WithBackup("backup")))
+		assert.Equal(t, "new key", read(t, filepath.Join(dir, "cp-as.key")))
+		assert.Equal(t, "new chain", read(t, filepath.Join(dir, "cp-as.pem")))
+		assert.Equal(t, "key", read(t, filepath.Join(dir, "cp-as.backup.key")))
+		assert.Equal(t, "chain", read(t, filepath.Join(dir, "cp-as.backup.pem")))
+		noTemp(t, dir)
+	})
+	t.Run("rename fails", func(t *testing.T) {
+		dir := t.TempDir()
+		prepare(t, dir)
+		renamed := 0
+		defer file.SetRename(func(oldpath, newpath string) error {
+			// Fail renaming the second file, but not restoring the first.
+			if renamed++; renamed == 2 {
+				return os.ErrPermission
+			}
+			return os.Rename(oldpath, newpath)
+		})()
+		err := file.WriteFiles(files(dir), file.WithForce(true), file.WithBackup("backup"))
+		assert.ErrorIs(t, err, os.ErrPermission)
+		// The replaced key is restored.
+		assert.Equal(t, "key", read(t, filepath.Join(dir, "cp-as.key")))
+		assert.Equal(t, "chain", read(t, filepath.Join(dir, "cp-as.pem")))
+		assert.NoFileExists(t, filepath.Join(dir, "cp-as.backup.key"))
+		assert.NoFileExists(t, filepath.Join(dir, "cp-as.backup.pem"))
+		noTemp(t, dir)
+	})
 }
@@ -18,7 +18,8 @@ import "github.com/spf13/pflag"
 
 func BindFlagKms(flags *pflag.FlagSet, kms *string) {
 	flags.StringVar(kms, "kms", "",
-		"The uri to configure a Cloud KMS or an HSM.",
+		"The uri to configure a Cloud KMS or an HSM.\n"+
+			"PKCS#11 URIs are supported natively, all other URIs require step-kms-plugin.",
 	)
 }
 
@@ -26,6 +26,7 @@ import (
 
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/private/app/command"
+	"github.com/scionproto/scion/private/pkcs11"
 	scionpki "github.com/scionproto/scion/scion-pki"
 	"github.com/scionproto/scion/scion-pki/file"
 )
@@ -95,8 +96,13 @@ By default, the public key is written to standard out.
 	return cmd
 }
 
-// LoadPrivate key loads a private key from file.
+// LoadPrivate key loads a private key from file. If kms is set, the key is
+// loaded from the KMS instead. PKCS#11 URIs are handled natively, all other
+// KMS URIs are delegated to `step-kms-plugin`.
 func LoadPrivateKey(kms, name string) (crypto.Signer, error) {
+	if pkcs11.IsURI(kms) || (kms == "" && pkcs11.IsURI(name)) {
+		return newPKCS11Signer(kms, name)
+	}
 	if kms == "" {
 		raw, err := os.ReadFile(name)
 		if err != nil {
@@ -128,3 +134,32 @@ func LoadPrivateKey(kms, name string) (crypto.Signer, error) {
 	}
 	return newKMSSigner(kms, name)
 }
+
+// newPKCS11Signer creates a signer for a private key on a PKCS#11 token. The
+// kms URI selects the token, and name selects the key on the token. The name is
+// either a PKCS#11 URI, or the label of the key. If kms is empty, name must be
+// a PKCS#11 URI that selects both the token and the key.
+func newPKCS11Signer(kms, name string) (crypto.Signer, error) {
+	var uri pkcs11.URI
+	var err error
+	switch {
+	case kms == "":
+		uri, err = pkcs11.ParseURI(name)
+	case pkcs11.IsURI(name):
+		uri, err = pkcs11.ParseURI(kms)
+		if err == nil {
+			uri, err = uri.Merge(name)
+		}
+	default:
+		uri, err = pkcs11.ParseURI(kms)
+		uri.Object = name
+	}
+	if err != nil {
+		return nil, serrors.Wrap("parsing PKCS#11 URI", err)
+	}
+	signer, err := pkcs11.LoadSigner(uri)
+	if err != nil {
+		return nil, serrors.Wrap("loading private key from PKCS#11 token", err)
+	}
+	return signer, nil
+}
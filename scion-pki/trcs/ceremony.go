@@ -0,0 +1,499 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trcs
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scrypto/cms/protocol"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/app/command"
+	"github.com/scionproto/scion/scion-pki/conf"
+)
+
+// The files in a ceremony directory.
+const (
+	CeremonyPayload     = "payload.der"
+	CeremonyPredecessor = "predecessor.trc"
+	CeremonyParts       = "parts"
+)
+
+// The types of signatures that are required for a TRC.
+const (
+	SignatureVote              = "vote"
+	SignatureProofOfPossession = "proof of possession"
+	SignatureAcknowledgement   = "acknowledgement"
+)
+
+func newCeremony(pather command.Pather) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ceremony",
+		Short: "Run a TRC signing ceremony from a ceremony directory",
+		Long: `'ceremony' guides through a TRC signing ceremony.
+
+The state of the ceremony is kept in a ceremony directory with the following layout::
+
+	<dir>/payload.der      The TRC payload that is signed.
+	<dir>/predecessor.trc  The predecessor TRC. Only present for TRC updates.
+	<dir>/parts/           The partially signed TRCs contributed by the participants.
+
+The directory is created with 'init'. The participants sign the payload with
+'sign' and place the resulting partially signed TRCs in the parts directory.
+'status' reports which signatures are still missing, and 'combine' creates the
+final TRC once all required signatures are present.
+`,
+	}
+	joined := command.Join(pather, cmd)
+	cmd.AddCommand(
+		newCeremonyInit(joined),
+		newCeremonyStatus(joined),
+		newCeremonyCombine(joined),
+	)
+	return cmd
+}
+
+func newCeremonyInit(pather command.Pather) *cobra.Command {
+	var flags struct {
+		tmpl string
+		pred string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "init <dir>",
+		Short: "Create the ceremony directory with the TRC payload",
+		Example: fmt.Sprintf(`  %[1]s init -t template.toml ceremony
+  %[1]s init -t template.toml -p ISD1-B1-S1.trc ceremony`,
+			pather.CommandPath()),
+		Long: `'init' creates the ceremony directory and the TRC payload in it.
+
+The payload is generated from the template in the same way as with 'payload'.
+For a TRC update, the predecessor TRC must be specified. It is copied to the
+ceremony directory, and the update is validated against the rules of the
+predecessor. The command prints the type of the update and the signatures that
+are required for it.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			cfg, err := conf.LoadTRC(flags.tmpl)
+			if err != nil {
+				return serrors.Wrap("failed to load template file", err)
+			}
+			if err := InitCeremony(args[0], cfg, flags.pred); err != nil {
+				return err
+			}
+			status, err := LoadCeremony(args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully initialized ceremony at %s\n\n", args[0])
+			writeCeremonyStatus(cmd.OutOrStdout(), status)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.tmpl, "template", "t", "", "Template file (required)")
+	cmd.MarkFlagRequired("template")
+	cmd.Flags().StringVarP(&flags.pred, "predecessor", "p", "", "Predecessor TRC")
+	return cmd
+}
+
+func newCeremonyStatus(pather command.Pather) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "status <dir>",
+		Short:   "Report the signatures that are still missing",
+		Example: fmt.Sprintf(`  %[1]s status ceremony`, pather.CommandPath()),
+		Long: `'status' reports the state of the ceremony.
+
+It lists all signatures that are required for the TRC, and the parts that
+contain them. Parts that sign a different payload, contain an invalid
+signature, or do not contain any required signature are reported as rejected.
+
+The command exits with a non-zero exit code if signatures are still missing.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			status, err := LoadCeremony(args[0])
+			if err != nil {
+				return err
+			}
+			writeCeremonyStatus(cmd.OutOrStdout(), status)
+			if missing := len(status.Missing()); missing > 0 {
+				return serrors.New("signatures missing", "count", missing)
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+func newCeremonyCombine(pather command.Pather) *cobra.Command {
+	var flags struct {
+		out    string
+		format string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "combine <dir>",
+		Short: "Combine the parts to the final TRC",
+		Example: fmt.Sprintf(`  %[1]s combine ceremony
+  %[1]s combine -o ISD1-B1-S2.trc ceremony`,
+			pather.CommandPath()),
+		Long: `'combine' combines the valid parts of the ceremony into the final TRC.
+
+The command refuses to combine the parts if any required signature is missing.
+The combined TRC is verified against the predecessor TRC, or for a base TRC,
+against the proofs of possession, before it is written.
+
+By default, the TRC is written to the ceremony directory with the file name
+ISD<isd>-B<base_version>-S<serial_number>.trc. An alternative name can be
+specified with the \--out flag.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			status, err := LoadCeremony(args[0])
+			if err != nil {
+				return err
+			}
+			if missing := status.Missing(); len(missing) > 0 {
+				writeCeremonyStatus(cmd.OutOrStdout(), status)
+				return serrors.New("signatures missing", "count", len(missing))
+			}
+			packed, err := CombineCeremony(status)
+			if err != nil {
+				return err
+			}
+			out := flags.out
+			if out == "" {
+				out = filepath.Join(args[0], status.TRC.ID.String()+".trc")
+			}
+			if flags.format == "pem" {
+				packed = pem.EncodeToMemory(&pem.Block{
+					Type:  "TRC",
+					Bytes: packed,
+				})
+			}
+			if err := os.WriteFile(out, packed, 0644); err != nil {
+				return serrors.Wrap("error writing combined TRC", err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully combined TRC at %s\n", out)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.out, "out", "o", "", "Output file")
+	cmd.Flags().StringVar(&flags.format, "format", "der", "Output format (der|pem)")
+	return cmd
+}
+
+// CeremonySignature is a signature that is required for the TRC.
+type CeremonySignature struct {
+	// Type is the type of the signature, i.e., SignatureVote,
+	// SignatureProofOfPossession or SignatureAcknowledgement.
+	Type string
+	// Certificate is the certificate that authenticates the signature.
+	Certificate *x509.Certificate
+	// Parts lists the parts that contain a valid signature.
+	Parts []string
+}
+
+// CeremonyStatus is the state of a TRC ceremony.
+type CeremonyStatus struct {
+	// TRC is the payload that is signed.
+	TRC *cppki.TRC
+	// Predecessor is the predecessor TRC. It is nil for a base TRC.
+	Predecessor *cppki.TRC
+	// Update describes the TRC update. It is only set if the predecessor is
+	// not nil.
+	Update cppki.Update
+	// Signatures lists the required signatures.
+	Signatures []CeremonySignature
+	// Parts contains the parts that contribute at least one required
+	// signature.
+	Parts map[string]cppki.SignedTRC
+	// Rejected contains the parts that can not be used, with the reason.
+	Rejected map[string]error
+}
+
+// Missing returns the required signatures that are not contained in any part.
+func (s CeremonyStatus) Missing() []CeremonySignature {
+	var missing []CeremonySignature
+	for _, sig := range s.Signatures {
+		if len(sig.Parts) == 0 {
+			missing = append(missing, sig)
+		}
+	}
+	return missing
+}
+
+// InitCeremony creates the ceremony directory with the payload created from
+// the configuration. For a TRC update, the predecessor TRC is copied to the
+// directory and the update is validated against it.
+func InitCeremony(dir string, cfg conf.TRC, predFile string) error {
+	if _, err := os.Stat(filepath.Join(dir, CeremonyPayload)); err == nil {
+		return serrors.New("ceremony already initialized", "dir", dir)
+	}
+	pred, err := loadPredecessor(cfg.SerialVersion == cfg.BaseVersion, predFile)
+	if err != nil {
+		return err
+	}
+	prepareCfg(&cfg, pred)
+	trc, err := CreatePayload(cfg, pred)
+	if err != nil {
+		return serrors.Wrap("failed to marshal TRC", err)
+	}
+	if pred != nil {
+		if _, err := trc.ValidateUpdate(pred); err != nil {
+			return serrors.Wrap("validating update", err)
+		}
+	}
+	raw, err := trc.Encode()
+	if err != nil {
+		return serrors.Wrap("encoding payload", err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, CeremonyParts), 0755); err != nil {
+		return serrors.Wrap("creating ceremony directory", err, "dir", dir)
+	}
+	if pred != nil {
+		rawPred, err := os.ReadFile(predFile)
+		if err != nil {
+			return serrors.Wrap("reading predecessor", err, "file", predFile)
+		}
+		err = os.WriteFile(filepath.Join(dir, CeremonyPredecessor), rawPred, 0644)
+		if err != nil {
+			return serrors.Wrap("writing predecessor", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, CeremonyPayload), raw, 0644); err != nil {
+		return serrors.Wrap("writing payload", err)
+	}
+	return nil
+}
+
+// LoadCeremony loads the state of the ceremony in the directory. Parts that
+// can not be used are not treated as an error, but reported in the status.
+func LoadCeremony(dir string) (CeremonyStatus, error) {
+	rawPld, err := os.ReadFile(filepath.Join(dir, CeremonyPayload))
+	if err != nil {
+		return CeremonyStatus{}, serrors.Wrap("error loading payload", err)
+	}
+	trc, err := cppki.DecodeTRC(rawPld)
+	if err != nil {
+		return CeremonyStatus{}, serrors.Wrap("error decoding payload", err)
+	}
+	status := CeremonyStatus{
+		TRC:      &trc,
+		Parts:    make(map[string]cppki.SignedTRC),
+		Rejected: make(map[string]error),
+	}
+
+	if !trc.ID.IsBase() {
+		pred, err := DecodeFromFile(filepath.Join(dir, CeremonyPredecessor))
+		if err != nil {
+			return CeremonyStatus{}, serrors.Wrap("error loading predecessor", err)
+		}
+		status.Predecessor = &pred.TRC
+		update, err := trc.ValidateUpdate(status.Predecessor)
+		if err != nil {
+			return CeremonyStatus{}, serrors.Wrap("validating update", err)
+		}
+		status.Update = update
+	}
+	status.Signatures, err = requiredSignatures(status)
+	if err != nil {
+		return CeremonyStatus{}, err
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, CeremonyParts))
+	if err != nil {
+		return CeremonyStatus{}, serrors.Wrap("error listing parts", err)
+	}
+	certs := make([]*x509.Certificate, 0, len(status.Signatures))
+	for _, sig := range status.Signatures {
+		certs = append(certs, sig.Certificate)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := filepath.Join(CeremonyParts, entry.Name())
+		part, err := DecodeFromFile(filepath.Join(dir, name))
+		if err != nil {
+			status.Rejected[name] = serrors.Wrap("error decoding part", err)
+			continue
+		}
+		if !bytes.Equal(part.TRC.Raw, trc.Raw) {
+			status.Rejected[name] = serrors.New("different payload contents")
+			continue
+		}
+		signers, err := partSigners(part, certs)
+		if err != nil {
+			status.Rejected[name] = err
+			continue
+		}
+		if len(signers) == 0 {
+			status.Rejected[name] = serrors.New("no required signature")
+			continue
+		}
+		for i := range status.Signatures {
+			if _, ok := signers[status.Signatures[i].Certificate]; ok {
+				status.Signatures[i].Parts = append(status.Signatures[i].Parts, name)
+			}
+		}
+		status.Parts[name] = part
+	}
+	return status, nil
+}
+
+// CombineCeremony combines the parts of the ceremony and verifies the
+// resulting TRC.
+func CombineCeremony(status CeremonyStatus) ([]byte, error) {
+	if len(status.Parts) == 0 {
+		return nil, serrors.New("no parts to combine")
+	}
+	packed, err := CombineSignedPayloads(status.Parts)
+	if err != nil {
+		return nil, err
+	}
+	signed, err := cppki.DecodeSignedTRC(packed)
+	if err != nil {
+		return nil, serrors.Wrap("error decoding combined TRC", err)
+	}
+	if err := signed.Verify(status.Predecessor); err != nil {
+		return nil, serrors.Wrap("verifying combined TRC", err)
+	}
+	return packed, nil
+}
+
+// requiredSignatures returns the signatures that are required for the TRC.
+// For a base TRC, these are the proofs of possession of all voters. For an
+// update, they are determined by the update rules of the predecessor.
+func requiredSignatures(status CeremonyStatus) ([]CeremonySignature, error) {
+	var sigs []CeremonySignature
+	add := func(typ string, certs []*x509.Certificate) {
+		sorted := append([]*x509.Certificate(nil), certs...)
+		sort.Slice(sorted, func(i, j int) bool {
+			return sorted[i].Subject.CommonName < sorted[j].Subject.CommonName
+		})
+		for _, cert := range sorted {
+			sigs = append(sigs, CeremonySignature{Type: typ, Certificate: cert})
+		}
+	}
+	if status.Predecessor != nil {
+		add(SignatureVote, status.Update.Votes)
+		add(SignatureProofOfPossession, status.Update.NewVoters)
+		add(SignatureAcknowledgement, status.Update.RootAcknowledgments)
+		return sigs, nil
+	}
+	var voters []*x509.Certificate
+	for _, cert := range status.TRC.Certificates {
+		ct, err := cppki.ValidateCert(cert)
+		if err != nil {
+			return nil, serrors.Wrap("classifying certificate", err,
+				"name", cert.Subject.CommonName)
+		}
+		if ct == cppki.Sensitive || ct == cppki.Regular {
+			voters = append(voters, cert)
+		}
+	}
+	add(SignatureProofOfPossession, voters)
+	return sigs, nil
+}
+
+// partSigners returns the certificates of the required signatures that are
+// contained in the part. An invalid signature by a required signer is an error.
+func partSigners(
+	part cppki.SignedTRC,
+	certs []*x509.Certificate,
+) (map[*x509.Certificate]struct{}, error) {
+
+	signers := make(map[*x509.Certificate]struct{})
+	for i, si := range part.SignerInfos {
+		cert, err := si.FindCertificate(certs)
+		if errors.Is(err, protocol.ErrNoCertificate) {
+			continue
+		}
+		if err != nil {
+			return nil, serrors.Wrap("finding certificate", err, "index", i)
+		}
+		err = verifySignerInfo(si, part.TRC.Raw, []*x509.Certificate{cert})
+		if err != nil {
+			return nil, serrors.Wrap("verifying signer info", err, "index", i,
+				"name", cert.Subject.CommonName)
+		}
+		signers[cert] = struct{}{}
+	}
+	return signers, nil
+}
+
+func writeCeremonyStatus(w io.Writer, status CeremonyStatus) {
+	printf := func(format string, ctx ...any) {
+		_, _ = fmt.Fprintf(w, format, ctx...)
+	}
+	printf("TRC:         %s\n", status.TRC.ID)
+	if status.Predecessor == nil {
+		printf("Type:        base\n")
+	} else {
+		printf("Type:        %s update\n", status.Update.Type)
+		printf("Predecessor: %s\n", status.Predecessor.ID)
+		printf("Quorum:      %d (votes: %d)\n", status.Predecessor.Quorum,
+			len(status.Update.Votes))
+	}
+
+	printf("\nRequired signatures:\n")
+	for _, sig := range status.Signatures {
+		state := "missing"
+		if len(sig.Parts) > 0 {
+			state = "present"
+		}
+		printf("  [%s] %s: %s (serial: % X)\n", state, sig.Type,
+			sig.Certificate.Subject.CommonName, sig.Certificate.SerialNumber.Bytes())
+		for _, part := range sig.Parts {
+			printf("            %s\n", part)
+		}
+	}
+
+	if len(status.Rejected) > 0 {
+		names := make([]string, 0, len(status.Rejected))
+		for name := range status.Rejected {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		printf("\nRejected parts:\n")
+		for _, name := range names {
+			printf("  %s: %s\n", name, status.Rejected[name])
+		}
+	}
+
+	missing := status.Missing()
+	if len(missing) == 0 {
+		printf("\nAll %d required signatures are present.\n", len(status.Signatures))
+		return
+	}
+	printf("\n%d of %d required signatures are missing.\n", len(missing), len(status.Signatures))
+}
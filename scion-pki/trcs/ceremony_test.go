@@ -0,0 +1,124 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trcs_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/app/command"
+	"github.com/scionproto/scion/scion-pki/conf"
+	"github.com/scionproto/scion/scion-pki/trcs"
+)
+
+func TestCeremony(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "ceremony")
+	cfg, err := conf.LoadTRC("testdata/admin/ISD1-B1-S1.toml")
+	require.NoError(t, err)
+	require.NoError(t, trcs.InitCeremony(dir, cfg, ""))
+	assert.Error(t, trcs.InitCeremony(dir, cfg, ""), "already initialized")
+
+	expected, err := os.ReadFile("testdata/admin/ISD1-B1-S1.pld.der")
+	require.NoError(t, err)
+	actual, err := os.ReadFile(filepath.Join(dir, trcs.CeremonyPayload))
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	addPart := func(t *testing.T, src, name string) {
+		raw, err := os.ReadFile(src)
+		require.NoError(t, err)
+		dst := filepath.Join(dir, trcs.CeremonyParts, name)
+		require.NoError(t, os.WriteFile(dst, raw, 0o644))
+	}
+
+	status, err := trcs.LoadCeremony(dir)
+	require.NoError(t, err)
+	assert.Nil(t, status.Predecessor)
+	// Every sensitive and regular voter proves possession of its key.
+	assert.Len(t, status.Signatures, 6)
+	assert.Len(t, status.Missing(), 6)
+	for _, sig := range status.Signatures {
+		assert.Equal(t, trcs.SignatureProofOfPossession, sig.Type)
+	}
+	_, err = trcs.CombineCeremony(status)
+	assert.Error(t, err)
+
+	addPart(t, "testdata/admin/bern/ISD1-B1-S1.regular.trc", "bern.regular.trc")
+	addPart(t, "testdata/admin/bern/ISD1-B1-S1.sensitive.trc", "bern.sensitive.trc")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, trcs.CeremonyParts, "garbage.trc"),
+		[]byte("garbage"), 0o644))
+
+	status, err = trcs.LoadCeremony(dir)
+	require.NoError(t, err)
+	assert.Len(t, status.Missing(), 4)
+	assert.Len(t, status.Parts, 2)
+	assert.Contains(t, status.Rejected, filepath.Join(trcs.CeremonyParts, "garbage.trc"))
+	_, err = trcs.CombineCeremony(status)
+	assert.Error(t, err)
+
+	for _, org := range []string{"geneva", "zürich"} {
+		for _, typ := range []string{"regular", "sensitive"} {
+			src := filepath.Join("testdata/admin", org, "ISD1-B1-S1."+typ+".trc")
+			addPart(t, src, org+"."+typ+".trc")
+		}
+	}
+	status, err = trcs.LoadCeremony(dir)
+	require.NoError(t, err)
+	assert.Empty(t, status.Missing())
+	assert.Len(t, status.Parts, 6)
+	for _, sig := range status.Signatures {
+		assert.Len(t, sig.Parts, 1, sig.Certificate.Subject.CommonName)
+	}
+
+	packed, err := trcs.CombineCeremony(status)
+	require.NoError(t, err)
+	signed, err := cppki.DecodeSignedTRC(packed)
+	require.NoError(t, err)
+	assert.Equal(t, expected, signed.TRC.Raw)
+	assert.NoError(t, signed.Verify(nil))
+}
+
+func TestCeremonyCmd(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "ceremony")
+	run := func(t *testing.T, args ...string) string {
+		var out bytes.Buffer
+		cmd := trcs.Cmd(command.StringPather("test"))
+		cmd.SetArgs(append([]string{"ceremony"}, args...))
+		cmd.SetOut(&out)
+		require.NoError(t, cmd.Execute())
+		return out.String()
+	}
+
+	out := run(t, "init", "-t", "testdata/admin/ISD1-B1-S1.toml", dir)
+	assert.Contains(t, out, "Successfully initialized ceremony at "+dir)
+
+	for _, org := range []string{"bern", "geneva", "zürich"} {
+		for _, typ := range []string{"regular", "sensitive"} {
+			raw, err := os.ReadFile(filepath.Join("testdata/admin", org,
+				"ISD1-B1-S1."+typ+".trc"))
+			require.NoError(t, err)
+			dst := filepath.Join(dir, trcs.CeremonyParts, org+"."+typ+".trc")
+			require.NoError(t, os.WriteFile(dst, raw, 0o644))
+		}
+	}
+	out = run(t, "combine", dir)
+	assert.Contains(t, out, "Successfully combined TRC at "+filepath.Join(dir, "ISD1-B1-S1.trc"))
+}
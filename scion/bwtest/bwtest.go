@@ -0,0 +1,390 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bwtest measures the bandwidth between a client and a server.
+//
+// The client requests a test session from the server, with the direction, the duration, the
+// packet size and the target rate. The server accepts it with a random cookie, which the client
+// echoes to start the test. The server thus only sends data to clients that can receive its
+// answers, and cannot be abused to flood a spoofed address. The data packets are then sent at the
+// target rate in the requested directions for the duration of the test. At the end of the test, the server reports
+// the statistics of the packets it received, and the number of packets it sent. The
+// bandwidth, loss, reordering and jitter are thus measured by the receiver of each direction.
+package bwtest
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+// Direction is the direction in which the data is sent.
+type Direction uint8
+
+const (
+	// Upload sends data from the client to the server.
+	Upload Direction = 1 << iota
+	// Download sends data from the server to the client.
+	Download
+	// Both sends data in both directions at the same time.
+	Both = Upload | Download
+)
+
+func (d Direction) String() string {
+	switch d {
+	case Upload:
+		return "upload"
+	case Download:
+		return "download"
+	case Both:
+		return "both"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseDirection parses a direction, the inverse of String.
+func ParseDirection(s string) (Direction, error) {
+	for _, d := range []Direction{Upload, Download, Both} {
+		if d.String() == s {
+			return d, nil
+		}
+	}
+	return 0, serrors.New("unknown direction", "direction", s)
+}
+
+// Config configures a test.
+type Config struct {
+	// Direction is the direction in which the data is sent.
+	Direction Direction
+	// Duration is the time during which the data is sent.
+	Duration time.Duration
+	// PacketSize is the size of the payload of the data packets. It must be at least
+	// MinPacketSize.
+	PacketSize int
+	// Rate is the target rate in bit/s, counting the payload of the data packets.
+	Rate uint64
+	// Timeout is the time to wait for an answer of the server. The data packets still in
+	// flight are also awaited for this time at the end of the test.
+	Timeout time.Duration
+}
+
+func (c Config) validate() error {
+	if c.Direction&Both == 0 || c.Direction&^Both != 0 {
+		return serrors.New("invalid direction", "direction", c.Direction)
+	}
+	if c.Duration <= 0 {
+		return serrors.New("duration must be positive", "duration", c.Duration)
+	}
+	if c.PacketSize < MinPacketSize {
+		return serrors.New("packet size too small", "size", c.PacketSize, "min", MinPacketSize)
+	}
+	if c.Rate == 0 {
+		return serrors.New("rate must be positive")
+	}
+	if c.Timeout <= 0 {
+		return serrors.New("timeout must be positive", "timeout", c.Timeout)
+	}
+	return nil
+}
+
+// Result is the result of a test. The statistics of a direction are nil if no data was sent in
+// that direction.
+type Result struct {
+	Upload   *Stats `json:"upload,omitempty" yaml:"upload,omitempty"`
+	Download *Stats `json:"download,omitempty" yaml:"download,omitempty"`
+}
+
+// ErrRejected is returned if the server rejects the test.
+var ErrRejected = errors.New("test rejected by server")
+
+// controlAttempts is the number of times a control message is sent before giving up.
+const controlAttempts = 3
+
+// Run runs a test with the server that conn is connected to. It blocks until the test is
+// finished or the context is canceled. Run uses the deadlines of conn.
+func Run(ctx context.Context, conn net.Conn, cfg Config) (Result, error) {
+	if err := cfg.validate(); err != nil {
+		return Result{}, err
+	}
+	session := rand.Uint64()
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetReadDeadline(time.Now())
+	})
+	defer stop()
+
+	cookie, err := handshake(conn, session, cfg)
+	if err != nil {
+		return Result{}, err
+	}
+
+	// From now on, the socket is read by the background reader until the result is received.
+	results := make(chan result, 1)
+	readerDone := make(chan struct{})
+	var download receiver
+	var receiving atomic.Bool
+	go func() {
+		defer log.HandlePanic()
+		defer close(readerDone)
+		readData(conn, session, &download, &receiving, results)
+	}()
+	defer func() {
+		_ = conn.SetReadDeadline(time.Now())
+		<-readerDone
+	}()
+
+	start := encodeCookie(msgStart, session, cookie)
+	if _, err := conn.Write(start); err != nil {
+		return Result{}, serrors.Wrap("sending start", err)
+	}
+	if cfg.Direction&Download != 0 {
+		// The download only begins once the server got the start message.
+		resendCtx, stopResend := context.WithCancel(ctx)
+		defer stopResend()
+		go func() {
+			defer log.HandlePanic()
+			resendStart(resendCtx, conn, start, cfg.Timeout, &receiving)
+		}()
+	}
+
+	var sent uint64
+	if cfg.Direction&Upload != 0 {
+		if sent, err = sendData(ctx, conn.Write, session, cfg); err != nil {
+			return Result{}, serrors.Wrap("sending data", err)
+		}
+	} else {
+		select {
+		case <-time.After(cfg.Duration):
+		case <-ctx.Done():
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return Result{}, err
+	}
+	// Let the packets in flight arrive.
+	select {
+	case <-time.After(cfg.Timeout):
+	case <-ctx.Done():
+		return Result{}, ctx.Err()
+	}
+
+	remote, err := finish(ctx, conn, session, cfg.Timeout, results)
+	if err != nil {
+		return Result{}, err
+	}
+	// The reader is stopped before the download statistics are read.
+	_ = conn.SetReadDeadline(time.Now())
+	<-readerDone
+
+	var res Result
+	if cfg.Direction&Upload != 0 {
+		s := newStats(sent, remote, cfg.Duration)
+		res.Upload = &s
+	}
+	if cfg.Direction&Download != 0 {
+		s := newStats(remote.sent, download.result(), cfg.Duration)
+		res.Download = &s
+	}
+	return res, nil
+}
+
+// handshake requests the session and waits until it is accepted. It returns the cookie of the
+// session.
+func handshake(conn net.Conn, session uint64, cfg Config) (uint64, error) {
+	req := encodeRequest(session, request{
+		direction:  cfg.Direction,
+		duration:   cfg.Duration,
+		packetSize: cfg.PacketSize,
+		rate:       cfg.Rate,
+	})
+	buf := make([]byte, 1<<16)
+	for i := 0; i < controlAttempts; i++ {
+		if _, err := conn.Write(req); err != nil {
+			return 0, serrors.Wrap("sending request", err)
+		}
+		if err := conn.SetReadDeadline(time.Now().Add(cfg.Timeout)); err != nil {
+			return 0, err
+		}
+		for {
+			n, err := conn.Read(buf)
+			if isTemporary(err) {
+				continue
+			}
+			if errors.Is(err, os.ErrDeadlineExceeded) {
+				break
+			}
+			if err != nil {
+				return 0, serrors.Wrap("reading answer", err)
+			}
+			hdr, err := decodeHeader(buf[:n])
+			if err != nil || hdr.session != session {
+				continue
+			}
+			switch hdr.typ {
+			case msgAccept:
+				cookie, err := decodeCookie(buf[:n])
+				if err != nil {
+					continue
+				}
+				return cookie, conn.SetReadDeadline(time.Time{})
+			case msgReject:
+				return 0, serrors.Wrap("request failed", ErrRejected,
+					"reason", string(buf[headerLen:n]))
+			}
+		}
+	}
+	return 0, serrors.New("no answer from server", "attempts", controlAttempts)
+}
+
+// resendStart sends the start message again while no data is received, in case it was lost.
+func resendStart(
+	ctx context.Context,
+	conn net.Conn,
+	start []byte,
+	timeout time.Duration,
+	receiving *atomic.Bool,
+) {
+	for i := 1; i < controlAttempts; i++ {
+		select {
+		case <-time.After(timeout):
+		case <-ctx.Done():
+			return
+		}
+		if receiving.Load() {
+			return
+		}
+		if _, err := conn.Write(start); err != nil {
+			return
+		}
+	}
+}
+
+// finish ends the session and waits for the result of the server, which is received by the
+// reader.
+func finish(
+	ctx context.Context,
+	conn net.Conn,
+	session uint64,
+	timeout time.Duration,
+	results <-chan result,
+) (result, error) {
+	msg := encodeHeader(nil, msgFinish, session)
+	for i := 0; i < controlAttempts; i++ {
+		if _, err := conn.Write(msg); err != nil {
+			return result{}, serrors.Wrap("sending finish", err)
+		}
+		select {
+		case r := <-results:
+			return r, nil
+		case <-time.After(timeout):
+		case <-ctx.Done():
+			return result{}, ctx.Err()
+		}
+	}
+	return result{}, serrors.New("no result from server", "attempts", controlAttempts)
+}
+
+// readData reads the data packets and the result of the session, until reading fails. receiving
+// is set once a data packet is received.
+func readData(
+	conn net.Conn,
+	session uint64,
+	r *receiver,
+	receiving *atomic.Bool,
+	results chan<- result,
+) {
+	buf := make([]byte, 1<<16)
+	for {
+		n, err := conn.Read(buf)
+		if isTemporary(err) {
+			continue
+		}
+		if err != nil {
+			return
+		}
+		arrived := time.Now()
+		hdr, err := decodeHeader(buf[:n])
+		if err != nil || hdr.session != session {
+			continue
+		}
+		switch hdr.typ {
+		case msgData:
+			seq, sent, err := decodeData(buf[:n])
+			if err != nil {
+				continue
+			}
+			r.add(seq, sent, arrived, n)
+			receiving.Store(true)
+		case msgResult:
+			res, err := decodeResult(buf[:n])
+			if err != nil {
+				continue
+			}
+			select {
+			case results <- res:
+			default:
+			}
+		}
+	}
+}
+
+// sendData sends the data packets at the configured rate, until the duration passed or the
+// context is canceled. It returns the number of packets sent.
+func sendData(
+	ctx context.Context,
+	write func([]byte) (int, error),
+	session uint64,
+	cfg Config,
+) (uint64, error) {
+	interval := time.Duration(float64(cfg.PacketSize*8) / float64(cfg.Rate) * float64(time.Second))
+	buf := make([]byte, cfg.PacketSize)
+	start := time.Now()
+	end := start.Add(cfg.Duration)
+	var seq uint64
+	for {
+		now := time.Now()
+		if !now.Before(end) || ctx.Err() != nil {
+			return seq, nil
+		}
+		// Sleeping has a coarse granularity, the packets that are due are sent in a burst.
+		if due := start.Add(time.Duration(seq) * interval); due.After(now) {
+			time.Sleep(min(due.Sub(now), end.Sub(now)))
+			continue
+		}
+		encodeData(buf, session, seq, now)
+		if _, err := write(buf); err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return seq, err
+			}
+			// The packet is dropped, like it would be by a full queue on the path.
+			log.Debug("Sending data packet failed", "err", err)
+		}
+		seq++
+	}
+}
+
+// isTemporary is whether a read error does not affect the following reads. This is the case for
+// the SCMP errors that are reported by snet.
+func isTemporary(err error) bool {
+	var opErr *snet.OpError
+	return errors.As(err, &opErr)
+}
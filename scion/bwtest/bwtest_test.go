@@ -0,0 +1,183 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bwtest_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/scion/bwtest"
+)
+
+func startServer(t *testing.T, s *bwtest.Server) net.Addr {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	s.Conn = conn
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Serve(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		assert.NoError(t, <-done)
+	})
+	return conn.LocalAddr()
+}
+
+func dial(t *testing.T, server net.Addr) net.Conn {
+	conn, err := net.DialUDP("udp4", nil, server.(*net.UDPAddr))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestRun(t *testing.T) {
+	server := startServer(t, &bwtest.Server{})
+	cfg := bwtest.Config{
+		Duration:   300 * time.Millisecond,
+		PacketSize: 1000,
+		Rate:       8_000_000,
+		Timeout:    100 * time.Millisecond,
+	}
+	wantPackets := float64(cfg.Rate) * cfg.Duration.Seconds() / float64(cfg.PacketSize*8)
+
+	check := func(t *testing.T, s *bwtest.Stats) {
+		require.NotNil(t, s)
+		assert.InDelta(t, wantPackets, s.Sent, wantPackets*0.1)
+		// The loopback does not lose packets at this rate.
+		assert.Equal(t, s.Sent, s.Received)
+		assert.Zero(t, s.Loss)
+		assert.Equal(t, s.Received*uint64(cfg.PacketSize), s.Bytes)
+		assert.InDelta(t, cfg.Rate, s.Bandwidth, float64(cfg.Rate)*0.1)
+	}
+	for _, dir := range []bwtest.Direction{bwtest.Upload, bwtest.Download, bwtest.Both} {
+		t.Run(dir.String(), func(t *testing.T) {
+			cfg := cfg
+			cfg.Direction = dir
+			res, err := bwtest.Run(context.Background(), dial(t, server), cfg)
+			require.NoError(t, err)
+			if dir&bwtest.Upload != 0 {
+				check(t, res.Upload)
+			} else {
+				assert.Nil(t, res.Upload)
+			}
+			if dir&bwtest.Download != 0 {
+				check(t, res.Download)
+			} else {
+				assert.Nil(t, res.Download)
+			}
+		})
+	}
+}
+
+func TestRunRejected(t *testing.T) {
+	server := startServer(t, &bwtest.Server{MaxDuration: time.Second, MaxRate: 1_000_000})
+	testCases := map[string]bwtest.Config{
+		"duration": {Duration: 2 * time.Second, Rate: 1000},
+		"rate":     {Duration: time.Second, Rate: 2_000_000},
+	}
+	for name, cfg := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg.Direction = bwtest.Upload
+			cfg.PacketSize = bwtest.MinPacketSize
+			cfg.Timeout = time.Second
+			_, err := bwtest.Run(context.Background(), dial(t, server), cfg)
+			assert.ErrorIs(t, err, bwtest.ErrRejected)
+		})
+	}
+}
+
+func TestServerWaitsForStart(t *testing.T) {
+	server := startServer(t, &bwtest.Server{})
+	conn := dial(t, server)
+	buf := make([]byte, 1<<16)
+	read := func(timeout time.Duration) ([]byte, error) {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(timeout)))
+		n, err := conn.Read(buf)
+		return buf[:n], err
+	}
+
+	const session = 42
+	_, err := conn.Write(bwtest.EncodeDownloadRequest(session, time.Second, 1000, 8_000_000))
+	require.NoError(t, err)
+	msg, err := read(time.Second)
+	require.NoError(t, err)
+	cookie, ok := bwtest.DecodeAccept(msg)
+	require.True(t, ok)
+
+	// Without the start message, no data is sent, e.g., to the victim of a spoofed request.
+	_, err = read(200 * time.Millisecond)
+	assert.ErrorIs(t, err, os.ErrDeadlineExceeded)
+	_, err = conn.Write(bwtest.EncodeStart(session, cookie+1))
+	require.NoError(t, err)
+	_, err = read(200 * time.Millisecond)
+	assert.ErrorIs(t, err, os.ErrDeadlineExceeded)
+
+	_, err = conn.Write(bwtest.EncodeStart(session, cookie))
+	require.NoError(t, err)
+	_, err = read(time.Second)
+	assert.NoError(t, err)
+}
+
+func TestRunCanceled(t *testing.T) {
+	server := startServer(t, &bwtest.Server{})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := bwtest.Run(ctx, dial(t, server), bwtest.Config{
+		Direction:  bwtest.Both,
+		Duration:   10 * time.Second,
+		PacketSize: bwtest.MinPacketSize,
+		Rate:       100_000,
+		Timeout:    time.Second,
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestParseBandwidth(t *testing.T) {
+	testCases := map[string]struct {
+		input     string
+		want      uint64
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"plain":     {input: "1200", want: 1200, assertErr: assert.NoError},
+		"bps":       {input: "300bps", want: 300, assertErr: assert.NoError},
+		"kbps":      {input: "64kbps", want: 64_000, assertErr: assert.NoError},
+		"Mbps":      {input: "1.5Mbps", want: 1_500_000, assertErr: assert.NoError},
+		"Gbps":      {input: "10 Gbps", want: 10_000_000_000, assertErr: assert.NoError},
+		"zero":      {input: "0Mbps", assertErr: assert.Error},
+		"negative":  {input: "-1Mbps", assertErr: assert.Error},
+		"no number": {input: "Mbps", assertErr: assert.Error},
+		"bad unit":  {input: "10MB", assertErr: assert.Error},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := bwtest.ParseBandwidth(tc.input)
+			tc.assertErr(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFormatBandwidth(t *testing.T) {
+	assert.Equal(t, "999 bps", bwtest.FormatBandwidth(999))
+	assert.Equal(t, "1.50 Mbps", bwtest.FormatBandwidth(1_500_000))
+	assert.Equal(t, "10.00 Gbps", bwtest.FormatBandwidth(10_000_000_000))
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bwtest
+
+import "time"
+
+// EncodeDownloadRequest encodes the request of a download session.
+func EncodeDownloadRequest(
+	session uint64,
+	duration time.Duration,
+	packetSize int,
+	rate uint64,
+) []byte {
+	return encodeRequest(session, request{
+		direction:  Download,
+		duration:   duration,
+		packetSize: packetSize,
+		rate:       rate,
+	})
+}
+
+// DecodeAccept returns the cookie of an accept message.
+func DecodeAccept(b []byte) (uint64, bool) {
+	hdr, err := decodeHeader(b)
+	if err != nil || hdr.typ != msgAccept {
+		return 0, false
+	}
+	cookie, err := decodeCookie(b)
+	return cookie, err == nil
+}
+
+// EncodeStart encodes a start message.
+func EncodeStart(session, cookie uint64) []byte {
+	return encodeCookie(msgStart, session, cookie)
+}
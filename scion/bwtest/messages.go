@@ -0,0 +1,168 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bwtest
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// The messages start with the type and the session ID. All integers are big endian.
+//
+//	request: direction (1B), duration in ns (8B), packet size (4B), rate in bit/s (8B)
+//	accept:  cookie (8B)
+//	start:   cookie (8B)
+//	reject:  reason (variable)
+//	data:    sequence number (8B), send timestamp in ns (8B), padding
+//	finish:  -
+//	result:  received (8B), bytes (8B), reordered (8B), jitter in ns (8B), sent (8B)
+type msgType uint8
+
+const (
+	msgRequest msgType = iota + 1
+	msgAccept
+	msgReject
+	msgData
+	msgFinish
+	msgResult
+	msgStart
+)
+
+const (
+	headerLen  = 1 + 8
+	requestLen = headerLen + 1 + 8 + 4 + 8
+	cookieLen  = headerLen + 8
+	dataLen    = headerLen + 8 + 8
+	resultLen  = headerLen + 5*8
+
+	// MinPacketSize is the smallest size of the payload of the data packets.
+	MinPacketSize = dataLen
+)
+
+var errMalformed = serrors.New("malformed message")
+
+type header struct {
+	typ     msgType
+	session uint64
+}
+
+func decodeHeader(b []byte) (header, error) {
+	if len(b) < headerLen {
+		return header{}, errMalformed
+	}
+	return header{typ: msgType(b[0]), session: binary.BigEndian.Uint64(b[1:9])}, nil
+}
+
+func encodeHeader(b []byte, typ msgType, session uint64) []byte {
+	b = append(b, byte(typ))
+	return binary.BigEndian.AppendUint64(b, session)
+}
+
+type request struct {
+	direction  Direction
+	duration   time.Duration
+	packetSize int
+	rate       uint64
+}
+
+func encodeRequest(session uint64, r request) []byte {
+	b := encodeHeader(make([]byte, 0, requestLen), msgRequest, session)
+	b = append(b, byte(r.direction))
+	b = binary.BigEndian.AppendUint64(b, uint64(r.duration))
+	b = binary.BigEndian.AppendUint32(b, uint32(r.packetSize))
+	return binary.BigEndian.AppendUint64(b, r.rate)
+}
+
+func decodeRequest(b []byte) (request, error) {
+	if len(b) < requestLen {
+		return request{}, errMalformed
+	}
+	b = b[headerLen:]
+	return request{
+		direction:  Direction(b[0]),
+		duration:   time.Duration(binary.BigEndian.Uint64(b[1:9])),
+		packetSize: int(binary.BigEndian.Uint32(b[9:13])),
+		rate:       binary.BigEndian.Uint64(b[13:21]),
+	}, nil
+}
+
+// encodeCookie encodes an accept or a start message, which carry the cookie of the session.
+func encodeCookie(typ msgType, session, cookie uint64) []byte {
+	b := encodeHeader(make([]byte, 0, cookieLen), typ, session)
+	return binary.BigEndian.AppendUint64(b, cookie)
+}
+
+func decodeCookie(b []byte) (uint64, error) {
+	if len(b) < cookieLen {
+		return 0, errMalformed
+	}
+	return binary.BigEndian.Uint64(b[headerLen:]), nil
+}
+
+func encodeReject(session uint64, reason string) []byte {
+	b := encodeHeader(make([]byte, 0, headerLen+len(reason)), msgReject, session)
+	return append(b, reason...)
+}
+
+// encodeData writes a data packet into b, which has the size of the packet.
+func encodeData(b []byte, session, seq uint64, sent time.Time) {
+	encodeHeader(b[:0], msgData, session)
+	binary.BigEndian.PutUint64(b[headerLen:], seq)
+	binary.BigEndian.PutUint64(b[headerLen+8:], uint64(sent.UnixNano()))
+}
+
+func decodeData(b []byte) (uint64, time.Time, error) {
+	if len(b) < dataLen {
+		return 0, time.Time{}, errMalformed
+	}
+	seq := binary.BigEndian.Uint64(b[headerLen:])
+	sent := time.Unix(0, int64(binary.BigEndian.Uint64(b[headerLen+8:])))
+	return seq, sent, nil
+}
+
+// result is what the server reports at the end of a session: the statistics of the packets it
+// received, and the number of packets it sent.
+type result struct {
+	received  uint64
+	bytes     uint64
+	reordered uint64
+	jitter    time.Duration
+	sent      uint64
+}
+
+func encodeResult(session uint64, r result) []byte {
+	b := encodeHeader(make([]byte, 0, resultLen), msgResult, session)
+	b = binary.BigEndian.AppendUint64(b, r.received)
+	b = binary.BigEndian.AppendUint64(b, r.bytes)
+	b = binary.BigEndian.AppendUint64(b, r.reordered)
+	b = binary.BigEndian.AppendUint64(b, uint64(r.jitter))
+	return binary.BigEndian.AppendUint64(b, r.sent)
+}
+
+func decodeResult(b []byte) (result, error) {
+	if len(b) < resultLen {
+		return result{}, errMalformed
+	}
+	b = b[headerLen:]
+	return result{
+		received:  binary.BigEndian.Uint64(b[0:8]),
+		bytes:     binary.BigEndian.Uint64(b[8:16]),
+		reordered: binary.BigEndian.Uint64(b[16:24]),
+		jitter:    time.Duration(binary.BigEndian.Uint64(b[24:32])),
+		sent:      binary.BigEndian.Uint64(b[32:40]),
+	}, nil
+}
@@ -0,0 +1,258 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bwtest
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+const (
+	defaultMaxDuration = 30 * time.Second
+	defaultMaxRate     = 100_000_000
+	defaultMaxSessions = 16
+	// acceptTimeout is the time that an accepted session waits for the start message.
+	acceptTimeout = 10 * time.Second
+	// sessionGrace is the time that a session is kept after its data was sent, to answer the
+	// late finish messages.
+	sessionGrace = 30 * time.Second
+)
+
+// Server answers the test requests of clients.
+type Server struct {
+	// Conn is the connection on which the requests are received.
+	Conn net.PacketConn
+	// MaxDuration is the longest test duration that is accepted. If zero, 30s is used.
+	MaxDuration time.Duration
+	// MaxRate is the highest rate in bit/s that is accepted. If zero, 100 Mbit/s is used.
+	MaxRate uint64
+	// MaxSessions is the number of tests that can run at the same time. If zero, 16 is used.
+	MaxSessions int
+}
+
+type serverSession struct {
+	remote string
+	req    request
+	// cookie is sent to the client with the accept, and must be echoed in the start message.
+	// The data is only sent once the session is started.
+	cookie   uint64
+	started  bool
+	expires  time.Time
+	receiver receiver
+	sent     atomic.Uint64
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// Serve answers the requests until the context is canceled or reading from the connection
+// fails.
+func (s *Server) Serve(ctx context.Context) error {
+	maxSessions := s.MaxSessions
+	if maxSessions == 0 {
+		maxSessions = defaultMaxSessions
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(ctx, func() {
+		_ = s.Conn.SetReadDeadline(time.Now())
+	})
+	defer stop()
+
+	sessions := make(map[uint64]*serverSession)
+	var wg sync.WaitGroup
+	defer func() {
+		for _, sess := range sessions {
+			sess.cancel()
+		}
+		wg.Wait()
+	}()
+
+	buf := make([]byte, 1<<16)
+	lastExpiry := time.Now()
+	for {
+		n, from, err := s.Conn.ReadFrom(buf)
+		if isTemporary(err) {
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil && errors.Is(err, os.ErrDeadlineExceeded) {
+				return nil
+			}
+			return serrors.Wrap("reading", err)
+		}
+		now := time.Now()
+		if now.Sub(lastExpiry) > time.Second {
+			for id, sess := range sessions {
+				if now.After(sess.expires) {
+					sess.cancel()
+					delete(sessions, id)
+				}
+			}
+			lastExpiry = now
+		}
+		hdr, err := decodeHeader(buf[:n])
+		if err != nil {
+			continue
+		}
+		sess, ok := sessions[hdr.session]
+		if ok && sess.remote != from.String() {
+			continue
+		}
+		switch hdr.typ {
+		case msgRequest:
+			if ok {
+				// The accept was lost, the request is retransmitted.
+				s.write(encodeCookie(msgAccept, hdr.session, sess.cookie), from)
+				continue
+			}
+			req, err := decodeRequest(buf[:n])
+			if err != nil {
+				continue
+			}
+			if reason := s.check(req, len(sessions), maxSessions); reason != "" {
+				log.Debug("Rejecting test", "remote", from, "reason", reason)
+				s.write(encodeReject(hdr.session, reason), from)
+				continue
+			}
+			sessions[hdr.session] = s.accept(hdr.session, req, from)
+		case msgStart:
+			if !ok || sess.started {
+				continue
+			}
+			if cookie, err := decodeCookie(buf[:n]); err != nil || cookie != sess.cookie {
+				continue
+			}
+			s.start(ctx, &wg, hdr.session, sess, from)
+			log.Info("Starting test", "remote", from, "direction", sess.req.direction,
+				"duration", sess.req.duration, "size", sess.req.packetSize,
+				"rate", sess.req.rate)
+		case msgData:
+			if !ok {
+				continue
+			}
+			seq, sent, err := decodeData(buf[:n])
+			if err != nil {
+				continue
+			}
+			sess.receiver.add(seq, sent, now, n)
+		case msgFinish:
+			if !ok {
+				continue
+			}
+			// The data is sent for the same duration on both sides, the sender is done by now
+			// unless the finish message was sent early.
+			sess.cancel()
+			if sess.started {
+				<-sess.done
+			}
+			res := sess.receiver.result()
+			res.sent = sess.sent.Load()
+			s.write(encodeResult(hdr.session, res), from)
+		}
+	}
+}
+
+// check returns the reason why a request is rejected, or the empty string.
+func (s *Server) check(req request, sessions, maxSessions int) string {
+	maxDuration := s.MaxDuration
+	if maxDuration == 0 {
+		maxDuration = defaultMaxDuration
+	}
+	maxRate := s.MaxRate
+	if maxRate == 0 {
+		maxRate = defaultMaxRate
+	}
+	cfg := Config{
+		Direction:  req.direction,
+		Duration:   req.duration,
+		PacketSize: req.packetSize,
+		Rate:       req.rate,
+		Timeout:    time.Second,
+	}
+	switch err := cfg.validate(); {
+	case err != nil:
+		return err.Error()
+	case req.duration > maxDuration:
+		return "duration exceeds " + maxDuration.String()
+	case req.rate > maxRate:
+		return "rate exceeds " + FormatBandwidth(maxRate)
+	case sessions >= maxSessions:
+		return "too many tests running"
+	}
+	return ""
+}
+
+// accept accepts the requested session. Nothing is sent until the client starts the session,
+// which proves that it receives the answers sent to its address.
+func (s *Server) accept(id uint64, req request, remote net.Addr) *serverSession {
+	sess := &serverSession{
+		remote:  remote.String(),
+		req:     req,
+		cookie:  rand.Uint64(),
+		expires: time.Now().Add(acceptTimeout),
+		cancel:  func() {},
+		done:    make(chan struct{}),
+	}
+	s.write(encodeCookie(msgAccept, id, sess.cookie), remote)
+	log.Debug("Accepting test", "remote", remote)
+	return sess
+}
+
+// start starts the session, and sends the data to remote if requested.
+func (s *Server) start(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	id uint64,
+	sess *serverSession,
+	remote net.Addr,
+) {
+	ctx, cancel := context.WithCancel(ctx)
+	req := sess.req
+	sess.started = true
+	sess.expires = time.Now().Add(req.duration + sessionGrace)
+	sess.cancel = cancel
+	if req.direction&Download == 0 {
+		close(sess.done)
+		return
+	}
+	wg.Add(1)
+	go func() {
+		defer log.HandlePanic()
+		defer wg.Done()
+		defer close(sess.done)
+		cfg := Config{Duration: req.duration, PacketSize: req.packetSize, Rate: req.rate}
+		write := func(b []byte) (int, error) { return s.Conn.WriteTo(b, remote) }
+		sent, err := sendData(ctx, write, id, cfg)
+		sess.sent.Store(sent)
+		if err != nil {
+			log.Debug("Sending data failed", "remote", remote, "err", err)
+		}
+	}()
+}
+
+func (s *Server) write(b []byte, to net.Addr) {
+	if _, err := s.Conn.WriteTo(b, to); err != nil {
+		log.Debug("Sending answer failed", "remote", to, "err", err)
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bwtest
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// Stats are the statistics of the packets sent in one direction.
+type Stats struct {
+	// Sent is the number of packets sent.
+	Sent uint64 `json:"sent" yaml:"sent"`
+	// Received is the number of packets received.
+	Received uint64 `json:"received" yaml:"received"`
+	// Bytes is the number of payload bytes received.
+	Bytes uint64 `json:"bytes" yaml:"bytes"`
+	// Bandwidth is the achieved bandwidth in bit/s, computed from the received payload bytes
+	// over the duration of the test.
+	Bandwidth uint64 `json:"bandwidth_bps" yaml:"bandwidth_bps"`
+	// Loss is the fraction of the sent packets that were not received.
+	Loss float64 `json:"loss" yaml:"loss"`
+	// Reordered is the number of packets received after a packet that was sent later.
+	Reordered uint64 `json:"reordered" yaml:"reordered"`
+	// Jitter is the interarrival jitter as defined in RFC 3550.
+	Jitter time.Duration `json:"jitter" yaml:"jitter"`
+}
+
+func newStats(sent uint64, r result, duration time.Duration) Stats {
+	s := Stats{
+		Sent:      sent,
+		Received:  r.received,
+		Bytes:     r.bytes,
+		Reordered: r.reordered,
+		Jitter:    r.jitter,
+	}
+	if duration > 0 {
+		s.Bandwidth = uint64(float64(r.bytes*8) / duration.Seconds())
+	}
+	if sent > 0 && r.received < sent {
+		s.Loss = float64(sent-r.received) / float64(sent)
+	}
+	return s
+}
+
+// receiver accumulates the statistics of the received data packets.
+type receiver struct {
+	received  uint64
+	bytes     uint64
+	reordered uint64
+	maxSeq    uint64
+	// jitter is in nanoseconds.
+	jitter      float64
+	lastTransit time.Duration
+}
+
+func (r *receiver) add(seq uint64, sent, arrived time.Time, size int) {
+	// The clocks of sender and receiver are not synchronized, but the offset cancels out in the
+	// difference of the transit times.
+	transit := arrived.Sub(sent)
+	if r.received > 0 {
+		if seq < r.maxSeq {
+			r.reordered++
+		}
+		d := math.Abs(float64(transit - r.lastTransit))
+		r.jitter += (d - r.jitter) / 16
+	}
+	r.received++
+	r.bytes += uint64(size)
+	r.maxSeq = max(r.maxSeq, seq)
+	r.lastTransit = transit
+}
+
+func (r *receiver) result() result {
+	return result{
+		received:  r.received,
+		bytes:     r.bytes,
+		reordered: r.reordered,
+		jitter:    time.Duration(r.jitter),
+	}
+}
+
+var bandwidthUnits = []struct {
+	name   string
+	factor float64
+}{
+	{"Gbps", 1e9},
+	{"Mbps", 1e6},
+	{"kbps", 1e3},
+	{"bps", 1},
+}
+
+// ParseBandwidth parses a bandwidth in bit/s, with an optional unit suffix: bps, kbps, Mbps
+// or Gbps. For example, "1.5Mbps" is 1500000 bit/s.
+func ParseBandwidth(s string) (uint64, error) {
+	lower := strings.ToLower(strings.TrimSpace(s))
+	factor := 1.0
+	for _, u := range bandwidthUnits {
+		if suffix := strings.ToLower(u.name); strings.HasSuffix(lower, suffix) {
+			lower = strings.TrimSpace(strings.TrimSuffix(lower, suffix))
+			factor = u.factor
+			break
+		}
+	}
+	v, err := strconv.ParseFloat(lower, 64)
+	if err != nil {
+		return 0, serrors.Wrap("parsing bandwidth", err, "input", s)
+	}
+	bw := v * factor
+	if bw < 1 || math.IsInf(bw, 0) || bw > math.MaxUint64 {
+		return 0, serrors.New("bandwidth out of range", "input", s)
+	}
+	return uint64(bw), nil
+}
+
+// FormatBandwidth formats a bandwidth in bit/s with the largest unit that fits.
+func FormatBandwidth(bps uint64) string {
+	for _, u := range bandwidthUnits[:len(bandwidthUnits)-1] {
+		if float64(bps) >= u.factor {
+			return fmt.Sprintf("%.2f %s", float64(bps)/u.factor, u.name)
+		}
+	}
+	return fmt.Sprintf("%d bps", bps)
+}
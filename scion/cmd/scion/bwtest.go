@@ -0,0 +1,450 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/snet/addrutil"
+	"github.com/scionproto/scion/private/app"
+	"github.com/scionproto/scion/private/app/flag"
+	"github.com/scionproto/scion/private/app/path"
+	"github.com/scionproto/scion/private/path/pathpol"
+	"github.com/scionproto/scion/private/tracing"
+	"github.com/scionproto/scion/scion/bwtest"
+)
+
+// BwtestResult is the result of the bandwidth test on one path.
+type BwtestResult struct {
+	Path     Path         `json:"path" yaml:"path"`
+	Upload   *BwtestStats `json:"upload,omitempty" yaml:"upload,omitempty"`
+	Download *BwtestStats `json:"download,omitempty" yaml:"download,omitempty"`
+	Error    string       `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// BwtestStats are the statistics of one direction of the bandwidth test.
+type BwtestStats struct {
+	Sent      uint64         `json:"sent" yaml:"sent"`
+	Received  uint64         `json:"received" yaml:"received"`
+	Bytes     uint64         `json:"bytes" yaml:"bytes"`
+	Bandwidth uint64         `json:"bandwidth_bps" yaml:"bandwidth_bps"`
+	Loss      float64        `json:"packet_loss" yaml:"packet_loss"`
+	Reordered uint64         `json:"reordered" yaml:"reordered"`
+	Jitter    durationMillis `json:"jitter" yaml:"jitter"`
+}
+
+func newBwtestStats(s *bwtest.Stats) *BwtestStats {
+	if s == nil {
+		return nil
+	}
+	return &BwtestStats{
+		Sent:      s.Sent,
+		Received:  s.Received,
+		Bytes:     s.Bytes,
+		Bandwidth: s.Bandwidth,
+		// The loss is reported in percent, like for ping.
+		Loss:      s.Loss * 100,
+		Reordered: s.Reordered,
+		Jitter:    durationMillis(s.Jitter),
+	}
+}
+
+func newBwtest(pather CommandPather) *cobra.Command {
+	var envFlags flag.SCIONEnvironment
+	var flags struct {
+		direction   string
+		duration    time.Duration
+		format      string
+		interactive bool
+		logLevel    string
+		maxPaths    int
+		noColor     bool
+		policy      string
+		rate        string
+		refresh     bool
+		sequence    string
+		size        int
+		timeout     time.Duration
+		tracer      string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "bwtest [flags] <remote>",
+		Short: "Measure the bandwidth to a remote SCION host",
+		Example: fmt.Sprintf(`  %[1]s bwtest 1-ff00:0:110,10.0.0.1:30100
+  %[1]s bwtest 1-ff00:0:110,10.0.0.1:30100 --rate 100Mbps --direction download
+  %[1]s bwtest 1-ff00:0:110,10.0.0.1:30100 --policy policy.json --max-paths 3`,
+			pather.CommandPath()),
+		Long: fmt.Sprintf(`'bwtest' measures the bandwidth to a remote SCION host that
+runs '%[1]s server'.
+
+The data is sent at the target rate for the duration of the test, from the client
+to the server (upload), from the server to the client (download), or in both
+directions at the same time. For each direction, the achieved bandwidth, the
+packet loss, the number of reordered packets and the interarrival jitter are
+measured by the receiver.
+
+The paths can be filtered with a sequence and with a path policy file in JSON
+format. When the \--max-paths option is set, the test is run on up to that many
+of the matching paths, one after the other, starting with the shortest.
+
+If a test fails on any path, bwtest will exit with code 1.
+On other errors, bwtest will exit with code 2.
+
+%[2]s`, pather.CommandPath()+" bwtest", app.SequenceHelp),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			remote, err := snet.ParseUDPAddr(args[0])
+			if err != nil {
+				return serrors.Wrap("parsing remote", err)
+			}
+			if remote.Host.Port == 0 {
+				return serrors.New("remote port must be set", "remote", args[0])
+			}
+			direction, err := bwtest.ParseDirection(flags.direction)
+			if err != nil {
+				return err
+			}
+			rate, err := bwtest.ParseBandwidth(flags.rate)
+			if err != nil {
+				return err
+			}
+			if flags.maxPaths < 1 {
+				return serrors.New("max-paths must be at least 1", "max_paths", flags.maxPaths)
+			}
+			var policy *pathpol.Policy
+			if flags.policy != "" {
+				if policy, err = path.LoadPolicy(flags.policy); err != nil {
+					return err
+				}
+			}
+			if err := app.SetupLog(flags.logLevel); err != nil {
+				return serrors.Wrap("setting up logging", err)
+			}
+			closer, err := setupTracer("bwtest", flags.tracer)
+			if err != nil {
+				return serrors.Wrap("setting up tracing", err)
+			}
+			defer closer()
+			printf, err := getPrintf(flags.format, cmd.OutOrStdout())
+			if err != nil {
+				return serrors.Wrap("get formatting", err)
+			}
+
+			cmd.SilenceUsage = true
+
+			if err := envFlags.LoadExternalVars(); err != nil {
+				return err
+			}
+			daemonAddr := envFlags.Daemon()
+			localIP := net.IP(envFlags.Local().AsSlice())
+			log.Debug("Resolved SCION environment flags",
+				"daemon", daemonAddr,
+				"local", localIP,
+			)
+
+			span, traceCtx := tracing.CtxWith(context.Background(), "run")
+			span.SetTag("dst.isd_as", remote.IA)
+			span.SetTag("dst.host", remote.Host.IP)
+			defer span.Finish()
+
+			ctx, cancelF := context.WithTimeout(traceCtx, time.Second)
+			defer cancelF()
+			sd, err := daemon.NewService(daemonAddr).Connect(ctx)
+			if err != nil {
+				return serrors.Wrap("connecting to SCION Daemon", err)
+			}
+			defer sd.Close()
+			topo, err := daemon.LoadTopology(ctx, sd)
+			if err != nil {
+				return serrors.Wrap("loading topology", err)
+			}
+			span.SetTag("src.isd_as", topo.LocalIA)
+
+			opts := []path.Option{
+				path.WithInteractive(flags.interactive),
+				path.WithRefresh(flags.refresh),
+				path.WithSequence(flags.sequence),
+				path.WithPolicy(policy),
+				path.WithColorScheme(path.DefaultColorScheme(flags.noColor)),
+			}
+			var paths []snet.Path
+			if flags.maxPaths == 1 {
+				p, err := path.Choose(traceCtx, sd, remote.IA, opts...)
+				if err != nil {
+					return err
+				}
+				paths = []snet.Path{p}
+			} else {
+				if paths, err = path.Fetch(traceCtx, sd, remote.IA, opts...); err != nil {
+					return err
+				}
+				paths = paths[:min(len(paths), flags.maxPaths)]
+			}
+
+			cfg := bwtest.Config{
+				Direction:  direction,
+				Duration:   flags.duration,
+				PacketSize: flags.size,
+				Rate:       rate,
+				Timeout:    flags.timeout,
+			}
+			printf("BWTEST %s direction=%s duration=%s rate=%s pld=%dB\n", remote,
+				direction, flags.duration, bwtest.FormatBandwidth(rate), flags.size)
+
+			sn := &snet.SCIONNetwork{
+				Topology: topo,
+				SCMPHandler: snet.DefaultSCMPHandler{
+					RevocationHandler: daemon.RevHandler{Connector: sd},
+				},
+			}
+			ctx = app.WithSignal(traceCtx, os.Interrupt, syscall.SIGTERM)
+			var results []BwtestResult
+			var failed int
+			for _, p := range paths {
+				res, err := runBwtest(ctx, sn, p, remote, localIP, cfg)
+				if err != nil {
+					if ctx.Err() != nil {
+						return err
+					}
+					failed++
+				}
+				results = append(results, res)
+				printf("\nPath: %s\n", p)
+				if res.Error != "" {
+					printf("  error: %s\n", res.Error)
+					continue
+				}
+				printBwtestStats(printf, "upload", res.Upload)
+				printBwtestStats(printf, "download", res.Download)
+			}
+
+			switch flags.format {
+			case "json":
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				enc.SetEscapeHTML(false)
+				if err := enc.Encode(results); err != nil {
+					return err
+				}
+			case "yaml":
+				enc := yaml.NewEncoder(os.Stdout)
+				if err := enc.Encode(results); err != nil {
+					return err
+				}
+			}
+			if failed > 0 {
+				return app.WithExitCode(serrors.New("bandwidth test failed",
+					"failed_paths", failed, "paths", len(paths)), 1)
+			}
+			return nil
+		},
+	}
+
+	envFlags.Register(cmd.Flags())
+	cmd.Flags().StringVar(&flags.direction, "direction", "both",
+		"direction of the data (upload|download|both)")
+	cmd.Flags().DurationVar(&flags.duration, "duration", 3*time.Second,
+		"time during which the data is sent")
+	cmd.Flags().IntVarP(&flags.size, "payload-size", "s", 1000,
+		fmt.Sprintf(`number of bytes of the payload of the data packets, at least %d;
+the total size of the packets is larger due to the SCION and UDP headers.`,
+			bwtest.MinPacketSize),
+	)
+	cmd.Flags().StringVar(&flags.rate, "rate", "10Mbps",
+		"target rate of the data in each direction, in bps, kbps, Mbps or Gbps")
+	cmd.Flags().DurationVar(&flags.timeout, "timeout", time.Second,
+		"time to wait for answers of the server")
+	cmd.Flags().IntVar(&flags.maxPaths, "max-paths", 1, "maximum number of paths to test")
+	cmd.Flags().StringVar(&flags.policy, "policy", "", "path policy file in JSON format")
+	cmd.Flags().BoolVarP(&flags.interactive, "interactive", "i", false,
+		"interactive mode, only with a single path")
+	cmd.Flags().BoolVar(&flags.noColor, "no-color", false, "disable colored output")
+	cmd.Flags().StringVar(&flags.sequence, "sequence", "", app.SequenceUsage)
+	cmd.Flags().BoolVar(&flags.refresh, "refresh", false, "set refresh flag for path request")
+	cmd.Flags().StringVar(&flags.logLevel, "log.level", "", app.LogLevelUsage)
+	cmd.Flags().StringVar(&flags.tracer, "tracing.agent", "", "Tracing agent address")
+	cmd.Flags().StringVar(&flags.format, "format", "human",
+		"Specify the output format (human|json|yaml)")
+	cmd.MarkFlagsMutuallyExclusive("interactive", "max-paths")
+	cmd.AddCommand(newBwtestServer(pather))
+	return cmd
+}
+
+func runBwtest(
+	ctx context.Context,
+	sn *snet.SCIONNetwork,
+	p snet.Path,
+	remote *snet.UDPAddr,
+	localIP net.IP,
+	cfg bwtest.Config,
+) (BwtestResult, error) {
+	var res BwtestResult
+	fail := func(err error) (BwtestResult, error) {
+		res.Error = err.Error()
+		return res, err
+	}
+	remote = remote.Copy()
+	remote.Path = p.Dataplane()
+	remote.NextHop = p.UnderlayNextHop()
+	if localIP == nil {
+		target := remote.Host.IP
+		if remote.NextHop != nil {
+			target = remote.NextHop.IP
+		}
+		var err error
+		if localIP, err = addrutil.ResolveLocal(target); err != nil {
+			return fail(serrors.Wrap("resolving local address", err))
+		}
+	}
+	seq, err := pathpol.GetSequence(p)
+	if err != nil {
+		return fail(serrors.New("get sequence from used path"))
+	}
+	res.Path = Path{
+		Fingerprint: p.Metadata().Fingerprint().String(),
+		Hops:        getHops(p),
+		Sequence:    seq,
+		LocalIP:     localIP,
+		NextHop:     remote.NextHop.String(),
+	}
+
+	conn, err := sn.Dial(ctx, "udp", &net.UDPAddr{IP: localIP}, remote)
+	if err != nil {
+		return fail(serrors.Wrap("opening connection", err))
+	}
+	defer conn.Close()
+	r, err := bwtest.Run(ctx, conn, cfg)
+	if err != nil {
+		return fail(err)
+	}
+	res.Upload = newBwtestStats(r.Upload)
+	res.Download = newBwtestStats(r.Download)
+	return res, nil
+}
+
+func printBwtestStats(printf func(string, ...any), direction string, s *BwtestStats) {
+	if s == nil {
+		return
+	}
+	printf("  %-8s %s, %d/%d packets received, %.2f%% packet loss, "+
+		"%d reordered, jitter %s\n",
+		direction+":", bwtest.FormatBandwidth(s.Bandwidth), s.Received, s.Sent, s.Loss,
+		s.Reordered, s.Jitter)
+}
+
+func newBwtestServer(pather CommandPather) *cobra.Command {
+	var envFlags flag.SCIONEnvironment
+	var flags struct {
+		logLevel    string
+		maxDuration time.Duration
+		maxRate     string
+		maxSessions int
+		port        uint16
+	}
+
+	cmd := &cobra.Command{
+		Use:   "server [flags]",
+		Short: "Answer bandwidth tests of remote SCION hosts",
+		Example: fmt.Sprintf(`  %[1]s bwtest server
+  %[1]s bwtest server --local 10.0.0.1 --port 30100 --max-rate 100Mbps`, pather.CommandPath()),
+		Long: `'server' answers the bandwidth tests of remote SCION hosts that run 'bwtest'.
+
+The server runs until it is interrupted. Tests that exceed the limits of the server
+are rejected. The server only sends data to a client once the client has confirmed
+that it receives the answers of the server, so that the server cannot be used to
+flood the address of another host with spoofed requests.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			maxRate, err := bwtest.ParseBandwidth(flags.maxRate)
+			if err != nil {
+				return err
+			}
+			if err := app.SetupLog(flags.logLevel); err != nil {
+				return serrors.Wrap("setting up logging", err)
+			}
+			cmd.SilenceUsage = true
+
+			if err := envFlags.LoadExternalVars(); err != nil {
+				return err
+			}
+			daemonAddr := envFlags.Daemon()
+			localIP := net.IP(envFlags.Local().AsSlice())
+
+			ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+			defer cancelF()
+			sd, err := daemon.NewService(daemonAddr).Connect(ctx)
+			if err != nil {
+				return serrors.Wrap("connecting to SCION Daemon", err)
+			}
+			defer sd.Close()
+			topo, err := daemon.LoadTopology(ctx, sd)
+			if err != nil {
+				return serrors.Wrap("loading topology", err)
+			}
+			if localIP == nil {
+				localIP, err = addrutil.DefaultLocalIP(ctx, daemon.TopoQuerier{Connector: sd})
+				if err != nil {
+					return serrors.Wrap("determining local address", err)
+				}
+			}
+
+			sn := &snet.SCIONNetwork{
+				Topology: topo,
+				SCMPHandler: snet.DefaultSCMPHandler{
+					RevocationHandler: daemon.RevHandler{Connector: sd},
+				},
+			}
+			conn, err := sn.Listen(ctx, "udp", &net.UDPAddr{IP: localIP, Port: int(flags.port)})
+			if err != nil {
+				return serrors.Wrap("listening", err)
+			}
+			defer conn.Close()
+			fmt.Fprintf(cmd.OutOrStdout(), "Listening on %s\n", conn.LocalAddr())
+
+			server := &bwtest.Server{
+				Conn:        conn,
+				MaxDuration: flags.maxDuration,
+				MaxRate:     maxRate,
+				MaxSessions: flags.maxSessions,
+			}
+			return server.Serve(app.WithSignal(context.Background(), os.Interrupt, syscall.SIGTERM))
+		},
+	}
+
+	envFlags.Register(cmd.Flags())
+	cmd.Flags().Uint16Var(&flags.port, "port", 30100, "port to listen on")
+	cmd.Flags().DurationVar(&flags.maxDuration, "max-duration", 30*time.Second,
+		"longest test duration that is accepted")
+	cmd.Flags().StringVar(&flags.maxRate, "max-rate", "100Mbps",
+		"highest rate that is accepted")
+	cmd.Flags().IntVar(&flags.maxSessions, "max-sessions", 16,
+		"number of tests that can run at the same time")
+	cmd.Flags().StringVar(&flags.logLevel, "log.level", "", app.LogLevelUsage)
+	return cmd
+}
@@ -0,0 +1,341 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	api "github.com/scionproto/scion/gateway/mgmtapi"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/ptr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/private/app/command"
+)
+
+// gatewayFlags are the flags shared by the gateway subcommands.
+type gatewayFlags struct {
+	api     string
+	timeout time.Duration
+	format  string
+}
+
+func (f *gatewayFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.api, "api", "localhost:30456",
+		"Address of the management API of the gateway")
+	cmd.Flags().DurationVar(&f.timeout, "timeout", 5*time.Second, "Timeout")
+	cmd.Flags().StringVar(&f.format, "format", "human",
+		"Specify the output format (human|json|yaml)")
+}
+
+func (f *gatewayFlags) client() (*api.ClientWithResponses, error) {
+	server := f.api
+	if !strings.Contains(server, "://") {
+		server = "http://" + server
+	}
+	return api.NewClientWithResponses(strings.TrimSuffix(server, "/") + "/api/v1")
+}
+
+func newGateway(pather CommandPather) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "gateway",
+		Short: "Inspect the state of a SCION IP gateway",
+		Args:  cobra.NoArgs,
+		Example: fmt.Sprintf(`  %[1]s gateway sessions
+  %[1]s gateway remotes --api 127.0.0.1:30456`, pather.CommandPath()),
+		Long: `'gateway' inspects the state of a running SCION IP gateway through its
+management API.
+
+The address of the management API is set in the [api] section of the gateway
+configuration. The management API must be reachable from this host.
+`,
+	}
+	joined := command.Join(pather, cmd)
+	cmd.AddCommand(
+		newGatewayRemotes(joined),
+		newGatewaySessions(joined),
+		newGatewayPrefixes(joined),
+	)
+	return cmd
+}
+
+func newGatewayRemotes(pather CommandPather) *cobra.Command {
+	var flags gatewayFlags
+	var cmd = &cobra.Command{
+		Use:     "remotes [flags]",
+		Short:   "List the remote gateways discovered by a gateway",
+		Args:    cobra.NoArgs,
+		Example: fmt.Sprintf(`  %[1]s remotes --api 127.0.0.1:30456`, pather.CommandPath()),
+		Long: `'remotes' lists the remote gateways that the gateway discovered in the
+remote ASes for which a session policy is configured, together with the IP
+prefixes that they announce.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := flags.client()
+			if err != nil {
+				return serrors.Wrap("creating client", err)
+			}
+			cmd.SilenceUsage = true
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), flags.timeout)
+			defer cancel()
+			rep, err := client.GetRemotesWithResponse(ctx)
+			if err != nil {
+				return serrors.Wrap("requesting remote gateways", err)
+			}
+			if rep.JSON200 == nil {
+				return problemError(rep.StatusCode(), rep.ApplicationproblemJSON500)
+			}
+			return writeGatewayOutput(cmd.OutOrStdout(), flags.format, rep.JSON200,
+				func(w io.Writer) { humanRemotes(w, rep.JSON200) })
+		},
+	}
+	flags.register(cmd)
+	return cmd
+}
+
+func newGatewaySessions(pather CommandPather) *cobra.Command {
+	var flags gatewayFlags
+	var cmd = &cobra.Command{
+		Use:   "sessions [remote-isd-as] [flags]",
+		Short: "List the sessions of a gateway and the paths they use",
+		Args:  cobra.MaximumNArgs(1),
+		Example: fmt.Sprintf(`  %[1]s sessions
+  %[1]s sessions 1-ff00:0:110 --format json`, pather.CommandPath()),
+		Long: `'sessions' lists the sessions of the gateway to the remote gateways,
+together with the session policies that led to their creation and the paths
+that were considered during the last path selection.
+
+The paths that currently carry the traffic of a session are marked with '-->'.
+The latency and the jitter are one-way estimates that are derived from the
+round-trip times of the recent probes. The drop rate is the fraction of the
+recent probes that were not answered.
+
+If a remote ISD-AS is given, only the sessions to that ISD-AS are listed.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var params api.GetSessionsParams
+			if len(args) == 1 {
+				ia, err := addr.ParseIA(args[0])
+				if err != nil {
+					return serrors.Wrap("invalid remote ISD-AS", err)
+				}
+				params.IsdAs = ptr.To(ia.String())
+			}
+			client, err := flags.client()
+			if err != nil {
+				return serrors.Wrap("creating client", err)
+			}
+			cmd.SilenceUsage = true
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), flags.timeout)
+			defer cancel()
+			rep, err := client.GetSessionsWithResponse(ctx, &params)
+			if err != nil {
+				return serrors.Wrap("requesting sessions", err)
+			}
+			if rep.JSON200 == nil {
+				problem := rep.ApplicationproblemJSON500
+				if rep.ApplicationproblemJSON400 != nil {
+					problem = rep.ApplicationproblemJSON400
+				}
+				return problemError(rep.StatusCode(), problem)
+			}
+			return writeGatewayOutput(cmd.OutOrStdout(), flags.format, rep.JSON200,
+				func(w io.Writer) { humanSessions(w, rep.JSON200) })
+		},
+	}
+	flags.register(cmd)
+	return cmd
+}
+
+func newGatewayPrefixes(pather CommandPather) *cobra.Command {
+	var flags gatewayFlags
+	var cmd = &cobra.Command{
+		Use:     "prefixes [flags]",
+		Short:   "List the IP prefixes advertised and learned by a gateway",
+		Args:    cobra.NoArgs,
+		Example: fmt.Sprintf(`  %[1]s prefixes --format yaml`, pather.CommandPath()),
+		Long: `'prefixes' lists the IP prefixes that the gateway advertises to the remote
+gateways according to its routing policy, and the routes to the IP prefixes
+that it learned from the remote gateways.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := flags.client()
+			if err != nil {
+				return serrors.Wrap("creating client", err)
+			}
+			cmd.SilenceUsage = true
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), flags.timeout)
+			defer cancel()
+			rep, err := client.GetPrefixesWithResponse(ctx)
+			if err != nil {
+				return serrors.Wrap("requesting prefixes", err)
+			}
+			if rep.JSON200 == nil {
+				return problemError(rep.StatusCode(), rep.ApplicationproblemJSON500)
+			}
+			return writeGatewayOutput(cmd.OutOrStdout(), flags.format, rep.JSON200,
+				func(w io.Writer) { humanPrefixes(w, rep.JSON200) })
+		},
+	}
+	flags.register(cmd)
+	return cmd
+}
+
+func problemError(status int, problem *api.Problem) error {
+	if problem == nil {
+		return serrors.New("unexpected response", "status", status)
+	}
+	if problem.Detail != nil {
+		return serrors.New(problem.Title, "status", status, "detail", *problem.Detail)
+	}
+	return serrors.New(problem.Title, "status", status)
+}
+
+// writeGatewayOutput writes the response in the requested format. The
+// machine readable formats use the field names of the management API.
+func writeGatewayOutput(w io.Writer, format string, rep any, human func(io.Writer)) error {
+	switch format {
+	case "human":
+		human(w)
+		return nil
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		return enc.Encode(rep)
+	case "yaml":
+		// The API types only carry json tags, round-trip through json to keep
+		// the field names.
+		raw, err := json.Marshal(rep)
+		if err != nil {
+			return err
+		}
+		var generic any
+		if err := json.Unmarshal(raw, &generic); err != nil {
+			return err
+		}
+		return yaml.NewEncoder(w).Encode(generic)
+	default:
+		return serrors.New("output format not supported", "format", format)
+	}
+}
+
+func humanRemotes(w io.Writer, rep *api.RemotesResponse) {
+	if rep.Remotes == nil || len(*rep.Remotes) == 0 {
+		fmt.Fprintln(w, "No remote AS monitored")
+		return
+	}
+	for _, remote := range *rep.Remotes {
+		fmt.Fprintf(w, "Remote gateways in %s:\n", remote.IsdAs)
+		if len(remote.Gateways) == 0 {
+			fmt.Fprintln(w, "  none discovered")
+		}
+		for _, gw := range remote.Gateways {
+			fmt.Fprintf(w, "  %s Probe: %s Data: %s Interfaces: %v\n",
+				gw.ControlAddress, gw.ProbeAddress, gw.DataAddress, gw.Interfaces)
+			updated := "never"
+			if gw.LastUpdate != nil {
+				updated = gw.LastUpdate.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "    Prefixes: %s (updated: %s)\n",
+				joinOrNone(gw.Prefixes), updated)
+		}
+	}
+}
+
+func humanSessions(w io.Writer, rep *api.SessionsResponse) {
+	if rep.Sessions == nil || len(*rep.Sessions) == 0 {
+		fmt.Fprintln(w, "No session")
+		return
+	}
+	for i, s := range *rep.Sessions {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		health := "unhealthy"
+		if s.Healthy {
+			health = "healthy"
+		}
+		fmt.Fprintf(w, "Session %d to %s via %s: %s\n",
+			s.SessionId, s.IsdAs, s.RemoteGateway.ControlAddress, health)
+		fmt.Fprintf(w, "  Policy: %d Traffic: %s Path count: %d Cipher suite: %s\n",
+			s.Policy.PolicyId, s.Policy.TrafficMatcher, s.Policy.PathCount,
+			s.Policy.CipherSuite)
+		fmt.Fprintf(w, "  Prefixes: %s\n", joinOrNone(s.Policy.Prefixes))
+		if len(s.Paths) == 0 {
+			fmt.Fprintln(w, "  Paths: none")
+			continue
+		}
+		fmt.Fprintln(w, "  Paths:")
+		idxWidth := len(fmt.Sprint(len(s.Paths) - 1))
+		for j, p := range s.Paths {
+			marker := "   "
+			if p.InUse {
+				marker = "-->"
+			}
+			status := "alive"
+			switch {
+			case p.Rejected != nil:
+				status = *p.Rejected
+			case !p.Alive:
+				status = "dead"
+			}
+			if p.Revoked {
+				status += ", revoked"
+			}
+			fmt.Fprintf(w, "  %s [%*d] %s Latency: %s Jitter: %s Drop rate: %.1f%% Status: %s\n",
+				marker, idxWidth, j, p.Path, p.Latency, p.Jitter, p.DropRate*100, status)
+		}
+	}
+}
+
+func humanPrefixes(w io.Writer, rep *api.PrefixesResponse) {
+	fmt.Fprintln(w, "Advertised prefixes:")
+	if len(rep.Advertised) == 0 {
+		fmt.Fprintln(w, "  none")
+	}
+	for _, prefix := range rep.Advertised {
+		fmt.Fprintf(w, "  %s\n", prefix)
+	}
+	fmt.Fprintln(w, "Learned prefixes:")
+	if len(rep.Learned) == 0 {
+		fmt.Fprintln(w, "  none")
+	}
+	for _, learned := range rep.Learned {
+		if learned.NextHop != nil {
+			fmt.Fprintf(w, "  %s from %s via %s\n",
+				learned.Prefix, learned.IsdAs, *learned.NextHop)
+			continue
+		}
+		fmt.Fprintf(w, "  %s from %s\n", learned.Prefix, learned.IsdAs)
+	}
+}
+
+func joinOrNone(s []string) string {
+	if len(s) == 0 {
+		return "none"
+	}
+	return strings.Join(s, ", ")
+}
@@ -55,6 +55,8 @@ func main() {
 		newShowpaths(cmd),
 		newTraceroute(cmd),
 		newAddress(cmd),
+		newBwtest(cmd),
+		newGateway(cmd),
 		newGendocs(cmd),
 	)
 	// This Templatefunc allows use some escape characters for the rst
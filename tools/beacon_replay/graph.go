@@ -0,0 +1,113 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"sort"
+
+	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/xtest/graph"
+)
+
+// graphDescriptions are the topologies that can be used as input.
+var graphDescriptions = map[string]*graph.Description{
+	"default": graph.DefaultGraphDescription,
+	"big":     graph.BigGraphDescription,
+}
+
+// graphLink is the local end of a non-peering link.
+type graphLink struct {
+	ifID       uint16
+	remote     addr.IA
+	remoteIfID uint16
+}
+
+// graphBeacons returns the beacons that the local AS receives in the topology.
+// A beacon is created for every loop-free path from one of the origins to the
+// local AS with at most maxHops links. If no origins are given, all other ASes
+// originate beacons. Peering links are not traversed.
+func graphBeacons(
+	desc *graph.Description,
+	local addr.IA,
+	origins []addr.IA,
+	maxHops int,
+) ([]beacon.Beacon, error) {
+	nodes := make(map[addr.IA]struct{}, len(desc.Nodes))
+	for _, node := range desc.Nodes {
+		nodes[graph.MustParseIA(node)] = struct{}{}
+	}
+	if _, ok := nodes[local]; !ok {
+		return nil, serrors.New("local AS not in topology", "isd_as", local)
+	}
+	if len(origins) == 0 {
+		for ia := range nodes {
+			if ia != local {
+				origins = append(origins, ia)
+			}
+		}
+		sort.Slice(origins, func(i, j int) bool { return origins[i] < origins[j] })
+	}
+	for _, origin := range origins {
+		if _, ok := nodes[origin]; !ok {
+			return nil, serrors.New("origin AS not in topology", "isd_as", origin)
+		}
+	}
+
+	links := make(map[addr.IA][]graphLink)
+	for _, e := range desc.Edges {
+		if e.Peer {
+			continue
+		}
+		x, y := graph.MustParseIA(e.Xia), graph.MustParseIA(e.Yia)
+		links[x] = append(links[x], graphLink{ifID: e.XifID, remote: y, remoteIfID: e.YifID})
+		links[y] = append(links[y], graphLink{ifID: e.YifID, remote: x, remoteIfID: e.XifID})
+	}
+
+	// The graph does not use the controller to create beacons.
+	g := graph.NewFromDescription(nil, desc)
+	var beacons []beacon.Beacon
+	var walk func(ia addr.IA, ifIDs []uint16, visited map[addr.IA]bool)
+	walk = func(ia addr.IA, ifIDs []uint16, visited map[addr.IA]bool) {
+		if len(ifIDs) == maxHops {
+			return
+		}
+		for _, l := range links[ia] {
+			if visited[l.remote] {
+				continue
+			}
+			path := append(append([]uint16(nil), ifIDs...), l.ifID)
+			if l.remote == local {
+				// The last AS entry is the one of the local AS, which is not
+				// part of the received beacon.
+				ps := g.Beacon(path)
+				ps.ASEntries = ps.ASEntries[:len(ps.ASEntries)-1]
+				beacons = append(beacons, beacon.Beacon{Segment: ps, InIfID: l.remoteIfID})
+				continue
+			}
+			visited[l.remote] = true
+			walk(l.remote, path, visited)
+			visited[l.remote] = false
+		}
+	}
+	for _, origin := range origins {
+		if origin == local {
+			continue
+		}
+		walk(origin, nil, map[addr.IA]bool{origin: true})
+	}
+	return beacons, nil
+}
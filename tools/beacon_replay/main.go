@@ -0,0 +1,256 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// tool to replay beacons against a candidate beaconing policy and compare
+// the outcome with the current policy.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	cs "github.com/scionproto/scion/control"
+	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/control/config"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/private/env"
+	storagebeacon "github.com/scionproto/scion/private/storage/beacon"
+	"github.com/scionproto/scion/private/storage/beacon/sqlite"
+)
+
+func main() {
+	if err := realMain(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error while executing: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func realMain() error {
+	dbFile := flag.String("db", "", "Sqlite beacon DB snapshot")
+	graphName := flag.String("graph", "",
+		"Topology to generate the beacons from instead of a DB snapshot (default|big)")
+	localIA := flag.String("ia", "", "ISD-AS of the local AS (required)")
+	core := flag.Bool("core", false, "The local AS is a core AS")
+	origins := flag.String("origins", "",
+		"Comma-separated ISD-ASes that originate beacons in the topology (default all)")
+	maxHops := flag.Int("max-hops", 4, "Maximum number of links of the beacons in the topology")
+	current := flag.String("current", "",
+		"Current policies as comma-separated type=file pairs (default policies if empty)")
+	candidate := flag.String("candidate", "",
+		"Candidate policies as comma-separated type=file pairs (required)")
+	version := flag.Bool("version", false, "Output version information and exit.")
+	flag.Parse()
+
+	if *version {
+		fmt.Print(env.VersionInfo())
+		os.Exit(0)
+	}
+	if *localIA == "" {
+		return serrors.New("the -ia flag is required")
+	}
+	ia, err := addr.ParseIA(*localIA)
+	if err != nil {
+		return serrors.Wrap("parsing local ISD-AS", err)
+	}
+	if *candidate == "" {
+		return serrors.New("the -candidate flag is required")
+	}
+	currentPolicies, err := loadPolicies(*core, *current)
+	if err != nil {
+		return serrors.Wrap("loading current policies", err)
+	}
+	candidatePolicies, err := loadPolicies(*core, *candidate)
+	if err != nil {
+		return serrors.Wrap("loading candidate policies", err)
+	}
+
+	ctx := context.Background()
+	var beacons []beacon.Beacon
+	switch {
+	case *dbFile != "" && *graphName != "":
+		return serrors.New("the -db and -graph flags are mutually exclusive")
+	case *dbFile != "":
+		if beacons, err = dbBeacons(ctx, *dbFile, ia); err != nil {
+			return err
+		}
+	case *graphName != "":
+		desc, ok := graphDescriptions[*graphName]
+		if !ok {
+			return serrors.New("unknown topology", "graph", *graphName)
+		}
+		originIAs, err := parseIAs(*origins)
+		if err != nil {
+			return err
+		}
+		if beacons, err = graphBeacons(desc, ia, originIAs, *maxHops); err != nil {
+			return err
+		}
+	default:
+		return serrors.New("one of the -db and -graph flags is required")
+	}
+
+	currentResult, err := replay(ctx, ia, currentPolicies, beacons)
+	if err != nil {
+		return serrors.Wrap("replaying current policies", err)
+	}
+	candidateResult, err := replay(ctx, ia, candidatePolicies, beacons)
+	if err != nil {
+		return serrors.Wrap("replaying candidate policies", err)
+	}
+	fmt.Printf("Replayed %d beacons\n", len(beacons))
+	printResult(os.Stdout, "current", currentResult)
+	printResult(os.Stdout, "candidate", candidateResult)
+	printDiffs(os.Stdout, compare(currentResult, candidateResult))
+	return nil
+}
+
+// loadPolicies loads the policies from a comma-separated list of type=file
+// pairs. The types are the keys of the beaconing policies in the control
+// service configuration. Policies that are not listed use the default policy.
+func loadPolicies(core bool, spec string) (policySet, error) {
+	var files config.Policies
+	if spec != "" {
+		for _, pair := range strings.Split(spec, ",") {
+			key, file, ok := strings.Cut(pair, "=")
+			if !ok {
+				return policySet{}, serrors.New("invalid policy, expected type=file",
+					"policy", pair)
+			}
+			switch key {
+			case "propagation":
+				files.Propagation = file
+			case "core_registration":
+				files.CoreRegistration = file
+			case "up_registration":
+				files.UpRegistration = file
+			case "down_registration":
+				files.DownRegistration = file
+			default:
+				return policySet{}, serrors.New("unknown policy type", "type", key)
+			}
+		}
+	}
+	p := policySet{core: core}
+	var err error
+	if core {
+		p.corePolicies, err = cs.LoadCorePolicies(files)
+	} else {
+		p.policies, err = cs.LoadNonCorePolicies(files)
+	}
+	return p, err
+}
+
+// dbBeacons returns all beacons in the DB snapshot. The snapshot is left untouched: opening a
+// beacon DB sets it up and switches it to WAL mode, so the beacons are read from a copy.
+func dbBeacons(ctx context.Context, file string, ia addr.IA) ([]beacon.Beacon, error) {
+	if _, err := os.Stat(file); err != nil {
+		return nil, serrors.Wrap("opening beacon DB", err)
+	}
+	dir, err := os.MkdirTemp("", "beacon_replay")
+	if err != nil {
+		return nil, serrors.Wrap("creating temporary directory", err)
+	}
+	defer os.RemoveAll(dir)
+	snapshot := filepath.Join(dir, "beacon.db")
+	// The WAL may hold beacons that were not checkpointed yet.
+	for _, suffix := range []string{"", "-wal"} {
+		if err := copyFile(file+suffix, snapshot+suffix); err != nil {
+			if suffix != "" && errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, serrors.Wrap("copying beacon DB", err, "file", file+suffix)
+		}
+	}
+	db, err := sqlite.New(snapshot, ia, nil)
+	if err != nil {
+		return nil, serrors.Wrap("opening beacon DB", err, "file", file)
+	}
+	defer db.Close()
+	stored, err := db.GetBeacons(ctx, &storagebeacon.QueryParams{})
+	if err != nil {
+		return nil, serrors.Wrap("reading beacons", err)
+	}
+	beacons := make([]beacon.Beacon, 0, len(stored))
+	for _, b := range stored {
+		beacons = append(beacons, b.Beacon)
+	}
+	return beacons, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func parseIAs(s string) ([]addr.IA, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var ias []addr.IA
+	for _, raw := range strings.Split(s, ",") {
+		ia, err := addr.ParseIA(raw)
+		if err != nil {
+			return nil, serrors.Wrap("parsing ISD-AS", err, "isd_as", raw)
+		}
+		ias = append(ias, ia)
+	}
+	return ias, nil
+}
+
+func printResult(w io.Writer, name string, r result) {
+	fmt.Fprintf(w, "\n== %s policies ==\n", name)
+	for _, c := range r.allCategories() {
+		fmt.Fprintf(w, "%s (%d):\n", c.name, len(c.beacons))
+		for _, b := range c.beacons {
+			fmt.Fprintf(w, "  %s\n", describe(b))
+		}
+	}
+}
+
+func printDiffs(w io.Writer, diffs []diff) {
+	fmt.Fprintf(w, "\n== diff current -> candidate ==\n")
+	if len(diffs) == 0 {
+		fmt.Fprintln(w, "no changes")
+		return
+	}
+	for _, d := range diffs {
+		fmt.Fprintf(w, "%s (+%d -%d):\n", d.Category, len(d.Added), len(d.Removed))
+		for _, b := range d.Removed {
+			fmt.Fprintf(w, "- %s\n", describe(b))
+		}
+		for _, b := range d.Added {
+			fmt.Fprintf(w, "+ %s\n", describe(b))
+		}
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	seg "github.com/scionproto/scion/pkg/segment"
+	"github.com/scionproto/scion/private/storage/beacon/sqlite"
+	"github.com/scionproto/scion/private/storage/db"
+)
+
+// store is the part of beacon.Store and beacon.CoreStore that is used for the replay.
+type store interface {
+	InsertBeacon(ctx context.Context, b beacon.Beacon) (beacon.InsertStats, error)
+	BeaconsToPropagate(ctx context.Context) ([]beacon.Beacon, error)
+	SegmentsToRegister(ctx context.Context, segType seg.Type) (beacon.GroupedBeacons, error)
+}
+
+// policySet holds the beaconing policies of either a core or a non-core AS.
+type policySet struct {
+	core         bool
+	policies     beacon.Policies
+	corePolicies beacon.CorePolicies
+}
+
+// segTypes returns the segment types that are registered with the policies.
+func (p policySet) segTypes() []seg.Type {
+	if p.core {
+		return []seg.Type{seg.TypeCore}
+	}
+	return []seg.Type{seg.TypeUp, seg.TypeDown}
+}
+
+// dbCounter makes the names of the in-memory beacon DBs unique.
+var dbCounter atomic.Uint64
+
+func (p policySet) newStore(ia addr.IA) (store, func() error, error) {
+	name := fmt.Sprintf("beacon_replay_%d", dbCounter.Add(1))
+	bdb, err := sqlite.New(name, ia, &db.SqliteConfig{InMemory: true})
+	if err != nil {
+		return nil, nil, serrors.Wrap("creating beacon DB", err)
+	}
+	var s store
+	if p.core {
+		s, err = beacon.NewCoreBeaconStore(p.corePolicies, bdb)
+	} else {
+		s, err = beacon.NewBeaconStore(p.policies, bdb)
+	}
+	if err != nil {
+		bdb.Close()
+		return nil, nil, serrors.Wrap("creating beacon store", err)
+	}
+	return s, bdb.Close, nil
+}
+
+// result is the outcome of replaying a set of beacons against the policies.
+type result struct {
+	// Propagated are the beacons that are selected for propagation.
+	Propagated []beacon.Beacon
+	// Registered are the beacons that are selected for registration, grouped
+	// by segment type and registration policy.
+	Registered map[seg.Type]beacon.GroupedBeacons
+	// Filtered are the beacons that are rejected by the filters of all
+	// policies, and thus never stored.
+	Filtered []beacon.Beacon
+	// Dropped are the beacons that are stored but neither selected for
+	// propagation nor for registration.
+	Dropped []beacon.Beacon
+}
+
+// replay inserts the beacons into a fresh beacon store that is configured with
+// the policies, and returns the beacons that the store selects.
+func replay(
+	ctx context.Context,
+	ia addr.IA,
+	p policySet,
+	beacons []beacon.Beacon,
+) (result, error) {
+	s, closeFn, err := p.newStore(ia)
+	if err != nil {
+		return result{}, err
+	}
+	defer closeFn()
+
+	var r result
+	for _, b := range beacons {
+		stats, err := s.InsertBeacon(ctx, b)
+		if err != nil {
+			return result{}, serrors.Wrap("inserting beacon", err, "beacon", b)
+		}
+		if stats.Filtered > 0 {
+			r.Filtered = append(r.Filtered, b)
+		}
+	}
+	if r.Propagated, err = s.BeaconsToPropagate(ctx); err != nil {
+		return result{}, serrors.Wrap("selecting beacons to propagate", err)
+	}
+	r.Registered = make(map[seg.Type]beacon.GroupedBeacons)
+	for _, segType := range p.segTypes() {
+		if r.Registered[segType], err = s.SegmentsToRegister(ctx, segType); err != nil {
+			return result{}, serrors.Wrap("selecting segments to register", err,
+				"seg_type", segType)
+		}
+	}
+
+	selected := make(map[string]struct{})
+	for _, c := range r.categories() {
+		for _, b := range c.beacons {
+			selected[beaconKey(b)] = struct{}{}
+		}
+	}
+	for _, b := range beacons {
+		if _, ok := selected[beaconKey(b)]; !ok {
+			r.Dropped = append(r.Dropped, b)
+		}
+	}
+	r.Dropped = removeAll(r.Dropped, r.Filtered)
+	return r, nil
+}
+
+// category is a named set of beacons in the result.
+type category struct {
+	name    string
+	beacons []beacon.Beacon
+}
+
+// categories returns the selections of the result in a stable order.
+func (r result) categories() []category {
+	cats := []category{{name: "propagated", beacons: r.Propagated}}
+	segTypes := make([]seg.Type, 0, len(r.Registered))
+	for segType := range r.Registered {
+		segTypes = append(segTypes, segType)
+	}
+	sort.Slice(segTypes, func(i, j int) bool { return segTypes[i] < segTypes[j] })
+	for _, segType := range segTypes {
+		groups := r.Registered[segType]
+		names := make([]string, 0, len(groups))
+		for name := range groups {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			cats = append(cats, category{
+				name:    fmt.Sprintf("registered %s/%s", segType, name),
+				beacons: groups[name],
+			})
+		}
+	}
+	return cats
+}
+
+// allCategories returns the selections and the beacons that are not selected.
+func (r result) allCategories() []category {
+	return append(r.categories(),
+		category{name: "filtered", beacons: r.Filtered},
+		category{name: "dropped", beacons: r.Dropped},
+	)
+}
+
+// diff is the difference of one category between two results.
+type diff struct {
+	Category string
+	// Added are the beacons that are only in the candidate result.
+	Added []beacon.Beacon
+	// Removed are the beacons that are only in the current result.
+	Removed []beacon.Beacon
+}
+
+// compare returns the differences between the current and the candidate
+// result. Categories without differences are omitted.
+func compare(current, candidate result) []diff {
+	currentCats := make(map[string][]beacon.Beacon)
+	var names []string
+	for _, c := range current.allCategories() {
+		currentCats[c.name] = c.beacons
+		names = append(names, c.name)
+	}
+	candidateCats := make(map[string][]beacon.Beacon)
+	for _, c := range candidate.allCategories() {
+		if _, ok := currentCats[c.name]; !ok {
+			names = append(names, c.name)
+		}
+		candidateCats[c.name] = c.beacons
+	}
+	var diffs []diff
+	for _, name := range names {
+		d := diff{
+			Category: name,
+			Added:    removeAll(candidateCats[name], currentCats[name]),
+			Removed:  removeAll(currentCats[name], candidateCats[name]),
+		}
+		if len(d.Added) > 0 || len(d.Removed) > 0 {
+			diffs = append(diffs, d)
+		}
+	}
+	return diffs
+}
+
+// removeAll returns the beacons in bs that are not in other.
+func removeAll(bs, other []beacon.Beacon) []beacon.Beacon {
+	keys := make(map[string]struct{}, len(other))
+	for _, b := range other {
+		keys[beaconKey(b)] = struct{}{}
+	}
+	var res []beacon.Beacon
+	for _, b := range bs {
+		if _, ok := keys[beaconKey(b)]; !ok {
+			res = append(res, b)
+		}
+	}
+	return res
+}
+
+func beaconKey(b beacon.Beacon) string {
+	return fmt.Sprintf("%x %d", b.Segment.ID(), b.InIfID)
+}
+
+// describe returns a compact description of the beacon with the hops and the
+// ingress interface in the local AS.
+func describe(b beacon.Beacon) string {
+	hops := make([]string, 0, len(b.Segment.ASEntries))
+	for _, ase := range b.Segment.ASEntries {
+		hop := ase.HopEntry.HopField
+		desc := ase.Local.String()
+		if hop.ConsIngress > 0 {
+			desc = fmt.Sprintf("%d %s", hop.ConsIngress, desc)
+		}
+		if hop.ConsEgress > 0 {
+			desc = fmt.Sprintf("%s %d", desc, hop.ConsEgress)
+		}
+		hops = append(hops, desc)
+	}
+	return fmt.Sprintf("%s %s>%d", b.Segment.GetLoggingID(), strings.Join(hops, ">"), b.InIfID)
+}
@@ -0,0 +1,192 @@
+// Copyright 2025 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/xtest/graph"
+	seg "github.com/scionproto/scion/pkg/segment"
+	"github.com/scionproto/scion/private/storage/beacon/sqlite"
+)
+
+var (
+	ia110 = addr.MustParseIA("1-ff00:0:110")
+	ia111 = addr.MustParseIA("1-ff00:0:111")
+	ia120 = addr.MustParseIA("1-ff00:0:120")
+	ia130 = addr.MustParseIA("1-ff00:0:130")
+)
+
+func TestGraphBeacons(t *testing.T) {
+	origins := []addr.IA{ia110, ia120, ia130}
+	beacons, err := graphBeacons(graph.DefaultGraphDescription, ia111, origins, 2)
+	require.NoError(t, err)
+	require.NotEmpty(t, beacons)
+	for _, b := range beacons {
+		assert.Contains(t, origins, b.Segment.FirstIA())
+		assert.LessOrEqual(t, len(b.Segment.ASEntries), 2)
+		assert.NotEqual(t, ia111, b.Segment.LastIA())
+		last := b.Segment.ASEntries[len(b.Segment.ASEntries)-1]
+		remote, remoteIfID := graphRemote(t, last.HopEntry.HopField.ConsEgress)
+		assert.Equal(t, ia111, remote)
+		assert.Equal(t, remoteIfID, b.InIfID)
+	}
+
+	_, err = graphBeacons(graph.DefaultGraphDescription, addr.MustParseIA("1-ff00:0:999"),
+		nil, 2)
+	assert.Error(t, err)
+}
+
+func TestReplay(t *testing.T) {
+	ctx := context.Background()
+	beacons, err := graphBeacons(graph.DefaultGraphDescription, ia111,
+		[]addr.IA{ia110, ia120, ia130}, 4)
+	require.NoError(t, err)
+
+	current, err := loadPolicies(false, "propagation=testdata/propagation.yml")
+	require.NoError(t, err)
+	candidate, err := loadPolicies(false, "propagation=testdata/propagation.yml,"+
+		"up_registration=testdata/up_registration.yml")
+	require.NoError(t, err)
+
+	currentResult, err := replay(ctx, ia111, current, beacons)
+	require.NoError(t, err)
+	assert.Len(t, currentResult.Propagated, 2)
+	for _, b := range currentResult.Propagated {
+		assert.Len(t, b.Segment.ASEntries, 1)
+	}
+	assert.Len(t, currentResult.Registered[seg.TypeUp][beacon.DefaultGroup], len(beacons))
+	assert.Len(t, currentResult.Registered[seg.TypeDown][beacon.DefaultGroup], len(beacons))
+	assert.Empty(t, currentResult.Filtered)
+	assert.Empty(t, currentResult.Dropped)
+
+	candidateResult, err := replay(ctx, ia111, candidate, beacons)
+	require.NoError(t, err)
+	up := candidateResult.Registered[seg.TypeUp]
+	assert.NotContains(t, up, beacon.DefaultGroup)
+	assert.Equal(t, len(beacons), len(up["avoid-130"])+len(up["other"]))
+
+	diffs := compare(currentResult, candidateResult)
+	require.Len(t, diffs, 3)
+	assert.Equal(t, "registered up/default", diffs[0].Category)
+	assert.Empty(t, diffs[0].Added)
+	assert.Len(t, diffs[0].Removed, len(beacons))
+	assert.Equal(t, "registered up/avoid-130", diffs[1].Category)
+	assert.Equal(t, up["avoid-130"], diffs[1].Added)
+	assert.Equal(t, "registered up/other", diffs[2].Category)
+	assert.Equal(t, up["other"], diffs[2].Added)
+
+	assert.Empty(t, compare(currentResult, currentResult))
+}
+
+func TestReplayFiltered(t *testing.T) {
+	ctx := context.Background()
+	beacons, err := graphBeacons(graph.DefaultGraphDescription, ia110,
+		[]addr.IA{ia120, ia130}, 3)
+	require.NoError(t, err)
+
+	p, err := loadPolicies(true, "")
+	require.NoError(t, err)
+	// Beacons with more than one hop are filtered by all policies.
+	p.corePolicies.Prop.Filter.MaxHopsLength = 1
+	p.corePolicies.CoreReg.Filter.MaxHopsLength = 1
+
+	r, err := replay(ctx, ia110, p, beacons)
+	require.NoError(t, err)
+	assert.NotContains(t, r.Registered, seg.TypeUp)
+	for _, b := range r.Filtered {
+		assert.Greater(t, len(b.Segment.ASEntries), 1)
+	}
+	for _, b := range r.Propagated {
+		assert.Len(t, b.Segment.ASEntries, 1)
+	}
+	assert.Len(t, r.Registered[seg.TypeCore][beacon.DefaultGroup], len(r.Propagated))
+	assert.Equal(t, len(beacons), len(r.Filtered)+len(r.Propagated))
+	assert.Empty(t, r.Dropped)
+}
+
+func TestDBBeacons(t *testing.T) {
+	ctx := context.Background()
+	beacons, err := graphBeacons(graph.DefaultGraphDescription, ia111,
+		[]addr.IA{ia120, ia130}, 2)
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "beacon.db")
+	db, err := sqlite.New(file, ia111, nil)
+	require.NoError(t, err)
+	for _, b := range beacons {
+		_, err := db.InsertBeacon(ctx, b, beacon.UsageProp)
+		require.NoError(t, err)
+	}
+	require.NoError(t, db.Close())
+	before, err := os.ReadFile(file)
+	require.NoError(t, err)
+	entries, err := os.ReadDir(filepath.Dir(file))
+	require.NoError(t, err)
+
+	stored, err := dbBeacons(ctx, file, ia111)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, keys(beacons), keys(stored))
+
+	// The snapshot is not modified.
+	after, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+	entriesAfter, err := os.ReadDir(filepath.Dir(file))
+	require.NoError(t, err)
+	assert.Len(t, entriesAfter, len(entries))
+
+	_, err = dbBeacons(ctx, filepath.Join(t.TempDir(), "missing.db"), ia111)
+	assert.Error(t, err)
+}
+
+func TestLoadPolicies(t *testing.T) {
+	_, err := loadPolicies(false, "propagation")
+	assert.Error(t, err)
+	_, err = loadPolicies(false, "hole_punching=testdata/propagation.yml")
+	assert.Error(t, err)
+	_, err = loadPolicies(true, "core_registration=testdata/missing.yml")
+	assert.Error(t, err)
+}
+
+func keys(beacons []beacon.Beacon) []string {
+	var res []string
+	for _, b := range beacons {
+		res = append(res, beaconKey(b))
+	}
+	return res
+}
+
+// graphRemote returns the AS and the interface on the other side of the link of ifID.
+func graphRemote(t *testing.T, ifID uint16) (addr.IA, uint16) {
+	for _, e := range graph.DefaultGraphDescription.Edges {
+		if e.XifID == ifID {
+			return graph.MustParseIA(e.Yia), e.YifID
+		}
+		if e.YifID == ifID {
+			return graph.MustParseIA(e.Xia), e.XifID
+		}
+	}
+	t.Fatalf("unknown interface %d", ifID)
+	return 0, 0
+}
@@ -1204,3 +1204,7 @@ scion_proto:register_heuristic("udp", scion_proto_filter)
 -- for i = 50000, 50050, 1 do
 --     table_udp:add(i, scion_proto)
 -- end
+
+-- Raw SCION packets, as captured by the router's packet capture API, which uses the USER0 link
+-- type.
+DissectorTable.get("wtap_encap"):add(wtap.USER0, scion_proto)